@@ -1142,6 +1142,24 @@ func UpdateAsDroppedIfEmptyWhenFlushing(segmentID int64) UpdateOperator {
 	}
 }
 
+// UpdateAsSealedIfGrowing updates segment state to Sealed if segment is in Growing state.
+// It's used to apply the sealed segment pushed by the streaming node, the segment already moved forward by the flusher is kept.
+func UpdateAsSealedIfGrowing(segmentID int64) UpdateOperator {
+	return func(modPack *updateSegmentPack) bool {
+		segment := modPack.Get(segmentID)
+		if segment == nil {
+			log.Ctx(context.TODO()).Warn("meta update: update as sealed if growing failed - segment not found",
+				zap.Int64("segmentID", segmentID))
+			return false
+		}
+		if segment.GetState() != commonpb.SegmentState_Growing {
+			return false
+		}
+		updateSegStateAndPrepareMetrics(segment, commonpb.SegmentState_Sealed, modPack.metricMutation)
+		return true
+	}
+}
+
 // updateSegmentsInfo update segment infos
 // will exec all operators, and update all changed segments
 func (m *meta) UpdateSegmentsInfo(ctx context.Context, operators ...UpdateOperator) error {
@@ -955,6 +955,18 @@ func TestUpdateSegmentsInfo(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("update growing segment into sealed", func(t *testing.T) {
+		meta, err := newMemoryMeta(t)
+		assert.NoError(t, err)
+		meta.AddSegment(context.Background(), &SegmentInfo{SegmentInfo: &datapb.SegmentInfo{ID: 1, State: commonpb.SegmentState_Growing}})
+		meta.AddSegment(context.Background(), &SegmentInfo{SegmentInfo: &datapb.SegmentInfo{ID: 2, State: commonpb.SegmentState_Flushed}})
+		err = meta.UpdateSegmentsInfo(context.TODO(), UpdateAsSealedIfGrowing(1), UpdateAsSealedIfGrowing(2), UpdateAsSealedIfGrowing(3))
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.SegmentState_Sealed, meta.GetHealthySegment(context.TODO(), 1).GetState())
+		// the segment already flushed is never moved back.
+		assert.Equal(t, commonpb.SegmentState_Flushed, meta.GetHealthySegment(context.TODO(), 2).GetState())
+	})
+
 	t.Run("update checkpoints and start position of non existed segment", func(t *testing.T) {
 		meta, err := newMemoryMeta(t)
 		assert.NoError(t, err)
@@ -0,0 +1,44 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/sealednotify"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+// registerSealedSegmentHandler registers the handler of the sealed segments pushed by the streaming node,
+// so the growing segment is seen as sealed before the flush message is consumed from the wal.
+func (s *Server) registerSealedSegmentHandler() {
+	s.unregisterSealedSegmentHandler = sealednotify.Register(s.handleSealedSegmentNotification)
+}
+
+// handleSealedSegmentNotification marks the notified growing segment as sealed.
+// The notification is best-effort, the wal is still the source of truth, the flusher moves the segment into flushed.
+func (s *Server) handleSealedSegmentNotification(ctx context.Context, notification *streamingpb.SealedSegmentNotification) {
+	if err := s.meta.UpdateSegmentsInfo(ctx, UpdateAsSealedIfGrowing(notification.GetSegmentId())); err != nil {
+		log.Ctx(ctx).Warn("failed to mark the notified segment as sealed",
+			zap.String("vchannel", notification.GetVchannel()),
+			zap.Int64("segmentID", notification.GetSegmentId()),
+			zap.Error(err))
+	}
+}
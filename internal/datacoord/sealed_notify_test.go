@@ -0,0 +1,54 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/sealednotify"
+	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+func TestSealedSegmentHandler(t *testing.T) {
+	sealednotify.ResetRegistration()
+	defer sealednotify.ResetRegistration()
+
+	meta, err := newMemoryMeta(t)
+	assert.NoError(t, err)
+	meta.AddSegment(context.Background(), &SegmentInfo{SegmentInfo: &datapb.SegmentInfo{ID: 1, State: commonpb.SegmentState_Growing}})
+	s := &Server{meta: meta}
+	s.registerSealedSegmentHandler()
+
+	// the notified growing segment is sealed.
+	assert.True(t, sealednotify.Notify(context.Background(), &streamingpb.SealedSegmentNotification{SegmentId: 1}))
+	assert.Equal(t, commonpb.SegmentState_Sealed, meta.GetHealthySegment(context.Background(), 1).GetState())
+
+	// the unknown segment is ignored.
+	assert.True(t, sealednotify.Notify(context.Background(), &streamingpb.SealedSegmentNotification{SegmentId: 2}))
+	assert.Nil(t, meta.GetHealthySegment(context.Background(), 2))
+
+	// the segment is never touched after the handler is unregistered.
+	meta.AddSegment(context.Background(), &SegmentInfo{SegmentInfo: &datapb.SegmentInfo{ID: 3, State: commonpb.SegmentState_Growing}})
+	s.unregisterSealedSegmentHandler()
+	assert.True(t, sealednotify.Notify(context.Background(), &streamingpb.SealedSegmentNotification{SegmentId: 3}))
+	assert.Equal(t, commonpb.SegmentState_Growing, meta.GetHealthySegment(context.Background(), 3).GetState())
+}
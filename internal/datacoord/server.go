@@ -128,7 +128,9 @@ type Server struct {
 	compactionTriggerManager TriggerManager
 
 	syncSegmentsScheduler *SyncSegmentsScheduler
-	metricsCacheManager   *metricsinfo.MetricsCacheManager
+	// unregisterSealedSegmentHandler unregisters the handler of the sealed segments pushed by the streaming node.
+	unregisterSealedSegmentHandler func()
+	metricsCacheManager            *metricsinfo.MetricsCacheManager
 
 	flushCh         chan UniqueID
 	notifyIndexChan chan UniqueID
@@ -637,6 +639,9 @@ func (s *Server) startServerLoop() {
 	if !(streamingutil.IsStreamingServiceEnabled() || paramtable.Get().DataNodeCfg.SkipBFStatsLoad.GetAsBool()) {
 		s.syncSegmentsScheduler.Start()
 	}
+	if streamingutil.IsStreamingServiceEnabled() {
+		s.registerSealedSegmentHandler()
+	}
 }
 
 func (s *Server) startCollectMetaMetrics(ctx context.Context) {
@@ -944,6 +949,9 @@ func (s *Server) Stop() error {
 		return nil
 	}
 	log.Info("datacoord server shutdown")
+	if s.unregisterSealedSegmentHandler != nil {
+		s.unregisterSealedSegmentHandler()
+	}
 	s.garbageCollector.close()
 	log.Info("datacoord garbage collector stopped")
 
@@ -83,6 +83,7 @@ func (p *ResumableProducer) Produce(ctx context.Context, msg message.MutableMess
 	}
 	defer p.lifetime.Done()
 
+	var retryLaterBackoff *backoff.ExponentialBackOff
 	for {
 		// get producer.
 		producerHandler, err := p.producer.GetProducerAfterAvailable(ctx)
@@ -105,10 +106,42 @@ func (p *ResumableProducer) Produce(ctx context.Context, msg message.MutableMess
 			if sErr.IsUnrecoverable() {
 				return nil, errors.Mark(err, errs.ErrUnrecoverable)
 			}
+			// if the wal is saturated, the server asks the client to retry later,
+			// so the retry should be done with backoff rather than immediately.
+			if sErr.IsRetryLater() {
+				if retryLaterBackoff == nil {
+					retryLaterBackoff = newRetryLaterBackoff()
+				}
+				if err := waitForRetry(ctx, retryLaterBackoff.NextBackOff()); err != nil {
+					return nil, errors.Mark(err, errs.ErrCanceledOrDeadlineExceed)
+				}
+			}
 		}
 	}
 }
 
+// newRetryLaterBackoff creates a new backoff for the append operation that is asked to retry later.
+func newRetryLaterBackoff() *backoff.ExponentialBackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 10 * time.Millisecond
+	b.MaxInterval = time.Second
+	b.MaxElapsedTime = 0
+	b.Reset()
+	return b
+}
+
+// waitForRetry waits for the backoff interval or the context done.
+func waitForRetry(ctx context.Context, interval time.Duration) error {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // resumeLoop is used to resume producer from error.
 func (p *ResumableProducer) resumeLoop() {
 	defer func() {
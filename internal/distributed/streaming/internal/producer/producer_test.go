@@ -3,6 +3,7 @@ package producer
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/assert"
@@ -12,6 +13,7 @@ import (
 	"github.com/milvus-io/milvus/internal/mocks/streamingnode/client/handler/mock_producer"
 	"github.com/milvus-io/milvus/internal/streamingnode/client/handler"
 	"github.com/milvus-io/milvus/internal/streamingnode/client/handler/producer"
+	"github.com/milvus-io/milvus/internal/util/streamingutil/status"
 	"github.com/milvus-io/milvus/pkg/v2/mocks/streaming/util/mock_message"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
@@ -100,3 +102,37 @@ func TestResumableProducer(t *testing.T) {
 	assert.True(t, errors.Is(err, errs.ErrClosed))
 	rp.Close()
 }
+
+func TestResumableProducerRetryLater(t *testing.T) {
+	p := mock_producer.NewMockProducer(t)
+	msgID := mock_message.NewMockMessageID(t)
+	p.EXPECT().Append(mock.Anything, mock.Anything).Return(nil, status.NewRetryLater("wal is saturated")).Times(2)
+	p.EXPECT().Append(mock.Anything, mock.Anything).Return(&types.AppendResult{
+		MessageID: msgID,
+		TimeTick:  100,
+	}, nil).Once()
+	p.EXPECT().Close().Return()
+	p.EXPECT().Available().Return(make(chan struct{}))
+	p.EXPECT().IsAvailable().Return(true)
+
+	rp := NewResumableProducer(func(ctx context.Context, opts *handler.ProducerOptions) (producer.Producer, error) {
+		return p, nil
+	}, &ProducerOptions{
+		PChannel: "test",
+	})
+	defer rp.Close()
+
+	msg := mock_message.NewMockMutableMessage(t)
+	msg.EXPECT().EstimateSize().Return(100).Maybe()
+	id, err := rp.Produce(context.Background(), msg)
+	assert.NotNil(t, id)
+	assert.NoError(t, err)
+
+	// the retry later error should be retried with backoff until the context is done.
+	p.EXPECT().Append(mock.Anything, mock.Anything).Return(nil, status.NewRetryLater("wal is saturated"))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	id, err = rp.Produce(ctx, msg)
+	assert.Nil(t, id)
+	assert.True(t, errors.Is(err, errs.ErrCanceledOrDeadlineExceed))
+}
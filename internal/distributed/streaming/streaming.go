@@ -6,6 +6,7 @@ import (
 
 	"github.com/milvus-io/milvus/internal/streamingcoord/server/broadcaster/registry"
 	kvfactory "github.com/milvus-io/milvus/internal/util/dependency/kv"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/options"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
@@ -111,6 +112,10 @@ type WALAccesser interface {
 	// Read returns a scanner for reading records from the wal.
 	Read(ctx context.Context, opts ReadOption) Scanner
 
+	// NotifySealedSegment pushes the sealed segment to the streaming coord over the assignment discover stream.
+	// It's best-effort, the notification may be dropped, the wal is still the source of truth of the sealed segment.
+	NotifySealedSegment(ctx context.Context, notification *streamingpb.SealedSegmentNotification) error
+
 	// AppendMessages appends messages to the wal.
 	// It it a helper utility function to append messages to the wal.
 	// If the messages is belong to one vchannel, it will be sent as a transaction.
@@ -14,6 +14,7 @@ import (
 	"github.com/milvus-io/milvus/internal/util/streamingutil"
 	"github.com/milvus-io/milvus/internal/util/streamingutil/status"
 	"github.com/milvus-io/milvus/internal/util/streamingutil/util"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
 	"github.com/milvus-io/milvus/pkg/v2/util/conc"
@@ -119,6 +120,16 @@ func (w *walAccesserImpl) Broadcast() Broadcast {
 	return broadcast{w}
 }
 
+// NotifySealedSegment pushes the sealed segment to the streaming coord.
+func (w *walAccesserImpl) NotifySealedSegment(ctx context.Context, notification *streamingpb.SealedSegmentNotification) error {
+	if !w.lifetime.Add(typeutil.LifetimeStateWorking) {
+		return ErrWALAccesserClosed
+	}
+	defer w.lifetime.Done()
+
+	return w.streamingCoordClient.Assignment().NotifySealedSegment(ctx, notification)
+}
+
 func (w *walAccesserImpl) Txn(ctx context.Context, opts TxnOption) (Txn, error) {
 	if !w.lifetime.Add(typeutil.LifetimeStateWorking) {
 		return nil, ErrWALAccesserClosed
@@ -179,6 +179,14 @@ func (ddn *ddNode) Operate(in []Msg) []Msg {
 				continue
 			}
 
+			if imsg.GetNumRows() == 0 {
+				// zero rows insert message is not assigned to any segment, just skip it.
+				log.Debug("filter empty insert message",
+					zap.String("channel", ddn.vChannelName),
+					zap.Uint64("message timestamp", msg.EndTs()))
+				continue
+			}
+
 			if ddn.tryToFilterSegmentInsertMessages(imsg) {
 				log.Debug("filter insert messages",
 					zap.Int64("filter segmentID", imsg.GetSegmentID()),
@@ -49,6 +49,7 @@ type PipelineParams struct {
 	CheckpointUpdater  *ChannelCheckpointUpdater
 	Allocator          allocator.Interface
 	MsgHandler         MsgHandler
+	SyncDeferrer       writebuffer.SyncDeferrer // defers the periodic sync of the segments, nil if never deferred.
 }
 
 // TimeRange is a range of timestamp contains the min-timestamp and max-timestamp
@@ -22,21 +22,26 @@ type writeBufferOption struct {
 	errorHandler         func(error)
 	taskObserverCallback TaskObserverCallback
 	storageVersion       int64
+	syncDeferrer         SyncDeferrer
 }
 
 func defaultWBOption(metacache metacache.MetaCache) *writeBufferOption {
-	return &writeBufferOption{
-		syncPolicies: []SyncPolicy{
-			GetFullBufferPolicy(),
-			GetSyncStaleBufferPolicy(paramtable.Get().DataNodeCfg.SyncPeriod.GetAsDuration(time.Second)),
-			GetSealedSegmentsPolicy(metacache),
-			GetDroppedSegmentPolicy(metacache),
-		},
+	opt := &writeBufferOption{
 		// default error handler, just panicking
 		errorHandler: func(err error) {
 			panic(err)
 		},
 	}
+	opt.syncPolicies = []SyncPolicy{
+		GetFullBufferPolicy(),
+		// the deferrer is set by the option applied after the default one, so it's resolved at selection.
+		GetDeferrableSyncStaleBufferPolicy(paramtable.Get().DataNodeCfg.SyncPeriod.GetAsDuration(time.Second), func(segmentID int64) bool {
+			return opt.syncDeferrer != nil && opt.syncDeferrer(segmentID)
+		}),
+		GetSealedSegmentsPolicy(metacache),
+		GetDroppedSegmentPolicy(metacache),
+	}
+	return opt
 }
 
 func WithIDAllocator(allocator allocator.Interface) WriteBufferOption {
@@ -63,6 +68,13 @@ func WithSyncPolicy(policy SyncPolicy) WriteBufferOption {
 	}
 }
 
+// WithSyncDeferrer sets the deferrer of the periodic sync of the segments.
+func WithSyncDeferrer(deferrer SyncDeferrer) WriteBufferOption {
+	return func(opt *writeBufferOption) {
+		opt.syncDeferrer = deferrer
+	}
+}
+
 func WithErrorHandler(handler func(err error)) WriteBufferOption {
 	return func(opt *writeBufferOption) {
 		opt.errorHandler = handler
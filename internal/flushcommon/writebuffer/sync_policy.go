@@ -56,10 +56,23 @@ func GetFullBufferPolicy() SyncPolicy {
 		}, "buffer full")
 }
 
+// SyncDeferrer reports whether the periodic sync of the segment should be deferred,
+// e.g. the segment is about to be sealed, so its buffer can be synced by a final large sync at seal.
+type SyncDeferrer func(segmentID int64) bool
+
 func GetSyncStaleBufferPolicy(staleDuration time.Duration) SyncPolicy {
+	return GetDeferrableSyncStaleBufferPolicy(staleDuration, nil)
+}
+
+// GetDeferrableSyncStaleBufferPolicy returns the stale buffer policy that skips the segments deferred by the deferrer.
+// Only the periodic sync is deferred, the full, sealed and flushed buffers are always synced by other policies.
+func GetDeferrableSyncStaleBufferPolicy(staleDuration time.Duration, deferrer SyncDeferrer) SyncPolicy {
 	return wrapSelectSegmentFuncPolicy(func(buffers []*segmentBuffer, ts typeutil.Timestamp) []int64 {
 		current := tsoutil.PhysicalTime(ts)
 		return lo.FilterMap(buffers, func(buf *segmentBuffer, _ int) (int64, bool) {
+			if deferrer != nil && deferrer(buf.segmentID) {
+				return buf.segmentID, false
+			}
 			minTs := buf.MinTimestamp()
 			start := tsoutil.PhysicalTime(minTs)
 			jitter := time.Duration(rand.Float64() * 0.1 * float64(staleDuration))
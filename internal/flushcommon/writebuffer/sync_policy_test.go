@@ -73,6 +73,31 @@ func (s *SyncPolicySuite) TestSyncStalePolicy() {
 	s.Equal(0, len(ids), "")
 }
 
+func (s *SyncPolicySuite) TestSyncStalePolicyDeferred() {
+	deferred := true
+	policy := GetDeferrableSyncStaleBufferPolicy(2*time.Minute, func(segmentID int64) bool {
+		return segmentID == 100 && deferred
+	})
+
+	buffer, err := newSegmentBuffer(100, s.collSchema)
+	s.Require().NoError(err)
+	buffer.insertBuffer.startPos = &msgpb.MsgPosition{
+		Timestamp: tsoutil.ComposeTSByTime(time.Now().Add(-time.Minute*3), 0),
+	}
+	buffer2, err := newSegmentBuffer(200, s.collSchema)
+	s.Require().NoError(err)
+	buffer2.insertBuffer.startPos = &msgpb.MsgPosition{
+		Timestamp: tsoutil.ComposeTSByTime(time.Now().Add(-time.Minute*3), 0),
+	}
+
+	ids := policy.SelectSegments([]*segmentBuffer{buffer, buffer2}, tsoutil.ComposeTSByTime(time.Now(), 0))
+	s.ElementsMatch([]int64{200}, ids, "deferred stale buffer shall not be synced")
+
+	deferred = false
+	ids = policy.SelectSegments([]*segmentBuffer{buffer, buffer2}, tsoutil.ComposeTSByTime(time.Now(), 0))
+	s.ElementsMatch([]int64{100, 200}, ids)
+}
+
 func (s *SyncPolicySuite) TestSyncDroppedPolicy() {
 	metacache := metacache.NewMockMetaCache(s.T())
 	policy := GetDroppedSegmentPolicy(metacache)
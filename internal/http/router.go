@@ -64,6 +64,28 @@ const (
 	RouteCheckQueryNodeDistribution = "/management/querycoord/distribution/check"
 )
 
+// streaming node debug restful api path
+const (
+	// RouteStreamingNodeSegmentAssignment is the path to get the segment assignment snapshot of a collection on streaming node.
+	RouteStreamingNodeSegmentAssignment = "/debug/streamingnode/segment/assignment"
+	// RouteStreamingNodeExplainAssignment is the path to explain where a hypothetical insert would be assigned on streaming node.
+	RouteStreamingNodeExplainAssignment = "/debug/streamingnode/segment/explain_assignment"
+	// RouteStreamingNodeResetCircuitBreaker forces the segment assignment circuit breaker of a collection to be closed.
+	RouteStreamingNodeResetCircuitBreaker = "/debug/streamingnode/segment/circuit_breaker/reset"
+	// RouteStreamingNodeFlushOlderThan seals the segments of a collection which are older than the given timetick without fencing.
+	RouteStreamingNodeFlushOlderThan = "/debug/streamingnode/segment/flush_older_than"
+	// RouteStreamingNodeRecoverySummary is the path to get the summary of the last segment assignment recovery of a pchannel.
+	RouteStreamingNodeRecoverySummary = "/debug/streamingnode/segment/recovery_summary"
+	// RouteStreamingNodeSealHistory is the path to get the recent seal events of a pchannel on streaming node.
+	RouteStreamingNodeSealHistory = "/debug/streamingnode/segment/seal_history"
+	// RouteStreamingNodeSourceStats is the path to get the top source nodes by segment assignments on streaming node.
+	RouteStreamingNodeSourceStats = "/debug/streamingnode/segment/source_stats"
+	// RouteStreamingNodeResetSourceStats clears the segment assignments aggregated by source node on streaming node.
+	RouteStreamingNodeResetSourceStats = "/debug/streamingnode/segment/source_stats/reset"
+	// RouteStreamingNodeRedoState is the path to get the messages currently in redo of a pchannel on streaming node.
+	RouteStreamingNodeRedoState = "/debug/streamingnode/wal/redo"
+)
+
 // for WebUI restful api root path
 const (
 	// ClusterInfoPath is the path to get cluster information.
@@ -236,6 +236,7 @@ type StreamingNodeCataLog interface {
 	ListSegmentAssignment(ctx context.Context, pChannelName string) ([]*streamingpb.SegmentAssignmentMeta, error)
 
 	// SaveSegmentAssignments save the segment assignments for the wal.
+	// Every assignment carries the term of the writer, the implementation can reject the stale writer if the backend supports compare-and-swap.
 	SaveSegmentAssignments(ctx context.Context, pChannelName string, infos map[int64]*streamingpb.SegmentAssignmentMeta) error
 
 	// GetConsumeCheckpoint gets the consuming checkpoint of the wal.
@@ -10,6 +10,8 @@ import (
 
 	streaming "github.com/milvus-io/milvus/internal/distributed/streaming"
 
+	streamingpb "github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+
 	types "github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
 )
 
@@ -253,6 +255,53 @@ func (_c *MockWALAccesser_GetLatestMVCCTimestampIfLocal_Call) RunAndReturn(run f
 	return _c
 }
 
+// NotifySealedSegment provides a mock function with given fields: ctx, notification
+func (_m *MockWALAccesser) NotifySealedSegment(ctx context.Context, notification *streamingpb.SealedSegmentNotification) error {
+	ret := _m.Called(ctx, notification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifySealedSegment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingpb.SealedSegmentNotification) error); ok {
+		r0 = rf(ctx, notification)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWALAccesser_NotifySealedSegment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotifySealedSegment'
+type MockWALAccesser_NotifySealedSegment_Call struct {
+	*mock.Call
+}
+
+// NotifySealedSegment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notification *streamingpb.SealedSegmentNotification
+func (_e *MockWALAccesser_Expecter) NotifySealedSegment(ctx interface{}, notification interface{}) *MockWALAccesser_NotifySealedSegment_Call {
+	return &MockWALAccesser_NotifySealedSegment_Call{Call: _e.mock.On("NotifySealedSegment", ctx, notification)}
+}
+
+func (_c *MockWALAccesser_NotifySealedSegment_Call) Run(run func(ctx context.Context, notification *streamingpb.SealedSegmentNotification)) *MockWALAccesser_NotifySealedSegment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*streamingpb.SealedSegmentNotification))
+	})
+	return _c
+}
+
+func (_c *MockWALAccesser_NotifySealedSegment_Call) Return(_a0 error) *MockWALAccesser_NotifySealedSegment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWALAccesser_NotifySealedSegment_Call) RunAndReturn(run func(context.Context, *streamingpb.SealedSegmentNotification) error) *MockWALAccesser_NotifySealedSegment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RawAppend provides a mock function with given fields: ctx, msgs, opts
 func (_m *MockWALAccesser) RawAppend(ctx context.Context, msgs message.MutableMessage, opts ...streaming.AppendOption) (*types.AppendResult, error) {
 	_va := make([]interface{}, len(opts))
@@ -7,6 +7,8 @@ import (
 
 	handler "github.com/milvus-io/milvus/internal/streamingnode/client/handler"
 	mock "github.com/stretchr/testify/mock"
+
+	streamingpb "github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
 )
 
 // MockHandlerClient is an autogenerated mock type for the HandlerClient type
@@ -22,6 +24,65 @@ func (_m *MockHandlerClient) EXPECT() *MockHandlerClient_Expecter {
 	return &MockHandlerClient_Expecter{mock: &_m.Mock}
 }
 
+// AssignPreview provides a mock function with given fields: ctx, opts
+func (_m *MockHandlerClient) AssignPreview(ctx context.Context, opts *handler.AssignPreviewOptions) (*streamingpb.AssignPreviewResponse, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignPreview")
+	}
+
+	var r0 *streamingpb.AssignPreviewResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *handler.AssignPreviewOptions) (*streamingpb.AssignPreviewResponse, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *handler.AssignPreviewOptions) *streamingpb.AssignPreviewResponse); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*streamingpb.AssignPreviewResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *handler.AssignPreviewOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHandlerClient_AssignPreview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignPreview'
+type MockHandlerClient_AssignPreview_Call struct {
+	*mock.Call
+}
+
+// AssignPreview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts *handler.AssignPreviewOptions
+func (_e *MockHandlerClient_Expecter) AssignPreview(ctx interface{}, opts interface{}) *MockHandlerClient_AssignPreview_Call {
+	return &MockHandlerClient_AssignPreview_Call{Call: _e.mock.On("AssignPreview", ctx, opts)}
+}
+
+func (_c *MockHandlerClient_AssignPreview_Call) Run(run func(ctx context.Context, opts *handler.AssignPreviewOptions)) *MockHandlerClient_AssignPreview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*handler.AssignPreviewOptions))
+	})
+	return _c
+}
+
+func (_c *MockHandlerClient_AssignPreview_Call) Return(_a0 *streamingpb.AssignPreviewResponse, _a1 error) *MockHandlerClient_AssignPreview_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHandlerClient_AssignPreview_Call) RunAndReturn(run func(context.Context, *handler.AssignPreviewOptions) (*streamingpb.AssignPreviewResponse, error)) *MockHandlerClient_AssignPreview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Close provides a mock function with no fields
 func (_m *MockHandlerClient) Close() {
 	_m.Called()
@@ -7,6 +7,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	streamingpb "github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+
 	types "github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
 )
 
@@ -160,6 +162,235 @@ func (_c *MockManagerClient_CollectAllStatus_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// FlushSegmentsOlderThan provides a mock function with given fields: ctx, pchannel, collectionID, timeTick
+func (_m *MockManagerClient) FlushSegmentsOlderThan(ctx context.Context, pchannel types.PChannelInfoAssigned, collectionID int64, timeTick uint64) ([]int64, error) {
+	ret := _m.Called(ctx, pchannel, collectionID, timeTick)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlushSegmentsOlderThan")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.PChannelInfoAssigned, int64, uint64) ([]int64, error)); ok {
+		return rf(ctx, pchannel, collectionID, timeTick)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.PChannelInfoAssigned, int64, uint64) []int64); ok {
+		r0 = rf(ctx, pchannel, collectionID, timeTick)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.PChannelInfoAssigned, int64, uint64) error); ok {
+		r1 = rf(ctx, pchannel, collectionID, timeTick)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManagerClient_FlushSegmentsOlderThan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushSegmentsOlderThan'
+type MockManagerClient_FlushSegmentsOlderThan_Call struct {
+	*mock.Call
+}
+
+// FlushSegmentsOlderThan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pchannel types.PChannelInfoAssigned
+//   - collectionID int64
+//   - timeTick uint64
+func (_e *MockManagerClient_Expecter) FlushSegmentsOlderThan(ctx interface{}, pchannel interface{}, collectionID interface{}, timeTick interface{}) *MockManagerClient_FlushSegmentsOlderThan_Call {
+	return &MockManagerClient_FlushSegmentsOlderThan_Call{Call: _e.mock.On("FlushSegmentsOlderThan", ctx, pchannel, collectionID, timeTick)}
+}
+
+func (_c *MockManagerClient_FlushSegmentsOlderThan_Call) Run(run func(ctx context.Context, pchannel types.PChannelInfoAssigned, collectionID int64, timeTick uint64)) *MockManagerClient_FlushSegmentsOlderThan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.PChannelInfoAssigned), args[2].(int64), args[3].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockManagerClient_FlushSegmentsOlderThan_Call) Return(_a0 []int64, _a1 error) *MockManagerClient_FlushSegmentsOlderThan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManagerClient_FlushSegmentsOlderThan_Call) RunAndReturn(run func(context.Context, types.PChannelInfoAssigned, int64, uint64) ([]int64, error)) *MockManagerClient_FlushSegmentsOlderThan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForceAddPartition provides a mock function with given fields: ctx, pchannel, collectionID, partitionID
+func (_m *MockManagerClient) ForceAddPartition(ctx context.Context, pchannel types.PChannelInfoAssigned, collectionID int64, partitionID int64) error {
+	ret := _m.Called(ctx, pchannel, collectionID, partitionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceAddPartition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.PChannelInfoAssigned, int64, int64) error); ok {
+		r0 = rf(ctx, pchannel, collectionID, partitionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManagerClient_ForceAddPartition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForceAddPartition'
+type MockManagerClient_ForceAddPartition_Call struct {
+	*mock.Call
+}
+
+// ForceAddPartition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pchannel types.PChannelInfoAssigned
+//   - collectionID int64
+//   - partitionID int64
+func (_e *MockManagerClient_Expecter) ForceAddPartition(ctx interface{}, pchannel interface{}, collectionID interface{}, partitionID interface{}) *MockManagerClient_ForceAddPartition_Call {
+	return &MockManagerClient_ForceAddPartition_Call{Call: _e.mock.On("ForceAddPartition", ctx, pchannel, collectionID, partitionID)}
+}
+
+func (_c *MockManagerClient_ForceAddPartition_Call) Run(run func(ctx context.Context, pchannel types.PChannelInfoAssigned, collectionID int64, partitionID int64)) *MockManagerClient_ForceAddPartition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.PChannelInfoAssigned), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockManagerClient_ForceAddPartition_Call) Return(_a0 error) *MockManagerClient_ForceAddPartition_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManagerClient_ForceAddPartition_Call) RunAndReturn(run func(context.Context, types.PChannelInfoAssigned, int64, int64) error) *MockManagerClient_ForceAddPartition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSealHistory provides a mock function with given fields: ctx, pchannel
+func (_m *MockManagerClient) GetSealHistory(ctx context.Context, pchannel types.PChannelInfoAssigned) ([]byte, error) {
+	ret := _m.Called(ctx, pchannel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSealHistory")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.PChannelInfoAssigned) ([]byte, error)); ok {
+		return rf(ctx, pchannel)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.PChannelInfoAssigned) []byte); ok {
+		r0 = rf(ctx, pchannel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.PChannelInfoAssigned) error); ok {
+		r1 = rf(ctx, pchannel)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManagerClient_GetSealHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSealHistory'
+type MockManagerClient_GetSealHistory_Call struct {
+	*mock.Call
+}
+
+// GetSealHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pchannel types.PChannelInfoAssigned
+func (_e *MockManagerClient_Expecter) GetSealHistory(ctx interface{}, pchannel interface{}) *MockManagerClient_GetSealHistory_Call {
+	return &MockManagerClient_GetSealHistory_Call{Call: _e.mock.On("GetSealHistory", ctx, pchannel)}
+}
+
+func (_c *MockManagerClient_GetSealHistory_Call) Run(run func(ctx context.Context, pchannel types.PChannelInfoAssigned)) *MockManagerClient_GetSealHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.PChannelInfoAssigned))
+	})
+	return _c
+}
+
+func (_c *MockManagerClient_GetSealHistory_Call) Return(_a0 []byte, _a1 error) *MockManagerClient_GetSealHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManagerClient_GetSealHistory_Call) RunAndReturn(run func(context.Context, types.PChannelInfoAssigned) ([]byte, error)) *MockManagerClient_GetSealHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReloadCollectionPartitions provides a mock function with given fields: ctx, pchannel, collectionID
+func (_m *MockManagerClient) ReloadCollectionPartitions(ctx context.Context, pchannel types.PChannelInfoAssigned, collectionID int64) (*streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse, error) {
+	ret := _m.Called(ctx, pchannel, collectionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReloadCollectionPartitions")
+	}
+
+	var r0 *streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.PChannelInfoAssigned, int64) (*streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse, error)); ok {
+		return rf(ctx, pchannel, collectionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.PChannelInfoAssigned, int64) *streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse); ok {
+		r0 = rf(ctx, pchannel, collectionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.PChannelInfoAssigned, int64) error); ok {
+		r1 = rf(ctx, pchannel, collectionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManagerClient_ReloadCollectionPartitions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReloadCollectionPartitions'
+type MockManagerClient_ReloadCollectionPartitions_Call struct {
+	*mock.Call
+}
+
+// ReloadCollectionPartitions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pchannel types.PChannelInfoAssigned
+//   - collectionID int64
+func (_e *MockManagerClient_Expecter) ReloadCollectionPartitions(ctx interface{}, pchannel interface{}, collectionID interface{}) *MockManagerClient_ReloadCollectionPartitions_Call {
+	return &MockManagerClient_ReloadCollectionPartitions_Call{Call: _e.mock.On("ReloadCollectionPartitions", ctx, pchannel, collectionID)}
+}
+
+func (_c *MockManagerClient_ReloadCollectionPartitions_Call) Run(run func(ctx context.Context, pchannel types.PChannelInfoAssigned, collectionID int64)) *MockManagerClient_ReloadCollectionPartitions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.PChannelInfoAssigned), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockManagerClient_ReloadCollectionPartitions_Call) Return(_a0 *streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse, _a1 error) *MockManagerClient_ReloadCollectionPartitions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManagerClient_ReloadCollectionPartitions_Call) RunAndReturn(run func(context.Context, types.PChannelInfoAssigned, int64) (*streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse, error)) *MockManagerClient_ReloadCollectionPartitions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Remove provides a mock function with given fields: ctx, pchannel
 func (_m *MockManagerClient) Remove(ctx context.Context, pchannel types.PChannelInfoAssigned) error {
 	ret := _m.Called(ctx, pchannel)
@@ -207,6 +438,66 @@ func (_c *MockManagerClient_Remove_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// SealSegments provides a mock function with given fields: ctx, pchannel, segments
+func (_m *MockManagerClient) SealSegments(ctx context.Context, pchannel types.PChannelInfoAssigned, segments []*streamingpb.SealSegmentTarget) ([]*streamingpb.SealSegmentResult, error) {
+	ret := _m.Called(ctx, pchannel, segments)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SealSegments")
+	}
+
+	var r0 []*streamingpb.SealSegmentResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.PChannelInfoAssigned, []*streamingpb.SealSegmentTarget) ([]*streamingpb.SealSegmentResult, error)); ok {
+		return rf(ctx, pchannel, segments)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.PChannelInfoAssigned, []*streamingpb.SealSegmentTarget) []*streamingpb.SealSegmentResult); ok {
+		r0 = rf(ctx, pchannel, segments)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*streamingpb.SealSegmentResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.PChannelInfoAssigned, []*streamingpb.SealSegmentTarget) error); ok {
+		r1 = rf(ctx, pchannel, segments)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManagerClient_SealSegments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SealSegments'
+type MockManagerClient_SealSegments_Call struct {
+	*mock.Call
+}
+
+// SealSegments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pchannel types.PChannelInfoAssigned
+//   - segments []*streamingpb.SealSegmentTarget
+func (_e *MockManagerClient_Expecter) SealSegments(ctx interface{}, pchannel interface{}, segments interface{}) *MockManagerClient_SealSegments_Call {
+	return &MockManagerClient_SealSegments_Call{Call: _e.mock.On("SealSegments", ctx, pchannel, segments)}
+}
+
+func (_c *MockManagerClient_SealSegments_Call) Run(run func(ctx context.Context, pchannel types.PChannelInfoAssigned, segments []*streamingpb.SealSegmentTarget)) *MockManagerClient_SealSegments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.PChannelInfoAssigned), args[2].([]*streamingpb.SealSegmentTarget))
+	})
+	return _c
+}
+
+func (_c *MockManagerClient_SealSegments_Call) Return(_a0 []*streamingpb.SealSegmentResult, _a1 error) *MockManagerClient_SealSegments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManagerClient_SealSegments_Call) RunAndReturn(run func(context.Context, types.PChannelInfoAssigned, []*streamingpb.SealSegmentTarget) ([]*streamingpb.SealSegmentResult, error)) *MockManagerClient_SealSegments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WatchNodeChanged provides a mock function with given fields: ctx
 func (_m *MockManagerClient) WatchNodeChanged(ctx context.Context) (<-chan struct{}, error) {
 	ret := _m.Called(ctx)
@@ -70,47 +70,58 @@ func (_c *MockSealOperator_Channel_Call) RunAndReturn(run func() types.PChannelI
 	return _c
 }
 
-// IsNoWaitSeal provides a mock function with no fields
-func (_m *MockSealOperator) IsNoWaitSeal() bool {
-	ret := _m.Called()
+// CompactAssignmentMeta provides a mock function with given fields: ctx
+func (_m *MockSealOperator) CompactAssignmentMeta(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IsNoWaitSeal")
+		panic("no return value specified for CompactAssignmentMeta")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func() bool); ok {
-		r0 = rf()
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockSealOperator_IsNoWaitSeal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsNoWaitSeal'
-type MockSealOperator_IsNoWaitSeal_Call struct {
+// MockSealOperator_CompactAssignmentMeta_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompactAssignmentMeta'
+type MockSealOperator_CompactAssignmentMeta_Call struct {
 	*mock.Call
 }
 
-// IsNoWaitSeal is a helper method to define mock.On call
-func (_e *MockSealOperator_Expecter) IsNoWaitSeal() *MockSealOperator_IsNoWaitSeal_Call {
-	return &MockSealOperator_IsNoWaitSeal_Call{Call: _e.mock.On("IsNoWaitSeal")}
+// CompactAssignmentMeta is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSealOperator_Expecter) CompactAssignmentMeta(ctx interface{}) *MockSealOperator_CompactAssignmentMeta_Call {
+	return &MockSealOperator_CompactAssignmentMeta_Call{Call: _e.mock.On("CompactAssignmentMeta", ctx)}
 }
 
-func (_c *MockSealOperator_IsNoWaitSeal_Call) Run(run func()) *MockSealOperator_IsNoWaitSeal_Call {
+func (_c *MockSealOperator_CompactAssignmentMeta_Call) Run(run func(ctx context.Context)) *MockSealOperator_CompactAssignmentMeta_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockSealOperator_IsNoWaitSeal_Call) Return(_a0 bool) *MockSealOperator_IsNoWaitSeal_Call {
-	_c.Call.Return(_a0)
+func (_c *MockSealOperator_CompactAssignmentMeta_Call) Return(_a0 int, _a1 error) *MockSealOperator_CompactAssignmentMeta_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSealOperator_IsNoWaitSeal_Call) RunAndReturn(run func() bool) *MockSealOperator_IsNoWaitSeal_Call {
+func (_c *MockSealOperator_CompactAssignmentMeta_Call) RunAndReturn(run func(context.Context) (int, error)) *MockSealOperator_CompactAssignmentMeta_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -163,6 +174,51 @@ func (_c *MockSealOperator_MustSealSegments_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// SealWaitState provides a mock function with no fields
+func (_m *MockSealOperator) SealWaitState() stats.SealWaitState {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SealWaitState")
+	}
+
+	var r0 stats.SealWaitState
+	if rf, ok := ret.Get(0).(func() stats.SealWaitState); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(stats.SealWaitState)
+	}
+
+	return r0
+}
+
+// MockSealOperator_SealWaitState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SealWaitState'
+type MockSealOperator_SealWaitState_Call struct {
+	*mock.Call
+}
+
+// SealWaitState is a helper method to define mock.On call
+func (_e *MockSealOperator_Expecter) SealWaitState() *MockSealOperator_SealWaitState_Call {
+	return &MockSealOperator_SealWaitState_Call{Call: _e.mock.On("SealWaitState")}
+}
+
+func (_c *MockSealOperator_SealWaitState_Call) Run(run func()) *MockSealOperator_SealWaitState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSealOperator_SealWaitState_Call) Return(_a0 stats.SealWaitState) *MockSealOperator_SealWaitState_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSealOperator_SealWaitState_Call) RunAndReturn(run func() stats.SealWaitState) *MockSealOperator_SealWaitState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TryToSealSegments provides a mock function with given fields: ctx, infos
 func (_m *MockSealOperator) TryToSealSegments(ctx context.Context, infos ...stats.SegmentBelongs) {
 	_va := make([]interface{}, len(infos))
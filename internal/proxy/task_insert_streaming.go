@@ -14,6 +14,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v2/mq/msgstream"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v2/util/timerecord"
 	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
 )
@@ -111,6 +112,7 @@ func repackInsertDataForStreamingService(
 					},
 				}).
 				WithBody(insertRequest).
+				WithSourceNode(paramtable.GetStringNodeID()).
 				BuildMutable()
 			if err != nil {
 				return nil, err
@@ -190,6 +192,7 @@ func repackInsertDataWithPartitionKeyForStreamingService(
 						},
 					}).
 					WithBody(insertRequest).
+					WithSourceNode(paramtable.GetStringNodeID()).
 					BuildMutable()
 				if err != nil {
 					return nil, err
@@ -17,6 +17,8 @@ import (
 	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
 )
 
+var errSealedSegmentNotificationDropped = errors.New("sealed segment notification is dropped")
+
 // NewAssignmentService creates a new assignment service.
 func NewAssignmentService(service lazygrpc.Service[streamingpb.StreamingCoordAssignmentServiceClient]) *AssignmentServiceImpl {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -73,6 +75,24 @@ func (c *AssignmentServiceImpl) ReportAssignmentError(ctx context.Context, pchan
 	return nil
 }
 
+// NotifySealedSegment notifies the sealed segment to server.
+func (c *AssignmentServiceImpl) NotifySealedSegment(ctx context.Context, notification *streamingpb.SealedSegmentNotification) error {
+	if !c.lifetime.Add(typeutil.LifetimeStateWorking) {
+		return status.NewOnShutdownError("assignment service client is closing")
+	}
+	defer c.lifetime.Done()
+
+	// wait for service ready.
+	assignment, err := c.getAssignmentDiscoverOrWait(ctx)
+	if err != nil {
+		return errors.Wrap(err, "at creating assignment service")
+	}
+	if !assignment.NotifySealedSegment(notification) {
+		return errSealedSegmentNotificationDropped
+	}
+	return nil
+}
+
 // Close closes the assignment service.
 func (c *AssignmentServiceImpl) Close() {
 	c.lifetime.SetState(typeutil.LifetimeStateStopped)
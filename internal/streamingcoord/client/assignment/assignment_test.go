@@ -99,6 +99,12 @@ func TestAssignmentService(t *testing.T) {
 	assignmentService.ReportAssignmentError(ctx, types.PChannelInfo{Name: "c1", Term: 1}, errors.New("test"))
 	assignmentService.ReportAssignmentError(ctx, types.PChannelInfo{Name: "c1", Term: 1}, errors.New("test"))
 
+	err = assignmentService.NotifySealedSegment(ctx, &streamingpb.SealedSegmentNotification{
+		Pchannel:  &streamingpb.PChannelInfo{Name: "c1", Term: 1},
+		SegmentId: 1,
+	})
+	assert.NoError(t, err)
+
 	// test close
 	go close(closeCh)
 	time.Sleep(10 * time.Millisecond)
@@ -114,4 +120,8 @@ func TestAssignmentService(t *testing.T) {
 	err = assignmentService.ReportAssignmentError(ctx, types.PChannelInfo{Name: "c1", Term: 1}, errors.New("test"))
 	se = status.AsStreamingError(err)
 	assert.Equal(t, streamingpb.StreamingCode_STREAMING_CODE_ON_SHUTDOWN, se.Code)
+
+	err = assignmentService.NotifySealedSegment(ctx, &streamingpb.SealedSegmentNotification{SegmentId: 1})
+	se = status.AsStreamingError(err)
+	assert.Equal(t, streamingpb.StreamingCode_STREAMING_CODE_ON_SHUTDOWN, se.Code)
 }
@@ -60,6 +60,26 @@ func (c *assignmentDiscoverClient) ReportAssignmentError(pchannel types.PChannel
 	}
 }
 
+// NotifySealedSegment notifies the sealed segment to server.
+// It never blocks, false is returned if the notification is dropped because the send queue is full or the client is closing.
+func (c *assignmentDiscoverClient) NotifySealedSegment(notification *streamingpb.SealedSegmentNotification) bool {
+	if !c.lifetime.Add(typeutil.LifetimeStateWorking) {
+		return false
+	}
+	defer c.lifetime.Done()
+
+	select {
+	case c.requestCh <- &streamingpb.AssignmentDiscoverRequest{
+		Command: &streamingpb.AssignmentDiscoverRequest_SealedSegment{
+			SealedSegment: notification,
+		},
+	}:
+		return true
+	default:
+		return false
+	}
+}
+
 func (c *assignmentDiscoverClient) IsAvailable() bool {
 	select {
 	case <-c.Available():
@@ -34,6 +34,10 @@ var _ Client = (*clientImpl)(nil)
 type AssignmentService interface {
 	// AssignmentDiscover is used to watches the assignment discovery.
 	types.AssignmentDiscoverWatcher
+
+	// NotifySealedSegment pushes the sealed segment to the streaming coord over the assignment discover stream.
+	// It's best-effort, the notification may be dropped, the wal is still the source of truth of the sealed segment.
+	NotifySealedSegment(ctx context.Context, notification *streamingpb.SealedSegmentNotification) error
 }
 
 // BroadcastService is the interface of broadcast service.
@@ -0,0 +1,93 @@
+package sealednotify
+
+import (
+	"context"
+	"sync"
+
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+// dedupCapacity is the count of recent notified segments kept for dedup.
+const dedupCapacity = 4096
+
+// Handler handles the sealed segment notification pushed by the streaming node.
+// The notification may be lost or duplicated with the flush message consumed from the wal,
+// the wal is still the source of truth, so the handler should be idempotent.
+type Handler func(ctx context.Context, notification *streamingpb.SealedSegmentNotification)
+
+var localRegistry = newRegistry()
+
+// Register registers the handler of the sealed segment notification, the returned function unregisters it.
+func Register(h Handler) (unregister func()) {
+	return localRegistry.register(h)
+}
+
+// Notify dispatches the sealed segment notification to all registered handlers.
+// The repeated notification of the same segment is ignored, false is returned if the notification is ignored.
+func Notify(ctx context.Context, notification *streamingpb.SealedSegmentNotification) bool {
+	return localRegistry.notify(ctx, notification)
+}
+
+// newRegistry creates a new registry of sealed segment handlers.
+func newRegistry() *registry {
+	return &registry{
+		handlers: make(map[int64]Handler),
+		notified: make(map[int64]struct{}, dedupCapacity),
+		order:    make([]int64, 0, dedupCapacity),
+	}
+}
+
+// registry is the registry of sealed segment handlers with the dedup of recent notified segments.
+type registry struct {
+	mu       sync.Mutex
+	nextID   int64
+	handlers map[int64]Handler
+	notified map[int64]struct{}
+	order    []int64 // the notified segments in fifo order, the oldest one is evicted if the capacity is exceeded.
+}
+
+// register registers the handler and returns the function to unregister it.
+func (r *registry) register(h Handler) func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := r.nextID
+	r.nextID++
+	r.handlers[id] = h
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.handlers, id)
+	}
+}
+
+// notify dispatches the notification to the handlers if the segment is not notified recently.
+func (r *registry) notify(ctx context.Context, notification *streamingpb.SealedSegmentNotification) bool {
+	handlers, ok := r.markNotified(notification.GetSegmentId())
+	if !ok {
+		return false
+	}
+	for _, h := range handlers {
+		h(ctx, notification)
+	}
+	return true
+}
+
+// markNotified marks the segment as notified and returns the handlers, false is returned if the segment is notified recently.
+func (r *registry) markNotified(segmentID int64) ([]Handler, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.notified[segmentID]; ok {
+		return nil, false
+	}
+	if len(r.order) >= dedupCapacity {
+		delete(r.notified, r.order[0])
+		r.order = r.order[1:]
+	}
+	r.notified[segmentID] = struct{}{}
+	r.order = append(r.order, segmentID)
+	handlers := make([]Handler, 0, len(r.handlers))
+	for _, h := range r.handlers {
+		handlers = append(handlers, h)
+	}
+	return handlers, true
+}
@@ -0,0 +1,41 @@
+package sealednotify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+func TestNotify(t *testing.T) {
+	ResetRegistration()
+	defer ResetRegistration()
+
+	ctx := context.Background()
+	// no handler registered, the notification is dropped but still deduped.
+	assert.True(t, Notify(ctx, &streamingpb.SealedSegmentNotification{SegmentId: 1}))
+
+	received := make([]int64, 0)
+	unregister := Register(func(ctx context.Context, notification *streamingpb.SealedSegmentNotification) {
+		received = append(received, notification.GetSegmentId())
+	})
+	assert.False(t, Notify(ctx, &streamingpb.SealedSegmentNotification{SegmentId: 1}))
+	assert.True(t, Notify(ctx, &streamingpb.SealedSegmentNotification{SegmentId: 2}))
+	assert.False(t, Notify(ctx, &streamingpb.SealedSegmentNotification{SegmentId: 2}))
+	assert.Equal(t, []int64{2}, received)
+
+	// the oldest segment is evicted from the dedup if the capacity is exceeded.
+	for i := 0; i < dedupCapacity; i++ {
+		Notify(ctx, &streamingpb.SealedSegmentNotification{SegmentId: int64(100 + i)})
+	}
+	assert.Len(t, received, dedupCapacity+1)
+	assert.True(t, Notify(ctx, &streamingpb.SealedSegmentNotification{SegmentId: 2}))
+	assert.False(t, Notify(ctx, &streamingpb.SealedSegmentNotification{SegmentId: int64(100 + dedupCapacity - 1)}))
+
+	// the unregistered handler is never called.
+	unregister()
+	assert.True(t, Notify(ctx, &streamingpb.SealedSegmentNotification{SegmentId: 3}))
+	assert.Len(t, received, dedupCapacity+2)
+}
@@ -0,0 +1,9 @@
+//go:build test
+// +build test
+
+package sealednotify
+
+// ResetRegistration resets the registered handlers and the dedup of notified segments.
+func ResetRegistration() {
+	localRegistry = newRegistry()
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/milvus-io/milvus/internal/streamingcoord/server/balancer"
 	"github.com/milvus-io/milvus/internal/streamingcoord/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/sealednotify"
 	"github.com/milvus-io/milvus/pkg/v2/log"
 	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
@@ -81,6 +82,14 @@ func (s *AssignmentDiscoverServer) recvLoop() (err error) {
 			channel := types.NewPChannelInfoFromProto(req.ReportError.GetPchannel())
 			// mark the channel as unavailable and trigger a recover right away.
 			s.balancer.MarkAsUnavailable(s.ctx, []types.PChannelInfo{channel})
+		case *streamingpb.AssignmentDiscoverRequest_SealedSegment:
+			// the notification is best-effort, the duplicated one is ignored.
+			if sealednotify.Notify(s.ctx, req.SealedSegment) {
+				s.logger.Debug("sealed segment notified",
+					zap.String("pchannel", req.SealedSegment.GetPchannel().GetName()),
+					zap.Int64("segmentID", req.SealedSegment.GetSegmentId()),
+					zap.Uint64("sealTimeTick", req.SealedSegment.GetSealTimeTick()))
+			}
 		case *streamingpb.AssignmentDiscoverRequest_Close:
 		default:
 			s.logger.Warn("unknown command type", zap.Any("command", req))
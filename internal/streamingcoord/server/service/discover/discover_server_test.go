@@ -5,10 +5,12 @@ import (
 	"io"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/milvus-io/milvus/internal/mocks/streamingcoord/server/mock_balancer"
 	"github.com/milvus-io/milvus/internal/streamingcoord/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingcoord/server/sealednotify"
 	"github.com/milvus-io/milvus/pkg/v2/mocks/proto/mock_streamingpb"
 	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
@@ -17,6 +19,12 @@ import (
 
 func TestAssignmentDiscover(t *testing.T) {
 	resource.InitForTest()
+	sealednotify.ResetRegistration()
+	defer sealednotify.ResetRegistration()
+	notified := make([]int64, 0)
+	sealednotify.Register(func(ctx context.Context, notification *streamingpb.SealedSegmentNotification) {
+		notified = append(notified, notification.GetSegmentId())
+	})
 	b := mock_balancer.NewMockBalancer(t)
 	b.EXPECT().WatchChannelAssignments(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, cb func(typeutil.VersionInt64Pair, []types.PChannelInfoAssigned) error) error {
 		versions := []typeutil.VersionInt64Pair{
@@ -66,6 +74,22 @@ func TestAssignmentDiscover(t *testing.T) {
 				},
 			},
 		},
+		{
+			Command: &streamingpb.AssignmentDiscoverRequest_SealedSegment{
+				SealedSegment: &streamingpb.SealedSegmentNotification{
+					Pchannel:  &streamingpb.PChannelInfo{Name: "pchannel", Term: 1},
+					SegmentId: 1,
+				},
+			},
+		},
+		{
+			Command: &streamingpb.AssignmentDiscoverRequest_SealedSegment{
+				SealedSegment: &streamingpb.SealedSegmentNotification{
+					Pchannel:  &streamingpb.PChannelInfo{Name: "pchannel", Term: 1},
+					SegmentId: 1,
+				},
+			},
+		},
 		{
 			Command: &streamingpb.AssignmentDiscoverRequest_Close{},
 		},
@@ -81,4 +105,6 @@ func TestAssignmentDiscover(t *testing.T) {
 	streamServer.EXPECT().Send(mock.Anything).Return(nil)
 	ads := NewAssignmentDiscoverServer(b, streamServer)
 	ads.Execute()
+	// the repeated notification is deduped.
+	assert.Equal(t, []int64{1}, notified)
 }
@@ -62,6 +62,18 @@ type ConsumerOptions struct {
 	MessageHandler message.Handler
 }
 
+// AssignPreviewOptions is the options for previewing the segment assignment of an insert batch.
+type AssignPreviewOptions struct {
+	// PChannel is the pchannel that the insert will be appended to.
+	PChannel string
+
+	CollectionID int64
+	PartitionID  int64
+	Rows         uint64
+	BinarySize   uint64
+	HighPriority bool
+}
+
 // HandlerClient is the interface that wraps streamingpb.StreamingNodeHandlerServiceClient.
 // HandlerClient wraps the PChannel Assignment Service Discovery.
 // Provides the ability to create pchannel-level producer and consumer.
@@ -82,6 +94,11 @@ type HandlerClient interface {
 	// A consumer will not share stream connection with other consumers.
 	CreateConsumer(ctx context.Context, opts *ConsumerOptions) (Consumer, error)
 
+	// AssignPreview previews the segment assignment of an insert batch at the streaming node of the pchannel without appending it.
+	// It returns whether the batch fits in an existing growing segment, would trigger a new segment or would be rejected as too large.
+	// It's a one-shot call without retry, the caller should fall back to the normal insert if it fails.
+	AssignPreview(ctx context.Context, opts *AssignPreviewOptions) (*streamingpb.AssignPreviewResponse, error)
+
 	// Close closes the handler client.
 	// It will only stop the underlying service discovery, but don't stop the producer and consumer created by it.
 	// So please close Producer and Consumer created by it before close the handler client.
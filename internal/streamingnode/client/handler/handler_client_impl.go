@@ -14,6 +14,7 @@ import (
 	"github.com/milvus-io/milvus/internal/streamingnode/client/handler/registry"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
 	"github.com/milvus-io/milvus/internal/util/streamingutil/service/balancer/picker"
+	"github.com/milvus-io/milvus/internal/util/streamingutil/service/contextutil"
 	"github.com/milvus-io/milvus/internal/util/streamingutil/service/lazygrpc"
 	"github.com/milvus-io/milvus/internal/util/streamingutil/service/resolver"
 	"github.com/milvus-io/milvus/internal/util/streamingutil/status"
@@ -148,6 +149,33 @@ func (hc *handlerClientImpl) CreateConsumer(ctx context.Context, opts *ConsumerO
 	return c.(Consumer), nil
 }
 
+// AssignPreview previews the segment assignment of an insert batch at the streaming node of the pchannel.
+func (hc *handlerClientImpl) AssignPreview(ctx context.Context, opts *AssignPreviewOptions) (*streamingpb.AssignPreviewResponse, error) {
+	if !hc.lifetime.Add(typeutil.LifetimeStateWorking) {
+		return nil, ErrClientClosed
+	}
+	defer hc.lifetime.Done()
+
+	assign := hc.watcher.Get(ctx, opts.PChannel)
+	if assign == nil {
+		return nil, ErrClientAssignmentNotReady
+	}
+	handlerService, err := hc.service.GetService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// select the server that holds the pchannel.
+	ctx = contextutil.WithPickServerID(ctx, assign.Node.ServerID)
+	return handlerService.AssignPreview(ctx, &streamingpb.AssignPreviewRequest{
+		Pchannel:     types.NewProtoFromPChannelInfo(assign.Channel),
+		CollectionId: opts.CollectionID,
+		PartitionId:  opts.PartitionID,
+		Rows:         opts.Rows,
+		BinarySize:   opts.BinarySize,
+		HighPriority: opts.HighPriority,
+	})
+}
+
 type handlerCreateFunc func(ctx context.Context, assign *types.PChannelInfoAssigned) (any, error)
 
 // createHandlerAfterStreamingNodeReady creates a handler until streaming node ready.
@@ -301,7 +301,7 @@ func (p *producerImpl) recvLoop() (err error) {
 				}
 			case *streamingpb.ProduceMessageResponse_Error:
 				result = produceResponse{
-					err: status.New(produceResp.Error.Code, produceResp.Error.Cause),
+					err: status.NewFromPBError(produceResp.Error),
 				}
 			default:
 				panic("unreachable")
@@ -39,6 +39,28 @@ type ManagerClient interface {
 	// Remove the wal instance for the channel on streaming node of given server id.
 	Remove(ctx context.Context, pchannel types.PChannelInfoAssigned) error
 
+	// SealSegments seals a batch of growing segments of the channel together on streaming node of given server id.
+	// The outcome of every segment is returned, a segment failed to seal never fails the whole batch.
+	SealSegments(ctx context.Context, pchannel types.PChannelInfoAssigned, segments []*streamingpb.SealSegmentTarget) ([]*streamingpb.SealSegmentResult, error)
+
+	// ReloadCollectionPartitions re-fetches the partitions of the collection from the coordinator
+	// and merges them into the segment assignment of the channel on streaming node of given server id.
+	// The added and removed partitions are returned.
+	ReloadCollectionPartitions(ctx context.Context, pchannel types.PChannelInfoAssigned, collectionID int64) (*streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse, error)
+
+	// ForceAddPartition adds the partition unknown by the coordinator into the segment assignment of the channel
+	// on streaming node of given server id. It's a break-glass operation and only works if it's enabled by config.
+	ForceAddPartition(ctx context.Context, pchannel types.PChannelInfoAssigned, collectionID int64, partitionID int64) error
+
+	// GetSealHistory gets the recent seal events of the channel on streaming node of given server id.
+	// The seal events are json encoded, the newest first.
+	GetSealHistory(ctx context.Context, pchannel types.PChannelInfoAssigned) ([]byte, error)
+
+	// FlushSegmentsOlderThan seals the growing segments of the collection on the channel on streaming node of given server id,
+	// whose assigned data are all not newer than the timetick. No fence is applied, so the ingest is never blocked.
+	// The sealed segments are returned.
+	FlushSegmentsOlderThan(ctx context.Context, pchannel types.PChannelInfoAssigned, collectionID int64, timeTick uint64) ([]int64, error)
+
 	// Close closes the manager client.
 	// It close the underlying connection, stop the node watcher and release all resources.
 	Close()
@@ -3,6 +3,7 @@ package manager
 import (
 	"context"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -125,6 +126,50 @@ func (c *managerClientImpl) getAllStreamingNodeStatus(ctx context.Context, state
 				log.Warn("collect status failed, skip", zap.Int64("serverID", serverID), zap.Error(err))
 				return err
 			}
+			result[serverID].TimeToSealP99 = make(map[int64]time.Duration, len(resp.GetBalanceAttributes().GetTimeToSeal()))
+			for _, tts := range resp.GetBalanceAttributes().GetTimeToSeal() {
+				result[serverID].TimeToSealP99[tts.GetCollectionId()] = time.Duration(tts.GetP99Milliseconds()) * time.Millisecond
+			}
+			result[serverID].VChannelGrowing = make(map[string]types.VChannelGrowingStats, len(resp.GetBalanceAttributes().GetVchannelGrowing()))
+			for _, growing := range resp.GetBalanceAttributes().GetVchannelGrowing() {
+				result[serverID].VChannelGrowing[growing.GetVchannel()] = types.VChannelGrowingStats{
+					CollectionID: growing.GetCollectionId(),
+					SegmentCount: growing.GetSegmentCount(),
+					Rows:         growing.GetRows(),
+					BinarySize:   growing.GetBinarySize(),
+				}
+			}
+			result[serverID].StorageVersions = make(map[string]map[int64]int64)
+			for _, count := range resp.GetBalanceAttributes().GetStorageVersions() {
+				if _, ok := result[serverID].StorageVersions[count.GetPchannel()]; !ok {
+					result[serverID].StorageVersions[count.GetPchannel()] = make(map[int64]int64)
+				}
+				result[serverID].StorageVersions[count.GetPchannel()][count.GetStorageVersion()] = count.GetSegmentCount()
+			}
+			result[serverID].SealedUnflushed = make(map[string][]int64, len(resp.GetBalanceAttributes().GetSealedUnflushed()))
+			for _, segments := range resp.GetBalanceAttributes().GetSealedUnflushed() {
+				result[serverID].SealedUnflushed[segments.GetPchannel()] = segments.GetSegmentIds()
+			}
+			result[serverID].Saturations = make(map[string]types.PChannelSaturation, len(resp.GetBalanceAttributes().GetSaturations()))
+			for _, saturation := range resp.GetBalanceAttributes().GetSaturations() {
+				result[serverID].Saturations[saturation.GetPchannel()] = types.PChannelSaturation{
+					Saturation: saturation.GetSaturation(),
+					Saturated:  saturation.GetSaturated(),
+				}
+			}
+			result[serverID].AssignmentMetadata = make(map[string]types.PChannelAssignmentMetadata, len(resp.GetBalanceAttributes().GetAssignmentMetadata()))
+			for _, metadata := range resp.GetBalanceAttributes().GetAssignmentMetadata() {
+				result[serverID].AssignmentMetadata[metadata.GetPchannel()] = types.PChannelAssignmentMetadata{
+					Term:            metadata.GetTerm(),
+					NodeVersion:     metadata.GetNodeVersion(),
+					EnabledFeatures: metadata.GetEnabledFeatures(),
+					PolicyChain:     metadata.GetPolicyChain(),
+					Collections:     metadata.GetCollections(),
+					Partitions:      metadata.GetPartitions(),
+					MaxCollections:  metadata.GetMaxCollections(),
+					MaxPartitions:   metadata.GetMaxPartitions(),
+				}
+			}
 			log.Debug("collect status success", zap.Int64("serverID", serverID), zap.Any("status", resp))
 			return nil
 		})
@@ -187,6 +232,125 @@ func (c *managerClientImpl) Remove(ctx context.Context, pchannel types.PChannelI
 	return err
 }
 
+// SealSegments seals a batch of growing segments of the channel together on log node of given server id.
+func (c *managerClientImpl) SealSegments(ctx context.Context, pchannel types.PChannelInfoAssigned, segments []*streamingpb.SealSegmentTarget) ([]*streamingpb.SealSegmentResult, error) {
+	if !c.lifetime.Add(typeutil.LifetimeStateWorking) {
+		return nil, status.NewOnShutdownError("manager client is closing")
+	}
+	defer c.lifetime.Done()
+
+	// wait for manager service ready.
+	manager, err := c.service.GetService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Select the streaming node that holds the wal instance.
+	ctx = contextutil.WithPickServerID(ctx, pchannel.Node.ServerID)
+	resp, err := manager.SealSegments(ctx, &streamingpb.StreamingNodeManagerSealSegmentsRequest{
+		Pchannel: types.NewProtoFromPChannelInfo(pchannel.Channel),
+		Segments: segments,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetResults(), nil
+}
+
+// ReloadCollectionPartitions reloads the partitions of the collection from the coordinator on log node of given server id.
+func (c *managerClientImpl) ReloadCollectionPartitions(ctx context.Context, pchannel types.PChannelInfoAssigned, collectionID int64) (*streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse, error) {
+	if !c.lifetime.Add(typeutil.LifetimeStateWorking) {
+		return nil, status.NewOnShutdownError("manager client is closing")
+	}
+	defer c.lifetime.Done()
+
+	// wait for manager service ready.
+	manager, err := c.service.GetService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Select the streaming node that holds the wal instance.
+	ctx = contextutil.WithPickServerID(ctx, pchannel.Node.ServerID)
+	return manager.ReloadCollectionPartitions(ctx, &streamingpb.StreamingNodeManagerReloadCollectionPartitionsRequest{
+		Pchannel:     types.NewProtoFromPChannelInfo(pchannel.Channel),
+		CollectionId: collectionID,
+	})
+}
+
+// ForceAddPartition forcibly adds the partition of the collection on log node of given server id.
+func (c *managerClientImpl) ForceAddPartition(ctx context.Context, pchannel types.PChannelInfoAssigned, collectionID int64, partitionID int64) error {
+	if !c.lifetime.Add(typeutil.LifetimeStateWorking) {
+		return status.NewOnShutdownError("manager client is closing")
+	}
+	defer c.lifetime.Done()
+
+	// wait for manager service ready.
+	manager, err := c.service.GetService(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Select the streaming node that holds the wal instance.
+	ctx = contextutil.WithPickServerID(ctx, pchannel.Node.ServerID)
+	_, err = manager.ForceAddPartition(ctx, &streamingpb.StreamingNodeManagerForceAddPartitionRequest{
+		Pchannel:     types.NewProtoFromPChannelInfo(pchannel.Channel),
+		CollectionId: collectionID,
+		PartitionId:  partitionID,
+	})
+	return err
+}
+
+// GetSealHistory gets the recent seal events of the channel on log node of given server id.
+func (c *managerClientImpl) GetSealHistory(ctx context.Context, pchannel types.PChannelInfoAssigned) ([]byte, error) {
+	if !c.lifetime.Add(typeutil.LifetimeStateWorking) {
+		return nil, status.NewOnShutdownError("manager client is closing")
+	}
+	defer c.lifetime.Done()
+
+	// wait for manager service ready.
+	manager, err := c.service.GetService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Select the streaming node that holds the wal instance.
+	ctx = contextutil.WithPickServerID(ctx, pchannel.Node.ServerID)
+	resp, err := manager.GetSealHistory(ctx, &streamingpb.StreamingNodeManagerGetSealHistoryRequest{
+		Pchannel: types.NewProtoFromPChannelInfo(pchannel.Channel),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetHistory(), nil
+}
+
+// FlushSegmentsOlderThan seals the growing segments of the collection on log node of given server id whose assigned data are all not newer than the timetick.
+func (c *managerClientImpl) FlushSegmentsOlderThan(ctx context.Context, pchannel types.PChannelInfoAssigned, collectionID int64, timeTick uint64) ([]int64, error) {
+	if !c.lifetime.Add(typeutil.LifetimeStateWorking) {
+		return nil, status.NewOnShutdownError("manager client is closing")
+	}
+	defer c.lifetime.Done()
+
+	// wait for manager service ready.
+	manager, err := c.service.GetService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Select the streaming node that holds the wal instance.
+	ctx = contextutil.WithPickServerID(ctx, pchannel.Node.ServerID)
+	resp, err := manager.FlushSegmentsOlderThan(ctx, &streamingpb.StreamingNodeManagerFlushSegmentsOlderThanRequest{
+		Pchannel:     types.NewProtoFromPChannelInfo(pchannel.Channel),
+		CollectionId: collectionID,
+		TimeTick:     timeTick,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetSegmentIds(), nil
+}
+
 // Close closes the manager client.
 func (c *managerClientImpl) Close() {
 	c.lifetime.SetState(typeutil.LifetimeStateStopped)
@@ -118,6 +118,102 @@ func TestManager(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
+	// Test SealSegments
+	serverID = int64(3)
+	managerServiceClient.EXPECT().SealSegments(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, req *streamingpb.StreamingNodeManagerSealSegmentsRequest, co ...grpc.CallOption) (*streamingpb.StreamingNodeManagerSealSegmentsResponse, error) {
+			pickedServerID, ok := contextutil.GetPickServerID(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, serverID, pickedServerID)
+			assert.Equal(t, "p", req.GetPchannel().GetName())
+			results := make([]*streamingpb.SealSegmentResult, 0, len(req.GetSegments()))
+			for _, segment := range req.GetSegments() {
+				results = append(results, &streamingpb.SealSegmentResult{
+					SegmentId: segment.GetSegmentId(),
+					Outcome:   streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_SEALED,
+				})
+			}
+			return &streamingpb.StreamingNodeManagerSealSegmentsResponse{Results: results}, nil
+		})
+	results, err := m.SealSegments(context.Background(), types.PChannelInfoAssigned{
+		Channel: types.PChannelInfo{Name: "p", Term: 1},
+		Node:    types.StreamingNodeInfo{ServerID: serverID},
+	}, []*streamingpb.SealSegmentTarget{{CollectionId: 1, PartitionId: 2, SegmentId: 3}})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, int64(3), results[0].GetSegmentId())
+
+	// Test ReloadCollectionPartitions
+	managerServiceClient.EXPECT().ReloadCollectionPartitions(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, req *streamingpb.StreamingNodeManagerReloadCollectionPartitionsRequest, co ...grpc.CallOption) (*streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse, error) {
+			pickedServerID, ok := contextutil.GetPickServerID(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, serverID, pickedServerID)
+			assert.Equal(t, "p", req.GetPchannel().GetName())
+			assert.Equal(t, int64(1), req.GetCollectionId())
+			return &streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse{
+				AddedPartitionIds:   []int64{4},
+				RemovedPartitionIds: []int64{2},
+			}, nil
+		})
+	diff, err := m.ReloadCollectionPartitions(context.Background(), types.PChannelInfoAssigned{
+		Channel: types.PChannelInfo{Name: "p", Term: 1},
+		Node:    types.StreamingNodeInfo{ServerID: serverID},
+	}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{4}, diff.GetAddedPartitionIds())
+	assert.Equal(t, []int64{2}, diff.GetRemovedPartitionIds())
+
+	// Test ForceAddPartition
+	managerServiceClient.EXPECT().ForceAddPartition(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, req *streamingpb.StreamingNodeManagerForceAddPartitionRequest, co ...grpc.CallOption) (*streamingpb.StreamingNodeManagerForceAddPartitionResponse, error) {
+			pickedServerID, ok := contextutil.GetPickServerID(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, serverID, pickedServerID)
+			assert.Equal(t, int64(1), req.GetCollectionId())
+			assert.Equal(t, int64(5), req.GetPartitionId())
+			return &streamingpb.StreamingNodeManagerForceAddPartitionResponse{}, nil
+		})
+	err = m.ForceAddPartition(context.Background(), types.PChannelInfoAssigned{
+		Channel: types.PChannelInfo{Name: "p", Term: 1},
+		Node:    types.StreamingNodeInfo{ServerID: serverID},
+	}, 1, 5)
+	assert.NoError(t, err)
+
+	// Test GetSealHistory
+	managerServiceClient.EXPECT().GetSealHistory(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, req *streamingpb.StreamingNodeManagerGetSealHistoryRequest, co ...grpc.CallOption) (*streamingpb.StreamingNodeManagerGetSealHistoryResponse, error) {
+			pickedServerID, ok := contextutil.GetPickServerID(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, serverID, pickedServerID)
+			assert.Equal(t, "p", req.GetPchannel().GetName())
+			return &streamingpb.StreamingNodeManagerGetSealHistoryResponse{History: []byte("[]")}, nil
+		})
+	history, err := m.GetSealHistory(context.Background(), types.PChannelInfoAssigned{
+		Channel: types.PChannelInfo{Name: "p", Term: 1},
+		Node:    types.StreamingNodeInfo{ServerID: serverID},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("[]"), history)
+
+	// Test FlushSegmentsOlderThan
+	managerServiceClient.EXPECT().FlushSegmentsOlderThan(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, req *streamingpb.StreamingNodeManagerFlushSegmentsOlderThanRequest, co ...grpc.CallOption) (*streamingpb.StreamingNodeManagerFlushSegmentsOlderThanResponse, error) {
+			pickedServerID, ok := contextutil.GetPickServerID(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, serverID, pickedServerID)
+			assert.Equal(t, "p", req.GetPchannel().GetName())
+			assert.Equal(t, int64(1), req.GetCollectionId())
+			assert.Equal(t, uint64(100), req.GetTimeTick())
+			return &streamingpb.StreamingNodeManagerFlushSegmentsOlderThanResponse{SegmentIds: []int64{1000}}, nil
+		})
+	segmentIDs, err := m.FlushSegmentsOlderThan(context.Background(), types.PChannelInfoAssigned{
+		Channel: types.PChannelInfo{Name: "p", Term: 1},
+		Node:    types.StreamingNodeInfo{ServerID: serverID},
+	}, 1, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1000}, segmentIDs)
+
 	// Test Close
 	managerService.EXPECT().Close().Return()
 	rb.EXPECT().Close().Return()
@@ -130,6 +226,20 @@ func TestManager(t *testing.T) {
 	assert.Error(t, err)
 	err = m.Remove(context.Background(), types.PChannelInfoAssigned{})
 	assert.Error(t, err)
+	results, err = m.SealSegments(context.Background(), types.PChannelInfoAssigned{}, nil)
+	assert.Nil(t, results)
+	assert.Error(t, err)
+	diff, err = m.ReloadCollectionPartitions(context.Background(), types.PChannelInfoAssigned{}, 1)
+	assert.Nil(t, diff)
+	assert.Error(t, err)
+	err = m.ForceAddPartition(context.Background(), types.PChannelInfoAssigned{}, 1, 5)
+	assert.Error(t, err)
+	history, err = m.GetSealHistory(context.Background(), types.PChannelInfoAssigned{})
+	assert.Nil(t, history)
+	assert.Error(t, err)
+	segmentIDs, err = m.FlushSegmentsOlderThan(context.Background(), types.PChannelInfoAssigned{}, 1, 100)
+	assert.Nil(t, segmentIDs)
+	assert.Error(t, err)
 	resultCh, err = m.WatchNodeChanged(context.Background())
 	assert.Nil(t, resultCh)
 	assert.Error(t, err)
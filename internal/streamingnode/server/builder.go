@@ -4,12 +4,14 @@ import (
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"google.golang.org/grpc"
 
+	"github.com/milvus-io/milvus/internal/distributed/streaming"
 	"github.com/milvus-io/milvus/internal/metastore/kv/streamingnode"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/pkg/v2/kv"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
 )
 
@@ -73,6 +75,10 @@ func (b *ServerBuilder) Build() *Server {
 		resource.OptMixCoordClient(b.mixc),
 		resource.OptStreamingNodeCatalog(streamingnode.NewCataLog(b.kv)),
 	)
+	if paramtable.Get().StreamingCfg.WALSegmentAssignSealedNotifyEnabled.GetAsBool() {
+		// the sealed segments are pushed to the coordinator over the assignment discover stream of the wal accesser.
+		resource.Apply(resource.OptSealedSegmentNotifier(streaming.WAL()))
+	}
 	resource.Done()
 	s := &Server{
 		session:    b.session,
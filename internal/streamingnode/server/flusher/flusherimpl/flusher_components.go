@@ -3,6 +3,7 @@ package flusherimpl
 import (
 	"context"
 
+	"github.com/cockroachdb/errors"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 
@@ -33,6 +34,7 @@ type flusherComponents struct {
 	cpUpdater    *util.ChannelCheckpointUpdater
 	chunkManager storage.ChunkManager
 	dataServices map[string]*dataSyncServiceWrapper
+	segmentFill  *segmentFillTracker
 	logger       *log.MLogger
 }
 
@@ -64,6 +66,7 @@ func (impl *flusherComponents) WhenCreateCollection(createCollectionMsg message.
 			CheckpointUpdater:  impl.cpUpdater,
 			Allocator:          idalloc.NewMAllocator(resource.Resource().IDAllocator()),
 			MsgHandler:         newMsgHandler(resource.Resource().WriteBufferManager()),
+			SyncDeferrer:       impl.segmentFill.ShouldDeferSync,
 		},
 		msgChan,
 		&datapb.VchannelInfo{
@@ -78,23 +81,56 @@ func (impl *flusherComponents) WhenCreateCollection(createCollectionMsg message.
 			},
 		},
 		schema,
-		func(t syncmgr.Task, err error) {
-			if err != nil || t == nil {
-				return
-			}
-			if tt, ok := t.(*syncmgr.SyncTask); ok {
-				insertLogs, _, _, _ := tt.Binlogs()
-				resource.Resource().SegmentAssignStatsManager().UpdateOnSync(tt.SegmentID(), stats.SyncOperationMetrics{
-					BinLogCounterIncr:     1,
-					BinLogFileCounterIncr: uint64(len(insertLogs)),
-				})
-			}
-		},
+		impl.updateSegmentStatsOnSync,
 		nil,
 	)
 	impl.addNewDataSyncService(createCollectionMsg, msgChan, ds)
 }
 
+// updateSegmentStatsOnSync updates the stats of the segment assignment after the sync task is done.
+func (impl *flusherComponents) updateSegmentStatsOnSync(t syncmgr.Task, err error) {
+	if err != nil || t == nil {
+		return
+	}
+	tt, ok := t.(*syncmgr.SyncTask)
+	if !ok {
+		return
+	}
+	if tt.IsFlush() {
+		// the flush of the sealed segment is completed, so the coordinator can compact it.
+		resource.Resource().SegmentStateIndex().ObserveFlushCompleted(tt.SegmentID())
+	}
+	insertLogs, statsLogs, _, bm25Logs := tt.Binlogs()
+	if err := resource.Resource().SegmentAssignStatsManager().UpdateOnSync(tt.SegmentID(), stats.SyncOperationMetrics{
+		BinLogCounterIncr:     1,
+		BinLogFileCounterIncr: uint64(len(insertLogs)),
+		WrittenBinarySizeIncr: binlogsSize(insertLogs) + binlogsSize(statsLogs) + binlogsSize(bm25Logs),
+	}); err != nil {
+		if errors.Is(err, stats.ErrSegmentRecentlyRemoved) {
+			// the late update of the flushed or discarded segment is expected, it's already counted by the stats manager.
+			return
+		}
+		if errors.Is(err, stats.ErrSegmentNotFound) {
+			// the sealed segment is removed from the stats manager, but its data is still synced.
+			impl.logger.Debug("skip the stats update of the non-growing segment on sync", zap.Int64("segmentID", tt.SegmentID()), zap.Error(err))
+			return
+		}
+		impl.logger.Warn("the stats update on sync is malformed", zap.Int64("segmentID", tt.SegmentID()), zap.Int("insertLogs", len(insertLogs)), zap.Error(err))
+	}
+}
+
+// binlogsSize returns the total log size of the binlogs written by the sync operation.
+// The delta binlogs are not counted, they're the deletes but not the inserts of the segment.
+func binlogsSize(binlogs map[int64]*datapb.FieldBinlog) uint64 {
+	size := uint64(0)
+	for _, fieldBinlog := range binlogs {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			size += uint64(binlog.GetLogSize())
+		}
+	}
+	return size
+}
+
 // WhenDropCollection handles the drop collection message.
 func (impl *flusherComponents) WhenDropCollection(vchannel string) {
 	// flowgraph is removed by data sync service it self.
@@ -249,21 +285,11 @@ func (impl *flusherComponents) buildDataSyncService(ctx context.Context, recover
 			CheckpointUpdater:  impl.cpUpdater,
 			Allocator:          idalloc.NewMAllocator(resource.Resource().IDAllocator()),
 			MsgHandler:         newMsgHandler(resource.Resource().WriteBufferManager()),
+			SyncDeferrer:       impl.segmentFill.ShouldDeferSync,
 		},
 		&datapb.ChannelWatchInfo{Vchan: recoverInfo.GetInfo(), Schema: recoverInfo.GetSchema()},
 		input,
-		func(t syncmgr.Task, err error) {
-			if err != nil || t == nil {
-				return
-			}
-			if tt, ok := t.(*syncmgr.SyncTask); ok {
-				insertLogs, _, _, _ := tt.Binlogs()
-				resource.Resource().SegmentAssignStatsManager().UpdateOnSync(tt.SegmentID(), stats.SyncOperationMetrics{
-					BinLogCounterIncr:     1,
-					BinLogFileCounterIncr: uint64(len(insertLogs)),
-				})
-			}
-		},
+		impl.updateSegmentStatsOnSync,
 		nil,
 	)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
 	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
@@ -29,6 +30,7 @@ func newFlusherMetrics(pchannel types.PChannelInfo) *flusherMetrics {
 		constLabels: constLabels,
 		info:        metrics.WALFlusherInfo.MustCurryWith(constLabels),
 		timetick:    metrics.WALFlusherTimeTick.With(constLabels),
+		quarantined: metrics.WALFlusherQuarantinedMessageTotal.MustCurryWith(constLabels),
 		state:       flusherStateInRecovering,
 	}
 	m.info.WithLabelValues(flusherStateInRecovering).Set(1)
@@ -39,6 +41,7 @@ type flusherMetrics struct {
 	constLabels prometheus.Labels
 	info        *prometheus.GaugeVec
 	timetick    prometheus.Gauge
+	quarantined *prometheus.CounterVec
 	state       flusherState
 }
 
@@ -52,7 +55,13 @@ func (m *flusherMetrics) ObserveMetrics(tickTime uint64) {
 	m.timetick.Set(tsoutil.PhysicalTimeSeconds(tickTime))
 }
 
+// ObserveQuarantined observes a message quarantined for the corrupted segment assignment.
+func (m *flusherMetrics) ObserveQuarantined(msgType message.MessageType) {
+	m.quarantined.WithLabelValues(msgType.String()).Inc()
+}
+
 func (m *flusherMetrics) Close() {
 	metrics.WALFlusherInfo.DeletePartialMatch(m.constLabels)
 	metrics.WALFlusherTimeTick.DeletePartialMatch(m.constLabels)
+	metrics.WALFlusherQuarantinedMessageTotal.DeletePartialMatch(m.constLabels)
 }
@@ -55,7 +55,8 @@ func (impl *msgHandlerImpl) HandleCreateSegment(ctx context.Context, createSegme
 		return err
 	}
 	logger := log.With(log.FieldMessage(createSegmentMsg))
-	if err := impl.wbMgr.CreateNewGrowingSegment(ctx, vchannel, h.PartitionId, h.SegmentId); err != nil {
+	// the segment is created with the storage version recorded at allocation, so the sync writes the right format.
+	if err := impl.wbMgr.CreateNewGrowingSegment(ctx, vchannel, h.PartitionId, h.SegmentId, h.StorageVersion); err != nil {
 		logger.Warn("fail to create new growing segment")
 		return err
 	}
@@ -0,0 +1,66 @@
+package flusherimpl
+
+import (
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+)
+
+// newSegmentEpochTracker creates a new segment epoch tracker.
+func newSegmentEpochTracker() *segmentEpochTracker {
+	return &segmentEpochTracker{
+		epochs: make(map[int64]uint64),
+	}
+}
+
+// segmentEpochTracker keeps the assignment epoch of the growing segments of the pchannel learned from their create segment messages,
+// so the segment assignments of the consumed insert messages can be verified before the rows are buffered.
+// The segment created before the checkpoint of the flusher is unknown, its assignments are not verifiable and skipped.
+// It's only accessed by the executing goroutine of the flusher, so no lock is required.
+type segmentEpochTracker struct {
+	epochs map[int64]uint64 // map[SegmentID]AssignmentEpoch
+}
+
+// Observe records the assignment epoch of the created segment, the unsigned segment is not recorded.
+func (t *segmentEpochTracker) Observe(h *message.CreateSegmentMessageHeader) {
+	if h.GetAssignmentEpoch() == 0 {
+		return
+	}
+	t.epochs[h.GetSegmentId()] = h.GetAssignmentEpoch()
+}
+
+// Remove forgets the segment, it's called when the flush message of the segment is consumed.
+func (t *segmentEpochTracker) Remove(segmentID int64) {
+	delete(t.epochs, segmentID)
+}
+
+// Verify verifies the segment assignments of the insert message, or the inserts of the txn message.
+// The txn is verified as a whole, so the corrupted insert makes the whole txn unverified.
+// message.ErrSegmentAssignmentCorrupted is returned if any assignment mismatches.
+func (t *segmentEpochTracker) Verify(msg message.ImmutableMessage) error {
+	switch msg.MessageType() {
+	case message.MessageTypeInsert:
+		insertMsg, err := message.AsImmutableInsertMessageV1(msg)
+		if err != nil {
+			// the broken insert message is never verifiable, it's rejected by the data sync service as before.
+			return nil
+		}
+		return message.VerifyInsertMessageSegmentAssignments(insertMsg, t.epochOf)
+	case message.MessageTypeTxn:
+		txnMsg := message.AsImmutableTxnMessage(msg)
+		if txnMsg == nil {
+			return nil
+		}
+		return txnMsg.RangeOver(func(im message.ImmutableMessage) error {
+			if im.MessageType() != message.MessageTypeInsert {
+				return nil
+			}
+			return t.Verify(im)
+		})
+	}
+	return nil
+}
+
+// epochOf returns the assignment epoch of the segment, false if the segment is unknown.
+func (t *segmentEpochTracker) epochOf(segmentID int64) (uint64, bool) {
+	epoch, ok := t.epochs[segmentID]
+	return epoch, ok
+}
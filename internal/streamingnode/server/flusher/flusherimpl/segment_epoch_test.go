@@ -0,0 +1,51 @@
+package flusherimpl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+)
+
+func TestSegmentEpochTracker(t *testing.T) {
+	const (
+		epoch    = uint64(7)
+		timetick = uint64(100)
+	)
+	build := func(segmentID int64, checksum uint32) message.ImmutableMessage {
+		msgID := rmq.NewRmqID(1)
+		return message.NewInsertMessageBuilderV1().
+			WithVChannel("v1").
+			WithHeader(&message.InsertMessageHeader{
+				CollectionId: 1,
+				Partitions: []*message.PartitionSegmentAssignment{{
+					PartitionId:       2,
+					SegmentAssignment: &message.SegmentAssignment{SegmentId: segmentID, Checksum: checksum},
+				}},
+			}).
+			WithBody(&msgpb.InsertRequest{CollectionID: 1}).
+			MustBuildMutable().
+			WithTimeTick(timetick).
+			WithLastConfirmed(msgID).
+			IntoImmutableMessage(msgID)
+	}
+
+	tracker := newSegmentEpochTracker()
+	tracker.Observe(&message.CreateSegmentMessageHeader{CollectionId: 1, PartitionId: 2, SegmentId: 1000, AssignmentEpoch: epoch})
+	// the unsigned segment is never verified.
+	tracker.Observe(&message.CreateSegmentMessageHeader{CollectionId: 1, PartitionId: 2, SegmentId: 2000})
+
+	assert.NoError(t, tracker.Verify(build(1000, message.SegmentAssignmentChecksum(epoch, 1, 2, 1000, timetick))))
+	assert.ErrorIs(t, tracker.Verify(build(1000, 12345)), message.ErrSegmentAssignmentCorrupted)
+	assert.NoError(t, tracker.Verify(build(2000, 12345)))
+	// the segment created before the checkpoint is unknown, so it's not verifiable.
+	assert.NoError(t, tracker.Verify(build(3000, 12345)))
+
+	// the flushed segment is forgotten.
+	tracker.Remove(1000)
+	assert.NoError(t, tracker.Verify(build(1000, 12345)))
+	assert.Empty(t, tracker.epochs)
+}
@@ -0,0 +1,62 @@
+package flusherimpl
+
+import (
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// newSegmentFillTracker creates a new segment fill tracker.
+func newSegmentFillTracker(c clock.Clock) *segmentFillTracker {
+	return &segmentFillTracker{
+		clock: c,
+		fills: make(map[int64]stats.SegmentFillUpdate),
+	}
+}
+
+// segmentFillTracker keeps the latest fill of the nearly full growing segments of the pchannel,
+// it's fed by the fill updates of the segment assignment.
+// The periodic sync of the segment about to be sealed is deferred, so its buffer is synced by a final large sync at seal
+// instead of many small ones.
+// It's written by the executing goroutine of the flusher and read by the write buffers, so it's protected by a lock.
+type segmentFillTracker struct {
+	mu    sync.Mutex
+	clock clock.Clock
+	fills map[int64]stats.SegmentFillUpdate // map[SegmentID]SegmentFillUpdate
+}
+
+// Observe records the fill updates, the older update of a segment is replaced.
+func (t *segmentFillTracker) Observe(updates []stats.SegmentFillUpdate) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, update := range updates {
+		t.fills[update.Belongs.SegmentID] = update
+	}
+}
+
+// Remove forgets the segment, it's called when the flush message of the segment is consumed.
+func (t *segmentFillTracker) Remove(segmentID int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.fills, segmentID)
+}
+
+// ShouldDeferSync returns whether the periodic sync of the segment should be deferred.
+// Only the segment that is still being filled is deferred, the update older than the sync period is expired,
+// so the buffer of the nearly full segment without new insert is still synced and the checkpoint keeps moving.
+func (t *segmentFillTracker) ShouldDeferSync(segmentID int64) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	update, ok := t.fills[segmentID]
+	if !ok {
+		return false
+	}
+	syncPeriod := paramtable.Get().DataNodeCfg.SyncPeriod.GetAsDuration(time.Second)
+	return t.clock.Since(update.UpdatedAt) < syncPeriod
+}
@@ -0,0 +1,60 @@
+package flusherimpl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestSegmentFillTracker(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.WALSegmentAssignFillNotifyThreshold.Key, "0.5")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignFillNotifyThreshold.Key)
+	params.Save(params.DataNodeCfg.SyncPeriod.Key, "10")
+	defer params.Reset(params.DataNodeCfg.SyncPeriod.Key)
+
+	c := clock.NewFakeClock(time.Now())
+	m := stats.NewStatsManager(c)
+	m.RegisterNewGrowingSegment(stats.SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: 3}, 3, &stats.SegmentStats{
+		MaxBinarySize:    1000,
+		CreateTime:       c.Now(),
+		LastModifiedTime: c.Now(),
+	})
+	s := m.FillNotifier().Subscribe("pchannel")
+	defer s.Close()
+
+	tracker := newSegmentFillTracker(c)
+	assert.False(t, tracker.ShouldDeferSync(3))
+
+	// the segment below the threshold is synced periodically.
+	assert.NoError(t, m.AllocRows(3, stats.InsertMetrics{Rows: 1, BinarySize: 400}))
+	tracker.Observe(s.Get())
+	assert.False(t, tracker.ShouldDeferSync(3))
+
+	// the nearly full segment is deferred after the fill update is consumed.
+	assert.NoError(t, m.AllocRows(3, stats.InsertMetrics{Rows: 1, BinarySize: 200}))
+	<-s.WaitChan()
+	tracker.Observe(s.Get())
+	assert.True(t, tracker.ShouldDeferSync(3))
+	assert.False(t, tracker.ShouldDeferSync(4))
+
+	// the deferring is expired if no more update comes within the sync period.
+	c.Advance(10 * time.Second)
+	assert.False(t, tracker.ShouldDeferSync(3))
+
+	// the new update defers the segment again.
+	assert.NoError(t, m.AllocRows(3, stats.InsertMetrics{Rows: 1, BinarySize: 100}))
+	<-s.WaitChan()
+	tracker.Observe(s.Get())
+	assert.True(t, tracker.ShouldDeferSync(3))
+
+	// the flushed segment is never deferred.
+	tracker.Remove(3)
+	assert.False(t, tracker.ShouldDeferSync(3))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/inspector"
 	"github.com/milvus-io/milvus/pkg/v2/log"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message/adaptor"
@@ -29,7 +30,9 @@ func RecoverWALFlusher(param *interceptors.InterceptorBuildParam) *WALFlusherImp
 		logger: resource.Resource().Logger().With(
 			log.FieldComponent("flusher"),
 			zap.String("pchannel", param.ChannelInfo.String())),
-		metrics: newFlusherMetrics(param.ChannelInfo),
+		metrics:       newFlusherMetrics(param.ChannelInfo),
+		segmentFill:   newSegmentFillTracker(resource.Resource().Clock()),
+		segmentEpochs: newSegmentEpochTracker(),
 	}
 	go flusher.Execute()
 	return flusher
@@ -41,6 +44,8 @@ type WALFlusherImpl struct {
 	flusherComponents *flusherComponents
 	logger            *log.MLogger
 	metrics           *flusherMetrics
+	segmentFill       *segmentFillTracker  // the latest fill of the nearly full growing segments of the pchannel.
+	segmentEpochs     *segmentEpochTracker // the assignment epochs of the growing segments of the pchannel.
 }
 
 // Execute starts the wal flusher.
@@ -72,6 +77,14 @@ func (impl *WALFlusherImpl) Execute() (err error) {
 	}
 	defer impl.flusherComponents.Close()
 
+	// pre-allocate the write buffer of the new growing segment before the first insert is consumed.
+	inspector.GetGrowingSegmentNotifier().Register(l.Channel().Name, impl.preallocateGrowingSegment)
+	defer inspector.GetGrowingSegmentNotifier().Unregister(l.Channel().Name)
+
+	// subscribe the fill updates of the growing segments, so the sync of the segment about to be sealed can be aggregated.
+	fillSubscriber := resource.Resource().SegmentAssignStatsManager().FillNotifier().Subscribe(l.Channel().Name)
+	defer fillSubscriber.Close()
+
 	scanner, err := impl.generateScanner(impl.notifier.Context(), impl.wal.Get(), checkpoint)
 	if err != nil {
 		return errors.Wrap(err, "when generate scanner")
@@ -86,6 +99,8 @@ func (impl *WALFlusherImpl) Execute() (err error) {
 		select {
 		case <-impl.notifier.Context().Done():
 			return nil
+		case <-fillSubscriber.WaitChan():
+			impl.segmentFill.Observe(fillSubscriber.Get())
 		case msg, ok := <-scanner.Chan():
 			if !ok {
 				impl.logger.Warn("wal flusher is closing for closed scanner channel, which is unexpected at graceful way")
@@ -107,6 +122,17 @@ func (impl *WALFlusherImpl) Close() {
 	impl.metrics.Close()
 }
 
+// preallocateGrowingSegment creates the write buffer of the new growing segment in advance.
+// The consume path of the create segment message is idempotent, so it's safe to be applied twice.
+func (impl *WALFlusherImpl) preallocateGrowingSegment(info inspector.GrowingSegmentInfo) {
+	if err := resource.Resource().WriteBufferManager().CreateNewGrowingSegment(
+		impl.notifier.Context(), info.VChannel, info.PartitionID, info.SegmentID, info.StorageVersion); err != nil {
+		// the write buffer of the vchannel may be not ready, the segment will be created by the consume path.
+		impl.logger.Debug("skip to pre-allocate the write buffer of growing segment",
+			zap.String("vchannel", info.VChannel), zap.Int64("segmentID", info.SegmentID), zap.Error(err))
+	}
+}
+
 // buildFlusherComponents builds the components of the flusher.
 func (impl *WALFlusherImpl) buildFlusherComponents(ctx context.Context, l wal.WAL) (*flusherComponents, message.MessageID, error) {
 	// Get all existed vchannels of the pchannel.
@@ -145,6 +171,7 @@ func (impl *WALFlusherImpl) buildFlusherComponents(ctx context.Context, l wal.WA
 		cpUpdater:    cpUpdater,
 		chunkManager: chunkManager,
 		dataServices: make(map[string]*dataSyncServiceWrapper),
+		segmentFill:  impl.segmentFill,
 		logger:       impl.logger,
 	}
 	impl.logger.Info("flusher components intiailizing done")
@@ -190,6 +217,25 @@ func (impl *WALFlusherImpl) dispatch(msg message.ImmutableMessage) error {
 		// defer to remove the data sync service from the components.
 		// TODO: Current drop collection message will be handled by the underlying data sync service.
 		defer impl.flusherComponents.WhenDropCollection(msg.VChannel())
+	case message.MessageTypeCreateSegment:
+		if createSegmentMsg, err := message.AsImmutableCreateSegmentMessageV2(msg); err == nil {
+			impl.segmentEpochs.Observe(createSegmentMsg.Header())
+		}
+	case message.MessageTypeFlush:
+		// the flushed segment is never filled or assigned anymore.
+		if flushMsg, err := message.AsImmutableFlushMessageV2(msg); err == nil {
+			impl.segmentFill.Remove(flushMsg.Header().GetSegmentId())
+			impl.segmentEpochs.Remove(flushMsg.Header().GetSegmentId())
+		}
+	case message.MessageTypeInsert, message.MessageTypeTxn:
+		// the rows of the corrupted segment assignment must not be buffered into the segment declared by it,
+		// the message is already persisted and can't be rejected, so it's quarantined and never buffered.
+		if err := impl.segmentEpochs.Verify(msg); err != nil {
+			impl.metrics.ObserveQuarantined(msg.MessageType())
+			impl.logger.Error("the message with corrupted segment assignment is quarantined, its rows are never buffered",
+				log.FieldMessage(msg), zap.Error(err))
+			return nil
+		}
 	}
 	return impl.flusherComponents.HandleMessage(impl.notifier.Context(), msg)
 }
@@ -59,9 +59,16 @@ func TestWALFlusher(t *testing.T) {
 			},
 		},
 	}, nil)
-	mixcoord.EXPECT().AllocSegment(mock.Anything, mock.Anything).Return(&datapb.AllocSegmentResponse{
-		Status: merr.Status(nil),
-	}, nil)
+	mixcoord.EXPECT().AllocSegment(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, asr *datapb.AllocSegmentRequest, co ...grpc.CallOption) (*datapb.AllocSegmentResponse, error) {
+		return &datapb.AllocSegmentResponse{
+			SegmentInfo: &datapb.SegmentInfo{
+				ID:           asr.GetSegmentId(),
+				CollectionID: asr.GetCollectionId(),
+				PartitionID:  asr.GetPartitionId(),
+			},
+			Status: merr.Success(),
+		}, nil
+	})
 	mixcoord.EXPECT().DropVirtualChannel(mock.Anything, mock.Anything).Return(&datapb.DropVirtualChannelResponse{
 		Status: merr.Status(nil),
 	}, nil)
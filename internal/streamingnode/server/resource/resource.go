@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"context"
 	"reflect"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -9,18 +10,38 @@ import (
 	"github.com/milvus-io/milvus/internal/flushcommon/writebuffer"
 	"github.com/milvus-io/milvus/internal/metastore"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/faultinject"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
 	tinspector "github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/timetick/inspector"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/vchantempstore"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/idalloc"
 	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
 	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
 	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
 )
 
+// SealedSegmentNotifier pushes the sealed segments to the coordinator.
+type SealedSegmentNotifier interface {
+	// NotifySealedSegment notifies the coordinator that the flush message of the segment is durably appended.
+	NotifySealedSegment(ctx context.Context, notification *streamingpb.SealedSegmentNotification) error
+}
+
+// SegmentStateView is the read-only view of the assignment state of the segments on current node.
+// It's used by the consume side (such as flusher) to know whether more data may still arrive to a segment.
+type SegmentStateView interface {
+	// SegmentState returns the assignment state of the segment,
+	// return false if the segment is not assigned on current node or already flushed.
+	SegmentState(segmentID int64) (streamingpb.SegmentAssignmentState, bool)
+}
+
+var _ SegmentStateView = (*stats.SegmentStateIndex)(nil)
+
 var r = &resourceImpl{
 	logger: log.With(log.FieldModule(typeutil.StreamingNodeRole)),
+	clock:  clock.NewSystemClock(),
 } // singleton resource instance
 
 // optResourceInit is the option to initialize the resource.
@@ -57,6 +78,21 @@ func OptStreamingNodeCatalog(catalog metastore.StreamingNodeCataLog) optResource
 	}
 }
 
+// OptClock provides the clock to the resource, the system clock is used by default.
+func OptClock(c clock.Clock) optResourceInit {
+	return func(r *resourceImpl) {
+		r.clock = c
+	}
+}
+
+// OptSealedSegmentNotifier provides the notifier to push the sealed segments to the coordinator directly.
+// The sealed segments are only learned by consuming the wal if it's not provided.
+func OptSealedSegmentNotifier(notifier SealedSegmentNotifier) optResourceInit {
+	return func(r *resourceImpl) {
+		r.sealedSegmentNotifier = notifier
+	}
+}
+
 // Apply initializes the singleton of resources.
 // Should be call when streaming node startup.
 func Apply(opts ...optResourceInit) {
@@ -67,16 +103,21 @@ func Apply(opts ...optResourceInit) {
 
 // Done finish all initialization of resources.
 func Done() {
-	r.segmentAssignStatsManager = stats.NewStatsManager()
+	r.segmentAssignStatsManager = stats.NewStatsManager(r.clock)
+	r.segmentStateIndex = stats.NewSegmentStateIndex()
+	r.flushPendingRegistry = stats.NewFlushPendingRegistry(r.clock)
 	r.timeTickInspector = tinspector.NewTimeTickSyncInspector()
 	r.syncMgr = syncmgr.NewSyncManager(r.chunkManager)
 	r.wbMgr = writebuffer.NewManager(r.syncMgr)
 	r.wbMgr.Start()
+	assertNotNil(r.Clock())
 	assertNotNil(r.ChunkManager())
 	assertNotNil(r.TSOAllocator())
 	assertNotNil(r.MixCoordClient())
 	assertNotNil(r.StreamingNodeCatalog())
 	assertNotNil(r.SegmentAssignStatsManager())
+	assertNotNil(r.SegmentStateIndex())
+	assertNotNil(r.FlushPendingRegistry())
 	assertNotNil(r.TimeTickInspector())
 	assertNotNil(r.SyncManager())
 	assertNotNil(r.WriteBufferManager())
@@ -97,6 +138,7 @@ func Resource() *resourceImpl {
 // All utility on it is concurrent-safe and singleton.
 type resourceImpl struct {
 	logger                    *log.MLogger
+	clock                     clock.Clock
 	timestampAllocator        idalloc.Allocator
 	idAllocator               idalloc.Allocator
 	etcdClient                *clientv3.Client
@@ -104,14 +146,23 @@ type resourceImpl struct {
 	mixCoordClient            *syncutil.Future[types.MixCoordClient]
 	streamingNodeCatalog      metastore.StreamingNodeCataLog
 	segmentAssignStatsManager *stats.StatsManager
+	segmentStateIndex         *stats.SegmentStateIndex
+	flushPendingRegistry      *stats.FlushPendingRegistry
 	timeTickInspector         tinspector.TimeTickSyncInspector
 	vchannelTempStorage       *vchantempstore.VChannelTempStorage
+	sealedSegmentNotifier     SealedSegmentNotifier
+	faultInjector             *faultinject.Registry // only registered by the chaos test, nil in production.
 
 	// TODO: Global flusher components, should be removed afteer flushering in wal refactoring.
 	syncMgr syncmgr.SyncManager
 	wbMgr   writebuffer.BufferManager
 }
 
+// Clock returns the clock of the streaming node.
+func (r *resourceImpl) Clock() clock.Clock {
+	return r.clock
+}
+
 // TSOAllocator returns the timestamp allocator to allocate timestamp.
 func (r *resourceImpl) TSOAllocator() idalloc.Allocator {
 	return r.timestampAllocator
@@ -157,6 +208,21 @@ func (r *resourceImpl) SegmentAssignStatsManager() *stats.StatsManager {
 	return r.segmentAssignStatsManager
 }
 
+// SegmentStateIndex returns the segment state index, it's written by the segment assignment.
+func (r *resourceImpl) SegmentStateIndex() *stats.SegmentStateIndex {
+	return r.segmentStateIndex
+}
+
+// SegmentStateView returns the read-only view of the segment state index.
+func (r *resourceImpl) SegmentStateView() SegmentStateView {
+	return r.segmentStateIndex
+}
+
+// FlushPendingRegistry returns the registry of the in-flight manual flushes of the collections on current node.
+func (r *resourceImpl) FlushPendingRegistry() *stats.FlushPendingRegistry {
+	return r.flushPendingRegistry
+}
+
 func (r *resourceImpl) TimeTickInspector() tinspector.TimeTickSyncInspector {
 	return r.timeTickInspector
 }
@@ -166,6 +232,17 @@ func (r *resourceImpl) VChannelTempStorage() *vchantempstore.VChannelTempStorage
 	return r.vchannelTempStorage
 }
 
+// SealedSegmentNotifier returns the notifier of the sealed segments, nil if the notification is not enabled.
+func (r *resourceImpl) SealedSegmentNotifier() SealedSegmentNotifier {
+	return r.sealedSegmentNotifier
+}
+
+// FaultInjector returns the registry of the fault injection hooks of the segment assignment,
+// the injection is a no-op in the production build.
+func (r *resourceImpl) FaultInjector() *faultinject.Registry {
+	return r.faultInjector
+}
+
 func (r *resourceImpl) Logger() *log.MLogger {
 	return r.logger
 }
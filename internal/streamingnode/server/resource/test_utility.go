@@ -8,6 +8,8 @@ import (
 
 	"github.com/milvus-io/milvus/internal/flushcommon/syncmgr"
 	"github.com/milvus-io/milvus/internal/flushcommon/writebuffer"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/faultinject"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
 	tinspector "github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/timetick/inspector"
 	"github.com/milvus-io/milvus/internal/types"
@@ -16,10 +18,18 @@ import (
 	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
 )
 
+// OptFaultInjector provides the registry of the fault injection hooks to the resource, only available in the test build.
+func OptFaultInjector(registry *faultinject.Registry) optResourceInit {
+	return func(r *resourceImpl) {
+		r.faultInjector = registry
+	}
+}
+
 // InitForTest initializes the singleton of resources for test.
-func InitForTest(t *testing.T, opts ...optResourceInit) {
+func InitForTest(t testing.TB, opts ...optResourceInit) {
 	r = &resourceImpl{
 		logger: log.With(),
+		clock:  clock.NewSystemClock(),
 	}
 	for _, opt := range opts {
 		opt(r)
@@ -38,6 +48,8 @@ func InitForTest(t *testing.T, opts ...optResourceInit) {
 		r.timestampAllocator = idalloc.NewTSOAllocator(r.mixCoordClient)
 		r.idAllocator = idalloc.NewIDAllocator(r.mixCoordClient)
 	}
-	r.segmentAssignStatsManager = stats.NewStatsManager()
+	r.segmentAssignStatsManager = stats.NewStatsManager(r.clock)
+	r.segmentStateIndex = stats.NewSegmentStateIndex()
+	r.flushPendingRegistry = stats.NewFlushPendingRegistry(r.clock)
 	r.timeTickInspector = tinspector.NewTimeTickSyncInspector()
 }
@@ -5,9 +5,12 @@ import (
 
 	"google.golang.org/grpc"
 
+	mhttp "github.com/milvus-io/milvus/internal/http"
 	"github.com/milvus-io/milvus/internal/streamingnode/client/handler/registry"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/service"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/redo"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/manager"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/walmanager"
 	"github.com/milvus-io/milvus/internal/util/initcore"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
@@ -75,6 +78,47 @@ func (s *Server) initService() {
 	s.handlerService = service.NewHandlerService(s.walManager)
 	s.managerService = service.NewManagerService(s.walManager)
 	s.registerGRPCService(s.grpcServer)
+	s.registerHTTPService()
+}
+
+// registerHTTPService register all debug http service to the node http server.
+func (s *Server) registerHTTPService() {
+	mhttp.Register(&mhttp.Handler{
+		Path:        mhttp.RouteStreamingNodeSegmentAssignment,
+		HandlerFunc: manager.ServeSegmentAssignmentSnapshot,
+	})
+	mhttp.Register(&mhttp.Handler{
+		Path:        mhttp.RouteStreamingNodeExplainAssignment,
+		HandlerFunc: manager.ServeExplainAssignment,
+	})
+	mhttp.Register(&mhttp.Handler{
+		Path:        mhttp.RouteStreamingNodeResetCircuitBreaker,
+		HandlerFunc: manager.ServeResetCollectionCircuitBreaker,
+	})
+	mhttp.Register(&mhttp.Handler{
+		Path:        mhttp.RouteStreamingNodeFlushOlderThan,
+		HandlerFunc: manager.ServeFlushSegmentsOlderThan,
+	})
+	mhttp.Register(&mhttp.Handler{
+		Path:        mhttp.RouteStreamingNodeRecoverySummary,
+		HandlerFunc: manager.ServeRecoverySummary,
+	})
+	mhttp.Register(&mhttp.Handler{
+		Path:        mhttp.RouteStreamingNodeSealHistory,
+		HandlerFunc: manager.ServeSealHistory,
+	})
+	mhttp.Register(&mhttp.Handler{
+		Path:        mhttp.RouteStreamingNodeSourceStats,
+		HandlerFunc: manager.ServeSourceStats,
+	})
+	mhttp.Register(&mhttp.Handler{
+		Path:        mhttp.RouteStreamingNodeResetSourceStats,
+		HandlerFunc: manager.ServeResetSourceStats,
+	})
+	mhttp.Register(&mhttp.Handler{
+		Path:        mhttp.RouteStreamingNodeRedoState,
+		HandlerFunc: redo.ServeRedoState,
+	})
 }
 
 // registerGRPCService register all grpc service to grpc server.
@@ -1,10 +1,14 @@
 package service
 
 import (
+	"context"
+
 	"github.com/milvus-io/milvus/internal/streamingnode/server/service/handler/consumer"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/service/handler/producer"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/manager"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/walmanager"
 	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
 )
 
 var _ HandlerService = (*handlerServiceImpl)(nil)
@@ -45,3 +49,12 @@ func (hs *handlerServiceImpl) Consume(streamServer streamingpb.StreamingNodeHand
 	}
 	return c.Execute()
 }
+
+// AssignPreview previews the segment assignment of an insert batch on this log node without appending it.
+func (hs *handlerServiceImpl) AssignPreview(ctx context.Context, req *streamingpb.AssignPreviewRequest) (*streamingpb.AssignPreviewResponse, error) {
+	// reject the preview if the channel is not available on this log node or the term is unmatched.
+	if _, err := hs.walManager.GetAvailableWAL(types.NewPChannelInfoFromProto(req.GetPchannel())); err != nil {
+		return nil, err
+	}
+	return manager.PreviewAssignOnPChannel(ctx, req)
+}
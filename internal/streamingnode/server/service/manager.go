@@ -3,9 +3,12 @@ package service
 import (
 	"context"
 
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/manager"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/walmanager"
 	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 )
 
 var _ ManagerService = (*managerServiceImpl)(nil)
@@ -51,7 +54,126 @@ func (ms *managerServiceImpl) Remove(ctx context.Context, req *streamingpb.Strea
 // CollectStatus collects the status of all wal instances in these streamingnode.
 func (ms *managerServiceImpl) CollectStatus(ctx context.Context, req *streamingpb.StreamingNodeManagerCollectStatusRequest) (*streamingpb.StreamingNodeManagerCollectStatusResponse, error) {
 	// TODO: collect traffic metric for load balance.
+	summary := resource.Resource().SegmentAssignStatsManager().GetTimeToSealSummary()
+	timeToSeal := make([]*streamingpb.CollectionTimeToSeal, 0, len(summary))
+	for collectionID, stat := range summary {
+		timeToSeal = append(timeToSeal, &streamingpb.CollectionTimeToSeal{
+			CollectionId:    collectionID,
+			P99Milliseconds: stat.P99.Milliseconds(),
+			SampleCount:     int64(stat.SampleCount),
+		})
+	}
+	rollups := resource.Resource().SegmentAssignStatsManager().GetVChannelGrowingStats()
+	vchannelGrowing := make([]*streamingpb.VChannelGrowingStats, 0, len(rollups))
+	for _, rollup := range rollups {
+		vchannelGrowing = append(vchannelGrowing, &streamingpb.VChannelGrowingStats{
+			Vchannel:     rollup.VChannel,
+			CollectionId: rollup.CollectionID,
+			SegmentCount: int64(rollup.SegmentCount),
+			Rows:         rollup.Insert.Rows,
+			BinarySize:   rollup.Insert.BinarySize,
+		})
+	}
+	// the upstream components consult the in-flight manual flushes to avoid interleaving the DDL with them.
+	flushes := resource.Resource().FlushPendingRegistry().List()
+	flushPending := make([]*streamingpb.CollectionFlushPending, 0, len(flushes))
+	for _, flush := range flushes {
+		flushPending = append(flushPending, &streamingpb.CollectionFlushPending{
+			CollectionId: flush.CollectionID,
+			Pchannel:     flush.PChannel,
+			FlushTs:      flush.FlushTs,
+		})
+	}
+	// the operators confirm the storage format of the live segments before a migration cutover.
+	versionCounts := resource.Resource().SegmentAssignStatsManager().GetStorageVersionStats()
+	storageVersions := make([]*streamingpb.PChannelStorageVersionStats, 0, len(versionCounts))
+	for _, count := range versionCounts {
+		storageVersions = append(storageVersions, &streamingpb.PChannelStorageVersionStats{
+			Pchannel:       count.PChannel,
+			StorageVersion: count.StorageVersion,
+			SegmentCount:   int64(count.SegmentCount),
+		})
+	}
+	// the coordinator never schedules the compaction involving the segments whose flush is still in flight.
+	unflushed := resource.Resource().SegmentStateIndex().ListSealedUnflushedSegments()
+	sealedUnflushed := make([]*streamingpb.PChannelSealedUnflushedSegments, 0, len(unflushed))
+	for _, segments := range unflushed {
+		sealedUnflushed = append(sealedUnflushed, &streamingpb.PChannelSealedUnflushedSegments{
+			Pchannel:   segments.PChannel,
+			SegmentIds: segments.SegmentIDs,
+		})
+	}
+	// the coordinator avoids placing more vchannels on the pchannel whose append path is saturated.
+	threshold := paramtable.Get().StreamingCfg.WALSegmentAssignSaturationThreshold.GetAsFloat()
+	pchannelSaturations := resource.Resource().SegmentAssignStatsManager().GetPChannelSaturations()
+	saturations := make([]*streamingpb.PChannelSaturation, 0, len(pchannelSaturations))
+	for pchannel, saturation := range pchannelSaturations {
+		saturations = append(saturations, &streamingpb.PChannelSaturation{
+			Pchannel:   pchannel,
+			Saturation: saturation,
+			Saturated:  threshold > 0 && saturation > threshold,
+		})
+	}
+	// the metadata tells the behavior differences of the segment assignment between the nodes of a mixed-version cluster.
+	assignmentMetadata := make([]*streamingpb.PChannelAssignmentMetadata, 0)
+	for _, metadata := range manager.ListAssignmentMetadata() {
+		assignmentMetadata = append(assignmentMetadata, metadata.IntoProto())
+	}
 	return &streamingpb.StreamingNodeManagerCollectStatusResponse{
-		BalanceAttributes: &streamingpb.StreamingNodeBalanceAttributes{},
+		BalanceAttributes: &streamingpb.StreamingNodeBalanceAttributes{
+			TimeToSeal:      timeToSeal,
+			VchannelGrowing: vchannelGrowing,
+			FlushPending:    flushPending,
+			StorageVersions: storageVersions,
+			SealedUnflushed: sealedUnflushed,
+			Saturations:     saturations,
+
+			AssignmentMetadata: assignmentMetadata,
+		},
 	}, nil
 }
+
+// SealSegments seals a batch of growing segments on this log node together, the outcome of every segment is returned.
+func (ms *managerServiceImpl) SealSegments(ctx context.Context, req *streamingpb.StreamingNodeManagerSealSegmentsRequest) (*streamingpb.StreamingNodeManagerSealSegmentsResponse, error) {
+	// reject the seal if the channel is not available on this log node or the term is unmatched.
+	if _, err := ms.walManager.GetAvailableWAL(types.NewPChannelInfoFromProto(req.GetPchannel())); err != nil {
+		return nil, err
+	}
+	return manager.MustSealSegmentsOnPChannel(ctx, req)
+}
+
+// ReloadCollectionPartitions re-fetches the partitions of a collection from the coordinator and merges them into the segment assignment on this log node.
+func (ms *managerServiceImpl) ReloadCollectionPartitions(ctx context.Context, req *streamingpb.StreamingNodeManagerReloadCollectionPartitionsRequest) (*streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse, error) {
+	// reject the reload if the channel is not available on this log node or the term is unmatched.
+	if _, err := ms.walManager.GetAvailableWAL(types.NewPChannelInfoFromProto(req.GetPchannel())); err != nil {
+		return nil, err
+	}
+	return manager.ReloadCollectionPartitionsOnPChannel(ctx, req)
+}
+
+// ForceAddPartition adds a partition unknown by the coordinator into the segment assignment on this log node.
+func (ms *managerServiceImpl) ForceAddPartition(ctx context.Context, req *streamingpb.StreamingNodeManagerForceAddPartitionRequest) (*streamingpb.StreamingNodeManagerForceAddPartitionResponse, error) {
+	// reject the force add if the channel is not available on this log node or the term is unmatched.
+	if _, err := ms.walManager.GetAvailableWAL(types.NewPChannelInfoFromProto(req.GetPchannel())); err != nil {
+		return nil, err
+	}
+	return manager.ForceAddPartitionOnPChannel(ctx, req)
+}
+
+// GetSealHistory gets the recent seal events of the segments on this log node.
+func (ms *managerServiceImpl) GetSealHistory(ctx context.Context, req *streamingpb.StreamingNodeManagerGetSealHistoryRequest) (*streamingpb.StreamingNodeManagerGetSealHistoryResponse, error) {
+	// reject the request if the channel is not available on this log node or the term is unmatched.
+	if _, err := ms.walManager.GetAvailableWAL(types.NewPChannelInfoFromProto(req.GetPchannel())); err != nil {
+		return nil, err
+	}
+	return manager.GetSealHistoryOnPChannel(ctx, req)
+}
+
+// FlushSegmentsOlderThan seals the growing segments of a collection on this log node whose assigned data are all not newer than the timetick.
+func (ms *managerServiceImpl) FlushSegmentsOlderThan(ctx context.Context, req *streamingpb.StreamingNodeManagerFlushSegmentsOlderThanRequest) (*streamingpb.StreamingNodeManagerFlushSegmentsOlderThanResponse, error) {
+	// reject the flush if the channel is not available on this log node or the term is unmatched.
+	if _, err := ms.walManager.GetAvailableWAL(types.NewPChannelInfoFromProto(req.GetPchannel())); err != nil {
+		return nil, err
+	}
+	return manager.FlushSegmentsOlderThanOnPChannel(ctx, req)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/timetick"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/timetick/mvcc"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/txn"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/wab"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls"
@@ -37,6 +38,8 @@ func buildInterceptorParams(ctx context.Context, underlyingWALImpls walimpls.WAL
 		msg,
 	)
 	mvccManager := mvcc.NewMVCCManager(msg.TimeTick())
+	// TODO: it's just a placeholder, should be replaced after recovery storage is merged.
+	txnManager := txn.NewTxnManager(underlyingWALImpls.Channel(), nil)
 	return &interceptors.InterceptorBuildParam{
 		ChannelInfo:          underlyingWALImpls.Channel(),
 		WAL:                  syncutil.NewFuture[wal.WAL](),
@@ -44,6 +47,7 @@ func buildInterceptorParams(ctx context.Context, underlyingWALImpls walimpls.WAL
 		InitializedMessageID: msg.MessageID(),
 		WriteAheadBuffer:     writeAheadBuffer,
 		MVCCManager:          mvccManager,
+		TxnManager:           txnManager,
 	}, nil
 }
 
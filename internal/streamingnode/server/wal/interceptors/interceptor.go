@@ -5,6 +5,7 @@ import (
 
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/timetick/mvcc"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/txn"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/wab"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
@@ -23,6 +24,7 @@ type InterceptorBuildParam struct {
 	InitializedMessageID message.MessageID         // The message id of the last message in the wal, can be used to skip the message id append.
 	WriteAheadBuffer     *wab.WriteAheadBuffer     // The write ahead buffer for the wal, used to erase the subscription of underlying wal.
 	MVCCManager          *mvcc.MVCCManager         // The MVCC manager for the wal, can be used to get the latest mvcc timetick.
+	TxnManager           *txn.TxnManager           // The txn manager for the wal, can be used to list the open txns.
 }
 
 // InterceptorBuilder is the interface to build a interceptor.
@@ -1,6 +1,13 @@
 package redo
 
-import "github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+import (
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
 
 // NewInterceptorBuilder creates a new redo interceptor builder.
 func NewInterceptorBuilder() interceptors.InterceptorBuilder {
@@ -12,5 +19,20 @@ type interceptorBuilder struct{}
 
 // Build creates a new redo interceptor.
 func (b *interceptorBuilder) Build(param *interceptors.InterceptorBuildParam) interceptors.Interceptor {
-	return &redoAppendInterceptor{}
+	nodeID := paramtable.GetStringNodeID()
+	channel := param.ChannelInfo.Name
+	registry := newRedoRegistry(
+		channel,
+		metrics.WALRedoPendingTotal.WithLabelValues(nodeID, channel),
+		metrics.WALRedoOldestAgeSeconds.WithLabelValues(nodeID, channel),
+	)
+	redoRegistries.Insert(channel, registry)
+	return &redoAppendInterceptor{
+		channel:      channel,
+		logger:       log.With(zap.String("pchannel", channel)),
+		serverRedo:   metrics.WALRedoTotal.WithLabelValues(nodeID, channel, redoOutcomeServer),
+		clientRetry:  metrics.WALRedoTotal.WithLabelValues(nodeID, channel, redoOutcomeClient),
+		registry:     registry,
+		metricLabels: map[string]string{metrics.NodeIDLabelName: nodeID, metrics.WALChannelLabelName: channel},
+	}
 }
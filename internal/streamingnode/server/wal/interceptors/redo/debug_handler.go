@@ -0,0 +1,39 @@
+package redo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/v2/log"
+)
+
+// ServeRedoState serves the state of the messages currently in redo of a pchannel as json.
+// Query params:
+//   - pchannel: required, the name of pchannel.
+func ServeRedoState(w http.ResponseWriter, req *http.Request) {
+	pchannel := req.URL.Query().Get("pchannel")
+	if pchannel == "" {
+		writeError(w, http.StatusBadRequest, "pchannel is required")
+		return
+	}
+	state, ok := GetRedoState(pchannel)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("redo state of pchannel %s not found", pchannel))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(state); err != nil {
+		log.Warn("failed to send the redo state response", zap.String("pchannel", pchannel), zap.Error(err))
+	}
+}
+
+// writeError writes the error message into response.
+func writeError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write([]byte(fmt.Sprintf(`{"msg": %q}`, msg)))
+}
@@ -2,11 +2,29 @@ package redo
 
 import (
 	"context"
+	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/utility"
+	"github.com/milvus-io/milvus/internal/util/streamingutil/status"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+const (
+	redoPolicyServer   = "server"
+	redoPolicyClient   = "client"
+	redoPolicyAdaptive = "adaptive"
+
+	redoOutcomeServer = "server"
+	redoOutcomeClient = "client"
 )
 
 var (
@@ -16,10 +34,34 @@ var (
 
 // redoAppendInterceptor is an append interceptor to retry the append operation if needed.
 // It's useful when the append operation want to refresh the append context (such as timetick belong to the message)
-type redoAppendInterceptor struct{}
+// When the wal is saturated, redo at server side amplifies the load,
+// so the redo may be converted into a retry later error and the client retries it with backoff.
+type redoAppendInterceptor struct {
+	channel      string
+	logger       *log.MLogger
+	inflight     atomic.Int64 // the inflight append operations of the wal, used as the pressure signal of the wal.
+	serverRedo   prometheus.Counter
+	clientRetry  prometheus.Counter
+	registry     *redoRegistry // the messages currently in redo.
+	metricLabels prometheus.Labels
+}
 
 // TODO: should be removed after lock-based before timetick is applied.
 func (r *redoAppendInterceptor) DoAppend(ctx context.Context, msg message.MutableMessage, append interceptors.Append) (msgID message.MessageID, err error) {
+	r.inflight.Inc()
+	defer r.inflight.Dec()
+
+	// the message is registered at its first redo, and removed when it leaves the redo,
+	// whether it's done, failed or converted into a retry at client side.
+	// the message that overflows the registry is never tracked.
+	var redoID int64
+	var redone, registered bool
+	defer func() {
+		if registered {
+			r.registry.Remove(redoID, time.Now())
+		}
+	}()
+
 	for {
 		if ctx.Err() != nil {
 			return nil, ctx.Err()
@@ -27,10 +69,57 @@ func (r *redoAppendInterceptor) DoAppend(ctx context.Context, msg message.Mutabl
 		msgID, err = append(ctx, msg)
 		// If the error is ErrRedo, we should redo the append operation.
 		if errors.Is(err, ErrRedo) {
+			if r.shouldRetryAtClient(msg) {
+				r.clientRetry.Inc()
+				return nil, status.NewRetryLater("wal %s is saturated, %s message should be retried later", r.channel, msg.MessageType())
+			}
+			r.serverRedo.Inc()
+			// the retry is the same logical message, it's only counted as a physical append by the metrics.
+			if !utility.ObserveAppendRedoAttempt(ctx) {
+				r.logger.Debug("redo is not marked by the interceptor", zap.Stringer("messageType", msg.MessageType()))
+			}
+			if !redone {
+				redone = true
+				redoID, registered = r.registry.Add(msg.MessageType(), time.Now())
+			} else if registered {
+				r.registry.Attempt(redoID, time.Now())
+			}
 			continue
 		}
 		return msgID, err
 	}
 }
 
-func (r *redoAppendInterceptor) Close() {}
+// shouldRetryAtClient checks if the redo should be converted into a retry at client side.
+func (r *redoAppendInterceptor) shouldRetryAtClient(msg message.MutableMessage) bool {
+	// The manual flush and prepare partition drop message accumulate the sealed segments into the append result across the redo,
+	// so they can only be redone at server side.
+	if msg.MessageType() == message.MessageTypeManualFlush || msg.MessageType() == message.MessageTypePreparePartitionDrop {
+		return false
+	}
+	policy := paramtable.Get().StreamingCfg.WALRedoPolicy.GetValue()
+	pressure := r.inflight.Load()
+	var retryAtClient bool
+	switch policy {
+	case redoPolicyClient:
+		retryAtClient = true
+	case redoPolicyAdaptive:
+		retryAtClient = pressure > paramtable.Get().StreamingCfg.WALRedoAdaptiveInflightThreshold.GetAsInt64()
+	default:
+		// redoPolicyServer, the unknown policy is treated as server too.
+		retryAtClient = false
+	}
+	r.logger.Debug("redo decision of append operation",
+		zap.String("policy", policy),
+		zap.Int64("inflight", pressure),
+		zap.Stringer("messageType", msg.MessageType()),
+		zap.Bool("retryAtClient", retryAtClient))
+	return retryAtClient
+}
+
+func (r *redoAppendInterceptor) Close() {
+	redoRegistries.Remove(r.channel)
+	metrics.WALRedoTotal.DeletePartialMatch(r.metricLabels)
+	metrics.WALRedoPendingTotal.DeletePartialMatch(r.metricLabels)
+	metrics.WALRedoOldestAgeSeconds.DeletePartialMatch(r.metricLabels)
+}
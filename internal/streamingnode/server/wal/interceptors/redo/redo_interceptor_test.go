@@ -0,0 +1,54 @@
+package redo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/utility"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestRedoAppendMetrics(t *testing.T) {
+	paramtable.Init()
+	channel := types.PChannelInfo{Name: "test-redo-append-metrics"}
+	interceptor := NewInterceptorBuilder().Build(&interceptors.InterceptorBuildParam{ChannelInfo: channel})
+	defer interceptor.Close()
+	wm := metricsutil.NewWriteMetrics(channel, "rocksmq")
+	defer wm.Close()
+
+	msg := message.NewManualFlushMessageBuilderV2().
+		WithVChannel("v1").
+		WithHeader(&message.ManualFlushMessageHeader{CollectionId: 1, FlushTs: 1}).
+		WithBody(&message.ManualFlushMessageBody{}).
+		MustBuildMutable()
+	am := wm.StartAppend(msg)
+	ctx := utility.WithAppendMetricsContext(context.Background(), am)
+
+	// the message is redone twice by the interceptor before it's appended.
+	attempts := 0
+	msgID, err := interceptor.DoAppend(ctx, msg, func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+		attempts++
+		if attempts <= 2 {
+			utility.MarkAppendRedo(ctx)
+			return nil, ErrRedo
+		}
+		return rmq.NewRmqID(1), nil
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, msgID)
+	assert.Equal(t, 3, am.PhysicalAttempts())
+	am.Done(&types.AppendResult{MessageID: msgID, TimeTick: 1}, nil)
+
+	labels := []string{paramtable.GetStringNodeID(), channel.Name, message.MessageTypeManualFlush.String(), metrics.WALStatusOK}
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.WALAppendMessageTotal.WithLabelValues(labels...)))
+	assert.Equal(t, float64(3), testutil.ToFloat64(metrics.WALAppendMessagePhysicalTotal.WithLabelValues(labels...)))
+}
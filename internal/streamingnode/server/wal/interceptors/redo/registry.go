@@ -0,0 +1,147 @@
+package redo
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+// redoRegistries keeps the redo registry of every pchannel which redo interceptor is alive.
+var redoRegistries = typeutil.NewConcurrentMap[string, *redoRegistry]()
+
+// RedoState is the state of the messages currently in redo of a pchannel.
+type RedoState struct {
+	PChannel  string        `json:"pchannel"`
+	Depth     int           `json:"depth"`
+	OldestAge time.Duration `json:"oldest_age"`
+	Overflow  uint64        `json:"overflow"` // the redo messages that are not tracked because the registry is full.
+	Entries   []RedoEntry   `json:"entries"`
+}
+
+// RedoEntry is a message currently in redo.
+type RedoEntry struct {
+	MessageType  string    `json:"message_type"`
+	FirstAttempt time.Time `json:"first_attempt"`
+	Attempts     int       `json:"attempts"`
+}
+
+// GetRedoState returns the state of the messages currently in redo of the pchannel.
+func GetRedoState(pchannel string) (RedoState, bool) {
+	r, ok := redoRegistries.Get(pchannel)
+	if !ok {
+		return RedoState{}, false
+	}
+	return r.State(), true
+}
+
+// redoRegistry tracks the messages currently in redo of a pchannel.
+// The registry is capped by streaming.walRedo.registryCapacity,
+// the redo message beyond the capacity is only counted as overflow, so a redo storm can't grow it unboundedly.
+type redoRegistry struct {
+	pchannel  string
+	depth     prometheus.Gauge
+	oldestAge prometheus.Gauge
+
+	mu       sync.Mutex
+	nextID   int64
+	entries  map[int64]*RedoEntry
+	overflow uint64
+}
+
+// newRedoRegistry creates a new redo registry of the pchannel.
+func newRedoRegistry(pchannel string, depth prometheus.Gauge, oldestAge prometheus.Gauge) *redoRegistry {
+	return &redoRegistry{
+		pchannel:  pchannel,
+		depth:     depth,
+		oldestAge: oldestAge,
+		entries:   make(map[int64]*RedoEntry),
+	}
+}
+
+// Add registers a message into redo, returns the id of the entry and false if the registry is full.
+func (r *redoRegistry) Add(msgType message.MessageType, now time.Time) (int64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.entries) >= paramtable.Get().StreamingCfg.WALRedoRegistryCapacity.GetAsInt() {
+		r.overflow++
+		return 0, false
+	}
+	r.nextID++
+	r.entries[r.nextID] = &RedoEntry{
+		MessageType:  msgType.String(),
+		FirstAttempt: now,
+		Attempts:     1,
+	}
+	r.updateMetrics(now)
+	return r.nextID, true
+}
+
+// Attempt records another redo attempt of the entry.
+func (r *redoRegistry) Attempt(id int64, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.entries[id]; ok {
+		entry.Attempts++
+	}
+	r.updateMetrics(now)
+}
+
+// Remove removes the entry from the registry, it's called when the message leaves the redo.
+func (r *redoRegistry) Remove(id int64, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.entries, id)
+	r.updateMetrics(now)
+}
+
+// State returns the state of the registry, the entries are ordered by the first attempt time.
+func (r *redoRegistry) State() RedoState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	entries := make([]RedoEntry, 0, len(r.entries))
+	for _, entry := range r.entries {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].FirstAttempt.Before(entries[j].FirstAttempt)
+	})
+	state := RedoState{
+		PChannel: r.pchannel,
+		Depth:    len(entries),
+		Overflow: r.overflow,
+		Entries:  entries,
+	}
+	if len(entries) > 0 {
+		state.OldestAge = now.Sub(entries[0].FirstAttempt)
+	}
+	return state
+}
+
+// updateMetrics updates the depth and the oldest age metrics, must be called with the lock held.
+// The oldest age is refreshed on every redo attempt, a message in redo is re-attempted right away,
+// so the metric is kept fresh as long as the redo is looping.
+func (r *redoRegistry) updateMetrics(now time.Time) {
+	var oldest time.Time
+	for _, entry := range r.entries {
+		if oldest.IsZero() || entry.FirstAttempt.Before(oldest) {
+			oldest = entry.FirstAttempt
+		}
+	}
+	r.depth.Set(float64(len(r.entries)))
+	if oldest.IsZero() {
+		r.oldestAge.Set(0)
+		return
+	}
+	r.oldestAge.Set(now.Sub(oldest).Seconds())
+}
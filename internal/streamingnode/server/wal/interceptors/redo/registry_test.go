@@ -0,0 +1,52 @@
+package redo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestRedoRegistry(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALRedoRegistryCapacity.Key, "2")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALRedoRegistryCapacity.Key)
+
+	r := newRedoRegistry("test-redo-registry", prometheus.NewGauge(prometheus.GaugeOpts{Name: "depth"}), prometheus.NewGauge(prometheus.GaugeOpts{Name: "age"}))
+	redoRegistries.Insert("test-redo-registry", r)
+	defer redoRegistries.Remove("test-redo-registry")
+
+	now := time.Now()
+	id1, ok := r.Add(message.MessageTypeTimeTick, now.Add(-time.Minute))
+	assert.True(t, ok)
+	id2, ok := r.Add(message.MessageTypeManualFlush, now)
+	assert.True(t, ok)
+	r.Attempt(id2, now)
+	r.Attempt(id2, now)
+
+	// the registry is capped, the message beyond the capacity is counted as overflow.
+	_, ok = r.Add(message.MessageTypeInsert, now)
+	assert.False(t, ok)
+
+	state, ok := GetRedoState("test-redo-registry")
+	assert.True(t, ok)
+	assert.Equal(t, 2, state.Depth)
+	assert.Equal(t, uint64(1), state.Overflow)
+	assert.GreaterOrEqual(t, state.OldestAge, time.Minute)
+	assert.Equal(t, message.MessageTypeTimeTick.String(), state.Entries[0].MessageType)
+	assert.Equal(t, 1, state.Entries[0].Attempts)
+	assert.Equal(t, 3, state.Entries[1].Attempts)
+
+	r.Remove(id1, now)
+	r.Remove(id2, now)
+	state = r.State()
+	assert.Equal(t, 0, state.Depth)
+	assert.Equal(t, time.Duration(0), state.OldestAge)
+
+	_, ok = GetRedoState("not-exist")
+	assert.False(t, ok)
+}
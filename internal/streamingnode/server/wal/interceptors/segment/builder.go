@@ -3,12 +3,15 @@ package segment
 import (
 	"context"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/manager"
 	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
 )
 
@@ -28,7 +31,24 @@ func (b *interceptorBuilder) Build(param *interceptors.InterceptorBuildParam) in
 			log.FieldComponent("segment-assigner"),
 			zap.Any("pchannel", param.ChannelInfo),
 		),
+		channel:       param.ChannelInfo,
 		assignManager: assignManager,
+		zeroRowsInsertTotal: metrics.WALSegmentAssignZeroRowsInsertTotal.WithLabelValues(
+			paramtable.GetStringNodeID(),
+			param.ChannelInfo.Name,
+		),
+		flushTimeTickRefreshTotal: metrics.WALSegmentFlushTimeTickRefreshTotal.WithLabelValues(
+			paramtable.GetStringNodeID(),
+			param.ChannelInfo.Name,
+		),
+		handlerPanicTotal: metrics.WALSegmentAssignHandlerPanicTotal.MustCurryWith(prometheus.Labels{
+			metrics.NodeIDLabelName:     paramtable.GetStringNodeID(),
+			metrics.WALChannelLabelName: param.ChannelInfo.Name,
+		}),
+		duplicatePartitionTotal: metrics.WALSegmentAssignDuplicatePartitionInsertTotal.MustCurryWith(prometheus.Labels{
+			metrics.NodeIDLabelName:     paramtable.GetStringNodeID(),
+			metrics.WALChannelLabelName: param.ChannelInfo.Name,
+		}),
 	}
 	go segmentInterceptor.recoverPChannelManager(param)
 	return segmentInterceptor
@@ -0,0 +1,56 @@
+package clock
+
+import (
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+)
+
+var _ Clock = systemClock{}
+
+// Clock is the time source of the segment assignment.
+// The local time is used to stamp the segment stats and make the time-based seal decision,
+// the tso is used to translate the timetick of messages into the physical time.
+// It's injected through the resource of streaming node, so the test can simulate the time without sleeping.
+type Clock interface {
+	// Now returns the current local time.
+	Now() time.Time
+
+	// Since returns the time elapsed since t.
+	Since(t time.Time) time.Duration
+
+	TSOSource
+}
+
+// TSOSource is the timestamp oracle source of the clock.
+type TSOSource interface {
+	// CurrentTSO returns a tso composed by the current time.
+	CurrentTSO() uint64
+
+	// PhysicalTime returns the physical time of the tso.
+	PhysicalTime(ts uint64) time.Time
+}
+
+// NewSystemClock returns the clock that backed by the system time and the global tso utility.
+func NewSystemClock() Clock {
+	return systemClock{}
+}
+
+// systemClock is the clock that backed by the system time.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (systemClock) CurrentTSO() uint64 {
+	return tsoutil.GetCurrentTime()
+}
+
+func (systemClock) PhysicalTime(ts uint64) time.Time {
+	return tsoutil.PhysicalTime(ts)
+}
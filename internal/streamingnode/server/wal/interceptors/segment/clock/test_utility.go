@@ -0,0 +1,58 @@
+//go:build test
+// +build test
+
+package clock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+)
+
+var _ Clock = (*FakeClock)(nil)
+
+// NewFakeClock creates a new fake clock started at the given time.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// FakeClock is the clock that only moves when it's advanced, used to simulate the time in test.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	logical int64
+}
+
+// Now returns the current time of the fake clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Since returns the time elapsed since t on the fake clock.
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// CurrentTSO returns a tso composed by the current time of the fake clock.
+// The logical part is never reset, so the returned tso is always increasing even if the clock is advanced less than a millisecond.
+func (c *FakeClock) CurrentTSO() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logical++
+	return tsoutil.ComposeTSByTime(c.now, c.logical)
+}
+
+// PhysicalTime returns the physical time of the tso.
+func (c *FakeClock) PhysicalTime(ts uint64) time.Time {
+	return tsoutil.PhysicalTime(ts)
+}
+
+// Advance moves the fake clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
@@ -0,0 +1,15 @@
+// Package faultinject provides the fault injection points at the key decision points of the segment assignment,
+// it's used by the chaos test to simulate the failure and crash windows without forking the code.
+// The hooks can only be registered in the test build, the injection is a no-op in the production build.
+package faultinject
+
+// Point is the fault injection point of the segment assignment.
+type Point string
+
+const (
+	PointCatalogWrite Point = "catalog_write" // before the segment assignment metas are saved into catalog.
+	PointAllocSegment Point = "alloc_segment" // before the growing segment is allocated at coordinator.
+	PointAck          Point = "ack"           // before the segment assign result is acked, the ack is dropped if the hook fails.
+	PointSealPersist  Point = "seal_persist"  // before the sealed state of the segment is persisted.
+	PointFence        Point = "fence"         // before the fence of the collection is installed by the manual flush.
+)
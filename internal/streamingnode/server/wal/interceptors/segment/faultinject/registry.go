@@ -0,0 +1,97 @@
+//go:build test
+// +build test
+
+package faultinject
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Hook is the fault injection hook of a point, the call is the 1-based count of the injections of the point.
+// The error returned by the hook is surfaced as the failure of the operation at the point.
+type Hook func(ctx context.Context, call int) error
+
+// NewRegistry creates a new empty registry of the fault injection hooks.
+func NewRegistry() *Registry {
+	return &Registry{
+		hooks: make(map[Point]Hook),
+		calls: make(map[Point]int),
+	}
+}
+
+// Registry is the registry of the fault injection hooks, it's registered into the resource by the test.
+type Registry struct {
+	mu    sync.Mutex
+	hooks map[Point]Hook
+	calls map[Point]int
+}
+
+// Register registers the hook of the point, the previous hook of the point is replaced.
+func (r *Registry) Register(point Point, hook Hook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks[point] = hook
+}
+
+// Unregister removes the hook of the point.
+func (r *Registry) Unregister(point Point) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.hooks, point)
+}
+
+// Calls returns the count of the injections of the point, the point without hook is also counted.
+func (r *Registry) Calls(point Point) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls[point]
+}
+
+// Inject runs the hook of the point, nil is returned if there's no registry or hook.
+func (r *Registry) Inject(ctx context.Context, point Point) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	r.calls[point]++
+	call := r.calls[point]
+	hook := r.hooks[point]
+	r.mu.Unlock()
+
+	// the hook is called without lock, so it can block (e.g. delay) without blocking other points.
+	if hook == nil {
+		return nil
+	}
+	return hook(ctx, call)
+}
+
+// FailAlways returns the hook that always fails with the error.
+func FailAlways(err error) Hook {
+	return func(ctx context.Context, call int) error {
+		return err
+	}
+}
+
+// FailOnNthCall returns the hook that only fails the nth call with the error.
+func FailOnNthCall(n int, err error) Hook {
+	return func(ctx context.Context, call int) error {
+		if call == n {
+			return err
+		}
+		return nil
+	}
+}
+
+// Delay returns the hook that delays every call, the context error is returned if the context is done before the delay.
+func Delay(d time.Duration) Hook {
+	return func(ctx context.Context, call int) error {
+		select {
+		case <-time.After(d):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
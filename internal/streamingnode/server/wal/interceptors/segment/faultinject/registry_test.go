@@ -0,0 +1,41 @@
+package faultinject
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	var nilRegistry *Registry
+	assert.NoError(t, nilRegistry.Inject(context.Background(), PointCatalogWrite))
+
+	r := NewRegistry()
+	ctx := context.Background()
+	assert.NoError(t, r.Inject(ctx, PointCatalogWrite))
+	assert.Equal(t, 1, r.Calls(PointCatalogWrite))
+
+	errInjected := errors.New("injected")
+	r.Register(PointCatalogWrite, FailOnNthCall(3, errInjected))
+	assert.NoError(t, r.Inject(ctx, PointCatalogWrite))
+	assert.ErrorIs(t, r.Inject(ctx, PointCatalogWrite), errInjected)
+	assert.NoError(t, r.Inject(ctx, PointCatalogWrite))
+	assert.Equal(t, 4, r.Calls(PointCatalogWrite))
+
+	r.Register(PointAck, FailAlways(errInjected))
+	assert.ErrorIs(t, r.Inject(ctx, PointAck), errInjected)
+	assert.ErrorIs(t, r.Inject(ctx, PointAck), errInjected)
+	r.Unregister(PointAck)
+	assert.NoError(t, r.Inject(ctx, PointAck))
+
+	// the delay is interrupted by the context.
+	r.Register(PointAllocSegment, Delay(5*time.Second))
+	ctx2, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, r.Inject(ctx2, PointAllocSegment), context.DeadlineExceeded)
+	r.Register(PointAllocSegment, Delay(time.Millisecond))
+	assert.NoError(t, r.Inject(ctx, PointAllocSegment))
+}
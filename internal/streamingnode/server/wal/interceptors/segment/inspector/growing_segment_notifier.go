@@ -0,0 +1,134 @@
+package inspector
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/v2/log"
+)
+
+const defaultGrowingSegmentNotifyQueueSize = 1024
+
+var (
+	growingSegmentNotifier     *GrowingSegmentNotifier
+	growingSegmentNotifierOnce sync.Once
+)
+
+// GetGrowingSegmentNotifier returns the global growing segment notifier.
+func GetGrowingSegmentNotifier() *GrowingSegmentNotifier {
+	growingSegmentNotifierOnce.Do(func() {
+		growingSegmentNotifier = NewGrowingSegmentNotifier()
+	})
+	return growingSegmentNotifier
+}
+
+// GrowingSegmentInfo is the identity and max size of the segment that is promoted to growing.
+type GrowingSegmentInfo struct {
+	PChannel       string
+	VChannel       string
+	CollectionID   int64
+	PartitionID    int64
+	SegmentID      int64
+	MaxBinarySize  uint64
+	StorageVersion int64
+}
+
+// GrowingSegmentHook is the consumer-side hook to be notified when a segment is promoted to growing,
+// e.g. the flusher can pre-allocate the write buffer of the segment before the first insert is consumed.
+// The hook is called in the background goroutine of the notifier, never on the segment assignment path.
+type GrowingSegmentHook func(info GrowingSegmentInfo)
+
+// NewGrowingSegmentNotifier creates a new growing segment notifier.
+func NewGrowingSegmentNotifier() *GrowingSegmentNotifier {
+	n := &GrowingSegmentNotifier{
+		hooks:    make(map[string]GrowingSegmentHook),
+		pending:  make(chan GrowingSegmentInfo, defaultGrowingSegmentNotifyQueueSize),
+		closed:   make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	go n.background()
+	return n
+}
+
+// GrowingSegmentNotifier dispatches the new growing segments to the registered hook of the pchannel.
+// The hook is optional, the notification is dropped if no hook is registered or the hook is too slow,
+// so the segment assignment is never blocked by the consumer side.
+type GrowingSegmentNotifier struct {
+	mu        sync.RWMutex
+	hooks     map[string]GrowingSegmentHook // pchannel -> hook
+	pending   chan GrowingSegmentInfo
+	closeOnce sync.Once
+	closed    chan struct{}
+	finished  chan struct{}
+}
+
+// Register registers the hook of the pchannel, the old one is replaced.
+func (n *GrowingSegmentNotifier) Register(pchannel string, hook GrowingSegmentHook) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.hooks[pchannel] = hook
+}
+
+// Unregister unregisters the hook of the pchannel.
+func (n *GrowingSegmentNotifier) Unregister(pchannel string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.hooks, pchannel)
+}
+
+// Notify notifies a new growing segment without blocking.
+func (n *GrowingSegmentNotifier) Notify(info GrowingSegmentInfo) {
+	if _, ok := n.getHook(info.PChannel); !ok {
+		return
+	}
+	select {
+	case n.pending <- info:
+	default:
+		log.Warn("growing segment hook is too slow, drop the notification",
+			zap.String("pchannel", info.PChannel),
+			zap.Int64("segmentID", info.SegmentID))
+	}
+}
+
+// Close closes the notifier, the pending notifications are dropped.
+func (n *GrowingSegmentNotifier) Close() {
+	n.closeOnce.Do(func() {
+		close(n.closed)
+	})
+	<-n.finished
+}
+
+// getHook returns the hook of the pchannel.
+func (n *GrowingSegmentNotifier) getHook(pchannel string) (GrowingSegmentHook, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	hook, ok := n.hooks[pchannel]
+	return hook, ok
+}
+
+// background calls the hooks with the pending notifications.
+func (n *GrowingSegmentNotifier) background() {
+	defer close(n.finished)
+	for {
+		select {
+		case <-n.closed:
+			return
+		case info := <-n.pending:
+			// the hook may be unregistered after the notification is pending.
+			if hook, ok := n.getHook(info.PChannel); ok {
+				n.call(hook, info)
+			}
+		}
+	}
+}
+
+// call calls the hook with panic capture, so one bad hook can not kill the notifier.
+func (n *GrowingSegmentNotifier) call(hook GrowingSegmentHook, info GrowingSegmentInfo) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("panic in growing segment hook", zap.String("pchannel", info.PChannel), zap.Int64("segmentID", info.SegmentID), zap.Any("panic", r))
+		}
+	}()
+	hook(info)
+}
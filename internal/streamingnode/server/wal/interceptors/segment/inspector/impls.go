@@ -2,13 +2,17 @@ package inspector
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
 	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
 	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
 	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
@@ -21,10 +25,17 @@ const (
 
 // NewSealedInspector creates a new seal inspector.
 func NewSealedInspector(n *stats.SealSignalNotifier) SealOperationInspector {
+	return newSealedInspector(n, defaultSealAllInterval)
+}
+
+// newSealedInspector creates a new seal inspector with the given interval of sealing all pchannels.
+func newSealedInspector(n *stats.SealSignalNotifier, sealAllInterval time.Duration) *sealOperationInspectorImpl {
 	s := &sealOperationInspectorImpl{
-		taskNotifier: syncutil.NewAsyncTaskNotifier[struct{}](),
-		managers:     typeutil.NewConcurrentMap[string, SealOperator](),
-		notifier:     n,
+		taskNotifier:    syncutil.NewAsyncTaskNotifier[struct{}](),
+		managers:        typeutil.NewConcurrentMap[string, SealOperator](),
+		workers:         make(map[string]*sealWorker),
+		sealAllInterval: sealAllInterval,
+		notifier:        n,
 		backOffTimer: typeutil.NewBackoffTimer(typeutil.BackoffTimerConfig{
 			Default: 1 * time.Second,
 			Backoff: typeutil.BackoffConfig{
@@ -34,6 +45,7 @@ func NewSealedInspector(n *stats.SealSignalNotifier) SealOperationInspector {
 			},
 		}),
 		triggerCh: make(chan string),
+		coalesced: metrics.WALSegmentSealTriggerCoalescedTotal.WithLabelValues(paramtable.GetStringNodeID()),
 		logger:    resource.Resource().Logger().With(log.FieldComponent("segment-assigner")),
 	}
 	go s.background()
@@ -44,11 +56,17 @@ func NewSealedInspector(n *stats.SealSignalNotifier) SealOperationInspector {
 type sealOperationInspectorImpl struct {
 	taskNotifier *syncutil.AsyncTaskNotifier[struct{}]
 
-	managers     *typeutil.ConcurrentMap[string, SealOperator]
-	notifier     *stats.SealSignalNotifier
-	backOffTimer *typeutil.BackoffTimer
-	triggerCh    chan string
-	logger       *log.MLogger
+	managers *typeutil.ConcurrentMap[string, SealOperator]
+	// workers executes the seal operations of every pchannel manager,
+	// the worker may be recreated by the watchdog if it's stalled.
+	workersMu       sync.Mutex
+	workers         map[string]*sealWorker
+	sealAllInterval time.Duration
+	notifier        *stats.SealSignalNotifier
+	backOffTimer    *typeutil.BackoffTimer
+	triggerCh       chan string
+	coalesced       prometheus.Counter
+	logger          *log.MLogger
 }
 
 // TriggerSealWaited implements SealInspector.TriggerSealWaited.
@@ -62,67 +80,144 @@ func (s *sealOperationInspectorImpl) TriggerSealWaited(ctx context.Context, pcha
 }
 
 // RegisterPChannelManager implements SealInspector.RegisterPChannelManager.
+// The registration is idempotent keyed by pchannel name, the first registered manager is kept.
 func (s *sealOperationInspectorImpl) RegisterPChannelManager(m SealOperator) {
-	_, loaded := s.managers.GetOrInsert(m.Channel().Name, m)
-	if loaded {
-		panic("pchannel manager already exists, critical bug in code")
+	existed, loaded := s.managers.GetOrInsert(m.Channel().Name, m)
+	if !loaded {
+		s.workersMu.Lock()
+		s.workers[m.Channel().Name] = newSealWorker(s.taskNotifier.Context(), m, s.logger)
+		s.workersMu.Unlock()
+		return
 	}
+	if existed == m {
+		s.logger.Info("pchannel manager already registered, ignore the registration", zap.String("pchannel", m.Channel().Name))
+		return
+	}
+	s.logger.Warn("another pchannel manager already registered on the pchannel, ignore the registration", zap.String("pchannel", m.Channel().Name))
 }
 
 // UnregisterPChannelManager implements SealInspector.UnregisterPChannelManager.
 func (s *sealOperationInspectorImpl) UnregisterPChannelManager(m SealOperator) {
 	_, loaded := s.managers.GetAndRemove(m.Channel().Name)
 	if !loaded {
-		panic("pchannel manager not found, critical bug in code")
+		s.logger.Info("pchannel manager not found, may be already unregistered", zap.String("pchannel", m.Channel().Name))
+		return
+	}
+	s.workersMu.Lock()
+	defer s.workersMu.Unlock()
+	if w, ok := s.workers[m.Channel().Name]; ok {
+		w.Stop()
+		delete(s.workers, m.Channel().Name)
 	}
 }
 
+// GetPChannelManager implements SealInspector.GetPChannelManager.
+func (s *sealOperationInspectorImpl) GetPChannelManager(pchannel string) (SealOperator, bool) {
+	return s.managers.Get(pchannel)
+}
+
 // Close implements SealInspector.Close.
 func (s *sealOperationInspectorImpl) Close() {
 	s.taskNotifier.Cancel()
 	s.taskNotifier.BlockUntilFinish()
+
+	// all workers are stopped by the cancellation of the inspector.
+	s.workersMu.Lock()
+	workers := lo.Values(s.workers)
+	s.workers = make(map[string]*sealWorker)
+	s.workersMu.Unlock()
+	for _, w := range workers {
+		<-w.Finished()
+	}
 }
 
 // background is the background task to inspect if a segment should be sealed or not.
 func (s *sealOperationInspectorImpl) background() {
 	defer s.taskNotifier.Finish(struct{}{})
 
-	sealAllTicker := time.NewTicker(defaultSealAllInterval)
+	sealAllTicker := time.NewTicker(s.sealAllInterval)
 	defer sealAllTicker.Stop()
 
+	watchdogTicker := time.NewTicker(s.sealAllInterval)
+	defer watchdogTicker.Stop()
+
 	mustSealTicker := time.NewTicker(defaultMustSealInterval)
 	defer mustSealTicker.Stop()
 
+	// the meta compaction is a low frequency maintenance, disabled if the interval is not positive.
+	var metaCompactionCh <-chan time.Time
+	if interval := paramtable.Get().StreamingCfg.WALSegmentAssignMetaCompactionInterval.GetAsDurationByParse(); interval > 0 {
+		metaCompactionTicker := time.NewTicker(interval)
+		defer metaCompactionTicker.Stop()
+		metaCompactionCh = metaCompactionTicker.C
+	}
+
+	// the top source nodes by segment assignments are logged periodically for abuse attribution.
+	var sourceStatsLogCh <-chan time.Time
+	if interval := paramtable.Get().StreamingCfg.WALSegmentAssignSourceStatsLogInterval.GetAsDurationByParse(); interval > 0 {
+		sourceStatsLogTicker := time.NewTicker(interval)
+		defer sourceStatsLogTicker.Stop()
+		sourceStatsLogCh = sourceStatsLogTicker.C
+	}
+
 	var backoffCh <-chan time.Time
+	var debounceCh <-chan time.Time
 	for {
-		if s.shouldEnableBackoff() {
-			// start a backoff if there's some pchannel wait for seal.
+		if len(s.wakeTargets()) > 0 {
+			// start a backoff if there's some pchannel wait for seal and the wake may make progress.
 			s.backOffTimer.EnableBackoff()
 			backoffCh, _ = s.backOffTimer.NextTimer()
 		} else {
 			s.backOffTimer.DisableBackoff()
 		}
+		// stop listening the seal signal until the debounce window is over,
+		// the signals arrived in the window are coalesced by the notifier.
+		var sealSignalCh <-chan struct{}
+		if debounceCh == nil {
+			sealSignalCh = s.notifier.WaitChan()
+		}
 
 		select {
 		case <-s.taskNotifier.Context().Done():
 			return
 		case pchannel := <-s.triggerCh:
-			if manager, ok := s.managers.Get(pchannel); ok {
-				manager.TryToSealWaitedSegment(s.taskNotifier.Context())
+			s.submit(pchannel, func(ctx context.Context, pm SealOperator) {
+				pm.TryToSealWaitedSegment(ctx)
+			})
+		case <-sealSignalCh:
+			debounce := paramtable.Get().StreamingCfg.WALSegmentAssignSyncSealDebounce.GetAsDurationByParse()
+			if debounce <= 0 {
+				s.tryToSealPartition(s.notifier.GetWithTriggers())
+				continue
 			}
-		case <-s.notifier.WaitChan():
-			s.tryToSealPartition(s.notifier.Get())
+			debounceCh = time.After(debounce)
+		case <-debounceCh:
+			debounceCh = nil
+			s.tryToSealPartition(s.notifier.GetWithTriggers())
 		case <-backoffCh:
-			// only seal waited segment for backoff.
-			s.managers.Range(func(_ string, pm SealOperator) bool {
-				pm.TryToSealWaitedSegment(s.taskNotifier.Context())
-				return true
-			})
+			// only seal waited segment for backoff, and only wake the pchannels that may make progress.
+			for _, pchannel := range s.wakeTargets() {
+				s.submit(pchannel, func(ctx context.Context, pm SealOperator) {
+					pm.TryToSealWaitedSegment(ctx)
+				})
+			}
 		case <-sealAllTicker.C:
-			s.managers.Range(func(_ string, pm SealOperator) bool {
-				pm.TryToSealSegments(s.taskNotifier.Context())
-				return true
+			s.submitAll(func(ctx context.Context, pm SealOperator) {
+				pm.TryToSealSegments(ctx)
+			})
+		case <-watchdogTicker.C:
+			s.recoverStalledWorkers()
+		case <-metaCompactionCh:
+			s.submitAll(func(ctx context.Context, pm SealOperator) {
+				if _, err := pm.CompactAssignmentMeta(ctx); err != nil {
+					s.logger.Warn("failed to compact segment assignment meta", zap.String("pchannel", pm.Channel().Name), zap.Error(err))
+				}
 			})
+		case <-sourceStatsLogCh:
+			topK := paramtable.Get().StreamingCfg.WALSegmentAssignSourceStatsTopK.GetAsInt()
+			if sources := resource.Resource().SegmentAssignStatsManager().GetTopSources(topK); len(sources) > 0 {
+				s.logger.Info("top sources of segment assignments", zap.Any("sources", sources))
+			}
 		case <-mustSealTicker.C:
 			threshold := paramtable.Get().DataCoordCfg.GrowingSegmentsMemSizeInMB.GetAsUint64() * 1024 * 1024
 			segmentBelongs := resource.Resource().SegmentAssignStatsManager().SealByTotalGrowingSegmentsSize(threshold)
@@ -132,34 +227,99 @@ func (s *sealOperationInspectorImpl) background() {
 			s.logger.Info("seal by total growing segments size", zap.String("vchannel", segmentBelongs.VChannel),
 				zap.Uint64("sealThreshold", threshold),
 				zap.Int64("sealSegment", segmentBelongs.SegmentID))
-			if pm, ok := s.managers.Get(segmentBelongs.PChannel); ok {
-				pm.MustSealSegments(s.taskNotifier.Context(), *segmentBelongs)
-			}
+			belongs := *segmentBelongs
+			s.submit(belongs.PChannel, func(ctx context.Context, pm SealOperator) {
+				pm.MustSealSegments(ctx, belongs)
+			})
 		}
 	}
 }
 
-// shouldEnableBackoff checks if the backoff should be enabled.
-// if there's any pchannel has a segment wait for seal, enable backoff.
-func (s *sealOperationInspectorImpl) shouldEnableBackoff() bool {
-	enableBackoff := false
-	s.managers.Range(func(_ string, pm SealOperator) bool {
-		if !pm.IsNoWaitSeal() {
-			enableBackoff = true
-			return false
+// wakeTargets returns the pchannels whose waiting segments may be sealed by a wake.
+// The pchannel whose waiting segments are only blocked by the open txns is skipped,
+// they're released by the txn done right away, so the backoff for them is useless.
+func (s *sealOperationInspectorImpl) wakeTargets() []string {
+	targets := make([]string, 0)
+	s.managers.Range(func(pchannel string, pm SealOperator) bool {
+		if pm.SealWaitState().IsWakeUseful() {
+			targets = append(targets, pchannel)
 		}
 		return true
 	})
-	return enableBackoff
+	return targets
 }
 
 // tryToSealPartition tries to seal the segment with the specified policies.
-func (s *sealOperationInspectorImpl) tryToSealPartition(infos typeutil.Set[stats.SegmentBelongs]) {
+// The infos are deduplicated by partition, so at most one sweep is applied on every pchannel.
+func (s *sealOperationInspectorImpl) tryToSealPartition(infos typeutil.Set[stats.SegmentBelongs], triggers int) {
+	partitions := make(map[string]map[partitionKey]stats.SegmentBelongs)
 	for info := range infos {
-		pm, ok := s.managers.Get(info.PChannel)
-		if !ok {
+		if _, ok := partitions[info.PChannel]; !ok {
+			partitions[info.PChannel] = make(map[partitionKey]stats.SegmentBelongs)
+		}
+		partitions[info.PChannel][partitionKey{collectionID: info.CollectionID, partitionID: info.PartitionID}] = info
+	}
+
+	sweeps := 0
+	for pchannel, belongs := range partitions {
+		sweeps += len(belongs)
+		infos := lo.Values(belongs)
+		s.submit(pchannel, func(ctx context.Context, pm SealOperator) {
+			pm.TryToSealSegments(ctx, infos...)
+		})
+	}
+	if triggers > sweeps {
+		s.coalesced.Add(float64(triggers - sweeps))
+	}
+}
+
+// submit submits the seal task to the worker of the pchannel.
+func (s *sealOperationInspectorImpl) submit(pchannel string, task sealTask) {
+	s.workersMu.Lock()
+	defer s.workersMu.Unlock()
+	if w, ok := s.workers[pchannel]; ok {
+		w.Submit(task)
+	}
+}
+
+// submitAll submits the seal task to the workers of all pchannels.
+func (s *sealOperationInspectorImpl) submitAll(task sealTask) {
+	s.workersMu.Lock()
+	defer s.workersMu.Unlock()
+	for _, w := range s.workers {
+		w.Submit(task)
+	}
+}
+
+// recoverStalledWorkers recreates the workers that doesn't complete any seal sweep within the stall intervals.
+// The stalled worker is stopped, and its running seal operation is notified by the context cancellation.
+func (s *sealOperationInspectorImpl) recoverStalledWorkers() {
+	stallIntervals := paramtable.Get().StreamingCfg.WALSegmentAssignWatchdogStallIntervals.GetAsInt()
+	if stallIntervals <= 0 {
+		return
+	}
+	threshold := time.Duration(stallIntervals) * s.sealAllInterval
+
+	s.workersMu.Lock()
+	defer s.workersMu.Unlock()
+	for pchannel, w := range s.workers {
+		lastSweep := w.LastSweep()
+		if time.Since(lastSweep) <= threshold {
 			continue
 		}
-		pm.TryToSealSegments(s.taskNotifier.Context(), info)
+		s.logger.Error("seal worker of pchannel is stalled, recreate it",
+			zap.String("pchannel", pchannel),
+			zap.Time("lastSweep", lastSweep),
+			zap.Duration("threshold", threshold))
+		metrics.WALSegmentSealWorkerFailureTotal.WithLabelValues(
+			paramtable.GetStringNodeID(), pchannel, sealWorkerFailureReasonStall).Inc()
+		w.Stop()
+		s.workers[pchannel] = newSealWorker(s.taskNotifier.Context(), w.operator, s.logger)
 	}
 }
+
+// partitionKey is the key to identify a partition.
+type partitionKey struct {
+	collectionID int64
+	partitionID  int64
+}
@@ -27,11 +27,16 @@ type SealOperationInspector interface {
 	TriggerSealWaited(ctx context.Context, pchannel string) error
 
 	// RegisterPChannelManager registers a pchannel manager.
+	// Register a pchannel manager more than once is a no-op.
 	RegisterPChannelManager(m SealOperator)
 
 	// UnregisterPChannelManager unregisters a pchannel manager.
+	// Unregister a not registered pchannel manager is a no-op.
 	UnregisterPChannelManager(m SealOperator)
 
+	// GetPChannelManager returns the registered pchannel manager of the given pchannel.
+	GetPChannelManager(pchannel string) (SealOperator, bool)
+
 	// Close closes the inspector.
 	Close()
 }
@@ -53,6 +58,10 @@ type SealOperator interface {
 	// MustSealSegments seals the given segments and waiting seal segments.
 	MustSealSegments(ctx context.Context, infos ...stats.SegmentBelongs)
 
-	// IsNoWaitSeal returns whether there's no segment wait for seal.
-	IsNoWaitSeal() bool
+	// SealWaitState returns the detailed state of the segments waiting for seal.
+	SealWaitState() stats.SealWaitState
+
+	// CompactAssignmentMeta removes the segment assignment metas of the dropped partitions from the catalog.
+	// Return the count of removed keys.
+	CompactAssignmentMeta(ctx context.Context) (int, error)
 }
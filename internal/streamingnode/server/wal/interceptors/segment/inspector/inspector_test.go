@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/atomic"
 
@@ -35,8 +36,11 @@ func TestSealedInspector(t *testing.T) {
 		RunAndReturn(func(ctx context.Context) {
 			ops.Add(1)
 		})
-	o.EXPECT().IsNoWaitSeal().RunAndReturn(func() bool {
-		return ops.Load()%2 == 0
+	o.EXPECT().SealWaitState().RunAndReturn(func() stats.SealWaitState {
+		if ops.Load()%2 == 0 {
+			return stats.SealWaitState{}
+		}
+		return stats.SealWaitState{Waiting: 1, Ready: 1}
 	})
 
 	inspector.RegisterPChannelManager(o)
@@ -67,3 +71,322 @@ func TestSealedInspector(t *testing.T) {
 	inspector.UnregisterPChannelManager(o)
 	inspector.Close()
 }
+
+func TestSealedInspectorCoalesceSyncTrigger(t *testing.T) {
+	paramtable.Init()
+	resource.InitForTest(t)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignSyncSealDebounce.Key, "100ms")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignSyncSealDebounce.Key)
+
+	notifier := stats.NewSealSignalNotifier()
+	inspector := NewSealedInspector(notifier)
+
+	o := mock_inspector.NewMockSealOperator(t)
+	sweeps := atomic.NewInt32(0)
+	o.EXPECT().Channel().Return(types.PChannelInfo{Name: "v1"})
+	o.EXPECT().TryToSealSegments(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, sb ...stats.SegmentBelongs) {
+			sweeps.Add(1)
+		})
+	o.EXPECT().SealWaitState().Return(stats.SealWaitState{}).Maybe()
+	inspector.RegisterPChannelManager(o)
+
+	for i := 0; i < 100; i++ {
+		notifier.AddAndNotify(stats.SegmentBelongs{
+			PChannel:     "v1",
+			VChannel:     "vv1",
+			CollectionID: 12,
+			PartitionID:  int64(i % 2),
+			SegmentID:    int64(i),
+		})
+	}
+	// all triggers in one debounce window should be coalesced into one sweep.
+	time.Sleep(300 * time.Millisecond)
+	if sweeps.Load() != 1 {
+		t.Errorf("expect only one sweep, but got %d", sweeps.Load())
+	}
+	inspector.UnregisterPChannelManager(o)
+	inspector.Close()
+}
+
+func TestSealedInspectorRegisterTwice(t *testing.T) {
+	paramtable.Init()
+	resource.InitForTest(t)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignSyncSealDebounce.Key, "0")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignSyncSealDebounce.Key)
+
+	notifier := stats.NewSealSignalNotifier()
+	inspector := NewSealedInspector(notifier)
+
+	o := mock_inspector.NewMockSealOperator(t)
+	sweeps := atomic.NewInt32(0)
+	o.EXPECT().Channel().Return(types.PChannelInfo{Name: "v1"})
+	o.EXPECT().TryToSealSegments(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, sb ...stats.SegmentBelongs) {
+			sweeps.Add(1)
+		})
+	o.EXPECT().SealWaitState().Return(stats.SealWaitState{}).Maybe()
+	inspector.RegisterPChannelManager(o)
+	inspector.RegisterPChannelManager(o)
+
+	// another manager on the same pchannel is ignored.
+	another := mock_inspector.NewMockSealOperator(t)
+	another.EXPECT().Channel().Return(types.PChannelInfo{Name: "v1"})
+	inspector.RegisterPChannelManager(another)
+	registered, ok := inspector.GetPChannelManager("v1")
+	if !ok || registered != o {
+		t.Errorf("expect the first registered manager is kept")
+	}
+
+	notifier.AddAndNotify(stats.SegmentBelongs{
+		PChannel:     "v1",
+		VChannel:     "vv1",
+		CollectionID: 12,
+		PartitionID:  1,
+		SegmentID:    1,
+	})
+	time.Sleep(100 * time.Millisecond)
+	if sweeps.Load() != 1 {
+		t.Errorf("expect only one sweep, but got %d", sweeps.Load())
+	}
+
+	inspector.UnregisterPChannelManager(o)
+	inspector.UnregisterPChannelManager(o)
+	if _, ok := inspector.GetPChannelManager("v1"); ok {
+		t.Errorf("expect the manager is unregistered")
+	}
+	inspector.Close()
+}
+
+func TestSealedInspectorRecoverPanickedSweep(t *testing.T) {
+	paramtable.Init()
+	resource.InitForTest(t)
+
+	notifier := stats.NewSealSignalNotifier()
+	inspector := newSealedInspector(notifier, 20*time.Millisecond)
+
+	o := mock_inspector.NewMockSealOperator(t)
+	sweeps := atomic.NewInt32(0)
+	o.EXPECT().Channel().Return(types.PChannelInfo{Name: "v1"})
+	o.EXPECT().TryToSealSegments(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, sb ...stats.SegmentBelongs) {
+			if sweeps.Inc() == 1 {
+				panic("panic in seal policy")
+			}
+		})
+	o.EXPECT().SealWaitState().Return(stats.SealWaitState{}).Maybe()
+	inspector.RegisterPChannelManager(o)
+
+	// the panicked sweep should not kill the seal loop, the following sweeps still run.
+	time.Sleep(200 * time.Millisecond)
+	if sweeps.Load() < 3 {
+		t.Errorf("expect the sweeps keep running after panic, but got %d", sweeps.Load())
+	}
+	inspector.UnregisterPChannelManager(o)
+	inspector.Close()
+}
+
+func TestSealedInspectorRecoverStalledWorker(t *testing.T) {
+	paramtable.Init()
+	resource.InitForTest(t)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignWatchdogStallIntervals.Key, "2")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignWatchdogStallIntervals.Key)
+
+	notifier := stats.NewSealSignalNotifier()
+	inspector := newSealedInspector(notifier, 20*time.Millisecond)
+
+	o := mock_inspector.NewMockSealOperator(t)
+	sweeps := atomic.NewInt32(0)
+	stalledCanceled := atomic.NewBool(false)
+	o.EXPECT().Channel().Return(types.PChannelInfo{Name: "v1"})
+	o.EXPECT().TryToSealSegments(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, sb ...stats.SegmentBelongs) {
+			if sweeps.Inc() == 1 {
+				// stall until the worker is recreated by the watchdog.
+				<-ctx.Done()
+				stalledCanceled.Store(true)
+			}
+		})
+	o.EXPECT().SealWaitState().Return(stats.SealWaitState{}).Maybe()
+	inspector.RegisterPChannelManager(o)
+	inspector.workersMu.Lock()
+	stalled := inspector.workers["v1"]
+	inspector.workersMu.Unlock()
+
+	time.Sleep(300 * time.Millisecond)
+	if !stalledCanceled.Load() {
+		t.Errorf("expect the stalled sweep is canceled by the watchdog")
+	}
+	inspector.workersMu.Lock()
+	recreated := inspector.workers["v1"]
+	inspector.workersMu.Unlock()
+	if recreated == stalled {
+		t.Errorf("expect the stalled worker is recreated")
+	}
+	if sweeps.Load() < 3 {
+		t.Errorf("expect the sweeps keep running after the worker is recreated, but got %d", sweeps.Load())
+	}
+	inspector.UnregisterPChannelManager(o)
+	inspector.Close()
+}
+
+func TestSealedInspectorCompactAssignmentMeta(t *testing.T) {
+	paramtable.Init()
+	resource.InitForTest(t)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignMetaCompactionInterval.Key, "50ms")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignMetaCompactionInterval.Key)
+
+	notifier := stats.NewSealSignalNotifier()
+	inspector := newSealedInspector(notifier, time.Hour)
+
+	o := mock_inspector.NewMockSealOperator(t)
+	compactions := atomic.NewInt32(0)
+	o.EXPECT().Channel().Return(types.PChannelInfo{Name: "v1"})
+	o.EXPECT().CompactAssignmentMeta(mock.Anything).RunAndReturn(func(ctx context.Context) (int, error) {
+		if compactions.Inc() == 1 {
+			return 0, errors.New("compaction failed")
+		}
+		return 1, nil
+	})
+	o.EXPECT().SealWaitState().Return(stats.SealWaitState{}).Maybe()
+	inspector.RegisterPChannelManager(o)
+
+	// the failed compaction is retried at next interval.
+	time.Sleep(300 * time.Millisecond)
+	if compactions.Load() < 2 {
+		t.Errorf("expect the compaction keep running, but got %d", compactions.Load())
+	}
+	inspector.UnregisterPChannelManager(o)
+	inspector.Close()
+}
+
+func TestSealedInspectorWakeOnlyUseful(t *testing.T) {
+	paramtable.Init()
+	resource.InitForTest(t)
+
+	notifier := stats.NewSealSignalNotifier()
+	inspector := newSealedInspector(notifier, time.Hour)
+
+	// the waiting segments of v1 are only blocked by the open txns, the wake is useless.
+	txnBlocked := mock_inspector.NewMockSealOperator(t)
+	txnBlocked.EXPECT().Channel().Return(types.PChannelInfo{Name: "v1"})
+	txnBlocked.EXPECT().SealWaitState().Return(stats.SealWaitState{
+		Waiting: 1,
+		Reasons: map[stats.SealWaitReason]int{stats.SealWaitReasonOpenTxns: 1},
+	}).Maybe()
+
+	// the waiting segments of v2 are blocked by the unacked assignments, they're observed by the wake.
+	ackBlocked := mock_inspector.NewMockSealOperator(t)
+	wakes := atomic.NewInt32(0)
+	ackBlocked.EXPECT().Channel().Return(types.PChannelInfo{Name: "v2"})
+	ackBlocked.EXPECT().SealWaitState().Return(stats.SealWaitState{
+		Waiting: 1,
+		Reasons: map[stats.SealWaitReason]int{stats.SealWaitReasonUnackedAssignments: 1},
+	}).Maybe()
+	ackBlocked.EXPECT().TryToSealWaitedSegment(mock.Anything).RunAndReturn(func(ctx context.Context) {
+		wakes.Inc()
+	})
+
+	inspector.RegisterPChannelManager(txnBlocked)
+	inspector.RegisterPChannelManager(ackBlocked)
+	time.Sleep(300 * time.Millisecond)
+	if wakes.Load() == 0 {
+		t.Errorf("expect the pchannel blocked by unacked assignments is waked")
+	}
+	inspector.UnregisterPChannelManager(txnBlocked)
+	inspector.UnregisterPChannelManager(ackBlocked)
+	inspector.Close()
+}
+
+func TestGrowingSegmentNotifier(t *testing.T) {
+	n := NewGrowingSegmentNotifier()
+	defer n.Close()
+
+	// no hook registered, the notification is dropped.
+	n.Notify(GrowingSegmentInfo{PChannel: "v1", SegmentID: 1})
+
+	received := make(chan GrowingSegmentInfo, 10)
+	n.Register("v1", func(info GrowingSegmentInfo) {
+		if info.SegmentID == 2 {
+			panic("panic in hook")
+		}
+		received <- info
+	})
+	n.Notify(GrowingSegmentInfo{PChannel: "v2", SegmentID: 1})
+	n.Notify(GrowingSegmentInfo{PChannel: "v1", SegmentID: 2})
+	n.Notify(GrowingSegmentInfo{PChannel: "v1", SegmentID: 3, MaxBinarySize: 1024})
+	select {
+	case info := <-received:
+		if info.SegmentID != 3 || info.MaxBinarySize != 1024 {
+			t.Errorf("unexpected growing segment info %+v", info)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("expect the hook is called")
+	}
+
+	// the notify is never blocked by a slow hook.
+	block := make(chan struct{})
+	n.Register("v1", func(info GrowingSegmentInfo) {
+		<-block
+	})
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*defaultGrowingSegmentNotifyQueueSize; i++ {
+			n.Notify(GrowingSegmentInfo{PChannel: "v1", SegmentID: int64(i)})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("expect the notify is not blocked")
+	}
+	n.Unregister("v1")
+	close(block)
+}
+
+// BenchmarkSealedInspectorSyncTrigger benchmarks the sweeps triggered by 10k sync operations per second.
+func BenchmarkSealedInspectorSyncTrigger(b *testing.B) {
+	paramtable.Init()
+	resource.InitForTest(b)
+
+	for _, debounce := range []string{"0", "50ms"} {
+		b.Run("debounce_"+debounce, func(b *testing.B) {
+			paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignSyncSealDebounce.Key, debounce)
+			defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignSyncSealDebounce.Key)
+
+			notifier := stats.NewSealSignalNotifier()
+			inspector := NewSealedInspector(notifier)
+			o := mock_inspector.NewMockSealOperator(b)
+			sweeps := atomic.NewInt32(0)
+			o.EXPECT().Channel().Return(types.PChannelInfo{Name: "v1"})
+			o.EXPECT().TryToSealSegments(mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, sb ...stats.SegmentBelongs) {
+					// simulate the cost of a full sweep.
+					sweeps.Add(1)
+					time.Sleep(100 * time.Microsecond)
+				}).Maybe()
+			o.EXPECT().SealWaitState().Return(stats.SealWaitState{}).Maybe()
+			inspector.RegisterPChannelManager(o)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				notifier.AddAndNotify(stats.SegmentBelongs{
+					PChannel:     "v1",
+					VChannel:     "vv1",
+					CollectionID: int64(i % 10),
+					PartitionID:  1,
+					SegmentID:    int64(i),
+				})
+				// about 10k sync operations per second.
+				if i%10 == 9 {
+					time.Sleep(time.Millisecond)
+				}
+			}
+			b.StopTimer()
+			b.ReportMetric(float64(sweeps.Load())/float64(b.N), "sweeps/op")
+			inspector.UnregisterPChannelManager(o)
+			inspector.Close()
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package inspector
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+const (
+	defaultSealWorkerQueueSize = 16
+
+	sealWorkerFailureReasonPanic = "panic"
+	sealWorkerFailureReasonStall = "stall"
+)
+
+// sealTask is a seal operation applied on the pchannel manager.
+type sealTask func(ctx context.Context, pm SealOperator)
+
+// newSealWorker creates a new seal worker of the pchannel manager and starts it.
+func newSealWorker(parent context.Context, pm SealOperator, logger *log.MLogger) *sealWorker {
+	ctx, cancel := context.WithCancel(parent)
+	w := &sealWorker{
+		ctx:       ctx,
+		cancel:    cancel,
+		finished:  make(chan struct{}),
+		operator:  pm,
+		tasks:     make(chan sealTask, defaultSealWorkerQueueSize),
+		lastSweep: atomic.NewTime(time.Now()),
+		logger:    logger.With(zap.String("pchannel", pm.Channel().Name)),
+	}
+	go w.background()
+	return w
+}
+
+// sealWorker executes the seal operations of one pchannel manager in its own goroutine,
+// so a stalled or panicked pchannel manager can not block the seal operations of other pchannels.
+type sealWorker struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	finished  chan struct{}
+	operator  SealOperator
+	tasks     chan sealTask
+	lastSweep *atomic.Time // the last time that a seal task is completed without panic.
+	logger    *log.MLogger
+}
+
+// Submit submits a seal task into the worker.
+// The task is dropped if the worker is too busy, the following periodic sweep will redo it.
+func (w *sealWorker) Submit(task sealTask) {
+	select {
+	case w.tasks <- task:
+	default:
+		w.logger.Warn("seal worker is too busy, drop the seal task")
+	}
+}
+
+// LastSweep returns the last time that a seal task is completed.
+func (w *sealWorker) LastSweep() time.Time {
+	return w.lastSweep.Load()
+}
+
+// Stop stops the worker without waiting for the running task.
+func (w *sealWorker) Stop() {
+	w.cancel()
+}
+
+// Finished returns a channel that is closed when the worker goroutine exits.
+func (w *sealWorker) Finished() <-chan struct{} {
+	return w.finished
+}
+
+// background executes the seal tasks until the worker is stopped.
+func (w *sealWorker) background() {
+	defer close(w.finished)
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case task := <-w.tasks:
+			if w.execute(task) {
+				w.lastSweep.Store(time.Now())
+			}
+		}
+	}
+}
+
+// execute executes the seal task with panic capture,
+// so one bad seal policy can not kill the seal loop permanently.
+func (w *sealWorker) execute(task sealTask) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+			w.logger.Error("panic in seal sweep, the seal task is skipped", zap.Any("panic", r), zap.Stack("stack"))
+			metrics.WALSegmentSealWorkerFailureTotal.WithLabelValues(
+				paramtable.GetStringNodeID(), w.operator.Channel().Name, sealWorkerFailureReasonPanic).Inc()
+		}
+	}()
+	task(w.ctx, w.operator)
+	return true
+}
@@ -0,0 +1,64 @@
+package manager
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/v2/log"
+)
+
+const (
+	ackMisuseDoubleAck     = "double_ack"
+	ackMisuseAckAfterClose = "ack_after_close"
+)
+
+// takeAck takes the only ack of the result, returns false if the ack is misused.
+// The repeated ack decreases the ack semaphore twice, so the segment may be sealed while another assignment is still flying,
+// and the ack after the segment assignment is closed is applied on a segment that is not managed anymore,
+// so both of them are detected and never applied.
+func (r *AssignSegmentResult) takeAck() bool {
+	if !r.acked.CompareAndSwap(false, true) {
+		r.reportAckMisuse(ackMisuseDoubleAck)
+		return false
+	}
+	if r.closed != nil && r.closed.Load() {
+		r.reportAckMisuse(ackMisuseAckAfterClose)
+		return false
+	}
+	return true
+}
+
+// reportAckMisuse reports the misused ack with the call site of it,
+// it panics in the test or race detector build, so the misuse is caught by the tests.
+func (r *AssignSegmentResult) reportAckMisuse(misuse string) {
+	callSite := ackCallSite()
+	log.Error("misused ack of segment assign result is ignored",
+		zap.String("misuse", misuse),
+		zap.Int64("segmentID", r.SegmentID),
+		zap.String("callSite", callSite))
+	if r.metrics != nil {
+		r.metrics.ObserveAckMisuse(misuse)
+	}
+	if panicOnAckMisuse {
+		panic(fmt.Sprintf("misused ack of segment assign result, misuse: %s, segmentID: %d, callSite: %s", misuse, r.SegmentID, callSite))
+	}
+}
+
+// ackCallSite returns the first caller outside the ack methods of the segment assign result.
+func ackCallSite() string {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.Function, "(*AssignSegmentResult)") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
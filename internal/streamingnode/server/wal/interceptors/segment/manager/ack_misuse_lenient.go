@@ -0,0 +1,6 @@
+//go:build !test && !race
+
+package manager
+
+// panicOnAckMisuse is disabled in the production build, the misused ack is only logged and counted.
+const panicOnAckMisuse = false
@@ -0,0 +1,6 @@
+//go:build test || race
+
+package manager
+
+// panicOnAckMisuse is enabled in the test or race detector build, so the misused ack is caught by the tests.
+const panicOnAckMisuse = true
@@ -0,0 +1,131 @@
+package manager
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+)
+
+// newAckMisuseTestManager recovers the manager of the test state, and returns a function to assign an insert on the segment 6000.
+func newAckMisuseTestManager(t *testing.T, pchannel string) (*PChannelSegmentAllocManager, func() *AssignSegmentResult) {
+	initializeTestState(t)
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  2,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: pchannel}, f)
+	assert.NoError(t, err)
+	return m, func() *AssignSegmentResult {
+		result, err := m.AssignSegment(context.Background(), &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       1,
+				BinarySize: 1,
+			},
+			TimeTick: tsoutil.GetCurrentTime(),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6000), result.SegmentID)
+		return result
+	}
+}
+
+// assertAckMisuse asserts the ack is detected as a misuse, it panics only in the test or race detector build.
+func assertAckMisuse(t *testing.T, ack func()) {
+	if panicOnAckMisuse {
+		assert.Panics(t, ack)
+	} else {
+		assert.NotPanics(t, ack)
+	}
+}
+
+func ackMisuseTotal(pchannel string, misuse string) float64 {
+	return testutil.ToFloat64(metrics.WALSegmentAssignAckMisuseTotal.WithLabelValues(paramtable.GetStringNodeID(), pchannel, misuse))
+}
+
+func TestAckMisuseDoubleAck(t *testing.T) {
+	m, assign := newAckMisuseTestManager(t, "v_double_ack")
+	defer m.Close(context.Background())
+
+	first := assign()
+	second := assign()
+	assert.Equal(t, int32(2), first.Acknowledge.Load())
+
+	// the repeated ack never decreases the ack semaphore again.
+	first.Ack()
+	assert.Equal(t, int32(1), first.Acknowledge.Load())
+	assertAckMisuse(t, first.Ack)
+	assertAckMisuse(t, func() { first.AckWithMetrics(stats.InsertMetrics{}) })
+	assertAckMisuse(t, first.Rollback)
+	assert.Equal(t, int32(1), first.Acknowledge.Load())
+	assert.Equal(t, float64(3), ackMisuseTotal("v_double_ack", ackMisuseDoubleAck))
+
+	// the reservation is never released twice by the repeated ack with metrics.
+	second.Rollback()
+	assert.Equal(t, int32(0), second.Acknowledge.Load())
+	inserted := resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(6000).Insert
+	assertAckMisuse(t, second.Rollback)
+	assert.Equal(t, inserted, resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(6000).Insert)
+}
+
+func TestAckMisuseAckAfterClose(t *testing.T) {
+	m, assign := newAckMisuseTestManager(t, "v_ack_after_close")
+	result := assign()
+	m.Close(context.Background())
+
+	assertAckMisuse(t, result.Ack)
+	assert.Equal(t, int32(1), result.Acknowledge.Load())
+	assert.Equal(t, float64(1), ackMisuseTotal("v_ack_after_close", ackMisuseAckAfterClose))
+}
+
+func TestAckMisuseConcurrentDoubleAck(t *testing.T) {
+	m, assign := newAckMisuseTestManager(t, "v_concurrent_double_ack")
+	defer m.Close(context.Background())
+	result := assign()
+
+	panics := atomic.NewInt32(0)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if recover() != nil {
+					panics.Inc()
+				}
+			}()
+			result.Ack()
+		}()
+	}
+	wg.Wait()
+
+	// only one of the concurrent acks is applied.
+	assert.Equal(t, int32(0), result.Acknowledge.Load())
+	assert.Equal(t, float64(9), ackMisuseTotal("v_concurrent_double_ack", ackMisuseDoubleAck))
+	if panicOnAckMisuse {
+		assert.Equal(t, int32(9), panics.Load())
+	} else {
+		assert.Zero(t, panics.Load())
+	}
+}
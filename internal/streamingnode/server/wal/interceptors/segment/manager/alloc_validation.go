@@ -0,0 +1,70 @@
+package manager
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
+)
+
+const (
+	allocMismatchMissingSegmentInfo = "missing_segment_info"
+	allocMismatchZeroSegmentID      = "zero_segment_id"
+	allocMismatchDuplicateSegmentID = "duplicate_segment_id"
+	allocMismatchSegmentID          = "segment_id"
+	allocMismatchCollectionID       = "collection_id"
+	allocMismatchPartitionID        = "partition_id"
+
+	// the alloc segment is retried once if the response of coordinator mismatches the request.
+	maxAllocSegmentAttempts = 2
+)
+
+var ErrAllocSegmentMismatch = errors.New("alloc segment response mismatch")
+
+var _ error = (*AllocSegmentMismatchError)(nil)
+
+// AllocSegmentMismatchError is the error returned when the alloc segment response of coordinator mismatches the request.
+// It can be matched by ErrAllocSegmentMismatch.
+type AllocSegmentMismatchError struct {
+	Reason   string // the reason of the mismatch, also used as the metric label.
+	Expected int64  // the id expected by the request.
+	Actual   int64  // the id returned by the coordinator.
+}
+
+// Error implements error interface.
+func (e *AllocSegmentMismatchError) Error() string {
+	return fmt.Sprintf("%s, reason: %s, expected: %d, actual: %d", ErrAllocSegmentMismatch.Error(), e.Reason, e.Expected, e.Actual)
+}
+
+// Is makes the error can be matched by ErrAllocSegmentMismatch.
+func (e *AllocSegmentMismatchError) Is(target error) bool {
+	return target == ErrAllocSegmentMismatch
+}
+
+// validateAllocSegmentResponse validates the segment info returned by the coordinator against the request,
+// so a confused coordinator never makes the segment registered under the wrong partition.
+// isKnownSegment checks if the segment id is already known by the manager.
+func validateAllocSegmentResponse(req *datapb.AllocSegmentRequest, resp *datapb.AllocSegmentResponse, isKnownSegment func(segmentID int64) bool) error {
+	info := resp.GetSegmentInfo()
+	if info == nil {
+		return &AllocSegmentMismatchError{Reason: allocMismatchMissingSegmentInfo, Expected: req.GetSegmentId()}
+	}
+	if info.GetID() == 0 {
+		return &AllocSegmentMismatchError{Reason: allocMismatchZeroSegmentID, Expected: req.GetSegmentId()}
+	}
+	if info.GetID() != req.GetSegmentId() {
+		reason := allocMismatchSegmentID
+		if isKnownSegment(info.GetID()) {
+			reason = allocMismatchDuplicateSegmentID
+		}
+		return &AllocSegmentMismatchError{Reason: reason, Expected: req.GetSegmentId(), Actual: info.GetID()}
+	}
+	if info.GetCollectionID() != req.GetCollectionId() {
+		return &AllocSegmentMismatchError{Reason: allocMismatchCollectionID, Expected: req.GetCollectionId(), Actual: info.GetCollectionID()}
+	}
+	if info.GetPartitionID() != req.GetPartitionId() {
+		return &AllocSegmentMismatchError{Reason: allocMismatchPartitionID, Expected: req.GetPartitionId(), Actual: info.GetPartitionID()}
+	}
+	return nil
+}
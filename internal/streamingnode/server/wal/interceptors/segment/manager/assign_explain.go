@@ -0,0 +1,249 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+const (
+	// AssignmentDecisionExistingSegment means the insert would be assigned on an existing growing segment.
+	AssignmentDecisionExistingSegment AssignmentDecision = "existing_segment"
+	// AssignmentDecisionNewSegment means the insert would create a new growing segment.
+	AssignmentDecisionNewSegment AssignmentDecision = "new_segment"
+	// AssignmentDecisionRejected means the insert would be rejected.
+	AssignmentDecisionRejected AssignmentDecision = "rejected"
+
+	// assignmentStrategyFirstFit tries the growing segments in the order of creation.
+	assignmentStrategyFirstFit = "first_fit"
+	// assignmentStrategyShardHint tries the growing segments in the order of the rendezvous score of the shard hint.
+	assignmentStrategyShardHint = "shard_hint"
+
+	// the outcomes of the candidate segments.
+	candidateChosen         = "chosen"
+	candidateNotGrowing     = "not_growing"
+	candidateTimeTickTooOld = "time_tick_too_old"
+	candidateNotEnoughSpace = "not_enough_space"
+	candidateTooLargeInsert = "too_large_insert"
+	candidateNotEvaluated   = "not_evaluated" // the insert is already decided by the former candidate.
+	candidateOtherRoute     = "other_route"   // the L0 segment or the segment of the other priority route.
+
+	// the conditions rejecting the insert.
+	rejectionCircuitOpen     = "circuit_open"
+	rejectionDropping        = "partition_dropping"
+	rejectionDropFenced      = "partition_drop_fenced"
+	rejectionManualFlush     = "fenced_by_manual_flush"
+	rejectionSealTimeTickOld = "time_tick_not_after_last_seal"
+	rejectionTimeTickTooOld  = "time_tick_too_old"
+	rejectionTooLargeInsert  = "too_large_insert"
+)
+
+// AssignmentDecision is the decision of the explained segment assignment.
+type AssignmentDecision string
+
+// ExplainAssignmentRequest is a request to explain the segment assignment of a hypothetical insert.
+type ExplainAssignmentRequest struct {
+	CollectionID  int64
+	PartitionID   int64
+	InsertMetrics stats.InsertMetrics
+	TimeTick      uint64 // the time tick of the hypothetical insert, the time tick based conditions are not evaluated if 0.
+	HighPriority  bool
+	ShardHint     uint64
+}
+
+// AssignmentExplanation explains where a hypothetical insert would be assigned right now and why.
+type AssignmentExplanation struct {
+	CollectionID int64              `json:"collection_id"`
+	PartitionID  int64              `json:"partition_id"`
+	Decision     AssignmentDecision `json:"decision"`
+	SegmentID    int64              `json:"segment_id,omitempty"` // the chosen segment, 0 if a new segment would be created or the insert is rejected.
+	Strategy     string             `json:"strategy"`
+	HighPriority bool               `json:"high_priority"`       // the insert is routed into the high priority segments.
+	Rejection    string             `json:"rejection,omitempty"` // the first condition rejecting the insert.
+	Conditions   []string           `json:"conditions"`          // all conditions rejecting the insert in the order of evaluation.
+	// the capacity math of the insert.
+	InsertBinarySize        uint64                                  `json:"insert_binary_size"`
+	NewSegmentMaxBinarySize uint64                                  `json:"new_segment_max_binary_size"` // the max binary size of the new growing segment without jitter.
+	Candidates              []AssignmentCandidate                   `json:"candidates"`
+	CircuitBreaker          string                                  `json:"circuit_breaker"`
+	Saturation              float64                                 `json:"saturation"` // the rolling saturation of the append path of the pchannel.
+	FencedAssignTimeTick    uint64                                  `json:"fenced_assign_time_tick"`
+	DropFenceTimeTick       uint64                                  `json:"drop_fence_time_tick,omitempty"`
+	Config                  *streamingpb.CollectionAssignmentConfig `json:"config"` // the assignment config of the collection in effect.
+}
+
+// AssignmentCandidate is a segment of the partition evaluated by the explained segment assignment.
+type AssignmentCandidate struct {
+	SegmentID           int64  `json:"segment_id"`
+	State               string `json:"state"`
+	MaxBinarySize       uint64 `json:"max_binary_size"`
+	InsertedBinarySize  uint64 `json:"inserted_binary_size"`
+	RemainingBinarySize uint64 `json:"remaining_binary_size"`
+	Outcome             string `json:"outcome"`
+}
+
+// reject appends the condition rejecting the insert, the first one is the rejection of the explanation.
+func (e *AssignmentExplanation) reject(condition string) {
+	if e.Rejection == "" {
+		e.Rejection = condition
+		e.Decision = AssignmentDecisionRejected
+		e.SegmentID = 0
+	}
+	e.Conditions = append(e.Conditions, condition)
+}
+
+// ExplainAssignment explains where a hypothetical insert would be assigned right now and why.
+// The selection logic of AssignSegment is evaluated read-only, no state is mutated and the capacity is never reserved,
+// so the explained decision may be different from the real assignment if the state is changed in between.
+func (m *PChannelSegmentAllocManager) ExplainAssignment(ctx context.Context, req *ExplainAssignmentRequest) (*AssignmentExplanation, error) {
+	if err := m.checkLifetime(); err != nil {
+		return nil, err
+	}
+	defer m.lifetime.Done()
+
+	manager, err := m.managers.Get(req.CollectionID, req.PartitionID)
+	if err != nil {
+		return nil, err
+	}
+	config, err := m.managers.ExportAssignmentConfig(req.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	explanation := &AssignmentExplanation{
+		CollectionID:     req.CollectionID,
+		PartitionID:      req.PartitionID,
+		InsertBinarySize: req.InsertMetrics.BinarySize,
+		Conditions:       make([]string, 0),
+		CircuitBreaker:   string(m.breakers.State(req.CollectionID)),
+		Saturation:       m.Saturation(),
+		Config:           config,
+	}
+	// the system collection skips the circuit breaker.
+	if !IsSystemCollection(req.CollectionID) && m.breakers.State(req.CollectionID) == circuitBreakerStateOpen {
+		explanation.reject(rejectionCircuitOpen)
+	}
+	manager.ExplainAssignment(req, explanation)
+	return explanation, nil
+}
+
+// ExplainAssignment evaluates the selection logic of AssignSegment read-only and fills the explanation.
+func (m *partitionSegmentManager) ExplainAssignment(req *ExplainAssignmentRequest, explanation *AssignmentExplanation) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	explanation.FencedAssignTimeTick = m.fencedAssignTimeTick
+	explanation.DropFenceTimeTick = m.activeDropFence()
+	if m.dropping {
+		explanation.reject(rejectionDropping)
+	}
+	// the insert without time tick is always newer than the active drop fence.
+	if explanation.DropFenceTimeTick != 0 && (req.TimeTick == 0 || req.TimeTick > explanation.DropFenceTimeTick) {
+		explanation.reject(rejectionDropFenced)
+	}
+	if req.TimeTick != 0 && req.TimeTick <= m.fencedAssignTimeTick {
+		explanation.reject(rejectionManualFlush)
+	}
+	if req.TimeTick != 0 && req.TimeTick <= m.lastSealTimeTick && policy.GetTimeTickValidationMode(m.collectionID) == policy.TimeTickValidationModeStrict {
+		explanation.reject(rejectionSealTimeTickOld)
+	}
+
+	highPriority := policy.IsHighPriorityAssign(m.collectionID, req.HighPriority)
+	explanation.HighPriority = highPriority
+	explanation.Strategy = assignmentStrategyFirstFit
+	if m.isShardHintApplied(req.ShardHint, highPriority) {
+		explanation.Strategy = assignmentStrategyShardHint
+	}
+	candidates := m.candidatesOfShardHint(req.ShardHint, highPriority)
+	limitation := policy.SegmentLimitation{SegmentSize: policy.GetSegmentMaxBinarySizeLimit()}
+	if highPriority {
+		limitation = policy.GetHighPrioritySegmentLimitation(limitation)
+	}
+	limitation = policy.GetSmallCollectionSegmentLimitation(limitation, m.ingest.MaxBinarySize())
+	limitation = policy.GetRowSizeFloorSegmentLimitation(limitation, m.ingest.RowSizeFloor())
+	explanation.NewSegmentMaxBinarySize = limitation.SegmentSize
+
+	decided := false
+	hitTimeTickTooOld := false
+	explanation.Candidates = make([]AssignmentCandidate, 0, len(candidates))
+	for _, segment := range candidates {
+		candidate := AssignmentCandidate{
+			SegmentID: segment.GetSegmentID(),
+			State:     segment.GetState().String(),
+		}
+		if stat := segment.GetStat(); stat != nil {
+			candidate.MaxBinarySize = stat.MaxBinarySize
+			candidate.InsertedBinarySize = stat.Insert.BinarySize
+			candidate.RemainingBinarySize = stat.BinaryCanBeAssign()
+		}
+		candidate.Outcome = m.explainCandidate(req, segment, highPriority, decided)
+		switch candidate.Outcome {
+		case candidateChosen:
+			decided = true
+			explanation.Decision = AssignmentDecisionExistingSegment
+			explanation.SegmentID = segment.GetSegmentID()
+		case candidateTooLargeInsert:
+			decided = true
+			explanation.reject(rejectionTooLargeInsert)
+		case candidateTimeTickTooOld:
+			hitTimeTickTooOld = true
+		}
+		explanation.Candidates = append(explanation.Candidates, candidate)
+	}
+	if decided {
+		return
+	}
+	// the new growing segment is never allocated if the time tick is too old for the existing one.
+	if hitTimeTickTooOld {
+		explanation.reject(rejectionTimeTickTooOld)
+		return
+	}
+	if req.InsertMetrics.BinarySize > limitation.SegmentSize {
+		explanation.reject(rejectionTooLargeInsert)
+		return
+	}
+	if explanation.Rejection == "" {
+		explanation.Decision = AssignmentDecisionNewSegment
+	}
+}
+
+// explainCandidate explains the outcome of the allocation of the insert on the segment without allocating it.
+func (m *partitionSegmentManager) explainCandidate(req *ExplainAssignmentRequest, segment *segmentAllocManager, highPriority bool, decided bool) string {
+	if segment.IsL0() || segment.IsHighPriority() != highPriority {
+		return candidateOtherRoute
+	}
+	if decided {
+		return candidateNotEvaluated
+	}
+	if segment.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
+		return candidateNotGrowing
+	}
+	if req.TimeTick != 0 && (req.TimeTick <= segment.inner.GetStat().GetCreateSegmentTimeTick() || req.TimeTick <= segment.migratedTimeTick) {
+		return candidateTimeTickTooOld
+	}
+	stat := segment.GetStat()
+	if stat == nil {
+		return candidateNotGrowing
+	}
+	if req.InsertMetrics.BinarySize <= stat.BinaryCanBeAssign() {
+		return candidateChosen
+	}
+	if stat.IsEmpty() {
+		return candidateTooLargeInsert
+	}
+	return candidateNotEnoughSpace
+}
+
+// String returns the one line summary of the explanation.
+func (e *AssignmentExplanation) String() string {
+	switch e.Decision {
+	case AssignmentDecisionExistingSegment:
+		return fmt.Sprintf("insert of %d bytes would be assigned on segment %d by %s", e.InsertBinarySize, e.SegmentID, e.Strategy)
+	case AssignmentDecisionNewSegment:
+		return fmt.Sprintf("insert of %d bytes would create a new growing segment of %d bytes", e.InsertBinarySize, e.NewSegmentMaxBinarySize)
+	default:
+		return fmt.Sprintf("insert of %d bytes would be rejected by %s", e.InsertBinarySize, e.Rejection)
+	}
+}
@@ -0,0 +1,27 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+// PreviewAssignOnPChannel previews the segment assignment of the insert on the pchannel for the pre-flight capacity check of the client.
+// It's cheap enough to be called for every insert batch, the capacity is never reserved and no segment is created.
+func PreviewAssignOnPChannel(ctx context.Context, req *streamingpb.AssignPreviewRequest) (*streamingpb.AssignPreviewResponse, error) {
+	pchannel := req.GetPchannel().GetName()
+	pm, err := getPChannelSegmentAllocManager(pchannel)
+	if err != nil {
+		return nil, err
+	}
+	return pm.PreviewAssign(ctx, &AssignPreviewRequest{
+		CollectionID: req.GetCollectionId(),
+		PartitionID:  req.GetPartitionId(),
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       req.GetRows(),
+			BinarySize: req.GetBinarySize(),
+		},
+		HighPriority: req.GetHighPriority(),
+	})
+}
@@ -0,0 +1,101 @@
+package manager
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+)
+
+func TestSegmentAssignmentChecksum(t *testing.T) {
+	initializeTestState(t)
+
+	var mu sync.Mutex
+	epochs := make(map[int64]uint64)
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, msg message.MutableMessage) (*wal.AppendResult, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		// the consumer learns the epoch of the segment from the create segment or flush message.
+		switch msg.MessageType() {
+		case message.MessageTypeCreateSegment:
+			header := message.MustAsMutableCreateSegmentMessageV2(msg).Header()
+			epochs[header.GetSegmentId()] = header.GetAssignmentEpoch()
+		case message.MessageTypeFlush:
+			header := message.MustAsMutableFlushMessageV2(msg).Header()
+			if epoch, ok := epochs[header.GetSegmentId()]; ok {
+				assert.Equal(t, epoch, header.GetAssignmentEpoch())
+			}
+			epochs[header.GetSegmentId()] = header.GetAssignmentEpoch()
+		}
+		return &wal.AppendResult{
+			MessageID: rmq.NewRmqID(1),
+			TimeTick:  1,
+		}, nil
+	}).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	ctx := context.Background()
+	m, err := RecoverPChannelSegmentAllocManager(ctx, types.PChannelInfo{Name: "v_assignment_checksum", Term: 3}, f)
+	assert.NoError(t, err)
+	defer m.Close(ctx)
+	assign := func(binarySize uint64) (*AssignSegmentResult, uint64) {
+		timetick := tsoutil.GetCurrentTime()
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       1,
+				BinarySize: binarySize,
+			},
+			TimeTick: timetick,
+		})
+		assert.NoError(t, err)
+		return result, timetick
+	}
+	verify := func(result *AssignSegmentResult, epoch uint64, timetick uint64) error {
+		return message.VerifySegmentAssignment(epoch, 1, timetick, &message.PartitionSegmentAssignment{
+			PartitionId: 3,
+			SegmentAssignment: &message.SegmentAssignment{
+				SegmentId: result.SegmentID,
+				Checksum:  result.Checksum,
+			},
+		})
+	}
+
+	// the recovered segment created before the signing is supported is unsigned.
+	result, _ := assign(1)
+	assert.Equal(t, int64(6000), result.SegmentID)
+	assert.Zero(t, result.Checksum)
+	result.Ack()
+
+	// the new segment is signed with the term of the pchannel, which is carried by its create segment message.
+	result, timetick := assign(901)
+	segmentID := result.SegmentID
+	assert.NotEqual(t, int64(6000), segmentID)
+	mu.Lock()
+	epoch := epochs[segmentID]
+	mu.Unlock()
+	assert.Equal(t, uint64(3), epoch)
+	assert.NoError(t, verify(result, epoch, timetick))
+	assert.ErrorIs(t, verify(result, epoch, timetick+1), message.ErrSegmentAssignmentCorrupted)
+	assert.ErrorIs(t, verify(result, epoch+1, timetick), message.ErrSegmentAssignmentCorrupted)
+	result.Ack()
+
+	// the flush message carries the same epoch.
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: segmentID})
+	_, ok := m.SegmentState(segmentID)
+	assert.False(t, ok)
+}
@@ -0,0 +1,79 @@
+package manager
+
+import (
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+// CollectionAssignmentConfig exports the effective assignment config of the collection for the collection backup.
+func (m *PChannelSegmentAllocManager) CollectionAssignmentConfig(collectionID int64) (*streamingpb.CollectionAssignmentConfig, error) {
+	if err := m.checkLifetime(); err != nil {
+		return nil, err
+	}
+	defer m.lifetime.Done()
+
+	return m.managers.ExportAssignmentConfig(collectionID)
+}
+
+// ApplyCollectionAssignmentConfig applies the assignment config carried by the create collection message of the restored collection.
+// The collection id of the config is replaced by the restored one, the config is exported from the original collection.
+func (m *PChannelSegmentAllocManager) ApplyCollectionAssignmentConfig(collectionID int64, config *streamingpb.CollectionAssignmentConfig) error {
+	if err := m.checkLifetime(); err != nil {
+		return err
+	}
+	defer m.lifetime.Done()
+
+	return m.managers.ApplyAssignmentConfig(collectionID, config)
+}
+
+// ExportAssignmentConfig exports the effective assignment config of the collection.
+func (m *partitionSegmentManagers) ExportAssignmentConfig(collectionID int64) (*streamingpb.CollectionAssignmentConfig, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ingest, ok := m.ingests[collectionID]
+	if !ok {
+		return nil, ErrCollectionNotFound
+	}
+	config := policy.ExportCollectionAssignmentConfig(collectionID)
+	config.MaxBinarySizeFloor = ingest.RowSizeFloor()
+	return config, nil
+}
+
+// ApplyAssignmentConfig applies the assignment config to the collection.
+func (m *partitionSegmentManagers) ApplyAssignmentConfig(collectionID int64, config *streamingpb.CollectionAssignmentConfig) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ingest, ok := m.ingests[collectionID]
+	if !ok {
+		return ErrCollectionNotFound
+	}
+	config = proto.Clone(config).(*streamingpb.CollectionAssignmentConfig)
+	config.CollectionId = collectionID
+	if err := policy.ApplyCollectionAssignmentConfig(config); err != nil {
+		return err
+	}
+	ingest.RaiseRowSizeFloor(config.GetMaxBinarySizeFloor())
+	m.markCollectionSweepDirty(collectionID)
+	m.logger.Info("collection assignment config applied", zap.Any("config", config))
+	return nil
+}
+
+// recoverAssignmentConfigs recovers the assignment config applied to the restored collections from the vchannel metas.
+func recoverAssignmentConfigs(logger *log.MLogger, managers *partitionSegmentManagers, vchannels []*streamingpb.VChannelMeta) {
+	for _, vchannel := range vchannels {
+		info := vchannel.GetCollectionInfo()
+		if vchannel.GetState() == streamingpb.VChannelState_VCHANNEL_STATE_DROPPED || info.GetAssignmentConfig() == nil {
+			continue
+		}
+		if err := managers.ApplyAssignmentConfig(info.GetCollectionId(), info.GetAssignmentConfig()); err != nil {
+			logger.Warn("failed to recover collection assignment config, ignored",
+				zap.Int64("collectionID", info.GetCollectionId()), zap.Error(err))
+		}
+	}
+}
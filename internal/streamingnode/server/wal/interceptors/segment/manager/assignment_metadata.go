@@ -0,0 +1,138 @@
+package manager
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// the feature flags relevant to the segment assignment, they're reported within the assignment metadata.
+const (
+	AssignmentFeatureStorageV2           = "storage_v2"
+	AssignmentFeatureSealPersistBatching = "seal_persist_batching"
+	AssignmentFeatureBackpressure        = "backpressure"
+	AssignmentFeaturePKSketch            = "pk_sketch"
+	AssignmentFeatureSealedNotify        = "sealed_notify"
+)
+
+// workingManagers keeps the working segment assignment managers of the pchannels on current node,
+// so the assignment metadata of them can be reported to the coordinator.
+var workingManagers = &workingManagerRegistry{
+	managers: make(map[string]*PChannelSegmentAllocManager),
+}
+
+// AssignmentMetadata is the build and behavior metadata of the segment assignment of a pchannel,
+// it's used to tell the behavior differences between the streaming nodes when debugging a mixed-version cluster.
+type AssignmentMetadata struct {
+	PChannel    string           `json:"pchannel"`
+	Term        int64            `json:"term"`
+	NodeVersion string           `json:"node_version"`
+	Features    map[string]bool  `json:"features"`     // the feature flags relevant to the segment assignment.
+	PolicyChain []string         `json:"policy_chain"` // the async seal policies in the evaluation order.
+	Capacity    PChannelCapacity `json:"capacity"`     // the registered collections and partitions and the caps of them.
+}
+
+// EnabledFeatures returns the enabled feature flags in order.
+func (m AssignmentMetadata) EnabledFeatures() []string {
+	features := make([]string, 0, len(m.Features))
+	for feature, enabled := range m.Features {
+		if enabled {
+			features = append(features, feature)
+		}
+	}
+	sort.Strings(features)
+	return features
+}
+
+// IntoProto converts the metadata into the proto reported to the coordinator.
+func (m AssignmentMetadata) IntoProto() *streamingpb.PChannelAssignmentMetadata {
+	return &streamingpb.PChannelAssignmentMetadata{
+		Pchannel:        m.PChannel,
+		Term:            m.Term,
+		NodeVersion:     m.NodeVersion,
+		EnabledFeatures: m.EnabledFeatures(),
+		PolicyChain:     m.PolicyChain,
+		Collections:     m.Capacity.Collections,
+		Partitions:      m.Capacity.Partitions,
+		MaxCollections:  m.Capacity.MaxCollections,
+		MaxPartitions:   m.Capacity.MaxPartitions,
+	}
+}
+
+// newAssignmentMetadata builds the assignment metadata of the pchannel from the current config,
+// so the refreshed feature flags are reflected right away.
+func newAssignmentMetadata(pchannel types.PChannelInfo) AssignmentMetadata {
+	params := paramtable.Get()
+	return AssignmentMetadata{
+		PChannel:    pchannel.Name,
+		Term:        pchannel.Term,
+		NodeVersion: common.Version.String(),
+		Features: map[string]bool{
+			AssignmentFeatureStorageV2: params.CommonCfg.EnableStorageV2.GetAsBool(),
+			AssignmentFeatureSealPersistBatching: params.StreamingCfg.WALSegmentAssignSealQueuePersistMaxEntries.GetAsInt() > 0 ||
+				params.StreamingCfg.WALSegmentAssignSealQueuePersistMaxBytes.GetAsInt() > 0,
+			AssignmentFeatureBackpressure: params.StreamingCfg.WALSegmentAssignCircuitBreakerFailureThreshold.GetAsInt() > 0,
+			AssignmentFeaturePKSketch:     params.StreamingCfg.WALSegmentAssignPKSketchEnabled.GetAsBool(),
+			AssignmentFeatureSealedNotify: params.StreamingCfg.WALSegmentAssignSealedNotifyEnabled.GetAsBool(),
+		},
+		PolicyChain: policy.GetAsyncSealPolicyChain(),
+	}
+}
+
+// Metadata returns the build and behavior metadata of the segment assignment of the pchannel.
+func (m *PChannelSegmentAllocManager) Metadata() AssignmentMetadata {
+	metadata := newAssignmentMetadata(m.pchannel)
+	metadata.Capacity = m.Capacity()
+	return metadata
+}
+
+// ListAssignmentMetadata returns the assignment metadata of all working pchannels on current node in the order of pchannel.
+func ListAssignmentMetadata() []AssignmentMetadata {
+	managers := workingManagers.list()
+	metadata := make([]AssignmentMetadata, 0, len(managers))
+	for _, m := range managers {
+		metadata = append(metadata, m.Metadata())
+	}
+	sort.Slice(metadata, func(i, j int) bool {
+		return metadata[i].PChannel < metadata[j].PChannel
+	})
+	return metadata
+}
+
+// workingManagerRegistry is the registry of the working segment assignment managers.
+type workingManagerRegistry struct {
+	mu       sync.Mutex
+	managers map[string]*PChannelSegmentAllocManager
+}
+
+// register registers the manager of the pchannel, the manager of the old term is replaced.
+func (r *workingManagerRegistry) register(m *PChannelSegmentAllocManager) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.managers[m.pchannel.Name] = m
+}
+
+// unregister unregisters the manager, it's a no-op if the manager is already replaced by the new term.
+func (r *workingManagerRegistry) unregister(m *PChannelSegmentAllocManager) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.managers[m.pchannel.Name] == m {
+		delete(r.managers, m.pchannel.Name)
+	}
+}
+
+// list lists the working managers.
+func (r *workingManagerRegistry) list() []*PChannelSegmentAllocManager {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	managers := make([]*PChannelSegmentAllocManager, 0, len(r.managers))
+	for _, m := range r.managers {
+		managers = append(managers, m)
+	}
+	return managers
+}
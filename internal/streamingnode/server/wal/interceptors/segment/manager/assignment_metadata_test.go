@@ -0,0 +1,91 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+)
+
+func TestAssignmentMetadata(t *testing.T) {
+	initializeTestState(t)
+	params := paramtable.Get()
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_metadata", Term: 3}, f)
+	assert.NoError(t, err)
+
+	// the recovery summary carries the metadata at the recovery.
+	summary, ok := GetRecoverySummary("v_metadata")
+	assert.True(t, ok)
+	assert.Equal(t, "v_metadata", summary.Metadata.PChannel)
+	assert.Equal(t, int64(3), summary.Metadata.Term)
+	assert.Equal(t, common.Version.String(), summary.Metadata.NodeVersion)
+	assert.Equal(t, []string{
+		string(policy.PolicyNameByCapacity),
+		string(policy.PolicyNameBinlogNumber),
+		string(policy.PolicyNameByLifetime),
+		string(policy.PolicyNameByIdleTime),
+		string(policy.PolicyNameSmallCollectionLifetime),
+		string(policy.PolicyNameL0MaxDeletes),
+		string(policy.PolicyNameL0Lifetime),
+	}, summary.Metadata.PolicyChain)
+
+	// the toggled flags are reflected right away.
+	params.Save(params.CommonCfg.EnableStorageV2.Key, "true")
+	defer params.Reset(params.CommonCfg.EnableStorageV2.Key)
+	params.Save(params.StreamingCfg.WALSegmentAssignSealQueuePersistMaxEntries.Key, "100")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignSealQueuePersistMaxEntries.Key)
+	params.Save(params.StreamingCfg.WALSegmentAssignCircuitBreakerFailureThreshold.Key, "0")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignCircuitBreakerFailureThreshold.Key)
+	params.Save(params.StreamingCfg.WALSegmentAssignSmallCollectionMaxLifetime.Key, "0")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignSmallCollectionMaxLifetime.Key)
+	metadata := m.Metadata()
+	assert.True(t, metadata.Features[AssignmentFeatureStorageV2])
+	assert.True(t, metadata.Features[AssignmentFeatureSealPersistBatching])
+	assert.False(t, metadata.Features[AssignmentFeatureBackpressure])
+	assert.NotContains(t, metadata.PolicyChain, string(policy.PolicyNameSmallCollectionLifetime))
+	assert.Contains(t, metadata.EnabledFeatures(), AssignmentFeatureStorageV2)
+	assert.NotContains(t, metadata.EnabledFeatures(), AssignmentFeatureBackpressure)
+
+	params.Save(params.CommonCfg.EnableStorageV2.Key, "false")
+	params.Save(params.StreamingCfg.WALSegmentAssignCircuitBreakerFailureThreshold.Key, "10")
+	metadata = m.Metadata()
+	assert.False(t, metadata.Features[AssignmentFeatureStorageV2])
+	assert.True(t, metadata.Features[AssignmentFeatureBackpressure])
+
+	// the introspection output and the coordinator report carry the same metadata.
+	snapshot, err := m.SnapshotCollection(1, []int64{3}, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, metadata, snapshot.Metadata)
+	reported := ListAssignmentMetadata()
+	assert.Contains(t, reported, metadata)
+	pb := metadata.IntoProto()
+	assert.Equal(t, "v_metadata", pb.GetPchannel())
+	assert.Equal(t, int64(3), pb.GetTerm())
+	assert.Equal(t, metadata.EnabledFeatures(), pb.GetEnabledFeatures())
+	assert.Equal(t, metadata.PolicyChain, pb.GetPolicyChain())
+
+	// the closed manager is not reported anymore.
+	m.Close(context.Background())
+	for _, metadata := range ListAssignmentMetadata() {
+		assert.NotEqual(t, "v_metadata", metadata.PChannel)
+	}
+}
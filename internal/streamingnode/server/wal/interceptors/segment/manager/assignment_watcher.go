@@ -0,0 +1,180 @@
+package manager
+
+import (
+	"context"
+	"sync"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+)
+
+const defaultAssignmentEventRingSize = 1024
+
+// AssignmentEventType is the type of segment assignment event.
+type AssignmentEventType string
+
+const (
+	AssignmentEventCreate  AssignmentEventType = "create"  // a new growing segment is created.
+	AssignmentEventGrow    AssignmentEventType = "grow"    // some rows are assigned to the growing segment.
+	AssignmentEventSeal    AssignmentEventType = "seal"    // the growing segment is sealed.
+	AssignmentEventUnseal  AssignmentEventType = "unseal"  // the sealed segment is reverted back to growing by the emergency unseal.
+	AssignmentEventMigrate AssignmentEventType = "migrate" // the growing segment is migrated into another partition.
+	AssignmentEventDrop    AssignmentEventType = "drop"    // the sealed segment is flushed and dropped from the assignment.
+	AssignmentEventDiscard AssignmentEventType = "discard" // the segment is discarded without flush and dropped from the assignment.
+	AssignmentEventLagged  AssignmentEventType = "lagged"  // the watcher is too slow, some events are dropped.
+)
+
+// AssignmentEvent is the segment lifecycle change event of segment assignment.
+type AssignmentEvent struct {
+	Type         AssignmentEventType
+	Sequence     uint64 // the sequence of the event on the pchannel, always increasing.
+	CollectionID int64
+	PartitionID  int64
+	SegmentID    int64
+	VChannel     string
+	// the effective storage version of the segment, decided when the segment is created.
+	StorageVersion int64
+	Delta          stats.InsertMetrics // the insert metrics delta of grow event.
+	Stat           *stats.SegmentStats // the stat of segment when the event happens, nil for grow, lagged and L0 segment event.
+	LaggedEvents   uint64              // the count of dropped events, only used by lagged event.
+	// the write amplification of the segment, only used by seal event, 0 if nothing is assigned or synced before sealed.
+	WriteAmplification float64
+}
+
+// newSegmentAssignmentEvent creates a new assignment event of the segment.
+func newSegmentAssignmentEvent(eventType AssignmentEventType, segment *segmentAllocManager, delta stats.InsertMetrics) AssignmentEvent {
+	event := AssignmentEvent{
+		Type:           eventType,
+		CollectionID:   segment.GetCollectionID(),
+		PartitionID:    segment.GetPartitionID(),
+		SegmentID:      segment.GetSegmentID(),
+		VChannel:       segment.GetVChannel(),
+		StorageVersion: segment.GetStorageVersion(),
+		Delta:          delta,
+	}
+	if eventType != AssignmentEventGrow {
+		// grow event is on the hot path of insert, only carry the delta.
+		event.Stat = segment.GetStat()
+	}
+	if eventType == AssignmentEventSeal && event.Stat != nil {
+		event.WriteAmplification, _ = event.Stat.WriteAmplification()
+	}
+	return event
+}
+
+// newAssignmentWatcher creates a new assignment watcher with a ring buffer of the given capacity.
+func newAssignmentWatcher(capacity int) *assignmentWatcher {
+	return &assignmentWatcher{
+		cond:    syncutil.NewContextCond(&sync.Mutex{}),
+		events:  make([]AssignmentEvent, capacity),
+		closeCh: make(chan struct{}),
+	}
+}
+
+// assignmentWatcher publishes the assignment events into a ring buffer,
+// every watcher keeps its own cursor on the ring buffer, so the publisher is never blocked by a slow watcher.
+// A lagged event is delivered to the watcher if the events it wants have been overwritten.
+type assignmentWatcher struct {
+	cond    *syncutil.ContextCond
+	events  []AssignmentEvent
+	next    uint64 // the sequence of next published event.
+	closed  bool
+	closeCh chan struct{}
+}
+
+// Publish publishes a new event into the ring buffer.
+func (w *assignmentWatcher) Publish(event AssignmentEvent) {
+	w.cond.LockAndBroadcast()
+	defer w.cond.L.Unlock()
+
+	if w.closed {
+		return
+	}
+	event.Sequence = w.next
+	w.events[w.next%uint64(len(w.events))] = event
+	w.next++
+}
+
+// Next returns the sequence of next published event.
+func (w *assignmentWatcher) Next() uint64 {
+	w.cond.L.Lock()
+	defer w.cond.L.Unlock()
+	return w.next
+}
+
+// Watch watches the events published after now.
+// The returned channel will be closed when the ctx is done or the watcher is closed.
+func (w *assignmentWatcher) Watch(ctx context.Context) <-chan AssignmentEvent {
+	ch := make(chan AssignmentEvent)
+	w.cond.L.Lock()
+	cursor := w.next
+	w.cond.L.Unlock()
+
+	go w.serve(ctx, cursor, ch)
+	return ch
+}
+
+// Close closes the watcher and terminates all watches.
+func (w *assignmentWatcher) Close() {
+	w.cond.LockAndBroadcast()
+	defer w.cond.L.Unlock()
+
+	if w.closed {
+		return
+	}
+	w.closed = true
+	close(w.closeCh)
+}
+
+// serve delivers the events from the cursor to the channel.
+func (w *assignmentWatcher) serve(ctx context.Context, cursor uint64, ch chan<- AssignmentEvent) {
+	defer close(ch)
+	for {
+		events, nextCursor, ok := w.fetch(ctx, cursor)
+		if !ok {
+			return
+		}
+		cursor = nextCursor
+		for _, event := range events {
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.closeCh:
+				return
+			case ch <- event:
+			}
+		}
+	}
+}
+
+// fetch blocks until there're new events after the cursor, return the events and the next cursor.
+// Return false if the ctx is done or the watcher is closed.
+func (w *assignmentWatcher) fetch(ctx context.Context, cursor uint64) ([]AssignmentEvent, uint64, bool) {
+	w.cond.L.Lock()
+	for cursor == w.next && !w.closed {
+		if err := w.cond.Wait(ctx); err != nil {
+			return nil, cursor, false
+		}
+	}
+	defer w.cond.L.Unlock()
+	if w.closed {
+		return nil, cursor, false
+	}
+
+	capacity := uint64(len(w.events))
+	events := make([]AssignmentEvent, 0, w.next-cursor+1)
+	if w.next-cursor > capacity {
+		// the events after the cursor have been overwritten.
+		oldest := w.next - capacity
+		events = append(events, AssignmentEvent{
+			Type:         AssignmentEventLagged,
+			Sequence:     cursor,
+			LaggedEvents: oldest - cursor,
+		})
+		cursor = oldest
+	}
+	for ; cursor < w.next; cursor++ {
+		events = append(events, w.events[cursor%capacity])
+	}
+	return events, cursor, true
+}
@@ -0,0 +1,160 @@
+package manager
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+)
+
+func TestAssignmentWatcherLagged(t *testing.T) {
+	w := newAssignmentWatcher(4)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := w.Watch(ctx)
+
+	// publish more events than the capacity before the watcher consumes.
+	for i := 0; i < 10; i++ {
+		w.Publish(AssignmentEvent{Type: AssignmentEventGrow, SegmentID: int64(i)})
+	}
+	event := <-ch
+	assert.Equal(t, AssignmentEventLagged, event.Type)
+	assert.Equal(t, uint64(6), event.LaggedEvents)
+	for i := 6; i < 10; i++ {
+		event := <-ch
+		assert.Equal(t, AssignmentEventGrow, event.Type)
+		assert.Equal(t, uint64(i), event.Sequence)
+		assert.Equal(t, int64(i), event.SegmentID)
+	}
+
+	// cancel the watch.
+	cancel()
+	_, ok := <-ch
+	assert.False(t, ok)
+
+	// close the watcher should terminate all watches.
+	ch = w.Watch(context.Background())
+	ch2 := w.Watch(context.Background())
+	w.Close()
+	_, ok = <-ch
+	assert.False(t, ok)
+	_, ok = <-ch2
+	assert.False(t, ok)
+
+	// publish after close should be ignored.
+	w.Publish(AssignmentEvent{Type: AssignmentEventGrow})
+	_, ok = <-w.Watch(context.Background())
+	assert.False(t, ok)
+}
+
+func TestWatchAssignmentsOrdering(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v1"}, f)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	events := make([]AssignmentEvent, 0)
+	eventsMu := sync.Mutex{}
+	watchCh := m.WatchAssignments(ctx)
+	consumed := make(chan struct{})
+	go func() {
+		defer close(consumed)
+		for event := range watchCh {
+			eventsMu.Lock()
+			events = append(events, event)
+			eventsMu.Unlock()
+		}
+	}()
+
+	// assign concurrently to generate create, grow and seal events.
+	wg := sync.WaitGroup{}
+	assigned := make([]int, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 30; j++ {
+				result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+					CollectionID: 1,
+					PartitionID:  int64(i + 1),
+					InsertMetrics: stats.InsertMetrics{
+						Rows:       100,
+						BinarySize: 200 * 1024,
+					},
+					TimeTick: tsoutil.GetCurrentTime(),
+				})
+				if err != nil {
+					continue
+				}
+				assigned[i]++
+				result.Ack()
+				if j%10 == 0 {
+					m.TryToSealSegments(ctx)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	m.TryToSealSegments(ctx)
+
+	assert.Eventually(t, func() bool {
+		m.watcher.cond.L.Lock()
+		published := m.watcher.next
+		m.watcher.cond.L.Unlock()
+		eventsMu.Lock()
+		defer eventsMu.Unlock()
+		return uint64(len(events)) == published
+	}, 5*time.Second, 10*time.Millisecond)
+	m.Close(ctx)
+	<-consumed
+
+	// the sequence should be continuous, and the transitions of every segment should be ordered.
+	// the recovered segments have no create event, so a missing phase is treated as growing or sealed.
+	const (
+		phaseGrowing = iota
+		phaseSealed
+		phaseDropped
+	)
+	phases := make(map[int64]int)
+	growEvents := 0
+	for i, event := range events {
+		assert.Equal(t, uint64(i), event.Sequence)
+		phase, seen := phases[event.SegmentID]
+		switch event.Type {
+		case AssignmentEventCreate:
+			assert.False(t, seen, "create should be the first event of segment")
+			phases[event.SegmentID] = phaseGrowing
+		case AssignmentEventGrow:
+			growEvents++
+			assert.Equal(t, phaseGrowing, phase, "grow should happen before seal")
+		case AssignmentEventSeal:
+			assert.Equal(t, phaseGrowing, phase, "seal should happen once after growing")
+			phases[event.SegmentID] = phaseSealed
+		case AssignmentEventDrop:
+			assert.True(t, !seen || phase == phaseSealed, "drop should happen after seal")
+			phases[event.SegmentID] = phaseDropped
+		default:
+			t.Errorf("unexpected event type %s", event.Type)
+		}
+	}
+	assert.Equal(t, assigned[0]+assigned[1]+assigned[2], growEvents)
+}
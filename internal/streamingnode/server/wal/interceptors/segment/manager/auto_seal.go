@@ -0,0 +1,80 @@
+package manager
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+// newAutoSealDisabledWarner creates a new auto seal disabled warner.
+func newAutoSealDisabledWarner() *autoSealDisabledWarner {
+	return &autoSealDisabledWarner{
+		lastWarn: make(map[int64]time.Time),
+	}
+}
+
+// autoSealDisabledWarner warns periodically if the growing binary size of a collection opted out of the auto seal exceeds the threshold,
+// the growing segments of such collection are only sealed by the operations outside the segment assignment,
+// so the opt-out may silently become a memory leak if the orchestration is broken.
+type autoSealDisabledWarner struct {
+	mu       sync.Mutex
+	lastWarn map[int64]time.Time // the last warning time of the collection that exceeds the threshold.
+}
+
+// observe observes the growing binary size of the collections opted out of the auto seal,
+// returns the collections warned by this observation in order.
+func (w *autoSealDisabledWarner) observe(logger *log.MLogger, growingBinarySize map[int64]uint64, now time.Time) []int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	threshold := policy.GetAutoSealDisabledWarnThreshold()
+	interval := policy.GetAutoSealDisabledWarnInterval()
+	warned := make([]int64, 0)
+	for collectionID, size := range growingBinarySize {
+		if threshold == 0 || size < threshold {
+			// the warning is raised right away if the collection exceeds the threshold again.
+			delete(w.lastWarn, collectionID)
+			continue
+		}
+		if last, ok := w.lastWarn[collectionID]; ok && now.Sub(last) < interval {
+			continue
+		}
+		w.lastWarn[collectionID] = now
+		warned = append(warned, collectionID)
+		logger.Warn("growing binary size of the collection opted out of the auto seal exceeds the threshold, the flush of it may be never triggered",
+			zap.Int64("collectionID", collectionID),
+			zap.Uint64("growingBinarySize", size),
+			zap.Uint64("threshold", threshold))
+	}
+	// the collection that is not opted out anymore or removed is forgotten.
+	for collectionID := range w.lastWarn {
+		if _, ok := growingBinarySize[collectionID]; !ok {
+			delete(w.lastWarn, collectionID)
+		}
+	}
+	sort.Slice(warned, func(i, j int) bool { return warned[i] < warned[j] })
+	return warned
+}
+
+// GrowingBinarySize returns the inserted binary size of the growing segments of the partition.
+func (m *partitionSegmentManager) GrowingBinarySize() uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	size := uint64(0)
+	for _, segment := range m.segments {
+		if segment.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING || segment.IsL0() {
+			continue
+		}
+		if stat := segment.GetStat(); stat != nil {
+			size += stat.Insert.BinarySize
+		}
+	}
+	return size
+}
@@ -0,0 +1,117 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+)
+
+func TestAutoSealDisabled(t *testing.T) {
+	initializeTestState(t)
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.WALSegmentAssignAutoSealDisabledCollections.Key, "1")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignAutoSealDisabledCollections.Key)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  2,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	ctx := context.Background()
+	m, err := RecoverPChannelSegmentAllocManager(ctx, types.PChannelInfo{Name: "v_auto_seal_disabled"}, f)
+	assert.NoError(t, err)
+	defer m.Close(ctx)
+
+	// the opt-out is reflected in the introspection.
+	snapshot, err := m.SnapshotCollection(1, nil, 0, 10)
+	assert.NoError(t, err)
+	assert.True(t, snapshot.AutoSealDisabled)
+	config, err := m.CollectionAssignmentConfig(1)
+	assert.NoError(t, err)
+	assert.True(t, config.GetAutoSealDisabled())
+
+	// the full segment 2000 is not sealed by the policy sweep, but the recovered sealed segment 4000 is still flushed.
+	m.TryToSealSegments(ctx)
+	state, ok := m.SegmentState(2000)
+	assert.True(t, ok)
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING, state)
+	assert.Empty(t, savedSegmentStates(2000))
+	_, ok = m.SegmentState(4000)
+	assert.False(t, ok)
+
+	// the hard memory protection still sheds the largest growing segment of the opted-out collection.
+	belongs := resource.Resource().SegmentAssignStatsManager().SealByTotalGrowingSegmentsSize(1)
+	assert.NotNil(t, belongs)
+	assert.Equal(t, int64(2000), belongs.SegmentID)
+	m.MustSealSegments(ctx, *belongs)
+	assert.Equal(t, []streamingpb.SegmentAssignmentState{
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED,
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED,
+	}, savedSegmentStates(2000))
+	_, ok = m.SegmentState(2000)
+	assert.False(t, ok)
+
+	// the fence of the manual flush still seals the rest growing segments.
+	sealed, err := m.SealAndFenceSegmentUntil(ctx, 1, tsoutil.GetCurrentTime())
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int64{3000, 5000, 6000}, sealed)
+
+	// the applied collection config takes precedence over the param.
+	assert.NoError(t, m.ApplyCollectionAssignmentConfig(1, &streamingpb.CollectionAssignmentConfig{
+		StorageVersion:   storage.StorageV2,
+		AutoSealDisabled: false,
+	}))
+	defer policy.RemoveCollectionAssignmentConfig(1)
+	assert.False(t, policy.IsAutoSealDisabled(1))
+	snapshot, err = m.SnapshotCollection(1, nil, 0, 10)
+	assert.NoError(t, err)
+	assert.False(t, snapshot.AutoSealDisabled)
+}
+
+func TestAutoSealDisabledWarner(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.WALSegmentAssignAutoSealDisabledWarnThreshold.Key, "1")
+	params.Save(params.StreamingCfg.WALSegmentAssignAutoSealDisabledWarnInterval.Key, "1m")
+	defer func() {
+		params.Reset(params.StreamingCfg.WALSegmentAssignAutoSealDisabledWarnThreshold.Key)
+		params.Reset(params.StreamingCfg.WALSegmentAssignAutoSealDisabledWarnInterval.Key)
+	}()
+
+	w := newAutoSealDisabledWarner()
+	logger := log.With()
+	now := time.Now()
+	above, below := uint64(2*1024*1024), uint64(1024)
+
+	assert.Equal(t, []int64{1}, w.observe(logger, map[int64]uint64{1: above, 2: below}, now))
+	// the warning is not repeated within the interval.
+	assert.Empty(t, w.observe(logger, map[int64]uint64{1: above, 2: below}, now.Add(time.Second)))
+	assert.Equal(t, []int64{1, 2}, w.observe(logger, map[int64]uint64{1: above, 2: above}, now.Add(time.Minute)))
+
+	// the warning is raised right away if the collection exceeds the threshold again.
+	assert.Empty(t, w.observe(logger, map[int64]uint64{1: below, 2: above}, now.Add(time.Minute+time.Second)))
+	assert.Equal(t, []int64{1}, w.observe(logger, map[int64]uint64{1: above, 2: above}, now.Add(time.Minute+2*time.Second)))
+
+	// the warning is disabled by the zero threshold.
+	params.Save(params.StreamingCfg.WALSegmentAssignAutoSealDisabledWarnThreshold.Key, "0")
+	assert.Empty(t, w.observe(logger, map[int64]uint64{1: above, 2: above}, now.Add(time.Hour)))
+}
@@ -0,0 +1,62 @@
+package manager
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/faultinject"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// ErrCatalogTimeout is returned if the catalog operation exceeds its per-operation timeout.
+// It's a retryable condition, the sweep retries it at the next cycle, and the insert is retried by the client later.
+var ErrCatalogTimeout = errors.New("segment assignment catalog operation timeout")
+
+// catalogPath is the path that the catalog operation is issued on, every path has its own timeout.
+type catalogPath string
+
+const (
+	catalogPathAssign catalogPath = "assign" // the operations on the insert path, such as allocating a new growing segment.
+	catalogPathSweep  catalogPath = "sweep"  // the operations on the seal sweep path, such as sealing or flushing a segment.
+)
+
+// timeout returns the per-operation timeout of the path.
+func (p catalogPath) timeout() time.Duration {
+	if p == catalogPathAssign {
+		return paramtable.Get().StreamingCfg.WALSegmentAssignCatalogAssignTimeout.GetAsDurationByParse()
+	}
+	return paramtable.Get().StreamingCfg.WALSegmentAssignCatalogSweepTimeout.GetAsDurationByParse()
+}
+
+// saveSegmentAssignments saves the segment assignment metas into catalog with the timeout of the path.
+func saveSegmentAssignments(ctx context.Context, path catalogPath, metrics *metricsutil.SegmentAssignMetrics, pchannel string, metas map[int64]*streamingpb.SegmentAssignmentMeta) error {
+	return doCatalogOperation(ctx, path, metrics, func(ctx context.Context) error {
+		if err := resource.Resource().FaultInjector().Inject(ctx, faultinject.PointCatalogWrite); err != nil {
+			return err
+		}
+		return resource.Resource().StreamingNodeCatalog().SaveSegmentAssignments(ctx, pchannel, metas)
+	})
+}
+
+// doCatalogOperation executes the catalog operation with a bounded context derived from the timeout of the path,
+// so a hung catalog can never block the caller indefinitely.
+// The exceeded deadline of the derived context is converted into ErrCatalogTimeout,
+// the cancellation or deadline of the parent context is returned as is.
+func doCatalogOperation(ctx context.Context, path catalogPath, metrics *metricsutil.SegmentAssignMetrics, op func(ctx context.Context) error) error {
+	opCtx, cancel := context.WithTimeout(ctx, path.timeout())
+	defer cancel()
+
+	start := time.Now()
+	err := op(opCtx)
+	timeout := err != nil && ctx.Err() == nil && errors.Is(opCtx.Err(), context.DeadlineExceeded)
+	metrics.ObserveCatalogOperation(string(path), time.Since(start), timeout)
+	if timeout {
+		return errors.Mark(errors.Wrapf(err, "catalog operation on %s path exceeds %s", path, path.timeout()), ErrCatalogTimeout)
+	}
+	return err
+}
@@ -0,0 +1,196 @@
+package manager
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// ErrCollectionCircuitOpen is returned when the circuit breaker of the collection is open,
+// the assign request of the collection will fail fast until the cool down is over.
+var ErrCollectionCircuitOpen = errors.New("collection circuit breaker is open")
+
+// circuitBreakerState is the state of the circuit breaker.
+type circuitBreakerState string
+
+const (
+	circuitBreakerStateClosed   circuitBreakerState = "closed"
+	circuitBreakerStateOpen     circuitBreakerState = "open"
+	circuitBreakerStateHalfOpen circuitBreakerState = "half_open"
+)
+
+// collectionCircuitBreaker is the circuit breaker of a collection.
+type collectionCircuitBreaker struct {
+	state               circuitBreakerState
+	consecutiveFailures int
+	openedAt            time.Time
+	probing             bool // there's a probe request on flying when half open.
+}
+
+// newCircuitBreakers creates a new circuit breaker set.
+func newCircuitBreakers(logger *log.MLogger, metrics *metricsutil.SegmentAssignMetrics) *circuitBreakers {
+	return &circuitBreakers{
+		logger:   logger,
+		metrics:  metrics,
+		breakers: make(map[int64]*collectionCircuitBreaker),
+		now:      resource.Resource().Clock().Now,
+	}
+}
+
+// circuitBreakers manages the circuit breakers of all collections on the pchannel.
+// The breaker is opened after too many consecutive hard failures of segment assignment,
+// and become half open after the cool down, a probe request is allowed to pass to close it.
+type circuitBreakers struct {
+	mu       sync.Mutex
+	logger   *log.MLogger
+	metrics  *metricsutil.SegmentAssignMetrics
+	breakers map[int64]*collectionCircuitBreaker
+	now      func() time.Time
+}
+
+// Allow checks if the assign request of the collection can be done.
+func (c *circuitBreakers) Allow(collectionID int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.breakers[collectionID]
+	if !ok {
+		return nil
+	}
+	switch b.state {
+	case circuitBreakerStateOpen:
+		cooldown := paramtable.Get().StreamingCfg.WALSegmentAssignCircuitBreakerCooldown.GetAsDurationByParse()
+		if c.now().Sub(b.openedAt) < cooldown {
+			return ErrCollectionCircuitOpen
+		}
+		c.transferState(collectionID, b, circuitBreakerStateHalfOpen)
+		b.probing = true
+		return nil
+	case circuitBreakerStateHalfOpen:
+		if b.probing {
+			return ErrCollectionCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Record records the result of the assign request of the collection.
+func (c *circuitBreakers) Record(collectionID int64, err error) {
+	threshold := paramtable.Get().StreamingCfg.WALSegmentAssignCircuitBreakerFailureThreshold.GetAsInt()
+	if threshold <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.breakers[collectionID]
+	if !isHardAssignFailure(err) {
+		if !ok {
+			return
+		}
+		if err == nil {
+			// any success closes the breaker.
+			if b.state != circuitBreakerStateClosed {
+				c.transferState(collectionID, b, circuitBreakerStateClosed)
+			}
+			delete(c.breakers, collectionID)
+			return
+		}
+		// the retryable failure doesn't change the state, but the probe is done.
+		b.probing = false
+		return
+	}
+
+	if !ok {
+		b = &collectionCircuitBreaker{state: circuitBreakerStateClosed}
+		c.breakers[collectionID] = b
+	}
+	b.consecutiveFailures++
+	b.probing = false
+	switch b.state {
+	case circuitBreakerStateHalfOpen:
+		// the probe is failed, open the breaker again.
+		b.openedAt = c.now()
+		c.transferState(collectionID, b, circuitBreakerStateOpen)
+	case circuitBreakerStateClosed:
+		if b.consecutiveFailures >= threshold {
+			b.openedAt = c.now()
+			c.transferState(collectionID, b, circuitBreakerStateOpen)
+		}
+	}
+}
+
+// ForceClose forces the circuit breaker of the collection to be closed.
+func (c *circuitBreakers) ForceClose(collectionID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.breakers[collectionID]
+	if !ok {
+		return
+	}
+	if b.state != circuitBreakerStateClosed {
+		c.transferState(collectionID, b, circuitBreakerStateClosed)
+	}
+	delete(c.breakers, collectionID)
+}
+
+// Remove removes the circuit breaker of the collection.
+func (c *circuitBreakers) Remove(collectionID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.breakers, collectionID)
+}
+
+// State returns the state of the circuit breaker of the collection.
+func (c *circuitBreakers) State(collectionID int64) circuitBreakerState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if b, ok := c.breakers[collectionID]; ok {
+		return b.state
+	}
+	return circuitBreakerStateClosed
+}
+
+// transferState transfers the state of the circuit breaker.
+func (c *circuitBreakers) transferState(collectionID int64, b *collectionCircuitBreaker, state circuitBreakerState) {
+	c.logger.Warn("collection circuit breaker state changed",
+		zap.Int64("collectionID", collectionID),
+		zap.String("from", string(b.state)),
+		zap.String("to", string(state)),
+		zap.Int("consecutiveFailures", b.consecutiveFailures))
+	b.state = state
+	c.metrics.ObserveCircuitBreakerTransition(string(state))
+}
+
+// isHardAssignFailure checks if the error is a hard failure of segment assignment.
+// The retryable or backpressure error is not counted as hard failure.
+func isHardAssignFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	return !errors.IsAny(err,
+		ErrTimeTickTooOld,
+		ErrFencedAssign,
+		ErrPartitionDropping,
+		ErrTooLargeInsert,
+		ErrNotEnoughSpace,
+		ErrCollectionCircuitOpen,
+		context.Canceled,
+		context.DeadlineExceeded,
+	)
+}
@@ -0,0 +1,93 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestCircuitBreakers(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignCircuitBreakerFailureThreshold.Key, "3")
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignCircuitBreakerCooldown.Key, "10s")
+	defer func() {
+		paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignCircuitBreakerFailureThreshold.Key)
+		paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignCircuitBreakerCooldown.Key)
+	}()
+
+	now := time.Now()
+	b := newCircuitBreakers(log.With(), metricsutil.NewSegmentAssignMetrics("test"))
+	b.now = func() time.Time { return now }
+	hardErr := errors.New("alloc segment failed")
+
+	// retryable failures never open the breaker.
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, b.Allow(1))
+		b.Record(1, ErrTimeTickTooOld)
+		b.Record(1, context.Canceled)
+	}
+	assert.Equal(t, circuitBreakerStateClosed, b.State(1))
+
+	// a success resets the consecutive failures.
+	b.Record(1, hardErr)
+	b.Record(1, hardErr)
+	b.Record(1, nil)
+	b.Record(1, hardErr)
+	assert.Equal(t, circuitBreakerStateClosed, b.State(1))
+	assert.NoError(t, b.Allow(1))
+
+	// consecutive hard failures open the breaker.
+	b.Record(1, hardErr)
+	b.Record(1, hardErr)
+	assert.Equal(t, circuitBreakerStateOpen, b.State(1))
+	assert.ErrorIs(t, b.Allow(1), ErrCollectionCircuitOpen)
+	// other collections are not affected.
+	assert.NoError(t, b.Allow(2))
+
+	// half open after cool down, only one probe is allowed.
+	now = now.Add(11 * time.Second)
+	assert.NoError(t, b.Allow(1))
+	assert.Equal(t, circuitBreakerStateHalfOpen, b.State(1))
+	assert.ErrorIs(t, b.Allow(1), ErrCollectionCircuitOpen)
+
+	// probe failed, open again.
+	b.Record(1, hardErr)
+	assert.Equal(t, circuitBreakerStateOpen, b.State(1))
+	assert.ErrorIs(t, b.Allow(1), ErrCollectionCircuitOpen)
+
+	// probe with retryable failure, keep half open and allow next probe.
+	now = now.Add(11 * time.Second)
+	assert.NoError(t, b.Allow(1))
+	b.Record(1, ErrTimeTickTooOld)
+	assert.Equal(t, circuitBreakerStateHalfOpen, b.State(1))
+	assert.NoError(t, b.Allow(1))
+
+	// probe succeeds, close the breaker.
+	b.Record(1, nil)
+	assert.Equal(t, circuitBreakerStateClosed, b.State(1))
+	assert.NoError(t, b.Allow(1))
+
+	// force close by admin.
+	b.Record(1, hardErr)
+	b.Record(1, hardErr)
+	b.Record(1, hardErr)
+	assert.Equal(t, circuitBreakerStateOpen, b.State(1))
+	b.ForceClose(1)
+	assert.Equal(t, circuitBreakerStateClosed, b.State(1))
+	assert.NoError(t, b.Allow(1))
+
+	// disabled circuit breaker never opens.
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignCircuitBreakerFailureThreshold.Key, "0")
+	for i := 0; i < 10; i++ {
+		b.Record(3, hardErr)
+	}
+	assert.Equal(t, circuitBreakerStateClosed, b.State(3))
+	b.Remove(1)
+}
@@ -0,0 +1,32 @@
+package manager
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+)
+
+// checkCreationRate checks the projected segment creation rate of the collection when a new growing segment is created.
+// The collection with aggressive small segment settings can burn through the segment ids and catalog rows,
+// so it's reported once with a warning and a metric when the projection crosses the alert threshold,
+// the warning carries the limitation policy of the new segment to find the settings driving the rate.
+// It's purely observational, the creation is never rejected.
+func (m *partitionSegmentManager) checkCreationRate(limitation policy.SegmentLimitation) {
+	projection, crossed := resource.Resource().SegmentAssignStatsManager().ObserveSegmentCreated(m.collectionID)
+	if !crossed {
+		return
+	}
+	m.metrics.ObserveCreationRateAlert(m.collectionID)
+	m.logger.Warn("SEGMENT CREATION RATE TOO HIGH: projected segment creation rate of collection crosses the alert threshold, check the segment limitation policy",
+		zap.Int("createdInWindow", projection.CreatedInWindow),
+		zap.Duration("window", projection.Window),
+		zap.Float64("segmentsPerDay", projection.SegmentsPerDay),
+		zap.Int64("threshold", projection.Threshold),
+		zap.Duration("timeToThreshold", projection.TimeToThreshold),
+		zap.String("limitationPolicy", limitation.PolicyName),
+		zap.Uint64("segmentBinarySize", limitation.SegmentSize),
+		zap.Any("extraInfo", limitation.ExtraInfo),
+		zap.Bool("smallCollection", policy.IsSmallCollection(m.collectionID)),
+		zap.Any("assignmentConfig", policy.ExportCollectionAssignmentConfig(m.collectionID)))
+}
@@ -0,0 +1,344 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+const (
+	defaultSnapshotPartitionLimit = 100
+	maxSnapshotPartitionLimit     = 1000
+)
+
+// ServeSegmentAssignmentSnapshot serves the read-only segment assignment snapshot of a collection on a pchannel as json.
+// Query params:
+//   - pchannel: required, the name of pchannel.
+//   - collection: required, the collection id.
+//   - partition: optional, comma separated partition ids, all partitions are returned if not given.
+//   - offset, limit: optional, paginate the partitions sorted by partition id, at most 1000 partitions in one page.
+//   - origin: optional, only the segments of the origin are returned, such as "recovery_reconciled".
+func ServeSegmentAssignmentSnapshot(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	pchannel := query.Get("pchannel")
+	if pchannel == "" {
+		writeSnapshotError(w, http.StatusBadRequest, "pchannel is required")
+		return
+	}
+	collectionID, err := strconv.ParseInt(query.Get("collection"), 10, 64)
+	if err != nil {
+		writeSnapshotError(w, http.StatusBadRequest, fmt.Sprintf("invalid collection, %s", err.Error()))
+		return
+	}
+	var partitionIDs []int64
+	if partitions := query.Get("partition"); partitions != "" {
+		for _, partition := range strings.Split(partitions, ",") {
+			partitionID, err := strconv.ParseInt(strings.TrimSpace(partition), 10, 64)
+			if err != nil {
+				writeSnapshotError(w, http.StatusBadRequest, fmt.Sprintf("invalid partition, %s", err.Error()))
+				return
+			}
+			partitionIDs = append(partitionIDs, partitionID)
+		}
+	}
+	offset, err := parseSnapshotIntParam(query.Get("offset"), 0)
+	if err != nil || offset < 0 {
+		writeSnapshotError(w, http.StatusBadRequest, "invalid offset")
+		return
+	}
+	limit, err := parseSnapshotIntParam(query.Get("limit"), defaultSnapshotPartitionLimit)
+	if err != nil || limit <= 0 {
+		writeSnapshotError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	if limit > maxSnapshotPartitionLimit {
+		limit = maxSnapshotPartitionLimit
+	}
+	var origin *streamingpb.SegmentAssignmentOrigin
+	if param := query.Get("origin"); param != "" {
+		o, err := parseSegmentAssignmentOrigin(param)
+		if err != nil {
+			writeSnapshotError(w, http.StatusBadRequest, fmt.Sprintf("invalid origin, %s", err.Error()))
+			return
+		}
+		origin = &o
+	}
+
+	pm, ok := getPChannelManagerForDebug(w, pchannel)
+	if !ok {
+		return
+	}
+	snapshot, err := pm.SnapshotCollection(collectionID, partitionIDs, offset, limit)
+	if err != nil {
+		writeSnapshotError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if origin != nil {
+		snapshot.FilterByOrigin(*origin)
+	}
+	writeSnapshotJSON(w, snapshot)
+}
+
+// ServeExplainAssignment serves the explanation of where a hypothetical insert would be assigned right now as json.
+// The selection logic is evaluated read-only, no state is mutated and no capacity is reserved.
+// Query params:
+//   - pchannel: required, the name of pchannel.
+//   - collection: required, the collection id.
+//   - partition: required, the partition id.
+//   - binary_size: required, the binary size of the insert.
+//   - rows: optional, the row count of the insert, 1 by default.
+//   - ts: optional, the timetick of the insert, the timetick based conditions are not evaluated if not given.
+//   - high_priority: optional, the insert is flagged as high priority.
+//   - shard_hint: optional, the shard hint of the insert.
+func ServeExplainAssignment(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	pchannel := query.Get("pchannel")
+	if pchannel == "" {
+		writeSnapshotError(w, http.StatusBadRequest, "pchannel is required")
+		return
+	}
+	collectionID, err := strconv.ParseInt(query.Get("collection"), 10, 64)
+	if err != nil {
+		writeSnapshotError(w, http.StatusBadRequest, fmt.Sprintf("invalid collection, %s", err.Error()))
+		return
+	}
+	partitionID, err := strconv.ParseInt(query.Get("partition"), 10, 64)
+	if err != nil {
+		writeSnapshotError(w, http.StatusBadRequest, fmt.Sprintf("invalid partition, %s", err.Error()))
+		return
+	}
+	binarySize, err := strconv.ParseUint(query.Get("binary_size"), 10, 64)
+	if err != nil {
+		writeSnapshotError(w, http.StatusBadRequest, fmt.Sprintf("invalid binary_size, %s", err.Error()))
+		return
+	}
+	rows, err := parseSnapshotUintParam(query.Get("rows"), 1)
+	if err != nil {
+		writeSnapshotError(w, http.StatusBadRequest, fmt.Sprintf("invalid rows, %s", err.Error()))
+		return
+	}
+	ts, err := parseSnapshotUintParam(query.Get("ts"), 0)
+	if err != nil {
+		writeSnapshotError(w, http.StatusBadRequest, fmt.Sprintf("invalid ts, %s", err.Error()))
+		return
+	}
+	shardHint, err := parseSnapshotUintParam(query.Get("shard_hint"), 0)
+	if err != nil {
+		writeSnapshotError(w, http.StatusBadRequest, fmt.Sprintf("invalid shard_hint, %s", err.Error()))
+		return
+	}
+	highPriority := false
+	if param := query.Get("high_priority"); param != "" {
+		if highPriority, err = strconv.ParseBool(param); err != nil {
+			writeSnapshotError(w, http.StatusBadRequest, fmt.Sprintf("invalid high_priority, %s", err.Error()))
+			return
+		}
+	}
+
+	pm, ok := getPChannelManagerForDebug(w, pchannel)
+	if !ok {
+		return
+	}
+	explanation, err := pm.ExplainAssignment(req.Context(), &ExplainAssignmentRequest{
+		CollectionID:  collectionID,
+		PartitionID:   partitionID,
+		InsertMetrics: stats.InsertMetrics{Rows: rows, BinarySize: binarySize},
+		TimeTick:      ts,
+		HighPriority:  highPriority,
+		ShardHint:     shardHint,
+	})
+	if err != nil {
+		writeSnapshotError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeSnapshotJSON(w, explanation)
+}
+
+// ServeResetCollectionCircuitBreaker forces the segment assignment circuit breaker of a collection to be closed.
+// Query params:
+//   - pchannel: required, the name of pchannel.
+//   - collection: required, the collection id.
+func ServeResetCollectionCircuitBreaker(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeSnapshotError(w, http.StatusMethodNotAllowed, "only POST is allowed")
+		return
+	}
+	query := req.URL.Query()
+	pchannel := query.Get("pchannel")
+	if pchannel == "" {
+		writeSnapshotError(w, http.StatusBadRequest, "pchannel is required")
+		return
+	}
+	collectionID, err := strconv.ParseInt(query.Get("collection"), 10, 64)
+	if err != nil {
+		writeSnapshotError(w, http.StatusBadRequest, fmt.Sprintf("invalid collection, %s", err.Error()))
+		return
+	}
+	pm, ok := getPChannelManagerForDebug(w, pchannel)
+	if !ok {
+		return
+	}
+	pm.ResetCollectionCircuitBreaker(collectionID)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"msg": "OK"}`))
+}
+
+// ServeFlushSegmentsOlderThan seals the growing segments of a collection whose assigned data are all not newer than the timetick.
+// No fence is applied, so the ingest of the collection is never blocked.
+// Query params:
+//   - pchannel: required, the name of pchannel.
+//   - collection: required, the collection id.
+//   - ts: required, the timetick.
+func ServeFlushSegmentsOlderThan(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeSnapshotError(w, http.StatusMethodNotAllowed, "only POST is allowed")
+		return
+	}
+	query := req.URL.Query()
+	pchannel := query.Get("pchannel")
+	if pchannel == "" {
+		writeSnapshotError(w, http.StatusBadRequest, "pchannel is required")
+		return
+	}
+	collectionID, err := strconv.ParseInt(query.Get("collection"), 10, 64)
+	if err != nil {
+		writeSnapshotError(w, http.StatusBadRequest, fmt.Sprintf("invalid collection, %s", err.Error()))
+		return
+	}
+	ts, err := strconv.ParseUint(query.Get("ts"), 10, 64)
+	if err != nil {
+		writeSnapshotError(w, http.StatusBadRequest, fmt.Sprintf("invalid ts, %s", err.Error()))
+		return
+	}
+	pm, ok := getPChannelManagerForDebug(w, pchannel)
+	if !ok {
+		return
+	}
+	segmentIDs, err := pm.SealSegmentsOlderThan(req.Context(), collectionID, ts)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, ErrCollectionNotFound) {
+			code = http.StatusNotFound
+		} else if errors.IsAny(err, context.Canceled, context.DeadlineExceeded) {
+			code = http.StatusRequestTimeout
+		}
+		writeSnapshotError(w, code, err.Error())
+		return
+	}
+	writeSnapshotJSON(w, map[string][]int64{"segment_ids": segmentIDs})
+}
+
+// ServeRecoverySummary serves the summary of the last successful segment assignment recovery of a pchannel as json.
+// The summary is retained until the next recovery of the pchannel.
+// Query params:
+//   - pchannel: required, the name of pchannel.
+func ServeRecoverySummary(w http.ResponseWriter, req *http.Request) {
+	pchannel := req.URL.Query().Get("pchannel")
+	if pchannel == "" {
+		writeSnapshotError(w, http.StatusBadRequest, "pchannel is required")
+		return
+	}
+	summary, ok := GetRecoverySummary(pchannel)
+	if !ok {
+		writeSnapshotError(w, http.StatusNotFound, fmt.Sprintf("recovery summary of pchannel %s not found", pchannel))
+		return
+	}
+	writeSnapshotJSON(w, summary)
+}
+
+// ServeSealHistory serves the recent seal events of a pchannel as json, the newest first.
+// The history is kept in memory and bounded by streaming.walSegmentAssign.sealHistorySize, it's lost after the pchannel is reassigned.
+// Query params:
+//   - pchannel: required, the name of pchannel.
+func ServeSealHistory(w http.ResponseWriter, req *http.Request) {
+	pchannel := req.URL.Query().Get("pchannel")
+	if pchannel == "" {
+		writeSnapshotError(w, http.StatusBadRequest, "pchannel is required")
+		return
+	}
+	pm, ok := getPChannelManagerForDebug(w, pchannel)
+	if !ok {
+		return
+	}
+	writeSnapshotJSON(w, pm.SealHistory())
+}
+
+// ServeSourceStats serves the top source nodes by segment assignments on current node as json.
+// The assignments are aggregated since the node is started or the last reset.
+// Query params:
+//   - top: optional, the count of the top sources, streaming.walSegmentAssign.sourceStats.topK by default.
+func ServeSourceStats(w http.ResponseWriter, req *http.Request) {
+	topK, err := parseSnapshotIntParam(req.URL.Query().Get("top"), paramtable.Get().StreamingCfg.WALSegmentAssignSourceStatsTopK.GetAsInt())
+	if err != nil {
+		writeSnapshotError(w, http.StatusBadRequest, fmt.Sprintf("invalid top, %s", err.Error()))
+		return
+	}
+	writeSnapshotJSON(w, resource.Resource().SegmentAssignStatsManager().GetTopSources(topK))
+}
+
+// ServeResetSourceStats clears the segment assignments aggregated by source node on current node.
+func ServeResetSourceStats(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeSnapshotError(w, http.StatusMethodNotAllowed, "only POST is allowed")
+		return
+	}
+	resource.Resource().SegmentAssignStatsManager().ResetSourceStats()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"msg": "OK"}`))
+}
+
+// getPChannelManagerForDebug gets the pchannel manager from the inspector, write the error into response if not found.
+func getPChannelManagerForDebug(w http.ResponseWriter, pchannel string) (*PChannelSegmentAllocManager, bool) {
+	pm, err := getPChannelSegmentAllocManager(pchannel)
+	if err != nil {
+		writeSnapshotError(w, http.StatusNotFound, err.Error())
+		return nil, false
+	}
+	return pm, true
+}
+
+// parseSnapshotIntParam parses the int query param, return the default value if the param is empty.
+func parseSnapshotIntParam(param string, defaultValue int) (int, error) {
+	if param == "" {
+		return defaultValue, nil
+	}
+	return strconv.Atoi(param)
+}
+
+// parseSnapshotUintParam parses the uint64 query param, return the default value if the param is empty.
+func parseSnapshotUintParam(param string, defaultValue uint64) (uint64, error) {
+	if param == "" {
+		return defaultValue, nil
+	}
+	return strconv.ParseUint(param, 10, 64)
+}
+
+// writeSnapshotJSON writes the value into response as json.
+func writeSnapshotJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Warn("failed to send the segment assignment debug response", zap.Error(err))
+	}
+}
+
+// writeSnapshotError writes the error message into response.
+func writeSnapshotError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write([]byte(fmt.Sprintf(`{"msg": %q}`, msg)))
+}
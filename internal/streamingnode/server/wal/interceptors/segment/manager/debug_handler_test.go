@@ -0,0 +1,339 @@
+package manager
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/inspector"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+)
+
+func TestServeSegmentAssignmentSnapshot(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "debug"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer func() {
+		inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+		m.Close(context.Background())
+	}()
+
+	serve := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/debug/streamingnode/segment/assignment?"+query, nil)
+		recorder := httptest.NewRecorder()
+		ServeSegmentAssignmentSnapshot(recorder, req)
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusBadRequest, serve("").Code)
+	assert.Equal(t, http.StatusBadRequest, serve("pchannel=debug&collection=abc").Code)
+	assert.Equal(t, http.StatusBadRequest, serve("pchannel=debug&collection=1&limit=0").Code)
+	assert.Equal(t, http.StatusNotFound, serve("pchannel=notexist&collection=1").Code)
+	assert.Equal(t, http.StatusNotFound, serve("pchannel=debug&collection=100").Code)
+
+	resp := serve("pchannel=debug&collection=1")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	snapshot := &CollectionAssignmentSnapshot{}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), snapshot))
+	assert.Equal(t, 3, snapshot.TotalPartitions)
+	assert.Len(t, snapshot.Partitions, 3)
+	assert.Equal(t, int64(1), snapshot.Partitions[0].PartitionID)
+
+	// paginate the partitions.
+	resp = serve("pchannel=debug&collection=1&offset=1&limit=1")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	snapshot = &CollectionAssignmentSnapshot{}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), snapshot))
+	assert.Equal(t, 3, snapshot.TotalPartitions)
+	assert.Len(t, snapshot.Partitions, 1)
+	assert.Equal(t, int64(2), snapshot.Partitions[0].PartitionID)
+
+	// filter the partitions.
+	resp = serve("pchannel=debug&collection=1&partition=2")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	snapshot = &CollectionAssignmentSnapshot{}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), snapshot))
+	assert.Equal(t, 1, snapshot.TotalPartitions)
+	assert.Len(t, snapshot.Partitions, 1)
+	for _, segment := range snapshot.Partitions[0].Segments {
+		if segment.SegmentID == 2000 {
+			assert.Equal(t, float64(100), segment.FillPercent)
+		}
+	}
+
+	// filter the segments by origin, the recovered pending segment is reconciled by recovery.
+	assert.Equal(t, http.StatusBadRequest, serve("pchannel=debug&collection=1&origin=unknown").Code)
+	resp = serve("pchannel=debug&collection=1&partition=1,2&origin=recovery_reconciled")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	snapshot = &CollectionAssignmentSnapshot{}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), snapshot))
+	assert.Len(t, snapshot.Partitions, 2)
+	assert.Len(t, snapshot.Partitions[0].Segments, 1)
+	assert.Equal(t, int64(1000), snapshot.Partitions[0].Segments[0].SegmentID)
+	assert.Equal(t, "SEGMENT_ASSIGNMENT_ORIGIN_RECOVERY_RECONCILED", snapshot.Partitions[0].Segments[0].Origin)
+	assert.Empty(t, snapshot.Partitions[1].Segments)
+}
+
+func TestServeExplainAssignment(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "debug_explain"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer func() {
+		inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+		m.Close(context.Background())
+	}()
+
+	serve := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/debug/streamingnode/segment/explain_assignment?"+query, nil)
+		recorder := httptest.NewRecorder()
+		ServeExplainAssignment(recorder, req)
+		return recorder
+	}
+	explain := func(query string) *AssignmentExplanation {
+		resp := serve(query)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		explanation := &AssignmentExplanation{}
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), explanation))
+		return explanation
+	}
+
+	before, err := m.SnapshotCollection(1, nil, 0, 10)
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, serve("").Code)
+	assert.Equal(t, http.StatusBadRequest, serve("pchannel=debug_explain&collection=1&partition=2").Code)
+	assert.Equal(t, http.StatusBadRequest, serve("pchannel=debug_explain&collection=1&partition=2&binary_size=1&high_priority=abc").Code)
+	assert.Equal(t, http.StatusNotFound, serve("pchannel=notexist&collection=1&partition=2&binary_size=1").Code)
+	assert.Equal(t, http.StatusNotFound, serve("pchannel=debug_explain&collection=1&partition=100&binary_size=1").Code)
+
+	// the full segment is skipped, and the insert is assigned on the first segment that can hold it.
+	explanation := explain("pchannel=debug_explain&collection=1&partition=2&binary_size=500&rows=5")
+	assert.Equal(t, AssignmentDecisionExistingSegment, explanation.Decision)
+	assert.Equal(t, int64(3000), explanation.SegmentID)
+	assert.Equal(t, assignmentStrategyFirstFit, explanation.Strategy)
+	assert.Empty(t, explanation.Conditions)
+	assert.Equal(t, string(circuitBreakerStateClosed), explanation.CircuitBreaker)
+	assert.NotNil(t, explanation.Config)
+	outcomes := make(map[int64]string)
+	for _, candidate := range explanation.Candidates {
+		outcomes[candidate.SegmentID] = candidate.Outcome
+	}
+	assert.Equal(t, candidateNotEnoughSpace, outcomes[2000])
+	assert.Equal(t, candidateChosen, outcomes[3000])
+	assert.Equal(t, candidateNotEvaluated, outcomes[5000])
+
+	// no growing segment can hold the insert, a new growing segment would be created.
+	explanation = explain("pchannel=debug_explain&collection=1&partition=2&binary_size=950")
+	assert.Equal(t, AssignmentDecisionNewSegment, explanation.Decision)
+	assert.Zero(t, explanation.SegmentID)
+	assert.GreaterOrEqual(t, explanation.NewSegmentMaxBinarySize, uint64(950))
+
+	// the insert larger than the new growing segment is rejected.
+	explanation = explain("pchannel=debug_explain&collection=1&partition=3&binary_size=2097152")
+	assert.Equal(t, AssignmentDecisionRejected, explanation.Decision)
+	assert.Equal(t, rejectionTooLargeInsert, explanation.Rejection)
+
+	// the insert fenced by the manual flush is rejected.
+	explanation = explain("pchannel=debug_explain&collection=1&partition=3&binary_size=1&ts=1")
+	assert.Equal(t, AssignmentDecisionRejected, explanation.Decision)
+	assert.Equal(t, rejectionManualFlush, explanation.Rejection)
+
+	// the explanation never mutates the state or reserves the capacity.
+	assert.Equal(t, uint64(100), resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(3000).Insert.BinarySize)
+	after, err := m.SnapshotCollection(1, nil, 0, 10)
+	assert.NoError(t, err)
+	for i := range before.Partitions {
+		assert.Len(t, after.Partitions[i].Segments, len(before.Partitions[i].Segments))
+	}
+	result, err := m.AssignSegment(context.Background(), &AssignSegmentRequest{
+		CollectionID:  1,
+		PartitionID:   2,
+		InsertMetrics: stats.InsertMetrics{Rows: 5, BinarySize: 500},
+		TimeTick:      tsoutil.GetCurrentTime(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3000), result.SegmentID)
+	result.Ack()
+}
+
+func TestServeRecoverySummary(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	serve := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/debug/streamingnode/segment/recovery_summary?"+query, nil)
+		recorder := httptest.NewRecorder()
+		ServeRecoverySummary(recorder, req)
+		return recorder
+	}
+	assert.Equal(t, http.StatusBadRequest, serve("").Code)
+	assert.Equal(t, http.StatusNotFound, serve("pchannel=debug_recovery_summary").Code)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "debug_recovery_summary"}, f)
+	assert.NoError(t, err)
+	summary := m.RecoverySummary()
+	assert.Equal(t, "debug_recovery_summary", summary.PChannel)
+	assert.Equal(t, 1, summary.Collections)
+	assert.Equal(t, 3, summary.Partitions)
+	assert.Equal(t, map[string]int{
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING.String(): 1,
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING.String(): 4,
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED.String():  1,
+	}, summary.Segments)
+	assert.Equal(t, 6, summary.Valid)
+	assert.Zero(t, summary.Repaired)
+	assert.Zero(t, summary.Quarantined)
+	assert.Zero(t, summary.Orphans)
+	assert.Zero(t, summary.Discarded)
+	stages := make([]string, 0, len(summary.Stages))
+	var total time.Duration
+	for _, stage := range summary.Stages {
+		stages = append(stages, stage.Name)
+		total += stage.Duration
+	}
+	assert.Equal(t, []string{recoveryStageListSegments, recoveryStageListVChannels, recoveryStageGetPChannel, recoveryStageBuildManagers}, stages)
+	assert.Equal(t, summary.Duration, total)
+
+	// the summary is retained after the manager is closed until the next recovery.
+	m.Close(context.Background())
+	resp := serve("pchannel=debug_recovery_summary")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	retained := &RecoverySummary{}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), retained))
+	assert.Equal(t, summary.Segments, retained.Segments)
+	assert.Equal(t, summary.Valid, retained.Valid)
+
+	m, err = RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "debug_recovery_summary"}, f)
+	assert.NoError(t, err)
+	defer m.Close(context.Background())
+	latest, ok := GetRecoverySummary("debug_recovery_summary")
+	assert.True(t, ok)
+	assert.Same(t, m.RecoverySummary(), latest)
+}
+
+func TestServeSealHistory(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "debug_seal_history"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer func() {
+		inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+		m.Close(context.Background())
+	}()
+
+	serve := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/debug/streamingnode/segment/seal_history?"+query, nil)
+		recorder := httptest.NewRecorder()
+		ServeSealHistory(recorder, req)
+		return recorder
+	}
+	assert.Equal(t, http.StatusBadRequest, serve("").Code)
+	assert.Equal(t, http.StatusNotFound, serve("pchannel=notexist").Code)
+
+	m.MustSealSegments(context.Background(), stats.SegmentBelongs{PChannel: "debug_seal_history", CollectionID: 1, PartitionID: 2, SegmentID: 3000})
+	resp := serve("pchannel=debug_seal_history")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	events := make([]SealEvent, 0)
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &events))
+	assert.Len(t, events, 1)
+	assert.Equal(t, int64(3000), events[0].SegmentID)
+}
+
+func TestServeSourceStats(t *testing.T) {
+	initializeTestState(t)
+	resource.Resource().SegmentAssignStatsManager().ResetSourceStats()
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "debug_source_stats"}, f)
+	assert.NoError(t, err)
+	defer m.Close(context.Background())
+	for _, source := range []string{"1", "1", "2", ""} {
+		result, err := m.AssignSegment(context.Background(), &AssignSegmentRequest{
+			CollectionID:  1,
+			PartitionID:   3,
+			InsertMetrics: stats.InsertMetrics{Rows: 1, BinarySize: 10},
+			TimeTick:      tsoutil.GetCurrentTime(),
+			Source:        source,
+		})
+		assert.NoError(t, err)
+		result.Ack()
+	}
+
+	serve := func(method string, path string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		return recorder
+	}
+	recorder := serve(http.MethodGet, "/debug/streamingnode/segment/source_stats?top=1", ServeSourceStats)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var sources []stats.SourceStats
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &sources))
+	assert.Equal(t, []stats.SourceStats{
+		{Source: "1", Assignments: 2, BinarySize: 20},
+		{Source: stats.SourceOther, Assignments: 2, BinarySize: 20},
+	}, sources)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/debug/streamingnode/segment/source_stats?top=x", ServeSourceStats).Code)
+
+	// the aggregation is cleared by the reset.
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodGet, "/debug/streamingnode/segment/source_stats/reset", ServeResetSourceStats).Code)
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/debug/streamingnode/segment/source_stats/reset", ServeResetSourceStats).Code)
+	assert.Empty(t, resource.Resource().SegmentAssignStatsManager().GetTopSources(10))
+}
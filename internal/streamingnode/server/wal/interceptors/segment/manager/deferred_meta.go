@@ -0,0 +1,176 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v2/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// isDeferMetaCreationEnabled returns whether the first catalog write of the new growing segment is deferred until its first durable insert.
+func isDeferMetaCreationEnabled() bool {
+	return paramtable.Get().StreamingCfg.WALSegmentAssignDeferMetaCreationEnabled.GetAsBool()
+}
+
+// deferMeta defers the catalog write of the new segment until its first insert is durably appended.
+// The segment that never receives an insert is never written into catalog until it's sealed,
+// the allocation at the coordinator is the only record of it, and it's adopted by the recovery if the node crashes.
+func (s *segmentAllocManager) deferMeta() {
+	s.deferMu.Lock()
+	defer s.deferMu.Unlock()
+	s.metaDeferred.Store(true)
+}
+
+// isMetaDeferred returns whether the meta of the segment is held in memory only.
+func (s *segmentAllocManager) isMetaDeferred() bool {
+	return s.metaDeferred.Load()
+}
+
+// asyncPersistDeferredMeta persists the deferred meta of the segment in background, it's a no-op if the meta is not deferred.
+// It's called in the append path, so the catalog write never blocks the append,
+// at most one persist is in flight for a segment, and the catalog write is bounded by the timeout of the assign path.
+func (s *segmentAllocManager) asyncPersistDeferredMeta() {
+	if !s.isMetaDeferred() || !s.metaPersisting.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer s.metaPersisting.Store(false)
+		s.persistDeferredMeta(context.Background())
+	}()
+}
+
+// persistDeferredMeta persists the deferred meta of the segment into catalog, it's a no-op if the meta is not deferred.
+// The failure is only logged, the meta keeps deferred and the persist is retried by the next durable insert or the seal.
+func (s *segmentAllocManager) persistDeferredMeta(ctx context.Context) {
+	s.deferMu.Lock()
+	defer s.deferMu.Unlock()
+
+	if !s.metaDeferred.Load() || s.closed.Load() {
+		// the segment without durable meta of the closed manager is adopted by the next recovery.
+		return
+	}
+	logger := log.With(
+		zap.String("pchannel", s.pchannel.Name),
+		zap.Int64("collectionID", s.GetCollectionID()),
+		zap.Int64("partitionID", s.GetPartitionID()),
+		zap.Int64("segmentID", s.GetSegmentID()))
+	if s.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED {
+		// the flushed segment is removed from catalog, there's nothing to persist.
+		s.metaDeferred.Store(false)
+		return
+	}
+	snapshot := s.Snapshot()
+	if err := saveSegmentAssignments(ctx, catalogPathAssign, s.metrics, s.pchannel.Name, map[int64]*streamingpb.SegmentAssignmentMeta{
+		s.GetSegmentID(): snapshot,
+	}); err != nil {
+		logger.Warn("failed to persist deferred meta of segment, retry it at next durable insert or seal", zap.Error(err))
+		return
+	}
+	s.persistedPartitionLastAssign = snapshot.GetPartitionLastAssignTimeTick()
+	s.markOriginPersisted()
+	s.metaDeferred.Store(false)
+	logger.Info("deferred meta of segment is persisted", zap.String("state", snapshot.GetState().String()))
+}
+
+// adoptDeferredSegments adopts the segments that are allocated at the coordinator but unknown by the catalog.
+// They're left by the deferred meta creation if the node crashes before the meta is persisted by the first durable insert.
+// The rule is as follows:
+//   - The growing segment created by streaming of an alive partition is adopted as growing and sealed right away.
+//     The insert that may be appended before the crash is kept by the wal and the repeated flush message is ignored by the recovery storage.
+//   - The segment of a dropped collection or partition is not adopted, it's dropped by the coordinator together with the collection or partition.
+//   - The L0 segment is never deferred, so it's never seen here.
+func adoptDeferredSegments(
+	ctx context.Context,
+	pchannel types.PChannelInfo,
+	rawMetas []*streamingpb.SegmentAssignmentMeta,
+	collectionInfos []*rootcoordpb.CollectionInfoOnPChannel,
+	metrics *metricsutil.SegmentAssignMetrics,
+	source policy.RandomSource,
+) ([]*segmentAllocManager, error) {
+	knownSegments := make(map[int64]struct{}, len(rawMetas))
+	for _, rawMeta := range rawMetas {
+		knownSegments[rawMeta.GetSegmentId()] = struct{}{}
+	}
+	mix, err := resource.Resource().MixCoordClient().GetWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	adopted := make([]*segmentAllocManager, 0)
+	for _, collectionInfo := range collectionInfos {
+		resp, err := mix.GetChannelRecoveryInfo(ctx, &datapb.GetChannelRecoveryInfoRequest{Vchannel: collectionInfo.GetVchannel()})
+		if err := merr.CheckRPCCall(resp, err); err != nil {
+			if errors.Is(err, merr.ErrChannelNotAvailable) {
+				// the collection is dropped after the pchannel info is fetched.
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get recovery info of vchannel %s", collectionInfo.GetVchannel())
+		}
+		unknownSegmentIDs := make([]int64, 0)
+		for _, segmentID := range resp.GetInfo().GetUnflushedSegmentIds() {
+			if _, ok := knownSegments[segmentID]; !ok {
+				unknownSegmentIDs = append(unknownSegmentIDs, segmentID)
+			}
+		}
+		if len(unknownSegmentIDs) == 0 {
+			continue
+		}
+		infos, err := mix.GetSegmentInfo(ctx, &datapb.GetSegmentInfoRequest{SegmentIDs: unknownSegmentIDs})
+		if err := merr.CheckRPCCall(infos, err); err != nil {
+			return nil, errors.Wrapf(err, "failed to get segment info of vchannel %s", collectionInfo.GetVchannel())
+		}
+		partitionExist := make(map[int64]struct{}, len(collectionInfo.GetPartitions()))
+		for _, partition := range collectionInfo.GetPartitions() {
+			partitionExist[partition.GetPartitionId()] = struct{}{}
+		}
+		for _, info := range infos.GetInfos() {
+			logger := log.With(
+				zap.String("pchannel", pchannel.Name),
+				zap.String("vchannel", collectionInfo.GetVchannel()),
+				zap.Int64("collectionID", info.GetCollectionID()),
+				zap.Int64("partitionID", info.GetPartitionID()),
+				zap.Int64("segmentID", info.GetID()))
+			if info.GetState() != commonpb.SegmentState_Growing || !info.GetIsCreatedByStreaming() || info.GetCollectionID() != collectionInfo.GetCollectionId() {
+				continue
+			}
+			if _, ok := partitionExist[info.GetPartitionID()]; !ok {
+				logger.Info("segment unknown by catalog belongs to a dropped partition, leave it to coordinator")
+				continue
+			}
+			limitation := policy.GetSegmentLimitationPolicy().GenerateLimitation(source)
+			now := resource.Resource().Clock().Now().Unix()
+			m := newSegmentAllocManagerFromProto(pchannel, &streamingpb.SegmentAssignmentMeta{
+				CollectionId: info.GetCollectionID(),
+				PartitionId:  info.GetPartitionID(),
+				SegmentId:    info.GetID(),
+				Vchannel:     collectionInfo.GetVchannel(),
+				State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+				Stat: &streamingpb.SegmentAssignmentStat{
+					MaxBinarySize:         limitation.SegmentSize,
+					CreateTimestamp:       now,
+					LastModifiedTimestamp: now,
+				},
+				StorageVersion: info.GetStorageVersion(),
+				Level:          streamingpb.SegmentAssignmentLevel_SEGMENT_ASSIGNMENT_LEVEL_L1,
+			}, metrics)
+			// the flush message of the segment may be appended before the crash.
+			m.markFlushMaybeAppended()
+			m.markRecoveryReconciled()
+			adopted = append(adopted, m.WithSealPolicy(policy.PolicyNameAdopted))
+			logger.Info("segment unknown by catalog is adopted and sealed right away")
+		}
+	}
+	return adopted, nil
+}
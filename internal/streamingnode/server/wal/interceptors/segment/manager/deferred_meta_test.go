@@ -0,0 +1,199 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+)
+
+// newDeferMetaTestManager recovers the manager of the test state with the deferred meta creation enabled,
+// the coordinator reports the unflushed segments of the vchannel and the infos of the segments unknown by catalog.
+func newDeferMetaTestManager(t *testing.T, pchannel string, unflushed []int64, infos []*datapb.SegmentInfo) *PChannelSegmentAllocManager {
+	initializeTestState(t)
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.WALSegmentAssignDeferMetaCreationEnabled.Key, "true")
+	t.Cleanup(func() {
+		params.Reset(params.StreamingCfg.WALSegmentAssignDeferMetaCreationEnabled.Key)
+	})
+
+	mix := resource.Resource().MixCoordClient().Get().(*mocks.MockMixCoordClient)
+	mix.EXPECT().GetChannelRecoveryInfo(mock.Anything, mock.Anything).Return(&datapb.GetChannelRecoveryInfoResponse{
+		Status: merr.Success(),
+		Info:   &datapb.VchannelInfo{UnflushedSegmentIds: unflushed},
+	}, nil)
+	if len(infos) > 0 {
+		mix.EXPECT().GetSegmentInfo(mock.Anything, mock.Anything).Return(&datapb.GetSegmentInfoResponse{
+			Status: merr.Success(),
+			Infos:  infos,
+		}, nil)
+	}
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: pchannel}, f)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		m.Close(context.Background())
+	})
+	return m
+}
+
+// waitDeferredMetaPersisted waits until the deferred meta of the segment is persisted in background.
+func waitDeferredMetaPersisted(t *testing.T, m *PChannelSegmentAllocManager, partitionID int64, segmentID int64) {
+	pm, err := m.managers.Get(1, partitionID)
+	assert.NoError(t, err)
+	pm.mu.RLock()
+	var segment *segmentAllocManager
+	for _, s := range pm.segments {
+		if s.GetSegmentID() == segmentID {
+			segment = s
+		}
+	}
+	pm.mu.RUnlock()
+	assert.NotNil(t, segment)
+	assert.Eventually(t, func() bool {
+		return !segment.isMetaDeferred() && !segment.metaPersisting.Load()
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestDeferMetaCreation(t *testing.T) {
+	m := newDeferMetaTestManager(t, "v_defer_meta", []int64{2000, 3000, 5000, 6000}, nil)
+	ctx := context.Background()
+	assign := func() *AssignSegmentResult {
+		// the insert can't be held by the segment 6000, so a new segment is allocated.
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       1,
+				BinarySize: 901,
+			},
+			TimeTick: tsoutil.GetCurrentTime(),
+		})
+		assert.NoError(t, err)
+		assert.NotEqual(t, int64(6000), result.SegmentID)
+		return result
+	}
+
+	// the new segment is allocated at coordinator, but its meta is held in memory only,
+	// so it's unknown by catalog if the node crashes before the first insert is appended.
+	result := assign()
+	first := result.SegmentID
+	assert.Empty(t, savedSegmentStates(first))
+	state, ok := m.SegmentState(first)
+	assert.True(t, ok)
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING, state)
+
+	// the append of the insert is failed, the meta is still deferred,
+	// but the seal of the segment without durable insert is still written into catalog before it's flushed.
+	result.Ack()
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: first})
+	m.TryToSealWaitedSegment(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+	assert.Equal(t, []streamingpb.SegmentAssignmentState{
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED,
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED,
+	}, savedSegmentStates(first))
+
+	// the first durable insert persists the meta in background, the later one never writes it again.
+	result = assign()
+	second := result.SegmentID
+	assert.NotEqual(t, first, second)
+	assert.Empty(t, savedSegmentStates(second))
+	result.ObserveAppended(rmq.NewRmqID(2))
+	waitDeferredMetaPersisted(t, m, 3, second)
+	assert.Equal(t, []streamingpb.SegmentAssignmentState{
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+	}, savedSegmentStates(second))
+	result.Ack()
+
+	result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  3,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       1,
+			BinarySize: 1,
+		},
+		TimeTick: tsoutil.GetCurrentTime(),
+	})
+	assert.NoError(t, err)
+	result.ObserveAppended(rmq.NewRmqID(3))
+	result.Ack()
+	assert.Len(t, savedSegmentStates(second), 1)
+
+	// the persisted segment is sealed and flushed through catalog as usual.
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: second})
+	m.TryToSealWaitedSegment(ctx)
+	assert.Equal(t, []streamingpb.SegmentAssignmentState{
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED,
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED,
+	}, savedSegmentStates(second))
+}
+
+func TestAdoptDeferredSegments(t *testing.T) {
+	// the node crashes before the first insert of the segments 7000, 8000 and 9000 is appended,
+	// so they're allocated at coordinator but unknown by catalog.
+	m := newDeferMetaTestManager(t, "v_adopt_deferred", []int64{2000, 3000, 5000, 6000, 7000, 8000, 9000}, []*datapb.SegmentInfo{
+		{ID: 7000, CollectionID: 1, PartitionID: 3, State: commonpb.SegmentState_Growing, IsCreatedByStreaming: true, StorageVersion: 2},
+		// the partition is dropped, the segment is dropped by coordinator together with the partition.
+		{ID: 8000, CollectionID: 1, PartitionID: 10, State: commonpb.SegmentState_Growing, IsCreatedByStreaming: true},
+		// the segment is not created by streaming, it's never deferred.
+		{ID: 9000, CollectionID: 1, PartitionID: 3, State: commonpb.SegmentState_Growing},
+	})
+	ctx := context.Background()
+
+	summary := m.RecoverySummary()
+	assert.Equal(t, 1, summary.Adopted)
+	assert.Equal(t, recoveryStageAdoptDeferredSegments, summary.Stages[len(summary.Stages)-1].Name)
+
+	// the adopted segment is never assigned and sealed right away.
+	for i := 0; i < 10; i++ {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       1,
+				BinarySize: 1,
+			},
+			TimeTick: tsoutil.GetCurrentTime(),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6000), result.SegmentID)
+		result.Ack()
+	}
+	m.TryToSealWaitedSegment(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+	assert.Equal(t, []streamingpb.SegmentAssignmentState{
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED,
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED,
+	}, savedSegmentStates(7000))
+	assert.Empty(t, savedSegmentStates(8000))
+	assert.Empty(t, savedSegmentStates(9000))
+	_, ok := m.SegmentState(8000)
+	assert.False(t, ok)
+}
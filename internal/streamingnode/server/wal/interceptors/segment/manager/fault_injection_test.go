@@ -0,0 +1,233 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/faultinject"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/inspector"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+)
+
+var errInjected = errors.New("injected fault")
+
+// newChaosTestManager recovers the manager of the test state on the pchannel,
+// the fault injector is registered after the recovery, so the recovery is never affected by the injected faults.
+func newChaosTestManager(t *testing.T, pchannel string) (*PChannelSegmentAllocManager, *faultinject.Registry) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: pchannel}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	t.Cleanup(func() {
+		inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+		m.Close(context.Background())
+	})
+
+	injector := faultinject.NewRegistry()
+	resource.Apply(resource.OptFaultInjector(injector))
+	return m, injector
+}
+
+func assignForChaosTest(ctx context.Context, m *PChannelSegmentAllocManager, partitionID int64, timeTick uint64) (*AssignSegmentResult, error) {
+	return m.AssignSegment(ctx, &AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  partitionID,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       100,
+			BinarySize: 100,
+		},
+		TimeTick: timeTick,
+	})
+}
+
+func TestChaosCatalogWriteFailure(t *testing.T) {
+	m, injector := newChaosTestManager(t, "v_chaos_catalog_write")
+	ctx := context.Background()
+
+	// the create segment message is appended, but the growing state is never saved,
+	// it's the same as the crash between the append and the catalog write.
+	injector.Register(faultinject.PointCatalogWrite, faultinject.FailOnNthCall(1, errInjected))
+	_, err := assignForChaosTest(ctx, m, 1, tsoutil.GetCurrentTime())
+	assert.ErrorIs(t, err, errInjected)
+	assert.Empty(t, savedSegmentStates(1000))
+
+	// the pending segment is reused by the next assignment, so no segment is leaked at coordinator.
+	result, err := assignForChaosTest(ctx, m, 1, tsoutil.GetCurrentTime())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), result.SegmentID)
+	assert.Equal(t, []streamingpb.SegmentAssignmentState{
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+	}, savedSegmentStates(1000))
+	result.Ack()
+	assert.Equal(t, 2, injector.Calls(faultinject.PointCatalogWrite))
+}
+
+func TestChaosAllocSegmentDelay(t *testing.T) {
+	m, injector := newChaosTestManager(t, "v_chaos_alloc_segment")
+
+	// the coordinator hangs longer than the caller can wait.
+	injector.Register(faultinject.PointAllocSegment, faultinject.Delay(5*time.Second))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := assignForChaosTest(ctx, m, 1, tsoutil.GetCurrentTime())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, savedSegmentStates(1000))
+
+	// the pending segment is allocated after the coordinator recovers.
+	injector.Unregister(faultinject.PointAllocSegment)
+	result, err := assignForChaosTest(context.Background(), m, 1, tsoutil.GetCurrentTime())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), result.SegmentID)
+	result.Ack()
+}
+
+func TestChaosDropAck(t *testing.T) {
+	m, injector := newChaosTestManager(t, "v_chaos_drop_ack")
+	ctx := context.Background()
+
+	injector.Register(faultinject.PointAck, faultinject.FailOnNthCall(1, errInjected))
+	result, err := assignForChaosTest(ctx, m, 3, tsoutil.GetCurrentTime())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6000), result.SegmentID)
+	result.Ack()
+	assert.Equal(t, int32(1), result.Acknowledge.Load())
+
+	// the segment is sealed but never flushed until the lost ack arrives.
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: 6000})
+	assert.Equal(t, 1, m.helper.WaitCounter())
+	assert.Equal(t, []streamingpb.SegmentAssignmentState{
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED,
+	}, savedSegmentStates(6000))
+
+	result.Ack()
+	m.TryToSealWaitedSegment(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+	assert.Equal(t, []streamingpb.SegmentAssignmentState{
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED,
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED,
+	}, savedSegmentStates(6000))
+}
+
+func TestChaosSealPersistFailure(t *testing.T) {
+	m, injector := newChaosTestManager(t, "v_chaos_seal_persist")
+	ctx := context.Background()
+
+	// the seal is decided but never persisted, the segment is still growing in catalog,
+	// it's the same as the crash before the seal persistence.
+	injector.Register(faultinject.PointSealPersist, faultinject.FailOnNthCall(1, errInjected))
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: 6000})
+	assert.Equal(t, 1, m.helper.WaitCounter())
+	assert.Empty(t, savedSegmentStates(6000))
+
+	// the seal is persisted and flushed at the next seal cycle.
+	m.TryToSealWaitedSegment(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+	assert.Equal(t, []streamingpb.SegmentAssignmentState{
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED,
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED,
+	}, savedSegmentStates(6000))
+	assert.Equal(t, 2, injector.Calls(faultinject.PointSealPersist))
+}
+
+func TestChaosSealCatalogWriteFailure(t *testing.T) {
+	initializeTestState(t)
+
+	flushed := atomic.NewInt32(0)
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, msg message.MutableMessage) (*wal.AppendResult, error) {
+		if msg.MessageType() == message.MessageTypeFlush {
+			flushed.Inc()
+		}
+		return &wal.AppendResult{
+			MessageID: rmq.NewRmqID(1),
+			TimeTick:  1,
+		}, nil
+	}).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_chaos_seal_catalog_write"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	defer m.Close(context.Background())
+	injector := faultinject.NewRegistry()
+	resource.Apply(resource.OptFaultInjector(injector))
+	aborted := func() float64 {
+		return testutil.ToFloat64(metrics.WALSegmentSealAbortedTotal.WithLabelValues(paramtable.GetStringNodeID(), "v_chaos_seal_catalog_write", sealAbortReasonCatalogWrite))
+	}
+	ctx := context.Background()
+
+	// the catalog write of the sealed state fails, the seal is aborted before the flush message is appended.
+	injector.Register(faultinject.PointCatalogWrite, faultinject.FailOnNthCall(1, errInjected))
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: 6000})
+	assert.Zero(t, flushed.Load())
+	assert.Empty(t, savedSegmentStates(6000))
+	assert.Equal(t, float64(1), aborted())
+	assert.Equal(t, 1, m.helper.WaitCounter())
+	// the segment remains growing and is re-queued for the next sweep.
+	assert.Len(t, m.helper.waitForSealed, 1)
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING, m.helper.waitForSealed[0].GetState())
+	assert.NotNil(t, resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(6000))
+
+	// the seal is persisted before the flush message is appended at the next sweep.
+	m.TryToSealWaitedSegment(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+	assert.Equal(t, int32(1), flushed.Load())
+	assert.Equal(t, []streamingpb.SegmentAssignmentState{
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED,
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED,
+	}, savedSegmentStates(6000))
+	assert.Equal(t, float64(1), aborted())
+}
+
+func TestChaosFenceFailure(t *testing.T) {
+	m, injector := newChaosTestManager(t, "v_chaos_fence")
+	ctx := context.Background()
+
+	// the manual flush fails before the fence is installed, the assignment is not fenced.
+	injector.Register(faultinject.PointFence, faultinject.FailOnNthCall(1, errInjected))
+	flushTs := tsoutil.GetCurrentTime()
+	_, err := m.SealAndFenceSegmentUntil(ctx, 1, flushTs)
+	assert.ErrorIs(t, err, errInjected)
+	// the failed manual flush never leaves the pending flush behind.
+	_, ok := m.GetFlushPending(1)
+	assert.False(t, ok)
+	result, err := assignForChaosTest(ctx, m, 3, flushTs)
+	assert.NoError(t, err)
+	result.Ack()
+
+	// the retried manual flush installs the fence.
+	flushTs = tsoutil.GetCurrentTime()
+	segmentIDs, err := m.SealAndFenceSegmentUntil(ctx, 1, flushTs)
+	assert.NoError(t, err)
+	assert.Contains(t, segmentIDs, result.SegmentID)
+	_, err = assignForChaosTest(ctx, m, 3, flushTs)
+	assert.ErrorIs(t, err, ErrFencedAssign)
+}
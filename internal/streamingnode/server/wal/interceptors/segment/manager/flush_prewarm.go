@@ -0,0 +1,53 @@
+package manager
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// prewarmFlushTimeTicks advances the timetick allocator of the wal beyond the max barrier timetick of the flush messages of the batch,
+// it's called once before the flush messages of a large seal batch are appended.
+//
+// Every flush message is appended with the max assigned timetick of its segment as the barrier,
+// and the barrier above the local timeticks of the allocator costs an allocator round trip at the append of the message.
+// After the pre-warm, the barrier of every flush message of the batch is satisfied by the local timeticks,
+// so the round trip is paid at most once for the whole batch but not once per message.
+//
+// The timeticks of the batch are still monotonic but not shared: every flush message is allocated its own timetick by the wal,
+// because the timetick is the unique order of the messages on the wal, the consumer never sees two messages with the same one.
+// The barrier of every flush message is kept, so the flush message is still ordered after every insert of its segment.
+func (q *sealQueue) prewarmFlushTimeTicks(ctx context.Context, sealedSegments map[int64]map[string][]*segmentAllocManager) {
+	threshold := paramtable.Get().StreamingCfg.WALSegmentAssignFlushTimeTickPrewarmThreshold.GetAsInt()
+	if threshold <= 0 {
+		return
+	}
+	count := 0
+	maxBarrier := uint64(0)
+	for _, vchannelSegments := range sealedSegments {
+		for _, segments := range vchannelSegments {
+			for _, segment := range segments {
+				// only the flush message is appended with the barrier, the L0 segment and the segment not durably sealed are skipped by the flush.
+				if segment.IsL0() || !isSealDurable(segment) {
+					continue
+				}
+				count++
+				if _, maxTimeTick := segment.AssignedTimeTickRange(); maxTimeTick > maxBarrier {
+					maxBarrier = maxTimeTick
+				}
+			}
+		}
+	}
+	if count < threshold || maxBarrier == 0 {
+		return
+	}
+	if err := resource.Resource().TSOAllocator().BarrierUntil(ctx, maxBarrier); err != nil {
+		// the barrier of every flush message is still applied by the wal, so the failure only loses the pre-warm.
+		q.logger.Warn("fail to pre-warm the timetick allocator for the flush messages", zap.Int("count", count), zap.Uint64("barrier", maxBarrier), zap.Error(err))
+		return
+	}
+	q.logger.Debug("timetick allocator pre-warmed for the flush messages", zap.Int("count", count), zap.Uint64("barrier", maxBarrier))
+}
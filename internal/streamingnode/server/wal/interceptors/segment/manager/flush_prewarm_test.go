@@ -0,0 +1,142 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+)
+
+// appendedFlush is a flush message appended by the seal batch.
+type appendedFlush struct {
+	segmentID    int64
+	barrier      uint64
+	timeTick     uint64
+	roundTrips   int // the count of allocator round trips before the flush message is appended.
+	assignedTick uint64
+}
+
+// allocatorRoundTrips returns the count of the timetick allocator round trips to the coordinator.
+func allocatorRoundTrips() int {
+	client := resource.Resource().MixCoordClient().Get().(*mocks.MockMixCoordClient)
+	count := 0
+	for _, call := range client.Calls {
+		if call.Method == "AllocTimestamp" {
+			count++
+		}
+	}
+	return count
+}
+
+// flushBatchForPrewarmTest flushes the growing segments of collection 1 as one seal batch with the pre-warm threshold,
+// the inserts of them are assigned at the timeticks above the local timeticks of the allocator.
+// It returns the appended flush messages in the append order and the allocator round trips before the batch.
+func flushBatchForPrewarmTest(t *testing.T, threshold string) ([]appendedFlush, int) {
+	initializeTestState(t)
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.WALSegmentAssignFlushTimeTickPrewarmThreshold.Key, threshold)
+	t.Cleanup(func() { params.Reset(params.StreamingCfg.WALSegmentAssignFlushTimeTickPrewarmThreshold.Key) })
+
+	// the timetick interceptor of the wal allocates the timetick of the message after its barrier.
+	appended := make([]appendedFlush, 0)
+	assigned := make(map[int64]uint64)
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, msg message.MutableMessage) (*wal.AppendResult, error) {
+		header := message.MustAsMutableFlushMessageV2(msg).Header()
+		flush := appendedFlush{
+			segmentID:    header.GetSegmentId(),
+			barrier:      msg.BarrierTimeTick(),
+			roundTrips:   allocatorRoundTrips(),
+			assignedTick: assigned[header.GetSegmentId()],
+		}
+		if flush.barrier > 0 {
+			if err := resource.Resource().TSOAllocator().BarrierUntil(ctx, flush.barrier); err != nil {
+				return nil, err
+			}
+		}
+		tt, err := resource.Resource().TSOAllocator().Allocate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		flush.timeTick = tt
+		appended = append(appended, flush)
+		return &wal.AppendResult{MessageID: rmq.NewRmqID(1), TimeTick: tt}, nil
+	})
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+	ctx := context.Background()
+	m, err := RecoverPChannelSegmentAllocManager(ctx, types.PChannelInfo{Name: "v_flush_prewarm"}, f)
+	assert.NoError(t, err)
+	t.Cleanup(func() { m.Close(context.Background()) })
+
+	// the local timeticks of the allocator are fetched before the inserts are assigned.
+	_, err = resource.Resource().TSOAllocator().Allocate(ctx)
+	assert.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	timeTick := tsoutil.ComposeTSByTime(time.Now(), 0)
+	for i, partitionID := range []int64{2, 3} {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID:  1,
+			PartitionID:   partitionID,
+			InsertMetrics: stats.InsertMetrics{Rows: 1, BinarySize: 1},
+			TimeTick:      timeTick + uint64(i),
+		})
+		assert.NoError(t, err)
+		assigned[result.SegmentID] = timeTick + uint64(i)
+		result.Ack()
+	}
+
+	roundTrips := allocatorRoundTrips()
+	m.MustSealSegments(ctx,
+		stats.SegmentBelongs{PChannel: "v_flush_prewarm", CollectionID: 1, PartitionID: 2, SegmentID: 3000},
+		stats.SegmentBelongs{PChannel: "v_flush_prewarm", CollectionID: 1, PartitionID: 2, SegmentID: 5000},
+		stats.SegmentBelongs{PChannel: "v_flush_prewarm", CollectionID: 1, PartitionID: 3, SegmentID: 6000},
+	)
+	assert.Len(t, appended, 3)
+	return appended, roundTrips
+}
+
+// assertFlushOrdering asserts the consumer-visible ordering invariants of the flush messages of a batch.
+func assertFlushOrdering(t *testing.T, appended []appendedFlush) {
+	for i, flush := range appended {
+		// the flush message is ordered after every insert of its segment.
+		assert.Equal(t, flush.assignedTick, flush.barrier)
+		assert.Greater(t, flush.timeTick, flush.barrier)
+		// the timeticks of the batch are monotonic but never shared.
+		if i > 0 {
+			assert.Greater(t, flush.timeTick, appended[i-1].timeTick)
+		}
+	}
+}
+
+func TestFlushTimeTickPrewarm(t *testing.T) {
+	appended, roundTrips := flushBatchForPrewarmTest(t, "2")
+	assertFlushOrdering(t, appended)
+	// the allocator is advanced once before the batch, no flush message of the batch pays a round trip.
+	for _, flush := range appended {
+		assert.Equal(t, roundTrips+1, flush.roundTrips)
+	}
+	assert.Equal(t, roundTrips+1, allocatorRoundTrips())
+}
+
+func TestFlushTimeTickPrewarmDisabled(t *testing.T) {
+	appended, roundTrips := flushBatchForPrewarmTest(t, "0")
+	assertFlushOrdering(t, appended)
+	// the first flush message with a barrier above the local timeticks pays the round trip by itself.
+	assert.Equal(t, roundTrips, appended[0].roundTrips)
+	assert.Equal(t, roundTrips+1, allocatorRoundTrips())
+}
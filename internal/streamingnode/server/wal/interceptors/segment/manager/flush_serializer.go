@@ -0,0 +1,143 @@
+package manager
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// ErrFlushQueueFull is returned if there're too many queued flush operations of the collection.
+// It's a retryable condition, the flush can be retried by the client later.
+var ErrFlushQueueFull = errors.New("too many queued flush operations of collection")
+
+// flushCall is a running flush operation of a collection.
+type flushCall struct {
+	flushTs    uint64
+	done       chan struct{}
+	segmentIDs []int64
+	err        error
+}
+
+// collectionFlushState is the flush state of a collection.
+type collectionFlushState struct {
+	inflight *flushCall
+	queued   int // the count of flush operations that wait for the in-flight one to start a new sweep.
+	waiters  int // the count of flush operations that are coalesced onto the in-flight one.
+}
+
+// newFlushSerializer creates a new flush serializer.
+func newFlushSerializer() *flushSerializer {
+	return &flushSerializer{
+		states: make(map[int64]*collectionFlushState),
+	}
+}
+
+// flushSerializer serializes the flush operations of the same collection.
+// The flush with a not greater flush ts is coalesced onto the in-flight one and shares its result,
+// because all the segments that should be sealed by it are already sealed and fenced by the in-flight one.
+// Otherwise, the flush is queued until the in-flight one is done.
+type flushSerializer struct {
+	mu     sync.Mutex
+	states map[int64]*collectionFlushState
+}
+
+// Do executes the flush operation of the collection at the flush ts serially.
+// The coalesced flush returns the result of the in-flight one, including the error.
+func (s *flushSerializer) Do(ctx context.Context, collectionID int64, flushTs uint64, flush func() ([]int64, error)) ([]int64, error) {
+	queued := false
+	for {
+		s.mu.Lock()
+		state := s.getOrCreateState(collectionID)
+		if queued {
+			state.queued--
+			queued = false
+		}
+		if state.inflight == nil {
+			call := &flushCall{flushTs: flushTs, done: make(chan struct{})}
+			state.inflight = call
+			s.mu.Unlock()
+			return s.execute(collectionID, call, flush)
+		}
+		call := state.inflight
+		if flushTs <= call.flushTs {
+			state.waiters++
+			s.mu.Unlock()
+			err := s.wait(ctx, call)
+			s.mu.Lock()
+			state.waiters--
+			s.cleanupIfIdle(collectionID, state)
+			s.mu.Unlock()
+			if err != nil {
+				return nil, err
+			}
+			return call.segmentIDs, call.err
+		}
+		if maxDepth := paramtable.Get().StreamingCfg.WALSegmentAssignFlushQueueMaxDepth.GetAsInt(); state.queued >= maxDepth {
+			s.cleanupIfIdle(collectionID, state)
+			s.mu.Unlock()
+			return nil, errors.Wrapf(ErrFlushQueueFull, "collection %d, max depth %d", collectionID, maxDepth)
+		}
+		state.queued++
+		queued = true
+		s.mu.Unlock()
+		if err := s.wait(ctx, call); err != nil {
+			s.mu.Lock()
+			state.queued--
+			s.cleanupIfIdle(collectionID, state)
+			s.mu.Unlock()
+			return nil, err
+		}
+	}
+}
+
+// IsInflight returns whether a flush operation of the collection is in progress.
+func (s *flushSerializer) IsInflight(collectionID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[collectionID]
+	return ok && state.inflight != nil
+}
+
+// execute executes the flush operation as the in-flight one and wakes up all the waiters.
+func (s *flushSerializer) execute(collectionID int64, call *flushCall, flush func() ([]int64, error)) ([]int64, error) {
+	call.segmentIDs, call.err = flush()
+
+	s.mu.Lock()
+	state := s.states[collectionID]
+	state.inflight = nil
+	s.cleanupIfIdle(collectionID, state)
+	s.mu.Unlock()
+	close(call.done)
+	return call.segmentIDs, call.err
+}
+
+// wait waits for the in-flight flush operation done.
+func (s *flushSerializer) wait(ctx context.Context, call *flushCall) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-call.done:
+		return nil
+	}
+}
+
+// getOrCreateState gets the flush state of the collection, create it if not exist.
+func (s *flushSerializer) getOrCreateState(collectionID int64) *collectionFlushState {
+	state, ok := s.states[collectionID]
+	if !ok {
+		state = &collectionFlushState{}
+		s.states[collectionID] = state
+	}
+	return state
+}
+
+// cleanupIfIdle removes the flush state of the collection if there's no flush operation of it.
+func (s *flushSerializer) cleanupIfIdle(collectionID int64, state *collectionFlushState) {
+	if state.inflight == nil && state.queued == 0 && state.waiters == 0 {
+		delete(s.states, collectionID)
+	}
+}
@@ -0,0 +1,88 @@
+package manager
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestFlushSerializer(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignFlushQueueMaxDepth.Key, "1")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignFlushQueueMaxDepth.Key)
+
+	s := newFlushSerializer()
+	ctx := context.Background()
+	sweeps := atomic.NewInt32(0)
+	sweptTs := make([]uint64, 0)
+	release := make(chan struct{})
+	flush := func(flushTs uint64) func() ([]int64, error) {
+		return func() ([]int64, error) {
+			sweeps.Inc()
+			sweptTs = append(sweptTs, flushTs)
+			<-release
+			return []int64{int64(flushTs)}, nil
+		}
+	}
+	stateOf := func(collectionID int64) collectionFlushState {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if state, ok := s.states[collectionID]; ok {
+			return *state
+		}
+		return collectionFlushState{}
+	}
+
+	results := make([][]int64, 3)
+	wg := sync.WaitGroup{}
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		results[0], _ = s.Do(ctx, 1, 100, flush(100))
+	}()
+	assert.Eventually(t, func() bool { return sweeps.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// the overlapping flush is coalesced onto the in-flight one.
+	go func() {
+		defer wg.Done()
+		results[1], _ = s.Do(ctx, 1, 90, flush(90))
+	}()
+	// the newer flush is queued.
+	go func() {
+		defer wg.Done()
+		results[2], _ = s.Do(ctx, 1, 200, flush(200))
+	}()
+	assert.Eventually(t, func() bool {
+		state := stateOf(1)
+		return state.waiters == 1 && state.queued == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the flush beyond the max queue depth is rejected.
+	_, err := s.Do(ctx, 1, 300, flush(300))
+	assert.ErrorIs(t, err, ErrFlushQueueFull)
+	// the waiting flush can be canceled.
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = s.Do(canceledCtx, 1, 100, flush(100))
+	assert.ErrorIs(t, err, context.Canceled)
+	// other collections are never blocked.
+	segmentIDs, err := s.Do(ctx, 2, 300, func() ([]int64, error) { return []int64{300}, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{300}, segmentIDs)
+
+	close(release)
+	wg.Wait()
+	// only one sweep runs for the overlapping portion.
+	assert.Equal(t, int32(2), sweeps.Load())
+	assert.Equal(t, []uint64{100, 200}, sweptTs)
+	assert.Equal(t, []int64{100}, results[0])
+	assert.Equal(t, []int64{100}, results[1])
+	assert.Equal(t, []int64{200}, results[2])
+	assert.Empty(t, s.states)
+}
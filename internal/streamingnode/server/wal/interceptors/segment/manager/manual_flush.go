@@ -0,0 +1,132 @@
+package manager
+
+import (
+	"sync"
+
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+)
+
+// maxSealedSegmentStats is the max count of the final stats of the sealed segments kept on a pchannel.
+const maxSealedSegmentStats = 1024
+
+// manualFlushRecord is the record of the last applied manual flush of a collection.
+type manualFlushRecord struct {
+	flushTs    uint64
+	segmentIDs []int64 // the sealed segments of the manual flush, nil if unknown (recovered from meta).
+}
+
+// newManualFlushRecords creates the manual flush records from the persisted vchannel metas.
+func newManualFlushRecords(vchannels []*streamingpb.VChannelMeta) *manualFlushRecords {
+	records := make(map[int64]manualFlushRecord, len(vchannels))
+	for _, vchannel := range vchannels {
+		if vchannel.GetState() == streamingpb.VChannelState_VCHANNEL_STATE_DROPPED {
+			continue
+		}
+		info := vchannel.GetCollectionInfo()
+		if info.GetLastManualFlushTimeTick() == 0 {
+			continue
+		}
+		records[info.GetCollectionId()] = manualFlushRecord{flushTs: info.GetLastManualFlushTimeTick()}
+	}
+	return &manualFlushRecords{records: records}
+}
+
+// manualFlushRecords keeps the last applied manual flush of all collections on the pchannel,
+// so a replayed manual flush after failover can be detected and never seals the fresh growing segments.
+type manualFlushRecords struct {
+	mu      sync.Mutex
+	records map[int64]manualFlushRecord
+}
+
+// Get returns the sealed segments of the applied manual flush if the flush ts is not greater than the recorded one.
+func (r *manualFlushRecords) Get(collectionID int64, flushTs uint64) ([]int64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.records[collectionID]
+	if !ok || flushTs > record.flushTs {
+		return nil, false
+	}
+	if flushTs < record.flushTs {
+		// the sealed segments of an older manual flush are not recorded.
+		return nil, true
+	}
+	return record.segmentIDs, true
+}
+
+// Observe records the applied manual flush of the collection, the older one is ignored.
+func (r *manualFlushRecords) Observe(collectionID int64, flushTs uint64, segmentIDs []int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if record, ok := r.records[collectionID]; ok && flushTs <= record.flushTs {
+		return
+	}
+	r.records[collectionID] = manualFlushRecord{flushTs: flushTs, segmentIDs: segmentIDs}
+}
+
+// Remove removes the record of the collection.
+func (r *manualFlushRecords) Remove(collectionID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.records, collectionID)
+}
+
+// newSealedSegmentStats creates a new sealed segment stats.
+func newSealedSegmentStats() *sealedSegmentStats {
+	return &sealedSegmentStats{
+		stats: make(map[int64]*message.ManualFlushSegmentStat),
+	}
+}
+
+// sealedSegmentStats keeps the final stats of the segments sealed by the flush operations,
+// so the response of manual flush can carry them without querying the coordinator.
+// Only the latest maxSealedSegmentStats stats are kept, the older ones are evicted.
+type sealedSegmentStats struct {
+	mu    sync.Mutex
+	stats map[int64]*message.ManualFlushSegmentStat
+	order []int64 // the segment ids in the order of observation, used to evict the oldest one.
+}
+
+// Observe gathers the final stats of the sealed segments at seal time.
+// The sealed segments are removed from the assignment, so the stats will not be changed anymore.
+func (r *sealedSegmentStats) Observe(sealedSegments []*segmentAllocManager) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, segment := range sealedSegments {
+		if segment.IsL0() {
+			continue
+		}
+		stat := &message.ManualFlushSegmentStat{SegmentId: segment.GetSegmentID()}
+		if segmentStat := segment.GetStat(); segmentStat != nil {
+			stat.Rows = segmentStat.Insert.Rows
+			stat.BinarySize = segmentStat.Insert.BinarySize
+		}
+		_, stat.SealTimeTick = segment.AssignedTimeTickRange()
+		if _, ok := r.stats[stat.SegmentId]; !ok {
+			r.order = append(r.order, stat.SegmentId)
+		}
+		r.stats[stat.SegmentId] = stat
+	}
+	for len(r.order) > maxSealedSegmentStats {
+		delete(r.stats, r.order[0])
+		r.order = r.order[1:]
+	}
+}
+
+// Get returns the final stats of the given sealed segments, the segment without kept stat is skipped.
+func (r *sealedSegmentStats) Get(segmentIDs []int64) []*message.ManualFlushSegmentStat {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make([]*message.ManualFlushSegmentStat, 0, len(segmentIDs))
+	for _, segmentID := range segmentIDs {
+		if stat, ok := r.stats[segmentID]; ok {
+			stats = append(stats, stat)
+		}
+	}
+	return stats
+}
@@ -0,0 +1,48 @@
+package manager
+
+import (
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+)
+
+var ErrInvalidFlushTs = errors.New("invalid flush ts")
+
+// ValidateManualFlushTs validates the flush ts of the manual flush of the collection before the fence is installed,
+// the current time tick is the time tick of the manual flush message.
+// The valid range is [create time tick of the collection, current time tick + max future skew],
+// the lower bound is 1 if the collection is recovered and its create time tick is unknown.
+// The flush ts out of the range is rejected with ErrInvalidFlushTs,
+// the flush ts ahead of the current time tick but within the skew is clamped to the current time tick.
+// The flush ts to apply is returned.
+func (m *PChannelSegmentAllocManager) ValidateManualFlushTs(collectionID int64, flushTs uint64, currentTimeTick uint64) (uint64, error) {
+	if err := m.checkLifetime(); err != nil {
+		return 0, err
+	}
+	defer m.lifetime.Done()
+
+	lowerBound := uint64(1)
+	if createTimeTick, ok := m.managers.CollectionCreateTimeTick(collectionID); ok {
+		lowerBound = createTimeTick
+	}
+	skew := paramtable.Get().StreamingCfg.WALSegmentAssignManualFlushMaxFutureSkew.GetAsDurationByParse()
+	upperBound := currentTimeTick
+	if skew > 0 {
+		upperBound = tsoutil.AddPhysicalDurationOnTs(currentTimeTick, skew)
+	}
+	if flushTs < lowerBound || flushTs > upperBound {
+		return 0, errors.Wrapf(ErrInvalidFlushTs, "flush ts %d of collection %d is out of the valid range [%d, %d]",
+			flushTs, collectionID, lowerBound, upperBound)
+	}
+	if flushTs > currentTimeTick {
+		m.logger.Warn("flush ts of manual flush is ahead of the current time tick, clamp it to the current time tick",
+			zap.Int64("collectionID", collectionID),
+			zap.Uint64("flushTs", flushTs),
+			zap.Uint64("currentTimeTick", currentTimeTick),
+			zap.Duration("ahead", tsoutil.PhysicalTime(flushTs).Sub(tsoutil.PhysicalTime(currentTimeTick))))
+		return currentTimeTick, nil
+	}
+	return flushTs, nil
+}
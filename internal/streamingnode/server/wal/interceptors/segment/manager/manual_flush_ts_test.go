@@ -0,0 +1,71 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+)
+
+func TestValidateManualFlushTs(t *testing.T) {
+	m, _ := newPartitionMigrateTestManager(t, "v_validate_manual_flush_ts")
+
+	createTimeTick := tsoutil.GetCurrentTime()
+	currentTimeTick := tsoutil.AddPhysicalDurationOnTs(createTimeTick, 10*time.Second)
+	upperBound := tsoutil.AddPhysicalDurationOnTs(currentTimeTick, 5*time.Second)
+	assert.NoError(t, m.NewCollection(300, "v_validate_manual_flush_ts_300", []int64{301}, InitialSchemaVersion, createTimeTick))
+	got, ok := m.managers.CollectionCreateTimeTick(300)
+	assert.True(t, ok)
+	assert.Equal(t, createTimeTick, got)
+
+	// the flush ts older than the creation of the collection is rejected.
+	_, err := m.ValidateManualFlushTs(300, 0, currentTimeTick)
+	assert.ErrorIs(t, err, ErrInvalidFlushTs)
+	_, err = m.ValidateManualFlushTs(300, createTimeTick-1, currentTimeTick)
+	assert.ErrorIs(t, err, ErrInvalidFlushTs)
+	flushTs, err := m.ValidateManualFlushTs(300, createTimeTick, currentTimeTick)
+	assert.NoError(t, err)
+	assert.Equal(t, createTimeTick, flushTs)
+
+	// the flush ts not newer than the current time tick is kept as is.
+	flushTs, err = m.ValidateManualFlushTs(300, currentTimeTick-1, currentTimeTick)
+	assert.NoError(t, err)
+	assert.Equal(t, currentTimeTick-1, flushTs)
+	flushTs, err = m.ValidateManualFlushTs(300, currentTimeTick, currentTimeTick)
+	assert.NoError(t, err)
+	assert.Equal(t, currentTimeTick, flushTs)
+
+	// the flush ts slightly in the future is clamped to the current time tick, the far future one is rejected.
+	flushTs, err = m.ValidateManualFlushTs(300, currentTimeTick+1, currentTimeTick)
+	assert.NoError(t, err)
+	assert.Equal(t, currentTimeTick, flushTs)
+	flushTs, err = m.ValidateManualFlushTs(300, upperBound, currentTimeTick)
+	assert.NoError(t, err)
+	assert.Equal(t, currentTimeTick, flushTs)
+	_, err = m.ValidateManualFlushTs(300, upperBound+1, currentTimeTick)
+	assert.ErrorIs(t, err, ErrInvalidFlushTs)
+
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.WALSegmentAssignManualFlushMaxFutureSkew.Key, "0s")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignManualFlushMaxFutureSkew.Key)
+	_, err = m.ValidateManualFlushTs(300, currentTimeTick+1, currentTimeTick)
+	assert.ErrorIs(t, err, ErrInvalidFlushTs)
+	params.Reset(params.StreamingCfg.WALSegmentAssignManualFlushMaxFutureSkew.Key)
+
+	// the create time tick of the recovered collection is unknown, only the zero flush ts is rejected.
+	_, ok = m.managers.CollectionCreateTimeTick(1)
+	assert.False(t, ok)
+	_, err = m.ValidateManualFlushTs(1, 0, currentTimeTick)
+	assert.ErrorIs(t, err, ErrInvalidFlushTs)
+	flushTs, err = m.ValidateManualFlushTs(1, 1, currentTimeTick)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), flushTs)
+
+	// the create time tick is forgotten after the collection is removed.
+	m.managers.RemoveCollection(300)
+	_, ok = m.managers.CollectionCreateTimeTick(300)
+	assert.False(t, ok)
+}
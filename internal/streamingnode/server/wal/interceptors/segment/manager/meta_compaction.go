@@ -0,0 +1,93 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/pkg/v2/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+// CompactAssignmentMeta removes the segment assignment metas of the dropped partitions or collections from the catalog.
+// A meta is removed only if its partition is not known by the manager and is confirmed dropped by the coordinator.
+// The meta written by current or newer term is never removed, it may be created by a concurrent legitimate writer.
+// Return the count of removed keys.
+func (m *PChannelSegmentAllocManager) CompactAssignmentMeta(ctx context.Context) (int, error) {
+	if err := m.checkLifetime(); err != nil {
+		return 0, err
+	}
+	defer m.lifetime.Done()
+
+	// the keyspace is listed before checking the known partitions,
+	// so the meta of a concurrently created partition is always known by the manager.
+	var rawMetas []*streamingpb.SegmentAssignmentMeta
+	if err := doCatalogOperation(ctx, catalogPathSweep, m.metrics, func(ctx context.Context) (err error) {
+		rawMetas, err = resource.Resource().StreamingNodeCatalog().ListSegmentAssignment(ctx, m.pchannel.Name)
+		return err
+	}); err != nil {
+		return 0, errors.Wrap(err, "failed to list segment assignment from catalog")
+	}
+	candidates := make([]*streamingpb.SegmentAssignmentMeta, 0)
+	for _, meta := range rawMetas {
+		if meta.GetTerm() >= m.pchannel.Term {
+			continue
+		}
+		if _, err := m.managers.Get(meta.GetCollectionId(), meta.GetPartitionId()); err == nil {
+			continue
+		}
+		if m.helper.Contains(meta.GetSegmentId()) {
+			// the segment is still on sealing, it will be removed after flushed.
+			continue
+		}
+		candidates = append(candidates, meta)
+	}
+	if len(candidates) == 0 {
+		return 0, nil
+	}
+
+	// confirm the partitions are dropped by the coordinator.
+	mix, err := resource.Resource().MixCoordClient().GetWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := mix.GetPChannelInfo(ctx, &rootcoordpb.GetPChannelInfoRequest{
+		Pchannel: m.pchannel.Name,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		return 0, errors.Wrap(err, "failed to get pchannel info from rootcoord")
+	}
+	alivePartitions := typeutil.NewSet[int64]()
+	for _, collection := range resp.GetCollections() {
+		for _, partition := range collection.GetPartitions() {
+			alivePartitions.Insert(partition.GetPartitionId())
+		}
+	}
+
+	removes := make(map[int64]*streamingpb.SegmentAssignmentMeta, len(candidates))
+	for _, meta := range candidates {
+		if alivePartitions.Contain(meta.GetPartitionId()) {
+			continue
+		}
+		// the flushed meta is removed physically by the catalog.
+		removed := proto.Clone(meta).(*streamingpb.SegmentAssignmentMeta)
+		removed.State = streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED
+		removes[meta.GetSegmentId()] = removed
+	}
+	if len(removes) == 0 {
+		return 0, nil
+	}
+	if err := saveSegmentAssignments(ctx, catalogPathSweep, m.metrics, m.pchannel.Name, removes); err != nil {
+		return 0, errors.Wrap(err, "failed to remove segment assignment from catalog")
+	}
+	m.metrics.ObserveMetaCompacted(len(removes))
+	m.logger.Info("segment assignment meta of dropped partitions compacted",
+		zap.Int("listedCount", len(rawMetas)),
+		zap.Int("removedCount", len(removes)))
+	return len(removes), nil
+}
@@ -0,0 +1,33 @@
+package manager
+
+import (
+	"sync/atomic"
+
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+)
+
+// OpenTxnLister lists the txns still open that have written data into the segments of the collection.
+type OpenTxnLister interface {
+	OpenTxnsOfCollection(collectionID int64) []*message.OpenTxn
+}
+
+// openTxns is the open txns of the pchannel, the lister is bound after the manager is recovered.
+// Under the txn non-blocking seal policy, a segment can be flushed while the txns written into it are still open,
+// the open txns are annotated into the flush, so the consumers can wait for them before declaring the flush point consistent.
+type openTxns struct {
+	lister atomic.Pointer[OpenTxnLister]
+}
+
+// Bind binds the open txn lister.
+func (o *openTxns) Bind(lister OpenTxnLister) {
+	o.lister.Store(&lister)
+}
+
+// OfCollection returns the open txns of the collection, nil if no lister is bound.
+func (o *openTxns) OfCollection(collectionID int64) []*message.OpenTxn {
+	lister := o.lister.Load()
+	if lister == nil {
+		return nil
+	}
+	return (*lister).OpenTxnsOfCollection(collectionID)
+}
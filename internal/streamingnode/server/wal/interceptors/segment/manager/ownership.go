@@ -0,0 +1,38 @@
+package manager
+
+import (
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+)
+
+// ErrStaleOwnership is returned when the segment assignment metas of the pchannel are written by a newer owner.
+var ErrStaleOwnership = errors.New("stale ownership of pchannel")
+
+// validateOwnership checks the term of the segment assignment metas recovered from catalog.
+// If the coordinator briefly assigns a pchannel to two streaming nodes, both of them may write the segment assignment metas.
+// The metas written by a newer term means the pchannel has been taken over by another streaming node,
+// so the current owner is stale and should refuse to start.
+// The metas written by an older term are adopted by the current owner, they will be stamped with the current term at next write.
+func validateOwnership(pchannel types.PChannelInfo, rawMetas []*streamingpb.SegmentAssignmentMeta) error {
+	adopted := 0
+	for _, meta := range rawMetas {
+		if meta.GetTerm() > pchannel.Term {
+			return errors.Wrapf(ErrStaleOwnership, "segment %d at pchannel %s is written by term %d, current term %d",
+				meta.GetSegmentId(), pchannel.Name, meta.GetTerm(), pchannel.Term)
+		}
+		if meta.GetTerm() < pchannel.Term {
+			adopted++
+		}
+	}
+	if adopted > 0 {
+		log.Info("adopt segment assignments written by older term",
+			zap.String("pchannel", pchannel.Name),
+			zap.Int64("term", pchannel.Term),
+			zap.Int("adoptedCount", adopted))
+	}
+	return nil
+}
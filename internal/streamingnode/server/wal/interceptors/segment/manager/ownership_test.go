@@ -0,0 +1,34 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+)
+
+func TestValidateOwnership(t *testing.T) {
+	// two owners of the pchannel write the metas interleaved.
+	rawMetas := []*streamingpb.SegmentAssignmentMeta{
+		{SegmentId: 1, Term: 1},
+		{SegmentId: 2, Term: 2},
+		{SegmentId: 3, Term: 1},
+		{SegmentId: 4, Term: 2},
+	}
+
+	// the metas of older or same term are adopted.
+	assert.NoError(t, validateOwnership(types.PChannelInfo{Name: "v1", Term: 2}, rawMetas))
+	assert.NoError(t, validateOwnership(types.PChannelInfo{Name: "v1", Term: 3}, rawMetas))
+	assert.NoError(t, validateOwnership(types.PChannelInfo{Name: "v1", Term: 1}, nil))
+
+	// the owner is stale if any meta is written by a newer term.
+	err := validateOwnership(types.PChannelInfo{Name: "v1", Term: 1}, rawMetas)
+	assert.ErrorIs(t, err, ErrStaleOwnership)
+
+	// the metas written before the term is introduced are always adopted.
+	assert.NoError(t, validateOwnership(types.PChannelInfo{Name: "v1", Term: 1}, []*streamingpb.SegmentAssignmentMeta{
+		{SegmentId: 1},
+	}))
+}
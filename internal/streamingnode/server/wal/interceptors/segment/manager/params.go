@@ -1,30 +1,147 @@
 package manager
 
 import (
+	"context"
+
 	"go.uber.org/atomic"
 
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/faultinject"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/txn"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
 )
 
 // AssignSegmentRequest is a request to allocate segment.
 type AssignSegmentRequest struct {
 	CollectionID  int64
 	PartitionID   int64
+	VChannel      string // the vchannel that the insert arrives on, empty if not declared.
 	InsertMetrics stats.InsertMetrics
 	TimeTick      uint64
+	SchemaVersion uint64   // the schema version that the insert is built with, 0 if not declared.
+	HighPriority  bool     // the insert is latency-sensitive, it's assigned into the dedicated high priority segments.
+	Source        string   // the node that produces the insert, e.g. the proxy, empty if not declared.
+	PKHashes      []uint64 // the hashes of the primary keys of the insert, used to estimate the distinct primary keys of the segment, empty if not declared.
+	ShardHint     uint64   // the hash of the application level shard key, the rows of the same hint are kept in the same growing segment, 0 if not declared.
 	TxnSession    *txn.TxnSession
 }
 
 // AssignSegmentResult is a result of segment allocation.
 // The sum of Results.Row is equal to InserMetrics.NumRows.
 type AssignSegmentResult struct {
-	SegmentID   int64
-	Acknowledge *atomic.Int32 // used to ack the segment assign result has been consumed
+	SegmentID     int64
+	SchemaVersion uint64              // the schema version that the segment is created under.
+	InsertMetrics stats.InsertMetrics // the insert metrics that is assigned on the segment.
+	Checksum      uint32              // the checksum of the assignment signed by the assignment epoch of the segment, 0 if unsigned.
+	Acknowledge   *atomic.Int32       // used to ack the segment assign result has been consumed
+
+	observeAppended func(msgID message.MessageID)     // used to observe the origin of the segment.
+	pkHashes        []uint64                          // the hashes of the primary keys of the insert, observed after the insert is appended.
+	acked           atomic.Bool                       // the result is acked, the ack of the result must happen exactly once.
+	closed          *atomic.Bool                      // the segment assignment that the result belongs to is closed, nil if never closed.
+	metrics         *metricsutil.SegmentAssignMetrics // used to record the misused ack, nil if not recorded.
+}
+
+// ObserveAppended reports the message id of the insert message after it's appended into wal successfully,
+// the first durable insert of the segment is recorded as the origin of the segment,
+// and the primary key hashes of the insert are observed by the distinct primary key estimate.
+// Must be called before the result is acked.
+func (r *AssignSegmentResult) ObserveAppended(msgID message.MessageID) {
+	if r.observeAppended != nil {
+		r.observeAppended(msgID)
+	}
+	if len(r.pkHashes) > 0 {
+		// only the durable insert is counted into the distinct primary key estimate of the segment.
+		resource.Resource().SegmentAssignStatsManager().ObservePKHashes(r.SegmentID, r.pkHashes)
+	}
 }
 
 // Ack acks the segment assign result has been consumed.
-// Must be only call once after the segment assign result has been consumed.
+// Must be only call once after the segment assign result has been consumed,
+// the repeated ack or the ack after the segment assignment is closed is a no-op and reported as a misuse.
 func (r *AssignSegmentResult) Ack() {
+	if r.isAckDropped() || !r.takeAck() {
+		return
+	}
+	r.ack()
+}
+
+// isAckDropped returns whether the ack is dropped by the injected fault, it never happens in the production build.
+// The dropped ack is lost as if it's never called, so it's not taken and the lost ack can still arrive later.
+func (r *AssignSegmentResult) isAckDropped() bool {
+	return resource.Resource().FaultInjector().Inject(context.Background(), faultinject.PointAck) != nil
+}
+
+// ack decreases the ack semaphore of the segment.
+func (r *AssignSegmentResult) ack() {
+	if resource.Resource().SegmentAssignStatsManager().ObserveLateEvent(r.SegmentID, stats.LateEventAck) {
+		// the segment is already flushed (e.g. force resolved by the seal queue), the late ack is never applied.
+		return
+	}
+	r.Acknowledge.Dec()
+}
+
+// AckWithMetrics acks the segment assign result has been consumed with the actual appended insert metrics.
+// The unused part of the assignment is released from the segment stats,
+// so the segment will not be sealed earlier than needed.
+// Must be only call once after the segment assign result has been consumed, cannot be used with Ack together.
+func (r *AssignSegmentResult) AckWithMetrics(actual stats.InsertMetrics) {
+	// the ack is taken before the release, otherwise the reservation is released twice by the repeated ack.
+	if r.isAckDropped() || !r.takeAck() {
+		return
+	}
+	unused := stats.InsertMetrics{}
+	if actual.Rows < r.InsertMetrics.Rows {
+		unused.Rows = r.InsertMetrics.Rows - actual.Rows
+	}
+	if actual.BinarySize < r.InsertMetrics.BinarySize {
+		unused.BinarySize = r.InsertMetrics.BinarySize - actual.BinarySize
+	}
+	if unused.Rows > 0 || unused.BinarySize > 0 {
+		// release the reservation before ack, otherwise the segment may be sealed with the dirty stats.
+		resource.Resource().SegmentAssignStatsManager().ReleaseRows(r.SegmentID, unused)
+	}
+	r.ack()
+}
+
+// Rollback releases the whole reservation of the segment assign result and acks it,
+// it's used when the insert is never appended, so the segment is not sealed with the dirty stats.
+// Must be only call once, cannot be used with Ack or AckWithMetrics together.
+func (r *AssignSegmentResult) Rollback() {
+	r.AckWithMetrics(stats.InsertMetrics{})
+}
+
+// AssignPreviewRequest is a request to preview the segment assignment of an insert without assigning it.
+type AssignPreviewRequest struct {
+	CollectionID  int64
+	PartitionID   int64
+	InsertMetrics stats.InsertMetrics
+	HighPriority  bool
+}
+
+// AssignDeleteSegmentRequest is a request to allocate the L0 segment for the deletes of a partition.
+type AssignDeleteSegmentRequest struct {
+	CollectionID  int64
+	PartitionID   int64
+	VChannel      string // the vchannel that the delete arrives on, empty if not declared.
+	DeleteMetrics stats.DeleteMetrics
+	TimeTick      uint64
+}
+
+// AssignDeleteSegmentResult is a result of L0 segment allocation.
+type AssignDeleteSegmentResult struct {
+	SegmentID     int64
+	DeleteMetrics stats.DeleteMetrics // the delete metrics that is assigned on the L0 segment.
+	Acknowledge   *atomic.Int32       // used to ack the segment assign result has been consumed
+}
+
+// Ack acks the L0 segment assign result has been consumed.
+// Must be only call once after the segment assign result has been consumed.
+func (r *AssignDeleteSegmentResult) Ack() {
+	if resource.Resource().SegmentAssignStatsManager().ObserveLateEvent(r.SegmentID, stats.LateEventAck) {
+		return
+	}
 	r.Acknowledge.Dec()
 }
@@ -0,0 +1,110 @@
+package manager
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// partitionDropFence is the fence installed by the prepare of partition drop,
+// the insert newer than the fence is rejected until the drop partition arrives or the fence is expired.
+type partitionDropFence struct {
+	timeTick uint64    // the time tick of the prepare partition drop message.
+	expireAt time.Time // the fence is cleared after it, so the writes resume if the drop partition never arrives.
+}
+
+// PreparePartitionDrop seals all growing segments of the partition and fences the insert newer than the timetick with ErrPartitionDropping,
+// so the following drop partition arrives as a cheap operation without any growing data to be flushed.
+// The fence is cleared after the ttl if the drop partition never arrives, and the writes of the partition resume.
+// The fence is kept in memory only, it's lost after the wal is recovered, as same as the expiry.
+func (m *PChannelSegmentAllocManager) PreparePartitionDrop(ctx context.Context, collectionID int64, partitionID int64, timetick uint64) ([]int64, error) {
+	if err := m.checkLifetime(); err != nil {
+		return nil, err
+	}
+	defer m.lifetime.Done()
+
+	pm, err := m.managers.Get(collectionID, partitionID)
+	if err != nil {
+		return nil, err
+	}
+	ttl := paramtable.Get().StreamingCfg.WALSegmentAssignPreparePartitionDropTTL.GetAsDurationByParse()
+	sealedSegments := pm.PrepareDrop(timetick, resource.Resource().Clock().Now().Add(ttl))
+	segmentIDs := sealedSegmentIDs(sealedSegments)
+
+	// trigger a seal operation in background rightnow, and wait for the partition drained.
+	m.helper.AsyncSeal(sealedSegments...)
+	if err := m.helper.WaitUntilNoWaitSeal(ctx); err != nil {
+		return nil, err
+	}
+	m.logger.Info("partition prepared to drop in segment assignment service",
+		zap.Int64("collectionID", collectionID),
+		zap.Int64("partitionID", partitionID),
+		zap.Uint64("timetick", timetick),
+		zap.Duration("ttl", ttl),
+		zap.Int64s("segmentIDs", segmentIDs))
+	return segmentIDs, nil
+}
+
+// PrepareDrop seals all segments of the partition and installs the drop fence at the time tick until the expiry.
+// The assign operation not newer than the time tick is fenced as same as the manual flush,
+// and the newer one is rejected by the drop fence, so no more insert can be applied on the partition.
+// The repeated prepare refreshes the expiry of the fence.
+func (m *partitionSegmentManager) PrepareDrop(timeTick uint64, expireAt time.Time) []*segmentAllocManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.dropFence == nil {
+		m.dropFence = &partitionDropFence{}
+	}
+	if timeTick > m.dropFence.timeTick {
+		m.dropFence.timeTick = timeTick
+	}
+	m.dropFence.expireAt = expireAt
+
+	sealedSegments := m.collectShouldBeSealedWithPolicy(func(segmentMeta *segmentAllocManager) (policy.PolicyName, bool) {
+		return policy.PolicyNamePartitionDropPrepared, true
+	})
+	if timeTick > m.fencedAssignTimeTick {
+		m.fencedAssignTimeTick = timeTick
+	}
+	return sealedSegments
+}
+
+// activeDropFence returns the time tick of the unexpired drop fence of the partition, 0 if not prepared to drop.
+// The expired fence is not cleared, so it can be called with the read lock held.
+func (m *partitionSegmentManager) activeDropFence() uint64 {
+	if m.dropFence == nil || !resource.Resource().Clock().Now().Before(m.dropFence.expireAt) {
+		return 0
+	}
+	return m.dropFence.timeTick
+}
+
+// checkDropFence checks the insert time tick against the drop fence of the partition, the lock should be held.
+// The insert newer than the drop fence is rejected with ErrPartitionDropping until the fence is expired.
+func (m *partitionSegmentManager) checkDropFence(timeTick uint64) error {
+	m.clearExpiredDropFence()
+	if m.dropFence != nil && timeTick > m.dropFence.timeTick {
+		return errors.Wrapf(ErrPartitionDropping, "collection %d, partition %d is prepared to drop at time tick %d",
+			m.collectionID, m.paritionID, m.dropFence.timeTick)
+	}
+	return nil
+}
+
+// clearExpiredDropFence clears the drop fence of the partition if it's expired, the lock should be held.
+func (m *partitionSegmentManager) clearExpiredDropFence() {
+	if m.dropFence == nil || resource.Resource().Clock().Now().Before(m.dropFence.expireAt) {
+		return
+	}
+	m.logger.Warn("drop partition never arrives before the drop fence is expired, the writes of partition resume",
+		zap.Int64("collectionID", m.collectionID),
+		zap.Int64("partitionID", m.paritionID),
+		zap.Uint64("fenceTimeTick", m.dropFence.timeTick),
+		zap.Time("expireAt", m.dropFence.expireAt))
+	m.dropFence = nil
+}
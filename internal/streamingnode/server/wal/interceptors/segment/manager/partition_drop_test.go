@@ -0,0 +1,143 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+)
+
+// newPartitionDropTestManager recovers a segment assignment manager driven by the fake clock with the given drop fence ttl.
+func newPartitionDropTestManager(t *testing.T, name string, ttl string) (*PChannelSegmentAllocManager, *clock.FakeClock) {
+	fakeClock := clock.NewFakeClock(time.Unix(time.Now().Unix(), 0))
+	initializeTestStateWithClock(t, fakeClock)
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.WALSegmentAssignPreparePartitionDropTTL.Key, ttl)
+	t.Cleanup(func() { params.Reset(params.StreamingCfg.WALSegmentAssignPreparePartitionDropTTL.Key) })
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  2,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: name}, f)
+	assert.NoError(t, err)
+	t.Cleanup(func() { m.Close(context.Background()) })
+	return m, fakeClock
+}
+
+// assignForPartitionDropTest assigns an insert on the partition of collection 1 at the time tick.
+func assignForPartitionDropTest(m *PChannelSegmentAllocManager, partitionID int64, timeTick uint64) (*AssignSegmentResult, error) {
+	return m.AssignSegment(context.Background(), &AssignSegmentRequest{
+		CollectionID:  1,
+		PartitionID:   partitionID,
+		InsertMetrics: stats.InsertMetrics{Rows: 1, BinarySize: 1},
+		TimeTick:      timeTick,
+	})
+}
+
+// dropFenceOf returns the drop fence time tick of the partition of collection 1 in the snapshot.
+func dropFenceOf(t *testing.T, m *PChannelSegmentAllocManager, partitionID int64) uint64 {
+	snapshot, err := m.SnapshotCollection(1, []int64{partitionID}, 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, snapshot.Partitions, 1)
+	return snapshot.Partitions[0].DropFenceTimeTick
+}
+
+func TestPreparePartitionDrop(t *testing.T) {
+	m, fakeClock := newPartitionDropTestManager(t, "v_prepare_partition_drop", "1m")
+	ctx := context.Background()
+
+	// all growing segments of the partition are sealed and flushed by the prepare.
+	prepareTimeTick := fakeClock.CurrentTSO()
+	sealed, err := m.PreparePartitionDrop(ctx, 1, 2, prepareTimeTick)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int64{2000, 3000, 4000, 5000}, sealed)
+	for _, segmentID := range []int64{2000, 3000, 5000} {
+		assert.Contains(t, savedSegmentStates(segmentID), streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED)
+		_, ok := m.SegmentState(segmentID)
+		assert.False(t, ok)
+	}
+	assert.Equal(t, prepareTimeTick, dropFenceOf(t, m, 2))
+
+	// the insert at the prepare time tick is fenced to be redone, and the newer insert is rejected.
+	_, err = assignForPartitionDropTest(m, 2, prepareTimeTick)
+	assert.ErrorIs(t, err, ErrFencedAssign)
+	_, err = assignForPartitionDropTest(m, 2, fakeClock.CurrentTSO())
+	assert.ErrorIs(t, err, ErrPartitionDropping)
+
+	// the other partition is not affected.
+	result, err := assignForPartitionDropTest(m, 3, fakeClock.CurrentTSO())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6000), result.SegmentID)
+	result.Ack()
+	assert.Zero(t, dropFenceOf(t, m, 3))
+
+	// the repeated prepare never seals any new segment.
+	sealed, err = m.PreparePartitionDrop(ctx, 1, 2, fakeClock.CurrentTSO())
+	assert.NoError(t, err)
+	assert.Empty(t, sealed)
+
+	// the drop partition arrives before the fence is expired, no growing segment is left to be flushed by it.
+	assert.NoError(t, m.RemovePartition(ctx, 1, 2))
+	_, err = assignForPartitionDropTest(m, 2, fakeClock.CurrentTSO())
+	assert.Error(t, err)
+
+	// the prepare of an unknown partition is rejected.
+	_, err = m.PreparePartitionDrop(ctx, 1, 2, fakeClock.CurrentTSO())
+	assert.Error(t, err)
+}
+
+func TestPreparePartitionDropExpired(t *testing.T) {
+	m, fakeClock := newPartitionDropTestManager(t, "v_prepare_partition_drop_expired", "1m")
+	ctx := context.Background()
+
+	prepareTimeTick := fakeClock.CurrentTSO()
+	sealed, err := m.PreparePartitionDrop(ctx, 1, 3, prepareTimeTick)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{6000}, sealed)
+
+	// the fence is kept before the ttl is reached.
+	fakeClock.Advance(30 * time.Second)
+	_, err = assignForPartitionDropTest(m, 3, fakeClock.CurrentTSO())
+	assert.ErrorIs(t, err, ErrPartitionDropping)
+	assert.Equal(t, prepareTimeTick, dropFenceOf(t, m, 3))
+
+	// the drop partition never arrives, the fence is cleared after the ttl and the writes resume on a new growing segment.
+	fakeClock.Advance(31 * time.Second)
+	assert.Zero(t, dropFenceOf(t, m, 3))
+	result, err := assignForPartitionDropTest(m, 3, fakeClock.CurrentTSO())
+	assert.NoError(t, err)
+	assert.NotEqual(t, int64(6000), result.SegmentID)
+	result.Ack()
+	state, ok := m.SegmentState(result.SegmentID)
+	assert.True(t, ok)
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING, state)
+
+	// the insert not newer than the prepare is still fenced after the expiry.
+	_, err = assignForPartitionDropTest(m, 3, prepareTimeTick)
+	assert.ErrorIs(t, err, ErrFencedAssign)
+
+	// the repeated prepare refreshes the expiry of the fence.
+	sealed, err = m.PreparePartitionDrop(ctx, 1, 3, fakeClock.CurrentTSO())
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{result.SegmentID}, sealed)
+	fakeClock.Advance(30 * time.Second)
+	_, err = assignForPartitionDropTest(m, 3, fakeClock.CurrentTSO())
+	assert.ErrorIs(t, err, ErrPartitionDropping)
+}
@@ -2,16 +2,21 @@ package manager
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/samber/lo"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
-	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/faultinject"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/inspector"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
 	"github.com/milvus-io/milvus/pkg/v2/log"
 	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
@@ -19,11 +24,26 @@ import (
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
 	"github.com/milvus-io/milvus/pkg/v2/util/merr"
-	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
 )
 
-var ErrFencedAssign = errors.New("fenced assign")
+var (
+	ErrFencedAssign      = errors.New("fenced assign")
+	ErrVChannelMismatch  = errors.New("vchannel mismatch")
+	ErrPartitionDropping = errors.New("partition is dropping")
+)
+
+// reserveTimeout is the timeout of the background reservation of the next growing segment.
+const reserveTimeout = 10 * time.Second
+
+// checkVChannel checks the vchannel that the message arrives on with the vchannel that the collection is registered with on the pchannel.
+// The request that doesn't declare its vchannel is always accepted.
+func checkVChannel(collectionID int64, declared string, registered string) error {
+	if declared == "" || declared == registered {
+		return nil
+	}
+	return errors.Wrapf(ErrVChannelMismatch, "collection %d, message vchannel %s, registered vchannel %s", collectionID, declared, registered)
+}
 
 // newPartitionSegmentManager creates a new partition segment assign manager.
 func newPartitionSegmentManager(
@@ -33,10 +53,25 @@ func newPartitionSegmentManager(
 	collectionID int64,
 	paritionID int64,
 	segments []*segmentAllocManager,
+	schemaVersion *atomic.Uint64,
+	ingest *collectionIngest,
 	metrics *metricsutil.SegmentAssignMetrics,
+	watcher *assignmentWatcher,
+	random *sealRandom,
 ) *partitionSegmentManager {
+	// the last assign timetick of the partition is recovered from the newest one persisted with the metas of its segments.
+	lastAssign := atomic.NewUint64(0)
+	// the new partition is always evaluated by the next seal policy sweep.
+	sweepDirty := atomic.NewBool(true)
+	for _, segment := range segments {
+		if tt := segment.inner.GetPartitionLastAssignTimeTick(); tt > lastAssign.Load() {
+			lastAssign.Store(tt)
+		}
+		segment.bindPartitionLastAssign(lastAssign)
+		segment.bindPartitionSweepDirty(sweepDirty)
+	}
 	return &partitionSegmentManager{
-		mu: sync.Mutex{},
+		mu: sync.RWMutex{},
 		logger: resource.Resource().Logger().With(
 			log.FieldComponent("segment-assigner"),
 			zap.Any("pchannel", pchannel),
@@ -44,19 +79,25 @@ func newPartitionSegmentManager(
 			zap.String("vchannel", vchannel),
 			zap.Int64("collectionID", collectionID),
 			zap.Int64("partitionID", paritionID)),
-		wal:          wal,
-		pchannel:     pchannel,
-		vchannel:     vchannel,
-		collectionID: collectionID,
-		paritionID:   paritionID,
-		segments:     segments,
-		metrics:      metrics,
+		wal:           wal,
+		pchannel:      pchannel,
+		vchannel:      vchannel,
+		collectionID:  collectionID,
+		paritionID:    paritionID,
+		segments:      segments,
+		schemaVersion: schemaVersion,
+		ingest:        ingest,
+		metrics:       metrics,
+		watcher:       watcher,
+		random:        random,
+		lastAssign:    lastAssign,
+		sweepDirty:    sweepDirty,
 	}
 }
 
 // partitionSegmentManager is a assign manager of determined partition on determined vchannel.
 type partitionSegmentManager struct {
-	mu                   sync.Mutex
+	mu                   sync.RWMutex
 	logger               *log.MLogger
 	wal                  *syncutil.Future[wal.WAL]
 	pchannel             types.PChannelInfo
@@ -65,7 +106,17 @@ type partitionSegmentManager struct {
 	paritionID           int64
 	segments             []*segmentAllocManager // there will be very few segments in this list.
 	fencedAssignTimeTick uint64                 // the time tick that the assign operation is fenced.
+	lastSealTimeTick     uint64                 // the max assigned time tick of the insert segments removed from assignment to be sealed.
+	lastAssign           *atomic.Uint64         // the time tick of the last assigned insert, shared with the segments to be persisted with their metas.
+	sweepDirty           *atomic.Bool           // the partition is changed since the last seal policy sweep, shared with the segments.
+	dropping             bool                   // the manager is removed from the partition managers, the caller holding it can never revive a segment.
+	dropFence            *partitionDropFence    // the fence installed by the prepare of partition drop, nil if not prepared.
+	schemaVersion        *atomic.Uint64         // the current schema version of the collection, shared by all partitions of the collection.
+	ingest               *collectionIngest      // the ingest tracker of the collection, shared by all partitions of the collection.
 	metrics              *metricsutil.SegmentAssignMetrics
+	watcher              *assignmentWatcher
+	random               *sealRandom // the random source of the pchannel, shared by all partitions of the pchannel.
+	reserving            atomic.Bool // the reservation of the next growing segment is in flight in background.
 }
 
 func (m *partitionSegmentManager) CollectionID() int64 {
@@ -74,8 +125,19 @@ func (m *partitionSegmentManager) CollectionID() int64 {
 
 // AssignSegment assigns a segment for a assign segment request.
 func (m *partitionSegmentManager) AssignSegment(ctx context.Context, req *AssignSegmentRequest) (*AssignSegmentResult, error) {
+	lockStart := time.Now()
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.metrics.ObserveAssignLockWait(time.Since(lockStart))
+
+	// the manager may be fetched before a concurrent drop removes it,
+	// so the assignment either completes before the segments are collected by the drop or observes the drop.
+	if err := m.checkDropping(); err != nil {
+		return nil, err
+	}
+	if err := m.checkDropFence(req.TimeTick); err != nil {
+		return nil, err
+	}
 
 	// !!! We have promised that the fencedAssignTimeTick is always less than new incoming insert request by Barrier TimeTick of ManualFlush.
 	// So it's just a promise check here.
@@ -84,7 +146,226 @@ func (m *partitionSegmentManager) AssignSegment(ctx context.Context, req *Assign
 	if req.TimeTick <= m.fencedAssignTimeTick {
 		return nil, ErrFencedAssign
 	}
-	return m.assignSegment(ctx, req)
+	if err := m.checkSealTimeTick(req.TimeTick); err != nil {
+		return nil, err
+	}
+	// the mis-routed insert should never be assigned, otherwise the data lands on the wrong shard silently.
+	if err := checkVChannel(m.collectionID, req.VChannel, m.vchannel); err != nil {
+		return nil, err
+	}
+	if err := checkSchemaVersion(m.collectionID, req.SchemaVersion, m.schemaVersion.Load()); err != nil {
+		return nil, err
+	}
+	// the high priority insert is routed into the dedicated segments,
+	// so its flush latency is not affected by the bulk inserts of the partition.
+	m.checkRowSize(req)
+	highPriority := policy.IsHighPriorityAssign(m.collectionID, req.HighPriority)
+	result, err := m.assignSegment(ctx, req, highPriority)
+	if err != nil {
+		return nil, err
+	}
+	m.ingest.ObserveAssign(req.InsertMetrics.Rows, req.InsertMetrics.BinarySize)
+	if req.TimeTick > m.lastAssign.Load() {
+		m.lastAssign.Store(req.TimeTick)
+	}
+	m.MarkSweepDirty()
+	return result, nil
+}
+
+// ApplyPreassignedSegment applies the insert pre-assigned on the segment by the internal producer without assigning it.
+// The segment must be a growing segment of the partition, the insert is still counted into the stats of the segment.
+func (m *partitionSegmentManager) ApplyPreassignedSegment(ctx context.Context, req *AssignSegmentRequest, segmentID int64) (*AssignSegmentResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkDropping(); err != nil {
+		return nil, err
+	}
+	if err := checkVChannel(m.collectionID, req.VChannel, m.vchannel); err != nil {
+		return nil, err
+	}
+	var segment *segmentAllocManager
+	for _, s := range m.segments {
+		if s.GetSegmentID() == segmentID {
+			segment = s
+			break
+		}
+	}
+	if err := checkPreassignable(segment, segmentID, m.collectionID, m.paritionID); err != nil {
+		return nil, err
+	}
+	result, err := segment.AllocRows(ctx, req)
+	if errors.Is(err, ErrTimeTickTooOld) {
+		m.observeTimeTickTooOld(req.TimeTick, segment.inner.GetStat().GetCreateSegmentTimeTick(), timeTickTooOldAgainstCreate)
+	}
+	if errors.IsAny(err, ErrNotEnoughSpace, ErrTooLargeInsert) {
+		return nil, errors.Wrapf(ErrPreassignedSegmentInvalid, "segment %d can't hold the insert, %s", segmentID, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	m.watcher.Publish(newSegmentAssignmentEvent(AssignmentEventGrow, segment, req.InsertMetrics))
+	m.ingest.ObserveAssign(req.InsertMetrics.Rows, req.InsertMetrics.BinarySize)
+	if req.TimeTick > m.lastAssign.Load() {
+		m.lastAssign.Store(req.TimeTick)
+	}
+	m.MarkSweepDirty()
+	return result, nil
+}
+
+// MarkSweepDirty marks the partition is changed, so it's evaluated by the next seal policy sweep.
+func (m *partitionSegmentManager) MarkSweepDirty() {
+	m.sweepDirty.Store(true)
+}
+
+// takeSweepDirty returns whether the partition is changed since the last seal policy sweep and clears the mark.
+func (m *partitionSegmentManager) takeSweepDirty() bool {
+	return m.sweepDirty.Swap(false)
+}
+
+// QuiescentSince returns the time tick of the last assigned insert of the partition,
+// false is returned if no insert is assigned since the partition is created or the time tick is lost by the restart.
+func (m *partitionSegmentManager) QuiescentSince() (uint64, bool) {
+	lastAssign := m.lastAssign.Load()
+	return lastAssign, lastAssign != 0
+}
+
+// checkDropping checks if the manager is removed by the drop of partition or collection, the lock should be held.
+func (m *partitionSegmentManager) checkDropping() error {
+	if m.dropping {
+		return errors.Wrapf(ErrPartitionDropping, "collection %d, partition %d", m.collectionID, m.paritionID)
+	}
+	return nil
+}
+
+// checkSealTimeTick checks the insert time tick against the last seal time tick of the partition.
+// The insert not newer than the last seal lands in the next growing segment, which breaks the time tick monotonicity across the segments.
+// The violation is always counted, but it's only rejected in the strict mode with ErrTimeTickTooOld to redo it with a refreshed time tick.
+func (m *partitionSegmentManager) checkSealTimeTick(timeTick uint64) error {
+	if timeTick > m.lastSealTimeTick {
+		return nil
+	}
+	mode := policy.GetTimeTickValidationMode(m.collectionID)
+	m.metrics.ObserveTimeTickSealViolation(m.collectionID, mode)
+	if mode != policy.TimeTickValidationModeStrict {
+		return nil
+	}
+	m.observeTimeTickTooOld(timeTick, m.lastSealTimeTick, timeTickTooOldAgainstLastSeal)
+	return errors.Wrapf(ErrTimeTickTooOld, "time tick %d is not greater than the last seal time tick %d of partition %d", timeTick, m.lastSealTimeTick, m.paritionID)
+}
+
+// observeSeal records the max assigned time tick of the insert segment that is removed from assignment to be sealed.
+func (m *partitionSegmentManager) observeSeal(segment *segmentAllocManager) {
+	if segment.IsL0() {
+		return
+	}
+	if _, maxTimeTick := segment.AssignedTimeTickRange(); maxTimeTick > m.lastSealTimeTick {
+		m.lastSealTimeTick = maxTimeTick
+	}
+}
+
+// PreviewAssign evaluates where the insert would land as same as AssignSegment, but never reserves the capacity or creates the segment.
+// The new growing segment is evaluated with the limit without jitter,
+// so an insert near the limit may still be rejected by the new segment with a smaller jittered size.
+func (m *partitionSegmentManager) PreviewAssign(req *AssignPreviewRequest) *streamingpb.AssignPreviewResponse {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	highPriority := policy.IsHighPriorityAssign(m.collectionID, req.HighPriority)
+	limitation := policy.SegmentLimitation{SegmentSize: policy.GetSegmentMaxBinarySizeLimit()}
+	if highPriority {
+		limitation = policy.GetHighPrioritySegmentLimitation(limitation)
+	}
+	limitation = policy.GetSmallCollectionSegmentLimitation(limitation, m.ingest.MaxBinarySize())
+	limitation = policy.GetRowSizeFloorSegmentLimitation(limitation, m.ingest.RowSizeFloor())
+	resp := &streamingpb.AssignPreviewResponse{
+		Result:               streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_NEW_SEGMENT,
+		SegmentMaxBinarySize: limitation.SegmentSize,
+	}
+	resp.RemainingBinarySize, resp.MaxBinarySize = m.bestRemainingBinarySize(highPriority)
+	for _, segment := range m.segments {
+		if segment.IsL0() || segment.IsHighPriority() != highPriority || segment.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
+			continue
+		}
+		stat := segment.GetStat()
+		if stat == nil {
+			continue
+		}
+		if remaining := stat.BinaryCanBeAssign(); req.InsertMetrics.BinarySize <= remaining {
+			resp.Result = streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_FIT_EXISTING
+			resp.SegmentId = segment.GetSegmentID()
+			resp.RemainingBinarySize = remaining
+			resp.MaxBinarySize = stat.MaxBinarySize
+			return resp
+		}
+		if stat.IsEmpty() {
+			// the insert that can not be held by an empty segment is rejected by the assignment directly.
+			resp.Result = streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_TOO_LARGE
+			return resp
+		}
+	}
+	if req.InsertMetrics.BinarySize > resp.SegmentMaxBinarySize {
+		resp.Result = streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_TOO_LARGE
+	}
+	return resp
+}
+
+// AssignDeleteSegment assigns a L0 segment for the deletes of the partition.
+// The L0 segments are a separate track of the partition, they never hold the insert,
+// and the insert segments never hold the deletes.
+func (m *partitionSegmentManager) AssignDeleteSegment(ctx context.Context, req *AssignDeleteSegmentRequest) (*AssignDeleteSegmentResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkDropping(); err != nil {
+		return nil, err
+	}
+	// the L0 segment is fenced by the manual flush as same as the insert segment,
+	// so all the deletes before the fenced time tick are flushed with the manual flush.
+	if req.TimeTick <= m.fencedAssignTimeTick {
+		return nil, ErrFencedAssign
+	}
+	if err := checkVChannel(m.collectionID, req.VChannel, m.vchannel); err != nil {
+		return nil, err
+	}
+	for _, segment := range m.segments {
+		if !segment.IsL0() {
+			continue
+		}
+		if result, err := segment.AllocDeletes(ctx, req); err == nil {
+			return result, nil
+		}
+	}
+
+	// If not assigned, ask a new L0 segment to hold the deletes.
+	newL0Segment, err := m.allocNewL0Segment(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newL0Segment.AllocDeletes(ctx, req)
+}
+
+// Prewarm allocates a new growing segment if there's no growing segment in the partition,
+// so the first incoming insert doesn't need to wait for the segment allocation.
+// Only the normal segment is prewarmed, the high priority and L0 segment are allocated on demand.
+// Return true if a new growing segment is allocated.
+func (m *partitionSegmentManager) Prewarm(ctx context.Context) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkDropping(); err != nil {
+		return false, err
+	}
+
+	for _, segment := range m.segments {
+		if segment.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING && !segment.IsL0() && !segment.IsHighPriority() {
+			return false, nil
+		}
+	}
+	if _, err := m.allocNewGrowingSegment(ctx, false, streamingpb.SegmentAssignmentOrigin_SEGMENT_ASSIGNMENT_ORIGIN_PRE_ALLOCATED); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // SealAndFenceSegmentUntil seal all segment that contains the message less than the incoming timetick.
@@ -107,14 +388,89 @@ func (m *partitionSegmentManager) SealAndFenceSegmentUntil(timeTick uint64) (sea
 	return segmentManagers
 }
 
+// FencedTimeTick returns the time tick that the assign operation is fenced.
+func (m *partitionSegmentManager) FencedTimeTick() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.fencedAssignTimeTick
+}
+
+// CollectOlderThan collects all growing segments whose assigned insert are all not greater than the given timetick.
+// No fence is applied, so the new incoming insert can still be assigned to the partition.
+// The recovered segment without new insert is judged by its last modified time.
+func (m *partitionSegmentManager) CollectOlderThan(timeTick uint64) []*segmentAllocManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.collectShouldBeSealedWithPolicy(func(segmentMeta *segmentAllocManager) (policy.PolicyName, bool) {
+		return policy.PolicyNameOlderThan, segmentMeta.IsOlderThan(timeTick)
+	})
+}
+
+// CollectSchemaOutdated collects all growing segments that are created under the older schema version.
+// The L0 segment only holds the deletes by primary key, so it's never outdated by the schema change.
+func (m *partitionSegmentManager) CollectSchemaOutdated(schemaVersion uint64) []*segmentAllocManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.collectShouldBeSealedWithPolicy(func(segmentMeta *segmentAllocManager) (policy.PolicyName, bool) {
+		return policy.PolicyNameSchemaChanged, !segmentMeta.IsL0() && segmentMeta.GetSchemaVersion() < schemaVersion
+	})
+}
+
 // CollectShouldBeSealed try to collect all segments that should be sealed.
+// The collection opted out of the auto seal only gives up the recovered sealed segments.
 func (m *partitionSegmentManager) CollectShouldBeSealed() []*segmentAllocManager {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if policy.IsAutoSealDisabled(m.collectionID) {
+		return m.collectShouldBeSealedWithPolicy(neverHitSealPolicy)
+	}
 	return m.collectShouldBeSealedWithPolicy(m.hitSealPolicy)
 }
 
+// ReconcileMaxBinarySize reconciles the growing segments whose max binary size exceeds the current limit by more than the factor.
+// The stale segment is re-capped to the current limit if recap is enabled and its inserted binary size is still below the limit,
+// otherwise it's collected to be sealed.
+// The collection opted out of the auto seal is never reconciled.
+func (m *partitionSegmentManager) ReconcileMaxBinarySize(limit uint64, factor float64, recap bool) []*segmentAllocManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if policy.IsAutoSealDisabled(m.collectionID) {
+		return nil
+	}
+
+	// the segment bumped by the row size check is never reconciled below the floor.
+	limit = max(limit, m.ingest.RowSizeFloor())
+	return m.collectShouldBeSealedWithPolicy(func(segmentMeta *segmentAllocManager) (policy.PolicyName, bool) {
+		stat := segmentMeta.GetStat()
+		if stat == nil || float64(stat.MaxBinarySize) <= float64(limit)*factor {
+			return "", false
+		}
+		if recap {
+			if oldMaxBinarySize, ok := resource.Resource().SegmentAssignStatsManager().RecapMaxBinarySize(segmentMeta.GetSegmentID(), limit); ok {
+				m.logger.Info("segment max binary size is re-capped by current limit",
+					zap.Int64("segmentID", segmentMeta.GetSegmentID()),
+					zap.Uint64("oldMaxBinarySize", oldMaxBinarySize),
+					zap.Uint64("newMaxBinarySize", limit),
+					zap.Uint64("insertedBinarySize", stat.Insert.BinarySize),
+				)
+				return "", false
+			}
+		}
+		m.logger.Info("segment max binary size exceeds current limit, seal it",
+			zap.Int64("segmentID", segmentMeta.GetSegmentID()),
+			zap.Uint64("oldMaxBinarySize", stat.MaxBinarySize),
+			zap.Uint64("newMaxBinarySize", limit),
+			zap.Uint64("insertedBinarySize", stat.Insert.BinarySize),
+		)
+		return policy.PolicyNameMaxSizeReconciled, true
+	})
+}
+
 // CollectionMustSealed seals the specified segment.
 func (m *partitionSegmentManager) CollectionMustSealed(segmentID int64) *segmentAllocManager {
 	m.mu.Lock()
@@ -124,6 +480,7 @@ func (m *partitionSegmentManager) CollectionMustSealed(segmentID int64) *segment
 	m.segments = lo.Filter(m.segments, func(segment *segmentAllocManager, _ int) bool {
 		if segment.inner.GetSegmentId() == segmentID {
 			target = segment.WithSealPolicy(policy.PolicyNameForce)
+			m.observeSeal(segment)
 			return false
 		}
 		return true
@@ -151,6 +508,7 @@ func (m *partitionSegmentManager) collectShouldBeSealedWithPolicy(predicates fun
 		if segment.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
 			if policyName, shouldBeSealed := predicates(segment); shouldBeSealed {
 				shouldBeSealedSegments = append(shouldBeSealedSegments, segment.WithSealPolicy(policyName))
+				m.observeSeal(segment)
 				m.logger.Info("segment should be sealed by policy",
 					zap.Int64("segmentID", segment.GetSegmentID()),
 					zap.String("policy", string(policyName)),
@@ -165,21 +523,48 @@ func (m *partitionSegmentManager) collectShouldBeSealedWithPolicy(predicates fun
 	return shouldBeSealedSegments
 }
 
+// UnsealSegment reverts the sealed segment back to growing, so the new incoming insert can be assigned on it again.
+func (m *partitionSegmentManager) UnsealSegment(ctx context.Context, segment *segmentAllocManager) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkDropping(); err != nil {
+		return err
+	}
+
+	tx := segment.BeginModification()
+	tx.IntoUnsealed()
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	segment.resetSealDecision()
+	m.segments = append(m.segments, segment)
+	m.MarkSweepDirty()
+	m.watcher.Publish(newSegmentAssignmentEvent(AssignmentEventUnseal, segment, stats.InsertMetrics{}))
+	return nil
+}
+
 // CollectAllSegmentsAndClear collects all segments in the manager and clear the manager.
+// The manager is marked as dropping, the following assignment on it is rejected with ErrPartitionDropping.
 func (m *partitionSegmentManager) CollectAllSegmentsAndClear() []*segmentAllocManager {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.dropping = true
+
 	segments := m.segments
 	m.segments = nil
 	return segments
 }
 
 // CollectAllCanBeSealedAndClear collects all segments that can be sealed and clear the manager.
+// The manager is marked as dropping, the following assignment on it is rejected with ErrPartitionDropping.
 func (m *partitionSegmentManager) CollectAllCanBeSealedAndClear(policy policy.PolicyName) []*segmentAllocManager {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.dropping = true
+
 	canBeSealed := make([]*segmentAllocManager, 0, len(m.segments))
 	for _, segment := range m.segments {
 		if segment.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING ||
@@ -191,11 +576,24 @@ func (m *partitionSegmentManager) CollectAllCanBeSealedAndClear(policy policy.Po
 	return canBeSealed
 }
 
+// neverHitSealPolicy is the predicate that never seals the growing segment.
+func neverHitSealPolicy(*segmentAllocManager) (policy.PolicyName, bool) {
+	return "", false
+}
+
 // hitSealPolicy checks if the segment should be sealed by policy.
 func (m *partitionSegmentManager) hitSealPolicy(segmentMeta *segmentAllocManager) (policy.PolicyName, bool) {
-	stat := segmentMeta.GetStat()
-	for _, p := range policy.GetSegmentAsyncSealPolicy() {
-		if result := p.ShouldBeSealed(stat); result.ShouldBeSealed {
+	if segmentMeta.IsL0() {
+		return m.hitL0SealPolicy(segmentMeta)
+	}
+	stat := m.statForSealPolicy(segmentMeta)
+	now := resource.Resource().Clock().Now()
+	for _, p := range policy.GetCollectionAsyncSealPolicy(m.collectionID) {
+		if result := p.ShouldBeSealed(stat, now); result.ShouldBeSealed {
+			if policy.IsInSealGracePeriod(stat.CreateTime, now) {
+				m.deferSealByGracePeriod(segmentMeta, result, stat.CreateTime)
+				return "", false
+			}
 			m.logger.Info("segment should be sealed by policy",
 				zap.Int64("segmentID", segmentMeta.GetSegmentID()),
 				zap.String("policy", string(result.PolicyName)),
@@ -208,39 +606,113 @@ func (m *partitionSegmentManager) hitSealPolicy(segmentMeta *segmentAllocManager
 	return "", false
 }
 
-// allocNewGrowingSegment allocates a new growing segment.
+// statForSealPolicy returns the stat of the segment checked by the async seal policies.
+// The last modified time is replaced by the last assigned insert of the partition if it's known,
+// so the idle seal is decided by the quiescence of the partition rather than the wall clock of the segment stats.
+func (m *partitionSegmentManager) statForSealPolicy(segmentMeta *segmentAllocManager) *stats.SegmentStats {
+	stat := segmentMeta.GetStat()
+	lastAssign, ok := m.QuiescentSince()
+	if stat == nil || !ok {
+		return stat
+	}
+	stat = stat.Copy()
+	stat.LastModifiedTime = resource.Resource().Clock().PhysicalTime(lastAssign)
+	return stat
+}
+
+// hitL0SealPolicy checks if the L0 segment should be sealed by the L0 seal policy.
+func (m *partitionSegmentManager) hitL0SealPolicy(segmentMeta *segmentAllocManager) (policy.PolicyName, bool) {
+	stat := segmentMeta.GetDeleteStat()
+	if stat == nil {
+		return "", false
+	}
+	now := resource.Resource().Clock().Now()
+	for _, p := range policy.GetL0SegmentAsyncSealPolicy() {
+		if result := p.ShouldBeSealed(stat, now); result.ShouldBeSealed {
+			if policy.IsInSealGracePeriod(stat.CreateTime, now) {
+				m.deferSealByGracePeriod(segmentMeta, result, stat.CreateTime)
+				return "", false
+			}
+			m.logger.Info("L0 segment should be sealed by policy",
+				zap.Int64("segmentID", segmentMeta.GetSegmentID()),
+				zap.String("policy", string(result.PolicyName)),
+				zap.Any("stat", stat),
+				zap.Any("extraInfo", result.ExtraInfo),
+			)
+			return result.PolicyName, true
+		}
+	}
+	return "", false
+}
+
+// deferSealByGracePeriod records the policy-driven seal of the segment is deferred by the seal grace period.
+// Only the async seal policies are deferred, the manual flush, drop and fence operations never check the grace period.
+func (m *partitionSegmentManager) deferSealByGracePeriod(segmentMeta *segmentAllocManager, result policy.SealPolicyResult, createTime time.Time) {
+	m.metrics.ObserveSealDeferred(string(result.PolicyName))
+	m.logger.Debug("segment seal is deferred by the grace period",
+		zap.Int64("segmentID", segmentMeta.GetSegmentID()),
+		zap.String("policy", string(result.PolicyName)),
+		zap.Time("createTime", createTime),
+	)
+}
+
+// allocNewL0Segment allocates a new growing L0 segment.
+// The L0 segment is not registered at datacoord and no create segment message is sent into wal,
+// the downstream will see it by its flush after the L0 segment is supported by the flusher.
+func (m *partitionSegmentManager) allocNewL0Segment(ctx context.Context) (*segmentAllocManager, error) {
+	// A pending L0 segment may be already created when failure or recovery.
+	pendingSegment := m.findPendingSegmentInMeta(streamingpb.SegmentAssignmentLevel_SEGMENT_ASSIGNMENT_LEVEL_L0, false)
+	if pendingSegment == nil {
+		var err error
+		if pendingSegment, err = m.createNewPendingSegment(ctx, streamingpb.SegmentAssignmentLevel_SEGMENT_ASSIGNMENT_LEVEL_L0, false); err != nil {
+			return nil, err
+		}
+	}
+
+	tx := pendingSegment.BeginModification()
+	tx.IntoL0Growing()
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit modification of L0 segment assignment into growing, segmentID: %d", pendingSegment.GetSegmentID())
+	}
+	m.watcher.Publish(newSegmentAssignmentEvent(AssignmentEventCreate, pendingSegment, stats.InsertMetrics{}))
+	m.logger.Info("generate new growing L0 segment", zap.Int64("segmentID", pendingSegment.GetSegmentID()))
+	return pendingSegment, nil
+}
+
+// allocNewGrowingSegment allocates a new growing segment of the given route.
+// The origin labels how the segment is allocated, the recovered pending segment keeps its reconciled origin.
 // After this operation, the growing segment can be seen at datacoord.
-func (m *partitionSegmentManager) allocNewGrowingSegment(ctx context.Context) (*segmentAllocManager, error) {
+func (m *partitionSegmentManager) allocNewGrowingSegment(ctx context.Context, highPriority bool, origin streamingpb.SegmentAssignmentOrigin) (*segmentAllocManager, error) {
+	start := time.Now()
+	defer func() {
+		m.metrics.ObserveSegmentAllocation(time.Since(start))
+	}()
+
 	// A pending segment may be already created when failure or recovery.
-	pendingSegment := m.findPendingSegmentInMeta()
+	pendingSegment := m.findPendingSegmentInMeta(streamingpb.SegmentAssignmentLevel_SEGMENT_ASSIGNMENT_LEVEL_L1, highPriority)
 	if pendingSegment == nil {
 		// if there's no pending segment, create a new pending segment.
 		var err error
-		if pendingSegment, err = m.createNewPendingSegment(ctx); err != nil {
+		if pendingSegment, err = m.createNewPendingSegment(ctx, streamingpb.SegmentAssignmentLevel_SEGMENT_ASSIGNMENT_LEVEL_L1, highPriority); err != nil {
 			return nil, err
 		}
 	}
 
 	// Transfer the pending segment into growing state.
 	// Alloc the growing segment at datacoord first.
-	mix, err := resource.Resource().MixCoordClient().GetWithContext(ctx)
-	if err != nil {
+	if err := m.allocSegmentAtCoord(ctx, pendingSegment); err != nil {
 		return nil, err
 	}
-	resp, err := mix.AllocSegment(ctx, &datapb.AllocSegmentRequest{
-		CollectionId:         pendingSegment.GetCollectionID(),
-		PartitionId:          pendingSegment.GetPartitionID(),
-		SegmentId:            pendingSegment.GetSegmentID(),
-		Vchannel:             pendingSegment.GetVChannel(),
-		StorageVersion:       pendingSegment.GetStorageVersion(),
-		IsCreatedByStreaming: true,
-	})
-	if err := merr.CheckRPCCall(resp, err); err != nil {
-		return nil, errors.Wrap(err, "failed to alloc growing segment at datacoord")
-	}
 
 	// Getnerate growing segment limitation.
-	limitation := policy.GetSegmentLimitationPolicy().GenerateLimitation()
+	limitation := policy.GetSegmentLimitationPolicy().GenerateLimitation(m.random)
+	if pendingSegment.IsHighPriority() {
+		limitation = policy.GetHighPrioritySegmentLimitation(limitation)
+	}
+	// the small collection is capped by the max binary size derived from its ingest rate.
+	limitation = policy.GetSmallCollectionSegmentLimitation(limitation, m.ingest.MaxBinarySize())
+	// the collection failing the row size check is raised to the floor bumped by the check.
+	limitation = policy.GetRowSizeFloorSegmentLimitation(limitation, m.ingest.RowSizeFloor())
 	msg, err := message.NewCreateSegmentMessageBuilderV2().
 		WithVChannel(pendingSegment.GetVChannel()).
 		WithHeader(&message.CreateSegmentMessageHeader{
@@ -248,10 +720,11 @@ func (m *partitionSegmentManager) allocNewGrowingSegment(ctx context.Context) (*
 			// We only execute one segment creation operation at a time.
 			// But in future, we need to modify the segment creation operation to support batch creation.
 			// Because the partition-key based collection may create huge amount of segments at the same time.
-			PartitionId:    pendingSegment.GetPartitionID(),
-			SegmentId:      pendingSegment.GetSegmentID(),
-			StorageVersion: pendingSegment.GetStorageVersion(),
-			MaxSegmentSize: limitation.SegmentSize,
+			PartitionId:     pendingSegment.GetPartitionID(),
+			SegmentId:       pendingSegment.GetSegmentID(),
+			StorageVersion:  pendingSegment.GetStorageVersion(),
+			MaxSegmentSize:  limitation.SegmentSize,
+			AssignmentEpoch: pendingSegment.GetAssignmentEpoch(),
 		}).
 		WithBody(&message.CreateSegmentMessageBody{}).BuildMutable()
 	if err != nil {
@@ -267,25 +740,105 @@ func (m *partitionSegmentManager) allocNewGrowingSegment(ctx context.Context) (*
 	// growing segment can be assigned now.
 	tx := pendingSegment.BeginModification()
 	tx.IntoGrowing(&limitation, msgID.TimeTick)
+	tx.WithOrigin(origin)
 	if err := tx.Commit(ctx); err != nil {
 		return nil, errors.Wrapf(err, "failed to commit modification of segment assignment into growing, segmentID: %d", pendingSegment.GetSegmentID())
 	}
+	m.watcher.Publish(newSegmentAssignmentEvent(AssignmentEventCreate, pendingSegment, stats.InsertMetrics{}))
+	// notify the consumer side to prepare for the new growing segment, the pending segment never reaches here.
+	inspector.GetGrowingSegmentNotifier().Notify(inspector.GrowingSegmentInfo{
+		PChannel:       m.pchannel.Name,
+		VChannel:       pendingSegment.GetVChannel(),
+		CollectionID:   pendingSegment.GetCollectionID(),
+		PartitionID:    pendingSegment.GetPartitionID(),
+		SegmentID:      pendingSegment.GetSegmentID(),
+		MaxBinarySize:  limitation.SegmentSize,
+		StorageVersion: pendingSegment.GetStorageVersion(),
+	})
 	m.logger.Info("generate new growing segment",
 		zap.Int64("segmentID", pendingSegment.GetSegmentID()),
 		zap.String("messageID", msgID.MessageID.String()),
 		zap.Uint64("timetick", msgID.TimeTick),
+		zap.Bool("highPriority", pendingSegment.IsHighPriority()),
+		zap.String("origin", pendingSegment.GetAssignmentOrigin().String()),
+		zap.Int64("storageVersion", pendingSegment.GetStorageVersion()),
 		zap.String("limitationPolicy", limitation.PolicyName),
 		zap.Uint64("segmentBinarySize", limitation.SegmentSize),
 		zap.Any("extraInfo", limitation.ExtraInfo),
 	)
+	m.checkMixedStorageVersions()
+	m.checkCreationRate(limitation)
 	return pendingSegment, nil
 }
 
-// findPendingSegmentInMeta finds a pending segment in the meta list.
-func (m *partitionSegmentManager) findPendingSegmentInMeta() *segmentAllocManager {
+// checkMixedStorageVersions warns if the growing segments of the collection are of different storage versions,
+// it means the storage version is toggled while the collection is being written.
+func (m *partitionSegmentManager) checkMixedStorageVersions() {
+	versions := resource.Resource().SegmentAssignStatsManager().GetStorageVersionsOfCollection(m.collectionID)
+	if len(versions) <= 1 {
+		return
+	}
+	m.logger.Warn("collection has growing segments of mixed storage versions, the storage version may be toggled mid-stream",
+		zap.Any("segmentCountByStorageVersion", versions))
+}
+
+// allocSegmentAtCoord allocates the growing segment at datacoord.
+// The response is validated against the request, the mismatched response is retried once before the failure is surfaced.
+func (m *partitionSegmentManager) allocSegmentAtCoord(ctx context.Context, pendingSegment *segmentAllocManager) error {
+	mix, err := resource.Resource().MixCoordClient().GetWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	req := &datapb.AllocSegmentRequest{
+		CollectionId:         pendingSegment.GetCollectionID(),
+		PartitionId:          pendingSegment.GetPartitionID(),
+		SegmentId:            pendingSegment.GetSegmentID(),
+		Vchannel:             pendingSegment.GetVChannel(),
+		StorageVersion:       pendingSegment.GetStorageVersion(),
+		IsCreatedByStreaming: true,
+	}
+	for attempt := 1; ; attempt++ {
+		if err := resource.Resource().FaultInjector().Inject(ctx, faultinject.PointAllocSegment); err != nil {
+			return errors.Wrap(err, "failed to alloc growing segment at datacoord")
+		}
+		resp, err := mix.AllocSegment(ctx, req)
+		if err := merr.CheckRPCCall(resp, err); err != nil {
+			return errors.Wrap(err, "failed to alloc growing segment at datacoord")
+		}
+		err = validateAllocSegmentResponse(req, resp, m.isKnownSegment)
+		if err == nil {
+			return nil
+		}
+		var mismatch *AllocSegmentMismatchError
+		if errors.As(err, &mismatch) {
+			m.metrics.ObserveAllocMismatch(mismatch.Reason)
+		}
+		m.logger.Warn("alloc segment response of datacoord mismatches the request",
+			zap.Int64("segmentID", req.GetSegmentId()),
+			zap.Int("attempt", attempt),
+			zap.Error(err))
+		if attempt >= maxAllocSegmentAttempts {
+			return errors.Wrapf(err, "failed to alloc growing segment at datacoord, segmentID: %d", req.GetSegmentId())
+		}
+	}
+}
+
+// isKnownSegment checks if the segment is already known by the partition manager.
+func (m *partitionSegmentManager) isKnownSegment(segmentID int64) bool {
+	for _, segment := range m.segments {
+		if segment.GetSegmentID() == segmentID {
+			return true
+		}
+	}
+	return false
+}
+
+// findPendingSegmentInMeta finds a pending segment of the given level and route in the meta list.
+func (m *partitionSegmentManager) findPendingSegmentInMeta(level streamingpb.SegmentAssignmentLevel, highPriority bool) *segmentAllocManager {
 	// Found if there's already a pending segment.
 	for _, segment := range m.segments {
-		if segment.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING {
+		if segment.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING &&
+			segment.inner.GetLevel() == level && segment.IsHighPriority() == highPriority {
 			return segment
 		}
 	}
@@ -297,17 +850,24 @@ func (m *partitionSegmentManager) findPendingSegmentInMeta() *segmentAllocManage
 // and will be transfer into growing state until registering to datacoord.
 // The segment id is always allocated from rootcoord to avoid repeated.
 // Pending state is used to avoid growing segment leak at datacoord.
-func (m *partitionSegmentManager) createNewPendingSegment(ctx context.Context) (*segmentAllocManager, error) {
+func (m *partitionSegmentManager) createNewPendingSegment(ctx context.Context, level streamingpb.SegmentAssignmentLevel, highPriority bool) (*segmentAllocManager, error) {
 	// Allocate new segment id and create ts from remote.
 	segmentID, err := resource.Resource().IDAllocator().Allocate(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to allocate segment id")
 	}
-	storageVersion := storage.StorageV1
-	if paramtable.Get().CommonCfg.EnableStorageV2.GetAsBool() {
-		storageVersion = storage.StorageV2
+	// the storage version is recorded on the segment meta, so the recovered segment keeps its format.
+	storageVersion := policy.GetSegmentStorageVersion(m.collectionID)
+	// the schema version is stamped at creation, so the downstream knows the schema of the segment before it's sealed.
+	schemaVersion := m.schemaVersion.Load()
+	meta := newSegmentAllocManager(m.pchannel, m.collectionID, m.paritionID, int64(segmentID), m.vchannel, m.metrics, storageVersion, schemaVersion, level, highPriority)
+	// the new segment carries the last assign time tick of the partition forward after the older segments are flushed.
+	meta.bindPartitionLastAssign(m.lastAssign)
+	meta.bindPartitionSweepDirty(m.sweepDirty)
+	if level == streamingpb.SegmentAssignmentLevel_SEGMENT_ASSIGNMENT_LEVEL_L1 && isDeferMetaCreationEnabled() {
+		// the meta is written into catalog by the first durable insert of the segment.
+		meta.deferMeta()
 	}
-	meta := newSegmentAllocManager(m.pchannel, m.collectionID, m.paritionID, int64(segmentID), m.vchannel, m.metrics, storageVersion)
 	tx := meta.BeginModification()
 	tx.IntoPending()
 	if err := tx.Commit(ctx); err != nil {
@@ -318,21 +878,31 @@ func (m *partitionSegmentManager) createNewPendingSegment(ctx context.Context) (
 }
 
 // assignSegment assigns a segment for a assign segment request and return should trigger a seal operation.
-func (m *partitionSegmentManager) assignSegment(ctx context.Context, req *AssignSegmentRequest) (*AssignSegmentResult, error) {
+// The high priority insert is only assigned on the high priority segments, and the bulk insert only on the normal segments.
+// The insert is never assigned on the L0 segments.
+func (m *partitionSegmentManager) assignSegment(ctx context.Context, req *AssignSegmentRequest, highPriority bool) (*AssignSegmentResult, error) {
 	hitTimeTickTooOld := false
+	// the max create time tick of the segments rejecting the insert by the too old time tick.
+	tooOldReference := uint64(0)
 	// Alloc segment for insert at allocated segments.
-	for _, segment := range m.segments {
+	for _, segment := range m.candidatesOfShardHint(req.ShardHint, highPriority) {
+		if segment.IsL0() || segment.IsHighPriority() != highPriority {
+			continue
+		}
 		result, err := segment.AllocRows(ctx, req)
 		if err == nil {
+			m.watcher.Publish(newSegmentAssignmentEvent(AssignmentEventGrow, segment, req.InsertMetrics))
+			m.asyncReserveIfNeeded(segment, highPriority)
 			return result, nil
 		}
 		if errors.IsAny(err, ErrTooLargeInsert) {
 			// Return error directly.
 			// If the insert message is too large to hold by single segment, it can not be inserted anymore.
-			return nil, err
+			return nil, m.fillBestRemainingBinarySize(err, highPriority)
 		}
 		if errors.Is(err, ErrTimeTickTooOld) {
 			hitTimeTickTooOld = true
+			tooOldReference = max(tooOldReference, segment.inner.GetStat().GetCreateSegmentTimeTick())
 		}
 	}
 
@@ -340,13 +910,154 @@ func (m *partitionSegmentManager) assignSegment(ctx context.Context, req *Assign
 	// (new growing segment's timetick is always greater than the old gorwing segmet's timetick).
 	// Return directly to avoid unnecessary growing segment allocation.
 	if hitTimeTickTooOld {
+		m.observeTimeTickTooOld(req.TimeTick, tooOldReference, timeTickTooOldAgainstCreate)
 		return nil, ErrTimeTickTooOld
 	}
 
 	// If not inserted, ask a new growing segment to insert.
-	newGrowingSegment, err := m.allocNewGrowingSegment(ctx)
+	newGrowingSegment, err := m.allocNewGrowingSegment(ctx, highPriority, streamingpb.SegmentAssignmentOrigin_SEGMENT_ASSIGNMENT_ORIGIN_NORMAL)
 	if err != nil {
 		return nil, err
 	}
-	return newGrowingSegment.AllocRows(ctx, req)
+	result, err := newGrowingSegment.AllocRows(ctx, req)
+	if err != nil {
+		return nil, m.fillBestRemainingBinarySize(err, highPriority)
+	}
+	m.watcher.Publish(newSegmentAssignmentEvent(AssignmentEventGrow, newGrowingSegment, req.InsertMetrics))
+	m.asyncReserveIfNeeded(newGrowingSegment, highPriority)
+	return result, nil
+}
+
+// candidatesOfShardHint returns the segments that the insert is tried on in order.
+// If the insert declares a shard hint and there're multiple growing segments of the route,
+// the segments are ordered by the rendezvous score of the hint, so the rows of the same hint are kept in the same growing segment until it's sealed.
+// The capacity is still checked by the allocation, the insert falls through to the next candidate if the preferred one can not hold it.
+// The hint is ignored if there's only one growing segment of the route.
+func (m *partitionSegmentManager) candidatesOfShardHint(hint uint64, highPriority bool) []*segmentAllocManager {
+	if !m.isShardHintApplied(hint, highPriority) {
+		return m.segments
+	}
+	candidates := make([]*segmentAllocManager, len(m.segments))
+	copy(candidates, m.segments)
+	scores := make(map[int64]uint64, len(candidates))
+	for _, segment := range candidates {
+		scores[segment.GetSegmentID()] = policy.ShardHintScore(hint, segment.GetSegmentID())
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return scores[candidates[i].GetSegmentID()] > scores[candidates[j].GetSegmentID()]
+	})
+	return candidates
+}
+
+// isShardHintApplied checks if the shard hint orders the candidates, it's ignored if there's only one growing segment of the route.
+func (m *partitionSegmentManager) isShardHintApplied(hint uint64, highPriority bool) bool {
+	if hint == 0 {
+		return false
+	}
+	growing := 0
+	for _, segment := range m.segments {
+		if !segment.IsL0() && segment.IsHighPriority() == highPriority && segment.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
+			growing++
+		}
+	}
+	return growing >= 2
+}
+
+// asyncReserveIfNeeded pre-allocates the next growing segment of the route in background once the assigned segment passes the fill threshold,
+// so the write burst that can not be held by the assigned segment is switched to the reserved one without allocating a segment.
+// It's called with the lock held, the assignment never waits for the allocation, the reserved segment is picked up by the following assignment.
+// At most one reservation is in flight for a partition.
+func (m *partitionSegmentManager) asyncReserveIfNeeded(assigned *segmentAllocManager, highPriority bool) {
+	if !m.shouldReserve(assigned, highPriority) || !m.reserving.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer m.reserving.Store(false)
+		ctx, cancel := context.WithTimeout(context.Background(), reserveTimeout)
+		defer cancel()
+		m.reserve(ctx, assigned, highPriority)
+	}()
+}
+
+// reserve allocates the reserved growing segment of the route.
+// The condition is checked again, because the assigned segment may be sealed or the partition may be dropped before the lock is acquired.
+// The reservation is best-effort, the insert allocates the segment on demand if the reservation is failed.
+func (m *partitionSegmentManager) reserve(ctx context.Context, assigned *segmentAllocManager, highPriority bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.dropping || !m.shouldReserve(assigned, highPriority) {
+		return
+	}
+	segment, err := m.allocNewGrowingSegment(ctx, highPriority, streamingpb.SegmentAssignmentOrigin_SEGMENT_ASSIGNMENT_ORIGIN_PRE_ALLOCATED)
+	if err != nil {
+		m.logger.Warn("failed to reserve the next growing segment", zap.Int64("assignedSegmentID", assigned.GetSegmentID()), zap.Error(err))
+		return
+	}
+	m.logger.Info("reserve the next growing segment",
+		zap.Int64("assignedSegmentID", assigned.GetSegmentID()),
+		zap.Int64("reservedSegmentID", segment.GetSegmentID()),
+		zap.Float64("fillThreshold", policy.GetReserveFillThreshold()))
+}
+
+// shouldReserve checks if the next growing segment of the route should be reserved for the assigned segment, the lock should be held.
+func (m *partitionSegmentManager) shouldReserve(assigned *segmentAllocManager, highPriority bool) bool {
+	if !policy.IsReserveEnabled(m.paritionID) {
+		return false
+	}
+	threshold := policy.GetReserveFillThreshold()
+	if assigned.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING || !isFilledOver(assigned, threshold) {
+		return false
+	}
+	for _, segment := range m.segments {
+		if segment == assigned || segment.IsL0() || segment.IsHighPriority() != highPriority {
+			continue
+		}
+		if segment.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING && !isFilledOver(segment, threshold) {
+			// the reserved segment is already allocated.
+			return false
+		}
+	}
+	return true
+}
+
+// isFilledOver checks if the assigned binary size of the growing segment passes the fill threshold.
+func isFilledOver(segment *segmentAllocManager, threshold float64) bool {
+	stat := segment.GetStat()
+	if stat == nil || stat.MaxBinarySize == 0 {
+		return false
+	}
+	return float64(stat.Insert.BinarySize) >= threshold*float64(stat.MaxBinarySize)
+}
+
+// fillBestRemainingBinarySize fills the remaining capacity of the best candidate growing segment of the route into the too large insert error,
+// so the client can re-batch the insert into the size that can be held.
+func (m *partitionSegmentManager) fillBestRemainingBinarySize(err error, highPriority bool) error {
+	var tooLarge *TooLargeInsertError
+	if !errors.As(err, &tooLarge) {
+		return err
+	}
+	if remaining, maxBinarySize := m.bestRemainingBinarySize(highPriority); remaining > tooLarge.RemainingBinarySize {
+		tooLarge.RemainingBinarySize = remaining
+		tooLarge.MaxBinarySize = maxBinarySize
+	}
+	return tooLarge
+}
+
+// bestRemainingBinarySize returns the remaining capacity and the max binary size of the best candidate growing segment of the route.
+func (m *partitionSegmentManager) bestRemainingBinarySize(highPriority bool) (remaining uint64, maxBinarySize uint64) {
+	for _, segment := range m.segments {
+		if segment.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING || segment.IsL0() || segment.IsHighPriority() != highPriority {
+			continue
+		}
+		stat := segment.GetStat()
+		if stat == nil {
+			continue
+		}
+		if r := stat.BinaryCanBeAssign(); r > remaining {
+			remaining = r
+			maxBinarySize = stat.MaxBinarySize
+		}
+	}
+	return remaining, maxBinarySize
 }
@@ -1,9 +1,13 @@
 package manager
 
 import (
+	"context"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
@@ -19,13 +23,19 @@ import (
 	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
 )
 
+var ErrCollectionNotFound = errors.New("collection not found")
+
 // buildNewPartitionManagers builds new partition managers.
 func buildNewPartitionManagers(
 	wal *syncutil.Future[wal.WAL],
 	pchannel types.PChannelInfo,
 	rawMetas []*streamingpb.SegmentAssignmentMeta,
 	collectionInfos []*rootcoordpb.CollectionInfoOnPChannel,
+	recoveredSchemaVersions map[int64]uint64,
 	metrics *metricsutil.SegmentAssignMetrics,
+	watcher *assignmentWatcher,
+	random *sealRandom,
+	summary *RecoverySummary,
 ) (*partitionSegmentManagers, []*segmentAllocManager) {
 	// create a map to check if the partition exists.
 	partitionExist := make(map[int64]struct{}, len(collectionInfos))
@@ -38,17 +48,55 @@ func buildNewPartitionManagers(
 		collectionInfoMap[collectionInfo.GetCollectionId()] = collectionInfo
 	}
 
+	logger := resource.Resource().Logger().With(
+		log.FieldComponent("segment-assigner"),
+		zap.String("pchannel", pchannel.Name),
+	)
+
 	// recover the segment infos from the streaming node segment assignment meta storage
 	waitForSealed := make([]*segmentAllocManager, 0)
 	metaMaps := make(map[int64][]*segmentAllocManager)
+	classCounter := make(map[recoveredMetaClass]int, 3)
 	for _, rawMeta := range rawMetas {
+		rawMeta, class, reasons := validateRecoveredMeta(rawMeta, random)
+		classCounter[class]++
+		summary.observeMeta(class)
+		metrics.ObserveSegmentRecovered(string(class))
+		if class != recoveredMetaClassValid {
+			logger.Warn("recovered segment assignment meta violates invariants",
+				zap.Int64("collectionID", rawMeta.GetCollectionId()),
+				zap.Int64("partitionID", rawMeta.GetPartitionId()),
+				zap.Int64("segmentID", rawMeta.GetSegmentId()),
+				zap.String("vchannel", rawMeta.GetVchannel()),
+				zap.String("class", string(class)),
+				zap.Strings("reasons", reasons))
+		}
 		m := newSegmentAllocManagerFromProto(pchannel, rawMeta, metrics)
+		if rawMeta.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_DROPPED {
+			// the segment is discarded but not deleted physically before crash, delete it right now.
+			waitForSealed = append(waitForSealed, m.WithDiscard())
+			summary.Discarded++
+			continue
+		}
+		summary.observeSegment(m)
+		if class == recoveredMetaClassQuarantined {
+			// quarantined segment should be sealed right now whatever the partition exists or not.
+			m.markRecoveryReconciled()
+			waitForSealed = append(waitForSealed, m.WithSealPolicy(policy.PolicyNameQuarantined))
+			continue
+		}
 		if _, ok := partitionExist[rawMeta.GetPartitionId()]; !ok {
 			// related collection or partition is not exist.
 			// should be sealed right now.
+			m.markRecoveryReconciled()
 			waitForSealed = append(waitForSealed, m.WithSealPolicy(policy.PolicyNamePartitionNotFound))
+			summary.Orphans++
 			continue
 		}
+		if rawMeta.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING {
+			// the recovered pending segment is promoted into growing by the reconciliation but not a new allocation.
+			m.markRecoveryReconciled()
+		}
 		if _, ok := metaMaps[rawMeta.GetPartitionId()]; !ok {
 			metaMaps[rawMeta.GetPartitionId()] = make([]*segmentAllocManager, 0, 2)
 		}
@@ -57,8 +105,14 @@ func buildNewPartitionManagers(
 
 	// create managers list.
 	managers := typeutil.NewConcurrentMap[int64, *partitionSegmentManager]()
+	schemaVersions := make(map[int64]*atomic.Uint64, len(collectionInfoMap))
+	ingests := make(map[int64]*collectionIngest, len(collectionInfoMap))
 	for collectionID, collectionInfo := range collectionInfoMap {
+		schemaVersions[collectionID] = atomic.NewUint64(recoveredSchemaVersions[collectionID])
+		ingests[collectionID] = newCollectionIngest()
+		summary.Collections++
 		for _, partition := range collectionInfo.GetPartitions() {
+			summary.Partitions++
 			segmentManagers := make([]*segmentAllocManager, 0)
 			// recovery meta is recovered , use it.
 			if managers, ok := metaMaps[partition.GetPartitionId()]; ok {
@@ -72,24 +126,35 @@ func buildNewPartitionManagers(
 				collectionID,
 				partition.GetPartitionId(),
 				segmentManagers,
+				schemaVersions[collectionID],
+				ingests[collectionID],
 				metrics,
+				watcher,
+				random,
 			))
 			if ok {
 				panic("partition manager already exists when buildNewPartitionManagers in segment assignment service, there's a bug in system")
 			}
 		}
 	}
+	logger.Info("segment assignment meta recovered",
+		zap.Int("valid", classCounter[recoveredMetaClassValid]),
+		zap.Int("repaired", classCounter[recoveredMetaClassRepaired]),
+		zap.Int("quarantined", classCounter[recoveredMetaClassQuarantined]))
 	m := &partitionSegmentManagers{
-		mu: sync.Mutex{},
-		logger: resource.Resource().Logger().With(
-			log.FieldComponent("segment-assigner"),
-			zap.String("pchannel", pchannel.Name),
-		),
+		mu:              sync.RWMutex{},
+		logger:          logger,
 		wal:             wal,
 		pchannel:        pchannel,
 		managers:        managers,
 		collectionInfos: collectionInfoMap,
+		schemaVersions:  schemaVersions,
+		createTimeTicks: make(map[int64]uint64),
+		ingests:         ingests,
+		tombstones:      typeutil.NewConcurrentMap[int64, int64](),
 		metrics:         metrics,
+		watcher:         watcher,
+		random:          random,
 	}
 	m.updateMetrics()
 	return m, waitForSealed
@@ -97,18 +162,25 @@ func buildNewPartitionManagers(
 
 // partitionSegmentManagers is a collection of partition managers.
 type partitionSegmentManagers struct {
-	mu sync.Mutex
+	mu sync.RWMutex
 
 	logger          *log.MLogger
 	wal             *syncutil.Future[wal.WAL]
 	pchannel        types.PChannelInfo
 	managers        *typeutil.ConcurrentMap[int64, *partitionSegmentManager] // map partitionID to partition manager
 	collectionInfos map[int64]*rootcoordpb.CollectionInfoOnPChannel          // map collectionID to collectionInfo
+	schemaVersions  map[int64]*atomic.Uint64                                 // map collectionID to current schema version, shared with the partition managers
+	createTimeTicks map[int64]uint64                                         // map collectionID to the create time tick, absent if the collection is recovered
+	ingests         map[int64]*collectionIngest                              // map collectionID to the ingest tracker, shared with the partition managers
+	tombstones      *typeutil.ConcurrentMap[int64, int64]                    // map partitionID to collectionID, the partitions removed by the reload of coordinator metadata
 	metrics         *metricsutil.SegmentAssignMetrics
+	watcher         *assignmentWatcher
+	random          *sealRandom
 }
 
 // NewCollection creates a new partition manager.
-func (m *partitionSegmentManagers) NewCollection(collectionID int64, vchannel string, partitionID []int64) {
+// ErrPChannelFull is returned if the collection or its partitions exceed the capacity of the pchannel.
+func (m *partitionSegmentManagers) NewCollection(collectionID int64, vchannel string, partitionID []int64, schemaVersion uint64, createTimeTick uint64) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -116,10 +188,18 @@ func (m *partitionSegmentManagers) NewCollection(collectionID int64, vchannel st
 		m.logger.Warn("collection already exists when NewCollection in segment assignment service",
 			zap.Int64("collectionID", collectionID),
 		)
-		return
+		return nil
+	}
+	if err := m.capacityWithoutLock().check(collectionID, 1, len(partitionID)); err != nil {
+		m.logger.Warn("collection is rejected by the capacity of pchannel in segment assignment service",
+			zap.Int64("collectionID", collectionID), zap.Error(err))
+		return err
 	}
 
 	m.collectionInfos[collectionID] = newCollectionInfo(collectionID, vchannel, partitionID)
+	m.schemaVersions[collectionID] = atomic.NewUint64(schemaVersion)
+	m.createTimeTicks[collectionID] = createTimeTick
+	m.ingests[collectionID] = newCollectionIngest()
 	for _, partitionID := range partitionID {
 		if _, loaded := m.managers.GetOrInsert(partitionID, newPartitionSegmentManager(
 			m.wal,
@@ -128,7 +208,11 @@ func (m *partitionSegmentManagers) NewCollection(collectionID int64, vchannel st
 			collectionID,
 			partitionID,
 			make([]*segmentAllocManager, 0),
+			m.schemaVersions[collectionID],
+			m.ingests[collectionID],
 			m.metrics,
+			m.watcher,
+			m.random,
 		)); loaded {
 			m.logger.Warn("partition already exists when NewCollection in segment assignment service, it's may be a bug in system",
 				zap.Int64("collectionID", collectionID),
@@ -139,12 +223,26 @@ func (m *partitionSegmentManagers) NewCollection(collectionID int64, vchannel st
 	m.logger.Info("collection created in segment assignment service",
 		zap.Int64("collectionID", collectionID),
 		zap.String("vchannel", vchannel),
-		zap.Int64s("partitionIDs", partitionID))
+		zap.Int64s("partitionIDs", partitionID),
+		zap.Uint64("schemaVersion", schemaVersion),
+		zap.Uint64("createTimeTick", createTimeTick))
 	m.updateMetrics()
+	return nil
+}
+
+// CollectionCreateTimeTick returns the create time tick of the collection.
+// The create time tick of the collection recovered from the coordinator is unknown, false is returned.
+func (m *partitionSegmentManagers) CollectionCreateTimeTick(collectionID int64) (uint64, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	createTimeTick, ok := m.createTimeTicks[collectionID]
+	return createTimeTick, ok && createTimeTick > 0
 }
 
 // NewPartition creates a new partition manager.
-func (m *partitionSegmentManagers) NewPartition(collectionID int64, partitionID int64) {
+// ErrPChannelFull is returned if the partition exceeds the capacity of the pchannel.
+func (m *partitionSegmentManagers) NewPartition(collectionID int64, partitionID int64) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -153,8 +251,66 @@ func (m *partitionSegmentManagers) NewPartition(collectionID int64, partitionID
 			zap.Int64("collectionID", collectionID),
 			zap.Int64("partitionID", partitionID),
 		)
-		return
+		return nil
+	}
+	if err := m.capacityWithoutLock().check(collectionID, 0, m.countNewPartitionsWithoutLock([]int64{partitionID})); err != nil {
+		m.logger.Warn("partition is rejected by the capacity of pchannel in segment assignment service",
+			zap.Int64("collectionID", collectionID), zap.Int64("partitionID", partitionID), zap.Error(err))
+		return err
+	}
+	m.newPartitionWithoutLock(collectionID, partitionID)
+	m.logger.Info("partition created in segment assignment service",
+		zap.Int64("collectionID", collectionID),
+		zap.String("vchannel", m.collectionInfos[collectionID].Vchannel),
+		zap.Int64("partitionID", partitionID))
+	m.updateMetrics()
+	return nil
+}
+
+// NewPartitions creates a batch of new partition managers of one collection.
+// All partitions are registered under one lock acquisition,
+// so the incoming insert message can see either none or all of them.
+// ErrPChannelFull is returned and none of them is registered if the batch exceeds the capacity of the pchannel.
+func (m *partitionSegmentManagers) NewPartitions(collectionID int64, partitionIDs []int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.collectionInfos[collectionID]; !ok {
+		m.logger.Warn("collection not exists when NewPartitions in segment assignment service, it's may be a bug in system",
+			zap.Int64("collectionID", collectionID),
+			zap.Int64s("partitionIDs", partitionIDs),
+		)
+		return nil
+	}
+	if err := m.capacityWithoutLock().check(collectionID, 0, m.countNewPartitionsWithoutLock(partitionIDs)); err != nil {
+		m.logger.Warn("partitions are rejected by the capacity of pchannel in segment assignment service",
+			zap.Int64("collectionID", collectionID), zap.Int64s("partitionIDs", partitionIDs), zap.Error(err))
+		return err
+	}
+	for _, partitionID := range partitionIDs {
+		m.newPartitionWithoutLock(collectionID, partitionID)
+	}
+	m.logger.Info("partitions created in segment assignment service",
+		zap.Int64("collectionID", collectionID),
+		zap.String("vchannel", m.collectionInfos[collectionID].Vchannel),
+		zap.Int64s("partitionIDs", partitionIDs))
+	m.updateMetrics()
+	return nil
+}
+
+// countNewPartitionsWithoutLock counts the partitions that are not registered yet, the lock should be held.
+func (m *partitionSegmentManagers) countNewPartitionsWithoutLock(partitionIDs []int64) int {
+	count := 0
+	for _, partitionID := range partitionIDs {
+		if _, ok := m.managers.Get(partitionID); !ok {
+			count++
+		}
 	}
+	return count
+}
+
+// newPartitionWithoutLock creates a new partition manager, the collection should be checked exists before calling.
+func (m *partitionSegmentManagers) newPartitionWithoutLock(collectionID int64, partitionID int64) {
 	m.collectionInfos[collectionID].Partitions = append(m.collectionInfos[collectionID].Partitions, &rootcoordpb.PartitionInfoOnPChannel{
 		PartitionId: partitionID,
 	})
@@ -166,24 +322,26 @@ func (m *partitionSegmentManagers) NewPartition(collectionID int64, partitionID
 		collectionID,
 		partitionID,
 		make([]*segmentAllocManager, 0),
+		m.schemaVersions[collectionID],
+		m.ingests[collectionID],
 		m.metrics,
+		m.watcher,
+		m.random,
 	)); loaded {
 		m.logger.Warn(
 			"partition already exists when NewPartition in segment assignment service, it's may be a bug in system",
 			zap.Int64("collectionID", collectionID),
 			zap.Int64("partitionID", partitionID))
 	}
-	m.logger.Info("partition created in segment assignment service",
-		zap.Int64("collectionID", collectionID),
-		zap.String("vchannel", m.collectionInfos[collectionID].Vchannel),
-		zap.Int64("partitionID", partitionID))
-	m.updateMetrics()
 }
 
 // Get gets a partition manager from the partition managers.
 func (m *partitionSegmentManagers) Get(collectionID int64, partitionID int64) (*partitionSegmentManager, error) {
 	pm, ok := m.managers.Get(partitionID)
 	if !ok {
+		if m.tombstones.Contain(partitionID) {
+			return nil, status.NewUnrecoverableError("partition %d in collection %d is tombstoned by the reload of coordinator metadata in segment assignment service", partitionID, collectionID)
+		}
 		return nil, status.NewUnrecoverableError("partition %d in collection %d not found in segment assignment service", partitionID, collectionID)
 	}
 	return pm, nil
@@ -201,6 +359,15 @@ func (m *partitionSegmentManagers) RemoveCollection(collectionID int64) []*segme
 		return nil
 	}
 	delete(m.collectionInfos, collectionID)
+	delete(m.schemaVersions, collectionID)
+	delete(m.createTimeTicks, collectionID)
+	delete(m.ingests, collectionID)
+	m.tombstones.Range(func(partitionID int64, tombstonedCollectionID int64) bool {
+		if tombstonedCollectionID == collectionID {
+			m.tombstones.Remove(partitionID)
+		}
+		return true
+	})
 
 	needSealed := make([]*segmentAllocManager, 0)
 	partitionIDs := make([]int64, 0, len(collectionInfo.Partitions))
@@ -228,10 +395,17 @@ func (m *partitionSegmentManagers) RemoveCollection(collectionID int64) []*segme
 }
 
 // RemovePartition removes a partition manager from the partition managers.
-func (m *partitionSegmentManagers) RemovePartition(collectionID int64, partitionID int64) []*segmentAllocManager {
+// If discard is true, all segments of the partition are returned to be discarded without flush,
+// otherwise the growing and sealed segments are returned to be sealed and flushed.
+func (m *partitionSegmentManagers) RemovePartition(collectionID int64, partitionID int64, discard bool) []*segmentAllocManager {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	return m.removePartitionWithoutLock(collectionID, partitionID, discard)
+}
+
+// removePartitionWithoutLock removes a partition manager from the partition managers, must be called with the lock held.
+func (m *partitionSegmentManagers) removePartitionWithoutLock(collectionID int64, partitionID int64, discard bool) []*segmentAllocManager {
 	collectionInfo, ok := m.collectionInfos[collectionID]
 	if !ok {
 		m.logger.Warn("collection not exists when RemovePartition in segment assignment service", zap.Int64("collectionID", collectionID))
@@ -252,7 +426,16 @@ func (m *partitionSegmentManagers) RemovePartition(collectionID int64, partition
 			zap.Int64("partitionID", partitionID))
 		return nil
 	}
-	segments := pm.CollectAllCanBeSealedAndClear(policy.PolicyNamePartitionRemoved)
+	var segments []*segmentAllocManager
+	if discard {
+		// all segments of the partition are discarded without flush, including the pending one.
+		segments = pm.CollectAllSegmentsAndClear()
+		for _, segment := range segments {
+			segment.WithDiscard()
+		}
+	} else {
+		segments = pm.CollectAllCanBeSealedAndClear(policy.PolicyNamePartitionRemoved)
+	}
 	segmentIDs := make([]int64, 0, len(segments))
 	for _, segment := range segments {
 		segmentIDs = append(segmentIDs, segment.GetSegmentID())
@@ -261,12 +444,76 @@ func (m *partitionSegmentManagers) RemovePartition(collectionID int64, partition
 		"partition removed in segment assignment service",
 		zap.Int64("collectionID", collectionID),
 		zap.Int64("partitionID", partitionID),
+		zap.Bool("discard", discard),
 		zap.Int64s("segmentIDs", segmentIDs),
 	)
 	m.updateMetrics()
 	return segments
 }
 
+// ChangeSchemaVersion records the new schema version of the collection,
+// and collects the growing segments that are created under the older schema version to be sealed.
+// The older schema version is ignored, so the replayed schema change is a no-op.
+func (m *partitionSegmentManagers) ChangeSchemaVersion(collectionID int64, schemaVersion uint64) ([]*segmentAllocManager, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	collectionInfo, ok := m.collectionInfos[collectionID]
+	if !ok {
+		m.logger.Warn("collection not exists when ChangeSchemaVersion in segment assignment service", zap.Int64("collectionID", collectionID))
+		return nil, ErrCollectionNotFound
+	}
+	oldSchemaVersion := m.schemaVersions[collectionID].Load()
+	if schemaVersion <= oldSchemaVersion {
+		return nil, nil
+	}
+	m.schemaVersions[collectionID].Store(schemaVersion)
+
+	sealedSegments := make([]*segmentAllocManager, 0)
+	segmentIDs := make([]int64, 0)
+	for _, partition := range collectionInfo.Partitions {
+		pm, ok := m.managers.Get(partition.PartitionId)
+		if !ok {
+			continue
+		}
+		newSealedSegments := pm.CollectSchemaOutdated(schemaVersion)
+		for _, segment := range newSealedSegments {
+			segmentIDs = append(segmentIDs, segment.GetSegmentID())
+		}
+		sealedSegments = append(sealedSegments, newSealedSegments...)
+	}
+	m.logger.Info(
+		"schema version changed in segment assignment service",
+		zap.Int64("collectionID", collectionID),
+		zap.Uint64("oldSchemaVersion", oldSchemaVersion),
+		zap.Uint64("schemaVersion", schemaVersion),
+		zap.Int64s("segmentIDs", segmentIDs),
+	)
+	return sealedSegments, nil
+}
+
+// UnsealSegment reverts the sealed segment back to growing and adds it into its partition manager.
+// The segment created under the older schema version can never be unsealed.
+func (m *partitionSegmentManagers) UnsealSegment(ctx context.Context, segment *segmentAllocManager) error {
+	// the lock is held to avoid the partition being removed while unsealing.
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	schemaVersion, ok := m.schemaVersions[segment.GetCollectionID()]
+	if !ok {
+		return ErrCollectionNotFound
+	}
+	if segment.GetSchemaVersion() < schemaVersion.Load() {
+		return errors.Wrapf(ErrUnsealNotAllowed, "segment %d is created under an older schema version %d, current %d",
+			segment.GetSegmentID(), segment.GetSchemaVersion(), schemaVersion.Load())
+	}
+	pm, err := m.Get(segment.GetCollectionID(), segment.GetPartitionID())
+	if err != nil {
+		return err
+	}
+	return pm.UnsealSegment(ctx, segment)
+}
+
 // SealAndFenceSegmentUntil seal all segment that contains the message less than the incoming timetick.
 func (m *partitionSegmentManagers) SealAndFenceSegmentUntil(collectionID int64, timetick uint64) ([]*segmentAllocManager, error) {
 	m.mu.Lock()
@@ -275,7 +522,7 @@ func (m *partitionSegmentManagers) SealAndFenceSegmentUntil(collectionID int64,
 	collectionInfo, ok := m.collectionInfos[collectionID]
 	if !ok {
 		m.logger.Warn("collection not exists when Flush in segment assignment service", zap.Int64("collectionID", collectionID))
-		return nil, errors.New("collection not found")
+		return nil, ErrCollectionNotFound
 	}
 
 	sealedSegments := make([]*segmentAllocManager, 0)
@@ -305,6 +552,102 @@ func (m *partitionSegmentManagers) SealAndFenceSegmentUntil(collectionID int64,
 	return sealedSegments, nil
 }
 
+// SealSegmentsOlderThan collects all growing segments of the collection whose assigned insert are all not greater than the timetick.
+func (m *partitionSegmentManagers) SealSegmentsOlderThan(collectionID int64, timetick uint64) ([]*segmentAllocManager, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	collectionInfo, ok := m.collectionInfos[collectionID]
+	if !ok {
+		m.logger.Warn("collection not exists when SealSegmentsOlderThan in segment assignment service", zap.Int64("collectionID", collectionID))
+		return nil, ErrCollectionNotFound
+	}
+
+	sealedSegments := make([]*segmentAllocManager, 0)
+	segmentIDs := make([]int64, 0)
+	for _, partition := range collectionInfo.Partitions {
+		pm, ok := m.managers.Get(partition.PartitionId)
+		if !ok {
+			continue
+		}
+		newSealedSegments := pm.CollectOlderThan(timetick)
+		for _, segment := range newSealedSegments {
+			segmentIDs = append(segmentIDs, segment.GetSegmentID())
+		}
+		sealedSegments = append(sealedSegments, newSealedSegments...)
+	}
+	m.logger.Info(
+		"segments older than timetick sealed in segment assignment service",
+		zap.Int64("collectionID", collectionID),
+		zap.Uint64("timetick", timetick),
+		zap.Int64s("segmentIDs", segmentIDs),
+	)
+	return sealedSegments, nil
+}
+
+// GetFencedTimeTick returns the max fenced assign time tick of the partitions of the collection,
+// 0 is returned if the collection is not found or never fenced.
+func (m *partitionSegmentManagers) GetFencedTimeTick(collectionID int64) uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var fencedTimeTick uint64
+	for _, partition := range m.collectionInfos[collectionID].GetPartitions() {
+		if pm, ok := m.managers.Get(partition.GetPartitionId()); ok {
+			if tt := pm.FencedTimeTick(); tt > fencedTimeTick {
+				fencedTimeTick = tt
+			}
+		}
+	}
+	return fencedTimeTick
+}
+
+// SnapshotRowSizeCheck returns the snapshot of the row size check of the collection, nil if no row is observed yet.
+func (m *partitionSegmentManagers) SnapshotRowSizeCheck(collectionID int64) *RowSizeCheckSnapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ingest, ok := m.ingests[collectionID]
+	if !ok {
+		return nil
+	}
+	return snapshotRowSizeCheck(ingest)
+}
+
+// RecalculateSmallCollectionMaxBinarySize recalculates the max binary size override of the small collections by their observed ingest rate.
+// The override only applies to the new growing segments, the existing ones are sealed by the small collection lifetime.
+func (m *partitionSegmentManagers) RecalculateSmallCollectionMaxBinarySize(limit uint64, now time.Time) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for collectionID, ingest := range m.ingests {
+		if !policy.IsSmallCollection(collectionID) {
+			ingest.Reset()
+			continue
+		}
+		rate := ingest.Sample(now)
+		maxBinarySize := policy.GetSmallCollectionMaxBinarySize(rate, limit)
+		if old := ingest.SetMaxBinarySize(maxBinarySize); old != maxBinarySize {
+			m.markCollectionSweepDirty(collectionID)
+			m.logger.Info("max binary size of small collection recalculated",
+				zap.Int64("collectionID", collectionID),
+				zap.Float64("ingestRate", rate),
+				zap.Uint64("oldMaxBinarySize", old),
+				zap.Uint64("newMaxBinarySize", maxBinarySize))
+		}
+	}
+}
+
+// markCollectionSweepDirty marks all partitions of the collection are changed, so they're evaluated by the next seal policy sweep.
+// Must be called with the lock held.
+func (m *partitionSegmentManagers) markCollectionSweepDirty(collectionID int64) {
+	for _, partition := range m.collectionInfos[collectionID].GetPartitions() {
+		if pm, ok := m.managers.Get(partition.GetPartitionId()); ok {
+			pm.MarkSweepDirty()
+		}
+	}
+}
+
 // Range ranges the partition managers.
 func (m *partitionSegmentManagers) Range(f func(pm *partitionSegmentManager)) {
 	m.managers.Range(func(_ int64, pm *partitionSegmentManager) bool {
@@ -313,6 +656,25 @@ func (m *partitionSegmentManagers) Range(f func(pm *partitionSegmentManager)) {
 	})
 }
 
+// RangeSorted ranges the partition managers in a stable order sorted by the collection id and partition id,
+// so the same state is always visited in the same order, e.g. the seal sweep seals the same segments first across runs.
+func (m *partitionSegmentManagers) RangeSorted(f func(pm *partitionSegmentManager)) {
+	pms := make([]*partitionSegmentManager, 0, m.managers.Len())
+	m.managers.Range(func(_ int64, pm *partitionSegmentManager) bool {
+		pms = append(pms, pm)
+		return true
+	})
+	sort.Slice(pms, func(i, j int) bool {
+		if pms[i].collectionID != pms[j].collectionID {
+			return pms[i].collectionID < pms[j].collectionID
+		}
+		return pms[i].paritionID < pms[j].paritionID
+	})
+	for _, pm := range pms {
+		f(pm)
+	}
+}
+
 func (m *partitionSegmentManagers) updateMetrics() {
 	m.metrics.UpdatePartitionCount(m.managers.Len())
 	m.metrics.UpdateCollectionCount(len(m.collectionInfos))
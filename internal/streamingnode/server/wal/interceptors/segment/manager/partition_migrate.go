@@ -0,0 +1,210 @@
+package manager
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+)
+
+var (
+	ErrMigrationNotAllowed = errors.New("segment migration not allowed")
+	ErrSegmentPinnedByTxn  = errors.New("segment is pinned by txn")
+)
+
+// MigrateGrowingSegments re-labels all growing segments of the source partition into the target partition of the same collection,
+// so the remaining capacity of them is reused by the target partition after the partitions are merged.
+// The new partition is persisted into catalog for all segments at once, then a migrate segments message is appended into wal,
+// so the consumers re-key the buffered data of the segments. The stats of the segments are kept as is.
+// The migration is rejected if any segment is pinned by an open txn, the writes of the txn are bound to the source partition.
+// The migrated segment ids are returned, it's empty and nothing is appended if the source partition has no growing segment.
+func (m *PChannelSegmentAllocManager) MigrateGrowingSegments(ctx context.Context, collectionID int64, fromPartitionID int64, toPartitionID int64) ([]int64, error) {
+	if err := m.checkLifetime(); err != nil {
+		return nil, err
+	}
+	defer m.lifetime.Done()
+
+	if fromPartitionID == toPartitionID {
+		return nil, errors.Wrapf(ErrMigrationNotAllowed, "collection %d, migrate partition %d into itself", collectionID, fromPartitionID)
+	}
+	from, err := m.managers.Get(collectionID, fromPartitionID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := m.managers.Get(collectionID, toPartitionID)
+	if err != nil {
+		return nil, err
+	}
+	if from.CollectionID() != collectionID || to.CollectionID() != collectionID {
+		return nil, errors.Wrapf(ErrMigrationNotAllowed, "collection %d, partition %d of collection %d, partition %d of collection %d",
+			collectionID, fromPartitionID, from.CollectionID(), toPartitionID, to.CollectionID())
+	}
+
+	// the partitions are always locked in the order of partition id, so the concurrent migrations never deadlock.
+	first, second := from, to
+	if first.paritionID > second.paritionID {
+		first, second = second, first
+	}
+	first.mu.Lock()
+	defer first.mu.Unlock()
+	second.mu.Lock()
+	defer second.mu.Unlock()
+
+	if err := from.checkDropping(); err != nil {
+		return nil, err
+	}
+	if err := to.checkDropping(); err != nil {
+		return nil, err
+	}
+	migrated := from.collectMigratableSegments()
+	if len(migrated) == 0 {
+		return []int64{}, nil
+	}
+	for _, segment := range migrated {
+		if segment.TxnSem() > 0 {
+			return nil, errors.Wrapf(ErrSegmentPinnedByTxn, "segment %d of collection %d, partition %d is pinned by %d txns",
+				segment.GetSegmentID(), collectionID, fromPartitionID, segment.TxnSem())
+		}
+	}
+	segmentIDs := sealedSegmentIDs(migrated)
+
+	// the deferred meta is persisted by the appended insert outside the partition lock,
+	// so the defer lock of every segment is held until the new partition is applied in memory, as same as the commit of modification.
+	sort.Slice(migrated, func(i, j int) bool { return migrated[i].GetSegmentID() < migrated[j].GetSegmentID() })
+	for _, segment := range migrated {
+		segment.deferMu.Lock()
+	}
+	defer func() {
+		for _, segment := range migrated {
+			segment.deferMu.Unlock()
+		}
+	}()
+
+	originals := make(map[int64]*streamingpb.SegmentAssignmentMeta, len(migrated))
+	modified := make(map[int64]*streamingpb.SegmentAssignmentMeta, len(migrated))
+	persisted := make(map[int64]*streamingpb.SegmentAssignmentMeta, len(migrated))
+	var barrier uint64
+	for _, segment := range migrated {
+		original := segment.Snapshot()
+		copied := segment.Snapshot()
+		copied.PartitionId = toPartitionID
+		// the create time tick is not kept by the live stat, recover it from the meta.
+		if copied.Stat != nil {
+			copied.Stat.CreateSegmentTimeTick = segment.inner.GetStat().GetCreateSegmentTimeTick()
+			original.Stat.CreateSegmentTimeTick = copied.Stat.CreateSegmentTimeTick
+		}
+		modified[segment.GetSegmentID()] = copied
+		// the deferred meta is known by the coordinator only, the new partition is applied in memory.
+		if !segment.metaDeferred.Load() {
+			originals[segment.GetSegmentID()] = original
+			persisted[segment.GetSegmentID()] = copied
+		}
+		if _, maxTimeTick := segment.AssignedTimeTickRange(); maxTimeTick > barrier {
+			barrier = maxTimeTick
+		}
+	}
+	if len(persisted) > 0 {
+		if err := saveSegmentAssignments(ctx, catalogPathSweep, m.metrics, m.pchannel.Name, persisted); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := from.sendMigrateSegmentsMessageIntoWAL(ctx, toPartitionID, segmentIDs, barrier)
+	if err != nil {
+		// restore the source partition in catalog, the segments are still assigned by the source partition in memory.
+		if len(originals) > 0 {
+			if restoreErr := saveSegmentAssignments(ctx, catalogPathSweep, m.metrics, m.pchannel.Name, originals); restoreErr != nil {
+				m.logger.Warn("failed to restore the partition of migrated segments",
+					zap.Int64("collectionID", collectionID),
+					zap.Int64("fromPartitionID", fromPartitionID),
+					zap.Int64s("segmentIDs", segmentIDs),
+					zap.Error(restoreErr))
+			}
+		}
+		return nil, err
+	}
+
+	for _, segment := range migrated {
+		segment.inner = modified[segment.GetSegmentID()]
+		if !segment.metaDeferred.Load() {
+			segment.persistedPartitionLastAssign = segment.inner.GetPartitionLastAssignTimeTick()
+		}
+		segment.migratedTimeTick = result.TimeTick
+		segment.bindPartitionLastAssign(to.lastAssign)
+		segment.bindPartitionSweepDirty(to.sweepDirty)
+		resource.Resource().SegmentAssignStatsManager().UpdateSegmentPartition(segment.GetSegmentID(), toPartitionID)
+		to.segments = append(to.segments, segment)
+		to.watcher.Publish(newSegmentAssignmentEvent(AssignmentEventMigrate, segment, stats.InsertMetrics{}))
+	}
+	from.removeSegments(segmentIDs)
+	from.MarkSweepDirty()
+	to.MarkSweepDirty()
+	m.logger.Info("growing segments migrated into another partition",
+		zap.Int64("collectionID", collectionID),
+		zap.Int64("fromPartitionID", fromPartitionID),
+		zap.Int64("toPartitionID", toPartitionID),
+		zap.Int64s("segmentIDs", segmentIDs),
+		zap.Uint64("timetick", result.TimeTick),
+		zap.Any("msgID", result.MessageID))
+	return segmentIDs, nil
+}
+
+// collectMigratableSegments collects the growing insert segments of the partition, the lock should be held.
+func (m *partitionSegmentManager) collectMigratableSegments() []*segmentAllocManager {
+	migratable := make([]*segmentAllocManager, 0, len(m.segments))
+	for _, segment := range m.segments {
+		if segment.IsL0() || segment.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
+			continue
+		}
+		migratable = append(migratable, segment)
+	}
+	return migratable
+}
+
+// removeSegments removes the segments from the partition, the lock should be held.
+func (m *partitionSegmentManager) removeSegments(segmentIDs []int64) {
+	removed := make(map[int64]struct{}, len(segmentIDs))
+	for _, segmentID := range segmentIDs {
+		removed[segmentID] = struct{}{}
+	}
+	kept := make([]*segmentAllocManager, 0, len(m.segments))
+	for _, segment := range m.segments {
+		if _, ok := removed[segment.GetSegmentID()]; !ok {
+			kept = append(kept, segment)
+		}
+	}
+	m.segments = kept
+}
+
+// sendMigrateSegmentsMessageIntoWAL sends a migrate segments message of the partition into wal.
+func (m *partitionSegmentManager) sendMigrateSegmentsMessageIntoWAL(ctx context.Context, toPartitionID int64, segmentIDs []int64, barrier uint64) (*wal.AppendResult, error) {
+	msg, err := message.NewMigrateSegmentsMessageBuilderV2().
+		WithVChannel(m.vchannel).
+		WithHeader(&message.MigrateSegmentsMessageHeader{
+			CollectionId:    m.collectionID,
+			FromPartitionId: m.paritionID,
+			ToPartitionId:   toPartitionID,
+			SegmentIds:      segmentIDs,
+		}).
+		WithBody(&message.MigrateSegmentsMessageBody{}).BuildMutable()
+	if err != nil {
+		return nil, errors.Wrap(err, "at create new migrate segments message")
+	}
+	// the migrate segments message should be ordered after every insert assigned to the segments by the source partition.
+	if barrier > 0 {
+		msg.WithBarrierTimeTick(barrier)
+	}
+	result, err := m.wal.Get().Append(ctx, msg)
+	if err != nil {
+		m.logger.Warn("send migrate segments message into wal failed", zap.Int64("toPartitionID", toPartitionID), zap.Int64s("segmentIDs", segmentIDs), zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
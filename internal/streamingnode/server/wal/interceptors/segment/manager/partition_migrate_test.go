@@ -0,0 +1,148 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/mocks/mock_metastore"
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+)
+
+// newPartitionMigrateTestManager recovers a segment assignment manager whose appended messages are recorded.
+func newPartitionMigrateTestManager(t *testing.T, name string) (*PChannelSegmentAllocManager, *[]message.MutableMessage) {
+	initializeTestState(t)
+
+	appended := make([]message.MutableMessage, 0)
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, msg message.MutableMessage) (*wal.AppendResult, error) {
+		appended = append(appended, msg)
+		return &wal.AppendResult{MessageID: rmq.NewRmqID(1), TimeTick: 100}, nil
+	}).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: name}, f)
+	assert.NoError(t, err)
+	t.Cleanup(func() { m.Close(context.Background()) })
+	return m, &appended
+}
+
+// savedSegmentPartitions returns the partitions of the segment saved into catalog in the order of the saves.
+func savedSegmentPartitions(segmentID int64) []int64 {
+	catalog := resource.Resource().StreamingNodeCatalog().(*mock_metastore.MockStreamingNodeCataLog)
+	partitions := make([]int64, 0)
+	for _, call := range catalog.Calls {
+		if call.Method != "SaveSegmentAssignments" {
+			continue
+		}
+		infos := call.Arguments.Get(2).(map[int64]*streamingpb.SegmentAssignmentMeta)
+		if info, ok := infos[segmentID]; ok {
+			partitions = append(partitions, info.GetPartitionId())
+		}
+	}
+	return partitions
+}
+
+// segmentsOfPartition returns the segment ids of the partition of collection 1 in the snapshot.
+func segmentsOfPartition(t *testing.T, m *PChannelSegmentAllocManager, partitionID int64) []int64 {
+	snapshot, err := m.SnapshotCollection(1, []int64{partitionID}, 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, snapshot.Partitions, 1)
+	segmentIDs := make([]int64, 0, len(snapshot.Partitions[0].Segments))
+	for _, segment := range snapshot.Partitions[0].Segments {
+		segmentIDs = append(segmentIDs, segment.SegmentID)
+	}
+	return segmentIDs
+}
+
+func TestMigrateGrowingSegments(t *testing.T) {
+	m, appended := newPartitionMigrateTestManager(t, "v_migrate_growing_segments")
+	ctx := context.Background()
+
+	migrated, err := m.MigrateGrowingSegments(ctx, 1, 2, 3)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int64{2000, 3000, 5000}, migrated)
+
+	// the growing segments are re-labeled in catalog and moved into the target partition.
+	for _, segmentID := range migrated {
+		partitions := savedSegmentPartitions(segmentID)
+		assert.NotEmpty(t, partitions)
+		assert.Equal(t, int64(3), partitions[len(partitions)-1])
+	}
+	for _, segmentID := range migrated {
+		assert.NotContains(t, segmentsOfPartition(t, m, 2), segmentID)
+		assert.Contains(t, segmentsOfPartition(t, m, 3), segmentID)
+	}
+
+	// the migrate segments message is appended, so the consumers re-key the segments.
+	assert.Len(t, *appended, 1)
+	header := message.MustAsMutableMigrateSegmentsMessageV2((*appended)[0]).Header()
+	assert.Equal(t, int64(1), header.GetCollectionId())
+	assert.Equal(t, int64(2), header.GetFromPartitionId())
+	assert.Equal(t, int64(3), header.GetToPartitionId())
+	assert.ElementsMatch(t, migrated, header.GetSegmentIds())
+
+	// the stats of the segment is kept, and it's sealed as the segment of the target partition.
+	assert.NotNil(t, resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(2000))
+	pm, err := m.managers.Get(1, 3)
+	assert.NoError(t, err)
+	for _, segment := range pm.segments {
+		assert.Equal(t, int64(3), segment.GetPartitionID())
+		if segment.GetSegmentID() == 2000 {
+			// the insert of the target partition not newer than the migration is redone.
+			_, err := segment.AllocRows(ctx, &AssignSegmentRequest{
+				CollectionID:  1,
+				PartitionID:   3,
+				InsertMetrics: stats.InsertMetrics{Rows: 1, BinarySize: 1},
+				TimeTick:      100,
+			})
+			assert.ErrorIs(t, err, ErrTimeTickTooOld)
+		}
+	}
+	m.MustSealSegments(ctx, stats.SegmentBelongs{PChannel: "v_migrate_growing_segments", CollectionID: 1, PartitionID: 3, SegmentID: 2000})
+	_, ok := m.SegmentState(2000)
+	assert.False(t, ok)
+
+	// nothing is migrated and appended if the source partition has no growing segment.
+	migrated, err = m.MigrateGrowingSegments(ctx, 1, 2, 3)
+	assert.NoError(t, err)
+	assert.Empty(t, migrated)
+	assert.Len(t, *appended, 2) // the flush message of segment 2000.
+}
+
+func TestMigrateGrowingSegmentsRejected(t *testing.T) {
+	m, appended := newPartitionMigrateTestManager(t, "v_migrate_growing_segments_rejected")
+	ctx := context.Background()
+
+	_, err := m.MigrateGrowingSegments(ctx, 1, 2, 2)
+	assert.ErrorIs(t, err, ErrMigrationNotAllowed)
+	_, err = m.MigrateGrowingSegments(ctx, 1, 2, 100)
+	assert.Error(t, err)
+	_, err = m.MigrateGrowingSegments(ctx, 2, 2, 3)
+	assert.ErrorIs(t, err, ErrMigrationNotAllowed)
+
+	// the segment pinned by an open txn can never be migrated.
+	before := segmentsOfPartition(t, m, 2)
+	pm, err := m.managers.Get(1, 2)
+	assert.NoError(t, err)
+	for _, segment := range pm.segments {
+		if segment.GetSegmentID() == 3000 {
+			segment.txnSem.Inc()
+		}
+	}
+	_, err = m.MigrateGrowingSegments(ctx, 1, 2, 3)
+	assert.ErrorIs(t, err, ErrSegmentPinnedByTxn)
+	assert.ElementsMatch(t, before, segmentsOfPartition(t, m, 2))
+	assert.Empty(t, *appended)
+}
@@ -0,0 +1,195 @@
+package manager
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/inspector"
+	"github.com/milvus-io/milvus/pkg/v2/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+var ErrForceAddPartitionNotAllowed = errors.New("force add partition not allowed")
+
+// PartitionsDiff is the diff of the partitions of a collection applied by the reload of coordinator metadata.
+type PartitionsDiff struct {
+	Added   []int64 // the partitions known by the coordinator but missing in the segment assignment.
+	Removed []int64 // the partitions in the segment assignment but unknown by the coordinator, they're tombstoned.
+}
+
+// ReloadPartitions merges the partitions of the collection with the given partitions of the coordinator metadata.
+// The missing partitions are added, the unknown partitions are removed and tombstoned,
+// the growing segments of the removed partitions are returned to be sealed and flushed.
+func (m *partitionSegmentManagers) ReloadPartitions(collectionID int64, partitionIDs []int64) (PartitionsDiff, []*segmentAllocManager, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	collectionInfo, ok := m.collectionInfos[collectionID]
+	if !ok {
+		m.logger.Warn("collection not exists when ReloadPartitions in segment assignment service", zap.Int64("collectionID", collectionID))
+		return PartitionsDiff{}, nil, ErrCollectionNotFound
+	}
+	expected := make(map[int64]struct{}, len(partitionIDs))
+	for _, partitionID := range partitionIDs {
+		expected[partitionID] = struct{}{}
+	}
+	current := make(map[int64]struct{}, len(collectionInfo.Partitions))
+	diff := PartitionsDiff{}
+	for _, partition := range collectionInfo.Partitions {
+		current[partition.PartitionId] = struct{}{}
+		if _, ok := expected[partition.PartitionId]; !ok {
+			diff.Removed = append(diff.Removed, partition.PartitionId)
+		}
+	}
+	for partitionID := range expected {
+		if _, ok := current[partitionID]; !ok {
+			diff.Added = append(diff.Added, partitionID)
+		}
+	}
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i] < diff.Added[j] })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i] < diff.Removed[j] })
+
+	for _, partitionID := range diff.Added {
+		m.tombstones.Remove(partitionID)
+		m.newPartitionWithoutLock(collectionID, partitionID)
+	}
+	needSealed := make([]*segmentAllocManager, 0)
+	for _, partitionID := range diff.Removed {
+		needSealed = append(needSealed, m.removePartitionWithoutLock(collectionID, partitionID, false)...)
+		m.tombstones.Insert(partitionID, collectionID)
+	}
+	m.logger.Info("partitions reloaded from coordinator metadata in segment assignment service",
+		zap.Int64("collectionID", collectionID),
+		zap.Int64s("coordinatorPartitionIDs", partitionIDs),
+		zap.Int64s("addedPartitionIDs", diff.Added),
+		zap.Int64s("removedPartitionIDs", diff.Removed))
+	m.updateMetrics()
+	return diff, needSealed, nil
+}
+
+// ForceNewPartition adds the partition into the collection even if it's unknown by the coordinator metadata.
+// The existing partition is kept as is.
+func (m *partitionSegmentManagers) ForceNewPartition(collectionID int64, partitionID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.collectionInfos[collectionID]; !ok {
+		m.logger.Warn("collection not exists when ForceNewPartition in segment assignment service", zap.Int64("collectionID", collectionID))
+		return ErrCollectionNotFound
+	}
+	if _, ok := m.managers.Get(partitionID); ok {
+		return nil
+	}
+	m.tombstones.Remove(partitionID)
+	m.newPartitionWithoutLock(collectionID, partitionID)
+	m.logger.Warn("FORCE ADD PARTITION: partition is added into segment assignment service without coordinator metadata",
+		zap.Int64("collectionID", collectionID),
+		zap.String("vchannel", m.collectionInfos[collectionID].Vchannel),
+		zap.Int64("partitionID", partitionID))
+	m.updateMetrics()
+	return nil
+}
+
+// IsPartitionTombstoned checks if the partition is removed by the reload of coordinator metadata.
+func (m *partitionSegmentManagers) IsPartitionTombstoned(partitionID int64) bool {
+	return m.tombstones.Contain(partitionID)
+}
+
+// ReloadCollectionPartitions re-fetches the partitions of the collection from the coordinator and merges them into the segment assignment.
+// It's an admin operation to repair the partitions when the metadata seen by the segment assignment is wrong.
+// The partitions are always fetched from the coordinator directly, the growing segments of the removed partitions are sealed and flushed.
+func (m *PChannelSegmentAllocManager) ReloadCollectionPartitions(ctx context.Context, collectionID int64) (PartitionsDiff, error) {
+	if err := m.checkLifetime(); err != nil {
+		return PartitionsDiff{}, err
+	}
+	defer m.lifetime.Done()
+
+	mix, err := resource.Resource().MixCoordClient().GetWithContext(ctx)
+	if err != nil {
+		return PartitionsDiff{}, err
+	}
+	resp, err := mix.GetPChannelInfo(ctx, &rootcoordpb.GetPChannelInfoRequest{
+		Pchannel: m.pchannel.Name,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		return PartitionsDiff{}, errors.Wrap(err, "failed to get pchannel info from rootcoord")
+	}
+	var collectionInfo *rootcoordpb.CollectionInfoOnPChannel
+	for _, info := range resp.GetCollections() {
+		if info.GetCollectionId() == collectionID {
+			collectionInfo = info
+			break
+		}
+	}
+	if collectionInfo == nil {
+		// the collection is never removed by the reload, it's removed by the drop collection message only.
+		return PartitionsDiff{}, errors.Wrapf(ErrCollectionNotFound, "collection %d not found in coordinator metadata of pchannel %s", collectionID, m.pchannel.Name)
+	}
+	partitionIDs := make([]int64, 0, len(collectionInfo.GetPartitions()))
+	for _, partition := range collectionInfo.GetPartitions() {
+		partitionIDs = append(partitionIDs, partition.GetPartitionId())
+	}
+
+	diff, waitForSealed, err := m.managers.ReloadPartitions(collectionID, partitionIDs)
+	if err != nil {
+		return PartitionsDiff{}, err
+	}
+	if len(diff.Removed) == 0 {
+		return diff, nil
+	}
+	m.helper.AsyncSeal(waitForSealed...)
+
+	// trigger a seal operation in background rightnow.
+	inspector.GetSegmentSealedInspector().TriggerSealWaited(ctx, m.pchannel.Name)
+
+	// wait for all segment of the removed partitions has been flushed.
+	return diff, m.helper.WaitUntilNoWaitSeal(ctx)
+}
+
+// ForceAddPartition adds the partition into the collection even if it's unknown by the coordinator metadata.
+// It's a break-glass operation to unblock the insert when the coordinator metadata is wrong, and only works if it's enabled by config.
+func (m *PChannelSegmentAllocManager) ForceAddPartition(collectionID int64, partitionID int64) error {
+	if err := m.checkLifetime(); err != nil {
+		return err
+	}
+	defer m.lifetime.Done()
+
+	if !paramtable.Get().StreamingCfg.WALSegmentAssignForceAddPartitionEnabled.GetAsBool() {
+		return errors.Wrap(ErrForceAddPartitionNotAllowed, "force add partition is not enabled")
+	}
+	return m.managers.ForceNewPartition(collectionID, partitionID)
+}
+
+// ReloadCollectionPartitionsOnPChannel reloads the partitions of the collection on the pchannel for the admin.
+func ReloadCollectionPartitionsOnPChannel(ctx context.Context, req *streamingpb.StreamingNodeManagerReloadCollectionPartitionsRequest) (*streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse, error) {
+	pm, err := getPChannelSegmentAllocManager(req.GetPchannel().GetName())
+	if err != nil {
+		return nil, err
+	}
+	diff, err := pm.ReloadCollectionPartitions(ctx, req.GetCollectionId())
+	if err != nil {
+		return nil, err
+	}
+	return &streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse{
+		AddedPartitionIds:   diff.Added,
+		RemovedPartitionIds: diff.Removed,
+	}, nil
+}
+
+// ForceAddPartitionOnPChannel forcibly adds the partition of the collection on the pchannel for the admin.
+func ForceAddPartitionOnPChannel(ctx context.Context, req *streamingpb.StreamingNodeManagerForceAddPartitionRequest) (*streamingpb.StreamingNodeManagerForceAddPartitionResponse, error) {
+	pm, err := getPChannelSegmentAllocManager(req.GetPchannel().GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := pm.ForceAddPartition(req.GetCollectionId(), req.GetPartitionId()); err != nil {
+		return nil, err
+	}
+	return &streamingpb.StreamingNodeManagerForceAddPartitionResponse{}, nil
+}
@@ -0,0 +1,185 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/inspector"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+)
+
+// recoverPartitionReloadTestManager recovers a manager whose coordinator metadata of collection 1 is given by the partitions function.
+func recoverPartitionReloadTestManager(t *testing.T, pchannel string, partitions func() []int64) *PChannelSegmentAllocManager {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  2,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: pchannel}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	t.Cleanup(func() {
+		inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	})
+
+	// the coordinator metadata is changed after the recovery.
+	mix := resource.Resource().MixCoordClient().Get().(*mocks.MockMixCoordClient)
+	mix.EXPECT().GetPChannelInfo(mock.Anything, mock.Anything).Unset()
+	mix.EXPECT().GetPChannelInfo(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, req *rootcoordpb.GetPChannelInfoRequest, co ...grpc.CallOption) (*rootcoordpb.GetPChannelInfoResponse, error) {
+			infos := make([]*rootcoordpb.PartitionInfoOnPChannel, 0)
+			for _, partitionID := range partitions() {
+				infos = append(infos, &rootcoordpb.PartitionInfoOnPChannel{PartitionId: partitionID})
+			}
+			return &rootcoordpb.GetPChannelInfoResponse{
+				Status: merr.Success(),
+				Collections: []*rootcoordpb.CollectionInfoOnPChannel{
+					{CollectionId: 1, Partitions: infos},
+				},
+			}, nil
+		}).Maybe()
+	return m
+}
+
+func TestReloadCollectionPartitions(t *testing.T) {
+	coordinatorPartitions := []int64{1, 2, 7}
+	m := recoverPartitionReloadTestManager(t, "v_reload_partitions", func() []int64 { return coordinatorPartitions })
+	ctx := context.Background()
+	assign := func(partitionID int64) error {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  partitionID,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick: 100,
+		})
+		if err == nil {
+			result.Ack()
+		}
+		return err
+	}
+
+	// the missing partition is merged, the unknown partition is tombstoned and its segments are flushed.
+	resp, err := ReloadCollectionPartitionsOnPChannel(ctx, &streamingpb.StreamingNodeManagerReloadCollectionPartitionsRequest{
+		Pchannel:     &streamingpb.PChannelInfo{Name: "v_reload_partitions"},
+		CollectionId: 1,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{7}, resp.GetAddedPartitionIds())
+	assert.Equal(t, []int64{3}, resp.GetRemovedPartitionIds())
+	assert.True(t, m.SealWaitState().IsNoWait())
+	states := savedSegmentStates(6000)
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED, states[len(states)-1])
+	assert.NoError(t, assign(7))
+	assert.True(t, m.managers.IsPartitionTombstoned(3))
+	err = assign(3)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "tombstoned")
+
+	// the reload of the same metadata is a no-op.
+	diff, err := m.ReloadCollectionPartitions(ctx, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+
+	// the tombstoned partition is merged back if the coordinator knows it again.
+	coordinatorPartitions = []int64{1, 2, 3, 7}
+	diff, err = m.ReloadCollectionPartitions(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3}, diff.Added)
+	assert.Empty(t, diff.Removed)
+	assert.False(t, m.managers.IsPartitionTombstoned(3))
+	assert.NoError(t, assign(3))
+
+	// the collection unknown by the coordinator is never removed by the reload.
+	_, err = m.ReloadCollectionPartitions(ctx, 2)
+	assert.ErrorIs(t, err, ErrCollectionNotFound)
+
+	// the pchannel not ready is rejected.
+	_, err = ReloadCollectionPartitionsOnPChannel(ctx, &streamingpb.StreamingNodeManagerReloadCollectionPartitionsRequest{
+		Pchannel:     &streamingpb.PChannelInfo{Name: "v_reload_partitions_not_exist"},
+		CollectionId: 1,
+	})
+	assert.Error(t, err)
+
+	m.Close(ctx)
+	_, err = m.ReloadCollectionPartitions(ctx, 1)
+	assert.Error(t, err)
+}
+
+func TestForceAddPartition(t *testing.T) {
+	coordinatorPartitions := []int64{1, 2, 3}
+	m := recoverPartitionReloadTestManager(t, "v_force_add_partition", func() []int64 { return coordinatorPartitions })
+	ctx := context.Background()
+	req := &streamingpb.StreamingNodeManagerForceAddPartitionRequest{
+		Pchannel:     &streamingpb.PChannelInfo{Name: "v_force_add_partition"},
+		CollectionId: 1,
+		PartitionId:  9,
+	}
+
+	// the force add is rejected if it's not enabled.
+	_, err := ForceAddPartitionOnPChannel(ctx, req)
+	assert.ErrorIs(t, err, ErrForceAddPartitionNotAllowed)
+
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignForceAddPartitionEnabled.Key, "true")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignForceAddPartitionEnabled.Key)
+
+	_, err = ForceAddPartitionOnPChannel(ctx, req)
+	assert.NoError(t, err)
+	result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  9,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       100,
+			BinarySize: 100,
+		},
+		TimeTick: 100,
+	})
+	assert.NoError(t, err)
+	result.Ack()
+
+	// the existing partition is a no-op, the unknown collection is rejected.
+	assert.NoError(t, m.ForceAddPartition(1, 9))
+	assert.NoError(t, m.ForceAddPartition(1, 2))
+	assert.ErrorIs(t, m.ForceAddPartition(2, 10), ErrCollectionNotFound)
+
+	// the forcibly added partition is tombstoned by the reload, and the tombstone is cleared by the next force add.
+	diff, err := m.ReloadCollectionPartitions(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{9}, diff.Removed)
+	assert.True(t, m.managers.IsPartitionTombstoned(9))
+	assert.NoError(t, m.ForceAddPartition(1, 9))
+	assert.False(t, m.managers.IsPartitionTombstoned(9))
+
+	// the tombstones are removed with the collection.
+	_, err = m.ReloadCollectionPartitions(ctx, 1)
+	assert.NoError(t, err)
+	assert.True(t, m.managers.IsPartitionTombstoned(9))
+	assert.NoError(t, m.RemoveCollection(ctx, 1))
+	assert.False(t, m.managers.IsPartitionTombstoned(9))
+
+	m.Close(ctx)
+	assert.Error(t, m.ForceAddPartition(1, 9))
+}
@@ -0,0 +1,87 @@
+package manager
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+var ErrPChannelFull = errors.New("pchannel is full")
+
+// PChannelCapacity is the count of the registered collections and partitions of a pchannel and the caps of them.
+// The coordinator avoids placing more collections on the pchannel that is full.
+type PChannelCapacity struct {
+	Collections    int64 `json:"collections"`
+	Partitions     int64 `json:"partitions"`
+	MaxCollections int64 `json:"max_collections"` // 0 if unlimited.
+	MaxPartitions  int64 `json:"max_partitions"`  // 0 if unlimited.
+}
+
+// newPChannelCapacity creates the capacity of the pchannel with the configured caps.
+func newPChannelCapacity(collections int, partitions int) PChannelCapacity {
+	params := paramtable.Get().StreamingCfg
+	return PChannelCapacity{
+		Collections:    int64(collections),
+		Partitions:     int64(partitions),
+		MaxCollections: max(params.WALSegmentAssignMaxCollectionsPerPChannel.GetAsInt64(), 0),
+		MaxPartitions:  max(params.WALSegmentAssignMaxPartitionsPerPChannel.GetAsInt64(), 0),
+	}
+}
+
+// check checks if the new collections and partitions can be registered on the pchannel.
+// The system collection is never rejected, it's required by the cluster itself.
+func (c PChannelCapacity) check(collectionID int64, newCollections int, newPartitions int) error {
+	if IsSystemCollection(collectionID) {
+		return nil
+	}
+	if c.MaxCollections > 0 && newCollections > 0 && c.Collections+int64(newCollections) > c.MaxCollections {
+		return errors.Wrapf(ErrPChannelFull, "collection %d is rejected, %d collections are registered, max %d collections",
+			collectionID, c.Collections, c.MaxCollections)
+	}
+	if c.MaxPartitions > 0 && newPartitions > 0 && c.Partitions+int64(newPartitions) > c.MaxPartitions {
+		return errors.Wrapf(ErrPChannelFull, "%d partitions of collection %d are rejected, %d partitions are registered, max %d partitions",
+			newPartitions, collectionID, c.Partitions, c.MaxPartitions)
+	}
+	return nil
+}
+
+// capacityWithoutLock returns the capacity of the pchannel, the lock should be held.
+func (m *partitionSegmentManagers) capacityWithoutLock() PChannelCapacity {
+	return newPChannelCapacity(len(m.collectionInfos), m.managers.Len())
+}
+
+// Capacity returns the count of the registered collections and partitions of the pchannel and the caps of them.
+func (m *partitionSegmentManagers) Capacity() PChannelCapacity {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.capacityWithoutLock()
+}
+
+// CheckCapacity checks if the collection with the count of new partitions can be registered on the pchannel without registering it,
+// the collection is new if it's not registered yet.
+func (m *partitionSegmentManagers) CheckCapacity(collectionID int64, newPartitions int) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	newCollections := 0
+	if _, ok := m.collectionInfos[collectionID]; !ok {
+		newCollections = 1
+	}
+	return m.capacityWithoutLock().check(collectionID, newCollections, newPartitions)
+}
+
+// Capacity returns the count of the registered collections and partitions of the pchannel and the caps of them.
+func (m *PChannelSegmentAllocManager) Capacity() PChannelCapacity {
+	return m.managers.Capacity()
+}
+
+// CheckCapacity checks if the collection with the count of new partitions can be registered on the pchannel,
+// so the create collection or partition message can be rejected with ErrPChannelFull before it's appended.
+func (m *PChannelSegmentAllocManager) CheckCapacity(collectionID int64, newPartitions int) error {
+	if err := m.checkLifetime(); err != nil {
+		return err
+	}
+	defer m.lifetime.Done()
+
+	return m.managers.CheckCapacity(collectionID, newPartitions)
+}
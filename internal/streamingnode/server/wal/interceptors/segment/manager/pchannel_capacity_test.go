@@ -0,0 +1,108 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+)
+
+func TestPChannelCapacityCheck(t *testing.T) {
+	paramtable.Init()
+
+	c := PChannelCapacity{Collections: 2, Partitions: 5, MaxCollections: 3, MaxPartitions: 6}
+	assert.NoError(t, c.check(100, 1, 1))
+	assert.ErrorIs(t, c.check(100, 2, 1), ErrPChannelFull)
+	assert.ErrorIs(t, c.check(100, 0, 2), ErrPChannelFull)
+	// the registered collection is never counted again.
+	assert.NoError(t, c.check(100, 0, 0))
+
+	// the caps are disabled if they're zero.
+	c = PChannelCapacity{Collections: 2, Partitions: 5}
+	assert.NoError(t, c.check(100, 100, 100))
+}
+
+func TestPChannelCapacity(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  100,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_capacity"}, f)
+	assert.NoError(t, err)
+	defer m.Close(context.Background())
+
+	// the recovered collections and partitions are counted, the caps are reported as configured.
+	recovered := m.Capacity()
+	assert.Greater(t, recovered.Collections, int64(0))
+	assert.Greater(t, recovered.Partitions, int64(0))
+	assert.Equal(t, paramtable.Get().StreamingCfg.WALSegmentAssignMaxCollectionsPerPChannel.GetAsInt64(), recovered.MaxCollections)
+	assert.Equal(t, paramtable.Get().StreamingCfg.WALSegmentAssignMaxPartitionsPerPChannel.GetAsInt64(), recovered.MaxPartitions)
+
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.WALSegmentAssignMaxCollectionsPerPChannel.Key, fmt.Sprint(recovered.Collections+1))
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignMaxCollectionsPerPChannel.Key)
+	params.Save(params.StreamingCfg.WALSegmentAssignMaxPartitionsPerPChannel.Key, fmt.Sprint(recovered.Partitions+3))
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignMaxPartitionsPerPChannel.Key)
+
+	// the new collection is accepted until the collection cap is reached.
+	assert.NoError(t, m.CheckCapacity(1000, 1))
+	assert.NoError(t, m.NewCollection(1000, "v_capacity_1000", []int64{1001}, InitialSchemaVersion, 0))
+	assert.ErrorIs(t, m.CheckCapacity(1100, 1), ErrPChannelFull)
+	err = m.NewCollection(1100, "v_capacity_1100", []int64{1101}, InitialSchemaVersion, 0)
+	assert.ErrorIs(t, err, ErrPChannelFull)
+	_, err = m.managers.Get(1100, 1101)
+	assert.Error(t, err)
+
+	// the registered collection is not counted again, so the partition of it is checked by the partition cap only.
+	assert.NoError(t, m.CheckCapacity(1000, 1))
+	assert.NoError(t, m.NewPartition(1000, 1002))
+	// the batch beyond the partition cap is rejected as a whole.
+	assert.ErrorIs(t, m.CheckCapacity(1000, 2), ErrPChannelFull)
+	err = m.NewPartitions(1000, []int64{1003, 1004})
+	assert.ErrorIs(t, err, ErrPChannelFull)
+	_, err = m.managers.Get(1000, 1003)
+	assert.Error(t, err)
+	// the registered partitions of the batch are not counted.
+	assert.NoError(t, m.NewPartitions(1000, []int64{1002, 1003}))
+	assert.ErrorIs(t, m.NewPartition(1000, 1004), ErrPChannelFull)
+
+	// the system collection is never rejected by the caps.
+	params.Save(params.StreamingCfg.WALSegmentAssignSystemCollectionMaxID.Key, "10000")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignSystemCollectionMaxID.Key)
+	assert.NoError(t, m.CheckCapacity(1100, 1))
+	assert.NoError(t, m.NewCollection(1100, "v_capacity_1100", []int64{1101}, InitialSchemaVersion, 0))
+	params.Reset(params.StreamingCfg.WALSegmentAssignSystemCollectionMaxID.Key)
+
+	// the current counts and caps are reported to the coordinator.
+	capacity := m.Capacity()
+	assert.Equal(t, recovered.Collections+2, capacity.Collections)
+	assert.Equal(t, recovered.Partitions+4, capacity.Partitions)
+	metadata := m.Metadata()
+	assert.Equal(t, capacity, metadata.Capacity)
+	pb := metadata.IntoProto()
+	assert.Equal(t, capacity.Collections, pb.GetCollections())
+	assert.Equal(t, capacity.Partitions, pb.GetPartitions())
+	assert.Equal(t, recovered.Collections+1, pb.GetMaxCollections())
+	assert.Equal(t, recovered.Partitions+3, pb.GetMaxPartitions())
+
+	// the caps are disabled if they're zero.
+	params.Save(params.StreamingCfg.WALSegmentAssignMaxCollectionsPerPChannel.Key, "0")
+	params.Save(params.StreamingCfg.WALSegmentAssignMaxPartitionsPerPChannel.Key, "0")
+	assert.NoError(t, m.NewCollection(1200, "v_capacity_1200", []int64{1201, 1202}, InitialSchemaVersion, 0))
+	assert.Equal(t, int64(0), m.Metadata().IntoProto().GetMaxCollections())
+}
@@ -8,14 +8,18 @@ import (
 
 	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/faultinject"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/inspector"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
 	"github.com/milvus-io/milvus/pkg/v2/log"
 	"github.com/milvus-io/milvus/pkg/v2/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
 	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
 	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
 )
@@ -26,11 +30,22 @@ func RecoverPChannelSegmentAllocManager(
 	pchannel types.PChannelInfo,
 	wal *syncutil.Future[wal.WAL],
 ) (*PChannelSegmentAllocManager, error) {
+	summary := newRecoverySummary(pchannel.Name)
 	// recover streaming node growing segment metas.
 	rawMetas, err := resource.Resource().StreamingNodeCatalog().ListSegmentAssignment(ctx, pchannel.Name)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list segment assignment from catalog")
 	}
+	if err := validateOwnership(pchannel, rawMetas); err != nil {
+		return nil, err
+	}
+	summary.observeStage(recoveryStageListSegments)
+	// recover the applied manual flush from the vchannel metas.
+	vchannels, err := resource.Resource().StreamingNodeCatalog().ListVChannel(ctx, pchannel.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list vchannel from catalog")
+	}
+	summary.observeStage(recoveryStageListVChannels)
 	// get collection and parition info from rootcoord.
 	mix, err := resource.Resource().MixCoordClient().GetWithContext(ctx)
 	if err != nil {
@@ -42,20 +57,50 @@ func RecoverPChannelSegmentAllocManager(
 	if err := merr.CheckRPCCall(resp, err); err != nil {
 		return nil, errors.Wrap(err, "failed to get pchannel info from rootcoord")
 	}
+	summary.observeStage(recoveryStageGetPChannel)
 	metrics := metricsutil.NewSegmentAssignMetrics(pchannel.Name)
-	managers, waitForSealed := buildNewPartitionManagers(wal, pchannel, rawMetas, resp.GetCollections(), metrics)
+	watcher := newAssignmentWatcher(defaultAssignmentEventRingSize)
+	random := newSealRandomFromConfig()
+	summary.Seed = random.Seed()
+	managers, waitForSealed := buildNewPartitionManagers(wal, pchannel, rawMetas, resp.GetCollections(), recoverSchemaVersions(vchannels, rawMetas), metrics, watcher, random, summary)
+	summary.observeStage(recoveryStageBuildManagers)
+	if isDeferMetaCreationEnabled() {
+		// the segments allocated at coordinator without the deferred meta persisted are adopted and sealed right away.
+		adopted, err := adoptDeferredSegments(ctx, pchannel, rawMetas, resp.GetCollections(), metrics, random)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to adopt deferred segments")
+		}
+		waitForSealed = append(waitForSealed, adopted...)
+		summary.Adopted = len(adopted)
+		summary.observeStage(recoveryStageAdoptDeferredSegments)
+	}
 
 	// PChannelSegmentAllocManager is the segment assign manager of determined pchannel.
 	logger := log.With(zap.Any("pchannel", pchannel))
-
-	return &PChannelSegmentAllocManager{
-		lifetime: typeutil.NewLifetime(),
-		logger:   logger,
-		pchannel: pchannel,
-		managers: managers,
-		helper:   newSealQueue(logger, wal, waitForSealed, metrics),
-		metrics:  metrics,
-	}, nil
+	recoverAssignmentConfigs(logger, managers, vchannels)
+	summary.Metadata = newAssignmentMetadata(pchannel)
+	publishRecoverySummary(logger, metrics, summary)
+
+	m := &PChannelSegmentAllocManager{
+		lifetime:      typeutil.NewLifetime(),
+		logger:        logger,
+		pchannel:      pchannel,
+		managers:      managers,
+		helper:        newSealQueue(logger, pchannel.Name, wal, waitForSealed, metrics, watcher, newSealedSegmentNotifier(logger, pchannel)),
+		breakers:      newCircuitBreakers(logger, metrics),
+		manualFlushes: newManualFlushRecords(vchannels),
+		sealedStats:   newSealedSegmentStats(),
+		sweeper:       newSealSweeper(),
+		autoSeal:      newAutoSealDisabledWarner(),
+		saturation:    newSaturationTracker(metrics.AppendPathPressure(), resource.Resource().Clock().Now()),
+		flushes:       newFlushSerializer(),
+		summary:       summary,
+		shadow:        newShadowEvaluator(logger, pchannel, managers),
+		metrics:       metrics,
+		watcher:       watcher,
+	}
+	workingManagers.register(m)
+	return m, nil
 }
 
 // PChannelSegmentAllocManager is a segment assign manager of determined pchannel.
@@ -66,8 +111,18 @@ type PChannelSegmentAllocManager struct {
 	pchannel types.PChannelInfo
 	managers *partitionSegmentManagers
 	// There should always
-	helper  *sealQueue
-	metrics *metricsutil.SegmentAssignMetrics
+	helper        *sealQueue
+	breakers      *circuitBreakers
+	manualFlushes *manualFlushRecords
+	sealedStats   *sealedSegmentStats
+	sweeper       *sealSweeper
+	autoSeal      *autoSealDisabledWarner
+	saturation    *saturationTracker
+	flushes       *flushSerializer
+	summary       *RecoverySummary
+	shadow        *shadowEvaluator
+	metrics       *metricsutil.SegmentAssignMetrics
+	watcher       *assignmentWatcher
 }
 
 // Channel returns the pchannel info.
@@ -75,14 +130,86 @@ func (m *PChannelSegmentAllocManager) Channel() types.PChannelInfo {
 	return m.pchannel
 }
 
-// NewPartitions creates a new partition with the specified partitionIDs.
-func (m *PChannelSegmentAllocManager) NewCollection(collectionID int64, vchannel string, partitionIDs []int64) error {
+// RecoverySummary returns the summary of the recovery of the manager.
+func (m *PChannelSegmentAllocManager) RecoverySummary() *RecoverySummary {
+	return m.summary
+}
+
+// SegmentState returns the assignment state of the segment on the pchannel without acquiring the lock of manager,
+// return false if the segment is not assigned on the pchannel or already flushed.
+func (m *PChannelSegmentAllocManager) SegmentState(segmentID int64) (streamingpb.SegmentAssignmentState, bool) {
+	return resource.Resource().SegmentStateIndex().SegmentStateOfPChannel(m.pchannel.Name, segmentID)
+}
+
+// GetSealedUnflushedSegments returns the sorted segments sealed by the pchannel whose flush completion is not observed yet,
+// the coordinator should not schedule the compaction involving them.
+// The set is derived from the sealed metas, so it survives the restart of the manager.
+func (m *PChannelSegmentAllocManager) GetSealedUnflushedSegments() []int64 {
+	return resource.Resource().SegmentStateIndex().SealedUnflushedSegmentsOfPChannel(m.pchannel.Name)
+}
+
+// BindOpenTxnLister binds the lister of the open txns of the pchannel,
+// the open txns of the collection are annotated into the flush messages.
+func (m *PChannelSegmentAllocManager) BindOpenTxnLister(lister OpenTxnLister) {
+	m.helper.openTxns.Bind(lister)
+}
+
+// OnTxnDone re-evaluates the seal of the segments pinned by the done txn without waiting for the next sweep.
+// It's notified in the append path of the txn, so the seal is only signaled to the sealed inspector,
+// the signals of the done txns are coalesced and performed by the seal worker of the pchannel.
+func (m *PChannelSegmentAllocManager) OnTxnDone(pinnedSegmentIDs []int64) {
+	if !m.lifetime.Add(typeutil.LifetimeStateWorking) {
+		return
+	}
+	defer m.lifetime.Done()
+
+	notifier := resource.Resource().SegmentAssignStatsManager().SealNotifier()
+	for _, belongs := range m.helper.WaitingBelongs(pinnedSegmentIDs) {
+		notifier.AddAndNotify(belongs)
+	}
+}
+
+// OpenTxnsOfCollection returns the txns still open that have written data into the segments of the collection.
+func (m *PChannelSegmentAllocManager) OpenTxnsOfCollection(collectionID int64) []*message.OpenTxn {
+	return m.helper.openTxns.OfCollection(collectionID)
+}
+
+// WatchAssignments watches the segment lifecycle changes of the pchannel from now on.
+// A lagged event will be received if the watcher is too slow to consume the events.
+// The returned channel will be closed when the ctx is done or the manager is closed.
+func (m *PChannelSegmentAllocManager) WatchAssignments(ctx context.Context) <-chan AssignmentEvent {
+	return m.watcher.Watch(ctx)
+}
+
+// NewCollection creates a new collection with the specified partitionIDs and the current schema version,
+// the create time tick is the time tick of the create collection message.
+func (m *PChannelSegmentAllocManager) NewCollection(collectionID int64, vchannel string, partitionIDs []int64, schemaVersion uint64, createTimeTick uint64) error {
 	if err := m.checkLifetime(); err != nil {
 		return err
 	}
 	defer m.lifetime.Done()
 
-	m.managers.NewCollection(collectionID, vchannel, partitionIDs)
+	return m.managers.NewCollection(collectionID, vchannel, partitionIDs, schemaVersion, createTimeTick)
+}
+
+// ChangeSchemaVersion records the new schema version of the collection,
+// the growing segments created under the older schema version are sealed in background,
+// so the new incoming insert is assigned to the segment created under the new schema version.
+func (m *PChannelSegmentAllocManager) ChangeSchemaVersion(ctx context.Context, collectionID int64, schemaVersion uint64) error {
+	if err := m.checkLifetime(); err != nil {
+		return err
+	}
+	defer m.lifetime.Done()
+
+	sealedSegments, err := m.managers.ChangeSchemaVersion(collectionID, schemaVersion)
+	if err != nil {
+		return err
+	}
+	if len(sealedSegments) > 0 {
+		m.helper.AsyncSeal(sealedSegments...)
+		// trigger a seal operation in background rightnow.
+		inspector.GetSegmentSealedInspector().TriggerSealWaited(ctx, m.pchannel.Name)
+	}
 	return nil
 }
 
@@ -93,8 +220,17 @@ func (m *PChannelSegmentAllocManager) NewPartition(collectionID int64, partition
 	}
 	defer m.lifetime.Done()
 
-	m.managers.NewPartition(collectionID, partitionID)
-	return nil
+	return m.managers.NewPartition(collectionID, partitionID)
+}
+
+// NewPartitions creates a batch of new partitions with the specified collection id and partition ids.
+func (m *PChannelSegmentAllocManager) NewPartitions(collectionID int64, partitionIDs []int64) error {
+	if err := m.checkLifetime(); err != nil {
+		return err
+	}
+	defer m.lifetime.Done()
+
+	return m.managers.NewPartitions(collectionID, partitionIDs)
 }
 
 // AssignSegment assigns a segment for a assign segment request.
@@ -108,7 +244,83 @@ func (m *PChannelSegmentAllocManager) AssignSegment(ctx context.Context, req *As
 	if err != nil {
 		return nil, err
 	}
-	return manager.AssignSegment(ctx, req)
+	if IsSystemCollection(req.CollectionID) {
+		// the system collection skips the circuit breaker,
+		// but the fencing and max size check in partition manager are still applied.
+		result, err := manager.AssignSegment(ctx, req)
+		m.metrics.ObserveAssign(isBackpressureRejection(err))
+		m.shadow.Observe(req, result, err)
+		m.observeSourceAssign(req, err)
+		return result, err
+	}
+	if err := m.breakers.Allow(req.CollectionID); err != nil {
+		m.metrics.ObserveAssign(isBackpressureRejection(err))
+		return nil, err
+	}
+	result, err := manager.AssignSegment(ctx, req)
+	m.metrics.ObserveAssign(isBackpressureRejection(err))
+	m.breakers.Record(req.CollectionID, err)
+	// the shadow evaluation is done in background, it never influences the primary assignment.
+	m.shadow.Observe(req, result, err)
+	m.observeSourceAssign(req, err)
+	return result, err
+}
+
+// observeSourceAssign attributes the successful assignment to the source node, so the upstream responsible for a spike can be found.
+func (m *PChannelSegmentAllocManager) observeSourceAssign(req *AssignSegmentRequest, err error) {
+	if err != nil {
+		return
+	}
+	resource.Resource().SegmentAssignStatsManager().ObserveSourceAssign(req.Source, req.InsertMetrics)
+}
+
+// ApplyPreassignedSegment applies the insert pre-assigned on the segment by the internal producer, e.g. the data repair tool.
+// The segment is never re-assigned, the circuit breaker is skipped, ErrPreassignedSegmentInvalid is returned if the segment can't hold the insert.
+func (m *PChannelSegmentAllocManager) ApplyPreassignedSegment(ctx context.Context, req *AssignSegmentRequest, segmentID int64) (*AssignSegmentResult, error) {
+	if err := m.checkLifetime(); err != nil {
+		return nil, err
+	}
+	defer m.lifetime.Done()
+
+	manager, err := m.managers.Get(req.CollectionID, req.PartitionID)
+	if err != nil {
+		return nil, err
+	}
+	return manager.ApplyPreassignedSegment(ctx, req, segmentID)
+}
+
+// PreviewAssign previews the segment assignment of the insert for the pre-flight capacity check.
+// No state is mutated, the circuit breaker and the fencing are not evaluated.
+func (m *PChannelSegmentAllocManager) PreviewAssign(ctx context.Context, req *AssignPreviewRequest) (*streamingpb.AssignPreviewResponse, error) {
+	if err := m.checkLifetime(); err != nil {
+		return nil, err
+	}
+	defer m.lifetime.Done()
+
+	manager, err := m.managers.Get(req.CollectionID, req.PartitionID)
+	if err != nil {
+		return nil, err
+	}
+	return manager.PreviewAssign(req), nil
+}
+
+// AssignDeleteSegment assigns a L0 segment for the deletes of the partition.
+func (m *PChannelSegmentAllocManager) AssignDeleteSegment(ctx context.Context, req *AssignDeleteSegmentRequest) (*AssignDeleteSegmentResult, error) {
+	if err := m.checkLifetime(); err != nil {
+		return nil, err
+	}
+	defer m.lifetime.Done()
+
+	manager, err := m.managers.Get(req.CollectionID, req.PartitionID)
+	if err != nil {
+		return nil, err
+	}
+	return manager.AssignDeleteSegment(ctx, req)
+}
+
+// ResetCollectionCircuitBreaker forces the circuit breaker of the collection to be closed.
+func (m *PChannelSegmentAllocManager) ResetCollectionCircuitBreaker(collectionID int64) {
+	m.breakers.ForceClose(collectionID)
 }
 
 // RemoveCollection removes the specified collection.
@@ -119,41 +331,120 @@ func (m *PChannelSegmentAllocManager) RemoveCollection(ctx context.Context, coll
 	defer m.lifetime.Done()
 
 	waitForSealed := m.managers.RemoveCollection(collectionID)
+	m.breakers.Remove(collectionID)
+	m.manualFlushes.Remove(collectionID)
 	m.helper.AsyncSeal(waitForSealed...)
 
 	// trigger a seal operation in background rightnow.
 	inspector.GetSegmentSealedInspector().TriggerSealWaited(ctx, m.pchannel.Name)
 
 	// wait for all segment has been flushed.
-	return m.helper.WaitUntilNoWaitSeal(ctx)
+	if err := m.helper.WaitUntilNoWaitSeal(ctx); err != nil {
+		return err
+	}
+	resource.Resource().SegmentAssignStatsManager().UnregisterTimeToSeal(collectionID)
+	resource.Resource().SegmentAssignStatsManager().UnregisterSegmentCreation(collectionID)
+	resource.Resource().SegmentAssignStatsManager().UnregisterTimeTickTooOld(collectionID)
+	policy.RemoveCollectionAssignmentConfig(collectionID)
+	m.metrics.RemoveCollection(collectionID)
+	return nil
 }
 
 // RemovePartition removes the specified partitions.
+// The growing segments of the partition are sealed and flushed.
 func (m *PChannelSegmentAllocManager) RemovePartition(ctx context.Context, collectionID int64, partitionID int64) error {
+	return m.removePartition(ctx, collectionID, partitionID, false)
+}
+
+// RemovePartitionAndDiscardSegments removes the specified partitions.
+// The segments of the partition are discarded without flush, so no flush message is sent for the dropped data.
+func (m *PChannelSegmentAllocManager) RemovePartitionAndDiscardSegments(ctx context.Context, collectionID int64, partitionID int64) error {
+	return m.removePartition(ctx, collectionID, partitionID, true)
+}
+
+// removePartition removes the specified partitions, seal or discard the segments of the partition.
+func (m *PChannelSegmentAllocManager) removePartition(ctx context.Context, collectionID int64, partitionID int64, discard bool) error {
 	if err := m.checkLifetime(); err != nil {
 		return err
 	}
 	defer m.lifetime.Done()
 
 	// Remove the given partition from the partition managers.
-	// And seal all segments that should be sealed.
-	waitForSealed := m.managers.RemovePartition(collectionID, partitionID)
+	// And seal or discard all segments of the partition.
+	waitForSealed := m.managers.RemovePartition(collectionID, partitionID, discard)
 	m.helper.AsyncSeal(waitForSealed...)
 
 	// trigger a seal operation in background rightnow.
 	inspector.GetSegmentSealedInspector().TriggerSealWaited(ctx, m.pchannel.Name)
 
-	// wait for all segment has been flushed.
+	// wait for all segment has been flushed or discarded.
 	return m.helper.WaitUntilNoWaitSeal(ctx)
 }
 
+// PrewarmCollection allocates the growing segments for all partitions of the collection that has no growing segment.
+func (m *PChannelSegmentAllocManager) PrewarmCollection(ctx context.Context, collectionID int64) error {
+	if err := m.checkLifetime(); err != nil {
+		return err
+	}
+	defer m.lifetime.Done()
+
+	partitionIDs, ok := m.managers.PartitionIDs(collectionID)
+	if !ok {
+		return ErrCollectionNotFound
+	}
+	prewarmed := make([]int64, 0, len(partitionIDs))
+	for _, partitionID := range partitionIDs {
+		pm, err := m.managers.Get(collectionID, partitionID)
+		if err != nil {
+			// the partition may be removed concurrently.
+			continue
+		}
+		allocated, err := pm.Prewarm(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "failed to prewarm partition %d", partitionID)
+		}
+		if allocated {
+			prewarmed = append(prewarmed, partitionID)
+		}
+	}
+	m.logger.Info("collection segment assignment prewarmed",
+		zap.Int64("collectionID", collectionID),
+		zap.Int64s("prewarmedPartitionIDs", prewarmed))
+	return nil
+}
+
 // SealAndFenceSegmentUntil seal all segment that contains the message less than the incoming timetick.
+// Both the insert and L0 segments are sealed and fenced, but only the insert segments are returned.
+// The concurrent calls of the same collection are serialized, the call with a not greater timetick
+// is coalesced onto the in-flight one and returns the same sealed segments,
+// ErrFlushQueueFull is returned if there're too many queued calls of the collection.
 func (m *PChannelSegmentAllocManager) SealAndFenceSegmentUntil(ctx context.Context, collectionID int64, timetick uint64) ([]int64, error) {
 	if err := m.checkLifetime(); err != nil {
 		return nil, err
 	}
 	defer m.lifetime.Done()
 
+	return m.flushes.Do(ctx, collectionID, timetick, func() ([]int64, error) {
+		return m.sealAndFenceSegmentUntil(ctx, collectionID, timetick)
+	})
+}
+
+// sealAndFenceSegmentUntil seals and fences the segments of the collection until the timetick and waits for them flushed.
+func (m *PChannelSegmentAllocManager) sealAndFenceSegmentUntil(ctx context.Context, collectionID int64, timetick uint64) (_ []int64, err error) {
+	// the flush is pending until the manual flush message is appended or the flag is expired.
+	// It's marked before the fence, so the fence is never observed without the pending flush by the txn manager.
+	resource.Resource().FlushPendingRegistry().Start(m.pchannel.Name, collectionID, timetick,
+		paramtable.Get().StreamingCfg.WALSegmentAssignFlushPendingTimeout.GetAsDurationByParse())
+	defer func() {
+		if err != nil {
+			// no manual flush message will be appended for the failed flush, so the pending flush is cleared at once.
+			resource.Resource().FlushPendingRegistry().Clear(m.pchannel.Name, collectionID, timetick)
+		}
+	}()
+
+	if err := resource.Resource().FaultInjector().Inject(ctx, faultinject.PointFence); err != nil {
+		return nil, err
+	}
 	// All message's timetick less than incoming timetick is all belong to the output sealed segment.
 	// So the output sealed segment transfer into flush == all message's timetick less than incoming timetick are flushed.
 	sealedSegments, err := m.managers.SealAndFenceSegmentUntil(collectionID, timetick)
@@ -161,10 +452,8 @@ func (m *PChannelSegmentAllocManager) SealAndFenceSegmentUntil(ctx context.Conte
 		return nil, err
 	}
 
-	segmentIDs := make([]int64, 0, len(sealedSegments))
-	for _, segment := range sealedSegments {
-		segmentIDs = append(segmentIDs, segment.GetSegmentID())
-	}
+	segmentIDs := sealedSegmentIDs(sealedSegments)
+	m.sealedStats.Observe(sealedSegments)
 
 	// trigger a seal operation in background rightnow.
 	m.helper.AsyncSeal(sealedSegments...)
@@ -177,6 +466,102 @@ func (m *PChannelSegmentAllocManager) SealAndFenceSegmentUntil(ctx context.Conte
 	return segmentIDs, nil
 }
 
+// GetAppliedManualFlush checks if the manual flush of the collection at the flush ts has been applied.
+// A replayed manual flush should be a no-op, otherwise the fresh growing segments may be sealed incorrectly.
+// The sealed segments of the applied manual flush are returned if recorded.
+func (m *PChannelSegmentAllocManager) GetAppliedManualFlush(collectionID int64, flushTs uint64) ([]int64, bool) {
+	return m.manualFlushes.Get(collectionID, flushTs)
+}
+
+// ObserveManualFlush records the manual flush of the collection is applied with the sealed segments.
+// The manual flush message is appended, so the flush pending flag of the collection is cleared.
+func (m *PChannelSegmentAllocManager) ObserveManualFlush(collectionID int64, flushTs uint64, segmentIDs []int64) {
+	m.manualFlushes.Observe(collectionID, flushTs, segmentIDs)
+	resource.Resource().FlushPendingRegistry().Clear(m.pchannel.Name, collectionID, flushTs)
+}
+
+// GetSealedSegmentStats returns the final stats of the segments sealed by the flush operations, gathered at seal time.
+// The segment whose stat is evicted or not sealed by the flush operations of current manager is skipped.
+func (m *PChannelSegmentAllocManager) GetSealedSegmentStats(segmentIDs []int64) []*message.ManualFlushSegmentStat {
+	return m.sealedStats.Get(segmentIDs)
+}
+
+// GetFlushPending returns the in-flight manual flush of the collection,
+// the upstream components consult it to avoid interleaving the DDL with the manual flush.
+func (m *PChannelSegmentAllocManager) GetFlushPending(collectionID int64) (stats.FlushPending, bool) {
+	return resource.Resource().FlushPendingRegistry().Get(m.pchannel.Name, collectionID)
+}
+
+// GetCollectionFence returns the fenced assign time tick of the collection and whether the flush of the fence is still in flight,
+// the txn manager consults it to validate the txn commit.
+func (m *PChannelSegmentAllocManager) GetCollectionFence(collectionID int64) (uint64, bool) {
+	if policy.IsTxnBlockingSeal(collectionID) {
+		// the seal of the fenced segments waits for the txn done,
+		// so the txn data is always committed before the segments are flushed.
+		return 0, false
+	}
+	fencedTimeTick := m.managers.GetFencedTimeTick(collectionID)
+	if fencedTimeTick == 0 {
+		return 0, false
+	}
+	_, flushPending := m.GetFlushPending(collectionID)
+	return fencedTimeTick, flushPending
+}
+
+// PartitionQuiescentSince returns the time tick of the last assigned insert of the partition,
+// the partition is quiescent since then. False is returned if the partition is not found or the time tick is unknown.
+func (m *PChannelSegmentAllocManager) PartitionQuiescentSince(collectionID int64, partitionID int64) (uint64, bool) {
+	if err := m.checkLifetime(); err != nil {
+		return 0, false
+	}
+	defer m.lifetime.Done()
+
+	pm, err := m.managers.Get(collectionID, partitionID)
+	if err != nil {
+		return 0, false
+	}
+	return pm.QuiescentSince()
+}
+
+// SealSegmentsOlderThan seals the growing segments of the collection whose assigned insert are all not greater than the timetick.
+// Unlike SealAndFenceSegmentUntil, no fence is applied, so the current ingest is never blocked,
+// and the segments with newer data are left untouched.
+func (m *PChannelSegmentAllocManager) SealSegmentsOlderThan(ctx context.Context, collectionID int64, timetick uint64) ([]int64, error) {
+	if err := m.checkLifetime(); err != nil {
+		return nil, err
+	}
+	defer m.lifetime.Done()
+
+	sealedSegments, err := m.managers.SealSegmentsOlderThan(collectionID, timetick)
+	if err != nil {
+		return nil, err
+	}
+	segmentIDs := sealedSegmentIDs(sealedSegments)
+	m.sealedStats.Observe(sealedSegments)
+
+	// trigger a seal operation in background rightnow.
+	m.helper.AsyncSeal(sealedSegments...)
+
+	// wait for all segment has been flushed.
+	if err := m.helper.WaitUntilNoWaitSeal(ctx); err != nil {
+		return nil, err
+	}
+	return segmentIDs, nil
+}
+
+// FlushSegmentsOlderThanOnPChannel seals the growing segments of the collection on the pchannel whose assigned data are all not newer than the timetick for the admin.
+func FlushSegmentsOlderThanOnPChannel(ctx context.Context, req *streamingpb.StreamingNodeManagerFlushSegmentsOlderThanRequest) (*streamingpb.StreamingNodeManagerFlushSegmentsOlderThanResponse, error) {
+	pm, err := getPChannelSegmentAllocManager(req.GetPchannel().GetName())
+	if err != nil {
+		return nil, err
+	}
+	segmentIDs, err := pm.SealSegmentsOlderThan(ctx, req.GetCollectionId(), req.GetTimeTick())
+	if err != nil {
+		return nil, err
+	}
+	return &streamingpb.StreamingNodeManagerFlushSegmentsOlderThanResponse{SegmentIds: segmentIDs}, nil
+}
+
 // TryToSealSegments tries to seal the specified segments.
 func (m *PChannelSegmentAllocManager) TryToSealSegments(ctx context.Context, infos ...stats.SegmentBelongs) {
 	if !m.lifetime.Add(typeutil.LifetimeStateWorking) {
@@ -185,14 +570,15 @@ func (m *PChannelSegmentAllocManager) TryToSealSegments(ctx context.Context, inf
 	defer m.lifetime.Done()
 
 	if len(infos) == 0 {
-		// if no segment info specified, try to seal all segments.
-		m.managers.Range(func(pm *partitionSegmentManager) {
-			m.helper.AsyncSeal(pm.CollectShouldBeSealed()...)
-		})
+		// if no segment info specified, try to seal the segments of the partitions selected by the sweep.
+		m.sweepSealPolicies()
+		m.sampleSaturation()
 	} else {
 		// if some segment info specified, try to seal the specified partition.
+		// the signal is raised by the stats changes (e.g. sync), so the partition is evaluated by the next sweep too.
 		for _, info := range infos {
 			if pm, err := m.managers.Get(info.CollectionID, info.PartitionID); err == nil {
+				pm.MarkSweepDirty()
 				m.helper.AsyncSeal(pm.CollectShouldBeSealed()...)
 			}
 		}
@@ -223,6 +609,99 @@ func (m *PChannelSegmentAllocManager) MustSealSegments(ctx context.Context, info
 	m.helper.SealAllWait(ctx)
 }
 
+// MustSealSegmentsBatch seals the given segments together under one sweep of the seal queue,
+// so the compaction can schedule the merge of the segments right after they're flushed.
+// The flush messages of the batch are appended in the same sweep, and the outcome of every segment is returned.
+// A segment failed to seal never fails the whole batch, the error is only returned if the manager is closed.
+func (m *PChannelSegmentAllocManager) MustSealSegmentsBatch(ctx context.Context, infos []stats.SegmentBelongs) (map[int64]SealOutcome, error) {
+	if err := m.checkLifetime(); err != nil {
+		return nil, err
+	}
+	defer m.lifetime.Done()
+
+	outcomes := make(map[int64]SealOutcome, len(infos))
+	segments := make([]*segmentAllocManager, 0, len(infos))
+	for _, info := range infos {
+		if _, ok := outcomes[info.SegmentID]; ok {
+			continue
+		}
+		alreadySealed := m.isSealed(info.SegmentID)
+		if pm, err := m.managers.Get(info.CollectionID, info.PartitionID); err == nil {
+			// the recovered sealed segment is still held by the partition, it's flushed by the batch too.
+			if segment := pm.CollectionMustSealed(info.SegmentID); segment != nil {
+				segments = append(segments, segment)
+				outcomes[info.SegmentID] = streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_SEALED
+				if alreadySealed {
+					outcomes[info.SegmentID] = streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_ALREADY_SEALED
+				}
+				continue
+			}
+		}
+		if alreadySealed {
+			outcomes[info.SegmentID] = streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_ALREADY_SEALED
+			continue
+		}
+		outcomes[info.SegmentID] = streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_NOT_FOUND
+	}
+	m.helper.AsyncSeal(segments...)
+	m.helper.SealAllWait(ctx)
+
+	// the segment is removed from the state index after it's flushed,
+	// so the segment sealed by the batch but still in the index is waiting for the in-flight acks at the seal queue.
+	for _, segment := range segments {
+		if outcomes[segment.GetSegmentID()] != streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_SEALED {
+			continue
+		}
+		if _, ok := m.SegmentState(segment.GetSegmentID()); ok {
+			outcomes[segment.GetSegmentID()] = streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_WAITING_FOR_ACK
+		}
+	}
+	m.logger.Info("must seal segments batch done", zap.Int("batch", len(infos)), zap.Any("outcomes", outcomes))
+	return outcomes, nil
+}
+
+// isSealed checks if the segment is already sealed, the segment waiting in the seal queue is also sealed.
+func (m *PChannelSegmentAllocManager) isSealed(segmentID int64) bool {
+	if m.helper.Contains(segmentID) {
+		return true
+	}
+	state, ok := m.SegmentState(segmentID)
+	return ok && state == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED
+}
+
+// UnsealSegment reverts the sealed segment whose flush message is never appended back to growing.
+// It's an emergency operation to relieve the segment capacity shortage, and only works if it's enabled by config.
+func (m *PChannelSegmentAllocManager) UnsealSegment(ctx context.Context, segmentID int64) error {
+	if err := m.checkLifetime(); err != nil {
+		return err
+	}
+	defer m.lifetime.Done()
+
+	if !paramtable.Get().StreamingCfg.WALSegmentAssignEmergencyUnsealEnabled.GetAsBool() {
+		return errors.Wrap(ErrUnsealNotAllowed, "emergency unseal is not enabled")
+	}
+	segment, err := m.helper.TakeUnsealable(segmentID)
+	if err != nil {
+		m.logger.Warn("segment can not be unsealed", zap.Int64("segmentID", segmentID), zap.Error(err))
+		return err
+	}
+	sealPolicy := segment.SealPolicy()
+	if err := m.managers.UnsealSegment(ctx, segment); err != nil {
+		m.helper.FinishUnseal(segment, false)
+		m.logger.Warn("fail to unseal segment", zap.Int64("segmentID", segmentID), zap.Error(err))
+		return err
+	}
+	m.helper.FinishUnseal(segment, true)
+	m.metrics.ObserveSegmentUnsealed()
+	m.logger.Warn("EMERGENCY UNSEAL: sealed segment is reverted back to growing",
+		zap.Int64("collectionID", segment.GetCollectionID()),
+		zap.Int64("partitionID", segment.GetPartitionID()),
+		zap.String("vchannel", segment.GetVChannel()),
+		zap.Int64("segmentID", segmentID),
+		zap.String("sealPolicy", string(sealPolicy)))
+	return nil
+}
+
 // TryToSealWaitedSegment tries to seal the wait for sealing segment.
 func (m *PChannelSegmentAllocManager) TryToSealWaitedSegment(ctx context.Context) {
 	if !m.lifetime.Add(typeutil.LifetimeStateWorking) {
@@ -233,9 +712,15 @@ func (m *PChannelSegmentAllocManager) TryToSealWaitedSegment(ctx context.Context
 	m.helper.SealAllWait(ctx)
 }
 
+// SealWaitState returns the detailed state of the segments waiting for seal,
+// including why they're blocked and how long the oldest one has been blocked.
+func (m *PChannelSegmentAllocManager) SealWaitState() stats.SealWaitState {
+	return m.helper.WaitState(m.flushes.IsInflight)
+}
+
 // IsNoWaitSeal returns whether the segment manager is no segment wait for seal.
 func (m *PChannelSegmentAllocManager) IsNoWaitSeal() bool {
-	return m.helper.IsEmpty()
+	return m.SealWaitState().IsNoWait()
 }
 
 // WaitUntilNoWaitSeal waits until no segment wait for seal.
@@ -262,10 +747,13 @@ func (m *PChannelSegmentAllocManager) Close(ctx context.Context) {
 	m.logger.Info("segment assignment manager start to close")
 	m.lifetime.SetState(typeutil.LifetimeStateStopped)
 	m.lifetime.Wait()
+	m.shadow.Close()
+	workingManagers.unregister(m)
 
 	// Try to seal all wait
 	m.helper.SealAllWait(ctx)
 	m.logger.Info("seal all waited segments done, may be some not done here", zap.Int("waitCounter", m.helper.WaitCounter()))
+	m.helper.notifier.Close()
 
 	segments := make([]*segmentAllocManager, 0)
 	m.managers.Range(func(pm *partitionSegmentManager) {
@@ -276,11 +764,16 @@ func (m *PChannelSegmentAllocManager) Close(ctx context.Context) {
 	protoSegments := make(map[int64]*streamingpb.SegmentAssignmentMeta, len(segments))
 	growingCnt := 0
 	for _, segment := range segments {
+		segment.closed.Store(true)
 		if segment.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
 			growingCnt++
 		}
-		if segment.IsDirtyEnough() {
-			// Only persist the dirty segment.
+		if segment.isMetaDeferred() {
+			// the segment without durable insert is never written into catalog, it's adopted by the next recovery.
+			continue
+		}
+		if segment.IsDirtyEnough() || segment.isPartitionLastAssignDirty() {
+			// Only persist the dirty segment, the last assign timetick of the partition is carried by its growing segment.
 			protoSegments[segment.GetSegmentID()] = segment.Snapshot()
 		}
 	}
@@ -288,12 +781,30 @@ func (m *PChannelSegmentAllocManager) Close(ctx context.Context) {
 		zap.Int("dirtySegmentCount", len(protoSegments)),
 		zap.Int("growingSegmentCount", growingCnt),
 		zap.Int("segmentCount", len(segments)))
-	if err := resource.Resource().StreamingNodeCatalog().SaveSegmentAssignments(ctx, m.pchannel.Name, protoSegments); err != nil {
+	if err := saveSegmentAssignments(ctx, catalogPathSweep, m.metrics, m.pchannel.Name, protoSegments); err != nil {
 		m.logger.Warn("commit segment assignment at pchannel failed", zap.Error(err))
 	}
 
 	// remove the stats from stats manager.
 	removedStatsSegmentCnt := resource.Resource().SegmentAssignStatsManager().UnregisterAllStatsOnPChannel(m.pchannel.Name)
 	m.logger.Info("segment assignment manager remove all segment stats from stats manager", zap.Int("removedStatsSegmentCount", removedStatsSegmentCnt))
+	// the segments of the pchannel are not managed by current node anymore.
+	removedStateSegmentCnt := resource.Resource().SegmentStateIndex().RemovePChannel(m.pchannel.Name)
+	m.logger.Info("segment assignment manager remove all segment states from state index", zap.Int("removedStateSegmentCount", removedStateSegmentCnt))
+	resource.Resource().FlushPendingRegistry().RemovePChannel(m.pchannel.Name)
+	m.watcher.Close()
 	m.metrics.Close()
 }
+
+// sealedSegmentIDs returns the ids of the sealed segments that can be seen by datacoord.
+// The L0 segment is sealed and flushed together, but it's not registered at datacoord, so it's excluded.
+func sealedSegmentIDs(sealedSegments []*segmentAllocManager) []int64 {
+	segmentIDs := make([]int64, 0, len(sealedSegments))
+	for _, segment := range sealedSegments {
+		if segment.IsL0() {
+			continue
+		}
+		segmentIDs = append(segmentIDs, segment.GetSegmentID())
+	}
+	return segmentIDs
+}
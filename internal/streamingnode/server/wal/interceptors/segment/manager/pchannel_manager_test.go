@@ -2,22 +2,36 @@ package manager
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.uber.org/atomic"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus/internal/mocks/mock_metastore"
 	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/inspector"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/txn"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
 	internaltypes "github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/idalloc"
+	"github.com/milvus-io/milvus/internal/util/streamingutil/status"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
 	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v2/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
@@ -28,6 +42,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
 	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
 )
 
 func TestSegmentAllocManager(t *testing.T) {
@@ -89,7 +104,7 @@ func TestSegmentAllocManager(t *testing.T) {
 	// Ask for seal segment.
 	// Here already have a sealed segment, and a growing segment wait for seal, but the result is not acked.
 	m.TryToSealSegments(ctx)
-	assert.False(t, m.IsNoWaitSeal())
+	assert.NotZero(t, m.SealWaitState().Reasons[stats.SealWaitReasonUnackedAssignments])
 
 	// The following segment assign will trigger a reach limit, so new seal segment will be created.
 	result3, err := m.AssignSegment(ctx, &AssignSegmentRequest{
@@ -104,13 +119,13 @@ func TestSegmentAllocManager(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result3)
 	m.TryToSealSegments(ctx)
-	assert.False(t, m.IsNoWaitSeal()) // result2 is not acked, so new seal segment will not be sealed right away.
+	assert.NotZero(t, m.SealWaitState().Reasons[stats.SealWaitReasonUnackedAssignments]) // result2 is not acked, so new seal segment will not be sealed right away.
 
 	result.Ack()
 	result2.Ack()
 	result3.Ack()
 	m.TryToSealWaitedSegment(ctx)
-	assert.True(t, m.IsNoWaitSeal()) // result2 is acked, so new seal segment will be sealed right away.
+	assert.True(t, m.SealWaitState().IsNoWait()) // result2 is acked, so new seal segment will be sealed right away.
 
 	// interactive with txn
 	txnManager := txn.NewTxnManager(types.PChannelInfo{Name: "test"}, nil)
@@ -142,13 +157,27 @@ func TestSegmentAllocManager(t *testing.T) {
 	}
 	// because of there's a txn session uncommitted, so the segment will not be sealed.
 	m.TryToSealSegments(ctx)
-	assert.False(t, m.IsNoWaitSeal())
+	assert.NotZero(t, m.SealWaitState().Reasons[stats.SealWaitReasonOpenTxns])
+
+	// the segments pinned by the txn are signaled to the sealed inspector when the txn is done.
+	pinned := make([]int64, 0)
+	for _, segment := range m.helper.waitForSealed {
+		pinned = append(pinned, segment.GetSegmentID())
+	}
+	belongs := m.helper.WaitingBelongs(append(pinned, 100000))
+	assert.NotEmpty(t, belongs)
+	assert.Len(t, belongs, len(pinned))
+	for _, b := range belongs {
+		assert.Equal(t, "v1", b.PChannel)
+		assert.Equal(t, int64(1), b.PartitionID)
+	}
 
 	err = txn.RequestCommitAndWait(context.Background(), 0)
 	assert.NoError(t, err)
 	txn.CommitDone()
+	m.OnTxnDone(pinned)
 	m.TryToSealSegments(ctx)
-	assert.True(t, m.IsNoWaitSeal())
+	assert.True(t, m.SealWaitState().IsNoWait())
 
 	// Try to seal a partition.
 	m.TryToSealSegments(ctx, stats.SegmentBelongs{
@@ -158,12 +187,13 @@ func TestSegmentAllocManager(t *testing.T) {
 		PChannel:     "v1",
 		SegmentID:    3,
 	})
-	assert.True(t, m.IsNoWaitSeal())
+	assert.True(t, m.SealWaitState().IsNoWait())
 
 	// Try to seal with a policy
-	resource.Resource().SegmentAssignStatsManager().UpdateOnSync(6000, stats.SyncOperationMetrics{
+	err = resource.Resource().SegmentAssignStatsManager().UpdateOnSync(6000, stats.SyncOperationMetrics{
 		BinLogCounterIncr: 100,
 	})
+	assert.NoError(t, err)
 	// ask a unacknowledgement seal for partition 3 to avoid seal operation.
 	result, err = m.AssignSegment(ctx, &AssignSegmentRequest{
 		CollectionID: 1,
@@ -179,11 +209,11 @@ func TestSegmentAllocManager(t *testing.T) {
 
 	// Should be collected but not sealed.
 	m.TryToSealSegments(ctx)
-	assert.False(t, m.IsNoWaitSeal())
+	assert.NotZero(t, m.SealWaitState().Reasons[stats.SealWaitReasonUnackedAssignments])
 	result.Ack()
 	// Should be sealed.
 	m.TryToSealSegments(ctx)
-	assert.True(t, m.IsNoWaitSeal())
+	assert.True(t, m.SealWaitState().IsNoWait())
 
 	// Test fence
 	ts := tsoutil.GetCurrentTime()
@@ -193,9 +223,9 @@ func TestSegmentAllocManager(t *testing.T) {
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 	assert.Empty(t, ids)
-	assert.False(t, m.IsNoWaitSeal())
+	assert.NotZero(t, m.SealWaitState().Waiting)
 	m.TryToSealSegments(ctx)
-	assert.True(t, m.IsNoWaitSeal())
+	assert.True(t, m.SealWaitState().IsNoWait())
 
 	result, err = m.AssignSegment(ctx, &AssignSegmentRequest{
 		CollectionID: 1,
@@ -253,7 +283,7 @@ func TestCreateAndDropCollection(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, resp)
 
-	m.NewCollection(100, "v1", []int64{101, 102, 103})
+	m.NewCollection(100, "v1", []int64{101, 102, 103}, InitialSchemaVersion, 0)
 	resp, err = m.AssignSegment(ctx, testRequest)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
@@ -271,126 +301,3922 @@ func TestCreateAndDropCollection(t *testing.T) {
 	resp.Ack()
 
 	m.RemovePartition(ctx, 100, 104)
-	assert.True(t, m.IsNoWaitSeal())
+	assert.True(t, m.SealWaitState().IsNoWait())
 	resp, err = m.AssignSegment(ctx, testRequest)
 	assert.Error(t, err)
 	assert.Nil(t, resp)
 
 	m.RemoveCollection(ctx, 100)
 	resp, err = m.AssignSegment(ctx, testRequest)
-	assert.True(t, m.IsNoWaitSeal())
+	assert.True(t, m.SealWaitState().IsNoWait())
 	assert.Error(t, err)
 	assert.Nil(t, resp)
 }
 
-func newStat(insertedBinarySize uint64, maxBinarySize uint64) *streamingpb.SegmentAssignmentStat {
-	return &streamingpb.SegmentAssignmentStat{
-		MaxBinarySize:         maxBinarySize,
-		InsertedRows:          insertedBinarySize,
-		InsertedBinarySize:    insertedBinarySize,
-		CreateTimestamp:       time.Now().Unix(),
-		LastModifiedTimestamp: time.Now().Unix(),
+func TestNewPartitions(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_create_partitions"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+
+	ctx := context.Background()
+	m.NewCollection(200, "v_create_partitions", []int64{201}, InitialSchemaVersion, 0)
+	newRequest := func(partitionID int64) *AssignSegmentRequest {
+		return &AssignSegmentRequest{
+			CollectionID: 200,
+			PartitionID:  partitionID,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       1,
+				BinarySize: 1,
+			},
+			TimeTick: tsoutil.GetCurrentTime(),
+		}
 	}
+
+	// inserts racing the batch registration should see either none or all of the batch.
+	batch := []int64{202, 203, 204, 205}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			resp, err := m.AssignSegment(ctx, newRequest(batch[len(batch)-1]))
+			if err != nil {
+				continue
+			}
+			resp.Ack()
+			for _, partitionID := range batch[:len(batch)-1] {
+				resp, err := m.AssignSegment(ctx, newRequest(partitionID))
+				assert.NoError(t, err)
+				resp.Ack()
+			}
+			return
+		}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	assert.NoError(t, m.NewPartitions(200, batch))
+	<-done
+
+	// the single partition handling is kept.
+	assert.NoError(t, m.NewPartition(200, 206))
+	resp, err := m.AssignSegment(ctx, newRequest(206))
+	assert.NoError(t, err)
+	resp.Ack()
+
+	// the collection not found is ignored.
+	assert.NoError(t, m.NewPartitions(300, []int64{301}))
+	_, err = m.AssignSegment(ctx, &AssignSegmentRequest{CollectionID: 300, PartitionID: 301})
+	assert.Error(t, err)
 }
 
-// initializeTestState is a helper function to initialize the status for testing.
-func initializeTestState(t *testing.T) {
-	// c 1
-	//		p 1
-	//			s 1000p
-	//		p 2
-	//			s 2000g, 3000g, 4000s, 5000g
-	// 		p 3
-	//			s 6000g
+func TestAckWithMetrics(t *testing.T) {
+	initializeTestState(t)
 
-	paramtable.Init()
-	paramtable.Get().DataCoordCfg.SegmentSealProportion.SwapTempValue("1.0")
-	paramtable.Get().DataCoordCfg.SegmentSealProportionJitter.SwapTempValue("0.0")
-	paramtable.Get().DataCoordCfg.SegmentMaxSize.SwapTempValue("1")
-	paramtable.Get().Save(paramtable.Get().CommonCfg.EnableStorageV2.Key, "true")
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
 
-	streamingNodeCatalog := mock_metastore.NewMockStreamingNodeCataLog(t)
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v1"}, f)
+	assert.NoError(t, err)
+	ctx := context.Background()
 
-	rootCoordClient := idalloc.NewMockRootCoordClient(t)
-	rootCoordClient.EXPECT().AllocSegment(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, asr *datapb.AllocSegmentRequest, co ...grpc.CallOption) (*datapb.AllocSegmentResponse, error) {
-		return &datapb.AllocSegmentResponse{
-			SegmentInfo: &datapb.SegmentInfo{
-				ID:           asr.GetSegmentId(),
-				CollectionID: asr.GetCollectionId(),
-				PartitionID:  asr.GetPartitionId(),
-			},
-			Status: merr.Success(),
-		}, nil
-	})
-	rootCoordClient.EXPECT().GetPChannelInfo(mock.Anything, mock.Anything).Return(&rootcoordpb.GetPChannelInfoResponse{
-		Collections: []*rootcoordpb.CollectionInfoOnPChannel{
-			{
-				CollectionId: 1,
-				Partitions: []*rootcoordpb.PartitionInfoOnPChannel{
-					{PartitionId: 1},
-					{PartitionId: 2},
-					{PartitionId: 3},
-				},
+	assign := func() *AssignSegmentResult {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
 			},
-		},
+			TimeTick: tsoutil.GetCurrentTime(),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6000), result.SegmentID)
+		return result
+	}
+	statOf := func() *stats.SegmentStats {
+		return resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(6000)
+	}
+
+	// full ack, nothing released.
+	result := assign()
+	result.AckWithMetrics(stats.InsertMetrics{Rows: 100, BinarySize: 100})
+	assert.Equal(t, uint64(200), statOf().Insert.BinarySize)
+	assert.Equal(t, uint64(200), statOf().Insert.Rows)
+
+	// partial ack, the unused part is released.
+	result = assign()
+	result.AckWithMetrics(stats.InsertMetrics{Rows: 40, BinarySize: 60})
+	assert.Equal(t, uint64(260), statOf().Insert.BinarySize)
+	assert.Equal(t, uint64(240), statOf().Insert.Rows)
+
+	// zero ack, all assignment is released.
+	result = assign()
+	result.AckWithMetrics(stats.InsertMetrics{})
+	assert.Equal(t, uint64(260), statOf().Insert.BinarySize)
+	assert.Equal(t, uint64(240), statOf().Insert.Rows)
+
+	// plain ack keep the assignment.
+	result = assign()
+	result.Ack()
+	assert.Equal(t, uint64(360), statOf().Insert.BinarySize)
+	assert.Equal(t, uint64(340), statOf().Insert.Rows)
+
+	m.TryToSealWaitedSegment(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+	m.Close(ctx)
+}
+
+func TestPrewarmCollection(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
 	}, nil)
-	fRootCoordClient := syncutil.NewFuture[internaltypes.MixCoordClient]()
-	fRootCoordClient.Set(rootCoordClient)
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
 
-	resource.InitForTest(t,
-		resource.OptStreamingNodeCatalog(streamingNodeCatalog),
-		resource.OptMixCoordClient(fRootCoordClient),
-	)
-	streamingNodeCatalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).Return(
-		[]*streamingpb.SegmentAssignmentMeta{
-			{
-				CollectionId: 1,
-				PartitionId:  1,
-				SegmentId:    1000,
-				Vchannel:     "v1",
-				State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING,
-				Stat:         nil,
-			},
-			{
-				CollectionId: 1,
-				PartitionId:  2,
-				SegmentId:    2000,
-				Vchannel:     "v1",
-				State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
-				Stat:         newStat(1000, 1000),
-			},
-			{
-				CollectionId: 1,
-				PartitionId:  2,
-				SegmentId:    3000,
-				Vchannel:     "v1",
-				State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
-				Stat:         newStat(100, 1000),
-			},
-			{
-				CollectionId: 1,
-				PartitionId:  2,
-				SegmentId:    4000,
-				Vchannel:     "v1",
-				State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED,
-				Stat:         newStat(900, 1000),
-			},
-			{
-				CollectionId: 1,
-				PartitionId:  2,
-				SegmentId:    5000,
-				Vchannel:     "v1",
-				State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
-				Stat:         newStat(900, 1000),
-			},
-			{
-				CollectionId: 1,
-				PartitionId:  3,
-				SegmentId:    6000,
-				Vchannel:     "v1",
-				State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
-				Stat:         newStat(100, 1000),
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v1"}, f)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	err = m.PrewarmCollection(ctx, 100)
+	assert.ErrorIs(t, err, ErrCollectionNotFound)
+
+	// only partition 1 has no growing segment, the pending segment 1000 should be transferred into growing.
+	assert.NoError(t, m.PrewarmCollection(ctx, 1))
+	pm, err := m.managers.Get(1, 1)
+	assert.NoError(t, err)
+	snapshot := pm.Snapshot()
+	assert.Len(t, snapshot.Segments, 1)
+	assert.Equal(t, int64(1000), snapshot.Segments[0].SegmentID)
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING.String(), snapshot.Segments[0].State)
+	w.AssertNumberOfCalls(t, "Append", 1)
+
+	// prewarm again should be a no-op.
+	assert.NoError(t, m.PrewarmCollection(ctx, 1))
+	w.AssertNumberOfCalls(t, "Append", 1)
+	m.Close(ctx)
+}
+
+func TestSealSegmentsOlderThan(t *testing.T) {
+	// the last modified timestamp of segment is persisted in seconds, so the clock is started at a whole second.
+	fakeClock := clock.NewFakeClock(time.Unix(time.Now().Unix(), 0))
+	initializeTestStateWithClock(t, fakeClock)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_older_than"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	_, err = m.SealSegmentsOlderThan(ctx, 100, fakeClock.CurrentTSO())
+	assert.ErrorIs(t, err, ErrCollectionNotFound)
+
+	assign := func(partitionID int64) (int64, uint64) {
+		tt := fakeClock.CurrentTSO()
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  partitionID,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
 			},
-		}, nil)
-	streamingNodeCatalog.EXPECT().SaveSegmentAssignments(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			TimeTick: tt,
+		})
+		assert.NoError(t, err)
+		result.Ack()
+		return result.SegmentID, tt
+	}
+	segmentOfP1, tt1 := assign(1)
+	assert.Equal(t, int64(1000), segmentOfP1)
+	fakeClock.Advance(time.Millisecond)
+	segmentOfP3, tt2 := assign(3)
+	assert.Equal(t, int64(6000), segmentOfP3)
+
+	// only the segment of p1 and the recovered sealed segment is sealed,
+	// the recovered growing segments modified within the same second of the timetick are kept, because they may contain newer data.
+	segmentIDs, err := m.SealSegmentsOlderThan(ctx, 1, tt1)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int64{1000, 4000}, segmentIDs)
+
+	// no fence is applied, the partition can still be assigned with older timetick.
+	segmentOfP1, _ = assign(1)
+	assert.NotEqual(t, int64(1000), segmentOfP1)
+
+	// concurrent with a normal flush, every segment is sealed exactly once.
+	fakeClock.Advance(time.Millisecond)
+	tt3 := fakeClock.CurrentTSO()
+	var olderThanIDs, fencedIDs []int64
+	var olderThanErr, fencedErr error
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		olderThanIDs, olderThanErr = m.SealSegmentsOlderThan(ctx, 1, tt2)
+	}()
+	go func() {
+		defer wg.Done()
+		fencedIDs, fencedErr = m.SealAndFenceSegmentUntil(ctx, 1, tt3)
+	}()
+	wg.Wait()
+	assert.NoError(t, olderThanErr)
+	assert.NoError(t, fencedErr)
+	assert.ElementsMatch(t, []int64{segmentOfP1, 2000, 3000, 5000, 6000}, append(olderThanIDs, fencedIDs...))
+	assert.True(t, m.SealWaitState().IsNoWait())
+	m.Close(ctx)
+}
+
+func TestSealRecoveredSegmentsOlderThan(t *testing.T) {
+	// the last modified timestamp of segment is persisted in seconds, so the clock is started at a whole second.
+	fakeClock := clock.NewFakeClock(time.Unix(time.Now().Unix(), 0))
+	initializeTestStateWithClock(t, fakeClock)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_recovered_older_than"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	// the recovered growing segment without new insert is judged by its last modified time,
+	// the recovered segment with new insert is judged by its assigned timetick.
+	fakeClock.Advance(2 * time.Second)
+	tt := fakeClock.CurrentTSO()
+	fakeClock.Advance(time.Millisecond)
+	result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  3,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       100,
+			BinarySize: 100,
+		},
+		TimeTick: fakeClock.CurrentTSO(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6000), result.SegmentID)
+	result.Ack()
+
+	// the admin rpc is served by the manager registered on the inspector.
+	resp, err := FlushSegmentsOlderThanOnPChannel(ctx, &streamingpb.StreamingNodeManagerFlushSegmentsOlderThanRequest{
+		Pchannel:     &streamingpb.PChannelInfo{Name: "v_recovered_older_than"},
+		CollectionId: 1,
+		TimeTick:     tt,
+	})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int64{2000, 3000, 4000, 5000}, resp.GetSegmentIds())
+	_, err = FlushSegmentsOlderThanOnPChannel(ctx, &streamingpb.StreamingNodeManagerFlushSegmentsOlderThanRequest{
+		Pchannel:     &streamingpb.PChannelInfo{Name: "v_not_exist"},
+		CollectionId: 1,
+		TimeTick:     tt,
+	})
+	assert.True(t, status.AsStreamingError(err).IsRetryLater())
+	m.Close(ctx)
+}
+
+func TestRemovePartitionSealOrDiscard(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_discard"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watchCh := m.WatchAssignments(ctx)
+
+	// discard mode, no flush message is sent, the metas are dropped and then deleted.
+	assert.NoError(t, m.RemovePartitionAndDiscardSegments(ctx, 1, 2))
+	assert.True(t, m.SealWaitState().IsNoWait())
+	w.AssertNumberOfCalls(t, "Append", 0)
+	discarded := make([]int64, 0, 4)
+	for i := 0; i < 4; i++ {
+		event := <-watchCh
+		assert.Equal(t, AssignmentEventDiscard, event.Type)
+		discarded = append(discarded, event.SegmentID)
+	}
+	assert.ElementsMatch(t, []int64{2000, 3000, 4000, 5000}, discarded)
+	for _, segmentID := range discarded {
+		assert.Equal(t, []streamingpb.SegmentAssignmentState{
+			streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_DROPPED,
+			streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED,
+		}, savedSegmentStates(segmentID))
+	}
+
+	// seal mode, the growing segment is sealed and flushed.
+	assert.NoError(t, m.RemovePartition(ctx, 1, 3))
+	assert.True(t, m.SealWaitState().IsNoWait())
+	w.AssertNumberOfCalls(t, "Append", 1)
+	assert.Equal(t, []streamingpb.SegmentAssignmentState{
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED,
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED,
+	}, savedSegmentStates(6000))
+	m.Close(context.Background())
+}
+
+func TestRecoverDroppedSegment(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	// the segment 7000 is dropped but not deleted before crash.
+	pchannel := types.PChannelInfo{Name: "v_discard_recover"}
+	metrics := metricsutil.NewSegmentAssignMetrics(pchannel.Name)
+	defer metrics.Close()
+	watcher := newAssignmentWatcher(defaultAssignmentEventRingSize)
+	_, waitForSealed := buildNewPartitionManagers(f, pchannel, []*streamingpb.SegmentAssignmentMeta{
+		{
+			CollectionId: 1,
+			PartitionId:  2,
+			SegmentId:    7000,
+			Vchannel:     "v1",
+			State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_DROPPED,
+		},
+		{
+			CollectionId: 1,
+			PartitionId:  2,
+			SegmentId:    8000,
+			Vchannel:     "v1",
+			State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+			Stat:         newStat(100, 1000),
+		},
+	}, []*rootcoordpb.CollectionInfoOnPChannel{
+		{
+			CollectionId: 1,
+			Vchannel:     "v1",
+			Partitions:   []*rootcoordpb.PartitionInfoOnPChannel{{PartitionId: 2}},
+		},
+	}, nil, metrics, watcher, newSealRandom(1), newRecoverySummary(pchannel.Name))
+	assert.Len(t, waitForSealed, 1)
+	assert.Equal(t, int64(7000), waitForSealed[0].GetSegmentID())
+	assert.True(t, waitForSealed[0].IsDiscard())
+
+	// the dropped segment is deleted without flush message.
+	q := newSealQueue(log.With(), pchannel.Name, f, waitForSealed, metrics, watcher, newSealedSegmentNotifier(log.With(), pchannel))
+	q.SealAllWait(context.Background())
+	assert.True(t, q.IsEmpty())
+	w.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	assert.Equal(t, []streamingpb.SegmentAssignmentState{
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED,
+	}, savedSegmentStates(7000))
+}
+
+func TestReconcileMaxBinarySize(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_reconcile"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+
+	// the limit is not exceeded by more than the factor, nothing happens.
+	pm, err := m.managers.Get(1, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, pm.ReconcileMaxBinarySize(800, 1.5, true))
+
+	// re-cap the segment whose fill is below the new limit, seal the others.
+	sealed := pm.ReconcileMaxBinarySize(500, 1.5, true)
+	assert.ElementsMatch(t, []int64{2000, 4000, 5000}, lo.Map(sealed, func(s *segmentAllocManager, _ int) int64 { return s.GetSegmentID() }))
+	for _, segment := range sealed {
+		if segment.GetSegmentID() != 4000 {
+			assert.Equal(t, policy.PolicyNameMaxSizeReconciled, segment.SealPolicy())
+		}
+	}
+	assert.Equal(t, uint64(500), resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(3000).MaxBinarySize)
+	m.helper.AsyncSeal(sealed...)
+
+	// seal the segment directly if recap is disabled.
+	pm, err = m.managers.Get(1, 3)
+	assert.NoError(t, err)
+	sealed = pm.ReconcileMaxBinarySize(500, 1.5, false)
+	assert.Len(t, sealed, 1)
+	assert.Equal(t, int64(6000), sealed[0].GetSegmentID())
+	assert.Equal(t, policy.PolicyNameMaxSizeReconciled, sealed[0].SealPolicy())
+	assert.Equal(t, uint64(1000), sealed[0].GetStat().MaxBinarySize)
+	m.helper.AsyncSeal(sealed...)
+	m.helper.SealAllWait(context.Background())
+	assert.True(t, m.SealWaitState().IsNoWait())
+	m.Close(context.Background())
+}
+
+func TestTooLargeInsert(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_too_large"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+
+	req := &AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  3,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       1,
+			BinarySize: 2 * 1024 * 1024,
+		},
+		TimeTick: tsoutil.GetCurrentTime(),
+	}
+	// a new growing segment is allocated but can not hold the insert.
+	_, err = m.AssignSegment(context.Background(), req)
+	assert.ErrorIs(t, err, ErrTooLargeInsert)
+	var tooLarge *TooLargeInsertError
+	assert.ErrorAs(t, err, &tooLarge)
+	assert.Equal(t, req.InsertMetrics.BinarySize, tooLarge.BinarySize)
+	assert.LessOrEqual(t, tooLarge.MaxBinarySize, policy.GetSegmentMaxBinarySizeLimit())
+	assert.Equal(t, tooLarge.MaxBinarySize, tooLarge.RemainingBinarySize)
+
+	// the numbers follow the effective max binary size of the segment, not the global paramtable value.
+	pm, err := m.managers.Get(1, 3)
+	assert.NoError(t, err)
+	newSegment := pm.segments[len(pm.segments)-1]
+	_, ok := resource.Resource().SegmentAssignStatsManager().RecapMaxBinarySize(newSegment.GetSegmentID(), 4096)
+	assert.True(t, ok)
+	req.TimeTick = tsoutil.GetCurrentTime()
+	_, err = m.AssignSegment(context.Background(), req)
+	assert.ErrorAs(t, err, &tooLarge)
+	assert.Equal(t, uint64(4096), tooLarge.MaxBinarySize)
+	assert.Equal(t, uint64(4096), tooLarge.RemainingBinarySize)
+}
+
+func TestPreviewAssign(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_preview_assign"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	preview := func(binarySize uint64) *streamingpb.AssignPreviewResponse {
+		resp, err := m.PreviewAssign(ctx, &AssignPreviewRequest{
+			CollectionID:  1,
+			PartitionID:   2,
+			InsertMetrics: stats.InsertMetrics{Rows: 1, BinarySize: binarySize},
+		})
+		assert.NoError(t, err)
+		return resp
+	}
+	segmentCount := func() int {
+		pm, err := m.managers.Get(1, 2)
+		assert.NoError(t, err)
+		return len(pm.segments)
+	}
+	before := segmentCount()
+
+	// only the segment 3000 can hold the insert.
+	resp := preview(500)
+	assert.Equal(t, streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_FIT_EXISTING, resp.GetResult())
+	assert.Equal(t, int64(3000), resp.GetSegmentId())
+	assert.Equal(t, uint64(900), resp.GetRemainingBinarySize())
+	assert.Equal(t, uint64(1000), resp.GetMaxBinarySize())
+	assert.Equal(t, policy.GetSegmentMaxBinarySizeLimit(), resp.GetSegmentMaxBinarySize())
+
+	// no growing segment can hold the insert, a new segment would be allocated.
+	resp = preview(2000)
+	assert.Equal(t, streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_NEW_SEGMENT, resp.GetResult())
+	assert.Zero(t, resp.GetSegmentId())
+	assert.Equal(t, uint64(900), resp.GetRemainingBinarySize())
+
+	// the insert can not be held by any segment.
+	resp = preview(2 * 1024 * 1024)
+	assert.Equal(t, streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_TOO_LARGE, resp.GetResult())
+	assert.Equal(t, uint64(900), resp.GetRemainingBinarySize())
+
+	// no state is mutated by the preview.
+	assert.Equal(t, before, segmentCount())
+	assert.Equal(t, uint64(100), resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(3000).Insert.BinarySize)
+
+	// preview by the rpc request.
+	resp, err = PreviewAssignOnPChannel(ctx, &streamingpb.AssignPreviewRequest{
+		Pchannel:     &streamingpb.PChannelInfo{Name: "v_preview_assign"},
+		CollectionId: 1,
+		PartitionId:  3,
+		Rows:         1,
+		BinarySize:   500,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_FIT_EXISTING, resp.GetResult())
+	assert.Equal(t, int64(6000), resp.GetSegmentId())
+
+	_, err = PreviewAssignOnPChannel(ctx, &streamingpb.AssignPreviewRequest{
+		Pchannel:     &streamingpb.PChannelInfo{Name: "v_preview_assign_not_exist"},
+		CollectionId: 1,
+		PartitionId:  3,
+	})
+	assert.True(t, status.AsStreamingError(err).IsRetryLater())
+
+	// the partition is not found.
+	_, err = m.PreviewAssign(ctx, &AssignPreviewRequest{CollectionID: 1, PartitionID: 100})
+	assert.Error(t, err)
+
+	m.Close(ctx)
+	_, err = m.PreviewAssign(ctx, &AssignPreviewRequest{CollectionID: 1, PartitionID: 2})
+	assert.Error(t, err)
+}
+
+func TestSystemCollectionSkipCircuitBreaker(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_system_collection"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+
+	newReq := func(binarySize uint64) *AssignSegmentRequest {
+		return &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       1,
+				BinarySize: binarySize,
+			},
+			TimeTick: tsoutil.GetCurrentTime(),
+		}
+	}
+
+	// open the circuit breaker of the collection.
+	for i := 0; i < paramtable.Get().StreamingCfg.WALSegmentAssignCircuitBreakerFailureThreshold.GetAsInt(); i++ {
+		m.breakers.Record(1, errors.New("alloc segment failed"))
+	}
+	_, err = m.AssignSegment(context.Background(), newReq(100))
+	assert.ErrorIs(t, err, ErrCollectionCircuitOpen)
+
+	// the system collection is still assigned when the circuit breaker is open.
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignSystemCollectionMaxID.Key, "10")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignSystemCollectionMaxID.Key)
+	assert.True(t, IsSystemCollection(1))
+	assert.False(t, IsSystemCollection(100))
+	result, err := m.AssignSegment(context.Background(), newReq(100))
+	assert.NoError(t, err)
+	result.Ack()
+
+	// the max size check is still applied.
+	_, err = m.AssignSegment(context.Background(), newReq(2*1024*1024))
+	assert.ErrorIs(t, err, ErrTooLargeInsert)
+
+	// the fencing is still applied.
+	ts := tsoutil.GetCurrentTime()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	// the fence is applied before waiting for the segments to be flushed.
+	m.SealAndFenceSegmentUntil(ctx, 1, ts)
+	req := newReq(100)
+	req.TimeTick = ts
+	_, err = m.AssignSegment(context.Background(), req)
+	assert.ErrorIs(t, err, ErrFencedAssign)
+}
+
+func TestManualFlushReplay(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_manual_flush_replay"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	// the applied manual flush is recovered from the vchannel meta without the sealed segments.
+	segmentIDs, ok := m.GetAppliedManualFlush(1, 1)
+	assert.True(t, ok)
+	assert.Nil(t, segmentIDs)
+
+	// applyManualFlush applies the manual flush in the same way as the segment interceptor.
+	applyManualFlush := func(flushTs uint64) []int64 {
+		if segmentIDs, ok := m.GetAppliedManualFlush(1, flushTs); ok {
+			return segmentIDs
+		}
+		segmentIDs, err := m.SealAndFenceSegmentUntil(ctx, 1, flushTs)
+		assert.NoError(t, err)
+		m.ObserveManualFlush(1, flushTs, segmentIDs)
+		return segmentIDs
+	}
+	assign := func() int64 {
+		time.Sleep(time.Millisecond)
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick: tsoutil.GetCurrentTime(),
+		})
+		assert.NoError(t, err)
+		result.Ack()
+		return result.SegmentID
+	}
+
+	assert.Equal(t, int64(6000), assign())
+	flushTs := tsoutil.GetCurrentTime()
+	sealed := applyManualFlush(flushTs)
+	assert.Contains(t, sealed, int64(6000))
+
+	// insert after the manual flush is assigned to a new growing segment.
+	fresh := assign()
+	assert.NotEqual(t, int64(6000), fresh)
+
+	// the replayed manual flush is a no-op with the recorded sealed segments.
+	assert.ElementsMatch(t, sealed, applyManualFlush(flushTs))
+	assert.Equal(t, fresh, assign())
+
+	// the newer manual flush still seals the fresh growing segment.
+	assert.Contains(t, applyManualFlush(tsoutil.GetCurrentTime()), fresh)
+	assert.NotEqual(t, fresh, assign())
+
+	// the record is removed with the collection.
+	assert.NoError(t, m.RemoveCollection(ctx, 1))
+	_, ok = m.GetAppliedManualFlush(1, flushTs)
+	assert.False(t, ok)
+}
+
+func TestGrowingSegmentNotify(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	received := make(chan inspector.GrowingSegmentInfo, 10)
+	inspector.GetGrowingSegmentNotifier().Register("v_growing_notify", func(info inspector.GrowingSegmentInfo) {
+		received <- info
+	})
+	defer inspector.GetGrowingSegmentNotifier().Unregister("v_growing_notify")
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_growing_notify"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+
+	assign := func(partitionID int64) int64 {
+		result, err := m.AssignSegment(context.Background(), &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  partitionID,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick: tsoutil.GetCurrentTime(),
+		})
+		assert.NoError(t, err)
+		result.Ack()
+		return result.SegmentID
+	}
+
+	// the assignment on the existing growing segment doesn't notify.
+	assert.Equal(t, int64(6000), assign(3))
+	// the pending segment is promoted to growing.
+	assert.Equal(t, int64(1000), assign(1))
+	select {
+	case info := <-received:
+		assert.Equal(t, "v_growing_notify", info.PChannel)
+		assert.Equal(t, int64(1), info.CollectionID)
+		assert.Equal(t, int64(1), info.PartitionID)
+		assert.Equal(t, int64(1000), info.SegmentID)
+		assert.NotZero(t, info.MaxBinarySize)
+	case <-time.After(time.Second):
+		t.Errorf("expect the growing segment is notified")
+	}
+	select {
+	case info := <-received:
+		t.Errorf("unexpected growing segment notification %+v", info)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSegmentStorageVersion(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	received := make(chan inspector.GrowingSegmentInfo, 10)
+	inspector.GetGrowingSegmentNotifier().Register("v_storage_version", func(info inspector.GrowingSegmentInfo) {
+		received <- info
+	})
+	defer inspector.GetGrowingSegmentNotifier().Unregister("v_storage_version")
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_storage_version"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watchCh := m.WatchAssignments(ctx)
+
+	assign := func() (int64, uint64) {
+		tt := tsoutil.GetCurrentTime()
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  1,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick: tt,
+		})
+		assert.NoError(t, err)
+		result.Ack()
+		return result.SegmentID, tt
+	}
+	receiveStorageVersion := func(segmentID int64) int64 {
+		select {
+		case info := <-received:
+			assert.Equal(t, segmentID, info.SegmentID)
+			return info.StorageVersion
+		case <-time.After(time.Second):
+			t.Errorf("expect the growing segment is notified")
+			return -1
+		}
+	}
+
+	// the storage v2 is enabled globally, but collection 1 is overridden to use storage v1.
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignStorageVersionOverrides.Key, "1:0,invalid,2:3")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignStorageVersionOverrides.Key)
+	assert.Equal(t, storage.StorageV1, policy.GetSegmentStorageVersion(1))
+	assert.Equal(t, storage.StorageV2, policy.GetSegmentStorageVersion(2))
+
+	// the recovered pending segment keeps its persisted storage version.
+	segmentID, tt := assign()
+	assert.Equal(t, int64(1000), segmentID)
+	assert.Equal(t, storage.StorageV1, receiveStorageVersion(segmentID))
+
+	// the new segment follows the override.
+	_, err = m.SealSegmentsOlderThan(ctx, 1, tt)
+	assert.NoError(t, err)
+	segmentID, tt = assign()
+	assert.NotEqual(t, int64(1000), segmentID)
+	assert.Equal(t, storage.StorageV1, receiveStorageVersion(segmentID))
+
+	// the new segment follows the global switch after the override is removed,
+	// but the existing segment is not affected.
+	paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignStorageVersionOverrides.Key)
+	_, err = m.SealSegmentsOlderThan(ctx, 1, tt)
+	assert.NoError(t, err)
+	segmentID, _ = assign()
+	assert.Equal(t, storage.StorageV2, receiveStorageVersion(segmentID))
+
+	// the effective storage version is exposed by the snapshot, the assignment event and the stats.
+	pm, err := m.managers.Get(1, 1)
+	assert.NoError(t, err)
+	snapshot := pm.Snapshot()
+	assert.Len(t, snapshot.Segments, 1)
+	assert.Equal(t, segmentID, snapshot.Segments[0].SegmentID)
+	assert.Equal(t, storage.StorageV2, snapshot.Segments[0].StorageVersion)
+	for event := range watchCh {
+		if event.Type == AssignmentEventCreate && event.SegmentID == segmentID {
+			assert.Equal(t, storage.StorageV2, event.StorageVersion)
+			break
+		}
+	}
+	versions := resource.Resource().SegmentAssignStatsManager().GetStorageVersionsOfCollection(1)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, 1, versions[storage.StorageV2])
+	found := false
+	for _, count := range resource.Resource().SegmentAssignStatsManager().GetStorageVersionStats() {
+		if count.PChannel == "v_storage_version" && count.StorageVersion == storage.StorageV2 {
+			assert.Equal(t, 1, count.SegmentCount)
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestSegmentOrigin(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_segment_origin"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	assign := func(partitionID int64) *AssignSegmentResult {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  partitionID,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick: tsoutil.GetCurrentTime(),
+		})
+		assert.NoError(t, err)
+		return result
+	}
+	originOf := func(partitionID int64, segmentID int64) string {
+		snapshot, err := m.SnapshotCollection(1, []int64{partitionID}, 0, 10)
+		assert.NoError(t, err)
+		for _, segment := range snapshot.Partitions[0].Segments {
+			if segment.SegmentID == segmentID {
+				return segment.OriginMessageID
+			}
+		}
+		return ""
+	}
+
+	// the creating insert fails to be appended, so it's never observed.
+	failed := assign(1)
+	assert.Equal(t, int64(1000), failed.SegmentID)
+	failed.Ack()
+	assert.Empty(t, originOf(1, 1000))
+
+	// the concurrent appends may be observed out of order, the earliest one is kept.
+	for _, id := range []int64{20, 10, 30} {
+		result := assign(1)
+		result.ObserveAppended(rmq.NewRmqID(id))
+		result.Ack()
+	}
+	assert.Equal(t, rmq.NewRmqID(10).Marshal(), originOf(1, 1000))
+
+	// the observed origin is persisted by the next assignment.
+	assign(1).Ack()
+	catalog := resource.Resource().StreamingNodeCatalog().(*mock_metastore.MockStreamingNodeCataLog)
+	persisted := false
+	for _, call := range catalog.Calls {
+		if call.Method != "SaveSegmentAssignments" {
+			continue
+		}
+		infos := call.Arguments.Get(2).(map[int64]*streamingpb.SegmentAssignmentMeta)
+		if info, ok := infos[1000]; ok && info.GetOriginMessageId().GetId() == rmq.NewRmqID(10).Marshal() {
+			persisted = true
+		}
+	}
+	assert.True(t, persisted)
+
+	// the origin of the recovered growing segment is lost, never observed again.
+	result := assign(3)
+	assert.Equal(t, int64(6000), result.SegmentID)
+	result.ObserveAppended(rmq.NewRmqID(1))
+	result.Ack()
+	assert.Empty(t, originOf(3, 6000))
+}
+
+func TestSegmentAssignmentOrigin(t *testing.T) {
+	initializeTestState(t)
+
+	var mu sync.Mutex
+	flushedOrigins := make(map[int64]string)
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, msg message.MutableMessage) (*wal.AppendResult, error) {
+		if msg.MessageType() == message.MessageTypeFlush {
+			header := message.MustAsMutableFlushMessageV2(msg).Header()
+			mu.Lock()
+			flushedOrigins[header.GetSegmentId()] = header.GetOrigin()
+			mu.Unlock()
+		}
+		return &wal.AppendResult{
+			MessageID: rmq.NewRmqID(1),
+			TimeTick:  1,
+		}, nil
+	}).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_assignment_origin"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	originOf := func(partitionID int64, segmentID int64) string {
+		snapshot, err := m.SnapshotCollection(1, []int64{partitionID}, 0, 10)
+		assert.NoError(t, err)
+		for _, segment := range snapshot.Partitions[0].Segments {
+			if segment.SegmentID == segmentID {
+				return segment.Origin
+			}
+		}
+		return ""
+	}
+	flushedOrigin := func(segmentID int64) string {
+		mu.Lock()
+		defer mu.Unlock()
+		return flushedOrigins[segmentID]
+	}
+
+	// the recovered pending segment is reconciled by recovery, the meta written before the origin is normal.
+	reconciled := streamingpb.SegmentAssignmentOrigin_SEGMENT_ASSIGNMENT_ORIGIN_RECOVERY_RECONCILED.String()
+	normal := streamingpb.SegmentAssignmentOrigin_SEGMENT_ASSIGNMENT_ORIGIN_NORMAL.String()
+	assert.Equal(t, reconciled, originOf(1, 1000))
+	assert.Equal(t, normal, originOf(3, 6000))
+
+	// the reconciled origin is kept and persisted after the pending segment is promoted by prewarm.
+	assert.NoError(t, m.PrewarmCollection(ctx, 1))
+	assert.Equal(t, reconciled, originOf(1, 1000))
+	catalog := resource.Resource().StreamingNodeCatalog().(*mock_metastore.MockStreamingNodeCataLog)
+	persisted := false
+	for _, call := range catalog.Calls {
+		if call.Method != "SaveSegmentAssignments" {
+			continue
+		}
+		infos := call.Arguments.Get(2).(map[int64]*streamingpb.SegmentAssignmentMeta)
+		if info, ok := infos[1000]; ok && info.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
+			persisted = info.GetOrigin() == streamingpb.SegmentAssignmentOrigin_SEGMENT_ASSIGNMENT_ORIGIN_RECOVERY_RECONCILED
+		}
+	}
+	assert.True(t, persisted)
+
+	// the origin is propagated into the flush message.
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 1, SegmentID: 1000})
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: 6000})
+	assert.True(t, m.SealWaitState().IsNoWait())
+	assert.Equal(t, reconciled, flushedOrigin(1000))
+	assert.Equal(t, normal, flushedOrigin(6000))
+
+	// the segment allocated by prewarm is pre-allocated.
+	assert.NoError(t, m.PrewarmCollection(ctx, 1))
+	pm, err := m.managers.Get(1, 1)
+	assert.NoError(t, err)
+	snapshot := pm.Snapshot()
+	assert.Len(t, snapshot.Segments, 1)
+	assert.NotEqual(t, int64(1000), snapshot.Segments[0].SegmentID)
+	assert.Equal(t, streamingpb.SegmentAssignmentOrigin_SEGMENT_ASSIGNMENT_ORIGIN_PRE_ALLOCATED.String(), snapshot.Segments[0].Origin)
+
+	// the segment allocated by the insert is normal.
+	result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  3,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       100,
+			BinarySize: 100,
+		},
+		TimeTick: tsoutil.GetCurrentTime(),
+	})
+	assert.NoError(t, err)
+	result.Ack()
+	assert.Equal(t, normal, originOf(3, result.SegmentID))
+	m.Close(ctx)
+}
+
+func TestSchemaVersion(t *testing.T) {
+	initializeTestState(t)
+
+	var mu sync.Mutex
+	flushedSchemaVersions := make(map[int64]uint64)
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, msg message.MutableMessage) (*wal.AppendResult, error) {
+		if msg.MessageType() == message.MessageTypeFlush {
+			h := message.MustAsMutableFlushMessageV2(msg).Header()
+			mu.Lock()
+			flushedSchemaVersions[h.GetSegmentId()] = h.GetSchemaVersion()
+			mu.Unlock()
+		}
+		return &wal.AppendResult{
+			MessageID: rmq.NewRmqID(1),
+			TimeTick:  1,
+		}, nil
+	}).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_schema_version"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	assign := func(partitionID int64, schemaVersion uint64) (*AssignSegmentResult, error) {
+		return m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  partitionID,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick:      tsoutil.GetCurrentTime(),
+			SchemaVersion: schemaVersion,
+		})
+	}
+
+	// the insert that doesn't declare its schema version is always accepted.
+	result, err := assign(3, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6000), result.SegmentID)
+	assert.Equal(t, InitialSchemaVersion, result.SchemaVersion)
+	result.Ack()
+
+	// the insert built with a mismatched schema version is rejected.
+	_, err = assign(3, 7)
+	assert.ErrorIs(t, err, ErrSchemaVersionMismatch)
+
+	// the growing segments created under the older schema version are sealed after the schema change.
+	assert.NoError(t, m.ChangeSchemaVersion(ctx, 1, 7))
+	m.helper.SealAllWait(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+	mu.Lock()
+	assert.Contains(t, flushedSchemaVersions, int64(6000))
+	assert.Equal(t, InitialSchemaVersion, flushedSchemaVersions[6000])
+	mu.Unlock()
+
+	// the new segment is stamped with the new schema version.
+	_, err = assign(3, 5)
+	assert.ErrorIs(t, err, ErrSchemaVersionMismatch)
+	result, err = assign(3, 7)
+	assert.NoError(t, err)
+	assert.NotEqual(t, int64(6000), result.SegmentID)
+	assert.Equal(t, uint64(7), result.SchemaVersion)
+	result.Ack()
+	snapshot, err := m.SnapshotCollection(1, []int64{3}, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), snapshot.Partitions[0].Segments[0].SchemaVersion)
+
+	// the replayed schema change is a no-op.
+	assert.NoError(t, m.ChangeSchemaVersion(ctx, 1, 5))
+	result, err = assign(3, 7)
+	assert.NoError(t, err)
+	result.Ack()
+	assert.ErrorIs(t, m.ChangeSchemaVersion(ctx, 100, 7), ErrCollectionNotFound)
+
+	// the flushed segment carries its schema version.
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: result.SegmentID})
+	mu.Lock()
+	assert.Equal(t, uint64(7), flushedSchemaVersions[result.SegmentID])
+	mu.Unlock()
+	m.Close(ctx)
+}
+
+func TestRecoverSchemaVersions(t *testing.T) {
+	versions := recoverSchemaVersions([]*streamingpb.VChannelMeta{
+		{
+			State:          streamingpb.VChannelState_VCHANNEL_STATE_NORMAL,
+			CollectionInfo: &streamingpb.CollectionInfoOfVChannel{CollectionId: 1, SchemaVersion: 10},
+		},
+		{
+			State:          streamingpb.VChannelState_VCHANNEL_STATE_NORMAL,
+			CollectionInfo: &streamingpb.CollectionInfoOfVChannel{CollectionId: 2, SchemaVersion: 10},
+		},
+		{
+			State:          streamingpb.VChannelState_VCHANNEL_STATE_DROPPED,
+			CollectionInfo: &streamingpb.CollectionInfoOfVChannel{CollectionId: 3, SchemaVersion: 10},
+		},
+	}, []*streamingpb.SegmentAssignmentMeta{
+		{CollectionId: 1, SchemaVersion: 5},
+		// the vchannel meta is stale, the newer segment wins.
+		{CollectionId: 2, SchemaVersion: 20},
+		{CollectionId: 4, SchemaVersion: 30},
+	})
+	assert.Equal(t, map[int64]uint64{1: 10, 2: 20, 4: 30}, versions)
+
+	assert.NoError(t, checkSchemaVersion(1, 0, 10))
+	assert.NoError(t, checkSchemaVersion(1, 10, 10))
+	assert.ErrorIs(t, checkSchemaVersion(1, 5, 10), ErrSchemaVersionMismatch)
+}
+
+func TestVChannelMismatch(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_vchannel_mismatch"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	assign := func(vchannel string) (*AssignSegmentResult, error) {
+		return m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID:  1,
+			PartitionID:   3,
+			VChannel:      vchannel,
+			InsertMetrics: stats.InsertMetrics{Rows: 100, BinarySize: 100},
+			TimeTick:      tsoutil.GetCurrentTime(),
+		})
+	}
+
+	// the insert on the registered vchannel or without declared vchannel is accepted.
+	for _, vchannel := range []string{"v1", ""} {
+		result, err := assign(vchannel)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6000), result.SegmentID)
+		result.Ack()
+	}
+
+	// the mis-routed insert is rejected without any assignment.
+	before := resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(6000).Insert
+	_, err = assign("v2")
+	assert.ErrorIs(t, err, ErrVChannelMismatch)
+	assert.ErrorContains(t, err, "message vchannel v2, registered vchannel v1")
+	assert.Equal(t, before, resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(6000).Insert)
+
+	// the mis-routed delete is rejected too.
+	_, err = m.AssignDeleteSegment(ctx, &AssignDeleteSegmentRequest{
+		CollectionID:  1,
+		PartitionID:   3,
+		VChannel:      "v2",
+		DeleteMetrics: stats.DeleteMetrics{Rows: 1, BinarySize: 10},
+		TimeTick:      tsoutil.GetCurrentTime(),
+	})
+	assert.ErrorIs(t, err, ErrVChannelMismatch)
+	snapshot, err := m.SnapshotCollection(1, []int64{3}, 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, snapshot.Partitions[0].Segments, 1)
+
+	assert.NoError(t, checkVChannel(1, "", "v1"))
+	assert.NoError(t, checkVChannel(1, "v1", "v1"))
+	assert.ErrorIs(t, checkVChannel(1, "v2", "v1"), ErrVChannelMismatch)
+	m.Close(ctx)
+}
+
+func TestObserveSourceAssign(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_observe_source_assign"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+	resource.Resource().SegmentAssignStatsManager().ResetSourceStats()
+	defer resource.Resource().SegmentAssignStatsManager().ResetSourceStats()
+
+	assign := func(source string) {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID:  1,
+			PartitionID:   3,
+			InsertMetrics: stats.InsertMetrics{Rows: 1, BinarySize: 10},
+			TimeTick:      tsoutil.GetCurrentTime(),
+			Source:        source,
+		})
+		assert.NoError(t, err)
+		result.Ack()
+	}
+
+	// the assignments of both the user collection and the system collection are attributed to the source node.
+	assign("user")
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.WALSegmentAssignSystemCollectionMaxID.Key, "1")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignSystemCollectionMaxID.Key)
+	assign("system")
+
+	sources := resource.Resource().SegmentAssignStatsManager().GetTopSources(10)
+	assert.ElementsMatch(t, []stats.SourceStats{
+		{Source: "user", Assignments: 1, BinarySize: 10},
+		{Source: "system", Assignments: 1, BinarySize: 10},
+	}, sources)
+	m.Close(ctx)
+}
+
+func TestReserveGrowingSegment(t *testing.T) {
+	initializeTestState(t)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignReservePartitions.Key, "3")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignReservePartitions.Key)
+
+	createSegments := atomic.NewInt32(0)
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, msg message.MutableMessage) (*wal.AppendResult, error) {
+		if msg.MessageType() == message.MessageTypeCreateSegment {
+			createSegments.Inc()
+		}
+		return &wal.AppendResult{
+			MessageID: rmq.NewRmqID(1),
+			TimeTick:  1,
+		}, nil
+	}).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_reserve"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	assign := func(partitionID int64, binarySize uint64) int64 {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID:  1,
+			PartitionID:   partitionID,
+			InsertMetrics: stats.InsertMetrics{Rows: binarySize, BinarySize: binarySize},
+			TimeTick:      tsoutil.GetCurrentTime(),
+		})
+		assert.NoError(t, err)
+		result.Ack()
+		return result.SegmentID
+	}
+
+	// the segment 6000 is 100/1000 filled, nothing is reserved under the fill threshold.
+	assert.Equal(t, int64(6000), assign(3, 500))
+	assert.Zero(t, createSegments.Load())
+
+	// the next growing segment is reserved in background once the fill threshold is passed.
+	assert.Equal(t, int64(6000), assign(3, 200))
+	reservedID := int64(0)
+	assert.Eventually(t, func() bool {
+		snapshot, err := m.SnapshotCollection(1, []int64{3}, 0, 10)
+		assert.NoError(t, err)
+		for _, segment := range snapshot.Partitions[0].Segments {
+			if segment.Origin == streamingpb.SegmentAssignmentOrigin_SEGMENT_ASSIGNMENT_ORIGIN_PRE_ALLOCATED.String() &&
+				segment.State == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING.String() {
+				reservedID = segment.SegmentID
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), createSegments.Load())
+	// the reservation is done only once.
+	assert.Equal(t, int64(6000), assign(3, 100))
+	assert.Equal(t, int32(1), createSegments.Load())
+
+	// the burst across the seal boundary is switched to the reserved segment without any segment allocation.
+	assert.Equal(t, reservedID, assign(3, 300))
+	assert.Equal(t, int32(1), createSegments.Load())
+
+	// the partition without enablement never reserves.
+	assert.Equal(t, int64(3000), assign(2, 800))
+	assert.Equal(t, int32(1), createSegments.Load())
+	m.Close(ctx)
+}
+
+func TestSealQueueMaxWaiting(t *testing.T) {
+	initializeTestState(t)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignSealQueueMaxWaiting.Key, "1")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignSealQueueMaxWaiting.Key)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_seal_queue_max_waiting"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	assign := func(partitionID int64) *AssignSegmentResult {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  partitionID,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick: tsoutil.GetCurrentTime(),
+		})
+		assert.NoError(t, err)
+		return result
+	}
+	mustSeal := func(partitionID int64, segmentID int64) {
+		m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: partitionID, SegmentID: segmentID})
+	}
+	lastState := func(segmentID int64) streamingpb.SegmentAssignmentState {
+		states := savedSegmentStates(segmentID)
+		return states[len(states)-1]
+	}
+
+	// the segment with open txn is never force resolved.
+	assert.Equal(t, int64(6000), assign(3).SegmentID)
+	mustSeal(3, 6000)
+	assert.Equal(t, 1, m.helper.WaitCounter())
+	queueStats := resource.Resource().SegmentAssignStatsManager().GetSealQueueStats()
+	assert.Equal(t, 1, queueStats.WaitingCount)
+	assert.Greater(t, queueStats.MemoryBytes, uint64(0))
+	txnSegment := m.helper.waitForSealed[0]
+	txnSegment.txnSem.Inc()
+
+	// the cap is exceeded, the newer segment without txn is force resolved.
+	second := assign(2)
+	mustSeal(2, second.SegmentID)
+	assert.Equal(t, 1, m.helper.WaitCounter())
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED, lastState(second.SegmentID))
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED, lastState(6000))
+
+	// the txn is done, the oldest segment is force resolved.
+	txnSegment.txnSem.Dec()
+	third := assign(2)
+	assert.NotEqual(t, second.SegmentID, third.SegmentID)
+	mustSeal(2, third.SegmentID)
+	assert.Equal(t, 1, m.helper.WaitCounter())
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED, lastState(6000))
+	assert.Equal(t, policy.PolicyNameForce, txnSegment.SealPolicy())
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED, lastState(third.SegmentID))
+
+	// the acked segment is flushed as usual.
+	third.Ack()
+	m.TryToSealWaitedSegment(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+	assert.Equal(t, stats.SealQueueStats{}, resource.Resource().SegmentAssignStatsManager().GetSealQueueStats())
+	m.Close(ctx)
+}
+
+func TestSealQueueAckBlockedCycles(t *testing.T) {
+	initializeTestState(t)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignSealQueueAckBlockedWarn.Key, "2")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignSealQueueAckBlockedWarn.Key)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_seal_queue_ack_blocked"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  3,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       100,
+			BinarySize: 100,
+		},
+		TimeTick: tsoutil.GetCurrentTime(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6000), result.SegmentID)
+
+	// every seal cycle blocked by the flying ack is counted.
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: 6000})
+	assert.Equal(t, 1, m.helper.WaitCounter())
+	segment := m.helper.waitForSealed[0]
+	cycles := segment.AckBlockedSealCycles()
+	assert.Greater(t, cycles, int32(0))
+	m.TryToSealWaitedSegment(ctx)
+	m.TryToSealWaitedSegment(ctx)
+	assert.Equal(t, cycles+2, segment.AckBlockedSealCycles())
+
+	// the counters are exposed in the snapshot.
+	snapshot, err := m.SnapshotCollection(1, []int64{3}, 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, snapshot.Partitions, 1)
+	assert.Equal(t, int64(cycles+2), snapshot.Partitions[0].AckBlockedSealCycles)
+	found := false
+	for _, s := range snapshot.Partitions[0].Segments {
+		if s.SegmentID == 6000 {
+			found = true
+			assert.True(t, s.PendingSeal)
+			assert.Equal(t, cycles+2, s.AckBlockedSealCycles)
+		}
+	}
+	assert.True(t, found)
+
+	// the counter is reset after the segment is flushed.
+	result.Ack()
+	m.TryToSealWaitedSegment(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+	assert.Zero(t, segment.AckBlockedSealCycles())
+	m.Close(ctx)
+}
+
+// sealedSegmentNotifierFunc is the function adaptor of resource.SealedSegmentNotifier.
+type sealedSegmentNotifierFunc func(ctx context.Context, notification *streamingpb.SealedSegmentNotification) error
+
+func (f sealedSegmentNotifierFunc) NotifySealedSegment(ctx context.Context, notification *streamingpb.SealedSegmentNotification) error {
+	return f(ctx, notification)
+}
+
+func TestSealedSegmentNotify(t *testing.T) {
+	initializeTestState(t)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignSealedNotifyEnabled.Key, "true")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignSealedNotifyEnabled.Key)
+
+	// the coordinator is always unavailable.
+	notified := make(chan *streamingpb.SealedSegmentNotification, 10)
+	resource.Apply(resource.OptSealedSegmentNotifier(sealedSegmentNotifierFunc(
+		func(ctx context.Context, notification *streamingpb.SealedSegmentNotification) error {
+			notified <- notification
+			return errors.New("coordinator is unavailable")
+		})))
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  100,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_sealed_notify", Term: 1}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+	lastState := func(segmentID int64) streamingpb.SegmentAssignmentState {
+		states := savedSegmentStates(segmentID)
+		return states[len(states)-1]
+	}
+
+	// the failure of notification never affects the seal.
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: 6000})
+	assert.True(t, m.SealWaitState().IsNoWait())
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED, lastState(6000))
+	select {
+	case notification := <-notified:
+		assert.Equal(t, "v_sealed_notify", notification.GetPchannel().GetName())
+		assert.Equal(t, int64(1), notification.GetPchannel().GetTerm())
+		assert.Equal(t, int64(1), notification.GetCollectionId())
+		assert.Equal(t, int64(3), notification.GetPartitionId())
+		assert.Equal(t, int64(6000), notification.GetSegmentId())
+		assert.Equal(t, uint64(100), notification.GetSealTimeTick())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the sealed segment is not notified")
+	}
+
+	// the notification is skipped if it's disabled.
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignSealedNotifyEnabled.Key, "false")
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 2, SegmentID: 3000})
+	assert.True(t, m.SealWaitState().IsNoWait())
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED, lastState(3000))
+	assert.Empty(t, notified)
+	m.Close(ctx)
+}
+
+func TestEmergencyUnseal(t *testing.T) {
+	initializeTestState(t)
+
+	failAppend := atomic.NewBool(false)
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, msg message.MutableMessage) (*wal.AppendResult, error) {
+		if failAppend.Load() {
+			return nil, errors.New("mock append failure")
+		}
+		return &wal.AppendResult{
+			MessageID: rmq.NewRmqID(1),
+			TimeTick:  1,
+		}, nil
+	}).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_emergency_unseal"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	assign := func() *AssignSegmentResult {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick: tsoutil.GetCurrentTime(),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6000), result.SegmentID)
+		return result
+	}
+
+	// the segment is sealed by policy but blocked by the flying ack.
+	result := assign()
+	pm, err := m.managers.Get(1, 3)
+	assert.NoError(t, err)
+	segments := pm.CollectOlderThan(tsoutil.GetCurrentTime())
+	assert.Len(t, segments, 1)
+	segment := segments[0]
+	m.helper.AsyncSeal(segments...)
+	m.TryToSealWaitedSegment(ctx)
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED, segment.GetState())
+	assert.True(t, m.helper.Contains(6000))
+
+	// the unseal is not allowed if it's not enabled.
+	assert.ErrorIs(t, m.UnsealSegment(ctx, 6000), ErrUnsealNotAllowed)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignEmergencyUnsealEnabled.Key, "true")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignEmergencyUnsealEnabled.Key)
+	assert.ErrorIs(t, m.UnsealSegment(ctx, 100000), ErrUnsealNotAllowed)
+
+	// the sealed but unflushed segment is reverted back to growing.
+	assert.NoError(t, m.UnsealSegment(ctx, 6000))
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING, segment.GetState())
+	assert.Empty(t, segment.SealPolicy())
+	assert.True(t, m.SealWaitState().IsNoWait())
+	assert.Equal(t, uint64(200), segment.GetStat().Insert.Rows)
+	result.Ack()
+	result = assign()
+
+	// the segment sealed by force can never be unsealed.
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: 6000})
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED, segment.GetState())
+	assert.ErrorIs(t, m.UnsealSegment(ctx, 6000), ErrUnsealNotAllowed)
+	assert.True(t, m.helper.Contains(6000))
+
+	// the segment can never be unsealed after trying to append the flush message, even if the append is failed.
+	failAppend.Store(true)
+	result.Ack()
+	m.TryToSealWaitedSegment(ctx)
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED, segment.GetState())
+	assert.True(t, segment.IsFlushMaybeAppended())
+	assert.True(t, m.helper.Contains(6000))
+	segment.WithSealPolicy(policy.PolicyNameOlderThan)
+	assert.ErrorIs(t, checkUnsealable(segment), ErrUnsealNotAllowed)
+	assert.Panics(t, func() { segment.BeginModification().IntoUnsealed() })
+
+	// the flushed segment can never be unsealed.
+	failAppend.Store(false)
+	m.TryToSealWaitedSegment(ctx)
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED, segment.GetState())
+	assert.ErrorIs(t, m.UnsealSegment(ctx, 6000), ErrUnsealNotAllowed)
+	assert.Panics(t, func() { segment.BeginModification().IntoUnsealed() })
+	m.Close(ctx)
+}
+
+func TestCatalogTimeout(t *testing.T) {
+	initializeTestState(t)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignCatalogAssignTimeout.Key, "10ms")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignCatalogAssignTimeout.Key)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignCatalogSweepTimeout.Key, "10ms")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignCatalogSweepTimeout.Key)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_catalog_timeout"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	// the catalog stalls until the context is done.
+	stall := atomic.NewBool(true)
+	catalog := mock_metastore.NewMockStreamingNodeCataLog(t)
+	catalog.EXPECT().SaveSegmentAssignments(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, pchannel string, metas map[int64]*streamingpb.SegmentAssignmentMeta) error {
+			if stall.Load() {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			return nil
+		})
+	resource.Apply(resource.OptStreamingNodeCatalog(catalog))
+
+	// the assignment path returns a retryable timeout error.
+	_, err = m.AssignSegment(ctx, &AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  1,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       100,
+			BinarySize: 100,
+		},
+		TimeTick: tsoutil.GetCurrentTime(),
+	})
+	assert.ErrorIs(t, err, ErrCatalogTimeout)
+
+	// the sweep is never blocked by the stalled catalog, the segment is kept in the seal queue.
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: 6000})
+	assert.Equal(t, 1, m.helper.WaitCounter())
+	assert.True(t, m.helper.Contains(6000))
+	m.TryToSealWaitedSegment(ctx)
+	assert.Equal(t, 1, m.helper.WaitCounter())
+
+	// the parent cancellation is not a timeout.
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	err = saveSegmentAssignments(cancelledCtx, catalogPathSweep, m.metrics, m.pchannel.Name, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, errors.Is(err, ErrCatalogTimeout))
+
+	// the sweep recovers at the next cycle after the catalog is recovered.
+	stall.Store(false)
+	m.TryToSealWaitedSegment(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+	m.Close(ctx)
+}
+
+func TestSimulatedClock(t *testing.T) {
+	// the create timestamp of segment is persisted in seconds, so the clock is started at a whole second.
+	fakeClock := clock.NewFakeClock(time.Unix(time.Now().Unix(), 0))
+	initializeTestStateWithClock(t, fakeClock)
+	paramtable.Get().Save(paramtable.Get().DataCoordCfg.SegmentMaxLifetime.Key, "3600")
+	defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.SegmentMaxLifetime.Key)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_simulated_clock"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	assign := func(session *txn.TxnSession) *AssignSegmentResult {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick:   fakeClock.CurrentTSO(),
+			TxnSession: session,
+		})
+		assert.NoError(t, err)
+		return result
+	}
+	flushed := func(segmentID int64) bool {
+		return lo.Contains(savedSegmentStates(segmentID), streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED)
+	}
+
+	// the last modified time is stamped by the clock.
+	result := assign(nil)
+	assert.Equal(t, int64(6000), result.SegmentID)
+	result.Ack()
+	assert.Equal(t, fakeClock.Now(), resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(6000).LastModifiedTime)
+
+	// the segment is not sealed before the lifetime is reached.
+	fakeClock.Advance(30 * time.Minute)
+	m.TryToSealSegments(ctx)
+	assert.False(t, flushed(6000))
+
+	// the segment is sealed by lifetime once the clock passes the lifetime.
+	fakeClock.Advance(31 * time.Minute)
+	m.TryToSealSegments(ctx)
+	assert.True(t, flushed(6000))
+	assert.True(t, m.SealWaitState().IsNoWait())
+	summary := resource.Resource().SegmentAssignStatsManager().GetTimeToSealSummary()
+	assert.Equal(t, 1, summary[1].SampleCount)
+	assert.Equal(t, 61*time.Minute, summary[1].P99)
+
+	// the segment written by a txn waits until the keepalive of the txn expires.
+	txnManager := txn.NewTxnManager(types.PChannelInfo{Name: "v_simulated_clock"}, nil)
+	msg := message.NewBeginTxnMessageBuilderV2().
+		WithVChannel("v1").
+		WithHeader(&message.BeginTxnMessageHeader{KeepaliveMilliseconds: 1000}).
+		WithBody(&message.BeginTxnMessageBody{}).
+		MustBuildMutable().
+		WithTimeTick(fakeClock.CurrentTSO())
+	beginTxnMsg, _ := message.AsMutableBeginTxnMessageV2(msg)
+	session, err := txnManager.BeginNewTxn(ctx, beginTxnMsg)
+	assert.NoError(t, err)
+	session.BeginDone()
+
+	result = assign(session)
+	result.Ack()
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: result.SegmentID})
+	state := m.SealWaitState()
+	assert.Equal(t, 1, state.Waiting)
+	assert.Zero(t, state.Ready)
+	assert.Equal(t, map[stats.SealWaitReason]int{stats.SealWaitReasonOpenTxns: 1}, state.Reasons)
+	assert.Zero(t, state.OldestBlockedAge)
+	// the segment only blocked by the open txn is released by the txn done, the wake is useless.
+	assert.False(t, state.IsWakeUseful())
+
+	fakeClock.Advance(500 * time.Millisecond)
+	txnManager.CleanupTxnUntil(fakeClock.CurrentTSO())
+	m.TryToSealWaitedSegment(ctx)
+	state = m.SealWaitState()
+	assert.Equal(t, 1, state.Waiting)
+	assert.Equal(t, 500*time.Millisecond, state.OldestBlockedAge)
+	assert.False(t, flushed(result.SegmentID))
+
+	fakeClock.Advance(time.Second)
+	txnManager.CleanupTxnUntil(fakeClock.CurrentTSO())
+	m.TryToSealWaitedSegment(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+	assert.True(t, flushed(result.SegmentID))
+	m.Close(ctx)
+}
+
+func TestTxnNonBlockingSeal(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Unix(time.Now().Unix(), 0))
+	initializeTestStateWithClock(t, fakeClock)
+	paramtable.Get().Save(paramtable.Get().DataCoordCfg.SegmentMaxLifetime.Key, "60")
+	defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.SegmentMaxLifetime.Key)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_txn_non_blocking_seal"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+	txnManager := txn.NewTxnManager(types.PChannelInfo{Name: "v_txn_non_blocking_seal"}, nil)
+
+	beginTxn := func() *txn.TxnSession {
+		msg := message.NewBeginTxnMessageBuilderV2().
+			WithVChannel("v1").
+			WithHeader(&message.BeginTxnMessageHeader{KeepaliveMilliseconds: 3600 * 1000}).
+			WithBody(&message.BeginTxnMessageBody{}).
+			MustBuildMutable().
+			WithTimeTick(fakeClock.CurrentTSO())
+		beginTxnMsg, _ := message.AsMutableBeginTxnMessageV2(msg)
+		session, err := txnManager.BeginNewTxn(ctx, beginTxnMsg)
+		assert.NoError(t, err)
+		session.BeginDone()
+		return session
+	}
+	assign := func(session *txn.TxnSession) int64 {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick:   fakeClock.CurrentTSO(),
+			TxnSession: session,
+		})
+		assert.NoError(t, err)
+		result.Ack()
+		return result.SegmentID
+	}
+	flushed := func(segmentID int64) bool {
+		return lo.Contains(savedSegmentStates(segmentID), streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED)
+	}
+
+	// by default, the segment written by an open txn is not flushed by the policy sweep until the txn is done.
+	session := beginTxn()
+	assert.Equal(t, int64(6000), assign(session))
+	fakeClock.Advance(2 * time.Minute)
+	m.TryToSealSegments(ctx)
+	assert.NotZero(t, m.SealWaitState().Reasons[stats.SealWaitReasonOpenTxns])
+	assert.False(t, flushed(6000))
+	assert.NoError(t, session.RequestCommitAndWait(ctx, fakeClock.CurrentTSO()))
+	session.CommitDone()
+	m.TryToSealWaitedSegment(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+	assert.True(t, flushed(6000))
+
+	// the segment of a txn non-blocking seal collection is flushed by the policy sweep even if the txn is still open,
+	// the txn spans the seal boundary.
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignTxnNonBlockingSealCollections.Key, "1")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignTxnNonBlockingSealCollections.Key)
+	session = beginTxn()
+	first := assign(session)
+	fakeClock.Advance(2 * time.Minute)
+	m.TryToSealSegments(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+	assert.True(t, flushed(first))
+	second := assign(session)
+	assert.NotEqual(t, first, second)
+	rows, binarySize, segments := session.WriteSummary()
+	assert.Equal(t, uint64(200), rows)
+	assert.Equal(t, uint64(200), binarySize)
+	assert.Equal(t, []int64{first, second}, lo.Map(segments, func(w *message.TxnSegmentWrite, _ int) int64 { return w.GetSegmentId() }))
+	m.Close(ctx)
+}
+
+func TestTxnDoneSeal(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_txn_done_seal"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+	txnManager := txn.NewTxnManager(types.PChannelInfo{Name: "v_txn_done_seal"}, nil)
+	txnManager.BindTxnDoneListener(m)
+
+	msg := message.NewBeginTxnMessageBuilderV2().
+		WithVChannel("v1").
+		WithHeader(&message.BeginTxnMessageHeader{KeepaliveMilliseconds: 3600 * 1000}).
+		WithBody(&message.BeginTxnMessageBody{}).
+		MustBuildMutable().
+		WithTimeTick(tsoutil.GetCurrentTime())
+	beginTxnMsg, _ := message.AsMutableBeginTxnMessageV2(msg)
+	session, err := txnManager.BeginNewTxn(ctx, beginTxnMsg)
+	assert.NoError(t, err)
+	session.BeginDone()
+
+	result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  3,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       100,
+			BinarySize: 100,
+		},
+		TimeTick:   tsoutil.GetCurrentTime(),
+		TxnSession: session,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6000), result.SegmentID)
+	result.Ack()
+
+	// the seal is blocked by the open txn.
+	m.MustSealSegments(ctx, stats.SegmentBelongs{
+		CollectionID: 1,
+		VChannel:     "v1",
+		PartitionID:  3,
+		PChannel:     "v_txn_done_seal",
+		SegmentID:    6000,
+	})
+	assert.NotZero(t, m.SealWaitState().Reasons[stats.SealWaitReasonOpenTxns])
+	assert.Equal(t, int32(1), m.helper.waitForSealed[0].TxnSem())
+
+	// the seal completes right after the txn is committed, without waiting for the next sweep.
+	assert.NoError(t, session.RequestCommitAndWait(ctx, tsoutil.GetCurrentTime()))
+	session.CommitDone()
+	assert.Eventually(t, func() bool {
+		return m.SealWaitState().IsNoWait() && lo.Contains(savedSegmentStates(6000), streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED)
+	}, 5*time.Second, 10*time.Millisecond)
+	m.Close(ctx)
+}
+
+func TestTxnCommitFence(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Unix(time.Now().Unix(), 0))
+	initializeTestStateWithClock(t, fakeClock)
+	// the seal of a txn blocking seal collection waits for the txn, so only the non-blocking one may conflict with the fence.
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignTxnNonBlockingSealCollections.Key, "1")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignTxnNonBlockingSealCollections.Key)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_txn_commit_fence"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+	txnManager := txn.NewTxnManager(types.PChannelInfo{Name: "v_txn_commit_fence"}, nil)
+	txnManager.BindCollectionFenceView(m)
+
+	beginTxn := func() *txn.TxnSession {
+		msg := message.NewBeginTxnMessageBuilderV2().
+			WithVChannel("v1").
+			WithHeader(&message.BeginTxnMessageHeader{KeepaliveMilliseconds: 3600 * 1000}).
+			WithBody(&message.BeginTxnMessageBody{}).
+			MustBuildMutable().
+			WithTimeTick(fakeClock.CurrentTSO())
+		beginTxnMsg, _ := message.AsMutableBeginTxnMessageV2(msg)
+		session, err := txnManager.BeginNewTxn(ctx, beginTxnMsg)
+		assert.NoError(t, err)
+		session.BeginDone()
+		fakeClock.Advance(time.Second)
+		return session
+	}
+	assignAt := func(session *txn.TxnSession, timetick uint64) error {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick:   timetick,
+			TxnSession: session,
+		})
+		if err != nil {
+			return err
+		}
+		result.Ack()
+		fakeClock.Advance(time.Second)
+		return nil
+	}
+	fence := func() uint64 {
+		timetick := fakeClock.CurrentTSO()
+		_, err := m.SealAndFenceSegmentUntil(ctx, 1, timetick)
+		assert.NoError(t, err)
+		fakeClock.Advance(time.Second)
+		return timetick
+	}
+	commit := func(session *txn.TxnSession) error {
+		if err := txnManager.ValidateCommit(session); err != nil {
+			return err
+		}
+		assert.NoError(t, session.RequestCommitAndWait(ctx, fakeClock.CurrentTSO()))
+		session.CommitDone()
+		return nil
+	}
+
+	// assign before the fence and commit after it, the commit is delayed until the flush of the fence is appended.
+	session := beginTxn()
+	assert.NoError(t, assignAt(session, fakeClock.CurrentTSO()))
+	flushTs := fence()
+	assert.ErrorIs(t, commit(session), txn.ErrTxnCommitDelayed)
+	assert.ErrorIs(t, commit(session), txn.ErrTxnCommitDelayed)
+	m.ObserveManualFlush(1, flushTs, nil)
+	assert.NoError(t, commit(session))
+
+	// the fenced commit is failed with a typed error by the fail policy.
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.TxnFencedCommitPolicy.Key, txn.FencedCommitPolicyFail)
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.TxnFencedCommitPolicy.Key)
+	session = beginTxn()
+	assert.NoError(t, assignAt(session, fakeClock.CurrentTSO()))
+	fence()
+	err = commit(session)
+	assert.Error(t, err)
+	assert.True(t, status.AsStreamingError(err).IsTxnFenced())
+	assert.NoError(t, session.RequestRollback(ctx, fakeClock.CurrentTSO()))
+	session.RollbackDone()
+
+	// fence before the assign, the assign older than the fence is rejected and the later one never conflicts.
+	session = beginTxn()
+	staleTimeTick := fakeClock.CurrentTSO()
+	fakeClock.Advance(time.Second)
+	fence()
+	assert.ErrorIs(t, assignAt(session, staleTimeTick), ErrFencedAssign)
+	assert.NoError(t, assignAt(session, fakeClock.CurrentTSO()))
+	assert.NoError(t, commit(session))
+
+	// the txn blocking seal collection never conflicts, the fence waits for the txn done.
+	paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignTxnNonBlockingSealCollections.Key)
+	fencedTimeTick, flushPending := m.GetCollectionFence(1)
+	assert.Zero(t, fencedTimeTick)
+	assert.False(t, flushPending)
+	m.Close(ctx)
+}
+
+func TestAllocSegmentMismatch(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_alloc_mismatch"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	// applyMisbehavingCoord makes the coordinator return the misbehaving responses before the echoed one.
+	applyMisbehavingCoord := func(misbehaviors ...func(asr *datapb.AllocSegmentRequest) *datapb.SegmentInfo) *atomic.Int32 {
+		calls := atomic.NewInt32(0)
+		c := idalloc.NewMockRootCoordClient(t)
+		c.EXPECT().AllocSegment(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, asr *datapb.AllocSegmentRequest, co ...grpc.CallOption) (*datapb.AllocSegmentResponse, error) {
+			n := int(calls.Inc())
+			info := &datapb.SegmentInfo{
+				ID:           asr.GetSegmentId(),
+				CollectionID: asr.GetCollectionId(),
+				PartitionID:  asr.GetPartitionId(),
+			}
+			if n <= len(misbehaviors) {
+				info = misbehaviors[n-1](asr)
+			}
+			return &datapb.AllocSegmentResponse{SegmentInfo: info, Status: merr.Success()}, nil
+		}).Maybe()
+		fc := syncutil.NewFuture[internaltypes.MixCoordClient]()
+		fc.Set(c)
+		resource.Apply(resource.OptMixCoordClient(fc))
+		return calls
+	}
+	assign := func() error {
+		_, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  1,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick: tsoutil.GetCurrentTime(),
+		})
+		return err
+	}
+
+	misbehaviors := map[string]func(asr *datapb.AllocSegmentRequest) *datapb.SegmentInfo{
+		allocMismatchMissingSegmentInfo: func(asr *datapb.AllocSegmentRequest) *datapb.SegmentInfo {
+			return nil
+		},
+		allocMismatchZeroSegmentID: func(asr *datapb.AllocSegmentRequest) *datapb.SegmentInfo {
+			return &datapb.SegmentInfo{CollectionID: asr.GetCollectionId(), PartitionID: asr.GetPartitionId()}
+		},
+		allocMismatchSegmentID: func(asr *datapb.AllocSegmentRequest) *datapb.SegmentInfo {
+			return &datapb.SegmentInfo{ID: asr.GetSegmentId() + 1, CollectionID: asr.GetCollectionId(), PartitionID: asr.GetPartitionId()}
+		},
+		allocMismatchCollectionID: func(asr *datapb.AllocSegmentRequest) *datapb.SegmentInfo {
+			return &datapb.SegmentInfo{ID: asr.GetSegmentId(), CollectionID: asr.GetCollectionId() + 1, PartitionID: asr.GetPartitionId()}
+		},
+		allocMismatchPartitionID: func(asr *datapb.AllocSegmentRequest) *datapb.SegmentInfo {
+			return &datapb.SegmentInfo{ID: asr.GetSegmentId(), CollectionID: asr.GetCollectionId(), PartitionID: asr.GetPartitionId() + 1}
+		},
+	}
+	for reason, misbehavior := range misbehaviors {
+		// the mismatch is retried once, and surfaced if the retry mismatches again.
+		calls := applyMisbehavingCoord(misbehavior, misbehavior)
+		err := assign()
+		assert.ErrorIs(t, err, ErrAllocSegmentMismatch, reason)
+		var mismatch *AllocSegmentMismatchError
+		assert.True(t, errors.As(err, &mismatch), reason)
+		assert.Equal(t, reason, mismatch.Reason)
+		assert.Equal(t, int32(maxAllocSegmentAttempts), calls.Load(), reason)
+		// the segment is never registered as growing by the mismatched response.
+		pm, err := m.managers.Get(1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING, pm.segments[0].GetState(), reason)
+	}
+
+	// the mismatch is recovered by the retry.
+	calls := applyMisbehavingCoord(misbehaviors[allocMismatchPartitionID])
+	assert.NoError(t, assign())
+	assert.Equal(t, int32(2), calls.Load())
+	m.Close(ctx)
+}
+
+func TestValidateAllocSegmentResponse(t *testing.T) {
+	req := &datapb.AllocSegmentRequest{CollectionId: 1, PartitionId: 2, SegmentId: 1000}
+	known := func(segmentID int64) bool { return segmentID == 2000 }
+
+	assert.NoError(t, validateAllocSegmentResponse(req, &datapb.AllocSegmentResponse{
+		SegmentInfo: &datapb.SegmentInfo{ID: 1000, CollectionID: 1, PartitionID: 2},
+	}, known))
+
+	// the returned segment id is another segment already known by the manager.
+	err := validateAllocSegmentResponse(req, &datapb.AllocSegmentResponse{
+		SegmentInfo: &datapb.SegmentInfo{ID: 2000, CollectionID: 1, PartitionID: 2},
+	}, known)
+	assert.ErrorIs(t, err, ErrAllocSegmentMismatch)
+	var mismatch *AllocSegmentMismatchError
+	assert.True(t, errors.As(err, &mismatch))
+	assert.Equal(t, allocMismatchDuplicateSegmentID, mismatch.Reason)
+	assert.Equal(t, int64(1000), mismatch.Expected)
+	assert.Equal(t, int64(2000), mismatch.Actual)
+}
+
+func TestSegmentState(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_segment_state"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	// the recovered segments are indexed.
+	for segmentID, expected := range map[int64]streamingpb.SegmentAssignmentState{
+		1000: streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING,
+		3000: streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+		4000: streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED,
+		6000: streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+	} {
+		state, ok := m.SegmentState(segmentID)
+		assert.True(t, ok)
+		assert.Equal(t, expected, state)
+		state, ok = resource.Resource().SegmentStateView().SegmentState(segmentID)
+		assert.True(t, ok)
+		assert.Equal(t, expected, state)
+	}
+	_, ok := m.SegmentState(7000)
+	assert.False(t, ok)
+
+	// the state is updated when the segment is sealed and flushed.
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: 6000})
+	_, ok = m.SegmentState(6000)
+	assert.False(t, ok)
+
+	// the segments are removed from the index after the manager is closed.
+	m.Close(ctx)
+	_, ok = resource.Resource().SegmentStateView().SegmentState(3000)
+	assert.False(t, ok)
+}
+
+func TestHighPriorityAssign(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Unix(time.Now().Unix(), 0))
+	initializeTestStateWithClock(t, fakeClock)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_high_priority"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	assign := func(highPriority bool) *AssignSegmentResult {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick:     fakeClock.CurrentTSO(),
+			HighPriority: highPriority,
+		})
+		assert.NoError(t, err)
+		result.Ack()
+		return result
+	}
+
+	// the bulk insert is assigned on the normal segment, the high priority insert on the dedicated segment.
+	assert.Equal(t, int64(6000), assign(false).SegmentID)
+	priorityID := assign(true).SegmentID
+	assert.NotEqual(t, int64(6000), priorityID)
+	assert.Equal(t, priorityID, assign(true).SegmentID)
+	assert.Equal(t, int64(6000), assign(false).SegmentID)
+
+	priorityStat := resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(priorityID)
+	assert.True(t, priorityStat.HighPriority)
+	normalStat := resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(6000)
+	assert.False(t, normalStat.HighPriority)
+	assert.Equal(t, policy.GetSegmentMaxBinarySizeLimit()/4, priorityStat.MaxBinarySize)
+	assert.Equal(t, stats.InsertMetrics{Rows: 200, BinarySize: 200}, resource.Resource().SegmentAssignStatsManager().GetHighPriorityStats())
+
+	// the collection configured as high priority routes all inserts into the high priority segments.
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignHighPriorityCollections.Key, "1")
+	assert.Equal(t, priorityID, assign(false).SegmentID)
+	paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignHighPriorityCollections.Key)
+
+	// the introspection shows the dedicated segment.
+	snapshot, err := m.SnapshotCollection(1, []int64{3}, 0, 10)
+	assert.NoError(t, err)
+	for _, segment := range snapshot.Partitions[0].Segments {
+		assert.Equal(t, segment.SegmentID == priorityID, segment.HighPriority)
+	}
+
+	// the high priority segment is sealed on its own smaller lifetime.
+	fakeClock.Advance(2 * time.Minute)
+	m.TryToSealSegments(ctx)
+	assert.Contains(t, savedSegmentStates(priorityID), streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED)
+	assert.NotContains(t, savedSegmentStates(6000), streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED)
+	assert.Equal(t, stats.InsertMetrics{}, resource.Resource().SegmentAssignStatsManager().GetHighPriorityStats())
+	m.Close(ctx)
+}
+
+func TestSmallCollectionMaxBinarySize(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Unix(time.Now().Unix(), 0))
+	initializeTestStateWithClock(t, fakeClock)
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.WALSegmentAssignSmallCollectionCollections.Key, "1")
+	params.Save(params.StreamingCfg.WALSegmentAssignSmallCollectionMaxLifetime.Key, "100s")
+	params.Save(params.StreamingCfg.WALSegmentAssignSmallCollectionMinSize.Key, "0.001")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignSmallCollectionCollections.Key)
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignSmallCollectionMaxLifetime.Key)
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignSmallCollectionMinSize.Key)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_small_collection"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	assign := func(binarySize uint64) int64 {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       1,
+				BinarySize: binarySize,
+			},
+			TimeTick: fakeClock.CurrentTSO(),
+		})
+		assert.NoError(t, err)
+		result.Ack()
+		return result.SegmentID
+	}
+	maxBinarySize := func(segmentID int64) uint64 {
+		return resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(segmentID).MaxBinarySize
+	}
+
+	// the first sweep only records the baseline, so the new segment is capped by the min size.
+	m.TryToSealSegments(ctx)
+	assert.Equal(t, uint64(1048), m.managers.ingests[1].MaxBinarySize())
+	for i := 0; i < 9; i++ {
+		assert.Equal(t, int64(6000), assign(100))
+	}
+	smallID := assign(100)
+	assert.NotEqual(t, int64(6000), smallID)
+	assert.Equal(t, uint64(1048), maxBinarySize(smallID))
+	assert.Equal(t, uint64(1000), m.managers.ingests[1].assigned.Load())
+
+	// the override follows the ingest rate, 100 bytes per second smoothed into 50, within the lifetime of 100s.
+	fakeClock.Advance(10 * time.Second)
+	m.TryToSealSegments(ctx)
+	assert.Equal(t, uint64(5000), m.managers.ingests[1].MaxBinarySize())
+	largerID := assign(2000)
+	assert.NotEqual(t, smallID, largerID)
+	assert.Equal(t, uint64(5000), maxBinarySize(largerID))
+
+	// the segments of the small collection are sealed by its own lifetime.
+	fakeClock.Advance(101 * time.Second)
+	m.TryToSealSegments(ctx)
+	for _, segmentID := range []int64{6000, smallID, largerID} {
+		assert.Contains(t, savedSegmentStates(segmentID), streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED)
+	}
+
+	// the override is cleared once the collection is not a small collection anymore.
+	params.Reset(params.StreamingCfg.WALSegmentAssignSmallCollectionCollections.Key)
+	m.TryToSealSegments(ctx)
+	assert.Zero(t, m.managers.ingests[1].MaxBinarySize())
+	m.Close(ctx)
+}
+
+func TestRowSizeCheck(t *testing.T) {
+	initializeTestState(t)
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.WALSegmentAssignRowSizeCheckMinRows.Key, "10")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignRowSizeCheckMinRows.Key)
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignRowSizeCheckAutoBump.Key)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_row_size_check"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	assign := func(partitionID int64, binarySize uint64) int64 {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  partitionID,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       1,
+				BinarySize: binarySize,
+			},
+			TimeTick: tsoutil.GetCurrentTime(),
+		})
+		assert.NoError(t, err)
+		result.Ack()
+		return result.SegmentID
+	}
+	rowSizeCheck := func() *RowSizeCheckSnapshot {
+		snapshot, err := m.SnapshotCollection(1, nil, 0, 10)
+		assert.NoError(t, err)
+		return snapshot.RowSizeCheck
+	}
+	assert.Nil(t, rowSizeCheck())
+
+	// the 1MB segment can't hold 10 rows of 200KB, it's only reported if the auto bump is disabled.
+	assign(3, 200000)
+	assert.Equal(t, &RowSizeCheckSnapshot{
+		AvgRowSize:         200000,
+		RequiredBinarySize: 2000000,
+		MaxBinarySizeLimit: 1024 * 1024,
+		Undersized:         true,
+	}, rowSizeCheck())
+
+	// the floor is bumped and rounded up to MB once the auto bump is enabled.
+	params.Save(params.StreamingCfg.WALSegmentAssignRowSizeCheckAutoBump.Key, "true")
+	assign(3, 200000)
+	assert.Equal(t, uint64(2*1024*1024), rowSizeCheck().BumpedFloor)
+	resp, err := m.PreviewAssign(ctx, &AssignPreviewRequest{
+		CollectionID:  1,
+		PartitionID:   2,
+		InsertMetrics: stats.InsertMetrics{Rows: 1, BinarySize: 100},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2*1024*1024), resp.GetSegmentMaxBinarySize())
+
+	// the new growing segment is created with the bumped floor, so the large row is not rejected as too large insert.
+	segmentID := assign(2, 1400000)
+	assert.Equal(t, uint64(6*1024*1024), resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(segmentID).MaxBinarySize)
+
+	// the floor is kept after the check passes again.
+	params.Save(params.StreamingCfg.WALSegmentAssignRowSizeCheckMinRows.Key, "0")
+	assign(3, 100)
+	snapshot := rowSizeCheck()
+	assert.False(t, snapshot.Undersized)
+	assert.Equal(t, uint64(6*1024*1024), snapshot.BumpedFloor)
+	m.Close(ctx)
+}
+
+func TestL0SegmentAssign(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Unix(time.Now().Unix(), 0))
+	initializeTestStateWithClock(t, fakeClock)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_l0"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	assignDelete := func(rows uint64) *AssignDeleteSegmentResult {
+		result, err := m.AssignDeleteSegment(ctx, &AssignDeleteSegmentRequest{
+			CollectionID:  1,
+			PartitionID:   3,
+			DeleteMetrics: stats.DeleteMetrics{Rows: rows, BinarySize: rows * 10},
+			TimeTick:      fakeClock.CurrentTSO(),
+		})
+		assert.NoError(t, err)
+		result.Ack()
+		return result
+	}
+
+	// the deletes are assigned on the L0 segment, the inserts are never assigned on it.
+	l0ID := assignDelete(100).SegmentID
+	assert.NotEqual(t, int64(6000), l0ID)
+	assert.Equal(t, l0ID, assignDelete(100).SegmentID)
+	result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+		CollectionID:  1,
+		PartitionID:   3,
+		InsertMetrics: stats.InsertMetrics{Rows: 100, BinarySize: 100},
+		TimeTick:      fakeClock.CurrentTSO(),
+	})
+	assert.NoError(t, err)
+	result.Ack()
+	assert.Equal(t, int64(6000), result.SegmentID)
+	assert.Nil(t, resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(l0ID))
+
+	snapshot, err := m.SnapshotCollection(1, []int64{3}, 0, 10)
+	assert.NoError(t, err)
+	for _, segment := range snapshot.Partitions[0].Segments {
+		if segment.SegmentID == l0ID {
+			assert.Equal(t, streamingpb.SegmentAssignmentLevel_SEGMENT_ASSIGNMENT_LEVEL_L0.String(), segment.Level)
+			assert.Equal(t, uint64(200), segment.DeletedRows)
+			assert.Equal(t, uint64(2000), segment.DeletedBinarySize)
+		}
+	}
+
+	// the L0 segment is sealed by the count of deletes but never by size.
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignL0MaxDeletes.Key, "300")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignL0MaxDeletes.Key)
+	assignDelete(100)
+	m.TryToSealSegments(ctx)
+	assert.Contains(t, savedSegmentStates(l0ID), streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED)
+	assert.NotContains(t, savedSegmentStates(6000), streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED)
+
+	// the L0 segment is sealed by its lifetime.
+	l0ID = assignDelete(1).SegmentID
+	fakeClock.Advance(11 * time.Minute)
+	m.TryToSealSegments(ctx)
+	assert.Contains(t, savedSegmentStates(l0ID), streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED)
+
+	// the L0 segment is fenced and flushed by the manual flush, but it's not reported to datacoord.
+	l0ID = assignDelete(1).SegmentID
+	fenceTimeTick := fakeClock.CurrentTSO()
+	segmentIDs, err := m.SealAndFenceSegmentUntil(ctx, 1, fenceTimeTick)
+	assert.NoError(t, err)
+	assert.NotContains(t, segmentIDs, l0ID)
+	assert.Contains(t, savedSegmentStates(l0ID), streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED)
+	_, err = m.AssignDeleteSegment(ctx, &AssignDeleteSegmentRequest{
+		CollectionID:  1,
+		PartitionID:   3,
+		DeleteMetrics: stats.DeleteMetrics{Rows: 1, BinarySize: 10},
+		TimeTick:      fenceTimeTick,
+	})
+	assert.ErrorIs(t, err, ErrFencedAssign)
+	m.Close(ctx)
+}
+
+func TestRecoverL0Segment(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	pchannel := types.PChannelInfo{Name: "v_l0_recover"}
+	metrics := metricsutil.NewSegmentAssignMetrics(pchannel.Name)
+	defer metrics.Close()
+	watcher := newAssignmentWatcher(defaultAssignmentEventRingSize)
+	managers, waitForSealed := buildNewPartitionManagers(f, pchannel, []*streamingpb.SegmentAssignmentMeta{
+		{
+			CollectionId: 1,
+			PartitionId:  2,
+			SegmentId:    7000,
+			Vchannel:     "v1",
+			State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+			Level:        streamingpb.SegmentAssignmentLevel_SEGMENT_ASSIGNMENT_LEVEL_L0,
+			Stat:         newStat(100, 0),
+		},
+		{
+			CollectionId: 1,
+			PartitionId:  2,
+			SegmentId:    8000,
+			Vchannel:     "v1",
+			State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+			Stat:         newStat(100, 1000),
+		},
+	}, []*rootcoordpb.CollectionInfoOnPChannel{
+		{
+			CollectionId: 1,
+			Vchannel:     "v1",
+			Partitions:   []*rootcoordpb.PartitionInfoOnPChannel{{PartitionId: 2}},
+		},
+	}, nil, metrics, watcher, newSealRandom(1), newRecoverySummary(pchannel.Name))
+	assert.Empty(t, waitForSealed)
+
+	// both tracks are rebuilt, the L0 segment is not registered into the stats manager.
+	assert.Nil(t, resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(7000))
+	assert.NotNil(t, resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(8000))
+
+	pm, err := managers.Get(1, 2)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	deleteResult, err := pm.AssignDeleteSegment(ctx, &AssignDeleteSegmentRequest{
+		CollectionID:  1,
+		PartitionID:   2,
+		DeleteMetrics: stats.DeleteMetrics{Rows: 1, BinarySize: 10},
+		TimeTick:      100,
+	})
+	assert.NoError(t, err)
+	deleteResult.Ack()
+	assert.Equal(t, int64(7000), deleteResult.SegmentID)
+
+	insertResult, err := pm.AssignSegment(ctx, &AssignSegmentRequest{
+		CollectionID:  1,
+		PartitionID:   2,
+		InsertMetrics: stats.InsertMetrics{Rows: 1, BinarySize: 10},
+		TimeTick:      100,
+	})
+	assert.NoError(t, err)
+	insertResult.Ack()
+	assert.Equal(t, int64(8000), insertResult.SegmentID)
+
+	for _, segment := range pm.CollectAllSegmentsAndClear() {
+		if segment.GetSegmentID() == 7000 {
+			assert.True(t, segment.IsL0())
+			assert.Equal(t, uint64(101), segment.GetDeleteStat().Delete.Rows)
+			assert.Equal(t, streamingpb.SegmentAssignmentLevel_SEGMENT_ASSIGNMENT_LEVEL_L0, segment.Snapshot().GetLevel())
+		}
+	}
+}
+
+func TestSealGracePeriod(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Unix(time.Now().Unix(), 0))
+	initializeTestStateWithClock(t, fakeClock)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignSealGracePeriod.Key, "10s")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignSealGracePeriod.Key)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_seal_grace_period"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	flushed := func(segmentID int64) bool {
+		return lo.Contains(savedSegmentStates(segmentID), streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED)
+	}
+
+	// the policy-driven seal of a young segment is deferred.
+	err = resource.Resource().SegmentAssignStatsManager().UpdateOnSync(6000, stats.SyncOperationMetrics{
+		BinLogCounterIncr: 100,
+	})
+	assert.NoError(t, err)
+	m.TryToSealSegments(ctx)
+	assert.False(t, flushed(2000))
+	assert.False(t, flushed(6000))
+
+	// the segment is sealed once the grace period is passed.
+	fakeClock.Advance(11 * time.Second)
+	m.TryToSealSegments(ctx)
+	assert.True(t, flushed(2000))
+	assert.True(t, flushed(6000))
+
+	// a new segment is created in the grace period.
+	result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  3,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       100,
+			BinarySize: 100,
+		},
+		TimeTick: fakeClock.CurrentTSO(),
+	})
+	assert.NoError(t, err)
+	result.Ack()
+	segmentID := result.SegmentID
+	assert.NotEqual(t, int64(6000), segmentID)
+	err = resource.Resource().SegmentAssignStatsManager().UpdateOnSync(segmentID, stats.SyncOperationMetrics{
+		BinLogCounterIncr: 100,
+	})
+	assert.NoError(t, err)
+	m.TryToSealSegments(ctx)
+	assert.False(t, flushed(segmentID))
+
+	// the manual flush is never deferred by the grace period.
+	fakeClock.Advance(time.Second)
+	segmentIDs, err := m.SealAndFenceSegmentUntil(ctx, 1, fakeClock.CurrentTSO())
+	assert.NoError(t, err)
+	assert.Contains(t, segmentIDs, segmentID)
+	assert.True(t, flushed(segmentID))
+	m.Close(ctx)
+}
+
+func TestOwnershipTerm(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	// the metas written by term 0 are adopted by the new owner.
+	pchannel := types.PChannelInfo{Name: "v_ownership_term", Term: 2}
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), pchannel, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  1,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       100,
+			BinarySize: 100,
+		},
+		TimeTick: tsoutil.GetCurrentTime(),
+	})
+	assert.NoError(t, err)
+	result.Ack()
+	m.Close(ctx)
+
+	// every write is stamped with the term of the owner.
+	catalog := resource.Resource().StreamingNodeCatalog().(*mock_metastore.MockStreamingNodeCataLog)
+	written := make([]*streamingpb.SegmentAssignmentMeta, 0)
+	for _, call := range catalog.Calls {
+		if call.Method != "SaveSegmentAssignments" || call.Arguments.Get(1).(string) != pchannel.Name {
+			continue
+		}
+		for _, info := range call.Arguments.Get(2).(map[int64]*streamingpb.SegmentAssignmentMeta) {
+			assert.Equal(t, pchannel.Term, info.GetTerm())
+			written = append(written, info)
+		}
+	}
+	assert.NotEmpty(t, written)
+
+	// a stale owner interleaved with the new owner refuses to start.
+	err = validateOwnership(types.PChannelInfo{Name: pchannel.Name, Term: 1}, written)
+	assert.ErrorIs(t, err, ErrStaleOwnership)
+	assert.NoError(t, validateOwnership(types.PChannelInfo{Name: pchannel.Name, Term: 3}, written))
+}
+
+func TestCompactAssignmentMeta(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	pchannel := types.PChannelInfo{Name: "v_compact_meta", Term: 2}
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), pchannel, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	// the partition 3 is removed from the manager, but it's still known by the coordinator.
+	assert.NoError(t, m.RemovePartition(ctx, 1, 3))
+	assert.NoError(t, m.WaitUntilNoWaitSeal(ctx))
+
+	catalog := resource.Resource().StreamingNodeCatalog().(*mock_metastore.MockStreamingNodeCataLog)
+	catalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).Unset()
+	catalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).Return([]*streamingpb.SegmentAssignmentMeta{
+		// known by the manager.
+		{CollectionId: 1, PartitionId: 2, SegmentId: 2000, State: streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING, Term: 1},
+		// dropped partition written by the older term.
+		{CollectionId: 1, PartitionId: 4, SegmentId: 7000, State: streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING, Term: 1},
+		{CollectionId: 2, PartitionId: 5, SegmentId: 7001, State: streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED},
+		// dropped partition written by current or newer term may be written by a concurrent writer.
+		{CollectionId: 1, PartitionId: 4, SegmentId: 7002, State: streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING, Term: 2},
+		{CollectionId: 1, PartitionId: 4, SegmentId: 7003, State: streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING, Term: 3},
+		// not confirmed dropped by the coordinator.
+		{CollectionId: 1, PartitionId: 3, SegmentId: 7004, State: streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING, Term: 1},
+	}, nil)
+
+	removed, err := m.CompactAssignmentMeta(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	for _, segmentID := range []int64{7000, 7001} {
+		assert.Equal(t, []streamingpb.SegmentAssignmentState{streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED}, savedSegmentStates(segmentID))
+	}
+	for _, segmentID := range []int64{7002, 7003, 7004} {
+		assert.NotContains(t, savedSegmentStates(segmentID), streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED)
+	}
+
+	// the compaction is not allowed after the manager is closed.
+	m.Close(ctx)
+	_, err = m.CompactAssignmentMeta(ctx)
+	assert.Error(t, err)
+}
+
+func TestTimeToSeal(t *testing.T) {
+	base := time.UnixMilli(time.Now().UnixMilli())
+
+	// no seal decision.
+	segment := &segmentAllocManager{minAssignedTimeTick: tsoutil.ComposeTSByTime(base.Add(-10*time.Minute), 0)}
+	_, ok := segment.TimeToSeal()
+	assert.False(t, ok)
+
+	// the first seal decision is kept.
+	segment.sealDecision = base
+	segment.WithSealPolicy(policy.PolicyNameFenced)
+	d, ok := segment.TimeToSeal()
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Minute, d)
+
+	// the clock skew is clamped.
+	segment.minAssignedTimeTick = tsoutil.ComposeTSByTime(base.Add(time.Second), 10)
+	d, ok = segment.TimeToSeal()
+	assert.True(t, ok)
+	assert.Zero(t, d)
+
+	// no insert is assigned.
+	segment = (&segmentAllocManager{}).WithSealPolicy(policy.PolicyNameForce)
+	_, ok = segment.TimeToSeal()
+	assert.False(t, ok)
+}
+
+func TestObserveTimeToSeal(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_time_to_seal"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	assign := func(partitionID int64, tt uint64) {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  partitionID,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick: tt,
+		})
+		assert.NoError(t, err)
+		result.Ack()
+	}
+	assign(2, tsoutil.ComposeTSByTime(time.Now().Add(-time.Hour), 0))
+	assign(3, tsoutil.ComposeTSByTime(time.Now().Add(-5*time.Minute), 0))
+
+	// the segment sealed by drop partition is excluded.
+	assert.NoError(t, m.RemovePartition(ctx, 1, 2))
+	assert.Empty(t, resource.Resource().SegmentAssignStatsManager().GetTimeToSealSummary())
+
+	// only the segment with known first insert is observed.
+	_, err = m.SealAndFenceSegmentUntil(ctx, 1, tsoutil.GetCurrentTime())
+	assert.NoError(t, err)
+	summary := resource.Resource().SegmentAssignStatsManager().GetTimeToSealSummary()
+	assert.Len(t, summary, 1)
+	assert.Equal(t, 1, summary[1].SampleCount)
+	assert.GreaterOrEqual(t, summary[1].P99, 5*time.Minute)
+	assert.Less(t, summary[1].P99, time.Hour)
+
+	assert.NoError(t, m.RemoveCollection(ctx, 1))
+	assert.Empty(t, resource.Resource().SegmentAssignStatsManager().GetTimeToSealSummary())
+	m.Close(ctx)
+}
+
+// savedSegmentStates returns the states of the segment saved into the mock catalog in order.
+func savedSegmentStates(segmentID int64) []streamingpb.SegmentAssignmentState {
+	catalog := resource.Resource().StreamingNodeCatalog().(*mock_metastore.MockStreamingNodeCataLog)
+	states := make([]streamingpb.SegmentAssignmentState, 0)
+	for _, call := range catalog.Calls {
+		if call.Method != "SaveSegmentAssignments" {
+			continue
+		}
+		infos := call.Arguments.Get(2).(map[int64]*streamingpb.SegmentAssignmentMeta)
+		if info, ok := infos[segmentID]; ok {
+			states = append(states, info.GetState())
+		}
+	}
+	return states
+}
+
+func newStat(insertedBinarySize uint64, maxBinarySize uint64) *streamingpb.SegmentAssignmentStat {
+	return &streamingpb.SegmentAssignmentStat{
+		MaxBinarySize:         maxBinarySize,
+		InsertedRows:          insertedBinarySize,
+		InsertedBinarySize:    insertedBinarySize,
+		CreateTimestamp:       resource.Resource().Clock().Now().Unix(),
+		LastModifiedTimestamp: resource.Resource().Clock().Now().Unix(),
+	}
+}
+
+// initializeTestState is a helper function to initialize the status for testing.
+func initializeTestState(t *testing.T) {
+	initializeTestStateWithClock(t, clock.NewSystemClock())
+}
+
+// initializeTestStateWithClock is a helper function to initialize the status for testing with the given clock.
+func initializeTestStateWithClock(t *testing.T, c clock.Clock) {
+	// c 1
+	//		p 1
+	//			s 1000p
+	//		p 2
+	//			s 2000g, 3000g, 4000s, 5000g
+	// 		p 3
+	//			s 6000g
+
+	paramtable.Init()
+	paramtable.Get().DataCoordCfg.SegmentSealProportion.SwapTempValue("1.0")
+	paramtable.Get().DataCoordCfg.SegmentSealProportionJitter.SwapTempValue("0.0")
+	paramtable.Get().DataCoordCfg.SegmentMaxSize.SwapTempValue("1")
+	paramtable.Get().Save(paramtable.Get().CommonCfg.EnableStorageV2.Key, "true")
+
+	streamingNodeCatalog := mock_metastore.NewMockStreamingNodeCataLog(t)
+
+	rootCoordClient := idalloc.NewMockRootCoordClient(t)
+	rootCoordClient.EXPECT().AllocSegment(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, asr *datapb.AllocSegmentRequest, co ...grpc.CallOption) (*datapb.AllocSegmentResponse, error) {
+		return &datapb.AllocSegmentResponse{
+			SegmentInfo: &datapb.SegmentInfo{
+				ID:           asr.GetSegmentId(),
+				CollectionID: asr.GetCollectionId(),
+				PartitionID:  asr.GetPartitionId(),
+			},
+			Status: merr.Success(),
+		}, nil
+	})
+	rootCoordClient.EXPECT().GetPChannelInfo(mock.Anything, mock.Anything).Return(&rootcoordpb.GetPChannelInfoResponse{
+		Collections: []*rootcoordpb.CollectionInfoOnPChannel{
+			{
+				CollectionId: 1,
+				Partitions: []*rootcoordpb.PartitionInfoOnPChannel{
+					{PartitionId: 1},
+					{PartitionId: 2},
+					{PartitionId: 3},
+				},
+			},
+		},
+	}, nil)
+	fRootCoordClient := syncutil.NewFuture[internaltypes.MixCoordClient]()
+	fRootCoordClient.Set(rootCoordClient)
+
+	resource.InitForTest(t,
+		resource.OptClock(c),
+		resource.OptStreamingNodeCatalog(streamingNodeCatalog),
+		resource.OptMixCoordClient(fRootCoordClient),
+	)
+	streamingNodeCatalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).Return(
+		[]*streamingpb.SegmentAssignmentMeta{
+			{
+				CollectionId: 1,
+				PartitionId:  1,
+				SegmentId:    1000,
+				Vchannel:     "v1",
+				State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING,
+				Stat:         nil,
+			},
+			{
+				CollectionId: 1,
+				PartitionId:  2,
+				SegmentId:    2000,
+				Vchannel:     "v1",
+				State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+				Stat:         newStat(1000, 1000),
+			},
+			{
+				CollectionId: 1,
+				PartitionId:  2,
+				SegmentId:    3000,
+				Vchannel:     "v1",
+				State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+				Stat:         newStat(100, 1000),
+			},
+			{
+				CollectionId: 1,
+				PartitionId:  2,
+				SegmentId:    4000,
+				Vchannel:     "v1",
+				State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED,
+				Stat:         newStat(900, 1000),
+			},
+			{
+				CollectionId: 1,
+				PartitionId:  2,
+				SegmentId:    5000,
+				Vchannel:     "v1",
+				State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+				Stat:         newStat(900, 1000),
+			},
+			{
+				CollectionId: 1,
+				PartitionId:  3,
+				SegmentId:    6000,
+				Vchannel:     "v1",
+				State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+				Stat:         newStat(100, 1000),
+			},
+		}, nil)
+	streamingNodeCatalog.EXPECT().ListVChannel(mock.Anything, mock.Anything).Return(
+		[]*streamingpb.VChannelMeta{
+			{
+				Vchannel: "v1",
+				State:    streamingpb.VChannelState_VCHANNEL_STATE_NORMAL,
+				CollectionInfo: &streamingpb.CollectionInfoOfVChannel{
+					CollectionId:            1,
+					LastManualFlushTimeTick: 1,
+				},
+			},
+		}, nil)
+	streamingNodeCatalog.EXPECT().SaveSegmentAssignments(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+}
+
+// openTxnListerFunc is a function adapter of OpenTxnLister.
+type openTxnListerFunc func(collectionID int64) []*message.OpenTxn
+
+func (f openTxnListerFunc) OpenTxnsOfCollection(collectionID int64) []*message.OpenTxn {
+	return f(collectionID)
+}
+
+func TestFlushWithOpenTxns(t *testing.T) {
+	initializeTestState(t)
+
+	var mu sync.Mutex
+	flushedOpenTxns := make(map[int64][]*message.OpenTxn)
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, msg message.MutableMessage) (*wal.AppendResult, error) {
+		if msg.MessageType() == message.MessageTypeFlush {
+			h := message.MustAsMutableFlushMessageV2(msg).Header()
+			mu.Lock()
+			flushedOpenTxns[h.GetSegmentId()] = h.GetOpenTxns()
+			mu.Unlock()
+		}
+		return &wal.AppendResult{
+			MessageID: rmq.NewRmqID(1),
+			TimeTick:  1,
+		}, nil
+	}).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_open_txns"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	// no open txn is annotated if no lister is bound.
+	assert.Empty(t, m.OpenTxnsOfCollection(1))
+
+	openTxns := []*message.OpenTxn{{TxnId: 1, BeginTimeTick: 10}, {TxnId: 2, BeginTimeTick: 20}}
+	m.BindOpenTxnLister(openTxnListerFunc(func(collectionID int64) []*message.OpenTxn {
+		if collectionID == 1 {
+			return openTxns
+		}
+		return nil
+	}))
+	assert.Equal(t, openTxns, m.OpenTxnsOfCollection(1))
+	assert.Empty(t, m.OpenTxnsOfCollection(2))
+
+	result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  3,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       100,
+			BinarySize: 100,
+		},
+		TimeTick: tsoutil.GetCurrentTime(),
+	})
+	assert.NoError(t, err)
+	result.Ack()
+
+	segmentIDs, err := m.SealAndFenceSegmentUntil(ctx, 1, tsoutil.GetCurrentTime())
+	assert.NoError(t, err)
+	assert.Contains(t, segmentIDs, result.SegmentID)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, openTxns, flushedOpenTxns[result.SegmentID])
+	m.Close(ctx)
+}
+
+func TestTimeTickSealValidation(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_timetick_seal"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	assign := func(timeTick uint64) (*AssignSegmentResult, error) {
+		return m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick: timeTick,
+		})
+	}
+	older := tsoutil.GetCurrentTime()
+	sealed := tsoutil.GetCurrentTime()
+	result, err := assign(sealed)
+	assert.NoError(t, err)
+	result.Ack()
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: result.SegmentID})
+
+	// the relaxed mode keeps the older insert landing in the next growing segment.
+	result, err = assign(older)
+	assert.NoError(t, err)
+	result.Ack()
+	tooOldTotal := func() float64 {
+		return testutil.ToFloat64(metrics.WALSegmentTimeTickTooOldTotal.WithLabelValues(paramtable.GetStringNodeID(), "v_timetick_seal", "1", "v1"))
+	}
+	assert.Zero(t, tooOldTotal())
+
+	// the insert not newer than the create time tick of the growing segment is redone even in the relaxed mode.
+	_, err = assign(1)
+	assert.ErrorIs(t, err, ErrTimeTickTooOld)
+	assert.Equal(t, float64(1), tooOldTotal())
+
+	// the strict mode redoes the older insert with a refreshed time tick.
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignTimeTickStrictCollections.Key, "1")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignTimeTickStrictCollections.Key)
+	_, err = assign(older)
+	assert.ErrorIs(t, err, ErrTimeTickTooOld)
+	_, err = assign(sealed)
+	assert.ErrorIs(t, err, ErrTimeTickTooOld)
+	result, err = assign(tsoutil.GetCurrentTime())
+	assert.NoError(t, err)
+	result.Ack()
+
+	// every redo is counted per collection and vchannel before it's converted into the redo by the interceptor.
+	assert.Equal(t, float64(3), tooOldTotal())
+	tooOld := resource.Resource().SegmentAssignStatsManager().GetTimeTickTooOldStats(1)
+	assert.Equal(t, uint64(3), tooOld.Total)
+	assert.Equal(t, map[string]uint64{"v1": 3}, tooOld.VChannels)
+	assert.Equal(t, 3, tooOld.InWindow)
+	assert.Greater(t, tooOld.RatePerSecond, float64(0))
+	m.Close(ctx)
+	assert.Zero(t, tooOldTotal())
+}
+
+func TestFlushPending(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	initializeTestStateWithClock(t, fakeClock)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignFlushPendingTimeout.Key, "1m")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignFlushPendingTimeout.Key)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_flush_pending"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	_, ok := m.GetFlushPending(1)
+	assert.False(t, ok)
+	snapshot, err := m.SnapshotCollection(1, nil, 0, 10)
+	assert.NoError(t, err)
+	assert.Nil(t, snapshot.FlushPending)
+
+	// the flush is pending from the fence until the manual flush message is appended.
+	flushTs := fakeClock.CurrentTSO()
+	segmentIDs, err := m.SealAndFenceSegmentUntil(ctx, 1, flushTs)
+	assert.NoError(t, err)
+	flush, ok := m.GetFlushPending(1)
+	assert.True(t, ok)
+	assert.Equal(t, flushTs, flush.FlushTs)
+	assert.Equal(t, "v_flush_pending", flush.PChannel)
+	snapshot, err = m.SnapshotCollection(1, nil, 0, 10)
+	assert.NoError(t, err)
+	assert.NotNil(t, snapshot.FlushPending)
+	assert.Len(t, resource.Resource().FlushPendingRegistry().List(), 1)
+
+	m.ObserveManualFlush(1, flushTs, segmentIDs)
+	_, ok = m.GetFlushPending(1)
+	assert.False(t, ok)
+	snapshot, err = m.SnapshotCollection(1, nil, 0, 10)
+	assert.NoError(t, err)
+	assert.Nil(t, snapshot.FlushPending)
+
+	// the flush that is never appended is expired by its deadline.
+	flushTs = fakeClock.CurrentTSO()
+	_, err = m.SealAndFenceSegmentUntil(ctx, 1, flushTs)
+	assert.NoError(t, err)
+	_, ok = m.GetFlushPending(1)
+	assert.True(t, ok)
+	fakeClock.Advance(time.Minute)
+	_, ok = m.GetFlushPending(1)
+	assert.False(t, ok)
+
+	// the pending flush is removed when the pchannel is closed.
+	_, err = m.SealAndFenceSegmentUntil(ctx, 1, fakeClock.CurrentTSO())
+	assert.NoError(t, err)
+	m.Close(ctx)
+	assert.Empty(t, resource.Resource().FlushPendingRegistry().List())
+}
+
+func TestMustSealSegmentsBatch(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_seal_batch"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	// the unacked assignment blocks the flush of the segment.
+	result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  3,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       100,
+			BinarySize: 100,
+		},
+		TimeTick: tsoutil.GetCurrentTime(),
+	})
+	assert.NoError(t, err)
+
+	// a partial failure batch, the segment failed to seal never fails the whole batch.
+	outcomes, err := m.MustSealSegmentsBatch(ctx, []stats.SegmentBelongs{
+		{CollectionID: 1, PartitionID: 2, SegmentID: 3000},
+		{CollectionID: 1, PartitionID: 2, SegmentID: 3000},
+		{CollectionID: 1, PartitionID: 3, SegmentID: result.SegmentID},
+		{CollectionID: 1, PartitionID: 3, SegmentID: 5000}, // the segment is not in the partition.
+		{CollectionID: 1, PartitionID: 100, SegmentID: 7000},
+		{CollectionID: 1, PartitionID: 2, SegmentID: 9999},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64]SealOutcome{
+		3000:             streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_SEALED,
+		result.SegmentID: streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_WAITING_FOR_ACK,
+		5000:             streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_NOT_FOUND,
+		7000:             streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_NOT_FOUND,
+		9999:             streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_NOT_FOUND,
+	}, outcomes)
+	assert.NotZero(t, m.SealWaitState().Reasons[stats.SealWaitReasonUnackedAssignments])
+
+	// the segment waiting for ack is already sealed, and the flushed segment can not be found anymore.
+	outcomes, err = m.MustSealSegmentsBatch(ctx, []stats.SegmentBelongs{
+		{CollectionID: 1, PartitionID: 3, SegmentID: result.SegmentID},
+		{CollectionID: 1, PartitionID: 2, SegmentID: 3000},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64]SealOutcome{
+		result.SegmentID: streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_ALREADY_SEALED,
+		3000:             streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_NOT_FOUND,
+	}, outcomes)
+
+	result.Ack()
+	m.TryToSealWaitedSegment(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+
+	// the batch over the rpc keeps the order of the request.
+	resp, err := MustSealSegmentsOnPChannel(ctx, &streamingpb.StreamingNodeManagerSealSegmentsRequest{
+		Pchannel: &streamingpb.PChannelInfo{Name: "v_seal_batch"},
+		Segments: []*streamingpb.SealSegmentTarget{
+			{CollectionId: 1, PartitionId: 2, SegmentId: 5000},
+			{CollectionId: 1, PartitionId: 2, SegmentId: 3000},
+			{CollectionId: 1, PartitionId: 2, SegmentId: 5000},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetResults(), 2)
+	assert.Equal(t, int64(5000), resp.GetResults()[0].GetSegmentId())
+	assert.Equal(t, streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_SEALED, resp.GetResults()[0].GetOutcome())
+	assert.Equal(t, int64(3000), resp.GetResults()[1].GetSegmentId())
+	assert.Equal(t, streamingpb.SegmentSealOutcome_SEGMENT_SEAL_OUTCOME_NOT_FOUND, resp.GetResults()[1].GetOutcome())
+
+	_, err = MustSealSegmentsOnPChannel(ctx, &streamingpb.StreamingNodeManagerSealSegmentsRequest{
+		Pchannel: &streamingpb.PChannelInfo{Name: "v_seal_batch_not_exist"},
+	})
+	assert.Error(t, err)
+
+	m.Close(ctx)
+	_, err = m.MustSealSegmentsBatch(ctx, []stats.SegmentBelongs{{CollectionID: 1, PartitionID: 2, SegmentID: 2000}})
+	assert.Error(t, err)
+}
+
+func TestCollectionAssignmentConfig(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_assignment_config"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	_, err = m.CollectionAssignmentConfig(100)
+	assert.ErrorIs(t, err, ErrCollectionNotFound)
+
+	// export the config of the original collection.
+	config, err := m.CollectionAssignmentConfig(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), config.CollectionId)
+	assert.False(t, config.HighPriority)
+	assert.Zero(t, config.MaxBinarySizeFloor)
+
+	config.HighPriority = true
+	config.TimeTickStrict = true
+	config.StorageVersion = storage.StorageV1
+	config.MaxBinarySizeFloor = 4 * 1024 * 1024
+	raw, err := proto.Marshal(config)
+	assert.NoError(t, err)
+	// the field of a newer node is kept by the round trip.
+	unknown := protowire.AppendVarint(protowire.AppendTag(nil, 100, protowire.VarintType), 1)
+	raw = append(raw, unknown...)
+	restored := &streamingpb.CollectionAssignmentConfig{}
+	assert.NoError(t, proto.Unmarshal(raw, restored))
+
+	// apply the config to the restored collection.
+	m.NewCollection(100, "v1", []int64{101}, InitialSchemaVersion, 0)
+	assert.NoError(t, m.ApplyCollectionAssignmentConfig(100, restored))
+	assert.True(t, policy.IsHighPriorityAssign(100, false))
+	assert.Equal(t, policy.TimeTickValidationModeStrict, policy.GetTimeTickValidationMode(100))
+	assert.Equal(t, storage.StorageV1, policy.GetSegmentStorageVersion(100))
+
+	exported, err := m.CollectionAssignmentConfig(100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), exported.CollectionId)
+	assert.True(t, exported.HighPriority)
+	assert.True(t, exported.TimeTickStrict)
+	assert.Equal(t, storage.StorageV1, exported.StorageVersion)
+	assert.Equal(t, uint64(4*1024*1024), exported.MaxBinarySizeFloor)
+	assert.Equal(t, unknown, []byte(exported.ProtoReflect().GetUnknown()))
+
+	// the invalid config is rejected.
+	assert.Error(t, m.ApplyCollectionAssignmentConfig(100, &streamingpb.CollectionAssignmentConfig{StorageVersion: 100}))
+	assert.ErrorIs(t, m.ApplyCollectionAssignmentConfig(200, restored), ErrCollectionNotFound)
+
+	// the applied config is removed with the collection.
+	assert.NoError(t, m.RemoveCollection(ctx, 100))
+	assert.False(t, policy.IsHighPriorityAssign(100, false))
+	m.Close(ctx)
+}
+
+func TestSealPersistBudget(t *testing.T) {
+	initializeTestState(t)
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.WALSegmentAssignSealQueuePersistMaxEntries.Key, "2")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignSealQueuePersistMaxEntries.Key)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	pchannel := types.PChannelInfo{Name: "v_seal_persist_budget"}
+	metrics := metricsutil.NewSegmentAssignMetrics(pchannel.Name)
+	defer metrics.Close()
+	segmentIDs := []int64{7000, 8000, 9000}
+	recover := func(states map[int64]streamingpb.SegmentAssignmentState) (*partitionSegmentManagers, *sealQueue) {
+		metas := make([]*streamingpb.SegmentAssignmentMeta, 0, len(segmentIDs))
+		for _, segmentID := range segmentIDs {
+			if states[segmentID] == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED {
+				continue
+			}
+			metas = append(metas, &streamingpb.SegmentAssignmentMeta{
+				CollectionId: 1,
+				PartitionId:  2,
+				SegmentId:    segmentID,
+				Vchannel:     "v1",
+				State:        states[segmentID],
+				Stat:         newStat(100, 1000),
+			})
+		}
+		watcher := newAssignmentWatcher(defaultAssignmentEventRingSize)
+		managers, waitForSealed := buildNewPartitionManagers(f, pchannel, metas, []*rootcoordpb.CollectionInfoOnPChannel{
+			{
+				CollectionId: 1,
+				Vchannel:     "v1",
+				Partitions:   []*rootcoordpb.PartitionInfoOnPChannel{{PartitionId: 2}},
+			},
+		}, nil, metrics, watcher, newSealRandom(1), newRecoverySummary(pchannel.Name))
+		return managers, newSealQueue(log.With(), pchannel.Name, f, waitForSealed, metrics, watcher, newSealedSegmentNotifier(log.With(), pchannel))
+	}
+	latestStates := func() map[int64]streamingpb.SegmentAssignmentState {
+		latest := make(map[int64]streamingpb.SegmentAssignmentState)
+		for _, segmentID := range segmentIDs {
+			latest[segmentID] = streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING
+			if states := savedSegmentStates(segmentID); len(states) > 0 {
+				latest[segmentID] = states[len(states)-1]
+			}
+		}
+		return latest
+	}
+	persistBacklog := func(q *sealQueue) int {
+		return q.WaitState(func(int64) bool { return false }).Reasons[stats.SealWaitReasonPersistBacklog]
+	}
+
+	managers, q := recover(nil)
+	pm, err := managers.Get(1, 2)
+	assert.NoError(t, err)
+	q.AsyncSeal(pm.CollectAllCanBeSealedAndClear(policy.PolicyNameForce)...)
+
+	// only two seals are persisted by the first cycle, the third one is kept in memory.
+	q.SealAllWait(context.Background())
+	w.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	assert.Equal(t, 1, persistBacklog(q))
+	assert.Equal(t, 3, q.WaitCounter())
+	states := latestStates()
+	assert.Equal(t, 2, lo.CountBy(segmentIDs, func(segmentID int64) bool {
+		return states[segmentID] == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED
+	}))
+
+	// the durable sealed segments are flushed first by the second cycle.
+	q.SealAllWait(context.Background())
+	w.AssertNumberOfCalls(t, "Append", 2)
+	assert.Equal(t, 1, persistBacklog(q))
+	assert.Equal(t, 1, q.WaitCounter())
+
+	// crash with the backlog outstanding, the pending seal is recovered as growing and sealed again.
+	states = latestStates()
+	pending := lo.Filter(segmentIDs, func(segmentID int64, _ int) bool {
+		return states[segmentID] == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING
+	})
+	assert.Len(t, pending, 1)
+	// the in-memory stats of the pending segment are lost with the crashed node.
+	resource.Resource().SegmentAssignStatsManager().UnregisterSealedSegment(pending[0])
+	managers, q = recover(states)
+	assert.True(t, q.IsEmpty())
+	pm, err = managers.Get(1, 2)
+	assert.NoError(t, err)
+	recovered := pm.CollectAllCanBeSealedAndClear(policy.PolicyNameForce)
+	assert.Len(t, recovered, 1)
+	assert.Equal(t, pending[0], recovered[0].GetSegmentID())
+	q.AsyncSeal(recovered...)
+	q.SealAllWait(context.Background())
+	assert.True(t, q.IsEmpty())
+	assert.Equal(t, 0, persistBacklog(q))
+	w.AssertNumberOfCalls(t, "Append", 3)
+	for _, segmentID := range segmentIDs {
+		assert.Equal(t, []streamingpb.SegmentAssignmentState{
+			streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED,
+			streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED,
+		}, savedSegmentStates(segmentID))
+	}
+}
+
+func TestAssignSegmentWithConcurrentDropPartition(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_concurrent_drop_partition"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	assigned := typeutil.NewSet[int64]()
+	collected := typeutil.NewSet[int64]()
+	dropping := atomic.NewInt64(0)
+	for i := 0; i < 20; i++ {
+		partitionID := int64(100 + i)
+		assert.NoError(t, m.NewPartition(1, partitionID))
+
+		wg := sync.WaitGroup{}
+		for j := 0; j < 8; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for k := 0; k < 25; k++ {
+					result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+						CollectionID: 1,
+						PartitionID:  partitionID,
+						InsertMetrics: stats.InsertMetrics{
+							Rows:       1,
+							BinarySize: 100,
+						},
+						TimeTick: tsoutil.GetCurrentTime(),
+					})
+					if err != nil {
+						// the assignment observes the drop cleanly, either the stale manager or the removed one.
+						if errors.Is(err, ErrPartitionDropping) {
+							dropping.Inc()
+						} else {
+							assert.True(t, status.AsStreamingError(err).IsUnrecoverable(), "%+v", err)
+						}
+						continue
+					}
+					result.Ack()
+					mu.Lock()
+					assigned.Insert(result.SegmentID)
+					mu.Unlock()
+				}
+			}()
+		}
+		segments := m.managers.RemovePartition(1, partitionID, false)
+		for _, segment := range segments {
+			collected.Insert(segment.GetSegmentID())
+		}
+		wg.Wait()
+		m.helper.AsyncSeal(segments...)
+	}
+
+	// every assigned segment is collected by the drop, no segment is revived on the dropped partition.
+	for segmentID := range assigned {
+		assert.True(t, collected.Contain(segmentID), "segment %d is revived after the partition is dropped", segmentID)
+	}
+	m.helper.SealAllWait(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+	t.Logf("assigned segments: %d, rejected by dropping: %d", assigned.Len(), dropping.Load())
+	m.Close(ctx)
+}
+
+func TestPartitionQuiescentSince(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Unix(time.Now().Unix(), 0))
+	initializeTestStateWithClock(t, fakeClock)
+	params := paramtable.Get()
+	params.Save(params.DataCoordCfg.SegmentMaxIdleTime.Key, "600")
+	defer params.Reset(params.DataCoordCfg.SegmentMaxIdleTime.Key)
+	params.Save(params.DataCoordCfg.SegmentMinSizeFromIdleToSealed.Key, "0")
+	defer params.Reset(params.DataCoordCfg.SegmentMinSizeFromIdleToSealed.Key)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	pchannel := types.PChannelInfo{Name: "v_partition_quiescent_since"}
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), pchannel, f)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	// no insert is assigned since the recovery.
+	_, ok := m.PartitionQuiescentSince(1, 3)
+	assert.False(t, ok)
+	_, ok = m.PartitionQuiescentSince(1, 100)
+	assert.False(t, ok)
+
+	assign := func() uint64 {
+		timeTick := fakeClock.CurrentTSO()
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick: timeTick,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6000), result.SegmentID)
+		result.Ack()
+		return timeTick
+	}
+	assign()
+	fakeClock.Advance(time.Minute)
+	lastAssign := assign()
+	quiescentSince, ok := m.PartitionQuiescentSince(1, 3)
+	assert.True(t, ok)
+	assert.Equal(t, lastAssign, quiescentSince)
+
+	// the last assign time tick of partition is persisted with the segment meta.
+	m.Close(ctx)
+	// the closed manager never reports the quiescence.
+	_, ok = m.PartitionQuiescentSince(1, 3)
+	assert.False(t, ok)
+	meta := latestSavedSegmentMeta(6000)
+	assert.NotNil(t, meta)
+	assert.Equal(t, lastAssign, meta.GetPartitionLastAssignTimeTick())
+
+	// the persisted last modified time of segment is stale, but the idle seal is decided by the quiescence of partition.
+	meta = proto.Clone(meta).(*streamingpb.SegmentAssignmentMeta)
+	meta.Stat.LastModifiedTimestamp = fakeClock.Now().Add(-time.Hour).Unix()
+	fakeClock.Advance(5 * time.Minute)
+
+	// the quiescence is recovered from the persisted segment metas after restart.
+	metrics := metricsutil.NewSegmentAssignMetrics(pchannel.Name)
+	defer metrics.Close()
+	managers, _ := buildNewPartitionManagers(f, pchannel, []*streamingpb.SegmentAssignmentMeta{meta}, []*rootcoordpb.CollectionInfoOnPChannel{
+		{
+			CollectionId: 1,
+			Vchannel:     "v1",
+			Partitions:   []*rootcoordpb.PartitionInfoOnPChannel{{PartitionId: 3}},
+		},
+	}, nil, metrics, newAssignmentWatcher(defaultAssignmentEventRingSize), newSealRandom(1), newRecoverySummary(pchannel.Name))
+	pm, err = managers.Get(1, 3)
+	assert.NoError(t, err)
+	quiescentSince, ok = pm.QuiescentSince()
+	assert.True(t, ok)
+	assert.Equal(t, lastAssign, quiescentSince)
+	assert.Empty(t, pm.CollectShouldBeSealed())
+
+	fakeClock.Advance(6 * time.Minute)
+	sealed := pm.CollectShouldBeSealed()
+	assert.Len(t, sealed, 1)
+	assert.Equal(t, int64(6000), sealed[0].GetSegmentID())
+}
+
+// latestSavedSegmentMeta returns the latest meta of the segment saved into the catalog.
+func latestSavedSegmentMeta(segmentID int64) *streamingpb.SegmentAssignmentMeta {
+	catalog := resource.Resource().StreamingNodeCatalog().(*mock_metastore.MockStreamingNodeCataLog)
+	var latest *streamingpb.SegmentAssignmentMeta
+	for _, call := range catalog.Calls {
+		if call.Method != "SaveSegmentAssignments" {
+			continue
+		}
+		infos := call.Arguments.Get(2).(map[int64]*streamingpb.SegmentAssignmentMeta)
+		if info, ok := infos[segmentID]; ok {
+			latest = info
+		}
+	}
+	return latest
+}
+
+func TestLateAckAfterFlush(t *testing.T) {
+	initializeTestState(t)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignSealQueueMaxWaiting.Key, "1")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignSealQueueMaxWaiting.Key)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_late_ack_after_flush"}, f)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	assign := func(partitionID int64) *AssignSegmentResult {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  partitionID,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick: tsoutil.GetCurrentTime(),
+		})
+		assert.NoError(t, err)
+		return result
+	}
+	mustSeal := func(partitionID int64, segmentID int64) {
+		m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: partitionID, SegmentID: segmentID})
+	}
+	lastState := func(segmentID int64) streamingpb.SegmentAssignmentState {
+		states := savedSegmentStates(segmentID)
+		return states[len(states)-1]
+	}
+
+	// the segment with the flying ack is force resolved and flushed by the seal queue.
+	late := assign(3)
+	assert.Equal(t, int64(6000), late.SegmentID)
+	mustSeal(3, 6000)
+	waiting := assign(2)
+	mustSeal(2, waiting.SegmentID)
+	assert.Equal(t, 1, m.helper.WaitCounter())
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED, lastState(6000))
+	flushedStates := savedSegmentStates(6000)
+
+	// the late ack and sync update of the flushed segment are classified and never applied.
+	late.AckWithMetrics(stats.InsertMetrics{Rows: 10, BinarySize: 10})
+	assert.Equal(t, int32(1), late.Acknowledge.Load())
+	err = resource.Resource().SegmentAssignStatsManager().UpdateOnSync(6000, stats.SyncOperationMetrics{BinLogCounterIncr: 1, BinLogFileCounterIncr: 1})
+	assert.ErrorIs(t, err, stats.ErrSegmentRecentlyRemoved)
+	assert.Equal(t, flushedStates, savedSegmentStates(6000))
+	m.TryToSealWaitedSegment(ctx)
+	assert.Equal(t, 1, m.helper.WaitCounter())
+	assert.False(t, m.helper.Contains(6000))
+
+	// the ack of the live segment is still applied.
+	waiting.Ack()
+	assert.Equal(t, int32(0), waiting.Acknowledge.Load())
+	m.TryToSealWaitedSegment(ctx)
+	assert.True(t, m.SealWaitState().IsNoWait())
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED, lastState(waiting.SegmentID))
+	m.Close(ctx)
+}
+
+func TestFlushMessageTimeTickAfterAssignedInserts(t *testing.T) {
+	initializeTestState(t)
+
+	var mu sync.Mutex
+	// the stale timetick that the allocator holds without any barrier.
+	staleTimeTick := uint64(1)
+	flushTimeTicks := make(map[int64]uint64)
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, msg message.MutableMessage) (*wal.AppendResult, error) {
+		// simulate the allocation of the timetick interceptor.
+		timeTick := staleTimeTick
+		if barrier := msg.BarrierTimeTick(); barrier >= timeTick {
+			timeTick = barrier + 1
+		}
+		if msg.MessageType() == message.MessageTypeFlush {
+			header := message.MustAsMutableFlushMessageV2(msg).Header()
+			mu.Lock()
+			flushTimeTicks[header.GetSegmentId()] = timeTick
+			mu.Unlock()
+		}
+		return &wal.AppendResult{
+			MessageID: rmq.NewRmqID(1),
+			TimeTick:  timeTick,
+		}, nil
+	}).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_flush_timetick"}, f)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	insertTimeTicks := make([]uint64, 0, 3)
+	for i := 0; i < 3; i++ {
+		timeTick := tsoutil.GetCurrentTime()
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       100,
+				BinarySize: 100,
+			},
+			TimeTick: timeTick,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6000), result.SegmentID)
+		result.Ack()
+		insertTimeTicks = append(insertTimeTicks, timeTick)
+	}
+
+	// the flush message is ordered after every insert assigned to the segment.
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: 6000})
+	assert.True(t, m.SealWaitState().IsNoWait())
+	mu.Lock()
+	flushTimeTick, ok := flushTimeTicks[6000]
+	mu.Unlock()
+	assert.True(t, ok)
+	for _, timeTick := range insertTimeTicks {
+		assert.Greater(t, flushTimeTick, timeTick)
+	}
+	m.Close(ctx)
+}
+
+func TestApplyPreassignedSegment(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_preassigned"}, f)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	apply := func(partitionID int64, segmentID int64, binarySize uint64) (*AssignSegmentResult, error) {
+		return m.ApplyPreassignedSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  partitionID,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       10,
+				BinarySize: binarySize,
+			},
+			TimeTick: tsoutil.GetCurrentTime(),
+		}, segmentID)
+	}
+
+	// the insert is applied on the pre-assigned growing segment and counted into its stats.
+	before := resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(6000)
+	result, err := apply(3, 6000, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6000), result.SegmentID)
+	assert.Equal(t, int32(1), result.Acknowledge.Load())
+	after := resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(6000)
+	assert.Equal(t, before.Insert.Rows+10, after.Insert.Rows)
+	assert.Equal(t, before.Insert.BinarySize+100, after.Insert.BinarySize)
+	result.Ack()
+	_, ok := m.PartitionQuiescentSince(1, 3)
+	assert.True(t, ok)
+
+	// the pending segment, the sealed segment and the segment of other partition are rejected.
+	_, err = apply(1, 1000, 100)
+	assert.ErrorIs(t, err, ErrPreassignedSegmentInvalid)
+	_, err = apply(2, 4000, 100)
+	assert.ErrorIs(t, err, ErrPreassignedSegmentInvalid)
+	_, err = apply(3, 2000, 100)
+	assert.ErrorIs(t, err, ErrPreassignedSegmentInvalid)
+	_, err = apply(3, 99999, 100)
+	assert.ErrorIs(t, err, ErrPreassignedSegmentInvalid)
+
+	// the segment that can't hold the insert is rejected, the stats are not changed.
+	_, err = apply(3, 6000, 1024*1024)
+	assert.ErrorIs(t, err, ErrPreassignedSegmentInvalid)
+	assert.Equal(t, after.Insert, resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(6000).Insert)
+
+	// the unknown partition is rejected as not found.
+	_, err = apply(100, 6000, 100)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrPreassignedSegmentInvalid)
+	m.Close(ctx)
+}
+
+func TestSealedUnflushedSegments(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  2,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+	ctx := context.Background()
+
+	// the sealed meta recovered from the catalog is still in flight.
+	m, err := RecoverPChannelSegmentAllocManager(ctx, types.PChannelInfo{Name: "v_sealed_unflushed"}, f)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{4000}, m.GetSealedUnflushedSegments())
+
+	// the segment leaves the set after its flush is completed by the flusher.
+	assert.True(t, resource.Resource().SegmentStateIndex().ObserveFlushCompleted(4000))
+	assert.Empty(t, m.GetSealedUnflushedSegments())
+	m.Close(ctx)
+
+	// the set is derived from the sealed metas again after the restart.
+	m, err = RecoverPChannelSegmentAllocManager(ctx, types.PChannelInfo{Name: "v_sealed_unflushed"}, f)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{4000}, m.GetSealedUnflushedSegments())
+
+	// the segment leaves the set after its meta is removed.
+	m.TryToSealSegments(ctx)
+	assert.NotContains(t, m.GetSealedUnflushedSegments(), int64(4000))
+	m.Close(ctx)
+	assert.Empty(t, m.GetSealedUnflushedSegments())
+}
+
+func TestPKSketchCarriedByFlush(t *testing.T) {
+	initializeTestState(t)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignPKSketchEnabled.Key, "true")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignPKSketchEnabled.Key)
+
+	var mu sync.Mutex
+	flushed := make(map[int64]*message.FlushMessageHeader)
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, msg message.MutableMessage) (*wal.AppendResult, error) {
+		if msg.MessageType() == message.MessageTypeFlush {
+			header := message.MustAsMutableFlushMessageV2(msg).Header()
+			mu.Lock()
+			flushed[header.GetSegmentId()] = header
+			mu.Unlock()
+		}
+		return &wal.AppendResult{
+			MessageID: rmq.NewRmqID(1),
+			TimeTick:  1,
+		}, nil
+	}).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_pk_sketch"}, f)
+	assert.NoError(t, err)
+	defer m.Close(context.Background())
+	ctx := context.Background()
+
+	pkHashes := make([]uint64, 0, 100)
+	for pk := uint64(1); pk <= 100; pk++ {
+		pkHashes = append(pkHashes, pk)
+	}
+	// the same primary keys are inserted twice, the insert without hashes is skipped, the failed insert is never observed.
+	for _, hashes := range [][]uint64{pkHashes, pkHashes, nil, {1000, 1001}} {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  3,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       10,
+				BinarySize: 100,
+			},
+			PKHashes: hashes,
+			TimeTick: tsoutil.GetCurrentTime(),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6000), result.SegmentID)
+		if len(hashes) != 2 {
+			result.ObserveAppended(rmq.NewRmqID(1))
+		}
+		result.Ack()
+	}
+	stat := resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(6000)
+	assert.InDelta(t, 100, float64(stat.PKSketch.Estimate()), 2)
+
+	// the estimate is carried by the flush message of the segment.
+	m.MustSealSegments(ctx, stats.SegmentBelongs{CollectionID: 1, PartitionID: 3, SegmentID: 6000})
+	assert.True(t, m.SealWaitState().IsNoWait())
+	mu.Lock()
+	header := flushed[6000]
+	mu.Unlock()
+	assert.NotNil(t, header)
+	assert.InDelta(t, 100, float64(header.GetApproximateDistinctPks()), 2)
+	sketch, err := stats.UnmarshalPKSketch(header.GetPkSketch())
+	assert.NoError(t, err)
+	assert.Equal(t, header.GetApproximateDistinctPks(), sketch.Estimate())
 }
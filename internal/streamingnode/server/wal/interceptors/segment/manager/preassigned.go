@@ -0,0 +1,23 @@
+package manager
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+var ErrPreassignedSegmentInvalid = errors.New("preassigned segment invalid")
+
+// checkPreassignable checks whether the segment can hold the insert pre-assigned by the internal producer.
+// The segment is looked up from the segments of the partition of the insert, so the segment of other partitions is never found.
+func checkPreassignable(segment *segmentAllocManager, segmentID int64, collectionID int64, partitionID int64) error {
+	switch {
+	case segment == nil:
+		return errors.Wrapf(ErrPreassignedSegmentInvalid, "segment %d is not found in collection %d partition %d", segmentID, collectionID, partitionID)
+	case segment.IsL0():
+		return errors.Wrapf(ErrPreassignedSegmentInvalid, "segment %d is a L0 segment", segmentID)
+	case segment.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING:
+		return errors.Wrapf(ErrPreassignedSegmentInvalid, "segment %d is at state %s", segmentID, segment.GetState())
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package manager
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+const (
+	recoveryStageListSegments          = "list_segments"
+	recoveryStageListVChannels         = "list_vchannels"
+	recoveryStageGetPChannel           = "get_pchannel_info"
+	recoveryStageBuildManagers         = "build_managers"
+	recoveryStageAdoptDeferredSegments = "adopt_deferred_segments"
+)
+
+// recoverySummaries keeps the summary of the last successful recovery of every pchannel,
+// it's retained until the next recovery of the pchannel, even if the manager is closed.
+var recoverySummaries = typeutil.NewConcurrentMap[string, *RecoverySummary]()
+
+// RecoverySummary is the consolidated outcome of the segment assignment recovery of a pchannel.
+type RecoverySummary struct {
+	PChannel    string          `json:"pchannel"`
+	Collections int             `json:"collections"`
+	Partitions  int             `json:"partitions"`
+	Segments    map[string]int  `json:"segments"`    // the count of recovered segments by the recovered state, the discarded segments are not included.
+	Orphans     int             `json:"orphans"`     // the segments whose partition is not found, they're sealed right away.
+	Discarded   int             `json:"discarded"`   // the dropped segments left before crash, they're deleted right away.
+	Valid       int             `json:"valid"`       // the metas that can be used directly.
+	Repaired    int             `json:"repaired"`    // the metas that are broken but repaired.
+	Quarantined int             `json:"quarantined"` // the metas that cannot be trusted, they're sealed right away.
+	Adopted     int             `json:"adopted"`     // the segments allocated at coordinator but unknown by catalog, they're sealed right away.
+	Seed        int64           `json:"seed"`        // the seed of the random source of the pchannel, configure it to reproduce the randomized decisions.
+	Stages      []RecoveryStage `json:"stages"`
+	Duration    time.Duration   `json:"duration"`
+	StartedAt   time.Time       `json:"started_at"`
+	// the build and behavior metadata of the segment assignment when the pchannel is recovered.
+	Metadata AssignmentMetadata `json:"metadata"`
+}
+
+// RecoveryStage is the duration of a stage of the recovery.
+type RecoveryStage struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+}
+
+// GetRecoverySummary returns the summary of the last successful recovery of the pchannel.
+func GetRecoverySummary(pchannel string) (*RecoverySummary, bool) {
+	return recoverySummaries.Get(pchannel)
+}
+
+// newRecoverySummary creates a new recovery summary of the pchannel.
+func newRecoverySummary(pchannel string) *RecoverySummary {
+	return &RecoverySummary{
+		PChannel:  pchannel,
+		Segments:  make(map[string]int),
+		Stages:    make([]RecoveryStage, 0, 4),
+		StartedAt: time.Now(),
+	}
+}
+
+// observeStage records the duration of the stage since the last stage is done.
+func (s *RecoverySummary) observeStage(name string) {
+	duration := time.Since(s.StartedAt) - s.Duration
+	s.Stages = append(s.Stages, RecoveryStage{Name: name, Duration: duration})
+	s.Duration += duration
+}
+
+// observeMeta records the validation class of a recovered meta.
+func (s *RecoverySummary) observeMeta(class recoveredMetaClass) {
+	switch class {
+	case recoveredMetaClassValid:
+		s.Valid++
+	case recoveredMetaClassRepaired:
+		s.Repaired++
+	case recoveredMetaClassQuarantined:
+		s.Quarantined++
+	}
+}
+
+// observeSegment records the state of a recovered segment.
+func (s *RecoverySummary) observeSegment(segment *segmentAllocManager) {
+	s.Segments[segment.GetState().String()]++
+}
+
+// items returns the counters of the summary, it's exported into metrics.
+func (s *RecoverySummary) items() map[string]int {
+	items := map[string]int{
+		"collections": s.Collections,
+		"partitions":  s.Partitions,
+		"orphans":     s.Orphans,
+		"discarded":   s.Discarded,
+		"valid":       s.Valid,
+		"repaired":    s.Repaired,
+		"quarantined": s.Quarantined,
+		"adopted":     s.Adopted,
+	}
+	for state, cnt := range s.Segments {
+		items[state] = cnt
+	}
+	return items
+}
+
+// publishRecoverySummary publishes the summary of the recovery into the log and metrics,
+// and retains it until the next recovery of the pchannel.
+func publishRecoverySummary(logger *log.MLogger, metrics *metricsutil.SegmentAssignMetrics, summary *RecoverySummary) {
+	stages := make(map[string]time.Duration, len(summary.Stages))
+	for _, stage := range summary.Stages {
+		stages[stage.Name] = stage.Duration
+	}
+	metrics.ObserveRecoverySummary(summary.items(), stages)
+	recoverySummaries.Insert(summary.PChannel, summary)
+
+	logger.Info("segment assignment recovery summary",
+		zap.Int("collections", summary.Collections),
+		zap.Int("partitions", summary.Partitions),
+		zap.Any("segments", summary.Segments),
+		zap.Int("orphans", summary.Orphans),
+		zap.Int("discarded", summary.Discarded),
+		zap.Int("valid", summary.Valid),
+		zap.Int("repaired", summary.Repaired),
+		zap.Int("quarantined", summary.Quarantined),
+		zap.Int("adopted", summary.Adopted),
+		zap.Int64("seed", summary.Seed),
+		zap.Any("stages", stages),
+		zap.Duration("duration", summary.Duration),
+		zap.String("nodeVersion", summary.Metadata.NodeVersion),
+		zap.Strings("features", summary.Metadata.EnabledFeatures()),
+		zap.Strings("policyChain", summary.Metadata.PolicyChain))
+}
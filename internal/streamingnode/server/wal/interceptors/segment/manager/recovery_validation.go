@@ -0,0 +1,88 @@
+package manager
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+// recoveredMetaClass is the validation result of a segment assignment meta recovered from catalog.
+type recoveredMetaClass string
+
+const (
+	recoveredMetaClassValid       recoveredMetaClass = "valid"       // the meta can be used directly.
+	recoveredMetaClassRepaired    recoveredMetaClass = "repaired"    // the meta is broken but repaired by filling defaults or clamping sizes.
+	recoveredMetaClassQuarantined recoveredMetaClass = "quarantined" // the meta cannot be trusted, it will be sealed right away.
+)
+
+// validateRecoveredMeta checks the invariants of a recovered segment assignment meta.
+// The input meta is never modified, a repaired or quarantined copy is returned if the invariants are violated.
+// A quarantined meta is transferred into sealed state in memory, so it will never be assigned again,
+// and the seal queue will flush it as soon as possible.
+// The repaired max binary size is jittered by the random source of the pchannel.
+func validateRecoveredMeta(meta *streamingpb.SegmentAssignmentMeta, source policy.RandomSource) (*streamingpb.SegmentAssignmentMeta, recoveredMetaClass, []string) {
+	switch meta.GetState() {
+	case streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING,
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_DROPPED:
+		// pending segment only have a segment id, the stat is never used.
+		// dropped segment will be deleted physically, the stat is never used too.
+		return meta, recoveredMetaClassValid, nil
+	case streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED:
+	default:
+		// unknown or flushed segment should never be seen in catalog.
+		return quarantineRecoveredMeta(meta), recoveredMetaClassQuarantined, []string{fmt.Sprintf("unexpected state %s", meta.GetState())}
+	}
+
+	if meta.GetStat() == nil && meta.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
+		// A growing segment without stat may be left by a partial write,
+		// we cannot know how many data has been written into it, so quarantine it.
+		return quarantineRecoveredMeta(meta), recoveredMetaClassQuarantined, []string{"growing segment without stat"}
+	}
+
+	reasons := make([]string, 0)
+	repaired := proto.Clone(meta).(*streamingpb.SegmentAssignmentMeta)
+	if repaired.Stat == nil {
+		repaired.Stat = &streamingpb.SegmentAssignmentStat{}
+		reasons = append(reasons, "sealed segment without stat")
+	}
+	stat := repaired.Stat
+	// the L0 segment has no max binary size, it's never sealed by size.
+	if repaired.GetLevel() != streamingpb.SegmentAssignmentLevel_SEGMENT_ASSIGNMENT_LEVEL_L0 {
+		if stat.MaxBinarySize == 0 {
+			stat.MaxBinarySize = policy.GetSegmentLimitationPolicy().GenerateLimitation(source).SegmentSize
+			reasons = append(reasons, "zero max binary size")
+		}
+		if stat.InsertedBinarySize > stat.MaxBinarySize {
+			reasons = append(reasons, fmt.Sprintf("inserted binary size %d exceeds max binary size %d", stat.InsertedBinarySize, stat.MaxBinarySize))
+			stat.InsertedBinarySize = stat.MaxBinarySize
+		}
+	}
+	now := resource.Resource().Clock().Now().Unix()
+	if stat.CreateTimestamp <= 0 {
+		stat.CreateTimestamp = now
+		reasons = append(reasons, "zero create timestamp")
+	}
+	if stat.LastModifiedTimestamp <= 0 {
+		stat.LastModifiedTimestamp = now
+		reasons = append(reasons, "zero last modified timestamp")
+	}
+	if len(reasons) == 0 {
+		return meta, recoveredMetaClassValid, nil
+	}
+	return repaired, recoveredMetaClassRepaired, reasons
+}
+
+// quarantineRecoveredMeta returns a sealed copy of the meta with an empty stat if the stat is missing.
+func quarantineRecoveredMeta(meta *streamingpb.SegmentAssignmentMeta) *streamingpb.SegmentAssignmentMeta {
+	quarantined := proto.Clone(meta).(*streamingpb.SegmentAssignmentMeta)
+	quarantined.State = streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED
+	if quarantined.Stat == nil {
+		quarantined.Stat = &streamingpb.SegmentAssignmentStat{}
+	}
+	return quarantined
+}
@@ -0,0 +1,114 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestValidateRecoveredMeta(t *testing.T) {
+	paramtable.Init()
+
+	// valid pending segment without stat.
+	pending := &streamingpb.SegmentAssignmentMeta{
+		SegmentId: 1000,
+		State:     streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING,
+	}
+	meta, class, reasons := validateRecoveredMeta(pending, nil)
+	assert.Equal(t, recoveredMetaClassValid, class)
+	assert.Empty(t, reasons)
+	assert.Same(t, pending, meta)
+
+	// valid dropped segment without stat.
+	dropped := &streamingpb.SegmentAssignmentMeta{
+		SegmentId: 1500,
+		State:     streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_DROPPED,
+	}
+	meta, class, reasons = validateRecoveredMeta(dropped, nil)
+	assert.Equal(t, recoveredMetaClassValid, class)
+	assert.Empty(t, reasons)
+	assert.Same(t, dropped, meta)
+
+	// valid growing segment.
+	growing := &streamingpb.SegmentAssignmentMeta{
+		SegmentId: 2000,
+		State:     streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+		Stat:      newStat(100, 1000),
+	}
+	meta, class, reasons = validateRecoveredMeta(growing, nil)
+	assert.Equal(t, recoveredMetaClassValid, class)
+	assert.Empty(t, reasons)
+	assert.Same(t, growing, meta)
+
+	// valid L0 segment without max binary size.
+	l0 := &streamingpb.SegmentAssignmentMeta{
+		SegmentId: 2500,
+		State:     streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+		Level:     streamingpb.SegmentAssignmentLevel_SEGMENT_ASSIGNMENT_LEVEL_L0,
+		Stat:      newStat(100, 0),
+	}
+	meta, class, reasons = validateRecoveredMeta(l0, nil)
+	assert.Equal(t, recoveredMetaClassValid, class)
+	assert.Empty(t, reasons)
+	assert.Same(t, l0, meta)
+
+	// inserted binary size exceeds max binary size.
+	oversize := &streamingpb.SegmentAssignmentMeta{
+		SegmentId: 3000,
+		State:     streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+		Stat:      newStat(2000, 1000),
+	}
+	meta, class, reasons = validateRecoveredMeta(oversize, nil)
+	assert.Equal(t, recoveredMetaClassRepaired, class)
+	assert.Len(t, reasons, 1)
+	assert.Equal(t, uint64(1000), meta.GetStat().GetInsertedBinarySize())
+	assert.Equal(t, uint64(2000), oversize.GetStat().GetInsertedBinarySize(), "input meta should not be modified")
+
+	// zero timestamps and zero max binary size.
+	zeroStat := &streamingpb.SegmentAssignmentMeta{
+		SegmentId: 4000,
+		State:     streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+		Stat:      &streamingpb.SegmentAssignmentStat{},
+	}
+	meta, class, reasons = validateRecoveredMeta(zeroStat, nil)
+	assert.Equal(t, recoveredMetaClassRepaired, class)
+	assert.Len(t, reasons, 3)
+	assert.NotZero(t, meta.GetStat().GetMaxBinarySize())
+	assert.NotZero(t, meta.GetStat().GetCreateTimestamp())
+	assert.NotZero(t, meta.GetStat().GetLastModifiedTimestamp())
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING, meta.GetState())
+
+	// sealed segment without stat.
+	sealed := &streamingpb.SegmentAssignmentMeta{
+		SegmentId: 5000,
+		State:     streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED,
+	}
+	meta, class, _ = validateRecoveredMeta(sealed, nil)
+	assert.Equal(t, recoveredMetaClassRepaired, class)
+	assert.NotNil(t, meta.GetStat())
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED, meta.GetState())
+
+	// growing segment without stat after a partial write.
+	growingNilStat := &streamingpb.SegmentAssignmentMeta{
+		SegmentId: 6000,
+		State:     streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+	}
+	meta, class, reasons = validateRecoveredMeta(growingNilStat, nil)
+	assert.Equal(t, recoveredMetaClassQuarantined, class)
+	assert.Len(t, reasons, 1)
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED, meta.GetState())
+	assert.NotNil(t, meta.GetStat())
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING, growingNilStat.GetState())
+
+	// unexpected state.
+	unknown := &streamingpb.SegmentAssignmentMeta{
+		SegmentId: 7000,
+		State:     streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_UNKNOWN,
+	}
+	meta, class, _ = validateRecoveredMeta(unknown, nil)
+	assert.Equal(t, recoveredMetaClassQuarantined, class)
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED, meta.GetState())
+}
@@ -0,0 +1,86 @@
+package manager
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+)
+
+// rowSizeFloorAlignment is the alignment of the max binary size floor bumped by the row size check.
+const rowSizeFloorAlignment = 1024 * 1024
+
+// RowSizeCheckSnapshot is the snapshot of the row size check of the collection.
+type RowSizeCheckSnapshot struct {
+	AvgRowSize         uint64 `json:"avg_row_size"`
+	RequiredBinarySize uint64 `json:"required_binary_size"`  // the binary size to hold the min rows of the average row size.
+	MaxBinarySizeLimit uint64 `json:"max_binary_size_limit"` // the max binary size of the segment before the floor is applied.
+	Undersized         bool   `json:"undersized"`
+	BumpedFloor        uint64 `json:"bumped_floor,omitempty"` // the max binary size floor bumped by the row size check, 0 if not bumped.
+}
+
+// checkRowSize checks the max binary size of the collection against its average row size when the insert arrives.
+// A misconfigured max binary size that can't hold the min rows of the average row size makes every insert create a new segment
+// or fail with ErrTooLargeInsert, so it's reported once with a warning and a metric when it's detected,
+// and the max binary size of the collection is raised to a floor holding the min rows if the auto bump is enabled.
+// Every bump is logged as a warning, and the floor is kept until the collection is dropped.
+// Must be called with the lock held.
+func (m *partitionSegmentManager) checkRowSize(req *AssignSegmentRequest) {
+	rows := m.ingest.rows.Load() + req.InsertMetrics.Rows
+	if rows == 0 {
+		return
+	}
+	avgRowSize := (m.ingest.assigned.Load() + req.InsertMetrics.BinarySize) / rows
+	required := policy.GetRowSizeRequiredBinarySize(avgRowSize)
+	limit := maxBinarySizeLimit(m.ingest)
+	if required == 0 || limit >= required {
+		if m.ingest.MarkUndersized(false) {
+			m.logger.Info("segment max binary size can hold the average rows of collection again",
+				zap.Uint64("avgRowSize", avgRowSize),
+				zap.Uint64("requiredBinarySize", required),
+				zap.Uint64("maxBinarySizeLimit", limit))
+		}
+		return
+	}
+	fields := []zap.Field{
+		zap.Uint64("avgRowSize", avgRowSize),
+		zap.Uint64("requiredBinarySize", required),
+		zap.Uint64("maxBinarySizeLimit", limit),
+	}
+	if m.ingest.MarkUndersized(true) {
+		m.metrics.ObserveUndersizedMaxSize(m.collectionID)
+		m.logger.Warn("SEGMENT MAX SIZE TOO SMALL: segment max binary size can't hold the min rows of the average row size of collection, check the segment max size config", fields...)
+	}
+	if !policy.IsRowSizeAutoBumpEnabled() {
+		return
+	}
+	// the floor is rounded up to MB, so the slowly growing average row size doesn't bump it on every insert.
+	floor := (required + rowSizeFloorAlignment - 1) / rowSizeFloorAlignment * rowSizeFloorAlignment
+	if oldFloor, ok := m.ingest.RaiseRowSizeFloor(floor); ok {
+		m.logger.Warn("SEGMENT MAX SIZE AUTO BUMP: segment max binary size of collection is bumped to hold the min rows of the average row size",
+			append(fields, zap.Uint64("oldFloor", oldFloor), zap.Uint64("newFloor", floor))...)
+	}
+}
+
+// maxBinarySizeLimit returns the max binary size of the new growing segment of the collection before the row size floor is applied.
+func maxBinarySizeLimit(ingest *collectionIngest) uint64 {
+	limit := policy.GetSegmentMaxBinarySizeLimit()
+	if override := ingest.MaxBinarySize(); override != 0 && override < limit {
+		return override
+	}
+	return limit
+}
+
+// snapshotRowSizeCheck returns the snapshot of the row size check of the collection, nil if no row is observed yet.
+func snapshotRowSizeCheck(ingest *collectionIngest) *RowSizeCheckSnapshot {
+	avgRowSize := ingest.AvgRowSize()
+	if avgRowSize == 0 {
+		return nil
+	}
+	return &RowSizeCheckSnapshot{
+		AvgRowSize:         avgRowSize,
+		RequiredBinarySize: policy.GetRowSizeRequiredBinarySize(avgRowSize),
+		MaxBinarySizeLimit: maxBinarySizeLimit(ingest),
+		Undersized:         ingest.undersized.Load(),
+		BumpedFloor:        ingest.RowSizeFloor(),
+	}
+}
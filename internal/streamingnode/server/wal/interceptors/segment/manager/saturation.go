@@ -0,0 +1,121 @@
+package manager
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/txn"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// saturationSmoothingFactor is the weight of the latest window in the rolling saturation.
+const saturationSmoothingFactor = 0.5
+
+// isBackpressureRejection checks if the assignment is rejected by the backpressure of the append path.
+func isBackpressureRejection(err error) bool {
+	return errors.IsAny(err, ErrCollectionCircuitOpen, context.DeadlineExceeded)
+}
+
+// computeSaturation computes the saturation of the append path in [0, 1] from the pressure observed in the window.
+// The append path is as saturated as its most saturated stage:
+//
+//	lockWaitRatio   = lockWait / window   // the fraction of the window spent waiting for the lock of the partitions.
+//	allocationRatio = allocation / window // the fraction of the window spent allocating the new growing segments.
+//	rejectionRatio  = rejected / assigns  // the fraction of the assignments rejected by the backpressure.
+//	saturation      = min(1, max(lockWaitRatio, allocationRatio, rejectionRatio))
+//
+// The lock wait and allocation are accumulated over the concurrent assignments, so their ratio may exceed 1 and is capped.
+func computeSaturation(delta metricsutil.AppendPathPressure, window time.Duration) float64 {
+	if window <= 0 {
+		return 0
+	}
+	saturation := max(delta.LockWait.Seconds()/window.Seconds(), delta.Allocation.Seconds()/window.Seconds())
+	if delta.Assigns > 0 {
+		saturation = max(saturation, float64(delta.Rejected)/float64(delta.Assigns))
+	}
+	return min(saturation, 1)
+}
+
+// newSaturationTracker creates a new saturation tracker starting from the given pressure.
+func newSaturationTracker(pressure metricsutil.AppendPathPressure, now time.Time) *saturationTracker {
+	return &saturationTracker{
+		last:          pressure,
+		lastSampledAt: now,
+	}
+}
+
+// saturationTracker tracks the rolling saturation of the append path of a pchannel.
+// The saturation of every window is smoothed by the exponential moving average,
+// so a single burst doesn't flip the placement decision of the coordinator.
+type saturationTracker struct {
+	mu            sync.Mutex
+	last          metricsutil.AppendPathPressure
+	lastSampledAt time.Time
+	score         float64
+}
+
+// sample samples the pressure observed since the last sample and returns the rolling saturation.
+func (t *saturationTracker) sample(pressure metricsutil.AppendPathPressure, now time.Time) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delta := metricsutil.AppendPathPressure{
+		LockWait:   pressure.LockWait - t.last.LockWait,
+		Allocation: pressure.Allocation - t.last.Allocation,
+		Assigns:    pressure.Assigns - t.last.Assigns,
+		Rejected:   pressure.Rejected - t.last.Rejected,
+	}
+	window := now.Sub(t.lastSampledAt)
+	if window <= 0 {
+		// the sample is too close to the last one, keep accumulating.
+		return t.score
+	}
+	t.score = saturationSmoothingFactor*computeSaturation(delta, window) + (1-saturationSmoothingFactor)*t.score
+	t.last = pressure
+	t.lastSampledAt = now
+	return t.score
+}
+
+// Score returns the rolling saturation of the last sample.
+func (t *saturationTracker) Score() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.score
+}
+
+// Saturation returns the rolling saturation of the append path of the pchannel in [0, 1].
+func (m *PChannelSegmentAllocManager) Saturation() float64 {
+	return m.saturation.Score()
+}
+
+// PressureLevel returns the pressure level of the append path of the pchannel by the rolling saturation.
+// It's the read hook of the txn manager to throttle the new txns, see streaming.walSegmentAssign.pressure*Saturation.
+func (m *PChannelSegmentAllocManager) PressureLevel() txn.PressureLevel {
+	return pressureLevelOfSaturation(m.Saturation())
+}
+
+// pressureLevelOfSaturation returns the pressure level of the saturation, the level with a non-positive threshold is disabled.
+func pressureLevelOfSaturation(saturation float64) txn.PressureLevel {
+	params := paramtable.Get().StreamingCfg
+	if critical := params.WALSegmentAssignPressureCriticalSaturation.GetAsFloat(); critical > 0 && saturation >= critical {
+		return txn.PressureLevelCritical
+	}
+	if warn := params.WALSegmentAssignPressureWarnSaturation.GetAsFloat(); warn > 0 && saturation >= warn {
+		return txn.PressureLevelWarn
+	}
+	return txn.PressureLevelNormal
+}
+
+// sampleSaturation samples the rolling saturation of the append path,
+// and publishes it to the metrics and the coordinator report.
+func (m *PChannelSegmentAllocManager) sampleSaturation() {
+	score := m.saturation.sample(m.metrics.AppendPathPressure(), resource.Resource().Clock().Now())
+	m.metrics.UpdateSaturation(score)
+	resource.Resource().SegmentAssignStatsManager().UpdatePChannelSaturation(m.pchannel.Name, score)
+}
@@ -0,0 +1,132 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/txn"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+)
+
+func TestComputeSaturation(t *testing.T) {
+	cases := []struct {
+		name     string
+		delta    metricsutil.AppendPathPressure
+		window   time.Duration
+		expected float64
+	}{
+		{"idle", metricsutil.AppendPathPressure{}, time.Second, 0},
+		{"empty window", metricsutil.AppendPathPressure{LockWait: time.Second}, 0, 0},
+		{"lock wait bound", metricsutil.AppendPathPressure{LockWait: 500 * time.Millisecond, Allocation: 100 * time.Millisecond, Assigns: 100}, time.Second, 0.5},
+		{"allocation bound", metricsutil.AppendPathPressure{LockWait: 100 * time.Millisecond, Allocation: 700 * time.Millisecond, Assigns: 100}, time.Second, 0.7},
+		{"rejection bound", metricsutil.AppendPathPressure{LockWait: 100 * time.Millisecond, Assigns: 100, Rejected: 90}, time.Second, 0.9},
+		{"capped", metricsutil.AppendPathPressure{LockWait: 4 * time.Second, Assigns: 100}, time.Second, 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.InDelta(t, c.expected, computeSaturation(c.delta, c.window), 1e-9)
+		})
+	}
+
+	assert.True(t, isBackpressureRejection(errors.Wrap(ErrCollectionCircuitOpen, "collection 1")))
+	assert.True(t, isBackpressureRejection(context.DeadlineExceeded))
+	assert.False(t, isBackpressureRejection(ErrFencedAssign))
+	assert.False(t, isBackpressureRejection(nil))
+}
+
+func TestSaturationTracker(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tracker := newSaturationTracker(metricsutil.AppendPathPressure{LockWait: time.Hour, Assigns: 1000}, now)
+
+	// the pressure before the tracker is created is never counted.
+	pressure := metricsutil.AppendPathPressure{LockWait: time.Hour + 800*time.Millisecond, Assigns: 1100}
+	now = now.Add(time.Second)
+	assert.InDelta(t, 0.4, tracker.sample(pressure, now), 1e-9)
+	assert.InDelta(t, 0.4, tracker.Score(), 1e-9)
+
+	// the saturated windows raise the rolling saturation gradually.
+	pressure.Rejected = 100
+	pressure.Assigns = 1200
+	now = now.Add(time.Second)
+	assert.InDelta(t, 0.7, tracker.sample(pressure, now), 1e-9)
+
+	// the sample without elapsed window keeps the score.
+	assert.InDelta(t, 0.7, tracker.sample(pressure, now), 1e-9)
+
+	// the idle windows decay the rolling saturation.
+	now = now.Add(time.Second)
+	assert.InDelta(t, 0.35, tracker.sample(pressure, now), 1e-9)
+	now = now.Add(time.Second)
+	assert.InDelta(t, 0.175, tracker.sample(pressure, now), 1e-9)
+}
+
+func TestSampleSaturation(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Unix(time.Now().Unix(), 0))
+	initializeTestStateWithClock(t, fakeClock)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  2,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_saturation"}, f)
+	assert.NoError(t, err)
+	gauge := func() float64 {
+		return testutil.ToFloat64(metrics.WALSegmentAssignSaturation.WithLabelValues(paramtable.GetStringNodeID(), "v_saturation"))
+	}
+
+	// half of the window is spent allocating the new growing segments.
+	m.metrics.ObserveSegmentAllocation(10 * time.Second)
+	fakeClock.Advance(20 * time.Second)
+	m.TryToSealSegments(context.Background())
+	assert.InDelta(t, 0.25, m.Saturation(), 1e-9)
+	assert.InDelta(t, 0.25, gauge(), 1e-9)
+	assert.InDelta(t, 0.25, resource.Resource().SegmentAssignStatsManager().GetPChannelSaturations()["v_saturation"], 1e-9)
+
+	// the rejected assignments by the backpressure.
+	m.metrics.ObserveAssign(true)
+	m.metrics.ObserveAssign(false)
+	fakeClock.Advance(20 * time.Second)
+	m.TryToSealSegments(context.Background())
+	assert.InDelta(t, 0.375, m.Saturation(), 1e-9)
+
+	// the saturation of the pchannel is removed with the manager.
+	m.Close(context.Background())
+	assert.NotContains(t, resource.Resource().SegmentAssignStatsManager().GetPChannelSaturations(), "v_saturation")
+}
+
+func TestPressureLevelOfSaturation(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+
+	assert.Equal(t, txn.PressureLevelNormal, pressureLevelOfSaturation(0.5))
+	assert.Equal(t, txn.PressureLevelWarn, pressureLevelOfSaturation(0.8))
+	assert.Equal(t, txn.PressureLevelCritical, pressureLevelOfSaturation(0.95))
+	assert.Equal(t, txn.PressureLevelCritical, pressureLevelOfSaturation(1))
+
+	// the level with a non-positive threshold is disabled.
+	params.Save(params.StreamingCfg.WALSegmentAssignPressureCriticalSaturation.Key, "0")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignPressureCriticalSaturation.Key)
+	assert.Equal(t, txn.PressureLevelWarn, pressureLevelOfSaturation(1))
+	params.Save(params.StreamingCfg.WALSegmentAssignPressureWarnSaturation.Key, "0")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignPressureWarnSaturation.Key)
+	assert.Equal(t, txn.PressureLevelNormal, pressureLevelOfSaturation(1))
+}
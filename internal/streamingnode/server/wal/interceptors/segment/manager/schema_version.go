@@ -0,0 +1,44 @@
+package manager
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+// InitialSchemaVersion is the schema version of a new created collection,
+// the schema version is the modify ts of the last schema change after the schema is changed.
+const InitialSchemaVersion uint64 = 0
+
+var ErrSchemaVersionMismatch = errors.New("schema version mismatch")
+
+// recoverSchemaVersions recovers the current schema version of the collections.
+// The schema version persisted in the vchannel meta may be stale if the vchannel meta is not persisted before crash,
+// so the max schema version of the recovered segments is also considered.
+func recoverSchemaVersions(vchannels []*streamingpb.VChannelMeta, rawMetas []*streamingpb.SegmentAssignmentMeta) map[int64]uint64 {
+	versions := make(map[int64]uint64, len(vchannels))
+	for _, vchannel := range vchannels {
+		if vchannel.GetState() == streamingpb.VChannelState_VCHANNEL_STATE_DROPPED {
+			continue
+		}
+		info := vchannel.GetCollectionInfo()
+		if info.GetSchemaVersion() > versions[info.GetCollectionId()] {
+			versions[info.GetCollectionId()] = info.GetSchemaVersion()
+		}
+	}
+	for _, rawMeta := range rawMetas {
+		if rawMeta.GetSchemaVersion() > versions[rawMeta.GetCollectionId()] {
+			versions[rawMeta.GetCollectionId()] = rawMeta.GetSchemaVersion()
+		}
+	}
+	return versions
+}
+
+// checkSchemaVersion checks the declared schema version of the insert with the current schema version of the collection.
+// The insert that doesn't declare its schema version is always accepted.
+func checkSchemaVersion(collectionID int64, declared uint64, current uint64) error {
+	if declared == 0 || declared == current {
+		return nil
+	}
+	return errors.Wrapf(ErrSchemaVersionMismatch, "collection %d, declared schema version %d, current schema version %d", collectionID, declared, current)
+}
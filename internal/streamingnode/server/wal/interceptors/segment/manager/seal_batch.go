@@ -0,0 +1,63 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/inspector"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/internal/util/streamingutil/status"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+// SealOutcome is the outcome of a segment in the must seal batch.
+type SealOutcome = streamingpb.SegmentSealOutcome
+
+// MustSealSegmentsOnPChannel seals the batch of segments on the pchannel for the compaction of the coordinator.
+func MustSealSegmentsOnPChannel(ctx context.Context, req *streamingpb.StreamingNodeManagerSealSegmentsRequest) (*streamingpb.StreamingNodeManagerSealSegmentsResponse, error) {
+	pchannel := req.GetPchannel().GetName()
+	pm, err := getPChannelSegmentAllocManager(pchannel)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]stats.SegmentBelongs, 0, len(req.GetSegments()))
+	for _, target := range req.GetSegments() {
+		infos = append(infos, stats.SegmentBelongs{
+			PChannel:     pchannel,
+			CollectionID: target.GetCollectionId(),
+			PartitionID:  target.GetPartitionId(),
+			SegmentID:    target.GetSegmentId(),
+		})
+	}
+	outcomes, err := pm.MustSealSegmentsBatch(ctx, infos)
+	if err != nil {
+		return nil, err
+	}
+	// the results keep the order of the request, the duplicated segment is only reported once.
+	results := make([]*streamingpb.SealSegmentResult, 0, len(outcomes))
+	for _, info := range infos {
+		outcome, ok := outcomes[info.SegmentID]
+		if !ok {
+			continue
+		}
+		results = append(results, &streamingpb.SealSegmentResult{
+			SegmentId: info.SegmentID,
+			Outcome:   outcome,
+		})
+		delete(outcomes, info.SegmentID)
+	}
+	return &streamingpb.StreamingNodeManagerSealSegmentsResponse{Results: results}, nil
+}
+
+// getPChannelSegmentAllocManager gets the segment assignment manager of the pchannel for the admin operations.
+func getPChannelSegmentAllocManager(pchannel string) (*PChannelSegmentAllocManager, error) {
+	operator, ok := inspector.GetSegmentSealedInspector().GetPChannelManager(pchannel)
+	if !ok {
+		// the segment assignment of the pchannel may be still recovering.
+		return nil, status.NewRetryLater("segment assignment of pchannel %s is not ready", pchannel)
+	}
+	pm, ok := operator.(*PChannelSegmentAllocManager)
+	if !ok {
+		return nil, status.NewInner("pchannel %s does not support the segment assignment operations", pchannel)
+	}
+	return pm, nil
+}
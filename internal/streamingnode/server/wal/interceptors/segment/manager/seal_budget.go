@@ -0,0 +1,42 @@
+package manager
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// newSealPersistBudget creates the catalog write budget of a seal cycle from the config.
+func newSealPersistBudget() *sealPersistBudget {
+	params := paramtable.Get()
+	return &sealPersistBudget{
+		maxEntries: params.StreamingCfg.WALSegmentAssignSealQueuePersistMaxEntries.GetAsInt(),
+		maxBytes:   params.StreamingCfg.WALSegmentAssignSealQueuePersistMaxBytes.GetAsInt(),
+	}
+}
+
+// sealPersistBudget limits the catalog writes issued by a seal cycle,
+// so the sweep of a large amount of segments is spread over multiple cycles but not a burst of catalog writes.
+type sealPersistBudget struct {
+	maxEntries int // 0 means no limit.
+	maxBytes   int // 0 means no limit.
+	entries    int
+	bytes      int
+}
+
+// TryConsume consumes the budget for the catalog write of the segment, return false if the budget is exhausted.
+// The first write of the cycle is always allowed, so the seal keeps progressing even if a single write exceeds the byte budget.
+func (b *sealPersistBudget) TryConsume(segment *segmentAllocManager) bool {
+	size := proto.Size(segment.inner)
+	if b.entries > 0 {
+		if b.maxEntries > 0 && b.entries+1 > b.maxEntries {
+			return false
+		}
+		if b.maxBytes > 0 && b.bytes+size > b.maxBytes {
+			return false
+		}
+	}
+	b.entries++
+	b.bytes += size
+	return true
+}
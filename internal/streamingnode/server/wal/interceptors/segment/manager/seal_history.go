@@ -0,0 +1,165 @@
+package manager
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// SealEvent is a seal event of a segment kept by the seal history of a pchannel.
+type SealEvent struct {
+	CollectionID    int64     `json:"collection_id"`
+	PartitionID     int64     `json:"partition_id"`
+	SegmentID       int64     `json:"segment_id"`
+	VChannel        string    `json:"vchannel"`
+	Level           string    `json:"level"`
+	Policy          string    `json:"policy"` // the reason of the seal.
+	Origin          string    `json:"origin"`
+	Rows            uint64    `json:"rows"`
+	BinarySize      uint64    `json:"binary_size"`
+	MaxBinarySize   uint64    `json:"max_binary_size"`
+	SealDecision    time.Time `json:"seal_decision"` // the time when the segment is decided to be sealed.
+	FlushedAt       time.Time `json:"flushed_at"`
+	PersistDuration string    `json:"persist_duration"` // the duration of the catalog write of the sealed state, 0 if the segment is sealed before recovery.
+	AppendDuration  string    `json:"append_duration"`  // the duration of the append of the flush message, 0 for the L0 segment.
+	FlushTimeTick   uint64    `json:"flush_time_tick,omitempty"`
+}
+
+// sealEvent is the raw seal event recorded by the seal path,
+// it's kept as a plain value, so the record never allocates and the formatting is delayed until it's read.
+type sealEvent struct {
+	collectionID    int64
+	partitionID     int64
+	segmentID       int64
+	vchannel        string
+	level           streamingpb.SegmentAssignmentLevel
+	policy          policy.PolicyName
+	origin          streamingpb.SegmentAssignmentOrigin
+	rows            uint64
+	binarySize      uint64
+	maxBinarySize   uint64
+	sealDecision    time.Time
+	flushedAt       time.Time
+	persistDuration time.Duration
+	appendDuration  time.Duration
+	flushTimeTick   uint64
+}
+
+// newSealEvent creates the seal event of the flushed segment.
+func newSealEvent(segment *segmentAllocManager, appendDuration time.Duration, flushTimeTick uint64) sealEvent {
+	event := sealEvent{
+		collectionID:    segment.GetCollectionID(),
+		partitionID:     segment.GetPartitionID(),
+		segmentID:       segment.GetSegmentID(),
+		vchannel:        segment.GetVChannel(),
+		level:           segment.inner.GetLevel(),
+		policy:          segment.SealPolicy(),
+		origin:          segment.GetAssignmentOrigin(),
+		binarySize:      segment.GetBinarySize(),
+		sealDecision:    segment.sealDecision,
+		flushedAt:       resource.Resource().Clock().Now(),
+		persistDuration: segment.sealPersistDuration,
+		appendDuration:  appendDuration,
+		flushTimeTick:   flushTimeTick,
+	}
+	if stat := segment.GetStat(); stat != nil {
+		event.rows = stat.Insert.Rows
+		event.maxBinarySize = stat.MaxBinarySize
+	}
+	return event
+}
+
+// newSealHistoryFromConfig creates the seal history with the configured size.
+func newSealHistoryFromConfig() *sealHistory {
+	return newSealHistory(paramtable.Get().StreamingCfg.WALSegmentAssignSealHistorySize.GetAsInt())
+}
+
+// newSealHistory creates a seal history keeping the last size seal events, the history is disabled if the size is not positive.
+func newSealHistory(size int) *sealHistory {
+	if size < 0 {
+		size = 0
+	}
+	return &sealHistory{events: make([]sealEvent, size)}
+}
+
+// sealHistory is a bounded ring buffer of the recent seal events of a pchannel for the post-incident debugging.
+// The buffer is allocated once, so the memory is bounded by the configured size.
+type sealHistory struct {
+	mu     sync.Mutex
+	events []sealEvent
+	next   int // the index of the slot to record the next event.
+	count  int // the count of the recorded events, at most the size of the buffer.
+}
+
+// Record records the seal event, the oldest event is overwritten if the history is full.
+func (h *sealHistory) Record(event sealEvent) {
+	if len(h.events) == 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.events[h.next] = event
+	h.next = (h.next + 1) % len(h.events)
+	if h.count < len(h.events) {
+		h.count++
+	}
+}
+
+// Snapshot returns the recorded seal events, the newest first.
+func (h *sealHistory) Snapshot() []SealEvent {
+	h.mu.Lock()
+	raw := make([]sealEvent, 0, h.count)
+	for i := 1; i <= h.count; i++ {
+		raw = append(raw, h.events[(h.next-i+len(h.events))%len(h.events)])
+	}
+	h.mu.Unlock()
+
+	events := make([]SealEvent, 0, len(raw))
+	for _, e := range raw {
+		events = append(events, SealEvent{
+			CollectionID:    e.collectionID,
+			PartitionID:     e.partitionID,
+			SegmentID:       e.segmentID,
+			VChannel:        e.vchannel,
+			Level:           e.level.String(),
+			Policy:          string(e.policy),
+			Origin:          e.origin.String(),
+			Rows:            e.rows,
+			BinarySize:      e.binarySize,
+			MaxBinarySize:   e.maxBinarySize,
+			SealDecision:    e.sealDecision,
+			FlushedAt:       e.flushedAt,
+			PersistDuration: e.persistDuration.String(),
+			AppendDuration:  e.appendDuration.String(),
+			FlushTimeTick:   e.flushTimeTick,
+		})
+	}
+	return events
+}
+
+// SealHistory returns the recent seal events of the pchannel, the newest first.
+func (m *PChannelSegmentAllocManager) SealHistory() []SealEvent {
+	return m.helper.history.Snapshot()
+}
+
+// GetSealHistoryOnPChannel gets the recent seal events of the pchannel as json for the admin.
+func GetSealHistoryOnPChannel(ctx context.Context, req *streamingpb.StreamingNodeManagerGetSealHistoryRequest) (*streamingpb.StreamingNodeManagerGetSealHistoryResponse, error) {
+	pm, err := getPChannelSegmentAllocManager(req.GetPchannel().GetName())
+	if err != nil {
+		return nil, err
+	}
+	history, err := json.Marshal(pm.SealHistory())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode the seal history")
+	}
+	return &streamingpb.StreamingNodeManagerGetSealHistoryResponse{History: history}, nil
+}
@@ -0,0 +1,96 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/inspector"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+)
+
+func TestSealHistory(t *testing.T) {
+	h := newSealHistory(3)
+	assert.Empty(t, h.Snapshot())
+
+	for i := int64(1); i <= 5; i++ {
+		h.Record(sealEvent{segmentID: i})
+	}
+	// the oldest events are overwritten, and the newest event comes first.
+	events := h.Snapshot()
+	assert.Len(t, events, 3)
+	assert.Equal(t, int64(5), events[0].SegmentID)
+	assert.Equal(t, int64(4), events[1].SegmentID)
+	assert.Equal(t, int64(3), events[2].SegmentID)
+
+	// the history is disabled if the size is not positive.
+	h = newSealHistory(0)
+	h.Record(sealEvent{segmentID: 1})
+	assert.Empty(t, h.Snapshot())
+	h = newSealHistory(-1)
+	h.Record(sealEvent{segmentID: 1})
+	assert.Empty(t, h.Snapshot())
+}
+
+func TestSealHistoryOfFlush(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  100,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	ctx := context.Background()
+	m, err := RecoverPChannelSegmentAllocManager(ctx, types.PChannelInfo{Name: "v_seal_history"}, f)
+	assert.NoError(t, err)
+	inspector.GetSegmentSealedInspector().RegisterPChannelManager(m)
+	defer func() {
+		inspector.GetSegmentSealedInspector().UnregisterPChannelManager(m)
+		m.Close(context.Background())
+	}()
+	assert.Empty(t, m.SealHistory())
+
+	m.MustSealSegments(ctx,
+		stats.SegmentBelongs{PChannel: "v_seal_history", CollectionID: 1, PartitionID: 2, SegmentID: 3000},
+		stats.SegmentBelongs{PChannel: "v_seal_history", CollectionID: 1, PartitionID: 2, SegmentID: 5000},
+	)
+	events := m.SealHistory()
+	assert.Len(t, events, 2)
+	segmentIDs := []int64{events[0].SegmentID, events[1].SegmentID}
+	assert.ElementsMatch(t, []int64{3000, 5000}, segmentIDs)
+	for _, event := range events {
+		assert.Equal(t, int64(1), event.CollectionID)
+		assert.Equal(t, int64(2), event.PartitionID)
+		assert.Equal(t, uint64(100), event.FlushTimeTick)
+		assert.NotEmpty(t, event.Policy)
+		assert.False(t, event.FlushedAt.IsZero())
+	}
+	assert.False(t, events[0].FlushedAt.Before(events[1].FlushedAt))
+
+	// the history is served by the admin rpc as json.
+	resp, err := GetSealHistoryOnPChannel(ctx, &streamingpb.StreamingNodeManagerGetSealHistoryRequest{
+		Pchannel: &streamingpb.PChannelInfo{Name: "v_seal_history"},
+	})
+	assert.NoError(t, err)
+	decoded := make([]SealEvent, 0)
+	assert.NoError(t, json.Unmarshal(resp.GetHistory(), &decoded))
+	assert.Len(t, decoded, 2)
+	assert.Equal(t, events[0].SegmentID, decoded[0].SegmentID)
+
+	_, err = GetSealHistoryOnPChannel(ctx, &streamingpb.StreamingNodeManagerGetSealHistoryRequest{
+		Pchannel: &streamingpb.PChannelInfo{Name: "notexist"},
+	})
+	assert.Error(t, err)
+}
@@ -2,45 +2,79 @@ package manager
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/faultinject"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
 	"github.com/milvus-io/milvus/pkg/v2/log"
 	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+// the reasons that the seal is aborted before the flush message is appended.
+const (
+	sealAbortReasonCatalogWrite   = "catalog_write"
+	sealAbortReasonCatalogTimeout = "catalog_timeout"
+	sealAbortReasonNotDurable     = "not_durable"
 )
 
 // newSealQueue creates a new seal helper queue.
 func newSealQueue(
 	logger *log.MLogger,
+	pchannel string,
 	wal *syncutil.Future[wal.WAL],
 	waitForSealed []*segmentAllocManager,
 	metrics *metricsutil.SegmentAssignMetrics,
+	watcher *assignmentWatcher,
+	notifier *sealedSegmentNotifier,
 ) *sealQueue {
-	return &sealQueue{
-		cond:          syncutil.NewContextCond(&sync.Mutex{}),
+	mu := &sync.RWMutex{}
+	q := &sealQueue{
+		mu:            mu,
+		cond:          syncutil.NewContextCond(mu),
 		logger:        logger,
+		pchannel:      pchannel,
 		wal:           wal,
 		waitForSealed: waitForSealed,
 		waitCounter:   len(waitForSealed),
 		metrics:       metrics,
+		watcher:       watcher,
+		notifier:      notifier,
+		openTxns:      &openTxns{},
+		history:       newSealHistoryFromConfig(),
 	}
+	q.updateStatsLocked()
+	return q
 }
 
 // sealQueue is a helper to seal segments.
 type sealQueue struct {
+	mu            *sync.RWMutex // the underlying locker of cond, read lock is used by read-only operations.
 	cond          *syncutil.ContextCond
 	logger        *log.MLogger
+	pchannel      string
 	wal           *syncutil.Future[wal.WAL]
 	waitForSealed []*segmentAllocManager
 	waitCounter   int // wait counter count the real wait segment count, it is not equal to waitForSealed length.
 	// some segments may be in sealing process.
-	metrics *metricsutil.SegmentAssignMetrics
+	metrics  *metricsutil.SegmentAssignMetrics
+	watcher  *assignmentWatcher
+	notifier *sealedSegmentNotifier
+	openTxns *openTxns
+	history  *sealHistory // the recent seal events of the pchannel for the post-incident debugging.
 }
 
 // AsyncSeal adds a segment into the queue, and will be sealed at next time.
@@ -60,6 +94,7 @@ func (q *sealQueue) AsyncSeal(manager ...*segmentAllocManager) {
 
 	q.waitForSealed = append(q.waitForSealed, manager...)
 	q.waitCounter += len(manager)
+	q.updateStatsLocked()
 }
 
 // SealAllWait seals all segments in the queue.
@@ -74,6 +109,28 @@ func (q *sealQueue) SealAllWait(ctx context.Context) {
 	q.tryToSealSegments(ctx, segments...)
 }
 
+// WaitingBelongs returns the belongs of the given segments waiting in the queue,
+// it's used to signal the seal of the segments released by the done txns.
+func (q *sealQueue) WaitingBelongs(segmentIDs []int64) []stats.SegmentBelongs {
+	released := typeutil.NewSet(segmentIDs...)
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	belongs := make([]stats.SegmentBelongs, 0, len(segmentIDs))
+	for _, segment := range q.waitForSealed {
+		if released.Contain(segment.GetSegmentID()) {
+			belongs = append(belongs, stats.SegmentBelongs{
+				PChannel:     q.pchannel,
+				VChannel:     segment.GetVChannel(),
+				CollectionID: segment.GetCollectionID(),
+				PartitionID:  segment.GetPartitionID(),
+				SegmentID:    segment.GetSegmentID(),
+			})
+		}
+	}
+	return belongs
+}
+
 // IsEmpty returns whether the queue is empty.
 func (q *sealQueue) IsEmpty() bool {
 	q.cond.L.Lock()
@@ -90,6 +147,93 @@ func (q *sealQueue) WaitCounter() int {
 	return q.waitCounter
 }
 
+// WaitState returns the detailed state of the segments waiting for seal in the queue.
+// The fencing function reports whether a fence of the collection is in progress.
+func (q *sealQueue) WaitState(fencing func(collectionID int64) bool) stats.SealWaitState {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	state := stats.SealWaitState{
+		Waiting: q.waitCounter,
+		Sealing: max(q.waitCounter-len(q.waitForSealed), 0),
+		Reasons: make(map[stats.SealWaitReason]int),
+	}
+	clock := resource.Resource().Clock()
+	for _, segment := range q.waitForSealed {
+		if fencing(segment.GetCollectionID()) {
+			state.Reasons[stats.SealWaitReasonFence]++
+		}
+		blocked := false
+		if segment.AckSem() > 0 {
+			state.Reasons[stats.SealWaitReasonUnackedAssignments]++
+			blocked = true
+		}
+		if segment.TxnSem() > 0 {
+			state.Reasons[stats.SealWaitReasonOpenTxns]++
+			blocked = true
+		}
+		if segment.sealPersistPending {
+			state.Reasons[stats.SealWaitReasonPersistBacklog]++
+			blocked = true
+		}
+		if !blocked {
+			state.Ready++
+			continue
+		}
+		if !segment.sealDecision.IsZero() {
+			state.OldestBlockedAge = max(state.OldestBlockedAge, clock.Since(segment.sealDecision))
+		}
+	}
+	return state
+}
+
+// Contains returns whether the segment is waiting for sealed in the queue.
+func (q *sealQueue) Contains(segmentID int64) bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	for _, segment := range q.waitForSealed {
+		if segment.GetSegmentID() == segmentID {
+			return true
+		}
+	}
+	return false
+}
+
+// TakeUnsealable takes the sealed segment out of the queue to be unsealed.
+// The segment is still counted by the wait counter until FinishUnseal is called.
+func (q *sealQueue) TakeUnsealable(segmentID int64) (*segmentAllocManager, error) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	for i, segment := range q.waitForSealed {
+		if segment.GetSegmentID() != segmentID {
+			continue
+		}
+		if err := checkUnsealable(segment); err != nil {
+			return nil, err
+		}
+		q.waitForSealed = append(q.waitForSealed[:i:i], q.waitForSealed[i+1:]...)
+		return segment, nil
+	}
+	// the segment in sealing process is not in the queue, its flush message may be appending.
+	return nil, errors.Wrapf(ErrUnsealNotAllowed, "segment %d is not waiting for flush in the seal queue", segmentID)
+}
+
+// FinishUnseal finishes the unseal of the segment taken by TakeUnsealable.
+// The segment is put back into the queue if the unseal is failed.
+func (q *sealQueue) FinishUnseal(segment *segmentAllocManager, unsealed bool) {
+	q.cond.LockAndBroadcast()
+	defer q.cond.L.Unlock()
+
+	if unsealed {
+		q.waitCounter--
+	} else {
+		q.waitForSealed = append(q.waitForSealed, segment)
+	}
+	q.updateStatsLocked()
+}
+
 // WaitUntilNoWaitSeal waits until no segment in the queue.
 func (q *sealQueue) WaitUntilNoWaitSeal(ctx context.Context) error {
 	// wait until the wait counter becomes 0.
@@ -108,17 +252,57 @@ func (q *sealQueue) tryToSealSegments(ctx context.Context, segments ...*segmentA
 	if len(segments) == 0 {
 		return
 	}
-	undone, sealedSegments := q.transferSegmentStateIntoSealed(ctx, segments...)
+	toSeal := make([]*segmentAllocManager, 0, len(segments))
+	toDiscard := make([]*segmentAllocManager, 0)
+	for _, segment := range segments {
+		if segment.IsDiscard() {
+			toDiscard = append(toDiscard, segment)
+			continue
+		}
+		toSeal = append(toSeal, segment)
+	}
+	undone := q.tryToDiscardSegments(ctx, toDiscard...)
+	forceResolved := q.selectForceResolved(toSeal)
+	// the durable sealed segments come first, so their flush is never starved by the new seals if the catalog write budget is limited.
+	sort.SliceStable(toSeal, func(i, j int) bool {
+		return toSeal[i].GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING &&
+			toSeal[j].GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING
+	})
+	undoneSealed, sealedSegments := q.transferSegmentStateIntoSealed(ctx, newSealPersistBudget(), forceResolved, toSeal...)
+	undone = append(undone, undoneSealed...)
+	q.prewarmFlushTimeTicks(ctx, sealedSegments)
 
 	// send flush message into wal.
 	for collectionID, vchannelSegments := range sealedSegments {
 		for vchannel, segments := range vchannelSegments {
 			for _, segment := range segments {
-				if err := q.sendFlushSegmentsMessageIntoWAL(ctx, collectionID, vchannel, segment); err != nil {
-					q.logger.Warn("fail to send flush message into wal", zap.String("vchannel", vchannel), zap.Int64("collectionID", collectionID), zap.Error(err))
-					undone = append(undone, segments...)
+				// the flush message can only be appended after the sealed state is durable,
+				// otherwise the segment is recovered as growing after crash while the downstream has already flushed it.
+				if !isSealDurable(segment) {
+					q.metrics.ObserveSealAborted(sealAbortReasonNotDurable)
+					q.logger.Warn("segment is not durably sealed, abort the flush and retry it at next sweep", zap.Int64("segmentID", segment.GetSegmentID()), zap.String("state", segment.GetState().String()))
+					undone = append(undone, segment)
 					continue
 				}
+				// TODO: the L0 segment is flushed without flush message until the flusher can consume the deletes of L0 segment.
+				var flushResult *wal.AppendResult
+				var appendDuration time.Duration
+				if segment.IsL0() {
+					q.logger.Info("L0 segment is flushed without flush message", zap.Int64("segmentID", segment.GetSegmentID()))
+				} else {
+					var err error
+					// the failed append may still be durable, so the segment can never be unsealed after trying to append.
+					segment.markFlushMaybeAppended()
+					appendStart := time.Now()
+					flushResult, err = q.sendFlushSegmentsMessageIntoWAL(ctx, collectionID, vchannel, segment)
+					appendDuration = time.Since(appendStart)
+					if err != nil {
+						q.logger.Warn("fail to send flush message into wal", zap.String("vchannel", vchannel), zap.Int64("collectionID", collectionID), zap.Error(err))
+						// only the failed segment is retried, the other segments of the vchannel are flushed independently.
+						undone = append(undone, segment)
+						continue
+					}
+				}
 
 				tx := segment.BeginModification()
 				tx.IntoFlushed()
@@ -127,15 +311,29 @@ func (q *sealQueue) tryToSealSegments(ctx context.Context, segments ...*segmentA
 					undone = append(undone, segment)
 					continue
 				}
+				segment.resetAckBlockedSealCycles()
+				resource.Resource().SegmentAssignStatsManager().ObserveSegmentRemoved(q.pchannel, segment.GetSegmentID())
+				if flushResult != nil {
+					// the flush message is durably appended, push it to the coordinator without waiting for the wal consumption.
+					q.notifier.Notify(segment, flushResult.TimeTick)
+				}
+				q.watcher.Publish(newSegmentAssignmentEvent(AssignmentEventDrop, segment, stats.InsertMetrics{}))
 				q.metrics.ObserveSegmentFlushed(
 					string(segment.SealPolicy()),
-					int64(segment.GetStat().Insert.BinarySize))
+					int64(segment.GetBinarySize()))
+				q.observeTimeToSeal(segment)
+				flushTimeTick := uint64(0)
+				if flushResult != nil {
+					flushTimeTick = flushResult.TimeTick
+				}
+				q.history.Record(newSealEvent(segment, appendDuration, flushTimeTick))
 				q.logger.Info("segment has been flushed",
 					zap.Int64("collectionID", segment.GetCollectionID()),
 					zap.Int64("partitionID", segment.GetPartitionID()),
 					zap.String("vchannel", segment.GetVChannel()),
 					zap.Int64("segmentID", segment.GetSegmentID()),
-					zap.String("sealPolicy", string(segment.SealPolicy())))
+					zap.String("sealPolicy", string(segment.SealPolicy())),
+					zap.String("origin", segment.GetAssignmentOrigin().String()))
 			}
 		}
 	}
@@ -144,11 +342,137 @@ func (q *sealQueue) tryToSealSegments(ctx context.Context, segments ...*segmentA
 	q.waitForSealed = append(q.waitForSealed, undone...)
 	// the undone one should be retried at next time, so the counter should not decrease.
 	q.waitCounter -= (len(segments) - len(undone))
+	q.updateStatsLocked()
 	q.cond.L.Unlock()
 }
 
+// selectForceResolved selects the oldest sealed segments that only wait for the flying acks if the seal queue exceeds its cap.
+// The segments with running txns are never selected, they are released by the txn manager after the txns are done or expired.
+func (q *sealQueue) selectForceResolved(segments []*segmentAllocManager) map[int64]struct{} {
+	maxWaiting := paramtable.Get().StreamingCfg.WALSegmentAssignSealQueueMaxWaiting.GetAsInt()
+	if maxWaiting <= 0 {
+		return nil
+	}
+	q.cond.L.Lock()
+	exceeded := q.waitCounter - maxWaiting
+	q.cond.L.Unlock()
+	if exceeded <= 0 {
+		return nil
+	}
+
+	candidates := lo.Filter(segments, func(segment *segmentAllocManager, _ int) bool {
+		return segment.AckSem() > 0 && segment.TxnSem() == 0
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].sealDecision.Before(candidates[j].sealDecision)
+	})
+	if len(candidates) > exceeded {
+		candidates = candidates[:exceeded]
+	}
+	forceResolved := make(map[int64]struct{}, len(candidates))
+	for _, segment := range candidates {
+		forceResolved[segment.GetSegmentID()] = struct{}{}
+	}
+	return forceResolved
+}
+
+// updateStatsLocked updates the waiting count, approximate memory and persist backlog of the seal queue, the lock should be held.
+func (q *sealQueue) updateStatsLocked() {
+	memoryBytes := uint64(0)
+	persistBacklog := 0
+	for _, segment := range q.waitForSealed {
+		memoryBytes += segment.ApproximateMemorySize()
+		if segment.sealPersistPending {
+			persistBacklog++
+		}
+	}
+	q.metrics.UpdateSealQueue(q.waitCounter, memoryBytes, persistBacklog)
+	resource.Resource().SegmentAssignStatsManager().UpdateSealQueueStats(q.pchannel, stats.SealQueueStats{
+		WaitingCount: q.waitCounter,
+		MemoryBytes:  memoryBytes,
+	})
+}
+
+// tryToDiscardSegments drops the segments without flush, and deletes the segment assignment meta physically.
+// return the undone segments.
+func (q *sealQueue) tryToDiscardSegments(ctx context.Context, segments ...*segmentAllocManager) []*segmentAllocManager {
+	undone := make([]*segmentAllocManager, 0)
+	for _, segment := range segments {
+		logger := q.logger.With(
+			zap.Int64("collectionID", segment.GetCollectionID()),
+			zap.Int64("partitionID", segment.GetPartitionID()),
+			zap.String("vchannel", segment.GetVChannel()),
+			zap.Int64("segmentID", segment.GetSegmentID()),
+			zap.String("sealPolicy", string(segment.SealPolicy())),
+			zap.String("origin", segment.GetAssignmentOrigin().String()))
+
+		if segment.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_DROPPED {
+			// if there'are flying acks or txns, wait them done, delay the discard at next retry.
+			if ackSem, txnSem := segment.AckSem(), segment.TxnSem(); ackSem > 0 || txnSem > 0 {
+				undone = append(undone, segment)
+				logger.Info("segment should be discarded, but there are flying acks or txns, delay it", zap.Int32("ackSem", ackSem), zap.Int32("txnSem", txnSem))
+				continue
+			}
+			tx := segment.BeginModification()
+			tx.IntoDropped()
+			if err := tx.Commit(ctx); err != nil {
+				logger.Warn("drop segment failed at commit", zap.Error(err))
+				undone = append(undone, segment)
+				continue
+			}
+		}
+
+		// the dropped segment can be deleted physically, no flush message is sent.
+		tx := segment.BeginModification()
+		tx.IntoFlushed()
+		if err := tx.Commit(ctx); err != nil {
+			logger.Warn("delete dropped segment failed at commit", zap.Error(err))
+			undone = append(undone, segment)
+			continue
+		}
+		resource.Resource().SegmentAssignStatsManager().ObserveSegmentRemoved(q.pchannel, segment.GetSegmentID())
+		q.watcher.Publish(newSegmentAssignmentEvent(AssignmentEventDiscard, segment, stats.InsertMetrics{}))
+		logger.Info("segment has been discarded without flush")
+	}
+	return undone
+}
+
+// observeTimeToSeal records the time to seal of the flushed segment,
+// the segments sealed by drop operations are excluded, so they don't skew the SLI.
+func (q *sealQueue) observeTimeToSeal(segment *segmentAllocManager) {
+	if policy.IsDropPolicy(segment.SealPolicy()) {
+		return
+	}
+	d, ok := segment.TimeToSeal()
+	if !ok {
+		return
+	}
+	q.metrics.ObserveTimeToSeal(segment.GetCollectionID(), string(segment.SealPolicy()), d)
+	resource.Resource().SegmentAssignStatsManager().ObserveTimeToSeal(segment.GetCollectionID(), d)
+}
+
+// observeWriteAmplification records the write amplification of the segment when it's sealed.
+// The final numbers are persisted in the sealed meta, so they're kept until the segment is flushed.
+func (q *sealQueue) observeWriteAmplification(logger *log.MLogger, segment *segmentAllocManager, stat *stats.SegmentStats) {
+	if stat == nil {
+		return
+	}
+	ratio, ok := stat.WriteAmplification()
+	if !ok {
+		return
+	}
+	q.metrics.ObserveWriteAmplification(segment.GetCollectionID(), ratio)
+	logger.Info("write amplification of sealed segment",
+		zap.Uint64("assignedBinarySize", stat.Insert.BinarySize),
+		zap.Uint64("syncedBinarySize", stat.SyncedBinarySize),
+		zap.Float64("writeAmplification", ratio))
+}
+
 // transferSegmentStateIntoSealed transfers the segment state into sealed.
-func (q *sealQueue) transferSegmentStateIntoSealed(ctx context.Context, segments ...*segmentAllocManager) ([]*segmentAllocManager, map[int64]map[string][]*segmentAllocManager) {
+// The force resolved segments are flushed without waiting for the flying acks.
+// The catalog writes of the seal and the flush are limited by the budget, the segment beyond the budget is retried at next time.
+// The seal is always persisted before the flush message is appended, so the segment is never reported as sealed before it's durable.
+func (q *sealQueue) transferSegmentStateIntoSealed(ctx context.Context, budget *sealPersistBudget, forceResolved map[int64]struct{}, segments ...*segmentAllocManager) ([]*segmentAllocManager, map[int64]map[string][]*segmentAllocManager) {
 	// undone sealed segment should be done at next time.
 	undone := make([]*segmentAllocManager, 0)
 	sealedSegments := make(map[int64]map[string][]*segmentAllocManager)
@@ -158,13 +482,18 @@ func (q *sealQueue) transferSegmentStateIntoSealed(ctx context.Context, segments
 			zap.Int64("partitionID", segment.GetPartitionID()),
 			zap.String("vchannel", segment.GetVChannel()),
 			zap.Int64("segmentID", segment.GetSegmentID()),
-			zap.String("sealPolicy", string(segment.SealPolicy())))
+			zap.String("sealPolicy", string(segment.SealPolicy())),
+			zap.String("origin", segment.GetAssignmentOrigin().String()))
 
 		if segment.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
-			tx := segment.BeginModification()
-			tx.IntoSealed()
-			if err := tx.Commit(ctx); err != nil {
-				logger.Warn("seal segment failed at commit", zap.Error(err))
+			if !budget.TryConsume(segment) {
+				// keep the seal pending in memory, it's still growing in catalog until it's persisted at next time.
+				segment.markSealPersistPending(true)
+				undone = append(undone, segment)
+				logger.Debug("seal of segment is delayed by the catalog write budget")
+				continue
+			}
+			if err := q.persistSeal(ctx, logger, segment); err != nil {
 				undone = append(undone, segment)
 				continue
 			}
@@ -177,9 +506,21 @@ func (q *sealQueue) transferSegmentStateIntoSealed(ctx context.Context, segments
 		// if there'are flying acks, wait them acked, delay the sealed at next retry.
 		ackSem := segment.AckSem()
 		if ackSem > 0 {
-			undone = append(undone, segment)
-			logger.Info("segment has been sealed, but there are flying acks, delay it", zap.Int32("ackSem", ackSem))
-			continue
+			if _, ok := forceResolved[segment.GetSegmentID()]; !ok {
+				undone = append(undone, segment)
+				cycles := segment.observeAckBlockedSealCycle()
+				logger.Info("segment has been sealed, but there are flying acks, delay it", zap.Int32("ackSem", ackSem), zap.Int32("blockedCycles", cycles))
+				q.warnIfAckBlockedTooLong(logger, segment, ackSem, cycles)
+				continue
+			}
+			minTimeTick, maxTimeTick := segment.AssignedTimeTickRange()
+			logger.Warn("seal queue is full, flush the segment without waiting for the flying acks, the inserts of the flying acks are discarded",
+				zap.Int32("ackSem", ackSem),
+				zap.Uint64("minAssignedTimeTick", minTimeTick),
+				zap.Uint64("maxAssignedTimeTick", maxTimeTick),
+				zap.Duration("waited", resource.Resource().Clock().Since(segment.sealDecision)))
+			segment.WithSealPolicy(policy.PolicyNameForce)
+			q.metrics.ObserveSealQueueForceResolved()
 		}
 
 		txnSem := segment.TxnSem()
@@ -189,6 +530,13 @@ func (q *sealQueue) transferSegmentStateIntoSealed(ctx context.Context, segments
 			continue
 		}
 
+		// reserve the budget of the flushed write before the flush message is appended.
+		if !budget.TryConsume(segment) {
+			undone = append(undone, segment)
+			logger.Debug("flush of segment is delayed by the catalog write budget")
+			continue
+		}
+
 		// collect all sealed segments and no flying ack segment.
 		if _, ok := sealedSegments[segment.GetCollectionID()]; !ok {
 			sealedSegments[segment.GetCollectionID()] = make(map[string][]*segmentAllocManager)
@@ -202,25 +550,104 @@ func (q *sealQueue) transferSegmentStateIntoSealed(ctx context.Context, segments
 	return undone, sealedSegments
 }
 
+// persistSeal persists the sealed state of the growing segment into catalog.
+// The success of the catalog write is the hard precondition of the flush message of the segment,
+// so the failed seal is aborted and counted, the segment keeps growing both in memory and catalog and is retried by the next sweep.
+func (q *sealQueue) persistSeal(ctx context.Context, logger *log.MLogger, segment *segmentAllocManager) error {
+	start := time.Now()
+	err := resource.Resource().FaultInjector().Inject(ctx, faultinject.PointSealPersist)
+	if err == nil {
+		tx := segment.BeginModification()
+		tx.IntoSealed()
+		err = tx.Commit(ctx)
+	}
+	if err != nil {
+		reason := sealAbortReasonCatalogWrite
+		if errors.Is(err, ErrCatalogTimeout) {
+			reason = sealAbortReasonCatalogTimeout
+		}
+		q.metrics.ObserveSealAborted(reason)
+		logger.Warn("seal segment aborted at commit, the segment keeps growing and is retried at next sweep", zap.String("reason", reason), zap.Error(err))
+		return err
+	}
+	segment.markSealPersistPending(false)
+	segment.sealPersistDuration = time.Since(start)
+	event := newSegmentAssignmentEvent(AssignmentEventSeal, segment, stats.InsertMetrics{})
+	q.watcher.Publish(event)
+	q.observeWriteAmplification(logger, segment, event.Stat)
+	return nil
+}
+
+// isSealDurable checks if the sealed state of the segment is persisted into catalog.
+// The in-memory state of the segment is only transferred after the catalog write is done,
+// and the seal of the deferred meta is always written into catalog, so the sealed segment is never deferred.
+func isSealDurable(segment *segmentAllocManager) bool {
+	return segment.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED &&
+		!segment.sealPersistPending && !segment.isMetaDeferred()
+}
+
+// warnIfAckBlockedTooLong logs a warning if the seal of the segment is blocked by the flying acks for too many cycles,
+// the warning is repeated every threshold cycles until the segment is flushed.
+func (q *sealQueue) warnIfAckBlockedTooLong(logger *log.MLogger, segment *segmentAllocManager, ackSem int32, cycles int32) {
+	threshold := paramtable.Get().StreamingCfg.WALSegmentAssignSealQueueAckBlockedWarn.GetAsInt32()
+	if threshold <= 0 || cycles%threshold != 0 {
+		return
+	}
+	clock := resource.Resource().Clock()
+	minTimeTick, maxTimeTick := segment.AssignedTimeTickRange()
+	fields := []zap.Field{
+		zap.Int32("ackSem", ackSem),
+		zap.Int32("blockedCycles", cycles),
+		zap.Uint64("minAssignedTimeTick", minTimeTick),
+		zap.Uint64("maxAssignedTimeTick", maxTimeTick),
+		zap.Duration("waited", clock.Since(segment.sealDecision)),
+	}
+	// no insert can be assigned after the segment is sealed,
+	// so the blocking acks are bounded by the assigned time tick range.
+	if minTimeTick > 0 {
+		fields = append(fields,
+			zap.Duration("oldestAckAge", clock.Since(clock.PhysicalTime(minTimeTick))),
+			zap.Duration("newestAckAge", clock.Since(clock.PhysicalTime(maxTimeTick))))
+	}
+	logger.Warn("segment seal is blocked by the flying acks for too many cycles", fields...)
+}
+
 // sendFlushSegmentsMessageIntoWAL sends a flush message into wal.
-func (m *sealQueue) sendFlushSegmentsMessageIntoWAL(ctx context.Context, collectionID int64, vchannel string, segment *segmentAllocManager) error {
+func (m *sealQueue) sendFlushSegmentsMessageIntoWAL(ctx context.Context, collectionID int64, vchannel string, segment *segmentAllocManager) (*wal.AppendResult, error) {
+	header := &message.FlushMessageHeader{
+		CollectionId:   collectionID,
+		PartitionId:    segment.GetPartitionID(),
+		SegmentId:      segment.GetSegmentID(),
+		StorageVersion: segment.GetStorageVersion(),
+		SchemaVersion:  segment.GetSchemaVersion(),
+		Origin:         segment.GetAssignmentOrigin().String(),
+		OpenTxns:       m.openTxns.OfCollection(collectionID),
+		// the consumer that misses the create segment message learns the epoch from the flush message.
+		AssignmentEpoch: segment.GetAssignmentEpoch(),
+	}
+	if stat := segment.GetStat(); stat != nil && stat.PKSketch != nil {
+		// the distinct primary key estimate is carried to the coordinator for the dedup and compaction planning.
+		header.ApproximateDistinctPks = stat.PKSketch.Estimate()
+		header.PkSketch = stat.PKSketch.Marshal()
+	}
 	msg, err := message.NewFlushMessageBuilderV2().
 		WithVChannel(vchannel).
-		WithHeader(&message.FlushMessageHeader{
-			CollectionId: collectionID,
-			PartitionId:  segment.GetPartitionID(),
-			SegmentId:    segment.GetSegmentID(),
-		}).
+		WithHeader(header).
 		WithBody(&message.FlushMessageBody{}).BuildMutable()
 	if err != nil {
-		return errors.Wrap(err, "at create new flush segments message")
+		return nil, errors.Wrap(err, "at create new flush segments message")
+	}
+	// the flush message should be ordered after every insert assigned to the segment,
+	// so the timetick of it is allocated after the max assigned timetick.
+	if _, maxTimeTick := segment.AssignedTimeTickRange(); maxTimeTick > 0 {
+		msg.WithBarrierTimeTick(maxTimeTick)
 	}
 
-	msgID, err := m.wal.Get().Append(ctx, msg)
+	result, err := m.wal.Get().Append(ctx, msg)
 	if err != nil {
 		m.logger.Warn("send flush message into wal failed", zap.Int64("collectionID", collectionID), zap.String("vchannel", vchannel), zap.Int64("segmentID", segment.GetSegmentID()), zap.Error(err))
-		return err
+		return nil, err
 	}
-	m.logger.Info("send flush message into wal", zap.Int64("collectionID", collectionID), zap.String("vchannel", vchannel), zap.Int64("segmentID", segment.GetSegmentID()), zap.Any("msgID", msgID))
-	return nil
+	m.logger.Info("send flush message into wal", zap.Int64("collectionID", collectionID), zap.String("vchannel", vchannel), zap.Int64("segmentID", segment.GetSegmentID()), zap.String("origin", segment.GetAssignmentOrigin().String()), zap.Any("msgID", result.MessageID))
+	return result, nil
 }
@@ -0,0 +1,48 @@
+package manager
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// newSealRandomFromConfig creates the random source of a pchannel with the configured seed,
+// a new seed is generated if it's not configured.
+func newSealRandomFromConfig() *sealRandom {
+	seed := paramtable.Get().StreamingCfg.WALSegmentAssignRandomSeed.GetAsInt64()
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return newSealRandom(seed)
+}
+
+// newSealRandom creates a new random source with the seed.
+func newSealRandom(seed int64) *sealRandom {
+	return &sealRandom{
+		seed: seed,
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// sealRandom is the seeded random source of the randomized decisions of the segment assignment of a pchannel,
+// such as the jitter of the segment size limitation.
+// The seed is logged at recovery, so the decisions can be reproduced by configuring the same seed.
+type sealRandom struct {
+	mu   sync.Mutex
+	seed int64
+	rand *rand.Rand
+}
+
+// Seed returns the seed of the random source.
+func (r *sealRandom) Seed() int64 {
+	return r.seed
+}
+
+// Float64 returns a random number in [0, 1), it's safe to be called concurrently.
+func (r *sealRandom) Float64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rand.Float64()
+}
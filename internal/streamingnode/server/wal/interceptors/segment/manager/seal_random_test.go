@@ -0,0 +1,38 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestSealRandomReproducible(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.DataCoordCfg.SegmentSealProportionJitter.Key, "0.5")
+	defer params.Reset(params.DataCoordCfg.SegmentSealProportionJitter.Key)
+
+	limitations := func(r *sealRandom) []uint64 {
+		sizes := make([]uint64, 0, 10)
+		for i := 0; i < 10; i++ {
+			sizes = append(sizes, policy.GetSegmentLimitationPolicy().GenerateLimitation(r).SegmentSize)
+		}
+		return sizes
+	}
+
+	// the jitter sampled from the same seed is identical.
+	r := newSealRandom(42)
+	assert.Equal(t, int64(42), r.Seed())
+	sizes := limitations(r)
+	assert.Equal(t, sizes, limitations(newSealRandom(42)))
+	assert.NotEqual(t, sizes, limitations(newSealRandom(43)))
+
+	// the configured seed is used by the recovery, otherwise a new seed is generated.
+	params.Save(params.StreamingCfg.WALSegmentAssignRandomSeed.Key, "42")
+	assert.Equal(t, int64(42), newSealRandomFromConfig().Seed())
+	params.Reset(params.StreamingCfg.WALSegmentAssignRandomSeed.Key)
+	assert.NotZero(t, newSealRandomFromConfig().Seed())
+}
@@ -0,0 +1,74 @@
+package manager
+
+import (
+	"sync"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// newSealSweeper creates a new seal sweeper.
+func newSealSweeper() *sealSweeper {
+	return &sealSweeper{}
+}
+
+// sealSweeper selects the partitions evaluated by the seal policy sweep.
+// Re-evaluating every partition at every cycle dominates the cpu on the node with huge amount of mostly-idle partitions,
+// so only the partitions changed since the last sweep are evaluated, and the unchanged ones are evaluated in a rolling pass,
+// 1/N of them per cycle, so the time-based seal policies of the idle partitions are still applied within N cycles.
+type sealSweeper struct {
+	mu                sync.Mutex
+	cycle             uint64
+	configFingerprint string // the fingerprint of the configs at the last sweep, every partition is evaluated if it's changed.
+}
+
+// next begins a new sweep cycle, returns the selector of the partitions evaluated by the cycle.
+func (s *sealSweeper) next() func(pm *partitionSegmentManager) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cycle++
+	fullPassCycles := policy.GetSealSweepFullPassCycles()
+	fingerprint := policy.GetSealPolicyConfigFingerprint()
+	fullPass := fullPassCycles <= 1 || fingerprint != s.configFingerprint
+	s.configFingerprint = fingerprint
+	slot := s.cycle % fullPassCycles
+	return func(pm *partitionSegmentManager) bool {
+		// the dirty mark is always taken, so the partition evaluated by the full pass is not evaluated again by the next cycle.
+		dirty := pm.takeSweepDirty()
+		return dirty || fullPass || uint64(pm.paritionID)%fullPassCycles == slot
+	}
+}
+
+// sweepSealPolicies evaluates the seal policies of the partitions selected by the sweeper,
+// the max binary size of growing segments of them is reconciled with current limit at the same time.
+func (m *PChannelSegmentAllocManager) sweepSealPolicies() {
+	start := resource.Resource().Clock().Now()
+	factor := paramtable.Get().StreamingCfg.WALSegmentAssignMaxSizeReconcileFactor.GetAsFloat()
+	recap := paramtable.Get().StreamingCfg.WALSegmentAssignMaxSizeReconcileRecap.GetAsBool()
+	limit := policy.GetSegmentMaxBinarySizeLimit()
+	// the max binary size of small collections is recalculated by the ingest rate observed since the last sweep.
+	m.managers.RecalculateSmallCollectionMaxBinarySize(limit, start)
+
+	selected := m.sweeper.next()
+	evaluated := 0
+	// the growing binary size of the collections opted out of the auto seal is summed over all partitions but not only the selected ones.
+	autoSealDisabled := make(map[int64]uint64)
+	// the partitions are evaluated in a stable order, so the same state seals the same segments first across runs.
+	m.managers.RangeSorted(func(pm *partitionSegmentManager) {
+		if policy.IsAutoSealDisabled(pm.collectionID) {
+			autoSealDisabled[pm.collectionID] += pm.GrowingBinarySize()
+		}
+		if !selected(pm) {
+			return
+		}
+		evaluated++
+		if factor > 0 {
+			m.helper.AsyncSeal(pm.ReconcileMaxBinarySize(limit, factor, recap)...)
+		}
+		m.helper.AsyncSeal(pm.CollectShouldBeSealed()...)
+	})
+	m.autoSeal.observe(m.logger, autoSealDisabled, start)
+	m.metrics.ObserveSealSweep(evaluated, resource.Resource().Clock().Since(start))
+}
@@ -0,0 +1,248 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/mocks/mock_metastore"
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	internaltypes "github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/idalloc"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+)
+
+// newIdlePartitions creates the partition managers that are only used by the seal sweeper.
+func newIdlePartitions(n int) []*partitionSegmentManager {
+	pms := make([]*partitionSegmentManager, 0, n)
+	for i := 0; i < n; i++ {
+		pms = append(pms, &partitionSegmentManager{paritionID: int64(i), sweepDirty: atomic.NewBool(false)})
+	}
+	return pms
+}
+
+func TestSealSweeperSelection(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignSealSweepFullPassCycles.Key, "10")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignSealSweepFullPassCycles.Key)
+
+	pms := newIdlePartitions(100)
+	sweep := func(s *sealSweeper) []int64 {
+		selected := s.next()
+		evaluated := make([]int64, 0)
+		for _, pm := range pms {
+			if selected(pm) {
+				evaluated = append(evaluated, pm.paritionID)
+			}
+		}
+		return evaluated
+	}
+
+	// the first sweep is always a full pass.
+	s := newSealSweeper()
+	assert.Len(t, sweep(s), 100)
+
+	// every clean partition is evaluated exactly once in 10 cycles.
+	counts := make(map[int64]int)
+	for i := 0; i < 10; i++ {
+		evaluated := sweep(s)
+		assert.Len(t, evaluated, 10)
+		for _, id := range evaluated {
+			counts[id]++
+		}
+	}
+	assert.Len(t, counts, 100)
+	for _, count := range counts {
+		assert.Equal(t, 1, count)
+	}
+
+	// the dirty partition is evaluated by the next cycle and the mark is cleared.
+	pms[1].MarkSweepDirty()
+	assert.Contains(t, sweep(s), int64(1))
+	assert.False(t, pms[1].sweepDirty.Load())
+
+	// the change of the seal policy configs triggers a full pass.
+	paramtable.Get().Save(paramtable.Get().DataCoordCfg.SegmentMaxIdleTime.Key, "1h")
+	defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.SegmentMaxIdleTime.Key)
+	assert.Len(t, sweep(s), 100)
+	assert.Len(t, sweep(s), 10)
+
+	// the rolling pass is disabled if the full pass cycles is 1.
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignSealSweepFullPassCycles.Key, "1")
+	assert.Len(t, sweep(s), 100)
+	assert.Len(t, sweep(s), 100)
+}
+
+func TestSealSweepEvaluatedPartitions(t *testing.T) {
+	initializeTestState(t)
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignSealSweepFullPassCycles.Key, "10")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignSealSweepFullPassCycles.Key)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  2,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_seal_sweep"}, f)
+	assert.NoError(t, err)
+	defer m.Close(context.Background())
+	evaluated := func() float64 {
+		return testutil.ToFloat64(metrics.WALSegmentSealSweepEvaluatedPartitions.WithLabelValues(paramtable.GetStringNodeID(), "v_seal_sweep"))
+	}
+
+	// all recovered partitions are evaluated by the first sweep.
+	m.sweepSealPolicies()
+	assert.Equal(t, float64(3), evaluated())
+
+	// only the partition of the rolling slot is evaluated if nothing is changed.
+	m.sweepSealPolicies()
+	assert.Equal(t, float64(1), evaluated())
+
+	// the partition with assigned inserts is evaluated by the next sweep.
+	_, err = m.AssignSegment(context.Background(), &AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  1,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       100,
+			BinarySize: 100,
+		},
+		TimeTick: tsoutil.GetCurrentTime(),
+	})
+	assert.NoError(t, err)
+	m.sweepSealPolicies()
+	assert.Equal(t, float64(2), evaluated())
+}
+
+func TestSealSweepDeterministicOrder(t *testing.T) {
+	paramtable.Init()
+
+	// the collections and partitions are declared out of order, every segment is expired by the lifetime.
+	expired := time.Now().Add(-2 * paramtable.Get().DataCoordCfg.SegmentMaxLifetime.GetAsDuration(time.Second)).Unix()
+	metas := make([]*streamingpb.SegmentAssignmentMeta, 0)
+	collections := make([]*rootcoordpb.CollectionInfoOnPChannel, 0)
+	for _, collectionID := range []int64{3, 1, 2} {
+		collection := &rootcoordpb.CollectionInfoOnPChannel{
+			CollectionId: collectionID,
+			Vchannel:     fmt.Sprintf("v%d", collectionID),
+		}
+		for _, partitionID := range []int64{collectionID*10 + 3, collectionID*10 + 1, collectionID*10 + 2} {
+			collection.Partitions = append(collection.Partitions, &rootcoordpb.PartitionInfoOnPChannel{PartitionId: partitionID})
+			metas = append(metas, &streamingpb.SegmentAssignmentMeta{
+				CollectionId: collectionID,
+				PartitionId:  partitionID,
+				SegmentId:    partitionID * 100,
+				Vchannel:     collection.Vchannel,
+				State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+				Stat: &streamingpb.SegmentAssignmentStat{
+					MaxBinarySize:         1024 * 1024,
+					CreateTimestamp:       expired,
+					LastModifiedTimestamp: expired,
+				},
+			})
+		}
+		collections = append(collections, collection)
+	}
+
+	// every recovery observes an identical clone of the state.
+	catalog := mock_metastore.NewMockStreamingNodeCataLog(t)
+	catalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, pchannel string) ([]*streamingpb.SegmentAssignmentMeta, error) {
+			cloned := make([]*streamingpb.SegmentAssignmentMeta, 0, len(metas))
+			for _, meta := range metas {
+				cloned = append(cloned, proto.Clone(meta).(*streamingpb.SegmentAssignmentMeta))
+			}
+			return cloned, nil
+		})
+	catalog.EXPECT().ListVChannel(mock.Anything, mock.Anything).Return(nil, nil)
+	catalog.EXPECT().SaveSegmentAssignments(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	mixCoord := idalloc.NewMockRootCoordClient(t)
+	mixCoord.EXPECT().GetPChannelInfo(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, req *rootcoordpb.GetPChannelInfoRequest, opts ...grpc.CallOption) (*rootcoordpb.GetPChannelInfoResponse, error) {
+			cloned := make([]*rootcoordpb.CollectionInfoOnPChannel, 0, len(collections))
+			for _, collection := range collections {
+				cloned = append(cloned, proto.Clone(collection).(*rootcoordpb.CollectionInfoOnPChannel))
+			}
+			return &rootcoordpb.GetPChannelInfoResponse{Collections: cloned}, nil
+		})
+	fMixCoord := syncutil.NewFuture[internaltypes.MixCoordClient]()
+	fMixCoord.Set(mixCoord)
+	resource.InitForTest(t, resource.OptStreamingNodeCatalog(catalog), resource.OptMixCoordClient(fMixCoord))
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  2,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+	sweep := func(pchannel string) []int64 {
+		m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: pchannel}, f)
+		assert.NoError(t, err)
+		defer m.Close(context.Background())
+
+		m.sweepSealPolicies()
+		m.helper.cond.L.Lock()
+		defer m.helper.cond.L.Unlock()
+		sealed := make([]int64, 0, len(m.helper.waitForSealed))
+		for _, segment := range m.helper.waitForSealed {
+			sealed = append(sealed, segment.GetSegmentID())
+		}
+		return sealed
+	}
+
+	// the segments are sealed in the order of collection id and partition id.
+	first := sweep("v_seal_sweep_order_1")
+	assert.Equal(t, []int64{1100, 1200, 1300, 2100, 2200, 2300, 3100, 3200, 3300}, first)
+	assert.Equal(t, first, sweep("v_seal_sweep_order_2"))
+}
+
+// BenchmarkSealSweep benchmarks the seal sweep over 100k mostly-idle partitions.
+func BenchmarkSealSweep(b *testing.B) {
+	paramtable.Init()
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignSealSweepFullPassCycles.Key)
+
+	pms := newIdlePartitions(100000)
+	for _, cycles := range []int{1, 10} {
+		b.Run("full_pass_cycles_"+strconv.Itoa(cycles), func(b *testing.B) {
+			paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignSealSweepFullPassCycles.Key, strconv.Itoa(cycles))
+			s := newSealSweeper()
+			evaluated := 0
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// 1% of the partitions are written between the sweeps.
+				for j := 0; j < len(pms); j += 100 {
+					pms[(j+i)%len(pms)].MarkSweepDirty()
+				}
+				selected := s.next()
+				for _, pm := range pms {
+					if selected(pm) {
+						evaluated++
+					}
+				}
+			}
+			b.ReportMetric(float64(evaluated)/float64(b.N), "partitions/op")
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package manager
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+const (
+	sealedSegmentNotifyQueueSize = 1024
+	sealedSegmentNotifyTimeout   = 5 * time.Second
+)
+
+// newSealedSegmentNotifier creates a new sealed segment notifier of the pchannel.
+// The notifier is a no-op if there's no notifier provided by the resource.
+func newSealedSegmentNotifier(logger *log.MLogger, pchannel types.PChannelInfo) *sealedSegmentNotifier {
+	n := &sealedSegmentNotifier{
+		logger:   logger,
+		pchannel: pchannel,
+		notifier: resource.Resource().SealedSegmentNotifier(),
+	}
+	if n.notifier == nil {
+		return n
+	}
+	n.ctx, n.cancel = context.WithCancel(context.Background())
+	n.ch = make(chan *streamingpb.SealedSegmentNotification, sealedSegmentNotifyQueueSize)
+	n.finished = make(chan struct{})
+	go n.background()
+	return n
+}
+
+// sealedSegmentNotifier pushes the sealed segments of the pchannel to the coordinator in background.
+// The notification is best-effort, it's dropped if the queue is full or the push is failed,
+// so the seal of segment is never blocked or failed by it, the wal is still the source of truth.
+type sealedSegmentNotifier struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	logger   *log.MLogger
+	pchannel types.PChannelInfo
+	notifier resource.SealedSegmentNotifier
+	ch       chan *streamingpb.SealedSegmentNotification
+	finished chan struct{}
+}
+
+// Notify notifies the segment is sealed by the flush message at the time tick, it never blocks.
+func (n *sealedSegmentNotifier) Notify(segment *segmentAllocManager, sealTimeTick uint64) {
+	if n.notifier == nil || !paramtable.Get().StreamingCfg.WALSegmentAssignSealedNotifyEnabled.GetAsBool() {
+		return
+	}
+	notification := &streamingpb.SealedSegmentNotification{
+		Pchannel:     types.NewProtoFromPChannelInfo(n.pchannel),
+		Vchannel:     segment.GetVChannel(),
+		CollectionId: segment.GetCollectionID(),
+		PartitionId:  segment.GetPartitionID(),
+		SegmentId:    segment.GetSegmentID(),
+		SealTimeTick: sealTimeTick,
+		BinarySize:   segment.GetBinarySize(),
+	}
+	if stat := segment.GetStat(); stat != nil {
+		notification.Rows = stat.Insert.Rows
+	}
+	select {
+	case n.ch <- notification:
+	default:
+		n.logger.Warn("sealed segment notification is dropped because the queue is full", zap.Int64("segmentID", segment.GetSegmentID()))
+	}
+}
+
+// background pushes the notifications to the coordinator one by one.
+func (n *sealedSegmentNotifier) background() {
+	defer close(n.finished)
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case notification := <-n.ch:
+			ctx, cancel := context.WithTimeout(n.ctx, sealedSegmentNotifyTimeout)
+			if err := n.notifier.NotifySealedSegment(ctx, notification); err != nil {
+				n.logger.Warn("fail to notify the sealed segment, the coordinator will learn it from wal", zap.Int64("segmentID", notification.GetSegmentId()), zap.Error(err))
+			}
+			cancel()
+		}
+	}
+}
+
+// Close stops the notifier, the pending notifications are dropped.
+func (n *sealedSegmentNotifier) Close() {
+	if n.notifier == nil {
+		return
+	}
+	n.cancel()
+	<-n.finished
+}
@@ -2,6 +2,7 @@ package manager
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -14,11 +15,18 @@ import (
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
 	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/proto/messagespb"
 	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
 )
 
-const dirtyThreshold = 30 * 1024 * 1024 // 30MB
+const (
+	dirtyThreshold = 30 * 1024 * 1024 // 30MB
+
+	// segmentAllocManagerMemoryOverhead is the approximate memory of a segment alloc manager besides its meta.
+	segmentAllocManagerMemoryOverhead = 512
+)
 
 var (
 	ErrSegmentNotGrowing = errors.New("segment is not growing")
@@ -27,6 +35,9 @@ var (
 	ErrTooLargeInsert    = stats.ErrTooLargeInsert
 )
 
+// TooLargeInsertError is the error returned when the insert is too large to be held by a segment.
+type TooLargeInsertError = stats.TooLargeInsertError
+
 // newSegmentAllocManagerFromProto creates a new segment assignment meta from proto.
 func newSegmentAllocManagerFromProto(
 	pchannel types.PChannelInfo,
@@ -34,27 +45,48 @@ func newSegmentAllocManagerFromProto(
 	metrics *metricsutil.SegmentAssignMetrics,
 ) *segmentAllocManager {
 	stat := stats.NewSegmentStatFromProto(inner.Stat)
-	// Growing segment's stat should be registered to stats manager.
-	// Async sealed policy will use it.
-	if inner.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
+	var deleteStat *stats.DeleteSegmentStats
+	if inner.GetLevel() == streamingpb.SegmentAssignmentLevel_SEGMENT_ASSIGNMENT_LEVEL_L0 {
+		// L0 segment only holds the deletes, it's never registered to stats manager.
+		deleteStat = stats.NewDeleteSegmentStatFromProto(inner.Stat)
+		stat = nil
+	} else if inner.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
+		// Growing segment's stat should be registered to stats manager.
+		// Async sealed policy will use it.
 		resource.Resource().SegmentAssignStatsManager().RegisterNewGrowingSegment(stats.SegmentBelongs{
-			CollectionID: inner.GetCollectionId(),
-			PartitionID:  inner.GetPartitionId(),
-			SegmentID:    inner.GetSegmentId(),
-			PChannel:     pchannel.Name,
-			VChannel:     inner.GetVchannel(),
+			CollectionID:   inner.GetCollectionId(),
+			PartitionID:    inner.GetPartitionId(),
+			SegmentID:      inner.GetSegmentId(),
+			PChannel:       pchannel.Name,
+			VChannel:       inner.GetVchannel(),
+			HighPriority:   inner.GetHighPriority(),
+			StorageVersion: inner.GetStorageVersion(),
 		}, inner.GetSegmentId(), stat)
 		stat = nil
 	}
 	metrics.UpdateGrowingSegmentState(streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_UNKNOWN, inner.GetState())
+	resource.Resource().SegmentStateIndex().Update(pchannel.Name, inner.GetSegmentId(), inner.GetState())
 	return &segmentAllocManager{
 		pchannel:      pchannel,
 		inner:         inner,
 		immutableStat: stat,
+		deleteStat:    deleteStat,
 		ackSem:        atomic.NewInt32(0),
 		txnSem:        atomic.NewInt32(0),
 		dirtyBytes:    0,
 		metrics:       metrics,
+		// the blocked cycles are not persisted, the recovered segment starts counting from zero.
+		ackBlockedCycles: atomic.NewInt32(0),
+		// the flush message of the recovered sealed segment may be appended before the crash.
+		flushMaybeAppended: inner.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING &&
+			inner.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+		// all the insert after recovery is later than the recovered origin,
+		// and the insert may be assigned on the non-pending segment before the crash without the origin persisted,
+		// so the origin of a recovered non-pending segment is never observed again.
+		recoveredOrigin: inner.GetOriginMessageId(),
+		originFrozen:    inner.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING,
+
+		persistedPartitionLastAssign: inner.GetPartitionLastAssignTimeTick(),
 	}
 }
 
@@ -67,6 +99,9 @@ func newSegmentAllocManager(
 	vchannel string,
 	metrics *metricsutil.SegmentAssignMetrics,
 	storageVersion int64,
+	schemaVersion uint64,
+	level streamingpb.SegmentAssignmentLevel,
+	highPriority bool,
 ) *segmentAllocManager {
 	return &segmentAllocManager{
 		pchannel: pchannel,
@@ -78,12 +113,19 @@ func newSegmentAllocManager(
 			State:          streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_UNKNOWN,
 			Stat:           nil,
 			StorageVersion: storageVersion,
+			SchemaVersion:  schemaVersion,
+			HighPriority:   highPriority,
+			Level:          level,
+			// the assignments of the segment are signed with the term of the pchannel when it's created,
+			// the epoch is kept by the recovered segment, so the consumer verifies them with the epoch carried by the create segment message.
+			AssignmentEpoch: uint64(pchannel.Term),
 		},
-		immutableStat: nil, // immutable stat can be seen after sealed.
-		ackSem:        atomic.NewInt32(0),
-		dirtyBytes:    0,
-		txnSem:        atomic.NewInt32(0),
-		metrics:       metrics,
+		immutableStat:    nil, // immutable stat can be seen after sealed.
+		ackSem:           atomic.NewInt32(0),
+		dirtyBytes:       0,
+		txnSem:           atomic.NewInt32(0),
+		metrics:          metrics,
+		ackBlockedCycles: atomic.NewInt32(0),
 	}
 }
 
@@ -92,6 +134,8 @@ func newSegmentAllocManager(
 // !!! Not Concurrent Safe
 // The state transfer is as follows:
 // Pending -> Growing -> Sealed -> Flushed.
+// Pending/Growing/Sealed -> Dropped -> Flushed, if the segment is discarded.
+// Sealed -> Growing, only by the emergency unseal if the flush message is never appended.
 //
 // The recovery process is as follows:
 //
@@ -101,23 +145,121 @@ func newSegmentAllocManager(
 // | Growing | Exist | Yes | Insert Message Exist; Seal Message Not Exist | nothing |
 // | Sealed  | Exist | No | Insert Message Exist; Seal Message Maybe Exist | Resend a Seal Message and transfer into Flushed. |
 // | Flushed | Exist | No | Insert Message Exist; Seal Message Exist | Already physically deleted, nothing to do |
+// | Dropped | Maybe Exist | No | Insert Message Exist; Seal Message Not Exist | Delete it physically without flush. |
 type segmentAllocManager struct {
 	pchannel      types.PChannelInfo
 	inner         *streamingpb.SegmentAssignmentMeta
-	immutableStat *stats.SegmentStats // after sealed or flushed, the stat is immutable and cannot be seen by stats manager.
-	ackSem        *atomic.Int32       // the ackSem is increased when segment allocRows, decreased when the segment is acked.
-	dirtyBytes    uint64              // records the dirty bytes that didn't persist.
-	txnSem        *atomic.Int32       // the runnint txn count of the segment.
+	immutableStat *stats.SegmentStats       // after sealed or flushed, the stat is immutable and cannot be seen by stats manager.
+	deleteStat    *stats.DeleteSegmentStats // the stat of L0 segment, it's held by the segment itself but not the stats manager.
+	ackSem        *atomic.Int32             // the ackSem is increased when segment allocRows, decreased when the segment is acked.
+	dirtyBytes    uint64                    // records the dirty bytes that didn't persist.
+	txnSem        *atomic.Int32             // the runnint txn count of the segment.
 	metrics       *metricsutil.SegmentAssignMetrics
 	sealPolicy    policy.PolicyName
+	sealDecision  time.Time // the local time when the segment is decided to be sealed.
+	discard       bool      // the segment should be discarded without flush.
+
+	// the count of seal cycles that the sealed segment is blocked by the flying acks, reset after the segment is flushed.
+	// It's read by the snapshot concurrently, so it's atomic.
+	ackBlockedCycles *atomic.Int32
+
+	// the flush message of the segment may be appended into wal, it's set before the flush message is appended,
+	// so the segment can never be unsealed even if the append is failed, the failed append may still be durable.
+	flushMaybeAppended bool
+
+	// the segment is decided to be sealed but the seal is not persisted yet because the catalog write budget of the seal cycle is exhausted,
+	// it's still growing in catalog, so it's recovered as growing and sealed again if the node crashes.
+	sealPersistPending bool
+
+	// the duration of the catalog write of the sealed state, it's unknown (zero) for the segment sealed before recovery.
+	sealPersistDuration time.Duration
+
+	// the timetick range of the assigned insert since the segment manager is created,
+	// it's not persisted, so the range is unknown (zero) for the recovered segment before new insert is assigned.
+	minAssignedTimeTick uint64
+	maxAssignedTimeTick uint64
+
+	// the time tick of the migrate segments message that moves the growing segment into the partition,
+	// the insert of the new partition not newer than it is redone, so it's never applied before the segment is re-keyed by the consumer.
+	// It's not persisted, the recovered segment is re-labeled by the catalog already.
+	migratedTimeTick uint64
+
+	// the origin is the first durable insert message of the segment, it's observed after the insert is appended,
+	// so it's protected by its own mutex but not the partition manager.
+	originMu        sync.Mutex
+	originMessageID message.MessageID     // the origin observed since the segment manager is created.
+	recoveredOrigin *messagespb.MessageID // the origin recovered from meta, nil if it's lost or not observed before recovery.
+	originDirty     bool                  // the observed origin is not persisted yet.
+	originFrozen    bool                  // the origin is recovered or lost, never observe it again.
+
+	// the timetick of the last assigned insert of the partition, it's shared by all segments of the partition,
+	// and stamped on every write of the meta, so the quiescence of the partition survives the restart.
+	partitionLastAssign          *atomic.Uint64
+	persistedPartitionLastAssign uint64 // the last assign timetick of the partition that is persisted with the meta.
+
+	partitionSweepDirty *atomic.Bool // the seal policy sweep dirty mark of the partition, it's shared by all segments of the partition.
+
+	// the meta of the new segment is held in memory until its first insert is durably appended or it's sealed,
+	// the catalog write is issued by the appended insert outside the partition lock, so it's protected by its own mutex.
+	// The flag is only modified with the mutex held, but it can be read without the lock.
+	deferMu        sync.Mutex
+	metaDeferred   atomic.Bool
+	metaPersisting atomic.Bool // the deferred meta is being persisted in background.
+
+	// the segment assignment of the pchannel is closed, the ack of the result assigned on the segment is a misuse after that.
+	closed atomic.Bool
+}
+
+// bindPartitionLastAssign binds the last assign timetick of the partition that the segment belongs to.
+func (s *segmentAllocManager) bindPartitionLastAssign(lastAssign *atomic.Uint64) {
+	s.partitionLastAssign = lastAssign
+}
+
+// bindPartitionSweepDirty binds the seal policy sweep dirty mark of the partition that the segment belongs to.
+func (s *segmentAllocManager) bindPartitionSweepDirty(dirty *atomic.Bool) {
+	s.partitionSweepDirty = dirty
+}
+
+// markPartitionSweepDirty marks the partition of the segment is changed, so it's evaluated by the next seal policy sweep.
+func (s *segmentAllocManager) markPartitionSweepDirty() {
+	if s.partitionSweepDirty != nil {
+		s.partitionSweepDirty.Store(true)
+	}
+}
+
+// isPartitionLastAssignDirty returns whether the last assign timetick of the partition is newer than the persisted one of the growing segment.
+func (s *segmentAllocManager) isPartitionLastAssignDirty() bool {
+	return s.inner.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING &&
+		s.partitionLastAssign != nil && s.partitionLastAssign.Load() > s.persistedPartitionLastAssign
 }
 
 // WithSealPolicy sets the seal policy of the segment assignment meta.
 func (s *segmentAllocManager) WithSealPolicy(policy policy.PolicyName) *segmentAllocManager {
 	s.sealPolicy = policy
+	s.markSealDecision()
+	return s
+}
+
+// WithDiscard marks the segment should be discarded without flush.
+func (s *segmentAllocManager) WithDiscard() *segmentAllocManager {
+	s.discard = true
+	s.sealPolicy = policy.PolicyNamePartitionDiscarded
+	s.markSealDecision()
 	return s
 }
 
+// markSealDecision records the time of the first seal decision of the segment.
+func (s *segmentAllocManager) markSealDecision() {
+	if s.sealDecision.IsZero() {
+		s.sealDecision = resource.Resource().Clock().Now()
+	}
+}
+
+// IsDiscard returns whether the segment should be discarded without flush.
+func (s *segmentAllocManager) IsDiscard() bool {
+	return s.discard
+}
+
 // SealPolicy returns the seal policy of the segment assignment meta.
 func (s *segmentAllocManager) SealPolicy() policy.PolicyName {
 	return s.sealPolicy
@@ -142,6 +284,41 @@ func (s *segmentAllocManager) GetStorageVersion() int64 {
 	return s.inner.GetStorageVersion()
 }
 
+// GetAssignmentEpoch returns the epoch that the assignments of the segment are signed with, 0 if unsigned.
+func (s *segmentAllocManager) GetAssignmentEpoch() uint64 {
+	return s.inner.GetAssignmentEpoch()
+}
+
+// GetSchemaVersion returns the schema version that the segment is created under.
+func (s *segmentAllocManager) GetSchemaVersion() uint64 {
+	return s.inner.GetSchemaVersion()
+}
+
+// GetAssignmentOrigin returns how the segment is created or sealed.
+// The meta written before the origin is introduced is recovered as normal.
+func (s *segmentAllocManager) GetAssignmentOrigin() streamingpb.SegmentAssignmentOrigin {
+	return s.inner.GetOrigin()
+}
+
+// markRecoveryReconciled labels the recovered segment that is reconciled by the recovery,
+// the label is persisted by the next modification of the segment.
+// The segment that is already labeled keeps its origin.
+func (s *segmentAllocManager) markRecoveryReconciled() {
+	if s.inner.GetOrigin() == streamingpb.SegmentAssignmentOrigin_SEGMENT_ASSIGNMENT_ORIGIN_NORMAL {
+		s.inner.Origin = streamingpb.SegmentAssignmentOrigin_SEGMENT_ASSIGNMENT_ORIGIN_RECOVERY_RECONCILED
+	}
+}
+
+// IsHighPriority returns whether the segment is dedicated to the high priority inserts.
+func (s *segmentAllocManager) IsHighPriority() bool {
+	return s.inner.GetHighPriority()
+}
+
+// IsL0 returns whether the segment is a L0 segment that only holds the deletes.
+func (s *segmentAllocManager) IsL0() bool {
+	return s.inner.GetLevel() == streamingpb.SegmentAssignmentLevel_SEGMENT_ASSIGNMENT_LEVEL_L0
+}
+
 // GetVChannel returns the vchannel of the segment assignment meta.
 func (s *segmentAllocManager) GetVChannel() string {
 	return s.inner.GetVchannel()
@@ -156,13 +333,124 @@ func (s *segmentAllocManager) GetState() streamingpb.SegmentAssignmentState {
 // Pending segment will return nil.
 // Growing segment will return a snapshot.
 // Sealed segment will return the final.
+// L0 segment will always return nil, use GetDeleteStat instead.
 func (s *segmentAllocManager) GetStat() *stats.SegmentStats {
+	if s.IsL0() {
+		return nil
+	}
 	if s.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
 		return resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(s.GetSegmentID())
 	}
 	return s.immutableStat
 }
 
+// GetDeleteStat returns a copy of the stat of L0 segment.
+// Pending or non-L0 segment will return nil.
+func (s *segmentAllocManager) GetDeleteStat() *stats.DeleteSegmentStats {
+	if s.deleteStat == nil {
+		return nil
+	}
+	return s.deleteStat.Copy()
+}
+
+// GetBinarySize returns the assigned binary size of the segment, the deleted binary size for L0 segment.
+func (s *segmentAllocManager) GetBinarySize() uint64 {
+	if s.IsL0() {
+		if s.deleteStat == nil {
+			return 0
+		}
+		return s.deleteStat.Delete.BinarySize
+	}
+	if stat := s.GetStat(); stat != nil {
+		return stat.Insert.BinarySize
+	}
+	return 0
+}
+
+// AssignedTimeTickRange returns the timetick range of the assigned insert of the segment,
+// zero is returned if no insert is assigned since the segment manager is created.
+func (s *segmentAllocManager) AssignedTimeTickRange() (uint64, uint64) {
+	return s.minAssignedTimeTick, s.maxAssignedTimeTick
+}
+
+// IsOlderThan checks if all assigned insert of the segment are not newer than the timetick.
+// The assigned timetick range is not persisted, so the recovered segment without new insert falls back to
+// the last modified time of its stat, which is persisted in seconds, so it's rounded up to be conservative.
+// The segment without any insert is never older than any timetick, there's nothing to flush.
+func (s *segmentAllocManager) IsOlderThan(timeTick uint64) bool {
+	if s.maxAssignedTimeTick != 0 {
+		return s.maxAssignedTimeTick <= timeTick
+	}
+	stat := s.GetStat()
+	if stat == nil || stat.Insert.Rows == 0 || stat.LastModifiedTime.IsZero() {
+		return false
+	}
+	return !stat.LastModifiedTime.Add(time.Second).After(resource.Resource().Clock().PhysicalTime(timeTick))
+}
+
+// TimeToSeal returns the duration from the first assigned insert to the seal decision of the segment.
+// false is returned if the segment is not decided to be sealed or no insert is assigned since the segment manager is created.
+func (s *segmentAllocManager) TimeToSeal() (time.Duration, bool) {
+	if s.sealDecision.IsZero() || s.minAssignedTimeTick == 0 {
+		return 0, false
+	}
+	d := s.sealDecision.Sub(resource.Resource().Clock().PhysicalTime(s.minAssignedTimeTick))
+	if d < 0 {
+		// the timetick is allocated by the tso of other node, so the clock skew may lead to a negative duration.
+		d = 0
+	}
+	return d, true
+}
+
+// ObserveAppended observes the message id of an appended insert message assigned on the segment,
+// the earliest one is kept as the origin of the segment, and the deferred meta of the segment is persisted.
+// It's called only after the insert message is appended successfully,
+// so the insert that fails to be appended never becomes the origin, the next durable one does.
+func (s *segmentAllocManager) ObserveAppended(msgID message.MessageID) {
+	s.observeOrigin(msgID)
+	// the deferred meta is persisted by the first durable insert, the failed one is retried by the next.
+	s.asyncPersistDeferredMeta()
+}
+
+// observeOrigin keeps the earliest appended insert message as the origin of the segment.
+func (s *segmentAllocManager) observeOrigin(msgID message.MessageID) {
+	s.originMu.Lock()
+	defer s.originMu.Unlock()
+
+	if s.originFrozen {
+		return
+	}
+	if s.originMessageID != nil && s.originMessageID.LTE(msgID) {
+		return
+	}
+	// the appends are concurrent, so the later observed insert may be the earlier one in wal.
+	s.originMessageID = msgID
+	s.originDirty = true
+	log.Info("origin of segment is observed",
+		zap.String("pchannel", s.pchannel.Name),
+		zap.String("vchannel", s.GetVChannel()),
+		zap.Int64("collectionID", s.GetCollectionID()),
+		zap.Int64("partitionID", s.GetPartitionID()),
+		zap.Int64("segmentID", s.GetSegmentID()),
+		zap.Stringer("messageID", msgID))
+}
+
+// GetOriginMessageID returns the message id of the first durable insert message of the segment.
+// nil is returned if the origin is not observed yet or lost.
+func (s *segmentAllocManager) GetOriginMessageID() *messagespb.MessageID {
+	s.originMu.Lock()
+	defer s.originMu.Unlock()
+	return s.getOriginMessageIDLocked()
+}
+
+// getOriginMessageIDLocked returns the origin message id with the lock held.
+func (s *segmentAllocManager) getOriginMessageIDLocked() *messagespb.MessageID {
+	if s.originMessageID != nil {
+		return &messagespb.MessageID{Id: s.originMessageID.Marshal()}
+	}
+	return s.recoveredOrigin
+}
+
 // AckSem returns the ack sem.
 func (s *segmentAllocManager) AckSem() int32 {
 	return s.ackSem.Load()
@@ -173,6 +461,49 @@ func (s *segmentAllocManager) TxnSem() int32 {
 	return s.txnSem.Load()
 }
 
+// AckBlockedSealCycles returns the count of seal cycles that the sealed segment is blocked by the flying acks.
+func (s *segmentAllocManager) AckBlockedSealCycles() int32 {
+	return s.ackBlockedCycles.Load()
+}
+
+// observeAckBlockedSealCycle records a seal cycle that the sealed segment is blocked by the flying acks, return the blocked cycles.
+func (s *segmentAllocManager) observeAckBlockedSealCycle() int32 {
+	return s.ackBlockedCycles.Inc()
+}
+
+// resetAckBlockedSealCycles resets the blocked cycles after the segment is flushed.
+func (s *segmentAllocManager) resetAckBlockedSealCycles() {
+	s.ackBlockedCycles.Store(0)
+}
+
+// markFlushMaybeAppended marks the flush message of the segment may be appended into wal.
+func (s *segmentAllocManager) markFlushMaybeAppended() {
+	s.flushMaybeAppended = true
+}
+
+// markSealPersistPending marks whether the seal of the segment is waiting for the catalog write budget.
+func (s *segmentAllocManager) markSealPersistPending(pending bool) {
+	s.sealPersistPending = pending
+}
+
+// IsFlushMaybeAppended returns whether the flush message of the segment may be appended into wal.
+func (s *segmentAllocManager) IsFlushMaybeAppended() bool {
+	return s.flushMaybeAppended
+}
+
+// resetSealDecision forgets the seal decision of the segment after it's unsealed,
+// so the segment can be sealed by the policies again.
+func (s *segmentAllocManager) resetSealDecision() {
+	s.sealPolicy = ""
+	s.sealDecision = time.Time{}
+	s.ackBlockedCycles.Store(0)
+}
+
+// ApproximateMemorySize returns the approximate memory used by the segment alloc manager.
+func (s *segmentAllocManager) ApproximateMemorySize() uint64 {
+	return segmentAllocManagerMemoryOverhead + uint64(proto.Size(s.inner))
+}
+
 // AllocRows ask for rows from current segment.
 // Only growing and not fenced segment can alloc rows.
 func (s *segmentAllocManager) AllocRows(ctx context.Context, req *AssignSegmentRequest) (*AssignSegmentResult, error) {
@@ -180,8 +511,8 @@ func (s *segmentAllocManager) AllocRows(ctx context.Context, req *AssignSegmentR
 	if s.inner.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
 		return nil, ErrSegmentNotGrowing
 	}
-	if req.TimeTick <= s.inner.GetStat().CreateSegmentTimeTick {
-		// The incoming insert request's timetick is less than the segment's create time tick,
+	if req.TimeTick <= s.inner.GetStat().CreateSegmentTimeTick || req.TimeTick <= s.migratedTimeTick {
+		// The incoming insert request's timetick is less than the segment's create time tick or the time tick it's migrated into the partition,
 		// return ErrTimeTickTooOld and reallocate new timetick.
 		return nil, ErrTimeTickTooOld
 	}
@@ -192,25 +523,90 @@ func (s *segmentAllocManager) AllocRows(ctx context.Context, req *AssignSegmentR
 	}
 	s.dirtyBytes += req.InsertMetrics.BinarySize
 	s.ackSem.Inc()
+	if s.minAssignedTimeTick == 0 || req.TimeTick < s.minAssignedTimeTick {
+		s.minAssignedTimeTick = req.TimeTick
+	}
+	if req.TimeTick > s.maxAssignedTimeTick {
+		s.maxAssignedTimeTick = req.TimeTick
+	}
 
 	// register the txn session cleanup to the segment.
 	if req.TxnSession != nil {
-		s.txnSem.Inc()
-		req.TxnSession.RegisterCleanup(func() { s.txnSem.Dec() }, req.TimeTick)
+		// the segment of a txn non-blocking seal collection is never held by the txn,
+		// the txn may span a seal boundary, and the writes are still recorded into the commit summary.
+		if policy.IsTxnBlockingSeal(s.GetCollectionID()) {
+			s.txnSem.Inc()
+			// the txn done changes the seal eligibility of the segment, so the partition is evaluated again.
+			req.TxnSession.PinSegment(s.GetSegmentID(), func() {
+				s.txnSem.Dec()
+				s.markPartitionSweepDirty()
+			}, req.TimeTick)
+		}
+		req.TxnSession.RecordSegmentWrite(s.GetCollectionID(), s.GetSegmentID(), req.InsertMetrics.Rows, req.InsertMetrics.BinarySize, req.TimeTick)
+	}
+
+	// the timetick of the insert in a txn is rewritten into the commit timetick at the consume side, so it's signed without the timetick.
+	signedTimeTick := req.TimeTick
+	if req.TxnSession != nil {
+		signedTimeTick = 0
 	}
 
 	// persist stats if too dirty.
 	s.persistStatsIfTooDirty(ctx)
 	return &AssignSegmentResult{
-		SegmentID:   s.GetSegmentID(),
-		Acknowledge: s.ackSem,
+		SegmentID:       s.GetSegmentID(),
+		SchemaVersion:   s.GetSchemaVersion(),
+		InsertMetrics:   req.InsertMetrics,
+		Checksum:        message.SegmentAssignmentChecksum(s.GetAssignmentEpoch(), s.GetCollectionID(), s.GetPartitionID(), s.GetSegmentID(), signedTimeTick),
+		Acknowledge:     s.ackSem,
+		observeAppended: s.ObserveAppended,
+		pkHashes:        req.PKHashes,
+		closed:          &s.closed,
+		metrics:         s.metrics,
+	}, nil
+}
+
+// AllocDeletes ask for the deletes from current L0 segment.
+// Only growing L0 segment can alloc deletes, the L0 segment has no capacity limit.
+// There's no create segment message of L0 segment in wal, so the delete is never too old for it.
+func (s *segmentAllocManager) AllocDeletes(ctx context.Context, req *AssignDeleteSegmentRequest) (*AssignDeleteSegmentResult, error) {
+	if s.inner.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
+		return nil, ErrSegmentNotGrowing
+	}
+	s.deleteStat.AllocDeletes(req.DeleteMetrics, resource.Resource().Clock().Now())
+	s.dirtyBytes += req.DeleteMetrics.BinarySize
+	s.ackSem.Inc()
+	if s.minAssignedTimeTick == 0 || req.TimeTick < s.minAssignedTimeTick {
+		s.minAssignedTimeTick = req.TimeTick
+	}
+	if req.TimeTick > s.maxAssignedTimeTick {
+		s.maxAssignedTimeTick = req.TimeTick
+	}
+
+	// persist stats if too dirty.
+	s.persistStatsIfTooDirty(ctx)
+	return &AssignDeleteSegmentResult{
+		SegmentID:     s.GetSegmentID(),
+		DeleteMetrics: req.DeleteMetrics,
+		Acknowledge:   s.ackSem,
 	}, nil
 }
 
 // Snapshot returns the snapshot of the segment assignment meta.
 func (s *segmentAllocManager) Snapshot() *streamingpb.SegmentAssignmentMeta {
 	copied := proto.Clone(s.inner).(*streamingpb.SegmentAssignmentMeta)
-	copied.Stat = stats.NewProtoFromSegmentStat(s.GetStat())
+	if s.IsL0() {
+		copied.Stat = stats.NewProtoFromDeleteSegmentStat(s.deleteStat)
+	} else {
+		copied.Stat = stats.NewProtoFromSegmentStat(s.GetStat())
+	}
+	copied.OriginMessageId = s.GetOriginMessageID()
+	// every write of the meta is stamped with the term of current owner,
+	// so the stale writer can be detected when recovering.
+	copied.Term = s.pchannel.Term
+	if s.partitionLastAssign != nil {
+		copied.PartitionLastAssignTimeTick = max(copied.GetPartitionLastAssignTimeTick(), s.partitionLastAssign.Load())
+	}
 	return copied
 }
 
@@ -222,20 +618,42 @@ func (s *segmentAllocManager) IsDirtyEnough() bool {
 
 // PersisteStatsIfTooDirty persists the stats if the dirty bytes is too large.
 func (s *segmentAllocManager) persistStatsIfTooDirty(ctx context.Context) {
-	if s.inner.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
+	if s.inner.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING || s.isMetaDeferred() {
 		return
 	}
-	if s.dirtyBytes < dirtyThreshold {
+	originDirty := s.isOriginDirty()
+	if s.dirtyBytes < dirtyThreshold && !originDirty {
 		return
 	}
-	if err := resource.Resource().StreamingNodeCatalog().SaveSegmentAssignments(ctx, s.pchannel.Name, map[int64]*streamingpb.SegmentAssignmentMeta{
-		s.GetSegmentID(): s.Snapshot(),
+	snapshot := s.Snapshot()
+	if err := saveSegmentAssignments(ctx, catalogPathAssign, s.metrics, s.pchannel.Name, map[int64]*streamingpb.SegmentAssignmentMeta{
+		s.GetSegmentID(): snapshot,
 	}); err != nil {
 		log.Warn("failed to persist stats of segment", zap.Int64("segmentID", s.GetSegmentID()), zap.Error(err))
+	} else {
+		s.persistedPartitionLastAssign = snapshot.GetPartitionLastAssignTimeTick()
+		if originDirty {
+			s.markOriginPersisted()
+		}
 	}
 	s.dirtyBytes = 0
 }
 
+// isOriginDirty returns whether the observed origin is not persisted yet.
+func (s *segmentAllocManager) isOriginDirty() bool {
+	s.originMu.Lock()
+	defer s.originMu.Unlock()
+	return s.originDirty
+}
+
+// markOriginPersisted marks the observed origin is persisted.
+// The origin may be updated by a concurrent append, but it's persisted by the next modification of the segment, at least the seal.
+func (s *segmentAllocManager) markOriginPersisted() {
+	s.originMu.Lock()
+	defer s.originMu.Unlock()
+	s.originDirty = false
+}
+
 // BeginModification begins the modification of the segment assignment meta.
 // Do a copy of the segment assignment meta, update the remote meta storage, than modifies the original.
 func (s *segmentAllocManager) BeginModification() *mutableSegmentAssignmentMeta {
@@ -259,13 +677,20 @@ func (m *mutableSegmentAssignmentMeta) IntoPending() {
 	m.modifiedCopy.State = streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING
 }
 
+// WithOrigin labels the origin of the segment if the segment is not labeled yet.
+func (m *mutableSegmentAssignmentMeta) WithOrigin(origin streamingpb.SegmentAssignmentOrigin) {
+	if m.modifiedCopy.Origin == streamingpb.SegmentAssignmentOrigin_SEGMENT_ASSIGNMENT_ORIGIN_NORMAL {
+		m.modifiedCopy.Origin = origin
+	}
+}
+
 // IntoGrowing transfers the segment assignment meta into growing state.
 func (m *mutableSegmentAssignmentMeta) IntoGrowing(limitation *policy.SegmentLimitation, createSegmentTimeTick uint64) {
 	if m.modifiedCopy.State != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING {
 		panic("tranfer state to growing from non-pending state")
 	}
 	m.modifiedCopy.State = streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING
-	now := time.Now().UnixNano()
+	now := resource.Resource().Clock().Now().Unix()
 	m.modifiedCopy.Stat = &streamingpb.SegmentAssignmentStat{
 		MaxBinarySize:         limitation.SegmentSize,
 		CreateTimestamp:       now,
@@ -274,6 +699,20 @@ func (m *mutableSegmentAssignmentMeta) IntoGrowing(limitation *policy.SegmentLim
 	}
 }
 
+// IntoL0Growing transfers the L0 segment assignment meta into growing state.
+// The L0 segment has no max binary size, it's never sealed by size.
+func (m *mutableSegmentAssignmentMeta) IntoL0Growing() {
+	if m.modifiedCopy.State != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING {
+		panic("tranfer state to growing from non-pending state")
+	}
+	m.modifiedCopy.State = streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING
+	now := resource.Resource().Clock().Now().Unix()
+	m.modifiedCopy.Stat = &streamingpb.SegmentAssignmentStat{
+		CreateTimestamp:       now,
+		LastModifiedTimestamp: now,
+	}
+}
+
 // IntoSealed transfers the segment assignment meta into sealed state.
 func (m *mutableSegmentAssignmentMeta) IntoSealed() {
 	if m.modifiedCopy.State != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
@@ -282,38 +721,115 @@ func (m *mutableSegmentAssignmentMeta) IntoSealed() {
 	m.modifiedCopy.State = streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED
 }
 
+// IntoUnsealed transfers the sealed segment assignment meta back into growing state.
+// Only the sealed segment whose flush message is never appended can be unsealed,
+// the flushed segment or the segment with flush message appended is immutable forever.
+func (m *mutableSegmentAssignmentMeta) IntoUnsealed() {
+	if m.modifiedCopy.State != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED {
+		panic("tranfer state to growing from non-sealed state")
+	}
+	if m.original.IsL0() || m.original.IsFlushMaybeAppended() {
+		panic("tranfer state to growing from sealed state with flush message appended")
+	}
+	if m.modifiedCopy.Stat == nil {
+		panic("tranfer state to growing from sealed state without stat")
+	}
+	m.modifiedCopy.State = streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING
+	// the create time tick is not kept by the immutable stat, recover it from the meta.
+	m.modifiedCopy.Stat.CreateSegmentTimeTick = m.original.inner.GetStat().GetCreateSegmentTimeTick()
+}
+
+// IntoDropped transfers the segment assignment meta into dropped state.
+// The dropped segment will never be flushed.
+func (m *mutableSegmentAssignmentMeta) IntoDropped() {
+	if m.modifiedCopy.State != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING &&
+		m.modifiedCopy.State != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING &&
+		m.modifiedCopy.State != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED {
+		panic("tranfer state to dropped from non-pending, non-growing or non-sealed state")
+	}
+	m.modifiedCopy.State = streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_DROPPED
+}
+
 // IntoFlushed transfers the segment assignment meta into flushed state.
 // Will be delted physically when transfer into flushed state.
 func (m *mutableSegmentAssignmentMeta) IntoFlushed() {
-	if m.modifiedCopy.State != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED {
-		panic("tranfer state to flushed from non-sealed state")
+	if m.modifiedCopy.State != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED &&
+		m.modifiedCopy.State != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_DROPPED {
+		panic("tranfer state to flushed from non-sealed or non-dropped state")
 	}
 	m.modifiedCopy.State = streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED
 }
 
+// catalogPath returns the path of the modification,
+// the creation of a new growing segment is on the assignment path, and the others are on the sweep path.
+func (m *mutableSegmentAssignmentMeta) catalogPath() catalogPath {
+	switch m.modifiedCopy.GetState() {
+	case streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING:
+		return catalogPathAssign
+	case streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING:
+		if m.original.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED {
+			return catalogPathAssign
+		}
+	}
+	return catalogPathSweep
+}
+
 // Commit commits the modification.
 func (m *mutableSegmentAssignmentMeta) Commit(ctx context.Context) error {
-	if err := resource.Resource().StreamingNodeCatalog().SaveSegmentAssignments(ctx, m.original.pchannel.Name, map[int64]*streamingpb.SegmentAssignmentMeta{
-		m.modifiedCopy.SegmentId: m.modifiedCopy,
-	}); err != nil {
-		return err
+	m.original.deferMu.Lock()
+	defer m.original.deferMu.Unlock()
+
+	// the modification of the deferred meta is only applied in memory,
+	// the segment is known by the coordinator only, and it's adopted by the recovery if the node crashes.
+	// But the seal of the deferred meta is always written into catalog,
+	// so the flush message is never appended before the sealed state is durable.
+	deferred := m.original.metaDeferred.Load() && !m.isSealOrFlush()
+	if !deferred {
+		if err := saveSegmentAssignments(ctx, m.catalogPath(), m.original.metrics, m.original.pchannel.Name, map[int64]*streamingpb.SegmentAssignmentMeta{
+			m.modifiedCopy.SegmentId: m.modifiedCopy,
+		}); err != nil {
+			return err
+		}
 	}
-	if m.original.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING &&
+	if m.original.IsL0() {
+		// the stat of L0 segment is held by itself, the stats manager only tracks the insert.
+		if m.original.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING &&
+			m.modifiedCopy.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
+			m.original.deleteStat = stats.NewDeleteSegmentStatFromProto(m.modifiedCopy.Stat)
+		}
+	} else if m.original.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING &&
 		m.modifiedCopy.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
 		// if the state transferred into growing, register the stats to stats manager.
 		resource.Resource().SegmentAssignStatsManager().RegisterNewGrowingSegment(stats.SegmentBelongs{
-			CollectionID: m.original.GetCollectionID(),
-			PartitionID:  m.original.GetPartitionID(),
-			SegmentID:    m.original.GetSegmentID(),
-			PChannel:     m.original.pchannel.Name,
-			VChannel:     m.original.GetVChannel(),
+			CollectionID:   m.original.GetCollectionID(),
+			PartitionID:    m.original.GetPartitionID(),
+			SegmentID:      m.original.GetSegmentID(),
+			PChannel:       m.original.pchannel.Name,
+			VChannel:       m.original.GetVChannel(),
+			HighPriority:   m.original.IsHighPriority(),
+			StorageVersion: m.modifiedCopy.GetStorageVersion(),
 		}, m.original.GetSegmentID(), stats.NewSegmentStatFromProto(m.modifiedCopy.Stat))
+		// the unsealed segment's stat is held by the stats manager again.
+		m.original.immutableStat = nil
 	} else if m.original.GetState() == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING &&
 		m.modifiedCopy.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
 		// if the state transferred from growing into others, remove the stats from stats manager.
 		m.original.immutableStat = resource.Resource().SegmentAssignStatsManager().UnregisterSealedSegment(m.original.GetSegmentID())
 	}
 	m.original.metrics.UpdateGrowingSegmentState(m.original.GetState(), m.modifiedCopy.GetState())
+	resource.Resource().SegmentStateIndex().Update(m.original.pchannel.Name, m.modifiedCopy.GetSegmentId(), m.modifiedCopy.GetState())
 	m.original.inner = m.modifiedCopy
+	m.original.persistedPartitionLastAssign = m.modifiedCopy.GetPartitionLastAssignTimeTick()
+	m.original.metaDeferred.Store(deferred)
 	return nil
 }
+
+// isSealOrFlush returns whether the modification transfers the segment into sealed or flushed.
+func (m *mutableSegmentAssignmentMeta) isSealOrFlush() bool {
+	switch m.modifiedCopy.GetState() {
+	case streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED,
+		streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,318 @@
+package manager
+
+import (
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+const (
+	shadowAssignQueueSize = 1024
+
+	ShadowStrategyFirstFit = "first_fit"
+	ShadowStrategyBestFit  = "best_fit"
+
+	shadowOutcomeMatch         = "match"
+	shadowOutcomeMismatch      = "mismatch"
+	shadowOutcomeNewSegment    = "new_segment"
+	shadowOutcomeTooLarge      = "too_large"
+	shadowOutcomePrimaryFailed = "primary_failed"
+	shadowOutcomeSkipped       = "skipped"
+	shadowOutcomeDropped       = "dropped"
+)
+
+var (
+	shadowStrategiesMu sync.RWMutex
+	shadowStrategies   = map[string]ShadowStrategy{
+		ShadowStrategyFirstFit: firstFitStrategy{},
+		ShadowStrategyBestFit:  bestFitStrategy{},
+	}
+)
+
+// ShadowCandidate is a growing segment that can hold the insert of the assignment route.
+type ShadowCandidate struct {
+	SegmentID     int64
+	Remaining     uint64 // the binary size that can still be assigned to the segment.
+	MaxBinarySize uint64
+	Empty         bool
+}
+
+// ShadowDecision is the decision of the shadow strategy.
+type ShadowDecision struct {
+	Result    streamingpb.AssignPreviewResult
+	SegmentID int64 // only set if the result is FIT_EXISTING.
+}
+
+// ShadowStrategy is a segment assignment strategy under evaluation.
+// It decides where the insert would land with the candidates in the order of the primary assignment,
+// the decision is only compared with the primary decision, it never takes effect.
+type ShadowStrategy interface {
+	// Name returns the name of the strategy, it's used to select the strategy by configuration.
+	Name() string
+
+	// Decide decides where the insert would land, the limit is the max binary size of a new growing segment.
+	Decide(candidates []ShadowCandidate, insert stats.InsertMetrics, limit uint64) ShadowDecision
+}
+
+// RegisterShadowStrategy registers a shadow strategy, the strategy with the same name is replaced.
+func RegisterShadowStrategy(strategy ShadowStrategy) {
+	shadowStrategiesMu.Lock()
+	defer shadowStrategiesMu.Unlock()
+	shadowStrategies[strategy.Name()] = strategy
+}
+
+// getShadowStrategy returns the shadow strategy of the name.
+func getShadowStrategy(name string) (ShadowStrategy, bool) {
+	shadowStrategiesMu.RLock()
+	defer shadowStrategiesMu.RUnlock()
+	strategy, ok := shadowStrategies[name]
+	return strategy, ok
+}
+
+// firstFitStrategy assigns the insert to the first candidate that can hold it, same as the primary assignment.
+type firstFitStrategy struct{}
+
+func (firstFitStrategy) Name() string {
+	return ShadowStrategyFirstFit
+}
+
+func (firstFitStrategy) Decide(candidates []ShadowCandidate, insert stats.InsertMetrics, limit uint64) ShadowDecision {
+	for _, candidate := range candidates {
+		if insert.BinarySize <= candidate.Remaining {
+			return ShadowDecision{Result: streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_FIT_EXISTING, SegmentID: candidate.SegmentID}
+		}
+		if candidate.Empty {
+			// the insert that can not be held by an empty segment is rejected by the assignment directly.
+			return ShadowDecision{Result: streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_TOO_LARGE}
+		}
+	}
+	return newSegmentDecision(insert, limit)
+}
+
+// bestFitStrategy assigns the insert to the candidate with the least remaining capacity that can hold it,
+// so the segments are filled up more tightly.
+type bestFitStrategy struct{}
+
+func (bestFitStrategy) Name() string {
+	return ShadowStrategyBestFit
+}
+
+func (bestFitStrategy) Decide(candidates []ShadowCandidate, insert stats.InsertMetrics, limit uint64) ShadowDecision {
+	var best *ShadowCandidate
+	for i := range candidates {
+		if insert.BinarySize > candidates[i].Remaining {
+			continue
+		}
+		if best == nil || candidates[i].Remaining < best.Remaining {
+			best = &candidates[i]
+		}
+	}
+	if best != nil {
+		return ShadowDecision{Result: streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_FIT_EXISTING, SegmentID: best.SegmentID}
+	}
+	return newSegmentDecision(insert, limit)
+}
+
+// newSegmentDecision returns the decision if no candidate can hold the insert.
+func newSegmentDecision(insert stats.InsertMetrics, limit uint64) ShadowDecision {
+	if insert.BinarySize > limit {
+		return ShadowDecision{Result: streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_TOO_LARGE}
+	}
+	return ShadowDecision{Result: streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_NEW_SEGMENT}
+}
+
+// shadowAssignment is a primary assignment that is waiting to be evaluated by the shadow strategy.
+type shadowAssignment struct {
+	collectionID     int64
+	partitionID      int64
+	insert           stats.InsertMetrics
+	highPriority     bool
+	primarySegmentID int64 // 0 if the primary assignment is failed.
+	primaryErr       error
+}
+
+// newShadowEvaluator creates a new shadow evaluator of the pchannel.
+func newShadowEvaluator(logger *log.MLogger, pchannel types.PChannelInfo, managers *partitionSegmentManagers) *shadowEvaluator {
+	e := &shadowEvaluator{
+		logger:   logger,
+		pchannel: pchannel,
+		managers: managers,
+		ch:       make(chan shadowAssignment, shadowAssignQueueSize),
+		closed:   make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	go e.background()
+	return e
+}
+
+// shadowEvaluator evaluates the primary segment assignments with the shadow strategy in background.
+// The evaluation works on the assignment state after the primary assignment and never modifies it,
+// so the outcome is approximate if there's concurrent assignment or seal on the same partition.
+type shadowEvaluator struct {
+	logger   *log.MLogger
+	pchannel types.PChannelInfo
+	managers *partitionSegmentManagers
+	ch       chan shadowAssignment
+	closed   chan struct{}
+	finished chan struct{}
+}
+
+// enabled checks if the shadow assignment is enabled on the pchannel.
+func (e *shadowEvaluator) enabled() bool {
+	for _, pchannel := range paramtable.Get().StreamingCfg.WALSegmentAssignShadowPChannels.GetAsStrings() {
+		if pchannel == e.pchannel.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// Observe submits the primary assignment to be evaluated by the shadow strategy, it never blocks.
+func (e *shadowEvaluator) Observe(req *AssignSegmentRequest, result *AssignSegmentResult, err error) {
+	if !e.enabled() {
+		return
+	}
+	assignment := shadowAssignment{
+		collectionID: req.CollectionID,
+		partitionID:  req.PartitionID,
+		insert:       req.InsertMetrics,
+		highPriority: req.HighPriority,
+		primaryErr:   err,
+	}
+	if result != nil {
+		assignment.primarySegmentID = result.SegmentID
+	}
+	select {
+	case e.ch <- assignment:
+	default:
+		e.observeOutcome(paramtable.Get().StreamingCfg.WALSegmentAssignShadowStrategy.GetValue(), shadowOutcomeDropped)
+	}
+}
+
+// background evaluates the submitted assignments one by one.
+func (e *shadowEvaluator) background() {
+	defer close(e.finished)
+	for {
+		select {
+		case <-e.closed:
+			return
+		case assignment := <-e.ch:
+			e.evaluate(assignment)
+		}
+	}
+}
+
+// evaluate evaluates the primary assignment with the shadow strategy and records the comparison outcome.
+func (e *shadowEvaluator) evaluate(assignment shadowAssignment) {
+	name := paramtable.Get().StreamingCfg.WALSegmentAssignShadowStrategy.GetValue()
+	strategy, ok := getShadowStrategy(name)
+	if !ok {
+		e.logger.Warn("shadow segment assignment strategy is not found", zap.String("strategy", name))
+		e.observeOutcome(name, shadowOutcomeSkipped)
+		return
+	}
+	pm, err := e.managers.Get(assignment.collectionID, assignment.partitionID)
+	if err != nil {
+		// the partition may be dropped after the primary assignment.
+		e.observeOutcome(name, shadowOutcomeSkipped)
+		return
+	}
+	candidates, limit := pm.ShadowCandidates(assignment.highPriority, assignment.primarySegmentID, assignment.insert)
+	decision := strategy.Decide(candidates, assignment.insert, limit)
+	outcome := compareShadowDecision(assignment, decision)
+	e.observeOutcome(name, outcome)
+
+	logger := e.logger.With(
+		zap.String("strategy", name),
+		zap.String("outcome", outcome),
+		zap.Int64("collectionID", assignment.collectionID),
+		zap.Int64("partitionID", assignment.partitionID),
+		zap.Uint64("binarySize", assignment.insert.BinarySize),
+		zap.Int64("primarySegmentID", assignment.primarySegmentID),
+		zap.String("shadowResult", decision.Result.String()),
+		zap.Int64("shadowSegmentID", decision.SegmentID),
+		zap.Int("candidates", len(candidates)),
+		zap.NamedError("primaryError", assignment.primaryErr),
+	)
+	if outcome == shadowOutcomeMatch {
+		logger.Debug("shadow segment assignment evaluated")
+		return
+	}
+	logger.Info("shadow segment assignment evaluated")
+}
+
+// compareShadowDecision compares the shadow decision with the primary decision.
+func compareShadowDecision(assignment shadowAssignment, decision ShadowDecision) string {
+	switch {
+	case assignment.primaryErr != nil:
+		if errors.Is(assignment.primaryErr, ErrTooLargeInsert) && decision.Result == streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_TOO_LARGE {
+			return shadowOutcomeMatch
+		}
+		return shadowOutcomePrimaryFailed
+	case decision.Result == streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_TOO_LARGE:
+		return shadowOutcomeTooLarge
+	case decision.Result == streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_NEW_SEGMENT:
+		return shadowOutcomeNewSegment
+	case decision.SegmentID == assignment.primarySegmentID:
+		return shadowOutcomeMatch
+	default:
+		return shadowOutcomeMismatch
+	}
+}
+
+// observeOutcome records the outcome of the shadow assignment into metrics.
+func (e *shadowEvaluator) observeOutcome(strategy string, outcome string) {
+	metrics.WALSegmentAssignShadowTotal.WithLabelValues(paramtable.GetStringNodeID(), e.pchannel.Name, strategy, outcome).Inc()
+}
+
+// Close stops the shadow evaluator, the pending assignments are dropped.
+func (e *shadowEvaluator) Close() {
+	close(e.closed)
+	<-e.finished
+}
+
+// ShadowCandidates returns the growing segments of the assignment route in the order of the primary assignment,
+// and the max binary size of a new growing segment.
+// The reservation of the primary assignment is given back to the remaining of the primary segment,
+// so the candidates are the view before the primary assignment.
+func (m *partitionSegmentManager) ShadowCandidates(highPriority bool, primarySegmentID int64, insert stats.InsertMetrics) ([]ShadowCandidate, uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	highPriority = policy.IsHighPriorityAssign(m.collectionID, highPriority)
+	limitation := policy.SegmentLimitation{SegmentSize: policy.GetSegmentMaxBinarySizeLimit()}
+	if highPriority {
+		limitation = policy.GetHighPrioritySegmentLimitation(limitation)
+	}
+	candidates := make([]ShadowCandidate, 0, len(m.segments))
+	for _, segment := range m.segments {
+		if segment.IsL0() || segment.IsHighPriority() != highPriority || segment.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
+			continue
+		}
+		stat := segment.GetStat()
+		if stat == nil {
+			continue
+		}
+		candidate := ShadowCandidate{
+			SegmentID:     segment.GetSegmentID(),
+			Remaining:     stat.BinaryCanBeAssign(),
+			MaxBinarySize: stat.MaxBinarySize,
+			Empty:         stat.IsEmpty(),
+		}
+		if candidate.SegmentID == primarySegmentID {
+			candidate.Remaining += insert.BinarySize
+			candidate.Empty = stat.Insert.Rows <= insert.Rows
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, limitation.SegmentSize
+}
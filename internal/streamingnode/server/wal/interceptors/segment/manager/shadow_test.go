@@ -0,0 +1,70 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+type testShadowStrategy struct{}
+
+func (testShadowStrategy) Name() string {
+	return "test"
+}
+
+func (testShadowStrategy) Decide(candidates []ShadowCandidate, insert stats.InsertMetrics, limit uint64) ShadowDecision {
+	return ShadowDecision{Result: streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_NEW_SEGMENT}
+}
+
+func TestShadowStrategy(t *testing.T) {
+	candidates := []ShadowCandidate{
+		{SegmentID: 1, Remaining: 100, MaxBinarySize: 1000},
+		{SegmentID: 2, Remaining: 30, MaxBinarySize: 1000},
+		{SegmentID: 3, Remaining: 1000, MaxBinarySize: 1000, Empty: true},
+	}
+	firstFit, ok := getShadowStrategy(ShadowStrategyFirstFit)
+	assert.True(t, ok)
+	bestFit, ok := getShadowStrategy(ShadowStrategyBestFit)
+	assert.True(t, ok)
+
+	// the first fit is the same as the primary assignment, the best fit fills up the tightest segment.
+	insert := stats.InsertMetrics{Rows: 1, BinarySize: 20}
+	assert.Equal(t, ShadowDecision{Result: streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_FIT_EXISTING, SegmentID: 1}, firstFit.Decide(candidates, insert, 1000))
+	assert.Equal(t, ShadowDecision{Result: streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_FIT_EXISTING, SegmentID: 2}, bestFit.Decide(candidates, insert, 1000))
+
+	// the insert that can not be held by the empty segment is rejected.
+	insert = stats.InsertMetrics{Rows: 1, BinarySize: 2000}
+	assert.Equal(t, streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_TOO_LARGE, firstFit.Decide(candidates, insert, 1000).Result)
+	assert.Equal(t, streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_TOO_LARGE, bestFit.Decide(candidates, insert, 1000).Result)
+
+	// a new segment is required if no candidate can hold the insert.
+	insert = stats.InsertMetrics{Rows: 1, BinarySize: 200}
+	assert.Equal(t, streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_NEW_SEGMENT, firstFit.Decide(candidates[:2], insert, 1000).Result)
+	assert.Equal(t, streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_NEW_SEGMENT, bestFit.Decide(candidates[:2], insert, 1000).Result)
+
+	// the strategy under test can be registered.
+	RegisterShadowStrategy(testShadowStrategy{})
+	strategy, ok := getShadowStrategy("test")
+	assert.True(t, ok)
+	assert.Equal(t, streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_NEW_SEGMENT, strategy.Decide(candidates, insert, 1000).Result)
+	_, ok = getShadowStrategy("not_exist")
+	assert.False(t, ok)
+}
+
+func TestCompareShadowDecision(t *testing.T) {
+	fit := func(segmentID int64) ShadowDecision {
+		return ShadowDecision{Result: streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_FIT_EXISTING, SegmentID: segmentID}
+	}
+	tooLarge := ShadowDecision{Result: streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_TOO_LARGE}
+	newSegment := ShadowDecision{Result: streamingpb.AssignPreviewResult_ASSIGN_PREVIEW_RESULT_NEW_SEGMENT}
+
+	assert.Equal(t, shadowOutcomeMatch, compareShadowDecision(shadowAssignment{primarySegmentID: 1}, fit(1)))
+	assert.Equal(t, shadowOutcomeMismatch, compareShadowDecision(shadowAssignment{primarySegmentID: 1}, fit(2)))
+	assert.Equal(t, shadowOutcomeNewSegment, compareShadowDecision(shadowAssignment{primarySegmentID: 1}, newSegment))
+	assert.Equal(t, shadowOutcomeTooLarge, compareShadowDecision(shadowAssignment{primarySegmentID: 1}, tooLarge))
+	assert.Equal(t, shadowOutcomeMatch, compareShadowDecision(shadowAssignment{primaryErr: &TooLargeInsertError{BinarySize: 2000, MaxBinarySize: 1000}}, tooLarge))
+	assert.Equal(t, shadowOutcomePrimaryFailed, compareShadowDecision(shadowAssignment{primaryErr: ErrTimeTickTooOld}, fit(1)))
+}
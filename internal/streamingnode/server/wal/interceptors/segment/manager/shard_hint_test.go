@@ -0,0 +1,113 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+)
+
+// routeOfShardHint returns the segment with the highest rendezvous score of the hint.
+func routeOfShardHint(hint uint64, segmentIDs []int64) int64 {
+	best := segmentIDs[0]
+	for _, segmentID := range segmentIDs[1:] {
+		if policy.ShardHintScore(hint, segmentID) > policy.ShardHintScore(hint, best) {
+			best = segmentID
+		}
+	}
+	return best
+}
+
+func TestShardHintDistribution(t *testing.T) {
+	segmentIDs := []int64{1001, 1002, 1003, 1004}
+	hints := 40000
+	routes := make(map[uint64]int64, hints)
+	counts := make(map[int64]int, len(segmentIDs))
+	for hint := uint64(1); hint <= uint64(hints); hint++ {
+		routes[hint] = routeOfShardHint(hint, segmentIDs)
+		counts[routes[hint]]++
+	}
+	// the hints are spread evenly over the growing segments.
+	for _, segmentID := range segmentIDs {
+		assert.InDelta(t, hints/len(segmentIDs), counts[segmentID], float64(hints)*0.02, "segment %d", segmentID)
+	}
+
+	// the hints of the sealed segment are spread over the remaining ones, the others are never moved.
+	remaining := []int64{1001, 1003, 1004}
+	moved := make(map[int64]int, len(remaining))
+	for hint, route := range routes {
+		newRoute := routeOfShardHint(hint, remaining)
+		if route != 1002 {
+			assert.Equal(t, route, newRoute)
+			continue
+		}
+		moved[newRoute]++
+	}
+	for _, segmentID := range remaining {
+		assert.InDelta(t, counts[1002]/len(remaining), moved[segmentID], float64(counts[1002])*0.05, "segment %d", segmentID)
+	}
+}
+
+func TestAssignSegmentWithShardHint(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  1,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_shard_hint"}, f)
+	assert.NoError(t, err)
+	defer m.Close(context.Background())
+
+	ctx := context.Background()
+	assign := func(partitionID int64, hint uint64) int64 {
+		result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+			CollectionID: 1,
+			PartitionID:  partitionID,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       1,
+				BinarySize: 1,
+			},
+			TimeTick:  100,
+			ShardHint: hint,
+		})
+		assert.NoError(t, err)
+		result.Ack()
+		return result.SegmentID
+	}
+
+	// the partition 2 has the growing segments 2000, 3000 and 5000, but 2000 is full,
+	// so the hints routed to 2000 fall through to the next candidate without violating the capacity.
+	counts := make(map[int64]int)
+	for hint := uint64(1); hint <= 40; hint++ {
+		expected := routeOfShardHint(hint, []int64{3000, 5000})
+		segmentID := assign(2, hint)
+		assert.Equal(t, expected, segmentID, "hint %d", hint)
+		// the same hint consistently maps to the same growing segment.
+		assert.Equal(t, segmentID, assign(2, hint), "hint %d", hint)
+		counts[segmentID]++
+	}
+	assert.Len(t, counts, 2)
+	assert.NotContains(t, counts, int64(2000))
+
+	// the insert without hint keeps the first fit order.
+	assert.Equal(t, int64(3000), assign(2, 0))
+
+	// the hint is ignored if there's only one growing segment.
+	for hint := uint64(1); hint <= 10; hint++ {
+		assert.Equal(t, int64(6000), assign(3, hint))
+	}
+}
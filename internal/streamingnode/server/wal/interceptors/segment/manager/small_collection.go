@@ -0,0 +1,109 @@
+package manager
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+)
+
+// collectionIngest tracks the ingest of a collection on the pchannel, it's shared by all partitions of the collection.
+// The max binary size override of the small collection is derived from the observed ingest rate,
+// and it's recalculated by the seal inspector periodically.
+type collectionIngest struct {
+	assigned      atomic.Uint64 // the cumulative binary size assigned into the collection.
+	maxBinarySize atomic.Uint64 // the max binary size override of the new growing segment, 0 if no override.
+	rows          atomic.Uint64 // the cumulative rows assigned into the collection.
+	rowSizeFloor  atomic.Uint64 // the max binary size floor bumped by the row size check, 0 if not bumped.
+	undersized    atomic.Bool   // whether the max binary size is detected too small to hold the average rows.
+
+	mu           sync.Mutex
+	lastAssigned uint64
+	lastSampled  time.Time
+	rate         float64 // the smoothed ingest rate in bytes per second, negative if not sampled yet.
+}
+
+// newCollectionIngest creates a new collection ingest tracker.
+func newCollectionIngest() *collectionIngest {
+	return &collectionIngest{rate: -1}
+}
+
+// ObserveAssign observes the insert assigned into the collection.
+func (c *collectionIngest) ObserveAssign(rows uint64, binarySize uint64) {
+	c.rows.Add(rows)
+	c.assigned.Add(binarySize)
+}
+
+// AvgRowSize returns the average row size of the assigned inserts, 0 if no row is assigned yet.
+func (c *collectionIngest) AvgRowSize() uint64 {
+	rows := c.rows.Load()
+	if rows == 0 {
+		return 0
+	}
+	return c.assigned.Load() / rows
+}
+
+// RowSizeFloor returns the max binary size floor bumped by the row size check, 0 if not bumped.
+func (c *collectionIngest) RowSizeFloor() uint64 {
+	return c.rowSizeFloor.Load()
+}
+
+// RaiseRowSizeFloor raises the max binary size floor bumped by the row size check,
+// returns the old floor and false if the floor is not lower than the given one.
+func (c *collectionIngest) RaiseRowSizeFloor(floor uint64) (uint64, bool) {
+	for {
+		old := c.rowSizeFloor.Load()
+		if floor <= old {
+			return old, false
+		}
+		if c.rowSizeFloor.CompareAndSwap(old, floor) {
+			return old, true
+		}
+	}
+}
+
+// MarkUndersized marks the max binary size of the collection is too small or not, returns true if the mark is changed.
+func (c *collectionIngest) MarkUndersized(undersized bool) bool {
+	return c.undersized.CompareAndSwap(!undersized, undersized)
+}
+
+// MaxBinarySize returns the max binary size override of the new growing segment, 0 if no override.
+func (c *collectionIngest) MaxBinarySize() uint64 {
+	return c.maxBinarySize.Load()
+}
+
+// Sample samples the ingest rate since the last sample, and returns the smoothed ingest rate.
+// The first sample only records the baseline, so the rate is 0 until the second sample.
+func (c *collectionIngest) Sample(now time.Time) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	assigned := c.assigned.Load()
+	if c.rate < 0 {
+		c.lastAssigned, c.lastSampled, c.rate = assigned, now, 0
+		return c.rate
+	}
+	elapsed := now.Sub(c.lastSampled).Seconds()
+	if elapsed <= 0 {
+		return c.rate
+	}
+	sample := float64(assigned-c.lastAssigned) / elapsed
+	// smooth the rate, so a single burst or idle interval doesn't swing the segment size.
+	c.rate = (c.rate + sample) / 2
+	c.lastAssigned, c.lastSampled = assigned, now
+	return c.rate
+}
+
+// SetMaxBinarySize sets the max binary size override, returns the old one.
+func (c *collectionIngest) SetMaxBinarySize(maxBinarySize uint64) uint64 {
+	return c.maxBinarySize.Swap(maxBinarySize)
+}
+
+// Reset clears the override and the sampled rate, it's called when the collection is not a small collection anymore.
+func (c *collectionIngest) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.maxBinarySize.Store(0)
+	c.rate = -1
+}
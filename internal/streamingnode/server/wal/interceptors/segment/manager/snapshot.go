@@ -0,0 +1,246 @@
+package manager
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+const segmentAssignmentOriginPrefix = "SEGMENT_ASSIGNMENT_ORIGIN_"
+
+// SegmentAssignmentSnapshot is a read-only view of a segment assignment.
+type SegmentAssignmentSnapshot struct {
+	SegmentID          int64     `json:"segment_id"`
+	State              string    `json:"state"`
+	InsertedRows       uint64    `json:"inserted_rows"`
+	InsertedBinarySize uint64    `json:"inserted_binary_size"`
+	MaxBinarySize      uint64    `json:"max_binary_size"`
+	FillPercent        float64   `json:"fill_percent"`
+	LastAssignTime     time.Time `json:"last_assign_time"`
+	MinAssignTimeTick  uint64    `json:"min_assign_time_tick"`
+	MaxAssignTimeTick  uint64    `json:"max_assign_time_tick"`
+	AckSem             int32     `json:"ack_sem"`
+	TxnSem             int32     `json:"txn_sem"`
+	PendingSeal        bool      `json:"pending_seal"`
+	SealPolicy         string    `json:"seal_policy,omitempty"`
+	OriginMessageID    string    `json:"origin_message_id,omitempty"`
+	SchemaVersion      uint64    `json:"schema_version"`
+	StorageVersion     int64     `json:"storage_version"` // the effective storage version of the segment, decided when the segment is created.
+	HighPriority       bool      `json:"high_priority"`
+	Level              string    `json:"level"`
+	DeletedRows        uint64    `json:"deleted_rows,omitempty"`
+	DeletedBinarySize  uint64    `json:"deleted_binary_size,omitempty"`
+	Origin             string    `json:"origin"`
+	// the count of seal cycles that the segment is blocked by the flying acks, reset after the segment is flushed.
+	AckBlockedSealCycles int32 `json:"ack_blocked_seal_cycles"`
+}
+
+// PartitionAssignmentSnapshot is a read-only view of the segment assignment of a partition.
+type PartitionAssignmentSnapshot struct {
+	PartitionID          int64                       `json:"partition_id"`
+	VChannel             string                      `json:"vchannel"`
+	FencedAssignTimeTick uint64                      `json:"fenced_assign_time_tick"`
+	DropFenceTimeTick    uint64                      `json:"drop_fence_time_tick,omitempty"` // the time tick of the drop fence installed by the prepare of partition drop, 0 if not prepared.
+	AckBlockedSealCycles int64                       `json:"ack_blocked_seal_cycles"`        // the sum of the blocked cycles of the segments of the partition.
+	Segments             []SegmentAssignmentSnapshot `json:"segments"`
+}
+
+// CollectionAssignmentSnapshot is a read-only and paginated view of the segment assignment of a collection.
+type CollectionAssignmentSnapshot struct {
+	PChannel         string                        `json:"pchannel"`
+	CollectionID     int64                         `json:"collection_id"`
+	TotalPartitions  int                           `json:"total_partitions"`
+	Offset           int                           `json:"offset"`
+	Limit            int                           `json:"limit"`
+	Partitions       []PartitionAssignmentSnapshot `json:"partitions"`
+	FlushPending     *stats.FlushPending           `json:"flush_pending,omitempty"`      // the in-flight manual flush of the collection.
+	RowSizeCheck     *RowSizeCheckSnapshot         `json:"row_size_check,omitempty"`     // the max binary size check against the average row size of the collection.
+	AutoSealDisabled bool                          `json:"auto_seal_disabled,omitempty"` // the policy-driven seal of the collection is opted out.
+	Metadata         AssignmentMetadata            `json:"metadata"`                     // the build and behavior metadata of the segment assignment of the pchannel.
+}
+
+// snapshot returns the read-only view of the segment assignment.
+func (s *segmentAllocManager) snapshot(pendingSeal bool) SegmentAssignmentSnapshot {
+	snapshot := SegmentAssignmentSnapshot{
+		SegmentID:      s.GetSegmentID(),
+		State:          s.GetState().String(),
+		AckSem:         s.AckSem(),
+		TxnSem:         s.TxnSem(),
+		PendingSeal:    pendingSeal,
+		SealPolicy:     string(s.SealPolicy()),
+		SchemaVersion:  s.GetSchemaVersion(),
+		StorageVersion: s.GetStorageVersion(),
+		HighPriority:   s.IsHighPriority(),
+		Level:          s.inner.GetLevel().String(),
+		Origin:         s.GetAssignmentOrigin().String(),
+
+		AckBlockedSealCycles: s.AckBlockedSealCycles(),
+	}
+	snapshot.MinAssignTimeTick, snapshot.MaxAssignTimeTick = s.AssignedTimeTickRange()
+	snapshot.OriginMessageID = s.GetOriginMessageID().GetId()
+	if stat := s.GetStat(); stat != nil {
+		snapshot.InsertedRows = stat.Insert.Rows
+		snapshot.InsertedBinarySize = stat.Insert.BinarySize
+		snapshot.MaxBinarySize = stat.MaxBinarySize
+		snapshot.LastAssignTime = stat.LastModifiedTime
+		if stat.MaxBinarySize > 0 {
+			snapshot.FillPercent = float64(stat.Insert.BinarySize) * 100 / float64(stat.MaxBinarySize)
+		}
+	}
+	if stat := s.GetDeleteStat(); stat != nil {
+		snapshot.DeletedRows = stat.Delete.Rows
+		snapshot.DeletedBinarySize = stat.Delete.BinarySize
+		snapshot.LastAssignTime = stat.LastModifiedTime
+	}
+	return snapshot
+}
+
+// parseSegmentAssignmentOrigin parses the origin from its enum name, the name without prefix is also accepted case-insensitively,
+// such as "recovery_reconciled".
+func parseSegmentAssignmentOrigin(name string) (streamingpb.SegmentAssignmentOrigin, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !strings.HasPrefix(name, segmentAssignmentOriginPrefix) {
+		name = segmentAssignmentOriginPrefix + name
+	}
+	origin, ok := streamingpb.SegmentAssignmentOrigin_value[name]
+	if !ok {
+		return streamingpb.SegmentAssignmentOrigin_SEGMENT_ASSIGNMENT_ORIGIN_NORMAL, errors.Newf("unknown segment assignment origin %s", name)
+	}
+	return streamingpb.SegmentAssignmentOrigin(origin), nil
+}
+
+// FilterByOrigin keeps only the segments of the given origin in the snapshot,
+// the partitions are kept even if no segment is left, so the pagination is not changed.
+func (s *CollectionAssignmentSnapshot) FilterByOrigin(origin streamingpb.SegmentAssignmentOrigin) {
+	for i := range s.Partitions {
+		segments := make([]SegmentAssignmentSnapshot, 0, len(s.Partitions[i].Segments))
+		for _, segment := range s.Partitions[i].Segments {
+			if segment.Origin == origin.String() {
+				segments = append(segments, segment)
+			}
+		}
+		s.Partitions[i].Segments = segments
+	}
+}
+
+// Snapshot returns the read-only view of the partition.
+func (m *partitionSegmentManager) Snapshot() PartitionAssignmentSnapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	segments := make([]SegmentAssignmentSnapshot, 0, len(m.segments))
+	for _, segment := range m.segments {
+		segments = append(segments, segment.snapshot(false))
+	}
+	return PartitionAssignmentSnapshot{
+		PartitionID:          m.paritionID,
+		VChannel:             m.vchannel,
+		FencedAssignTimeTick: m.fencedAssignTimeTick,
+		DropFenceTimeTick:    m.activeDropFence(),
+		Segments:             segments,
+	}
+}
+
+// PartitionIDs returns the sorted partition ids of the collection.
+func (m *partitionSegmentManagers) PartitionIDs(collectionID int64) ([]int64, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	collectionInfo, ok := m.collectionInfos[collectionID]
+	if !ok {
+		return nil, false
+	}
+	partitionIDs := make([]int64, 0, len(collectionInfo.Partitions))
+	for _, partition := range collectionInfo.Partitions {
+		partitionIDs = append(partitionIDs, partition.PartitionId)
+	}
+	sort.Slice(partitionIDs, func(i, j int) bool { return partitionIDs[i] < partitionIDs[j] })
+	return partitionIDs, true
+}
+
+// SnapshotPendingSeal returns the read-only view of the segments of the collection that wait for seal, grouped by partition.
+func (q *sealQueue) SnapshotPendingSeal(collectionID int64) map[int64][]SegmentAssignmentSnapshot {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	pending := make(map[int64][]SegmentAssignmentSnapshot)
+	for _, segment := range q.waitForSealed {
+		if segment.GetCollectionID() != collectionID {
+			continue
+		}
+		pending[segment.GetPartitionID()] = append(pending[segment.GetPartitionID()], segment.snapshot(true))
+	}
+	return pending
+}
+
+// SnapshotCollection returns the read-only view of the segment assignment of the collection.
+// If partitionIDs is given, only the given partitions are returned.
+// The partitions are sorted by partition id and paginated by offset and limit.
+func (m *PChannelSegmentAllocManager) SnapshotCollection(collectionID int64, partitionIDs []int64, offset int, limit int) (*CollectionAssignmentSnapshot, error) {
+	if err := m.checkLifetime(); err != nil {
+		return nil, err
+	}
+	defer m.lifetime.Done()
+
+	allPartitionIDs, ok := m.managers.PartitionIDs(collectionID)
+	if !ok {
+		return nil, ErrCollectionNotFound
+	}
+	if len(partitionIDs) > 0 {
+		filter := make(map[int64]struct{}, len(partitionIDs))
+		for _, partitionID := range partitionIDs {
+			filter[partitionID] = struct{}{}
+		}
+		filtered := make([]int64, 0, len(partitionIDs))
+		for _, partitionID := range allPartitionIDs {
+			if _, ok := filter[partitionID]; ok {
+				filtered = append(filtered, partitionID)
+			}
+		}
+		allPartitionIDs = filtered
+	}
+
+	snapshot := &CollectionAssignmentSnapshot{
+		PChannel:         m.pchannel.Name,
+		CollectionID:     collectionID,
+		TotalPartitions:  len(allPartitionIDs),
+		Offset:           offset,
+		Limit:            limit,
+		Partitions:       make([]PartitionAssignmentSnapshot, 0),
+		Metadata:         m.Metadata(),
+		AutoSealDisabled: policy.IsAutoSealDisabled(collectionID),
+	}
+	if flush, ok := m.GetFlushPending(collectionID); ok {
+		snapshot.FlushPending = &flush
+	}
+	snapshot.RowSizeCheck = m.managers.SnapshotRowSizeCheck(collectionID)
+	if offset >= len(allPartitionIDs) {
+		return snapshot, nil
+	}
+	end := offset + limit
+	if end > len(allPartitionIDs) {
+		end = len(allPartitionIDs)
+	}
+
+	pendingSeal := m.helper.SnapshotPendingSeal(collectionID)
+	for _, partitionID := range allPartitionIDs[offset:end] {
+		pm, err := m.managers.Get(collectionID, partitionID)
+		if err != nil {
+			// the partition may be removed concurrently.
+			continue
+		}
+		partition := pm.Snapshot()
+		partition.Segments = append(partition.Segments, pendingSeal[partitionID]...)
+		for _, segment := range partition.Segments {
+			partition.AckBlockedSealCycles += int64(segment.AckBlockedSealCycles)
+		}
+		snapshot.Partitions = append(snapshot.Partitions, partition)
+	}
+	return snapshot, nil
+}
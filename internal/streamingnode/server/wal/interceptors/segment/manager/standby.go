@@ -0,0 +1,453 @@
+package manager
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+)
+
+var ErrStandbyNotSynced = errors.New("standby replica not synced")
+
+// StandbyCollection is the collection of the pchannel seen by the standby.
+type StandbyCollection struct {
+	CollectionID int64
+	VChannel     string
+	PartitionIDs []int64 // sorted.
+}
+
+// StandbyStateSnapshot is the full snapshot of the segment assignment state of the pchannel published to the standby.
+type StandbyStateSnapshot struct {
+	// the events before the sequence are already applied into the snapshot,
+	// the events published while the snapshot is taken may be applied twice, so the stats of standby are approximate.
+	Sequence    uint64
+	Collections []StandbyCollection
+	Segments    []*streamingpb.SegmentAssignmentMeta // the metas of the growing and sealed segments in the format of catalog.
+}
+
+// StandbyStateDelta is an item of the state stream published by the active owner of the pchannel to the standby.
+// Exactly one of Snapshot and Event is set.
+type StandbyStateDelta struct {
+	PChannel string
+	Term     int64 // the term of the active owner that publishes the delta.
+	Snapshot *StandbyStateSnapshot
+	Event    *AssignmentEvent
+}
+
+// PublishStandbyState publishes the segment assignment state of the pchannel to the warm standby.
+// A full snapshot is published at first, then the assignment events are published as deltas,
+// the full snapshot is published again periodically and right after the events are lagged.
+// The returned channel will be closed when the ctx is done or the manager is closed.
+func (m *PChannelSegmentAllocManager) PublishStandbyState(ctx context.Context) <-chan StandbyStateDelta {
+	ch := make(chan StandbyStateDelta)
+	// watch before the first snapshot, so no event is missed between them.
+	events := m.watcher.Watch(ctx)
+	go m.publishStandbyState(ctx, events, ch)
+	return ch
+}
+
+// publishStandbyState forwards the snapshots and events to the channel until the ctx is done or the events are closed.
+func (m *PChannelSegmentAllocManager) publishStandbyState(ctx context.Context, events <-chan AssignmentEvent, ch chan<- StandbyStateDelta) {
+	defer close(ch)
+	publish := func(delta StandbyStateDelta) bool {
+		delta.PChannel = m.pchannel.Name
+		delta.Term = m.pchannel.Term
+		select {
+		case <-ctx.Done():
+			return false
+		case ch <- delta:
+			return true
+		}
+	}
+
+	interval := paramtable.Get().StreamingCfg.WALSegmentAssignStandbySnapshotInterval.GetAsDurationByParse()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	if !publish(StandbyStateDelta{Snapshot: m.snapshotStandbyState()}) {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !publish(StandbyStateDelta{Snapshot: m.snapshotStandbyState()}) {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if !publish(StandbyStateDelta{Event: &event}) {
+				return
+			}
+			if event.Type == AssignmentEventLagged {
+				// the standby drops its state after lagged, resync it right away.
+				m.logger.Info("standby state stream lagged, resync with a full snapshot", zap.Uint64("laggedEvents", event.LaggedEvents))
+				ticker.Reset(interval)
+				if !publish(StandbyStateDelta{Snapshot: m.snapshotStandbyState()}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// snapshotStandbyState takes the full snapshot of the segment assignment state published to the standby.
+func (m *PChannelSegmentAllocManager) snapshotStandbyState() *StandbyStateSnapshot {
+	snapshot := &StandbyStateSnapshot{
+		Sequence:    m.watcher.Next(),
+		Collections: m.managers.StandbyCollections(),
+	}
+	m.managers.Range(func(pm *partitionSegmentManager) {
+		snapshot.Segments = append(snapshot.Segments, pm.SegmentMetas()...)
+	})
+	// the segment moved into the seal queue concurrently may be seen twice, the later one is kept by the standby.
+	snapshot.Segments = append(snapshot.Segments, m.helper.SegmentMetas()...)
+	return snapshot
+}
+
+// StandbyCollections returns the collections and their partitions seen by the standby.
+func (m *partitionSegmentManagers) StandbyCollections() []StandbyCollection {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	collections := make([]StandbyCollection, 0, len(m.collectionInfos))
+	for collectionID, info := range m.collectionInfos {
+		partitionIDs := make([]int64, 0, len(info.Partitions))
+		for _, partition := range info.Partitions {
+			partitionIDs = append(partitionIDs, partition.PartitionId)
+		}
+		sort.Slice(partitionIDs, func(i, j int) bool { return partitionIDs[i] < partitionIDs[j] })
+		collections = append(collections, StandbyCollection{
+			CollectionID: collectionID,
+			VChannel:     info.Vchannel,
+			PartitionIDs: partitionIDs,
+		})
+	}
+	sort.Slice(collections, func(i, j int) bool { return collections[i].CollectionID < collections[j].CollectionID })
+	return collections
+}
+
+// SegmentMetas returns the metas of the segments of the partition.
+func (m *partitionSegmentManager) SegmentMetas() []*streamingpb.SegmentAssignmentMeta {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	metas := make([]*streamingpb.SegmentAssignmentMeta, 0, len(m.segments))
+	for _, segment := range m.segments {
+		metas = append(metas, segment.Snapshot())
+	}
+	return metas
+}
+
+// SegmentMetas returns the metas of the segments waiting for seal in the queue.
+func (q *sealQueue) SegmentMetas() []*streamingpb.SegmentAssignmentMeta {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	metas := make([]*streamingpb.SegmentAssignmentMeta, 0, len(q.waitForSealed))
+	for _, segment := range q.waitForSealed {
+		metas = append(metas, segment.Snapshot())
+	}
+	return metas
+}
+
+// NewStandbyReplica creates a new empty standby replica of the pchannel.
+func NewStandbyReplica(pchannel string) *StandbyReplica {
+	return &StandbyReplica{
+		logger:      resource.Resource().Logger().With(log.FieldComponent("segment-assigner-standby"), zap.String("pchannel", pchannel)),
+		pchannel:    pchannel,
+		collections: make(map[int64]*StandbyCollection),
+		segments:    make(map[int64]*streamingpb.SegmentAssignmentMeta),
+	}
+}
+
+// StandbyReplica is the read-only replica of the segment assignment state of a pchannel kept by the warm standby.
+// It's built from the state stream published by the active owner, and can be imported by the promotion of the standby,
+// so the recovery only needs to reconcile the assignments after the last applied delta.
+type StandbyReplica struct {
+	logger      *log.MLogger
+	mu          sync.RWMutex
+	pchannel    string
+	term        int64
+	synced      bool   // the replica is synced by a full snapshot of current term, the events are dropped until synced.
+	cursor      uint64 // the sequence of the next event to apply.
+	lastApplied time.Time
+	collections map[int64]*StandbyCollection
+	segments    map[int64]*streamingpb.SegmentAssignmentMeta
+}
+
+// Consume applies the deltas of the state stream into the replica until the stream is closed or the ctx is done.
+// Return error if the delta is published by a stale owner or another pchannel.
+func (r *StandbyReplica) Consume(ctx context.Context, deltas <-chan StandbyStateDelta) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case delta, ok := <-deltas:
+			if !ok {
+				return nil
+			}
+			if err := r.Apply(delta); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Apply applies a delta of the state stream into the replica.
+func (r *StandbyReplica) Apply(delta StandbyStateDelta) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if delta.PChannel != r.pchannel {
+		return errors.Errorf("standby replica of pchannel %s can not apply the delta of pchannel %s", r.pchannel, delta.PChannel)
+	}
+	if delta.Term < r.term {
+		return errors.Wrapf(ErrStaleOwnership, "delta of pchannel %s is published by term %d, replica term %d", r.pchannel, delta.Term, r.term)
+	}
+	if delta.Term > r.term {
+		// the pchannel is taken over by a new owner, the state of the old owner is dropped until the new owner is synced.
+		r.logger.Info("standby replica observes a new term", zap.Int64("oldTerm", r.term), zap.Int64("newTerm", delta.Term))
+		r.term = delta.Term
+		r.synced = false
+		r.collections = make(map[int64]*StandbyCollection)
+		r.segments = make(map[int64]*streamingpb.SegmentAssignmentMeta)
+	}
+	switch {
+	case delta.Snapshot != nil:
+		r.applySnapshot(delta.Snapshot)
+	case delta.Event != nil:
+		r.applyEvent(delta.Event)
+	default:
+		return nil
+	}
+	r.lastApplied = resource.Resource().Clock().Now()
+	return nil
+}
+
+// applySnapshot replaces the state of the replica with the snapshot.
+func (r *StandbyReplica) applySnapshot(snapshot *StandbyStateSnapshot) {
+	r.collections = make(map[int64]*StandbyCollection, len(snapshot.Collections))
+	for _, collection := range snapshot.Collections {
+		r.collections[collection.CollectionID] = &StandbyCollection{
+			CollectionID: collection.CollectionID,
+			VChannel:     collection.VChannel,
+			PartitionIDs: append([]int64(nil), collection.PartitionIDs...),
+		}
+	}
+	r.segments = make(map[int64]*streamingpb.SegmentAssignmentMeta, len(snapshot.Segments))
+	for _, meta := range snapshot.Segments {
+		r.segments[meta.GetSegmentId()] = proto.Clone(meta).(*streamingpb.SegmentAssignmentMeta)
+	}
+	r.cursor = snapshot.Sequence
+	r.synced = true
+}
+
+// applyEvent applies the assignment event into the replica.
+func (r *StandbyReplica) applyEvent(event *AssignmentEvent) {
+	if !r.synced || event.Sequence < r.cursor {
+		// the event is already applied by the snapshot or the replica waits for the next snapshot.
+		return
+	}
+	r.cursor = event.Sequence + 1
+
+	switch event.Type {
+	case AssignmentEventLagged:
+		r.logger.Warn("standby replica lagged, wait for the next snapshot", zap.Uint64("laggedEvents", event.LaggedEvents))
+		r.synced = false
+	case AssignmentEventCreate:
+		r.observePartition(event)
+		r.segments[event.SegmentID] = r.newSegmentMeta(event, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING)
+	case AssignmentEventGrow:
+		if meta, ok := r.segments[event.SegmentID]; ok && meta.GetStat() != nil {
+			meta.Stat.InsertedRows += event.Delta.Rows
+			meta.Stat.InsertedBinarySize += event.Delta.BinarySize
+		}
+	case AssignmentEventSeal:
+		r.segments[event.SegmentID] = r.newSegmentMeta(event, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED)
+	case AssignmentEventUnseal:
+		r.segments[event.SegmentID] = r.newSegmentMeta(event, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING)
+	case AssignmentEventMigrate:
+		// the migrated segment is re-labeled with the new partition carried by the event.
+		r.observePartition(event)
+		r.segments[event.SegmentID] = r.newSegmentMeta(event, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING)
+	case AssignmentEventDrop, AssignmentEventDiscard:
+		delete(r.segments, event.SegmentID)
+	}
+}
+
+// observePartition adds the partition of the event into the collections of the replica.
+func (r *StandbyReplica) observePartition(event *AssignmentEvent) {
+	collection, ok := r.collections[event.CollectionID]
+	if !ok {
+		collection = &StandbyCollection{CollectionID: event.CollectionID, VChannel: event.VChannel}
+		r.collections[event.CollectionID] = collection
+	}
+	idx := sort.Search(len(collection.PartitionIDs), func(i int) bool { return collection.PartitionIDs[i] >= event.PartitionID })
+	if idx < len(collection.PartitionIDs) && collection.PartitionIDs[idx] == event.PartitionID {
+		return
+	}
+	collection.PartitionIDs = append(collection.PartitionIDs, 0)
+	copy(collection.PartitionIDs[idx+1:], collection.PartitionIDs[idx:])
+	collection.PartitionIDs[idx] = event.PartitionID
+}
+
+// newSegmentMeta creates the meta of the segment from the event, the fields not carried by the event are kept from the previous meta.
+func (r *StandbyReplica) newSegmentMeta(event *AssignmentEvent, state streamingpb.SegmentAssignmentState) *streamingpb.SegmentAssignmentMeta {
+	meta := &streamingpb.SegmentAssignmentMeta{}
+	if previous, ok := r.segments[event.SegmentID]; ok {
+		meta = proto.Clone(previous).(*streamingpb.SegmentAssignmentMeta)
+	}
+	meta.CollectionId = event.CollectionID
+	meta.PartitionId = event.PartitionID
+	meta.SegmentId = event.SegmentID
+	meta.Vchannel = event.VChannel
+	meta.StorageVersion = event.StorageVersion
+	meta.State = state
+	meta.Term = r.term
+	if event.Stat != nil {
+		meta.Stat = stats.NewProtoFromSegmentStat(event.Stat)
+	}
+	return meta
+}
+
+// Term returns the term of the owner that the replica follows.
+func (r *StandbyReplica) Term() int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.term
+}
+
+// Synced returns whether the replica is synced with the owner by a full snapshot.
+func (r *StandbyReplica) Synced() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.synced
+}
+
+// LastApplied returns the local time when the last delta is applied.
+func (r *StandbyReplica) LastApplied() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lastApplied
+}
+
+// Collections returns the collections of the replica sorted by collection id.
+func (r *StandbyReplica) Collections() []StandbyCollection {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	collections := make([]StandbyCollection, 0, len(r.collections))
+	for _, collection := range r.collections {
+		copied := *collection
+		copied.PartitionIDs = append([]int64(nil), collection.PartitionIDs...)
+		collections = append(collections, copied)
+	}
+	sort.Slice(collections, func(i, j int) bool { return collections[i].CollectionID < collections[j].CollectionID })
+	return collections
+}
+
+// Segments returns the copied segment metas of the replica sorted by segment id.
+func (r *StandbyReplica) Segments() []*streamingpb.SegmentAssignmentMeta {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	metas := make([]*streamingpb.SegmentAssignmentMeta, 0, len(r.segments))
+	for _, meta := range r.segments {
+		metas = append(metas, proto.Clone(meta).(*streamingpb.SegmentAssignmentMeta))
+	}
+	sort.Slice(metas, func(i, j int) bool { return metas[i].GetSegmentId() < metas[j].GetSegmentId() })
+	return metas
+}
+
+// PromoteStandbyReplica recovers the manager of the pchannel on the standby that becomes the new owner.
+// The recovery is still anchored to the catalog and the ownership term, the replica only warms up the stats of
+// the growing segments with the assignments that are not persisted into the catalog by the previous owner.
+func PromoteStandbyReplica(
+	ctx context.Context,
+	replica *StandbyReplica,
+	pchannel types.PChannelInfo,
+	wal *syncutil.Future[wal.WAL],
+) (*PChannelSegmentAllocManager, error) {
+	m, err := RecoverPChannelSegmentAllocManager(ctx, pchannel, wal)
+	if err != nil {
+		return nil, err
+	}
+	imported, err := m.importStandbyReplica(replica)
+	if err != nil {
+		// the replica is only a hint, the manager recovered from catalog is still correct without it.
+		m.logger.Warn("standby replica is not imported when promotion", zap.Error(err))
+		return m, nil
+	}
+	m.logger.Info("standby replica is imported when promotion",
+		zap.Int64("replicaTerm", replica.Term()),
+		zap.Time("replicaLastApplied", replica.LastApplied()),
+		zap.Int("importedSegments", imported))
+	return m, nil
+}
+
+// importStandbyReplica imports the stats of the standby replica into the growing segments recovered from catalog.
+// Only the rows assigned after the persisted stats are imported, and the segment written by a newer owner than the replica is skipped.
+// Return the count of segments whose stats are imported.
+func (m *PChannelSegmentAllocManager) importStandbyReplica(replica *StandbyReplica) (int, error) {
+	if replica.pchannel != m.pchannel.Name {
+		return 0, errors.Errorf("standby replica of pchannel %s can not be imported into pchannel %s", replica.pchannel, m.pchannel.Name)
+	}
+	if !replica.Synced() {
+		return 0, ErrStandbyNotSynced
+	}
+	if term := replica.Term(); term >= m.pchannel.Term {
+		return 0, errors.Wrapf(ErrStaleOwnership, "standby replica of term %d can not be imported into term %d", term, m.pchannel.Term)
+	}
+	replicated := make(map[int64]*streamingpb.SegmentAssignmentMeta)
+	for _, meta := range replica.Segments() {
+		replicated[meta.GetSegmentId()] = meta
+	}
+
+	imported := 0
+	statsManager := resource.Resource().SegmentAssignStatsManager()
+	m.managers.Range(func(pm *partitionSegmentManager) {
+		pm.mu.RLock()
+		defer pm.mu.RUnlock()
+		for _, segment := range pm.segments {
+			if segment.IsL0() || segment.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING {
+				continue
+			}
+			meta, ok := replicated[segment.GetSegmentID()]
+			if !ok || meta.GetStat() == nil || meta.GetTerm() < segment.inner.GetTerm() {
+				// the catalog is newer than the replica, keep the persisted stats.
+				continue
+			}
+			recovered := segment.GetStat()
+			if recovered == nil {
+				continue
+			}
+			delta := stats.InsertMetrics{
+				Rows:       meta.GetStat().GetInsertedRows() - min(meta.GetStat().GetInsertedRows(), recovered.Insert.Rows),
+				BinarySize: meta.GetStat().GetInsertedBinarySize() - min(meta.GetStat().GetInsertedBinarySize(), recovered.Insert.BinarySize),
+			}
+			if delta.Rows == 0 && delta.BinarySize == 0 {
+				continue
+			}
+			if statsManager.ImportRows(segment.GetSegmentID(), delta) {
+				imported++
+			}
+		}
+	})
+	return imported, nil
+}
@@ -0,0 +1,172 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+)
+
+func TestStandbyReplica(t *testing.T) {
+	initializeTestState(t)
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  2,
+	}, nil).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(w)
+
+	m, err := RecoverPChannelSegmentAllocManager(context.Background(), types.PChannelInfo{Name: "v_standby", Term: 1}, f)
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replica := NewStandbyReplica("v_standby")
+	assert.Error(t, replica.Apply(StandbyStateDelta{PChannel: "v_other", Term: 1}))
+	consumed := make(chan error, 1)
+	go func() {
+		consumed <- replica.Consume(ctx, m.PublishStandbyState(ctx))
+	}()
+
+	// the replica is synced by the first snapshot.
+	assert.Eventually(t, replica.Synced, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(1), replica.Term())
+	assert.False(t, replica.LastApplied().IsZero())
+	collections := replica.Collections()
+	assert.Len(t, collections, 1)
+	assert.Equal(t, []int64{1, 2, 3}, collections[0].PartitionIDs)
+	assert.Equal(t, []int64{1000, 2000, 3000, 4000, 5000, 6000}, lo.Map(replica.Segments(), func(meta *streamingpb.SegmentAssignmentMeta, _ int) int64 {
+		return meta.GetSegmentId()
+	}))
+
+	// the assignment is replicated by the grow event.
+	result, err := m.AssignSegment(ctx, &AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  3,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       100,
+			BinarySize: 100,
+		},
+		TimeTick: 100,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6000), result.SegmentID)
+	result.Ack()
+	replicatedRows := func(segmentID int64) uint64 {
+		for _, meta := range replica.Segments() {
+			if meta.GetSegmentId() == segmentID {
+				return meta.GetStat().GetInsertedRows()
+			}
+		}
+		return 0
+	}
+	assert.Eventually(t, func() bool { return replicatedRows(6000) == 200 }, time.Second, 10*time.Millisecond)
+
+	// the delta of a stale owner is rejected.
+	assert.ErrorIs(t, replica.Apply(StandbyStateDelta{PChannel: "v_standby", Term: 0, Event: &AssignmentEvent{}}), ErrStaleOwnership)
+
+	// the stream is closed with the manager of the old owner.
+	m.Close(context.Background())
+	assert.NoError(t, <-consumed)
+
+	// the replica of the same term can not be imported, only the catalog is used.
+	promoted, err := PromoteStandbyReplica(context.Background(), replica, types.PChannelInfo{Name: "v_standby", Term: 1}, f)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(6000).Insert.Rows)
+	promoted.Close(context.Background())
+
+	// the rows not persisted by the old owner are imported by the promotion of a new term.
+	promoted, err = PromoteStandbyReplica(context.Background(), replica, types.PChannelInfo{Name: "v_standby", Term: 2}, f)
+	assert.NoError(t, err)
+	statsManager := resource.Resource().SegmentAssignStatsManager()
+	assert.Equal(t, uint64(200), statsManager.GetStatsOfSegment(6000).Insert.Rows)
+	assert.Equal(t, uint64(200), statsManager.GetStatsOfSegment(6000).Insert.BinarySize)
+	assert.Equal(t, uint64(1000), statsManager.GetStatsOfSegment(2000).Insert.Rows)
+	promoted.Close(context.Background())
+
+	// the replica of another pchannel or not synced is never imported.
+	_, err = promoted.importStandbyReplica(NewStandbyReplica("v_other"))
+	assert.Error(t, err)
+	_, err = promoted.importStandbyReplica(NewStandbyReplica("v_standby"))
+	assert.ErrorIs(t, err, ErrStandbyNotSynced)
+}
+
+func TestStandbyReplicaApplyEvents(t *testing.T) {
+	initializeTestState(t)
+
+	replica := NewStandbyReplica("v_standby")
+	apply := func(term int64, delta StandbyStateDelta) {
+		delta.PChannel = "v_standby"
+		delta.Term = term
+		assert.NoError(t, replica.Apply(delta))
+	}
+	event := func(eventType AssignmentEventType, sequence uint64, segmentID int64) StandbyStateDelta {
+		return StandbyStateDelta{Event: &AssignmentEvent{
+			Type:         eventType,
+			Sequence:     sequence,
+			CollectionID: 1,
+			PartitionID:  2,
+			SegmentID:    segmentID,
+			VChannel:     "v1",
+			Delta:        stats.InsertMetrics{Rows: 10, BinarySize: 10},
+			Stat:         stats.NewSegmentStatFromProto(newStat(10, 1000)),
+		}}
+	}
+	segmentIDs := func() []int64 {
+		return lo.Map(replica.Segments(), func(meta *streamingpb.SegmentAssignmentMeta, _ int) int64 { return meta.GetSegmentId() })
+	}
+
+	// the events before the first snapshot are dropped.
+	apply(1, event(AssignmentEventCreate, 0, 1))
+	assert.Empty(t, segmentIDs())
+
+	apply(1, StandbyStateDelta{Snapshot: &StandbyStateSnapshot{Sequence: 2}})
+	// the events already applied by the snapshot are skipped.
+	apply(1, event(AssignmentEventCreate, 1, 1))
+	assert.Empty(t, segmentIDs())
+	apply(1, event(AssignmentEventCreate, 2, 2))
+	apply(1, event(AssignmentEventGrow, 3, 2))
+	apply(1, event(AssignmentEventCreate, 4, 3))
+	assert.Equal(t, []int64{2, 3}, segmentIDs())
+	assert.Equal(t, []int64{2}, replica.Collections()[0].PartitionIDs)
+	segments := replica.Segments()
+	assert.Equal(t, uint64(20), segments[0].GetStat().GetInsertedRows())
+	assert.Equal(t, int64(1), segments[0].GetTerm())
+
+	apply(1, event(AssignmentEventSeal, 5, 2))
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED, replica.Segments()[0].GetState())
+	apply(1, event(AssignmentEventDrop, 6, 2))
+	apply(1, event(AssignmentEventDiscard, 7, 3))
+	assert.Empty(t, segmentIDs())
+
+	// the replica waits for the next snapshot after lagged.
+	apply(1, StandbyStateDelta{Event: &AssignmentEvent{Type: AssignmentEventLagged, Sequence: 8, LaggedEvents: 10}})
+	assert.False(t, replica.Synced())
+	apply(1, event(AssignmentEventCreate, 18, 4))
+	assert.Empty(t, segmentIDs())
+
+	// the state of the old owner is dropped until the new owner is synced.
+	apply(1, StandbyStateDelta{Snapshot: &StandbyStateSnapshot{Sequence: 19}})
+	assert.True(t, replica.Synced())
+	apply(1, event(AssignmentEventCreate, 19, 4))
+	assert.Equal(t, []int64{4}, segmentIDs())
+	apply(2, event(AssignmentEventCreate, 0, 5))
+	assert.False(t, replica.Synced())
+	assert.Equal(t, int64(2), replica.Term())
+	assert.Empty(t, segmentIDs())
+	assert.ErrorIs(t, replica.Apply(StandbyStateDelta{PChannel: "v_standby", Term: 1}), ErrStaleOwnership)
+}
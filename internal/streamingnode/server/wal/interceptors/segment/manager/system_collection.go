@@ -0,0 +1,11 @@
+package manager
+
+import "github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+
+// IsSystemCollection checks if the collection is in the reserved system collection id range.
+// The segment assignment of a system collection must never be rejected by the tenant-facing protection,
+// otherwise the cluster may be wedged by the internal write failure.
+func IsSystemCollection(collectionID int64) bool {
+	maxID := paramtable.Get().StreamingCfg.WALSegmentAssignSystemCollectionMaxID.GetAsInt64()
+	return maxID > 0 && collectionID > 0 && collectionID <= maxID
+}
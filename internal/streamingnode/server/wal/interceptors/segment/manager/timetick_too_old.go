@@ -0,0 +1,40 @@
+package manager
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+)
+
+const (
+	// the time ticks that the rejected insert time tick is compared with.
+	timeTickTooOldAgainstCreate   = "create_segment"
+	timeTickTooOldAgainstLastSeal = "last_seal"
+)
+
+// observeTimeTickTooOld records the insert is rejected by ErrTimeTickTooOld before it's converted into a redo by the interceptor.
+// The reference is the time tick that the insert time tick is compared with, the create time tick of the segment or the last seal time tick of the partition,
+// so the lag between them is the lag of the time tick allocator observed by the partition.
+// The rejection is counted per collection and vchannel, and a warning carrying the lag is logged when the rate of the collection crosses the warn threshold.
+func (m *partitionSegmentManager) observeTimeTickTooOld(timeTick uint64, reference uint64, against string) {
+	lag := tsoutil.PhysicalTime(reference).Sub(tsoutil.PhysicalTime(timeTick))
+	if lag < 0 {
+		lag = 0
+	}
+	m.metrics.ObserveTimeTickTooOld(m.collectionID, m.vchannel, lag)
+	stats, warn := resource.Resource().SegmentAssignStatsManager().ObserveTimeTickTooOld(m.collectionID, m.vchannel, lag)
+	if !warn {
+		return
+	}
+	m.logger.Warn("TIME TICK TOO OLD RATE TOO HIGH: the inserts of collection are redone by the too old time tick frequently, check the lag of the time tick allocator",
+		zap.Uint64("timeTick", timeTick),
+		zap.Uint64("referenceTimeTick", reference),
+		zap.String("against", against),
+		zap.Duration("lag", lag),
+		zap.Float64("ratePerSecond", stats.RatePerSecond),
+		zap.Float64("threshold", stats.Threshold),
+		zap.Int("rejectedInWindow", stats.InWindow),
+		zap.Duration("window", stats.Window),
+		zap.Uint64("total", stats.Total))
+}
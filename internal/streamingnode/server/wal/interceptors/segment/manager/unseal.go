@@ -0,0 +1,37 @@
+package manager
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+var ErrUnsealNotAllowed = errors.New("unseal not allowed")
+
+// checkUnsealable checks whether the segment waiting in the seal queue can be reverted back to growing.
+// The segment sealed by the operations that promise no more insert on it can never be unsealed,
+// otherwise the promise of fence, flush, schema change or drop is broken.
+func checkUnsealable(segment *segmentAllocManager) error {
+	switch {
+	case segment.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED:
+		return errors.Wrapf(ErrUnsealNotAllowed, "segment %d is at state %s", segment.GetSegmentID(), segment.GetState())
+	case segment.IsL0():
+		return errors.Wrapf(ErrUnsealNotAllowed, "segment %d is a L0 segment", segment.GetSegmentID())
+	case segment.IsDiscard():
+		return errors.Wrapf(ErrUnsealNotAllowed, "segment %d is discarded", segment.GetSegmentID())
+	case segment.IsFlushMaybeAppended():
+		return errors.Wrapf(ErrUnsealNotAllowed, "flush message of segment %d may be appended", segment.GetSegmentID())
+	case segment.GetStat() == nil:
+		return errors.Wrapf(ErrUnsealNotAllowed, "segment %d has no stat", segment.GetSegmentID())
+	}
+	switch segment.SealPolicy() {
+	case policy.PolicyNameFenced, policy.PolicyNameForce, policy.PolicyNameSchemaChanged,
+		policy.PolicyNameRecover, policy.PolicyNameQuarantined, policy.PolicyNamePartitionDropPrepared:
+		return errors.Wrapf(ErrUnsealNotAllowed, "segment %d is sealed by policy %s", segment.GetSegmentID(), segment.SealPolicy())
+	}
+	if policy.IsDropPolicy(segment.SealPolicy()) {
+		return errors.Wrapf(ErrUnsealNotAllowed, "segment %d is sealed by drop policy %s", segment.GetSegmentID(), segment.SealPolicy())
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package policy
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// IsAutoSealDisabled returns whether the collection is opted out of the policy-driven seal.
+// The segment of such collection is only sealed by the manual flush, the drop, the fence and the hard memory protection,
+// it's used by the ingestion pipeline that orchestrates the flush by itself.
+func IsAutoSealDisabled(collectionID int64) bool {
+	if config, ok := getAppliedCollectionConfig(collectionID); ok {
+		return config.GetAutoSealDisabled()
+	}
+	for _, item := range paramtable.Get().StreamingCfg.WALSegmentAssignAutoSealDisabledCollections.GetAsStrings() {
+		id, err := strconv.ParseInt(strings.TrimSpace(item), 10, 64)
+		if err != nil {
+			log.Warn("invalid auto seal disabled collection, ignored", zap.String("collection", item))
+			continue
+		}
+		if id == collectionID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAutoSealDisabledWarnThreshold returns the growing binary size of a collection opted out of the auto seal that triggers a warning,
+// 0 if the warning is disabled.
+func GetAutoSealDisabledWarnThreshold() uint64 {
+	return uint64(paramtable.Get().StreamingCfg.WALSegmentAssignAutoSealDisabledWarnThreshold.GetAsFloat() * 1024 * 1024)
+}
+
+// GetAutoSealDisabledWarnInterval returns the min interval between the warnings of a collection opted out of the auto seal.
+func GetAutoSealDisabledWarnInterval() time.Duration {
+	return paramtable.Get().StreamingCfg.WALSegmentAssignAutoSealDisabledWarnInterval.GetAsDurationByParse()
+}
@@ -0,0 +1,60 @@
+package policy
+
+import (
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+// appliedCollectionConfigs keeps the assignment config applied to the restored collections, keyed by collection id.
+// The applied config takes precedence over the collection level config of paramtable,
+// so the restored collection is assigned as same as the original one whatever the node config is.
+var appliedCollectionConfigs = typeutil.NewConcurrentMap[int64, *streamingpb.CollectionAssignmentConfig]()
+
+// ValidateCollectionAssignmentConfig checks if the assignment config can be applied.
+func ValidateCollectionAssignmentConfig(config *streamingpb.CollectionAssignmentConfig) error {
+	if version := config.GetStorageVersion(); version != storage.StorageV1 && version != storage.StorageV2 {
+		return errors.Newf("invalid storage version %d of collection assignment config", version)
+	}
+	return nil
+}
+
+// ApplyCollectionAssignmentConfig applies the assignment config to the collection of the config.
+func ApplyCollectionAssignmentConfig(config *streamingpb.CollectionAssignmentConfig) error {
+	if err := ValidateCollectionAssignmentConfig(config); err != nil {
+		return err
+	}
+	appliedCollectionConfigs.Insert(config.GetCollectionId(), proto.Clone(config).(*streamingpb.CollectionAssignmentConfig))
+	return nil
+}
+
+// RemoveCollectionAssignmentConfig removes the assignment config applied to the collection.
+func RemoveCollectionAssignmentConfig(collectionID int64) {
+	appliedCollectionConfigs.Remove(collectionID)
+}
+
+// ExportCollectionAssignmentConfig exports the effective assignment config of the collection.
+// The unknown fields of the applied config are kept, so the config of a newer node survives the round trip.
+// The max binary size floor is kept by the segment manager, it's not filled here.
+func ExportCollectionAssignmentConfig(collectionID int64) *streamingpb.CollectionAssignmentConfig {
+	config := &streamingpb.CollectionAssignmentConfig{}
+	if applied, ok := appliedCollectionConfigs.Get(collectionID); ok {
+		config = proto.Clone(applied).(*streamingpb.CollectionAssignmentConfig)
+	}
+	config.CollectionId = collectionID
+	config.HighPriority = IsHighPriorityAssign(collectionID, false)
+	config.SmallCollection = IsSmallCollection(collectionID)
+	config.TxnNonBlockingSeal = !IsTxnBlockingSeal(collectionID)
+	config.TimeTickStrict = GetTimeTickValidationMode(collectionID) == TimeTickValidationModeStrict
+	config.StorageVersion = GetSegmentStorageVersion(collectionID)
+	config.AutoSealDisabled = IsAutoSealDisabled(collectionID)
+	return config
+}
+
+// getAppliedCollectionConfig returns the assignment config applied to the collection.
+func getAppliedCollectionConfig(collectionID int64) (*streamingpb.CollectionAssignmentConfig, bool) {
+	return appliedCollectionConfigs.Get(collectionID)
+}
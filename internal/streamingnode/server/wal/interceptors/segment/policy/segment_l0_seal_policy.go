@@ -0,0 +1,78 @@
+package policy
+
+import (
+	"time"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// the names of the async seal policies of the L0 segment.
+const (
+	PolicyNameL0MaxDeletes PolicyName = "l0_max_deletes"
+	PolicyNameL0Lifetime   PolicyName = "l0_lifetime"
+)
+
+// GetL0SegmentAsyncSealPolicy returns the async seal policy of the L0 segment.
+// The L0 segment only holds the deletes, so it's never sealed by size but by the count of deletes and its lifetime.
+func GetL0SegmentAsyncSealPolicy() []L0SegmentAsyncSealPolicy {
+	return []L0SegmentAsyncSealPolicy{
+		&sealL0ByMaxDeletes{},
+		&sealL0ByLifetime{},
+	}
+}
+
+// L0SegmentAsyncSealPolicy is the policy to check if a L0 segment should be sealed or not.
+// A policy should be stateless, and only check by segment stats and the given local time.
+type L0SegmentAsyncSealPolicy interface {
+	// Name returns the name of the policy.
+	Name() PolicyName
+
+	// ShouldBeSealed checks if the L0 segment should be sealed, and return the reason string.
+	ShouldBeSealed(stats *stats.DeleteSegmentStats, now time.Time) SealPolicyResult
+}
+
+// sealL0ByMaxDeletesExtraInfo is the extra info of the seal L0 by max deletes policy.
+type sealL0ByMaxDeletesExtraInfo struct {
+	MaxDeletes uint64
+}
+
+// sealL0ByMaxDeletes is a policy to seal the L0 segment by the count of deletes.
+type sealL0ByMaxDeletes struct{}
+
+// Name returns the name of the policy.
+func (p *sealL0ByMaxDeletes) Name() PolicyName {
+	return PolicyNameL0MaxDeletes
+}
+
+// ShouldBeSealed checks if the L0 segment should be sealed, and return the reason string.
+func (p *sealL0ByMaxDeletes) ShouldBeSealed(stats *stats.DeleteSegmentStats, now time.Time) SealPolicyResult {
+	maxDeletes := uint64(paramtable.Get().StreamingCfg.WALSegmentAssignL0MaxDeletes.GetAsInt64())
+	return SealPolicyResult{
+		PolicyName:     p.Name(),
+		ShouldBeSealed: maxDeletes > 0 && stats.Delete.Rows >= maxDeletes,
+		ExtraInfo: sealL0ByMaxDeletesExtraInfo{
+			MaxDeletes: maxDeletes,
+		},
+	}
+}
+
+// sealL0ByLifetime is a policy to seal the L0 segment by the lifetime.
+type sealL0ByLifetime struct{}
+
+// Name returns the name of the policy.
+func (p *sealL0ByLifetime) Name() PolicyName {
+	return PolicyNameL0Lifetime
+}
+
+// ShouldBeSealed checks if the L0 segment should be sealed, and return the reason string.
+func (p *sealL0ByLifetime) ShouldBeSealed(stats *stats.DeleteSegmentStats, now time.Time) SealPolicyResult {
+	lifetime := paramtable.Get().StreamingCfg.WALSegmentAssignL0MaxLifetime.GetAsDurationByParse()
+	return SealPolicyResult{
+		PolicyName:     p.Name(),
+		ShouldBeSealed: now.Sub(stats.CreateTime) > lifetime,
+		ExtraInfo: sealByLifetimeExtraInfo{
+			MaxLifeTime: lifetime,
+		},
+	}
+}
@@ -12,6 +12,14 @@ func GetSegmentLimitationPolicy() SegmentLimitationPolicy {
 	return jitterSegmentLimitationPolicy{}
 }
 
+// GetSegmentMaxBinarySizeLimit returns the effective max binary size limit of a growing segment without jitter.
+// The limitation generated by the limitation policy is never greater than it.
+func GetSegmentMaxBinarySizeLimit() uint64 {
+	maxSegmentSize := uint64(paramtable.Get().DataCoordCfg.SegmentMaxSize.GetAsInt64() * 1024 * 1024)
+	proportion := paramtable.Get().DataCoordCfg.SegmentSealProportion.GetAsFloat()
+	return uint64(float64(maxSegmentSize) * proportion)
+}
+
 // SegmentLimitation is the limitation of the segment.
 type SegmentLimitation struct {
 	PolicyName  string
@@ -19,10 +27,17 @@ type SegmentLimitation struct {
 	ExtraInfo   interface{}
 }
 
+// RandomSource is the source of the randomized decisions of the policy,
+// the decisions are reproducible if the source is seeded with the same seed.
+type RandomSource interface {
+	// Float64 returns a random number in [0, 1).
+	Float64() float64
+}
+
 // SegmentLimitationPolicy is the interface to generate the limitation of the segment.
 type SegmentLimitationPolicy interface {
-	// GenerateLimitation generates the limitation of the segment.
-	GenerateLimitation() SegmentLimitation
+	// GenerateLimitation generates the limitation of the segment, the global random source is used if the source is nil.
+	GenerateLimitation(source RandomSource) SegmentLimitation
 }
 
 // jitterSegmentLimitationPolicyExtraInfo is the extra info of the jitter segment limitation policy.
@@ -38,11 +53,15 @@ type jitterSegmentLimitationPolicyExtraInfo struct {
 type jitterSegmentLimitationPolicy struct{}
 
 // GenerateLimitation generates the limitation of the segment.
-func (p jitterSegmentLimitationPolicy) GenerateLimitation() SegmentLimitation {
+func (p jitterSegmentLimitationPolicy) GenerateLimitation(source RandomSource) SegmentLimitation {
 	// TODO: It's weird to set such a parameter into datacoord configuration.
 	// Refactor it in the future
 	jitter := paramtable.Get().DataCoordCfg.SegmentSealProportionJitter.GetAsFloat()
-	jitterRatio := 1 - jitter*rand.Float64() // generate a random number in [1-jitter, 1]
+	sample := rand.Float64
+	if source != nil {
+		sample = source.Float64
+	}
+	jitterRatio := 1 - jitter*sample() // generate a random number in [1-jitter, 1]
 	if jitterRatio <= 0 || jitterRatio > 1 {
 		jitterRatio = 1
 	}
@@ -0,0 +1,21 @@
+package policy
+
+import (
+	"strings"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// IsPreassignedProducerAllowed returns whether the internal producer is allowed to bypass the segment assignment.
+// The empty producer is never allowed, so the pre-assigned insert of the normal client paths is always rejected.
+func IsPreassignedProducerAllowed(producer string) bool {
+	if producer == "" {
+		return false
+	}
+	for _, item := range paramtable.Get().StreamingCfg.WALSegmentAssignPreassignedProducers.GetAsStrings() {
+		if strings.TrimSpace(item) == producer {
+			return true
+		}
+	}
+	return false
+}
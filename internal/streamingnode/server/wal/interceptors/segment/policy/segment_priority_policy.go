@@ -0,0 +1,63 @@
+package policy
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// IsHighPriorityAssign returns whether the insert of the collection should be assigned into the high priority segments.
+// The insert is high priority if it's flagged by its header or the collection is configured as high priority,
+// the config applied to the restored collection takes precedence over the paramtable.
+func IsHighPriorityAssign(collectionID int64, flagged bool) bool {
+	if flagged {
+		return true
+	}
+	if config, ok := getAppliedCollectionConfig(collectionID); ok {
+		return config.GetHighPriority()
+	}
+	for _, item := range paramtable.Get().StreamingCfg.WALSegmentAssignHighPriorityCollections.GetAsStrings() {
+		id, err := strconv.ParseInt(strings.TrimSpace(item), 10, 64)
+		if err != nil {
+			log.Warn("invalid high priority collection, ignored", zap.String("collection", item))
+			continue
+		}
+		if id == collectionID {
+			return true
+		}
+	}
+	return false
+}
+
+// highPriorityLimitationExtraInfo is the extra info of the high priority segment limitation.
+type highPriorityLimitationExtraInfo struct {
+	SizeRatio  float64
+	Underlying interface{}
+}
+
+// GetHighPrioritySegmentLimitation scales the limitation of the normal segment into the high priority segment.
+// The high priority segment is smaller, so it's sealed and flushed earlier.
+func GetHighPrioritySegmentLimitation(limitation SegmentLimitation) SegmentLimitation {
+	ratio := paramtable.Get().StreamingCfg.WALSegmentAssignHighPrioritySizeRatio.GetAsFloat()
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+	return SegmentLimitation{
+		PolicyName:  "high_priority_" + limitation.PolicyName,
+		SegmentSize: uint64(float64(limitation.SegmentSize) * ratio),
+		ExtraInfo: highPriorityLimitationExtraInfo{
+			SizeRatio:  ratio,
+			Underlying: limitation.ExtraInfo,
+		},
+	}
+}
+
+// getHighPrioritySegmentMaxLifetime returns the max lifetime of the high priority segment.
+func getHighPrioritySegmentMaxLifetime() time.Duration {
+	return paramtable.Get().StreamingCfg.WALSegmentAssignHighPriorityMaxLifetime.GetAsDurationByParse()
+}
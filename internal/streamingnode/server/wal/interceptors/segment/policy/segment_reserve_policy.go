@@ -0,0 +1,86 @@
+package policy
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/v2/config"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+const reserveAllPartitions = "*"
+
+var (
+	reservePartitionsWatch sync.Once
+	reservePartitions      atomic.Pointer[reservePartitionSet]
+)
+
+// reservePartitionSet is the parsed partitions of the reserve config, it's parsed once for each raw value of the config.
+type reservePartitionSet struct {
+	raw string
+	all bool
+	ids typeutil.Set[int64]
+}
+
+// contains checks if the partition is in the set.
+func (s *reservePartitionSet) contains(partitionID int64) bool {
+	return s.all || s.ids.Contain(partitionID)
+}
+
+// parseReservePartitions parses the raw value of the reserve config, the invalid item is warned and ignored.
+func parseReservePartitions(raw string, items []string) *reservePartitionSet {
+	set := &reservePartitionSet{raw: raw, ids: typeutil.NewSet[int64]()}
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == reserveAllPartitions {
+			set.all = true
+			continue
+		}
+		id, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			log.Warn("invalid reserve partition, ignored", zap.String("partition", item))
+			continue
+		}
+		set.ids.Insert(id)
+	}
+	return set
+}
+
+// getReservePartitions returns the cached partitions of the reserve config.
+// The cache is refreshed by the config event, and also by the raw value change that isn't dispatched as an event.
+func getReservePartitions() *reservePartitionSet {
+	item := &paramtable.Get().StreamingCfg.WALSegmentAssignReservePartitions
+	reservePartitionsWatch.Do(func() {
+		paramtable.Get().Watch(item.Key, config.NewHandler("segment.reserve."+item.Key, func(_ *config.Event) {
+			reservePartitions.Store(parseReservePartitions(item.GetValue(), item.GetAsStrings()))
+		}))
+	})
+	raw := item.GetValue()
+	if cached := reservePartitions.Load(); cached != nil && cached.raw == raw {
+		return cached
+	}
+	set := parseReservePartitions(raw, item.GetAsStrings())
+	reservePartitions.Store(set)
+	return set
+}
+
+// IsReserveEnabled returns whether the partition keeps a reserved growing segment to absorb the write burst across the seal boundary.
+func IsReserveEnabled(partitionID int64) bool {
+	return getReservePartitions().contains(partitionID)
+}
+
+// GetReserveFillThreshold returns the fill ratio of the growing segment that triggers the pre-allocation of the reserved segment.
+// The invalid threshold falls back to 1, so the reserved segment is only allocated when the growing segment is full.
+func GetReserveFillThreshold() float64 {
+	threshold := paramtable.Get().StreamingCfg.WALSegmentAssignReserveFillThreshold.GetAsFloat()
+	if threshold <= 0 || threshold > 1 {
+		return 1
+	}
+	return threshold
+}
@@ -0,0 +1,41 @@
+package policy
+
+import (
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// GetRowSizeRequiredBinarySize returns the binary size that a growing segment should hold at least for the average row size,
+// return 0 if the row size check is disabled or the average row size is not observed yet.
+func GetRowSizeRequiredBinarySize(avgRowSize uint64) uint64 {
+	minRows := paramtable.Get().StreamingCfg.WALSegmentAssignRowSizeCheckMinRows.GetAsInt64()
+	if minRows <= 0 || avgRowSize == 0 {
+		return 0
+	}
+	return uint64(minRows) * avgRowSize
+}
+
+// IsRowSizeAutoBumpEnabled returns whether the max binary size of the collection failing the row size check is bumped.
+func IsRowSizeAutoBumpEnabled() bool {
+	return paramtable.Get().StreamingCfg.WALSegmentAssignRowSizeCheckAutoBump.GetAsBool()
+}
+
+// rowSizeFloorLimitationExtraInfo is the extra info of the row size floor segment limitation.
+type rowSizeFloorLimitationExtraInfo struct {
+	Floor      uint64
+	Underlying interface{}
+}
+
+// GetRowSizeFloorSegmentLimitation raises the limitation of the segment to the floor bumped by the row size check of the collection.
+func GetRowSizeFloorSegmentLimitation(limitation SegmentLimitation, floor uint64) SegmentLimitation {
+	if floor <= limitation.SegmentSize {
+		return limitation
+	}
+	return SegmentLimitation{
+		PolicyName:  "row_size_floor_" + limitation.PolicyName,
+		SegmentSize: floor,
+		ExtraInfo: rowSizeFloorLimitationExtraInfo{
+			Floor:      floor,
+			Underlying: limitation.ExtraInfo,
+		},
+	}
+}
@@ -10,14 +10,35 @@ import (
 type PolicyName string
 
 var (
-	PolicyNamePartitionNotFound PolicyName = "partition_not_found"
-	PolicyNamePartitionRemoved  PolicyName = "partition_removed"
-	PolicyNameCollectionRemoved PolicyName = "collection_removed"
-	PolicyNameRecover           PolicyName = "recover"
-	PolicyNameFenced            PolicyName = "fenced"
-	PolicyNameForce             PolicyName = "force"
+	PolicyNamePartitionNotFound     PolicyName = "partition_not_found"
+	PolicyNamePartitionRemoved      PolicyName = "partition_removed"
+	PolicyNameCollectionRemoved     PolicyName = "collection_removed"
+	PolicyNameRecover               PolicyName = "recover"
+	PolicyNameFenced                PolicyName = "fenced"
+	PolicyNameForce                 PolicyName = "force"
+	PolicyNameQuarantined           PolicyName = "quarantined"
+	PolicyNameOlderThan             PolicyName = "older_than"
+	PolicyNamePartitionDiscarded    PolicyName = "partition_discarded"
+	PolicyNameMaxSizeReconciled     PolicyName = "max_size_reconciled"
+	PolicyNameSchemaChanged         PolicyName = "schema_changed"
+	PolicyNameAdopted               PolicyName = "adopted"
+	PolicyNameByCapacity            PolicyName = "by_capacity"
+	PolicyNameBinlogNumber          PolicyName = "binlog_number"
+	PolicyNameByLifetime            PolicyName = "by_lifetime"
+	PolicyNameByIdleTime            PolicyName = "by_idle_time"
+	PolicyNamePartitionDropPrepared PolicyName = "partition_drop_prepared"
 )
 
+// IsDropPolicy returns whether the segment is sealed by a drop operation of collection or partition.
+func IsDropPolicy(name PolicyName) bool {
+	switch name {
+	case PolicyNamePartitionNotFound, PolicyNamePartitionRemoved, PolicyNameCollectionRemoved, PolicyNamePartitionDiscarded:
+		return true
+	default:
+		return false
+	}
+}
+
 // GetSegmentAsyncSealPolicy returns the segment async seal policy.
 func GetSegmentAsyncSealPolicy() []SegmentAsyncSealPolicy {
 	// TODO: dynamic policy can be applied here in future.
@@ -29,6 +50,31 @@ func GetSegmentAsyncSealPolicy() []SegmentAsyncSealPolicy {
 	}
 }
 
+// GetAsyncSealPolicyChain returns the names of the async seal policies in the evaluation order,
+// the policies of the growing segment come first, then the ones of the L0 segment.
+// The small collection lifetime policy is listed if it's enabled, but it's only evaluated on the small collections.
+func GetAsyncSealPolicyChain() []string {
+	chain := make([]string, 0, 7)
+	for _, p := range GetSegmentAsyncSealPolicy() {
+		chain = append(chain, string(p.Name()))
+	}
+	if GetSmallCollectionMaxLifetime() > 0 {
+		chain = append(chain, string((&sealBySmallCollectionLifetime{}).Name()))
+	}
+	for _, p := range GetL0SegmentAsyncSealPolicy() {
+		chain = append(chain, string(p.Name()))
+	}
+	return chain
+}
+
+// IsInSealGracePeriod returns whether the segment created at the given time is still in the seal grace period.
+// The segment in the grace period is exempt from the async seal policies,
+// so a near-empty segment is not sealed by a noisy policy right after it's created.
+func IsInSealGracePeriod(createTime time.Time, now time.Time) bool {
+	gracePeriod := paramtable.Get().StreamingCfg.WALSegmentAssignSealGracePeriod.GetAsDurationByParse()
+	return gracePeriod > 0 && now.Sub(createTime) < gracePeriod
+}
+
 // SealPolicyResult is the result of the seal policy.
 type SealPolicyResult struct {
 	PolicyName     PolicyName
@@ -38,20 +84,28 @@ type SealPolicyResult struct {
 
 // SegmentAsyncSealPolicy is the policy to check if a segment should be sealed or not.
 // Those policies are called asynchronously, so the stat is not real time.
-// A policy should be stateless, and only check by segment stats.
+// A policy should be stateless, and only check by segment stats and the given local time.
 // quick enough to be called.
 type SegmentAsyncSealPolicy interface {
+	// Name returns the name of the policy.
+	Name() PolicyName
+
 	// ShouldBeSealed checks if the segment should be sealed, and return the reason string.
-	ShouldBeSealed(stats *stats.SegmentStats) SealPolicyResult
+	ShouldBeSealed(stats *stats.SegmentStats, now time.Time) SealPolicyResult
 }
 
 // sealByCapacity is a policy to seal the segment by the capacity.
 type sealByCapacity struct{}
 
+// Name returns the name of the policy.
+func (p *sealByCapacity) Name() PolicyName {
+	return PolicyNameByCapacity
+}
+
 // ShouldBeSealed checks if the segment should be sealed, and return the reason string.
-func (p *sealByCapacity) ShouldBeSealed(stats *stats.SegmentStats) SealPolicyResult {
+func (p *sealByCapacity) ShouldBeSealed(stats *stats.SegmentStats, now time.Time) SealPolicyResult {
 	return SealPolicyResult{
-		PolicyName:     "by_capacity",
+		PolicyName:     p.Name(),
 		ShouldBeSealed: stats.ReachLimit,
 		ExtraInfo:      nil,
 	}
@@ -65,12 +119,17 @@ type sealByBinlogFileExtraInfo struct {
 // sealByBinlogNumber is a policy to seal the segment by the binlog file number.
 type sealByBinlogNumber struct{}
 
+// Name returns the name of the policy.
+func (p *sealByBinlogNumber) Name() PolicyName {
+	return PolicyNameBinlogNumber
+}
+
 // ShouldBeSealed checks if the segment should be sealed, and return the reason string.
-func (p *sealByBinlogNumber) ShouldBeSealed(stats *stats.SegmentStats) SealPolicyResult {
+func (p *sealByBinlogNumber) ShouldBeSealed(stats *stats.SegmentStats, now time.Time) SealPolicyResult {
 	limit := paramtable.Get().DataCoordCfg.SegmentMaxBinlogFileNumber.GetAsInt()
 	shouldBeSealed := stats.BinLogCounter >= uint64(limit)
 	return SealPolicyResult{
-		PolicyName:     "binlog_number",
+		PolicyName:     p.Name(),
 		ShouldBeSealed: shouldBeSealed,
 		ExtraInfo: &sealByBinlogFileExtraInfo{
 			BinLogNumberLimit: limit,
@@ -86,12 +145,21 @@ type sealByLifetimeExtraInfo struct {
 // sealByLifetime is a policy to seal the segment by the lifetime.
 type sealByLifetime struct{}
 
+// Name returns the name of the policy.
+func (p *sealByLifetime) Name() PolicyName {
+	return PolicyNameByLifetime
+}
+
 // ShouldBeSealed checks if the segment should be sealed, and return the reason string.
-func (p *sealByLifetime) ShouldBeSealed(stats *stats.SegmentStats) SealPolicyResult {
+func (p *sealByLifetime) ShouldBeSealed(stats *stats.SegmentStats, now time.Time) SealPolicyResult {
 	lifetime := paramtable.Get().DataCoordCfg.SegmentMaxLifetime.GetAsDuration(time.Second)
-	shouldBeSealed := time.Since(stats.CreateTime) > lifetime
+	if stats.HighPriority {
+		// the high priority segment is sealed on its own smaller lifetime to bound the flush latency.
+		lifetime = min(lifetime, getHighPrioritySegmentMaxLifetime())
+	}
+	shouldBeSealed := now.Sub(stats.CreateTime) > lifetime
 	return SealPolicyResult{
-		PolicyName:     "by_lifetime",
+		PolicyName:     p.Name(),
 		ShouldBeSealed: shouldBeSealed,
 		ExtraInfo: sealByLifetimeExtraInfo{
 			MaxLifeTime: lifetime,
@@ -108,14 +176,19 @@ type sealByIdleTimeExtraInfo struct {
 // sealByIdleTime is a policy to seal the segment by the idle time.
 type sealByIdleTime struct{}
 
+// Name returns the name of the policy.
+func (p *sealByIdleTime) Name() PolicyName {
+	return PolicyNameByIdleTime
+}
+
 // ShouldBeSealed checks if the segment should be sealed, and return the reason string.
-func (p *sealByIdleTime) ShouldBeSealed(stats *stats.SegmentStats) SealPolicyResult {
+func (p *sealByIdleTime) ShouldBeSealed(stats *stats.SegmentStats, now time.Time) SealPolicyResult {
 	idleTime := paramtable.Get().DataCoordCfg.SegmentMaxIdleTime.GetAsDuration(time.Second)
 	minSize := uint64(paramtable.Get().DataCoordCfg.SegmentMinSizeFromIdleToSealed.GetAsInt() * 1024 * 1024)
 
-	shouldBeSealed := stats.Insert.BinarySize > minSize && time.Since(stats.LastModifiedTime) > idleTime
+	shouldBeSealed := stats.Insert.BinarySize > minSize && now.Sub(stats.LastModifiedTime) > idleTime
 	return SealPolicyResult{
-		PolicyName:     "by_idle_time",
+		PolicyName:     p.Name(),
 		ShouldBeSealed: shouldBeSealed,
 		ExtraInfo: sealByIdleTimeExtraInfo{
 			IdleTime:    idleTime,
@@ -0,0 +1,18 @@
+package policy
+
+// ShardHintScore returns the rendezvous score of the growing segment for the shard hint of the insert.
+// The insert is routed to the growing segment with the highest score, so the rows of the same hint keep landing in the same segment until it's sealed,
+// and the hints of a sealed segment are spread over the remaining ones without moving the hints of the others.
+func ShardHintScore(hint uint64, segmentID int64) uint64 {
+	return mixShardHint(hint ^ mixShardHint(uint64(segmentID)))
+}
+
+// mixShardHint is the finalizer of splitmix64, it spreads the bits of the hash uniformly.
+func mixShardHint(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+const PolicyNameSmallCollectionLifetime PolicyName = "small_collection_lifetime"
+
+// IsSmallCollection returns whether the collection is designated as a small collection,
+// whose growing segment is sized by its observed ingest rate and sealed on its own smaller lifetime.
+func IsSmallCollection(collectionID int64) bool {
+	if config, ok := getAppliedCollectionConfig(collectionID); ok {
+		return config.GetSmallCollection()
+	}
+	for _, item := range paramtable.Get().StreamingCfg.WALSegmentAssignSmallCollectionCollections.GetAsStrings() {
+		id, err := strconv.ParseInt(strings.TrimSpace(item), 10, 64)
+		if err != nil {
+			log.Warn("invalid small collection, ignored", zap.String("collection", item))
+			continue
+		}
+		if id == collectionID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSmallCollectionMaxLifetime returns the max lifetime of the growing segment of the small collection.
+func GetSmallCollectionMaxLifetime() time.Duration {
+	return paramtable.Get().StreamingCfg.WALSegmentAssignSmallCollectionMaxLifetime.GetAsDurationByParse()
+}
+
+// GetSmallCollectionMaxBinarySize returns the max binary size override of the growing segment of the small collection.
+// The size is the binary size expected to be ingested within the max lifetime at the given ingest rate (bytes per second),
+// it's clamped into [minSize, limit], so the segment is never larger than the normal one.
+func GetSmallCollectionMaxBinarySize(ingestRate float64, limit uint64) uint64 {
+	minSize := uint64(paramtable.Get().StreamingCfg.WALSegmentAssignSmallCollectionMinSize.GetAsFloat() * 1024 * 1024)
+	size := uint64(ingestRate * GetSmallCollectionMaxLifetime().Seconds())
+	size = max(size, minSize)
+	return min(size, limit)
+}
+
+// smallCollectionLimitationExtraInfo is the extra info of the small collection segment limitation.
+type smallCollectionLimitationExtraInfo struct {
+	MaxBinarySize uint64
+	Underlying    interface{}
+}
+
+// GetSmallCollectionSegmentLimitation caps the limitation of the segment with the max binary size override of the small collection.
+func GetSmallCollectionSegmentLimitation(limitation SegmentLimitation, maxBinarySize uint64) SegmentLimitation {
+	if maxBinarySize == 0 || maxBinarySize >= limitation.SegmentSize {
+		return limitation
+	}
+	return SegmentLimitation{
+		PolicyName:  "small_collection_" + limitation.PolicyName,
+		SegmentSize: maxBinarySize,
+		ExtraInfo: smallCollectionLimitationExtraInfo{
+			MaxBinarySize: maxBinarySize,
+			Underlying:    limitation.ExtraInfo,
+		},
+	}
+}
+
+// GetCollectionAsyncSealPolicy returns the segment async seal policy of the collection.
+// The small collection is additionally sealed by its own smaller lifetime, so the tiny collection is flushed promptly.
+func GetCollectionAsyncSealPolicy(collectionID int64) []SegmentAsyncSealPolicy {
+	policies := GetSegmentAsyncSealPolicy()
+	if IsSmallCollection(collectionID) {
+		policies = append(policies, &sealBySmallCollectionLifetime{})
+	}
+	return policies
+}
+
+// sealBySmallCollectionLifetime is a policy to seal the segment of the small collection by its own lifetime.
+type sealBySmallCollectionLifetime struct{}
+
+// Name returns the name of the policy.
+func (p *sealBySmallCollectionLifetime) Name() PolicyName {
+	return PolicyNameSmallCollectionLifetime
+}
+
+// ShouldBeSealed checks if the segment should be sealed, and return the reason string.
+func (p *sealBySmallCollectionLifetime) ShouldBeSealed(stats *stats.SegmentStats, now time.Time) SealPolicyResult {
+	lifetime := GetSmallCollectionMaxLifetime()
+	return SealPolicyResult{
+		PolicyName:     p.Name(),
+		ShouldBeSealed: lifetime > 0 && now.Sub(stats.CreateTime) > lifetime,
+		ExtraInfo: sealByLifetimeExtraInfo{
+			MaxLifeTime: lifetime,
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package policy
+
+import (
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// GetSegmentStorageVersion returns the storage version of the new segment of the collection.
+// The config applied to the restored collection and the per-collection override are applied first,
+// otherwise the global storage v2 switch is used.
+// The storage version is recorded on the segment when allocated, so it never changes during the life of the segment.
+func GetSegmentStorageVersion(collectionID int64) int64 {
+	if config, ok := getAppliedCollectionConfig(collectionID); ok {
+		return config.GetStorageVersion()
+	}
+	if version, ok := getStorageVersionOverride(collectionID); ok {
+		return version
+	}
+	if paramtable.Get().CommonCfg.EnableStorageV2.GetAsBool() {
+		return storage.StorageV2
+	}
+	return storage.StorageV1
+}
+
+// getStorageVersionOverride returns the overridden storage version of the collection.
+// The override is formatted as "collectionID:storageVersion", the invalid one is ignored.
+func getStorageVersionOverride(collectionID int64) (int64, bool) {
+	for _, override := range paramtable.Get().StreamingCfg.WALSegmentAssignStorageVersionOverrides.GetAsStrings() {
+		parts := strings.Split(strings.TrimSpace(override), ":")
+		if len(parts) != 2 {
+			log.Warn("invalid storage version override, ignored", zap.String("override", override))
+			continue
+		}
+		id, err := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+		if err != nil || id != collectionID {
+			continue
+		}
+		version, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil || (version != storage.StorageV1 && version != storage.StorageV2) {
+			log.Warn("invalid storage version override, ignored", zap.String("override", override))
+			continue
+		}
+		return version, true
+	}
+	return 0, false
+}
@@ -0,0 +1,50 @@
+package policy
+
+import (
+	"strings"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// GetSealSweepFullPassCycles returns the count of sweep cycles that every clean partition is evaluated at least once.
+// 1 means every partition is evaluated at every sweep cycle.
+func GetSealSweepFullPassCycles() uint64 {
+	cycles := paramtable.Get().StreamingCfg.WALSegmentAssignSealSweepFullPassCycles.GetAsInt64()
+	if cycles < 1 {
+		return 1
+	}
+	return uint64(cycles)
+}
+
+// GetSealPolicyConfigFingerprint returns the fingerprint of the configs that the seal policy sweep depends on.
+// The fingerprint is changed if any of these configs is changed, so every partition is evaluated again by the sweep.
+func GetSealPolicyConfigFingerprint() string {
+	params := paramtable.Get()
+	items := []*paramtable.ParamItem{
+		&params.DataCoordCfg.SegmentMaxSize,
+		&params.DataCoordCfg.SegmentSealProportion,
+		&params.DataCoordCfg.SegmentSealProportionJitter,
+		&params.DataCoordCfg.SegmentMaxLifetime,
+		&params.DataCoordCfg.SegmentMaxIdleTime,
+		&params.DataCoordCfg.SegmentMinSizeFromIdleToSealed,
+		&params.DataCoordCfg.SegmentMaxBinlogFileNumber,
+		&params.StreamingCfg.WALSegmentAssignMaxSizeReconcileFactor,
+		&params.StreamingCfg.WALSegmentAssignMaxSizeReconcileRecap,
+		&params.StreamingCfg.WALSegmentAssignSealGracePeriod,
+		&params.StreamingCfg.WALSegmentAssignHighPriorityCollections,
+		&params.StreamingCfg.WALSegmentAssignHighPriorityMaxLifetime,
+		&params.StreamingCfg.WALSegmentAssignHighPrioritySizeRatio,
+		&params.StreamingCfg.WALSegmentAssignL0MaxDeletes,
+		&params.StreamingCfg.WALSegmentAssignL0MaxLifetime,
+		&params.StreamingCfg.WALSegmentAssignSmallCollectionCollections,
+		&params.StreamingCfg.WALSegmentAssignSmallCollectionMaxLifetime,
+		&params.StreamingCfg.WALSegmentAssignSmallCollectionMinSize,
+		&params.StreamingCfg.WALSegmentAssignAutoSealDisabledCollections,
+	}
+	var b strings.Builder
+	for _, item := range items {
+		b.WriteString(item.GetValue())
+		b.WriteByte(0)
+	}
+	return b.String()
+}
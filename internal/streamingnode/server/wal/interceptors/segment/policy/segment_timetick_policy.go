@@ -0,0 +1,39 @@
+package policy
+
+import (
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+const (
+	TimeTickValidationModeStrict  = "strict"
+	TimeTickValidationModeRelaxed = "relaxed"
+)
+
+// GetTimeTickValidationMode returns the mode of validating the insert time tick against the last seal time tick of the partition.
+// The insert of a strict collection is redone with a refreshed time tick if it's not newer than the last seal,
+// the relaxed collection keeps the insert landing in the next growing segment.
+func GetTimeTickValidationMode(collectionID int64) string {
+	if config, ok := getAppliedCollectionConfig(collectionID); ok {
+		if config.GetTimeTickStrict() {
+			return TimeTickValidationModeStrict
+		}
+		return TimeTickValidationModeRelaxed
+	}
+	for _, item := range paramtable.Get().StreamingCfg.WALSegmentAssignTimeTickStrictCollections.GetAsStrings() {
+		id, err := strconv.ParseInt(strings.TrimSpace(item), 10, 64)
+		if err != nil {
+			log.Warn("invalid time tick strict collection, ignored", zap.String("collection", item))
+			continue
+		}
+		if id == collectionID {
+			return TimeTickValidationModeStrict
+		}
+	}
+	return TimeTickValidationModeRelaxed
+}
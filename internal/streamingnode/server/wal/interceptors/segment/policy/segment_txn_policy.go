@@ -0,0 +1,30 @@
+package policy
+
+import (
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// IsTxnBlockingSeal returns whether the seal of the segment written by a running txn of the collection is blocked until the txn is done.
+// The collection configured as txn non-blocking seal keeps its segments seal-eligible, so the txn may span a seal boundary.
+func IsTxnBlockingSeal(collectionID int64) bool {
+	if config, ok := getAppliedCollectionConfig(collectionID); ok {
+		return !config.GetTxnNonBlockingSeal()
+	}
+	for _, item := range paramtable.Get().StreamingCfg.WALSegmentAssignTxnNonBlockingSealCollections.GetAsStrings() {
+		id, err := strconv.ParseInt(strings.TrimSpace(item), 10, 64)
+		if err != nil {
+			log.Warn("invalid txn non-blocking seal collection, ignored", zap.String("collection", item))
+			continue
+		}
+		if id == collectionID {
+			return false
+		}
+	}
+	return true
+}
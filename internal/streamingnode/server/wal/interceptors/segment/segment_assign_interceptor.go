@@ -2,27 +2,41 @@ package segment
 
 import (
 	"context"
-	"time"
+	"runtime/debug"
+	"sync"
 
 	"github.com/cockroachdb/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/redo"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/inspector"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/manager"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/policy"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/txn"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/utility"
 	"github.com/milvus-io/milvus/internal/util/streamingutil/status"
+	"github.com/milvus-io/milvus/pkg/v2/common"
 	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
 	"github.com/milvus-io/milvus/pkg/v2/proto/messagespb"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
 	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
 	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
 )
 
-const interceptorName = "segment-assign"
+const (
+	interceptorName = "segment-assign"
+
+	// maxPanicStackSize is the max size of the stack carried by the error converted from a handler panic.
+	maxPanicStackSize = 4096
+)
 
 var (
 	_ interceptors.InterceptorWithMetrics = (*segmentInterceptor)(nil)
@@ -34,8 +48,14 @@ type segmentInterceptor struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
-	logger        *log.MLogger
-	assignManager *syncutil.Future[*manager.PChannelSegmentAllocManager]
+	logger                    *log.MLogger
+	channel                   types.PChannelInfo
+	assignManager             *syncutil.Future[*manager.PChannelSegmentAllocManager]
+	registerOnce              sync.Once
+	zeroRowsInsertTotal       prometheus.Counter
+	flushTimeTickRefreshTotal prometheus.Counter
+	handlerPanicTotal         *prometheus.CounterVec
+	duplicatePartitionTotal   *prometheus.CounterVec
 }
 
 func (impl *segmentInterceptor) Name() string {
@@ -50,6 +70,24 @@ func (impl *segmentInterceptor) Ready() <-chan struct{} {
 
 // DoAppend assigns segment for every partition in the message.
 func (impl *segmentInterceptor) DoAppend(ctx context.Context, msg message.MutableMessage, appendOp interceptors.Append) (msgID message.MessageID, err error) {
+	// a panic of a handler (e.g. a broken header of a buggy client) should never take down the append pipeline of the pchannel,
+	// it's converted into an unrecoverable error of the message, the interceptor keeps serving the subsequent messages.
+	// Only the panic before the append is recovered, the message may be already persisted once the append is called,
+	// so the panic of or after the append is never converted into an error of the message.
+	appended := false
+	underlyingAppendOp := appendOp
+	appendOp = func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+		appended = true
+		return underlyingAppendOp(ctx, msg)
+	}
+	defer func() {
+		if appended {
+			return
+		}
+		if r := recover(); r != nil {
+			msgID, err = nil, impl.handlePanic(msg, r)
+		}
+	}()
 	switch msg.MessageType() {
 	case message.MessageTypeCreateCollection:
 		return impl.handleCreateCollection(ctx, msg, appendOp)
@@ -57,23 +95,60 @@ func (impl *segmentInterceptor) DoAppend(ctx context.Context, msg message.Mutabl
 		return impl.handleDropCollection(ctx, msg, appendOp)
 	case message.MessageTypeCreatePartition:
 		return impl.handleCreatePartition(ctx, msg, appendOp)
+	case message.MessageTypeCreatePartitions:
+		return impl.handleCreatePartitions(ctx, msg, appendOp)
 	case message.MessageTypeDropPartition:
 		return impl.handleDropPartition(ctx, msg, appendOp)
+	case message.MessageTypeSchemaChange:
+		return impl.handleSchemaChange(ctx, msg, appendOp)
 	case message.MessageTypeInsert:
 		return impl.handleInsertMessage(ctx, msg, appendOp)
+	case message.MessageTypeDelete:
+		return impl.handleDeleteMessage(ctx, msg, appendOp)
+	case message.MessageTypeFlush:
+		return impl.handleFlushMessage(ctx, msg, appendOp)
 	case message.MessageTypeManualFlush:
 		return impl.handleManualFlushMessage(ctx, msg, appendOp)
+	case message.MessageTypePreparePartitionDrop:
+		return impl.handlePreparePartitionDropMessage(ctx, msg, appendOp)
+	case message.MessageTypeCommitTxn, message.MessageTypeRollbackTxn:
+		return impl.handleTxnDoneMessage(ctx, msg, appendOp)
 	default:
 		return appendOp(ctx, msg)
 	}
 }
 
+// handlePanic converts the panic of the handler into an unrecoverable error carrying the message type and a truncated stack.
+func (impl *segmentInterceptor) handlePanic(msg message.MutableMessage, r any) error {
+	stack := debug.Stack()
+	if len(stack) > maxPanicStackSize {
+		stack = stack[:maxPanicStackSize]
+	}
+	impl.handlerPanicTotal.WithLabelValues(msg.MessageType().String()).Inc()
+	impl.logger.Error("panic in segment assignment handler, the message is rejected",
+		zap.Stringer("messageType", msg.MessageType()),
+		zap.Any("panic", r),
+		zap.ByteString("stack", stack))
+	return status.NewUnrecoverableError("panic in segment assignment of %s message: %v, stack: %s", msg.MessageType(), r, stack)
+}
+
 // handleCreateCollection handles the create collection message.
 func (impl *segmentInterceptor) handleCreateCollection(ctx context.Context, msg message.MutableMessage, appendOp interceptors.Append) (message.MessageID, error) {
 	createCollectionMsg, err := message.AsMutableCreateCollectionMessageV1(msg)
 	if err != nil {
 		return nil, err
 	}
+	// the restored collection may carry the assignment config exported from the original one.
+	assignmentConfig, err := decodeCollectionAssignmentConfig(msg)
+	if err != nil {
+		return nil, status.NewInvaildArgument("invalid collection assignment config, %s", err.Error())
+	}
+	h := createCollectionMsg.Header()
+	// the collection beyond the capacity of the pchannel is rejected before the message is persisted.
+	if err := impl.assignManager.Get().CheckCapacity(h.GetCollectionId(), len(h.GetPartitionIds())); err != nil {
+		return nil, convertPChannelFullError(err)
+	}
+
 	// send the create collection message.
 	msgID, err := appendOp(ctx, msg)
 	if err != nil {
@@ -81,11 +156,38 @@ func (impl *segmentInterceptor) handleCreateCollection(ctx context.Context, msg
 	}
 
 	// Set up the partition manager for the collection, new incoming insert message can be assign segment.
-	h := createCollectionMsg.Header()
-	impl.assignManager.Get().NewCollection(h.GetCollectionId(), msg.VChannel(), h.GetPartitionIds())
+	// the collection is always created with the initial schema version.
+	if err := impl.assignManager.Get().NewCollection(h.GetCollectionId(), msg.VChannel(), h.GetPartitionIds(), manager.InitialSchemaVersion, msg.TimeTick()); err != nil {
+		// the capacity is taken by a concurrent creation after the check.
+		return msgID, convertPChannelFullError(err)
+	}
+	if assignmentConfig != nil {
+		// the message is already persisted, so the config is only logged if it can't be applied.
+		if err := impl.assignManager.Get().ApplyCollectionAssignmentConfig(h.GetCollectionId(), assignmentConfig); err != nil {
+			impl.logger.Warn("failed to apply collection assignment config",
+				zap.Int64("collectionID", h.GetCollectionId()), zap.Error(err))
+		}
+	}
 	return msgID, nil
 }
 
+// decodeCollectionAssignmentConfig decodes the collection assignment config carried by the create collection message.
+func decodeCollectionAssignmentConfig(msg message.MutableMessage) (*streamingpb.CollectionAssignmentConfig, error) {
+	raw, err := message.GetCollectionAssignmentConfig(msg)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	config := &streamingpb.CollectionAssignmentConfig{}
+	if err := proto.Unmarshal(raw, config); err != nil {
+		return nil, err
+	}
+	// validate the config before the message is persisted.
+	if err := policy.ValidateCollectionAssignmentConfig(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
 // handleDropCollection handles the drop collection message.
 func (impl *segmentInterceptor) handleDropCollection(ctx context.Context, msg message.MutableMessage, appendOp interceptors.Append) (message.MessageID, error) {
 	dropCollectionMessage, err := message.AsMutableDropCollectionMessageV1(msg)
@@ -108,6 +210,11 @@ func (impl *segmentInterceptor) handleCreatePartition(ctx context.Context, msg m
 	if err != nil {
 		return nil, err
 	}
+	h := createPartitionMessage.Header()
+	// the partition beyond the capacity of the pchannel is rejected before the message is persisted.
+	if err := impl.assignManager.Get().CheckCapacity(h.GetCollectionId(), 1); err != nil {
+		return nil, convertPChannelFullError(err)
+	}
 	// send the create collection message.
 	msgID, err := appendOp(ctx, msg)
 	if err != nil {
@@ -115,12 +222,47 @@ func (impl *segmentInterceptor) handleCreatePartition(ctx context.Context, msg m
 	}
 
 	// Set up the partition manager for the collection, new incoming insert message can be assign segment.
-	h := createPartitionMessage.Header()
-	// error can never happens for wal lifetime control.
-	_ = impl.assignManager.Get().NewPartition(h.GetCollectionId(), h.GetPartitionId())
+	if err := impl.assignManager.Get().NewPartition(h.GetCollectionId(), h.GetPartitionId()); err != nil {
+		// the capacity is taken by a concurrent creation after the check.
+		return msgID, convertPChannelFullError(err)
+	}
 	return msgID, nil
 }
 
+// handleCreatePartitions handles the batched create partitions message.
+func (impl *segmentInterceptor) handleCreatePartitions(ctx context.Context, msg message.MutableMessage, appendOp interceptors.Append) (message.MessageID, error) {
+	createPartitionsMessage, err := message.AsMutableCreatePartitionsMessageV1(msg)
+	if err != nil {
+		return nil, err
+	}
+	h := createPartitionsMessage.Header()
+	// the batch beyond the capacity of the pchannel is rejected as a whole before the message is persisted.
+	if err := impl.assignManager.Get().CheckCapacity(h.GetCollectionId(), len(h.GetPartitionIds())); err != nil {
+		return nil, convertPChannelFullError(err)
+	}
+	// send the create partitions message in a single append.
+	msgID, err := appendOp(ctx, msg)
+	if err != nil {
+		return msgID, err
+	}
+
+	// Set up all partition managers of the batch at once, new incoming insert message can be assign segment.
+	if err := impl.assignManager.Get().NewPartitions(h.GetCollectionId(), h.GetPartitionIds()); err != nil {
+		// the capacity is taken by a concurrent creation after the check.
+		return msgID, convertPChannelFullError(err)
+	}
+	return msgID, nil
+}
+
+// convertPChannelFullError converts the capacity rejection of the pchannel into the status,
+// so the coordinator places the collection on another pchannel but not retry it on the full one.
+func convertPChannelFullError(err error) error {
+	if errors.Is(err, manager.ErrPChannelFull) {
+		return status.NewPChannelFull("%s, place the collection on another pchannel", err.Error())
+	}
+	return err
+}
+
 // handleDropPartition handles the drop partition message.
 func (impl *segmentInterceptor) handleDropPartition(ctx context.Context, msg message.MutableMessage, appendOp interceptors.Append) (message.MessageID, error) {
 	dropPartitionMessage, err := message.AsMutableDropPartitionMessageV1(msg)
@@ -130,7 +272,12 @@ func (impl *segmentInterceptor) handleDropPartition(ctx context.Context, msg mes
 
 	// drop partition, remove the partition manager from assignment service.
 	h := dropPartitionMessage.Header()
-	if err := impl.assignManager.Get().RemovePartition(ctx, h.GetCollectionId(), h.GetPartitionId()); err != nil {
+	if message.IsDiscardGrowingSegments(msg) {
+		// the growing segments of dropped partition are discarded without flush.
+		if err := impl.assignManager.Get().RemovePartitionAndDiscardSegments(ctx, h.GetCollectionId(), h.GetPartitionId()); err != nil {
+			return nil, err
+		}
+	} else if err := impl.assignManager.Get().RemovePartition(ctx, h.GetCollectionId(), h.GetPartitionId()); err != nil {
 		return nil, err
 	}
 
@@ -138,6 +285,34 @@ func (impl *segmentInterceptor) handleDropPartition(ctx context.Context, msg mes
 	return appendOp(ctx, msg)
 }
 
+// handleSchemaChange handles the schema change message.
+func (impl *segmentInterceptor) handleSchemaChange(ctx context.Context, msg message.MutableMessage, appendOp interceptors.Append) (message.MessageID, error) {
+	schemaChangeMsg, err := message.AsMutableSchemaChangeMessageV2(msg)
+	if err != nil {
+		return nil, err
+	}
+	body, err := schemaChangeMsg.Body()
+	if err != nil {
+		return nil, err
+	}
+	// send the schema change message.
+	msgID, err := appendOp(ctx, msg)
+	if err != nil {
+		return msgID, err
+	}
+
+	// the modify ts of the schema change is the new schema version of the collection,
+	// the new incoming insert should be built with the new schema.
+	h := schemaChangeMsg.Header()
+	if err := impl.assignManager.Get().ChangeSchemaVersion(ctx, h.GetCollectionId(), body.GetModifyTs()); err != nil {
+		impl.logger.Warn("failed to change the schema version of segment assignment",
+			zap.Int64("collectionID", h.GetCollectionId()),
+			zap.Uint64("schemaVersion", body.GetModifyTs()),
+			zap.Error(err))
+	}
+	return msgID, nil
+}
+
 // handleInsertMessage handles the insert message.
 func (impl *segmentInterceptor) handleInsertMessage(ctx context.Context, msg message.MutableMessage, appendOp interceptors.Append) (message.MessageID, error) {
 	insertMsg, err := message.AsMutableInsertMessageV1(msg)
@@ -147,42 +322,328 @@ func (impl *segmentInterceptor) handleInsertMessage(ctx context.Context, msg mes
 	// Assign segment for insert message.
 	// !!! Current implementation a insert message only has one parition, but we need to merge the message for partition-key in future.
 	header := insertMsg.Header()
-	for _, partition := range header.GetPartitions() {
+	if producer, ok := message.GetPreassignedProducer(msg); ok {
+		// The segments are pre-assigned by the internal producer, the assignments should never be cleared or re-assigned.
+		return impl.handlePreassignedInsertMessage(ctx, msg, insertMsg, producer, appendOp)
+	}
+	if clearSegmentAssignments(header) {
+		// The message is retried (e.g. redo), the assignments of the previous attempt should never be leaked into this attempt.
+		insertMsg.OverwriteHeader(header)
+	}
+	if err := impl.mergeDuplicatePartitions(insertMsg, header); err != nil {
+		return nil, err
+	}
+	if isZeroRowsInsert(header) {
+		// Nothing to insert, skip the segment assignment to avoid polluting the stats of segment.
+		impl.zeroRowsInsertTotal.Inc()
+		return appendOp(ctx, msg)
+	}
+	if err := checkTxnWriteLimit(ctx, msg, header); err != nil {
+		return nil, err
+	}
+	results, assignments, err := impl.assignSegments(ctx, msg, header)
+	// once the segment assignment is done, we need to ack the result,
+	// if other partitions failed to assign segment or wal write failure,
+	// the segment assignment will not rolled back for simple implementation.
+	defer func() {
+		for _, result := range results {
+			result.Ack()
+		}
+	}()
+	if err != nil {
+		return nil, err
+	}
+	// Attach segment assignments to message and update the insert message headers.
+	for i, partition := range header.GetPartitions() {
+		partition.SegmentAssignment = assignments[i]
+	}
+	insertMsg.OverwriteHeader(header)
+
+	msgID, err := appendOp(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	// Only the durable insert can be the origin of the segment, so it's observed after the append.
+	for _, result := range results {
+		result.ObserveAppended(msgID)
+	}
+	return msgID, nil
+}
+
+// assignSegments assigns the segment for every partition of the insert message.
+// The results assigned before a failure are returned with the error, they should be acked by the caller.
+// The reservations made before a panic are rolled back, the panic is re-raised to be converted by DoAppend.
+func (impl *segmentInterceptor) assignSegments(ctx context.Context, msg message.MutableMessage, header *message.InsertMessageHeader) (results []*manager.AssignSegmentResult, assignments []*message.SegmentAssignment, err error) {
+	results = make([]*manager.AssignSegmentResult, 0, len(header.GetPartitions()))
+	defer func() {
+		if r := recover(); r != nil {
+			for _, result := range results {
+				result.Rollback()
+			}
+			panic(r)
+		}
+	}()
+	// The assignments are only written into the header after all partitions are assigned,
+	// so the header never carries the partial assignments of a failed attempt.
+	assignments = make([]*message.SegmentAssignment, len(header.GetPartitions()))
+	for i, partition := range header.GetPartitions() {
+		if partition.GetRows() == 0 {
+			// zero rows partition doesn't need a segment, keep the segment assignment unset.
+			continue
+		}
 		result, err := impl.assignManager.Get().AssignSegment(ctx, &manager.AssignSegmentRequest{
 			CollectionID: header.GetCollectionId(),
 			PartitionID:  partition.GetPartitionId(),
+			VChannel:     msg.VChannel(),
 			InsertMetrics: stats.InsertMetrics{
 				Rows:       partition.GetRows(),
 				BinarySize: uint64(msg.EstimateSize()), // TODO: Use parition.BinarySize in future when merge partitions together in one message.
 			},
-			TimeTick:   msg.TimeTick(),
-			TxnSession: txn.GetTxnSessionFromContext(ctx),
+			TimeTick:      msg.TimeTick(),
+			SchemaVersion: header.GetSchemaVersion(),
+			HighPriority:  header.GetHighPriority(),
+			Source:        message.GetSourceNode(msg),
+			PKHashes:      partition.GetPkHashes(),
+			ShardHint:     header.GetShardHint(),
+			TxnSession:    txn.GetTxnSessionFromContext(ctx),
 		})
 		if errors.Is(err, manager.ErrTimeTickTooOld) {
 			// If current time tick of insert message is too old to alloc segment,
 			// we just redo it to refresh a new latest timetick.
-			return nil, redo.ErrRedo
+			utility.MarkAppendRedo(ctx)
+			return results, nil, redo.ErrRedo
 		}
-		if errors.Is(err, manager.ErrTooLargeInsert) {
+		var tooLarge *manager.TooLargeInsertError
+		if errors.As(err, &tooLarge) {
 			// Message is too large, so retry operation is unrecoverable, can't be retry at client side.
-			return nil, status.NewUnrecoverableError("insert too large, binary size: %d", msg.EstimateSize())
+			// Return the max acceptable size to client for re-batching.
+			return results, nil, status.NewTooLargeInsertError(uint64(msg.EstimateSize()), tooLarge.MaxBinarySize, tooLarge.RemainingBinarySize)
+		}
+		if errors.Is(err, manager.ErrSchemaVersionMismatch) {
+			// The insert is built with a stale schema, retry it with the same schema can never succeed,
+			// the proxy should refresh its schema cache and rebuild the insert.
+			return results, nil, status.NewUnrecoverableError("%s, refresh the schema cache and retry", err.Error())
+		}
+		if errors.Is(err, manager.ErrVChannelMismatch) {
+			// The insert is routed to the wrong vchannel, retry it with the same routing can never succeed,
+			// the proxy should invalidate its routing cache and rebuild the insert.
+			return results, nil, status.NewUnrecoverableError("%s, invalidate the routing cache and retry", err.Error())
+		}
+		if errors.Is(err, manager.ErrPartitionDropping) {
+			// The partition is dropped concurrently or prepared to drop, the insert can never be assigned as same as the partition not found.
+			return results, nil, status.NewUnrecoverableError("%s in segment assignment service", err.Error())
+		}
+		if errors.Is(err, manager.ErrCatalogTimeout) {
+			// The catalog is slow, the insert can be retried later without any side effect.
+			return results, nil, status.NewRetryLater("segment assignment of %s message is timeout, %s", msg.MessageType(), err.Error())
+		}
+		if err != nil {
+			return results, nil, err
+		}
+		results = append(results, result)
+
+		assignments[i] = &message.SegmentAssignment{
+			SegmentId:     result.SegmentID,
+			SchemaVersion: result.SchemaVersion,
+			Checksum:      result.Checksum,
+		}
+	}
+	return results, assignments, nil
+}
+
+// handlePreassignedInsertMessage handles the insert message whose segments are pre-assigned by the internal producer,
+// e.g. the data repair tool or the migration replayer.
+// The pre-assigned segments are validated and counted, the message is appended without segment assignment,
+// but the pre-assigned assignments are signed as same as the assigned ones, so the consumer can verify them.
+func (impl *segmentInterceptor) handlePreassignedInsertMessage(ctx context.Context, msg message.MutableMessage, insertMsg message.MutableInsertMessageV1, producer string, appendOp interceptors.Append) (msgID message.MessageID, err error) {
+	header := insertMsg.Header()
+	if !policy.IsPreassignedProducerAllowed(producer) {
+		// The bypass is only honored for the allowed internal producers, the normal client paths can never bypass the assignment.
+		return nil, status.NewUnrecoverableError("producer %q is not allowed to pre-assign the segments of insert message", producer)
+	}
+	results := make([]*manager.AssignSegmentResult, 0, len(header.GetPartitions()))
+	defer func() {
+		if r := recover(); r != nil {
+			for _, result := range results {
+				result.Rollback()
+			}
+			panic(r)
+		}
+		for _, result := range results {
+			result.Ack()
+		}
+	}()
+	for _, partition := range header.GetPartitions() {
+		if partition.GetRows() == 0 {
+			continue
+		}
+		segmentID := partition.GetSegmentAssignment().GetSegmentId()
+		if segmentID == 0 {
+			return nil, status.NewInvaildArgument("partition %d of the pre-assigned insert message has no segment assignment", partition.GetPartitionId())
+		}
+		result, err := impl.assignManager.Get().ApplyPreassignedSegment(ctx, &manager.AssignSegmentRequest{
+			CollectionID: header.GetCollectionId(),
+			PartitionID:  partition.GetPartitionId(),
+			VChannel:     msg.VChannel(),
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       partition.GetRows(),
+				BinarySize: uint64(msg.EstimateSize()),
+			},
+			TimeTick:   msg.TimeTick(),
+			Source:     message.GetSourceNode(msg),
+			TxnSession: txn.GetTxnSessionFromContext(ctx),
+		}, segmentID)
+		if errors.Is(err, manager.ErrTimeTickTooOld) {
+			// The time tick is older than the creation of the pre-assigned segment, redo it to refresh a new latest timetick.
+			utility.MarkAppendRedo(ctx)
+			return nil, redo.ErrRedo
+		}
+		if errors.IsAny(err, manager.ErrPreassignedSegmentInvalid, manager.ErrVChannelMismatch) {
+			return nil, status.NewInvaildArgument("invalid pre-assigned insert message of producer %q, %s", producer, err.Error())
 		}
 		if err != nil {
 			return nil, err
 		}
-		// once the segment assignment is done, we need to ack the result,
-		// if other partitions failed to assign segment or wal write failure,
-		// the segment assignment will not rolled back for simple implementation.
-		defer result.Ack()
+		results = append(results, result)
+		partition.SegmentAssignment.Checksum = result.Checksum
+	}
+	insertMsg.OverwriteHeader(header)
+
+	msgID, err = appendOp(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		result.ObserveAppended(msgID)
+	}
+	return msgID, nil
+}
 
-		// Attach segment assignment to message.
-		partition.SegmentAssignment = &message.SegmentAssignment{
-			SegmentId: result.SegmentID,
+// clearSegmentAssignments clears the segment assignments of all partitions of the insert message header.
+// Return true if there's any assignment cleared.
+func clearSegmentAssignments(header *message.InsertMessageHeader) bool {
+	cleared := false
+	for _, partition := range header.GetPartitions() {
+		if partition.SegmentAssignment != nil {
+			partition.SegmentAssignment = nil
+			cleared = true
 		}
 	}
-	// Update the insert message headers.
+	return cleared
+}
+
+// mergeDuplicatePartitions merges the entries of the same partition in the insert message header,
+// so one physical batch is assigned once per partition but not double-counted by a buggy producer.
+// The message is rejected if the entries of the same partition carry different binary sizes, the merge is ambiguous.
+func (impl *segmentInterceptor) mergeDuplicatePartitions(insertMsg message.MutableInsertMessageV1, header *message.InsertMessageHeader) error {
+	if len(header.GetPartitions()) < 2 {
+		return nil
+	}
+	merged := make([]*message.PartitionSegmentAssignment, 0, len(header.GetPartitions()))
+	indexes := make(map[int64]int, len(header.GetPartitions()))
+	duplicated := make([]int64, 0)
+	for _, partition := range header.GetPartitions() {
+		idx, ok := indexes[partition.GetPartitionId()]
+		if !ok {
+			indexes[partition.GetPartitionId()] = len(merged)
+			merged = append(merged, partition)
+			continue
+		}
+		duplicated = append(duplicated, partition.GetPartitionId())
+		if merged[idx].GetBinarySize() != partition.GetBinarySize() {
+			impl.duplicatePartitionTotal.WithLabelValues("rejected").Inc()
+			impl.logger.Warn("insert message lists the same partition with different binary sizes",
+				zap.Int64("collectionID", header.GetCollectionId()),
+				zap.Int64("partitionID", partition.GetPartitionId()),
+				zap.Uint64("binarySize", merged[idx].GetBinarySize()),
+				zap.Uint64("duplicatedBinarySize", partition.GetBinarySize()),
+				zap.String("source", message.GetSourceNode(insertMsg)))
+			return status.NewUnrecoverableError("insert message of collection %d lists partition %d more than once with different binary sizes %d and %d",
+				header.GetCollectionId(), partition.GetPartitionId(), merged[idx].GetBinarySize(), partition.GetBinarySize())
+		}
+		merged[idx] = &message.PartitionSegmentAssignment{
+			PartitionId: merged[idx].GetPartitionId(),
+			Rows:        merged[idx].GetRows() + partition.GetRows(),
+			BinarySize:  merged[idx].GetBinarySize(),
+			PkHashes:    append(append([]uint64(nil), merged[idx].GetPkHashes()...), partition.GetPkHashes()...),
+		}
+	}
+	if len(duplicated) == 0 {
+		return nil
+	}
+	impl.duplicatePartitionTotal.WithLabelValues("merged").Inc()
+	impl.logger.Warn("insert message lists the same partition more than once, the entries are merged",
+		zap.Int64("collectionID", header.GetCollectionId()),
+		zap.Int64s("duplicatedPartitionIDs", duplicated),
+		zap.String("source", message.GetSourceNode(insertMsg)))
+	header.Partitions = merged
 	insertMsg.OverwriteHeader(header)
+	return nil
+}
 
+// isZeroRowsInsert checks if every partition of the insert message carries zero rows.
+func isZeroRowsInsert(header *message.InsertMessageHeader) bool {
+	for _, partition := range header.GetPartitions() {
+		if partition.GetRows() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// handleDeleteMessage handles the delete message.
+// The deletes of a partition are assigned on the L0 segment track of the partition,
+// so the L0 segment is sealed by its own policies and flushed with the manual flush as same as the insert segments.
+func (impl *segmentInterceptor) handleDeleteMessage(ctx context.Context, msg message.MutableMessage, appendOp interceptors.Append) (message.MessageID, error) {
+	deleteMsg, err := message.AsMutableDeleteMessageV1(msg)
+	if err != nil {
+		return nil, err
+	}
+	body, err := deleteMsg.Body()
+	if err != nil {
+		return nil, err
+	}
+	if body.GetNumRows() == 0 || body.GetPartitionID() == common.AllPartitionsID {
+		// Nothing to delete, or the delete applies to all partitions which has no partition segment manager,
+		// it's appended without the L0 segment assignment.
+		return appendOp(ctx, msg)
+	}
+	result, err := impl.assignManager.Get().AssignDeleteSegment(ctx, &manager.AssignDeleteSegmentRequest{
+		CollectionID: deleteMsg.Header().GetCollectionId(),
+		PartitionID:  body.GetPartitionID(),
+		VChannel:     msg.VChannel(),
+		DeleteMetrics: stats.DeleteMetrics{
+			Rows:       uint64(body.GetNumRows()),
+			BinarySize: uint64(msg.EstimateSize()),
+		},
+		TimeTick: msg.TimeTick(),
+	})
+	if errors.IsAny(err, manager.ErrVChannelMismatch, manager.ErrPartitionDropping) {
+		// The delete can never be assigned with the same routing or on the dropping partition, as same as the insert.
+		return nil, status.NewUnrecoverableError("%s in segment assignment service", err.Error())
+	}
+	if errors.Is(err, manager.ErrCatalogTimeout) {
+		return nil, status.NewRetryLater("segment assignment of %s message is timeout, %s", msg.MessageType(), err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	// the L0 segment can't be sealed until the assigned delete is acked,
+	// the assignment is not rolled back if the wal write failure as same as the insert.
+	defer result.Ack()
+	return appendOp(ctx, msg)
+}
+
+// handleFlushMessage handles the flush message sent by the seal pipeline.
+func (impl *segmentInterceptor) handleFlushMessage(ctx context.Context, msg message.MutableMessage, appendOp interceptors.Append) (message.MessageID, error) {
+	// The barrier of flush message is the max timetick assigned to the flushed segment,
+	// the flush message must be ordered after every insert of the segment.
+	// If the allocated timetick is not greater than it, redo to refresh a new latest timetick.
+	if barrier := msg.BarrierTimeTick(); barrier != 0 && msg.TimeTick() <= barrier {
+		impl.flushTimeTickRefreshTotal.Inc()
+		utility.MarkAppendRedo(ctx)
+		return nil, redo.ErrRedo
+	}
 	return appendOp(ctx, msg)
 }
 
@@ -193,16 +654,74 @@ func (impl *segmentInterceptor) handleManualFlushMessage(ctx context.Context, ms
 		return nil, err
 	}
 	header := maunalFlushMsg.Header()
-	segmentIDs, err := impl.assignManager.Get().SealAndFenceSegmentUntil(ctx, header.GetCollectionId(), header.GetFlushTs())
+	switch message.GetCollectionLifecycleSignal(msg) {
+	case message.CollectionLifecycleSignalLoad:
+		// The load signal never seals any segment, only prewarm the segment assignment if enabled.
+		if paramtable.Get().StreamingCfg.WALSegmentAssignPrewarmOnCollectionLoad.GetAsBool() {
+			if err := impl.assignManager.Get().PrewarmCollection(ctx, header.GetCollectionId()); err != nil {
+				// prewarm is just an optimization, the failure can be ignored.
+				impl.logger.Warn("failed to prewarm segment assignment of collection", zap.Int64("collectionID", header.GetCollectionId()), zap.Error(err))
+			}
+		}
+		return appendOp(ctx, msg)
+	case message.CollectionLifecycleSignalRelease:
+		// Seal all growing segments of the collection before the release message is appended if enabled,
+		// the seal operation is the same as the manual flush, so the release can be done after all data is flushed.
+		if !paramtable.Get().StreamingCfg.WALSegmentAssignSealOnCollectionRelease.GetAsBool() {
+			return appendOp(ctx, msg)
+		}
+	}
+	// the nonsense flush ts is rejected before any segment is sealed or fenced by it.
+	flushTs, err := impl.assignManager.Get().ValidateManualFlushTs(header.GetCollectionId(), header.GetFlushTs(), msg.TimeTick())
 	if err != nil {
+		if errors.Is(err, manager.ErrInvalidFlushTs) {
+			return nil, status.NewUnrecoverableError("%s, the manual flush is rejected", err.Error())
+		}
+		return nil, err
+	}
+	if flushTs != header.GetFlushTs() {
+		// the clamped flush ts is kept by the redo rounds and the appended message.
+		header.FlushTs = flushTs
+		maunalFlushMsg.OverwriteHeader(header)
+	}
+
+	var segmentIDs []int64
+	var openTxns []*message.OpenTxn
+	fenced := !message.IsFlushOlderThanOnly(msg)
+	if !fenced {
+		// Only seal the segments whose data are all older than the flush ts, no fence is applied.
+		segmentIDs, err = impl.assignManager.Get().SealSegmentsOlderThan(ctx, header.GetCollectionId(), header.GetFlushTs())
+	} else {
+		if appliedSegmentIDs, ok := impl.assignManager.Get().GetAppliedManualFlush(header.GetCollectionId(), header.GetFlushTs()); ok {
+			// The manual flush has been applied (e.g. replayed after failover),
+			// sealing again may seal the fresh growing segments incorrectly, so only the message is appended.
+			impl.logger.Info("manual flush has been applied, skip the seal operation",
+				zap.Int64("collectionID", header.GetCollectionId()),
+				zap.Uint64("flushTs", header.GetFlushTs()),
+				zap.Int64s("appliedSegmentIDs", appliedSegmentIDs))
+			if appliedSegmentIDs != nil {
+				segments := impl.assignManager.Get().GetSealedSegmentStats(appliedSegmentIDs)
+				utility.ModifyAppendResultExtra(ctx, func(old *message.ManualFlushExtraResponse) *message.ManualFlushExtraResponse {
+					return &messagespb.ManualFlushExtraResponse{SegmentIds: appliedSegmentIDs, Segments: segments}
+				})
+			}
+			return appendOp(ctx, msg)
+		}
+		segmentIDs, err = impl.assignManager.Get().SealAndFenceSegmentUntil(ctx, header.GetCollectionId(), header.GetFlushTs())
+		// the txns still open at the fence, their data before the flush ts is only visible after they're committed.
+		openTxns = impl.assignManager.Get().OpenTxnsOfCollection(header.GetCollectionId())
+	}
+	if err != nil {
+		if errors.Is(err, manager.ErrFlushQueueFull) {
+			// Too many flush of the collection are queued, the flush can be retried later.
+			return nil, status.NewRetryLater("manual flush of collection %d is throttled, %s", header.GetCollectionId(), err.Error())
+		}
 		return nil, status.NewInner("segment seal failure with error: %s", err.Error())
 	}
 	// Modify the extra response for manual flush message.
+	segments := impl.assignManager.Get().GetSealedSegmentStats(segmentIDs)
 	utility.ModifyAppendResultExtra(ctx, func(old *message.ManualFlushExtraResponse) *message.ManualFlushExtraResponse {
-		if old == nil {
-			return &messagespb.ManualFlushExtraResponse{SegmentIds: segmentIDs}
-		}
-		return &messagespb.ManualFlushExtraResponse{SegmentIds: append(old.GetSegmentIds(), segmentIDs...)}
+		return mergeManualFlushExtraResponse(old, segmentIDs, segments, openTxns)
 	})
 	if len(segmentIDs) > 0 {
 		// There's some new segment sealed, we need to retry the manual flush operation refresh the context.
@@ -210,6 +729,7 @@ func (impl *segmentInterceptor) handleManualFlushMessage(ctx context.Context, ms
 		// FlushTsHere -> ManualFlush -> FlushSegment1 -> FlushSegment2 -> FlushSegment3.
 		// After refresh the context, keep the sequence of the message in the wal with following seq:
 		// FlushTsHere -> FlushSegment1 -> FlushSegment2 -> FlushSegment3 -> ManualFlush.
+		utility.MarkAppendRedo(ctx)
 		return nil, redo.ErrRedo
 	}
 
@@ -218,7 +738,121 @@ func (impl *segmentInterceptor) handleManualFlushMessage(ctx context.Context, ms
 	if err != nil {
 		return nil, err
 	}
+	if fenced {
+		// record the applied manual flush with all sealed segments of the redo rounds.
+		sealedSegmentIDs := make([]int64, 0)
+		utility.ModifyAppendResultExtra(ctx, func(old *message.ManualFlushExtraResponse) *message.ManualFlushExtraResponse {
+			sealedSegmentIDs = append(sealedSegmentIDs, old.GetSegmentIds()...)
+			return old
+		})
+		impl.assignManager.Get().ObserveManualFlush(header.GetCollectionId(), header.GetFlushTs(), sealedSegmentIDs)
+	}
+	return msgID, nil
+}
 
+// handlePreparePartitionDropMessage handles the prepare partition drop message.
+// The growing segments of the partition are sealed and flushed before the message is appended,
+// and the newer inserts of the partition are rejected, so the following drop partition message is cheap.
+func (impl *segmentInterceptor) handlePreparePartitionDropMessage(ctx context.Context, msg message.MutableMessage, appendOp interceptors.Append) (message.MessageID, error) {
+	prepareMsg, err := message.AsMutablePreparePartitionDropMessageV2(msg)
+	if err != nil {
+		return nil, err
+	}
+	header := prepareMsg.Header()
+	segmentIDs, err := impl.assignManager.Get().PreparePartitionDrop(ctx, header.GetCollectionId(), header.GetPartitionId(), msg.TimeTick())
+	if err != nil {
+		if status.AsStreamingError(err).IsUnrecoverable() {
+			return nil, err
+		}
+		return nil, status.NewInner("prepare partition drop failure with error: %s", err.Error())
+	}
+	utility.ModifyAppendResultExtra(ctx, func(old *message.PreparePartitionDropExtraResponse) *message.PreparePartitionDropExtraResponse {
+		return &messagespb.PreparePartitionDropExtraResponse{SegmentIds: append(old.GetSegmentIds(), segmentIDs...)}
+	})
+	if len(segmentIDs) > 0 {
+		// Redo to refresh the time tick as same as the manual flush,
+		// so the prepare message is ordered after the flush messages of the sealed segments in wal.
+		// The partition is fenced, so the redo round never seals any new segment.
+		utility.MarkAppendRedo(ctx)
+		return nil, redo.ErrRedo
+	}
+	return appendOp(ctx, msg)
+}
+
+// mergeManualFlushExtraResponse merges the sealed segments of a redo round into the extra response of manual flush.
+// The segment sealed by multiple rounds is only kept once, and the legacy segment ids are kept populated for old clients.
+// The open txns are always refreshed by the latest fence of the redo rounds.
+func mergeManualFlushExtraResponse(
+	old *message.ManualFlushExtraResponse,
+	segmentIDs []int64,
+	segments []*message.ManualFlushSegmentStat,
+	openTxns []*message.OpenTxn,
+) *message.ManualFlushExtraResponse {
+	merged := &messagespb.ManualFlushExtraResponse{
+		SegmentIds: make([]int64, 0, len(old.GetSegmentIds())+len(segmentIDs)),
+		Segments:   make([]*message.ManualFlushSegmentStat, 0, len(old.GetSegments())+len(segments)),
+		OpenTxns:   openTxns,
+	}
+	seenIDs := typeutil.NewSet[int64]()
+	for _, ids := range [][]int64{old.GetSegmentIds(), segmentIDs} {
+		for _, segmentID := range ids {
+			if !seenIDs.Contain(segmentID) {
+				seenIDs.Insert(segmentID)
+				merged.SegmentIds = append(merged.SegmentIds, segmentID)
+			}
+		}
+	}
+	seenStats := typeutil.NewSet[int64]()
+	for _, batch := range [][]*message.ManualFlushSegmentStat{old.GetSegments(), segments} {
+		for _, stat := range batch {
+			if !seenStats.Contain(stat.GetSegmentId()) {
+				seenStats.Insert(stat.GetSegmentId())
+				merged.Segments = append(merged.Segments, stat)
+			}
+		}
+	}
+	return merged
+}
+
+// checkTxnWriteLimit checks the insert against the write limit of the txn session begun under the pressure of the pchannel.
+// The insert of the txn bypasses the throttles of the plain insert once its segments are pinned by the session,
+// so the limit is checked before any segment is assigned.
+func checkTxnWriteLimit(ctx context.Context, msg message.MutableMessage, header *message.InsertMessageHeader) error {
+	session := txn.GetTxnSessionFromContext(ctx)
+	if session == nil {
+		return nil
+	}
+	// every assigned partition records the estimated size of the message into the session.
+	binarySize := uint64(0)
+	for _, partition := range header.GetPartitions() {
+		if partition.GetRows() > 0 {
+			binarySize += uint64(msg.EstimateSize())
+		}
+	}
+	return session.CheckWriteLimit(binarySize)
+}
+
+// handleTxnDoneMessage handles the commit or rollback txn message.
+// The write summary of the txn session is attached to the append result after the message is appended.
+func (impl *segmentInterceptor) handleTxnDoneMessage(ctx context.Context, msg message.MutableMessage, appendOp interceptors.Append) (message.MessageID, error) {
+	msgID, err := appendOp(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	session := txn.GetTxnSessionFromContext(ctx)
+	if session == nil {
+		return msgID, nil
+	}
+	rows, binarySize, segments := session.WriteSummary()
+	if msg.MessageType() == message.MessageTypeCommitTxn {
+		utility.ModifyAppendResultExtra(ctx, func(old *message.TxnCommitExtraResponse) *message.TxnCommitExtraResponse {
+			return &messagespb.TxnCommitExtraResponse{Rows: rows, BinarySize: binarySize, Segments: segments}
+		})
+		return msgID, nil
+	}
+	utility.ModifyAppendResultExtra(ctx, func(old *message.TxnRollbackExtraResponse) *message.TxnRollbackExtraResponse {
+		return &messagespb.TxnRollbackExtraResponse{Rows: rows, BinarySize: binarySize, Segments: segments}
+	})
 	return msgID, nil
 }
 
@@ -231,22 +865,29 @@ func (impl *segmentInterceptor) Close() {
 		inspector.GetSegmentSealedInspector().UnregisterPChannelManager(assignManager)
 		assignManager.Close(context.Background())
 	}
+	metrics.WALSegmentAssignZeroRowsInsertTotal.DeleteLabelValues(paramtable.GetStringNodeID(), impl.channel.Name)
+	metrics.WALSegmentAssignHandlerPanicTotal.DeletePartialMatch(prometheus.Labels{
+		metrics.NodeIDLabelName:     paramtable.GetStringNodeID(),
+		metrics.WALChannelLabelName: impl.channel.Name,
+	})
+	metrics.WALSegmentAssignDuplicatePartitionInsertTotal.DeletePartialMatch(prometheus.Labels{
+		metrics.NodeIDLabelName:     paramtable.GetStringNodeID(),
+		metrics.WALChannelLabelName: impl.channel.Name,
+	})
 }
 
 // recoverPChannelManager recovers PChannel Assignment Manager.
 func (impl *segmentInterceptor) recoverPChannelManager(param *interceptors.InterceptorBuildParam) {
-	timer := typeutil.NewBackoffTimer(typeutil.BackoffTimerConfig{
-		Default: time.Second,
-		Backoff: typeutil.BackoffConfig{
-			InitialInterval: 10 * time.Millisecond,
-			Multiplier:      2.0,
-			MaxInterval:     time.Second,
-		},
-	})
-	timer.EnableBackoff()
+	timer := newRecoveryBackoffTimer()
 	for counter := 0; ; counter++ {
+		// the ctx is canceled by Close, so the in-flight catalog and coordinator calls of recovery are canceled too.
 		pm, err := manager.RecoverPChannelSegmentAllocManager(impl.ctx, param.ChannelInfo, param.WAL)
 		if err != nil {
+			if impl.ctx.Err() != nil {
+				impl.logger.Info("segment interceptor has been closed", zap.Error(impl.ctx.Err()))
+				impl.assignManager.Set(nil)
+				return
+			}
 			ch, d := timer.NextTimer()
 			impl.logger.Warn("recover PChannel Assignment Manager failed, wait a backoff", zap.Int("retry", counter), zap.Duration("nextRetryInterval", d), zap.Error(err))
 			select {
@@ -259,10 +900,40 @@ func (impl *segmentInterceptor) recoverPChannelManager(param *interceptors.Inter
 			}
 		}
 
-		// register the manager into inspector, to do the seal asynchronously
-		inspector.GetSegmentSealedInspector().RegisterPChannelManager(pm)
+		// register the manager into inspector, to do the seal asynchronously.
+		// the manager is registered exactly once for a successful recovery.
+		if param.TxnManager != nil {
+			// the open txns of the collection are annotated into the flush.
+			pm.BindOpenTxnLister(param.TxnManager)
+			// the segments pinned by the txn are sealed right away once the txn is done.
+			param.TxnManager.BindTxnDoneListener(pm)
+			// the commit of the txn is validated against the fences of the touched collections.
+			param.TxnManager.BindCollectionFenceView(pm)
+			// the new txn is throttled by the pressure of the append path.
+			param.TxnManager.BindPressureView(pm)
+		}
+		impl.registerOnce.Do(func() {
+			inspector.GetSegmentSealedInspector().RegisterPChannelManager(pm)
+		})
 		impl.assignManager.Set(pm)
 		impl.logger.Info("recover PChannel Assignment Manager success")
 		return
 	}
 }
+
+// newRecoveryBackoffTimer creates the retry backoff timer of the pchannel manager recovery.
+// The jitter is applied to every attempt, so the recovering pchannels don't hammer the catalog at the same time.
+func newRecoveryBackoffTimer() *typeutil.BackoffTimer {
+	cfg := &paramtable.Get().StreamingCfg
+	timer := typeutil.NewBackoffTimer(typeutil.BackoffTimerConfig{
+		Default: cfg.WALSegmentAssignRecoveryBackoffMax.GetAsDurationByParse(),
+		Backoff: typeutil.BackoffConfig{
+			InitialInterval:     cfg.WALSegmentAssignRecoveryBackoffInitial.GetAsDurationByParse(),
+			Multiplier:          cfg.WALSegmentAssignRecoveryBackoffFactor.GetAsFloat(),
+			MaxInterval:         cfg.WALSegmentAssignRecoveryBackoffMax.GetAsDurationByParse(),
+			RandomizationFactor: cfg.WALSegmentAssignRecoveryBackoffJitter.GetAsFloat(),
+		},
+	})
+	timer.EnableBackoff()
+	return timer
+}
@@ -0,0 +1,1139 @@
+package segment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/internal/mocks/mock_metastore"
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/redo"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/manager"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/utility"
+	internaltypes "github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/idalloc"
+	"github.com/milvus-io/milvus/internal/util/streamingutil/status"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v2/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+)
+
+func TestCloseDuringRecovery(t *testing.T) {
+	paramtable.Init()
+
+	entered := make(chan struct{})
+	catalog := mock_metastore.NewMockStreamingNodeCataLog(t)
+	// the first recovery fails, the second one is blocked on a long catalog call.
+	catalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).Return(nil, context.DeadlineExceeded).Once()
+	catalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, pchannel string) ([]*streamingpb.SegmentAssignmentMeta, error) {
+			close(entered)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}).Once()
+	resource.InitForTest(t, resource.OptStreamingNodeCatalog(catalog))
+
+	interceptor := NewInterceptorBuilder().Build(&interceptors.InterceptorBuildParam{
+		ChannelInfo: types.PChannelInfo{Name: "v_close_during_recovery"},
+		WAL:         syncutil.NewFuture[wal.WAL](),
+	})
+	select {
+	case <-entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the recovery should be retried after a backoff")
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		interceptor.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("close should cancel the in-flight recovery and return promptly")
+	}
+}
+
+func TestInsertRetryWithFreshAssignments(t *testing.T) {
+	paramtable.Init()
+
+	now := time.Now().Unix()
+	catalog := mock_metastore.NewMockStreamingNodeCataLog(t)
+	catalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).Return([]*streamingpb.SegmentAssignmentMeta{
+		{
+			CollectionId: 1,
+			PartitionId:  1,
+			SegmentId:    1000,
+			Vchannel:     "v1",
+			State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+			Stat: &streamingpb.SegmentAssignmentStat{
+				MaxBinarySize:         1024 * 1024,
+				CreateTimestamp:       now,
+				LastModifiedTimestamp: now,
+			},
+		},
+	}, nil)
+	catalog.EXPECT().ListVChannel(mock.Anything, mock.Anything).Return(nil, nil)
+	catalog.EXPECT().SaveSegmentAssignments(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	mixCoord := idalloc.NewMockRootCoordClient(t)
+	// the partition 2 has no growing segment, the first allocation of it fails.
+	mixCoord.EXPECT().AllocSegment(mock.Anything, mock.Anything).Return(nil, errors.New("alloc segment failure")).Once()
+	mixCoord.EXPECT().AllocSegment(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, asr *datapb.AllocSegmentRequest, co ...grpc.CallOption) (*datapb.AllocSegmentResponse, error) {
+		return &datapb.AllocSegmentResponse{
+			SegmentInfo: &datapb.SegmentInfo{
+				ID:           asr.GetSegmentId(),
+				CollectionID: asr.GetCollectionId(),
+				PartitionID:  asr.GetPartitionId(),
+			},
+			Status: merr.Success(),
+		}, nil
+	})
+	mixCoord.EXPECT().GetPChannelInfo(mock.Anything, mock.Anything).Return(&rootcoordpb.GetPChannelInfoResponse{
+		Collections: []*rootcoordpb.CollectionInfoOnPChannel{
+			{
+				CollectionId: 1,
+				Vchannel:     "v1",
+				Partitions:   []*rootcoordpb.PartitionInfoOnPChannel{{PartitionId: 1}, {PartitionId: 2}},
+			},
+		},
+	}, nil)
+	fMixCoord := syncutil.NewFuture[internaltypes.MixCoordClient]()
+	fMixCoord.Set(mixCoord)
+	resource.InitForTest(t, resource.OptStreamingNodeCatalog(catalog), resource.OptMixCoordClient(fMixCoord))
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  tsoutil.GetCurrentTime(),
+	}, nil).Maybe()
+	fWAL := syncutil.NewFuture[wal.WAL]()
+	fWAL.Set(w)
+	ctx := context.Background()
+	pm, err := manager.RecoverPChannelSegmentAllocManager(ctx, types.PChannelInfo{Name: "v_insert_retry"}, fWAL)
+	assert.NoError(t, err)
+	defer pm.Close(ctx)
+	fManager := syncutil.NewFuture[*manager.PChannelSegmentAllocManager]()
+	fManager.Set(pm)
+	impl := &segmentInterceptor{
+		logger:              log.With(),
+		assignManager:       fManager,
+		zeroRowsInsertTotal: metrics.WALSegmentAssignZeroRowsInsertTotal.WithLabelValues(paramtable.GetStringNodeID(), "v_insert_retry"),
+	}
+
+	// the message carries the stale assignments of a previous attempt.
+	msg, err := message.NewInsertMessageBuilderV1().
+		WithVChannel("v1").
+		WithHeader(&message.InsertMessageHeader{
+			CollectionId: 1,
+			Partitions: []*message.PartitionSegmentAssignment{
+				{PartitionId: 1, Rows: 10, SegmentAssignment: &message.SegmentAssignment{SegmentId: 998}},
+				{PartitionId: 2, Rows: 10, SegmentAssignment: &message.SegmentAssignment{SegmentId: 999}},
+			},
+		}).
+		WithBody(&msgpb.InsertRequest{}).
+		BuildMutable()
+	assert.NoError(t, err)
+	msg.WithTimeTick(tsoutil.GetCurrentTime())
+	appended := 0
+	appendOp := func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+		appended++
+		return rmq.NewRmqID(1), nil
+	}
+
+	// the failed attempt never leaves any assignment in the header.
+	_, err = impl.handleInsertMessage(ctx, msg, appendOp)
+	assert.Error(t, err)
+	assert.Zero(t, appended)
+	for _, partition := range message.MustAsMutableInsertMessageV1(msg).Header().GetPartitions() {
+		assert.Nil(t, partition.GetSegmentAssignment())
+	}
+
+	// the retried attempt carries the fresh assignments.
+	_, err = impl.handleInsertMessage(ctx, msg, appendOp)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, appended)
+	partitions := message.MustAsMutableInsertMessageV1(msg).Header().GetPartitions()
+	assert.Equal(t, int64(1000), partitions[0].GetSegmentAssignment().GetSegmentId())
+	assert.NotZero(t, partitions[1].GetSegmentAssignment().GetSegmentId())
+	assert.NotEqual(t, int64(999), partitions[1].GetSegmentAssignment().GetSegmentId())
+}
+
+func TestInsertWithDuplicatePartitions(t *testing.T) {
+	paramtable.Init()
+
+	now := time.Now().Unix()
+	catalog := mock_metastore.NewMockStreamingNodeCataLog(t)
+	catalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).Return([]*streamingpb.SegmentAssignmentMeta{
+		{
+			CollectionId: 1,
+			PartitionId:  1,
+			SegmentId:    1000,
+			Vchannel:     "v1",
+			State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+			Stat: &streamingpb.SegmentAssignmentStat{
+				MaxBinarySize:         1024 * 1024,
+				CreateTimestamp:       now,
+				LastModifiedTimestamp: now,
+			},
+		},
+	}, nil)
+	catalog.EXPECT().ListVChannel(mock.Anything, mock.Anything).Return(nil, nil)
+	catalog.EXPECT().SaveSegmentAssignments(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	mixCoord := idalloc.NewMockRootCoordClient(t)
+	mixCoord.EXPECT().AllocSegment(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, asr *datapb.AllocSegmentRequest, co ...grpc.CallOption) (*datapb.AllocSegmentResponse, error) {
+		return &datapb.AllocSegmentResponse{
+			SegmentInfo: &datapb.SegmentInfo{
+				ID:           asr.GetSegmentId(),
+				CollectionID: asr.GetCollectionId(),
+				PartitionID:  asr.GetPartitionId(),
+			},
+			Status: merr.Success(),
+		}, nil
+	}).Maybe()
+	mixCoord.EXPECT().GetPChannelInfo(mock.Anything, mock.Anything).Return(&rootcoordpb.GetPChannelInfoResponse{
+		Collections: []*rootcoordpb.CollectionInfoOnPChannel{
+			{
+				CollectionId: 1,
+				Vchannel:     "v1",
+				Partitions:   []*rootcoordpb.PartitionInfoOnPChannel{{PartitionId: 1}, {PartitionId: 2}},
+			},
+		},
+	}, nil)
+	fMixCoord := syncutil.NewFuture[internaltypes.MixCoordClient]()
+	fMixCoord.Set(mixCoord)
+	resource.InitForTest(t, resource.OptStreamingNodeCatalog(catalog), resource.OptMixCoordClient(fMixCoord))
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  tsoutil.GetCurrentTime(),
+	}, nil).Maybe()
+	fWAL := syncutil.NewFuture[wal.WAL]()
+	fWAL.Set(w)
+	ctx := context.Background()
+	pm, err := manager.RecoverPChannelSegmentAllocManager(ctx, types.PChannelInfo{Name: "v_duplicate_partitions"}, fWAL)
+	assert.NoError(t, err)
+	defer pm.Close(ctx)
+	fManager := syncutil.NewFuture[*manager.PChannelSegmentAllocManager]()
+	fManager.Set(pm)
+	impl := &segmentInterceptor{
+		logger:              log.With(),
+		assignManager:       fManager,
+		zeroRowsInsertTotal: metrics.WALSegmentAssignZeroRowsInsertTotal.WithLabelValues(paramtable.GetStringNodeID(), "v_duplicate_partitions"),
+		duplicatePartitionTotal: metrics.WALSegmentAssignDuplicatePartitionInsertTotal.MustCurryWith(prometheus.Labels{
+			metrics.NodeIDLabelName:     paramtable.GetStringNodeID(),
+			metrics.WALChannelLabelName: "v_duplicate_partitions",
+		}),
+	}
+	newInsertMessage := func(partitions ...*message.PartitionSegmentAssignment) message.MutableMessage {
+		msg, err := message.NewInsertMessageBuilderV1().
+			WithVChannel("v1").
+			WithHeader(&message.InsertMessageHeader{
+				CollectionId: 1,
+				Partitions:   partitions,
+			}).
+			WithBody(&msgpb.InsertRequest{}).
+			BuildMutable()
+		assert.NoError(t, err)
+		msg.WithTimeTick(tsoutil.GetCurrentTime())
+		return msg
+	}
+	appended := 0
+	appendOp := func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+		appended++
+		return rmq.NewRmqID(1), nil
+	}
+
+	// the entries of the same partition are merged and assigned once.
+	msg := newInsertMessage(
+		&message.PartitionSegmentAssignment{PartitionId: 1, Rows: 10, BinarySize: 100},
+		&message.PartitionSegmentAssignment{PartitionId: 2, Rows: 5, BinarySize: 50},
+		&message.PartitionSegmentAssignment{PartitionId: 1, Rows: 20, BinarySize: 100},
+	)
+	_, err = impl.handleInsertMessage(ctx, msg, appendOp)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, appended)
+	partitions := message.MustAsMutableInsertMessageV1(msg).Header().GetPartitions()
+	assert.Len(t, partitions, 2)
+	assert.Equal(t, int64(1), partitions[0].GetPartitionId())
+	assert.Equal(t, uint64(30), partitions[0].GetRows())
+	assert.Equal(t, uint64(100), partitions[0].GetBinarySize())
+	assert.Equal(t, int64(1000), partitions[0].GetSegmentAssignment().GetSegmentId())
+	assert.Equal(t, int64(2), partitions[1].GetPartitionId())
+	assert.NotNil(t, partitions[1].GetSegmentAssignment())
+	assert.Equal(t, uint64(30), resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(1000).Insert.Rows)
+	assert.Equal(t, float64(1), testutil.ToFloat64(impl.duplicatePartitionTotal.WithLabelValues("merged")))
+
+	// the merge is ambiguous if the entries of the same partition carry different binary sizes.
+	msg = newInsertMessage(
+		&message.PartitionSegmentAssignment{PartitionId: 1, Rows: 10, BinarySize: 100},
+		&message.PartitionSegmentAssignment{PartitionId: 1, Rows: 10, BinarySize: 200},
+	)
+	_, err = impl.handleInsertMessage(ctx, msg, appendOp)
+	assert.Error(t, err)
+	assert.True(t, status.AsStreamingError(err).IsUnrecoverable())
+	assert.Contains(t, err.Error(), "more than once")
+	assert.Equal(t, 1, appended)
+	for _, partition := range message.MustAsMutableInsertMessageV1(msg).Header().GetPartitions() {
+		assert.Nil(t, partition.GetSegmentAssignment())
+	}
+	assert.Equal(t, uint64(30), resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(1000).Insert.Rows)
+	assert.Equal(t, float64(1), testutil.ToFloat64(impl.duplicatePartitionTotal.WithLabelValues("rejected")))
+}
+
+func TestZeroRowsInsert(t *testing.T) {
+	paramtable.Init()
+
+	now := time.Now().Unix()
+	catalog := mock_metastore.NewMockStreamingNodeCataLog(t)
+	catalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).Return([]*streamingpb.SegmentAssignmentMeta{
+		{
+			CollectionId: 1,
+			PartitionId:  1,
+			SegmentId:    1000,
+			Vchannel:     "v1",
+			State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+			Stat: &streamingpb.SegmentAssignmentStat{
+				MaxBinarySize:         1024 * 1024,
+				CreateTimestamp:       now,
+				LastModifiedTimestamp: now,
+			},
+		},
+	}, nil)
+	catalog.EXPECT().ListVChannel(mock.Anything, mock.Anything).Return(nil, nil)
+	catalog.EXPECT().SaveSegmentAssignments(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	// the zero rows partition never allocates a new segment.
+	mixCoord := idalloc.NewMockRootCoordClient(t)
+	mixCoord.EXPECT().GetPChannelInfo(mock.Anything, mock.Anything).Return(&rootcoordpb.GetPChannelInfoResponse{
+		Collections: []*rootcoordpb.CollectionInfoOnPChannel{
+			{
+				CollectionId: 1,
+				Vchannel:     "v1",
+				Partitions:   []*rootcoordpb.PartitionInfoOnPChannel{{PartitionId: 1}, {PartitionId: 2}},
+			},
+		},
+	}, nil)
+	fMixCoord := syncutil.NewFuture[internaltypes.MixCoordClient]()
+	fMixCoord.Set(mixCoord)
+	resource.InitForTest(t, resource.OptStreamingNodeCatalog(catalog), resource.OptMixCoordClient(fMixCoord))
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  tsoutil.GetCurrentTime(),
+	}, nil).Maybe()
+	fWAL := syncutil.NewFuture[wal.WAL]()
+	fWAL.Set(w)
+	ctx := context.Background()
+	pm, err := manager.RecoverPChannelSegmentAllocManager(ctx, types.PChannelInfo{Name: "v_zero_rows_insert"}, fWAL)
+	assert.NoError(t, err)
+	defer pm.Close(ctx)
+	fManager := syncutil.NewFuture[*manager.PChannelSegmentAllocManager]()
+	fManager.Set(pm)
+	zeroRowsInsertTotal := metrics.WALSegmentAssignZeroRowsInsertTotal.WithLabelValues(paramtable.GetStringNodeID(), "v_zero_rows_insert")
+	impl := &segmentInterceptor{
+		logger:              log.With(),
+		assignManager:       fManager,
+		zeroRowsInsertTotal: zeroRowsInsertTotal,
+	}
+	appended := 0
+	appendOp := func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+		appended++
+		return rmq.NewRmqID(1), nil
+	}
+	newInsertMessage := func(partitions ...*message.PartitionSegmentAssignment) message.MutableMessage {
+		msg, err := message.NewInsertMessageBuilderV1().
+			WithVChannel("v1").
+			WithHeader(&message.InsertMessageHeader{
+				CollectionId: 1,
+				Partitions:   partitions,
+			}).
+			WithBody(&msgpb.InsertRequest{}).
+			BuildMutable()
+		assert.NoError(t, err)
+		msg.WithTimeTick(tsoutil.GetCurrentTime())
+		return msg
+	}
+
+	// the insert without any rows is passed through without segment assignment.
+	before := testutil.ToFloat64(zeroRowsInsertTotal)
+	msg := newInsertMessage(
+		&message.PartitionSegmentAssignment{PartitionId: 1, Rows: 0},
+		&message.PartitionSegmentAssignment{PartitionId: 2, Rows: 0},
+	)
+	msgID, err := impl.handleInsertMessage(ctx, msg, appendOp)
+	assert.NoError(t, err)
+	assert.NotNil(t, msgID)
+	assert.Equal(t, 1, appended)
+	for _, partition := range message.MustAsMutableInsertMessageV1(msg).Header().GetPartitions() {
+		assert.Nil(t, partition.GetSegmentAssignment())
+	}
+	assert.Equal(t, before+1, testutil.ToFloat64(zeroRowsInsertTotal))
+	assert.Zero(t, resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(1000).Insert.Rows)
+
+	// the insert without any partition is passed through too.
+	_, err = impl.handleInsertMessage(ctx, newInsertMessage(), appendOp)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, appended)
+	assert.Equal(t, before+2, testutil.ToFloat64(zeroRowsInsertTotal))
+
+	// only the partition with rows is assigned, the zero rows partition keeps the assignment unset.
+	msg = newInsertMessage(
+		&message.PartitionSegmentAssignment{PartitionId: 1, Rows: 10},
+		&message.PartitionSegmentAssignment{PartitionId: 2, Rows: 0},
+	)
+	_, err = impl.handleInsertMessage(ctx, msg, appendOp)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, appended)
+	partitions := message.MustAsMutableInsertMessageV1(msg).Header().GetPartitions()
+	assert.Equal(t, int64(1000), partitions[0].GetSegmentAssignment().GetSegmentId())
+	assert.Nil(t, partitions[1].GetSegmentAssignment())
+	assert.Equal(t, before+2, testutil.ToFloat64(zeroRowsInsertTotal))
+	assert.Equal(t, uint64(10), resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(1000).Insert.Rows)
+}
+
+func TestDeleteAssignL0Segment(t *testing.T) {
+	paramtable.Init()
+
+	catalog := mock_metastore.NewMockStreamingNodeCataLog(t)
+	catalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListVChannel(mock.Anything, mock.Anything).Return(nil, nil)
+	catalog.EXPECT().SaveSegmentAssignments(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	// the L0 segment is never registered at coordinator, so no AllocSegment is expected.
+	mixCoord := idalloc.NewMockRootCoordClient(t)
+	mixCoord.EXPECT().GetPChannelInfo(mock.Anything, mock.Anything).Return(&rootcoordpb.GetPChannelInfoResponse{
+		Collections: []*rootcoordpb.CollectionInfoOnPChannel{
+			{
+				CollectionId: 1,
+				Vchannel:     "v1",
+				Partitions:   []*rootcoordpb.PartitionInfoOnPChannel{{PartitionId: 1}, {PartitionId: 2}},
+			},
+		},
+	}, nil)
+	fMixCoord := syncutil.NewFuture[internaltypes.MixCoordClient]()
+	fMixCoord.Set(mixCoord)
+	resource.InitForTest(t, resource.OptStreamingNodeCatalog(catalog), resource.OptMixCoordClient(fMixCoord))
+
+	w := mock_wal.NewMockWAL(t)
+	fWAL := syncutil.NewFuture[wal.WAL]()
+	fWAL.Set(w)
+	ctx := context.Background()
+	pm, err := manager.RecoverPChannelSegmentAllocManager(ctx, types.PChannelInfo{Name: "v_delete_l0"}, fWAL)
+	assert.NoError(t, err)
+	defer pm.Close(ctx)
+	fManager := syncutil.NewFuture[*manager.PChannelSegmentAllocManager]()
+	fManager.Set(pm)
+	impl := &segmentInterceptor{
+		logger:        log.With(),
+		assignManager: fManager,
+	}
+	appended := 0
+	appendOp := func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+		appended++
+		return rmq.NewRmqID(1), nil
+	}
+	newDeleteMessage := func(vchannel string, partitionID int64, rows int64) message.MutableMessage {
+		msg, err := message.NewDeleteMessageBuilderV1().
+			WithVChannel(vchannel).
+			WithHeader(&message.DeleteMessageHeader{CollectionId: 1}).
+			WithBody(&msgpb.DeleteRequest{CollectionID: 1, PartitionID: partitionID, NumRows: rows}).
+			BuildMutable()
+		assert.NoError(t, err)
+		msg.WithTimeTick(tsoutil.GetCurrentTime())
+		return msg
+	}
+	l0Segments := func(partitionID int64) []manager.SegmentAssignmentSnapshot {
+		snapshot, err := pm.SnapshotCollection(1, []int64{partitionID}, 0, 1)
+		assert.NoError(t, err)
+		segments := make([]manager.SegmentAssignmentSnapshot, 0)
+		for _, segment := range snapshot.Partitions[0].Segments {
+			if segment.Level == streamingpb.SegmentAssignmentLevel_SEGMENT_ASSIGNMENT_LEVEL_L0.String() {
+				segments = append(segments, segment)
+			}
+		}
+		return segments
+	}
+
+	// the deletes of the partition are assigned on the same L0 segment of the partition, and acked after the append.
+	_, err = impl.DoAppend(ctx, newDeleteMessage("v1", 2, 3), appendOp)
+	assert.NoError(t, err)
+	_, err = impl.DoAppend(ctx, newDeleteMessage("v1", 2, 2), appendOp)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, appended)
+	segments := l0Segments(2)
+	assert.Len(t, segments, 1)
+	assert.Equal(t, uint64(5), segments[0].DeletedRows)
+	assert.NotZero(t, segments[0].DeletedBinarySize)
+	assert.Zero(t, segments[0].AckSem)
+	assert.Empty(t, l0Segments(1))
+
+	// the empty delete and the delete of all partitions are appended without the L0 segment assignment.
+	_, err = impl.DoAppend(ctx, newDeleteMessage("v1", 1, 0), appendOp)
+	assert.NoError(t, err)
+	_, err = impl.DoAppend(ctx, newDeleteMessage("v1", common.AllPartitionsID, 10), appendOp)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, appended)
+	assert.Empty(t, l0Segments(1))
+	assert.Equal(t, uint64(5), l0Segments(2)[0].DeletedRows)
+
+	// the mis-routed delete is rejected before the append.
+	_, err = impl.DoAppend(ctx, newDeleteMessage("v2", 2, 1), appendOp)
+	assert.True(t, status.AsStreamingError(err).IsUnrecoverable())
+	assert.Equal(t, 4, appended)
+}
+
+func TestDoAppendPanicRecovered(t *testing.T) {
+	paramtable.Init()
+
+	now := time.Now().Unix()
+	catalog := mock_metastore.NewMockStreamingNodeCataLog(t)
+	catalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).Return([]*streamingpb.SegmentAssignmentMeta{
+		{
+			CollectionId: 1,
+			PartitionId:  1,
+			SegmentId:    1000,
+			Vchannel:     "v1",
+			State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+			Stat: &streamingpb.SegmentAssignmentStat{
+				MaxBinarySize:         1024 * 1024,
+				CreateTimestamp:       now,
+				LastModifiedTimestamp: now,
+			},
+		},
+	}, nil)
+	catalog.EXPECT().ListVChannel(mock.Anything, mock.Anything).Return(nil, nil)
+	catalog.EXPECT().SaveSegmentAssignments(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	mixCoord := idalloc.NewMockRootCoordClient(t)
+	// the partition 2 has no growing segment, the first allocation of it panics.
+	mixCoord.EXPECT().AllocSegment(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, asr *datapb.AllocSegmentRequest, co ...grpc.CallOption) (*datapb.AllocSegmentResponse, error) {
+		panic("nil header field")
+	}).Once()
+	mixCoord.EXPECT().AllocSegment(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, asr *datapb.AllocSegmentRequest, co ...grpc.CallOption) (*datapb.AllocSegmentResponse, error) {
+		return &datapb.AllocSegmentResponse{
+			SegmentInfo: &datapb.SegmentInfo{
+				ID:           asr.GetSegmentId(),
+				CollectionID: asr.GetCollectionId(),
+				PartitionID:  asr.GetPartitionId(),
+			},
+			Status: merr.Success(),
+		}, nil
+	})
+	mixCoord.EXPECT().GetPChannelInfo(mock.Anything, mock.Anything).Return(&rootcoordpb.GetPChannelInfoResponse{
+		Collections: []*rootcoordpb.CollectionInfoOnPChannel{
+			{
+				CollectionId: 1,
+				Vchannel:     "v1",
+				Partitions:   []*rootcoordpb.PartitionInfoOnPChannel{{PartitionId: 1}, {PartitionId: 2}},
+			},
+		},
+	}, nil)
+	fMixCoord := syncutil.NewFuture[internaltypes.MixCoordClient]()
+	fMixCoord.Set(mixCoord)
+	resource.InitForTest(t, resource.OptStreamingNodeCatalog(catalog), resource.OptMixCoordClient(fMixCoord))
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  tsoutil.GetCurrentTime(),
+	}, nil).Maybe()
+	fWAL := syncutil.NewFuture[wal.WAL]()
+	fWAL.Set(w)
+	ctx := context.Background()
+	pm, err := manager.RecoverPChannelSegmentAllocManager(ctx, types.PChannelInfo{Name: "v_panic_recovered"}, fWAL)
+	assert.NoError(t, err)
+	defer pm.Close(ctx)
+	fManager := syncutil.NewFuture[*manager.PChannelSegmentAllocManager]()
+	fManager.Set(pm)
+	impl := &segmentInterceptor{
+		logger:              log.With(),
+		assignManager:       fManager,
+		zeroRowsInsertTotal: metrics.WALSegmentAssignZeroRowsInsertTotal.WithLabelValues(paramtable.GetStringNodeID(), "v_panic_recovered"),
+		handlerPanicTotal: metrics.WALSegmentAssignHandlerPanicTotal.MustCurryWith(prometheus.Labels{
+			metrics.NodeIDLabelName:     paramtable.GetStringNodeID(),
+			metrics.WALChannelLabelName: "v_panic_recovered",
+		}),
+	}
+
+	msg, err := message.NewInsertMessageBuilderV1().
+		WithVChannel("v1").
+		WithHeader(&message.InsertMessageHeader{
+			CollectionId: 1,
+			Partitions: []*message.PartitionSegmentAssignment{
+				{PartitionId: 1, Rows: 10},
+				{PartitionId: 2, Rows: 10},
+			},
+		}).
+		WithBody(&msgpb.InsertRequest{}).
+		BuildMutable()
+	assert.NoError(t, err)
+	msg.WithTimeTick(tsoutil.GetCurrentTime())
+	appended := 0
+	appendOp := func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+		appended++
+		return rmq.NewRmqID(1), nil
+	}
+
+	// the panic is converted into an unrecoverable error, and the reservation of partition 1 is rolled back.
+	msgID, err := impl.DoAppend(ctx, msg, appendOp)
+	assert.Nil(t, msgID)
+	assert.Error(t, err)
+	assert.True(t, status.AsStreamingError(err).IsUnrecoverable())
+	assert.Contains(t, err.Error(), message.MessageTypeInsert.String())
+	assert.Contains(t, err.Error(), "nil header field")
+	assert.Zero(t, appended)
+	assert.Zero(t, resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(1000).Insert.BinarySize)
+
+	// the interceptor keeps serving the subsequent messages.
+	msgID, err = impl.DoAppend(ctx, msg, appendOp)
+	assert.NoError(t, err)
+	assert.NotNil(t, msgID)
+	assert.Equal(t, 1, appended)
+	assert.NotZero(t, resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(1000).Insert.BinarySize)
+
+	// the panic of the append operation is never recovered, the message may be already persisted.
+	createSegmentMsg := message.CreateTestCreateSegmentMessage(t, 1, tsoutil.GetCurrentTime(), rmq.NewRmqID(1))
+	assert.PanicsWithValue(t, "append failure", func() {
+		impl.DoAppend(ctx, createSegmentMsg, func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+			panic("append failure")
+		})
+	})
+
+	// the reservation is acked rather than rolled back if the append operation of the insert panics.
+	binarySize := resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(1000).Insert.BinarySize
+	assert.Panics(t, func() {
+		impl.DoAppend(ctx, msg, func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+			panic("append failure")
+		})
+	})
+	assert.Greater(t, resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(1000).Insert.BinarySize, binarySize)
+}
+
+func TestManualFlushExtraResponseWithSegmentStats(t *testing.T) {
+	paramtable.Init()
+
+	now := time.Now().Unix()
+	catalog := mock_metastore.NewMockStreamingNodeCataLog(t)
+	catalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).Return([]*streamingpb.SegmentAssignmentMeta{
+		{
+			CollectionId: 1,
+			PartitionId:  1,
+			SegmentId:    1000,
+			Vchannel:     "v1",
+			State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+			Stat: &streamingpb.SegmentAssignmentStat{
+				MaxBinarySize:         1024 * 1024,
+				CreateTimestamp:       now,
+				LastModifiedTimestamp: now,
+			},
+		},
+	}, nil)
+	catalog.EXPECT().ListVChannel(mock.Anything, mock.Anything).Return(nil, nil)
+	catalog.EXPECT().SaveSegmentAssignments(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	mixCoord := idalloc.NewMockRootCoordClient(t)
+	mixCoord.EXPECT().GetPChannelInfo(mock.Anything, mock.Anything).Return(&rootcoordpb.GetPChannelInfoResponse{
+		Collections: []*rootcoordpb.CollectionInfoOnPChannel{
+			{
+				CollectionId: 1,
+				Vchannel:     "v1",
+				Partitions:   []*rootcoordpb.PartitionInfoOnPChannel{{PartitionId: 1}},
+			},
+		},
+	}, nil)
+	fMixCoord := syncutil.NewFuture[internaltypes.MixCoordClient]()
+	fMixCoord.Set(mixCoord)
+	resource.InitForTest(t, resource.OptStreamingNodeCatalog(catalog), resource.OptMixCoordClient(fMixCoord))
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  tsoutil.GetCurrentTime(),
+	}, nil).Maybe()
+	fWAL := syncutil.NewFuture[wal.WAL]()
+	fWAL.Set(w)
+	ctx := context.Background()
+	pm, err := manager.RecoverPChannelSegmentAllocManager(ctx, types.PChannelInfo{Name: "v_manual_flush_stats"}, fWAL)
+	assert.NoError(t, err)
+	defer pm.Close(ctx)
+	fManager := syncutil.NewFuture[*manager.PChannelSegmentAllocManager]()
+	fManager.Set(pm)
+	impl := &segmentInterceptor{
+		logger:        log.With(),
+		assignManager: fManager,
+	}
+
+	assignTimeTick := tsoutil.GetCurrentTime()
+	result, err := pm.AssignSegment(ctx, &manager.AssignSegmentRequest{
+		CollectionID: 1,
+		PartitionID:  1,
+		InsertMetrics: stats.InsertMetrics{
+			Rows:       10,
+			BinarySize: 100,
+		},
+		TimeTick: assignTimeTick,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), result.SegmentID)
+	result.Ack()
+
+	msg, err := message.NewManualFlushMessageBuilderV2().
+		WithVChannel("v1").
+		WithHeader(&message.ManualFlushMessageHeader{
+			CollectionId: 1,
+			FlushTs:      tsoutil.GetCurrentTime(),
+		}).
+		WithBody(&message.ManualFlushMessageBody{}).
+		BuildMutable()
+	assert.NoError(t, err)
+	msg.WithTimeTick(tsoutil.GetCurrentTime())
+	appended := 0
+	appendOp := func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+		appended++
+		return rmq.NewRmqID(1), nil
+	}
+	flush := func() *message.ManualFlushExtraResponse {
+		extra := &utility.ExtraAppendResult{}
+		ctx := utility.WithExtraAppendResult(ctx, extra)
+		redoRounds := 0
+		for {
+			_, err := impl.handleManualFlushMessage(ctx, msg, appendOp)
+			if errors.Is(err, redo.ErrRedo) {
+				redoRounds++
+				continue
+			}
+			assert.NoError(t, err)
+			break
+		}
+		assert.LessOrEqual(t, redoRounds, 1)
+		return extra.Extra.(*message.ManualFlushExtraResponse)
+	}
+	expected := []*message.ManualFlushSegmentStat{
+		{SegmentId: 1000, Rows: 10, BinarySize: 100, SealTimeTick: assignTimeTick},
+	}
+
+	// the segment sealed by the first round is carried once after the redo round.
+	resp := flush()
+	assert.Equal(t, 1, appended)
+	assert.Equal(t, []int64{1000}, resp.GetSegmentIds())
+	assert.Len(t, resp.GetSegments(), 1)
+	assert.True(t, proto.Equal(expected[0], resp.GetSegments()[0]))
+
+	// the replayed manual flush carries the kept stats of the applied one.
+	resp = flush()
+	assert.Equal(t, 2, appended)
+	assert.Equal(t, []int64{1000}, resp.GetSegmentIds())
+	assert.Len(t, resp.GetSegments(), 1)
+	assert.True(t, proto.Equal(expected[0], resp.GetSegments()[0]))
+}
+
+func TestManualFlushTsValidation(t *testing.T) {
+	paramtable.Init()
+
+	catalog := mock_metastore.NewMockStreamingNodeCataLog(t)
+	catalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListVChannel(mock.Anything, mock.Anything).Return(nil, nil)
+	catalog.EXPECT().SaveSegmentAssignments(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	mixCoord := idalloc.NewMockRootCoordClient(t)
+	mixCoord.EXPECT().GetPChannelInfo(mock.Anything, mock.Anything).Return(&rootcoordpb.GetPChannelInfoResponse{}, nil)
+	fMixCoord := syncutil.NewFuture[internaltypes.MixCoordClient]()
+	fMixCoord.Set(mixCoord)
+	resource.InitForTest(t, resource.OptStreamingNodeCatalog(catalog), resource.OptMixCoordClient(fMixCoord))
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  tsoutil.GetCurrentTime(),
+	}, nil).Maybe()
+	fWAL := syncutil.NewFuture[wal.WAL]()
+	fWAL.Set(w)
+	ctx := context.Background()
+	pm, err := manager.RecoverPChannelSegmentAllocManager(ctx, types.PChannelInfo{Name: "v_manual_flush_ts"}, fWAL)
+	assert.NoError(t, err)
+	defer pm.Close(ctx)
+	fManager := syncutil.NewFuture[*manager.PChannelSegmentAllocManager]()
+	fManager.Set(pm)
+	impl := &segmentInterceptor{
+		logger:        log.With(),
+		assignManager: fManager,
+	}
+
+	createTimeTick := tsoutil.GetCurrentTime()
+	currentTimeTick := tsoutil.AddPhysicalDurationOnTs(createTimeTick, time.Second)
+	assert.NoError(t, pm.NewCollection(1, "v1", []int64{1}, manager.InitialSchemaVersion, createTimeTick))
+
+	var appended []message.MutableMessage
+	appendOp := func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+		appended = append(appended, msg)
+		return rmq.NewRmqID(1), nil
+	}
+	flush := func(flushTs uint64) error {
+		msg, err := message.NewManualFlushMessageBuilderV2().
+			WithVChannel("v1").
+			WithHeader(&message.ManualFlushMessageHeader{
+				CollectionId: 1,
+				FlushTs:      flushTs,
+			}).
+			WithBody(&message.ManualFlushMessageBody{}).
+			BuildMutable()
+		assert.NoError(t, err)
+		msg.WithTimeTick(currentTimeTick)
+		ctx := utility.WithExtraAppendResult(ctx, &utility.ExtraAppendResult{})
+		_, err = impl.handleManualFlushMessage(ctx, msg, appendOp)
+		return err
+	}
+
+	// the flush ts older than the creation of the collection is rejected without any fence.
+	err = flush(createTimeTick - 1)
+	assert.True(t, status.AsStreamingError(err).IsUnrecoverable())
+	assert.Contains(t, err.Error(), "valid range")
+	err = flush(0)
+	assert.True(t, status.AsStreamingError(err).IsUnrecoverable())
+	assert.Empty(t, appended)
+
+	// the flush ts slightly in the future is clamped to the current time tick.
+	assert.NoError(t, flush(currentTimeTick+1))
+	assert.Len(t, appended, 1)
+	assert.Equal(t, currentTimeTick, message.MustAsMutableManualFlushMessageV2(appended[0]).Header().GetFlushTs())
+
+	// the flush ts far in the future is rejected.
+	err = flush(tsoutil.AddPhysicalDurationOnTs(currentTimeTick, time.Hour))
+	assert.True(t, status.AsStreamingError(err).IsUnrecoverable())
+	assert.Len(t, appended, 1)
+}
+
+func TestMergeManualFlushExtraResponse(t *testing.T) {
+	openTxns := []*message.OpenTxn{{TxnId: 1}}
+	resp := mergeManualFlushExtraResponse(nil, []int64{1, 2}, []*message.ManualFlushSegmentStat{
+		{SegmentId: 1, Rows: 10},
+		{SegmentId: 2, Rows: 20},
+	}, nil)
+	assert.Equal(t, []int64{1, 2}, resp.GetSegmentIds())
+	assert.Len(t, resp.GetSegments(), 2)
+	assert.Empty(t, resp.GetOpenTxns())
+
+	// the segments returned again by the coalesced flush of later round are not duplicated.
+	resp = mergeManualFlushExtraResponse(resp, []int64{2, 3}, []*message.ManualFlushSegmentStat{
+		{SegmentId: 2, Rows: 20},
+		{SegmentId: 3, Rows: 30},
+	}, openTxns)
+	assert.Equal(t, []int64{1, 2, 3}, resp.GetSegmentIds())
+	assert.Equal(t, []int64{1, 2, 3}, lo.Map(resp.GetSegments(), func(stat *message.ManualFlushSegmentStat, _ int) int64 {
+		return stat.GetSegmentId()
+	}))
+	assert.Equal(t, openTxns, resp.GetOpenTxns())
+
+	// the segment without kept stat is still carried by the legacy segment ids.
+	resp = mergeManualFlushExtraResponse(resp, []int64{4}, nil, nil)
+	assert.Equal(t, []int64{1, 2, 3, 4}, resp.GetSegmentIds())
+	assert.Len(t, resp.GetSegments(), 3)
+	assert.Empty(t, resp.GetOpenTxns())
+}
+
+func TestConvertPChannelFullError(t *testing.T) {
+	err := convertPChannelFullError(errors.Wrap(manager.ErrPChannelFull, "collection 1 is rejected"))
+	assert.True(t, status.AsStreamingError(err).IsPChannelFull())
+	assert.True(t, status.AsStreamingError(err).IsUnrecoverable())
+	assert.Contains(t, err.Error(), "collection 1 is rejected")
+
+	// other errors are kept as is.
+	other := errors.New("other")
+	assert.Equal(t, other, convertPChannelFullError(other))
+	assert.NoError(t, convertPChannelFullError(nil))
+}
+
+func TestPreparePartitionDropExtraResponse(t *testing.T) {
+	paramtable.Init()
+
+	now := time.Now().Unix()
+	catalog := mock_metastore.NewMockStreamingNodeCataLog(t)
+	catalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).Return([]*streamingpb.SegmentAssignmentMeta{
+		{
+			CollectionId: 1,
+			PartitionId:  1,
+			SegmentId:    1000,
+			Vchannel:     "v1",
+			State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+			Stat: &streamingpb.SegmentAssignmentStat{
+				MaxBinarySize:         1024 * 1024,
+				CreateTimestamp:       now,
+				LastModifiedTimestamp: now,
+			},
+		},
+	}, nil)
+	catalog.EXPECT().ListVChannel(mock.Anything, mock.Anything).Return(nil, nil)
+	catalog.EXPECT().SaveSegmentAssignments(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	mixCoord := idalloc.NewMockRootCoordClient(t)
+	mixCoord.EXPECT().GetPChannelInfo(mock.Anything, mock.Anything).Return(&rootcoordpb.GetPChannelInfoResponse{
+		Collections: []*rootcoordpb.CollectionInfoOnPChannel{
+			{
+				CollectionId: 1,
+				Vchannel:     "v1",
+				Partitions:   []*rootcoordpb.PartitionInfoOnPChannel{{PartitionId: 1}},
+			},
+		},
+	}, nil)
+	fMixCoord := syncutil.NewFuture[internaltypes.MixCoordClient]()
+	fMixCoord.Set(mixCoord)
+	resource.InitForTest(t, resource.OptStreamingNodeCatalog(catalog), resource.OptMixCoordClient(fMixCoord))
+
+	w := mock_wal.NewMockWAL(t)
+	w.EXPECT().Append(mock.Anything, mock.Anything).Return(&wal.AppendResult{
+		MessageID: rmq.NewRmqID(1),
+		TimeTick:  tsoutil.GetCurrentTime(),
+	}, nil).Maybe()
+	fWAL := syncutil.NewFuture[wal.WAL]()
+	fWAL.Set(w)
+	ctx := context.Background()
+	pm, err := manager.RecoverPChannelSegmentAllocManager(ctx, types.PChannelInfo{Name: "v_prepare_partition_drop"}, fWAL)
+	assert.NoError(t, err)
+	defer pm.Close(ctx)
+	fManager := syncutil.NewFuture[*manager.PChannelSegmentAllocManager]()
+	fManager.Set(pm)
+	impl := &segmentInterceptor{
+		logger:        log.With(),
+		assignManager: fManager,
+	}
+
+	msg := message.NewPreparePartitionDropMessageBuilderV2().
+		WithVChannel("v1").
+		WithHeader(&message.PreparePartitionDropMessageHeader{
+			CollectionId: 1,
+			PartitionId:  1,
+		}).
+		WithBody(&message.PreparePartitionDropMessageBody{}).
+		MustBuildMutable().
+		WithTimeTick(tsoutil.GetCurrentTime())
+	appended := 0
+	appendOp := func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+		appended++
+		return rmq.NewRmqID(1), nil
+	}
+	extra := &utility.ExtraAppendResult{}
+	redoRounds := 0
+	for {
+		_, err := impl.handlePreparePartitionDropMessage(utility.WithExtraAppendResult(ctx, extra), msg, appendOp)
+		if errors.Is(err, redo.ErrRedo) {
+			redoRounds++
+			continue
+		}
+		assert.NoError(t, err)
+		break
+	}
+	// the message is appended once after the sealed segments are reported by the redo round.
+	assert.Equal(t, 1, redoRounds)
+	assert.Equal(t, 1, appended)
+	assert.Equal(t, []int64{1000}, extra.Extra.(*message.PreparePartitionDropExtraResponse).GetSegmentIds())
+
+	// the newer insert of the partition is rejected until the drop partition arrives.
+	_, err = pm.AssignSegment(ctx, &manager.AssignSegmentRequest{
+		CollectionID:  1,
+		PartitionID:   1,
+		InsertMetrics: stats.InsertMetrics{Rows: 1, BinarySize: 1},
+		TimeTick:      tsoutil.GetCurrentTime(),
+	})
+	assert.ErrorIs(t, err, manager.ErrPartitionDropping)
+
+	// the prepare of an unknown partition is unrecoverable.
+	unknown := message.NewPreparePartitionDropMessageBuilderV2().
+		WithVChannel("v1").
+		WithHeader(&message.PreparePartitionDropMessageHeader{
+			CollectionId: 1,
+			PartitionId:  2,
+		}).
+		WithBody(&message.PreparePartitionDropMessageBody{}).
+		MustBuildMutable().
+		WithTimeTick(tsoutil.GetCurrentTime())
+	_, err = impl.handlePreparePartitionDropMessage(ctx, unknown, appendOp)
+	assert.True(t, status.AsStreamingError(err).IsUnrecoverable())
+}
+
+func TestFlushMessageTimeTickRefresh(t *testing.T) {
+	paramtable.Init()
+	refreshTotal := metrics.WALSegmentFlushTimeTickRefreshTotal.WithLabelValues(paramtable.GetStringNodeID(), "v_flush_refresh")
+	impl := &segmentInterceptor{
+		logger:                    log.With(),
+		flushTimeTickRefreshTotal: refreshTotal,
+	}
+	newFlushMessage := func() message.MutableMessage {
+		msg, err := message.NewFlushMessageBuilderV2().
+			WithVChannel("v1").
+			WithHeader(&message.FlushMessageHeader{
+				CollectionId: 1,
+				PartitionId:  1,
+				SegmentId:    1000,
+			}).
+			WithBody(&message.FlushMessageBody{}).
+			BuildMutable()
+		assert.NoError(t, err)
+		return msg
+	}
+	appended := 0
+	appendOp := func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+		appended++
+		return rmq.NewRmqID(1), nil
+	}
+	ctx := context.Background()
+
+	// the flush message without barrier is appended directly.
+	msg := newFlushMessage()
+	msg.WithTimeTick(100)
+	_, err := impl.DoAppend(ctx, msg, appendOp)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, appended)
+
+	// the timetick not greater than the max assigned timetick of the segment is refreshed by redo.
+	msg = newFlushMessage()
+	msg.WithBarrierTimeTick(200)
+	msg.WithTimeTick(200)
+	_, err = impl.DoAppend(ctx, msg, appendOp)
+	assert.ErrorIs(t, err, redo.ErrRedo)
+	assert.Equal(t, 1, appended)
+	assert.Equal(t, float64(1), testutil.ToFloat64(refreshTotal))
+
+	// the refreshed timetick is appended.
+	msg.WithTimeTick(201)
+	_, err = impl.DoAppend(ctx, msg, appendOp)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, appended)
+	assert.Equal(t, float64(1), testutil.ToFloat64(refreshTotal))
+}
+
+func TestPreassignedInsertMessage(t *testing.T) {
+	paramtable.Init()
+
+	now := time.Now().Unix()
+	catalog := mock_metastore.NewMockStreamingNodeCataLog(t)
+	catalog.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).Return([]*streamingpb.SegmentAssignmentMeta{
+		{
+			CollectionId: 1,
+			PartitionId:  1,
+			SegmentId:    1000,
+			Vchannel:     "v1",
+			State:        streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING,
+			Stat: &streamingpb.SegmentAssignmentStat{
+				MaxBinarySize:         1024 * 1024,
+				CreateTimestamp:       now,
+				LastModifiedTimestamp: now,
+			},
+		},
+	}, nil)
+	catalog.EXPECT().ListVChannel(mock.Anything, mock.Anything).Return(nil, nil)
+	catalog.EXPECT().SaveSegmentAssignments(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	mixCoord := idalloc.NewMockRootCoordClient(t)
+	mixCoord.EXPECT().GetPChannelInfo(mock.Anything, mock.Anything).Return(&rootcoordpb.GetPChannelInfoResponse{
+		Collections: []*rootcoordpb.CollectionInfoOnPChannel{
+			{
+				CollectionId: 1,
+				Vchannel:     "v1",
+				Partitions:   []*rootcoordpb.PartitionInfoOnPChannel{{PartitionId: 1}, {PartitionId: 2}},
+			},
+		},
+	}, nil)
+	fMixCoord := syncutil.NewFuture[internaltypes.MixCoordClient]()
+	fMixCoord.Set(mixCoord)
+	resource.InitForTest(t, resource.OptStreamingNodeCatalog(catalog), resource.OptMixCoordClient(fMixCoord))
+
+	w := mock_wal.NewMockWAL(t)
+	fWAL := syncutil.NewFuture[wal.WAL]()
+	fWAL.Set(w)
+	ctx := context.Background()
+	pm, err := manager.RecoverPChannelSegmentAllocManager(ctx, types.PChannelInfo{Name: "v_preassigned"}, fWAL)
+	assert.NoError(t, err)
+	defer pm.Close(ctx)
+	fManager := syncutil.NewFuture[*manager.PChannelSegmentAllocManager]()
+	fManager.Set(pm)
+	impl := &segmentInterceptor{
+		logger:              log.With(),
+		assignManager:       fManager,
+		zeroRowsInsertTotal: metrics.WALSegmentAssignZeroRowsInsertTotal.WithLabelValues(paramtable.GetStringNodeID(), "v_preassigned"),
+	}
+
+	newPreassignedMessage := func(producer string, partitionID int64, assignment *message.SegmentAssignment) message.MutableMessage {
+		msg, err := message.NewInsertMessageBuilderV1().
+			WithVChannel("v1").
+			WithHeader(&message.InsertMessageHeader{
+				CollectionId: 1,
+				Partitions: []*message.PartitionSegmentAssignment{
+					{PartitionId: partitionID, Rows: 10, SegmentAssignment: assignment},
+				},
+			}).
+			WithBody(&msgpb.InsertRequest{}).
+			WithPreassignedProducer(producer).
+			BuildMutable()
+		assert.NoError(t, err)
+		msg.WithTimeTick(tsoutil.GetCurrentTime())
+		return msg
+	}
+	appended := 0
+	appendOp := func(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+		appended++
+		return rmq.NewRmqID(1), nil
+	}
+
+	// the producer out of the allow-list can never bypass the segment assignment.
+	_, err = impl.DoAppend(ctx, newPreassignedMessage("repair", 1, &message.SegmentAssignment{SegmentId: 1000}), appendOp)
+	assert.True(t, status.AsStreamingError(err).IsUnrecoverable())
+	assert.Zero(t, appended)
+
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.WALSegmentAssignPreassignedProducers.Key, "repair,replayer")
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.WALSegmentAssignPreassignedProducers.Key)
+	_, err = impl.DoAppend(ctx, newPreassignedMessage("", 1, &message.SegmentAssignment{SegmentId: 1000}), appendOp)
+	assert.Error(t, err)
+	assert.Zero(t, appended)
+
+	// the valid pre-assignment is appended as is and counted into the stats of the segment.
+	before := resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(1000)
+	msg := newPreassignedMessage("repair", 1, &message.SegmentAssignment{SegmentId: 1000})
+	_, err = impl.DoAppend(ctx, msg, appendOp)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, appended)
+	assert.Equal(t, int64(1000), message.MustAsMutableInsertMessageV1(msg).Header().GetPartitions()[0].GetSegmentAssignment().GetSegmentId())
+	after := resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(1000)
+	assert.Equal(t, before.Insert.Rows+10, after.Insert.Rows)
+
+	// the invalid pre-assignments are rejected.
+	for _, msg := range []message.MutableMessage{
+		newPreassignedMessage("replayer", 1, nil),
+		newPreassignedMessage("replayer", 1, &message.SegmentAssignment{SegmentId: 999}),
+		newPreassignedMessage("replayer", 2, &message.SegmentAssignment{SegmentId: 1000}),
+	} {
+		_, err = impl.DoAppend(ctx, msg, appendOp)
+		assert.Error(t, err)
+		assert.Equal(t, streamingpb.StreamingCode_STREAMING_CODE_INVAILD_ARGUMENT, status.AsStreamingError(err).Code)
+	}
+	assert.Equal(t, 1, appended)
+	assert.Equal(t, after.Insert, resource.Resource().SegmentAssignStatsManager().GetStatsOfSegment(1000).Insert)
+}
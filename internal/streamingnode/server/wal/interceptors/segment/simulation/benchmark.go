@@ -0,0 +1,29 @@
+//go:build test
+// +build test
+
+package simulation
+
+import (
+	"testing"
+)
+
+// Benchmark runs the workload b.N times and reports the result of the last run as the metrics of the benchmark,
+// so the seal policy changes can be compared by the benchmark tools.
+func Benchmark(b *testing.B, w Workload) {
+	var report *Report
+	for i := 0; i < b.N; i++ {
+		report = Run(b, w)
+	}
+	b.StopTimer()
+	if report == nil {
+		return
+	}
+	b.ReportMetric(float64(len(report.Segments)), "flushed_segments")
+	b.ReportMetric(report.FlushesPerMinute(), "flushes/min")
+	b.ReportMetric(report.SegmentBinarySize.P50/1024/1024, "p50_segment_MB")
+	b.ReportMetric(report.SegmentBinarySize.P99/1024/1024, "p99_segment_MB")
+	b.ReportMetric(report.SegmentLifetimeSeconds.P50, "p50_lifetime_s")
+	for reason, cnt := range report.SealReasons {
+		b.ReportMetric(float64(cnt), "sealed_by_"+reason)
+	}
+}
@@ -0,0 +1,113 @@
+//go:build test
+// +build test
+
+package simulation
+
+import (
+	"time"
+)
+
+const (
+	paramSegmentMaxSize         = "dataCoord.segment.maxSize"
+	paramSegmentSealJitter      = "dataCoord.segment.sealProportionJitter"
+	paramSegmentMaxLifetime     = "dataCoord.segment.maxLife"
+	paramGrowingSegmentsMemSize = "dataCoord.sealPolicy.channel.growingSegmentsMemSize"
+)
+
+// CannedWorkloads returns the canned workloads that are small enough to be run in CI.
+// The max segment size is shrunk, so the seal policies are hit within tens of simulated minutes.
+func CannedWorkloads() []Workload {
+	return []Workload{
+		{
+			// a single partition with steady inserts, the segments should be sealed by capacity.
+			Name:     "steady_single_partition",
+			Seed:     1,
+			Duration: 30 * time.Minute,
+			Params: map[string]string{
+				paramSegmentMaxSize: "64",
+			},
+			Collections: []CollectionWorkload{
+				{
+					CollectionID:  1,
+					Partitions:    1,
+					InsertRate:    Constant(20),
+					RowsPerInsert: Uniform(50, 150),
+					RowSize:       Constant(256),
+				},
+			},
+		},
+		{
+			// many partitions with bursty inserts, the segments are sealed by capacity and the growing segments memory.
+			Name:     "bursty_multi_partition",
+			Seed:     2,
+			Duration: 30 * time.Minute,
+			Params: map[string]string{
+				paramSegmentMaxSize:         "64",
+				paramGrowingSegmentsMemSize: "256",
+			},
+			Collections: []CollectionWorkload{
+				{
+					CollectionID:  1,
+					Partitions:    8,
+					InsertRate:    Uniform(0, 5).WithBurst(0.05, 20),
+					RowsPerInsert: Uniform(10, 200),
+					RowSize:       Uniform(128, 1024),
+				},
+			},
+		},
+		{
+			// most of inserts are written by txn, the seal of segments waits for the txn.
+			Name:     "txn_heavy",
+			Seed:     3,
+			Duration: 30 * time.Minute,
+			Params: map[string]string{
+				paramSegmentMaxSize: "32",
+			},
+			Collections: []CollectionWorkload{
+				{
+					CollectionID:  1,
+					Partitions:    2,
+					InsertRate:    Uniform(5, 15),
+					RowsPerInsert: Uniform(50, 100),
+					RowSize:       Constant(512),
+					TxnRatio:      0.8,
+					TxnDuration:   30 * time.Second,
+				},
+				{
+					CollectionID:  2,
+					Partitions:    2,
+					InsertRate:    Uniform(1, 3),
+					RowsPerInsert: Uniform(50, 100),
+					RowSize:       Constant(512),
+					TxnRatio:      0.5,
+					TxnDuration:   2 * time.Minute,
+				},
+			},
+		},
+		{
+			// many collections with a trickle of inserts, the segments should be sealed by lifetime or idle.
+			Name:     "many_small_collections",
+			Seed:     4,
+			Duration: time.Hour,
+			Params: map[string]string{
+				paramSegmentMaxLifetime: "900",
+			},
+			Collections: manySmallCollections(20),
+		},
+	}
+}
+
+// manySmallCollections returns n collections with a trickle of inserts.
+func manySmallCollections(n int) []CollectionWorkload {
+	collections := make([]CollectionWorkload, 0, n)
+	for i := 0; i < n; i++ {
+		collections = append(collections, CollectionWorkload{
+			CollectionID:  int64(i + 1),
+			Partitions:    1,
+			InsertRate:    Uniform(0, 0.4),
+			RowsPerInsert: Uniform(1, 20),
+			RowSize:       Constant(1024),
+		})
+	}
+	return collections
+}
@@ -0,0 +1,97 @@
+//go:build test
+// +build test
+
+package simulation
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/mocks/mock_metastore"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+// memoryCatalog is an in-memory segment assignment catalog of streaming node,
+// the flushed segments are recorded with the time of the clock when they're removed from the catalog.
+type memoryCatalog struct {
+	mu          sync.Mutex
+	clock       clock.Clock
+	mock        *mock_metastore.MockStreamingNodeCataLog
+	assignments map[int64]*streamingpb.SegmentAssignmentMeta
+	flushed     []FlushedSegment
+}
+
+// newMemoryCatalog creates a new in-memory catalog backed by the mocked streaming node catalog.
+func newMemoryCatalog(t testing.TB, c clock.Clock) *memoryCatalog {
+	catalog := &memoryCatalog{
+		clock:       c,
+		mock:        mock_metastore.NewMockStreamingNodeCataLog(t),
+		assignments: make(map[int64]*streamingpb.SegmentAssignmentMeta),
+	}
+	catalog.mock.EXPECT().ListSegmentAssignment(mock.Anything, mock.Anything).RunAndReturn(catalog.listSegmentAssignment).Maybe()
+	catalog.mock.EXPECT().SaveSegmentAssignments(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(catalog.saveSegmentAssignments).Maybe()
+	catalog.mock.EXPECT().ListVChannel(mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return catalog
+}
+
+// listSegmentAssignment lists all segment assignments in the catalog.
+func (c *memoryCatalog) listSegmentAssignment(ctx context.Context, pchannel string) ([]*streamingpb.SegmentAssignmentMeta, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	metas := make([]*streamingpb.SegmentAssignmentMeta, 0, len(c.assignments))
+	for _, meta := range c.assignments {
+		metas = append(metas, proto.Clone(meta).(*streamingpb.SegmentAssignmentMeta))
+	}
+	return metas, nil
+}
+
+// saveSegmentAssignments saves the segment assignments, the flushed segment is removed and recorded.
+func (c *memoryCatalog) saveSegmentAssignments(ctx context.Context, pchannel string, infos map[int64]*streamingpb.SegmentAssignmentMeta) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for segmentID, info := range infos {
+		if info.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED {
+			c.assignments[segmentID] = proto.Clone(info).(*streamingpb.SegmentAssignmentMeta)
+			continue
+		}
+		delete(c.assignments, segmentID)
+		c.flushed = append(c.flushed, FlushedSegment{
+			CollectionID: info.GetCollectionId(),
+			PartitionID:  info.GetPartitionId(),
+			SegmentID:    segmentID,
+			Rows:         info.GetStat().GetInsertedRows(),
+			BinarySize:   info.GetStat().GetInsertedBinarySize(),
+			CreateTime:   time.Unix(info.GetStat().GetCreateTimestamp(), 0),
+			FlushTime:    c.clock.Now(),
+		})
+	}
+	return nil
+}
+
+// Flushed returns the flushed segments ordered by the flush time and the count of segments that are not flushed yet.
+func (c *memoryCatalog) Flushed() ([]FlushedSegment, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	flushed := make([]FlushedSegment, len(c.flushed))
+	copy(flushed, c.flushed)
+	sort.SliceStable(flushed, func(i, j int) bool {
+		return flushed[i].FlushTime.Before(flushed[j].FlushTime)
+	})
+	unflushed := 0
+	for _, meta := range c.assignments {
+		if meta.GetState() != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING {
+			unflushed++
+		}
+	}
+	return flushed, unflushed
+}
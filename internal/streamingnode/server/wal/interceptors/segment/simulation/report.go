@@ -0,0 +1,182 @@
+//go:build test
+// +build test
+
+package simulation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+)
+
+// FlushedSegment is a segment flushed in the simulation.
+type FlushedSegment struct {
+	CollectionID int64
+	PartitionID  int64
+	SegmentID    int64
+	Rows         uint64
+	BinarySize   uint64
+	CreateTime   time.Time
+	FlushTime    time.Time
+}
+
+// Summary is the summary of a series of samples.
+type Summary struct {
+	Count int
+	Min   float64
+	Mean  float64
+	P50   float64
+	P90   float64
+	P99   float64
+	Max   float64
+}
+
+// newSummary creates a summary of the samples.
+func newSummary(samples []float64) Summary {
+	if len(samples) == 0 {
+		return Summary{}
+	}
+	sorted := make([]float64, len(samples))
+	copy(sorted, samples)
+	sort.Float64s(sorted)
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	quantile := func(q float64) float64 {
+		idx := int(math.Ceil(q*float64(len(sorted)))) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		return sorted[idx]
+	}
+	return Summary{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Mean:  sum / float64(len(sorted)),
+		P50:   quantile(0.5),
+		P90:   quantile(0.9),
+		P99:   quantile(0.99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// Report is the result of a simulation.
+type Report struct {
+	Workload           string
+	Duration           time.Duration
+	InsertCount        int    // the count of assigned insert messages.
+	InsertedRows       uint64 // the rows of assigned insert messages.
+	InsertedBinarySize uint64 // the binary size of assigned insert messages.
+	RejectedInserts    int    // the count of insert messages that are failed to be assigned.
+	TxnCount           int
+	UnflushedSegments  int            // the count of segments that are not flushed at the end of simulation.
+	SealReasons        map[string]int // the count of flushed segments by the seal policy.
+	Segments           []FlushedSegment
+
+	SegmentBinarySize      Summary // the binary size of flushed segments in bytes.
+	SegmentRows            Summary // the rows of flushed segments.
+	SegmentLifetimeSeconds Summary // the duration from the creation to the flush of segments.
+	FlushIntervalSeconds   Summary // the duration between two consecutive flushes on the pchannel.
+}
+
+// FlushesPerMinute returns the average flush cadence of the pchannel.
+func (r *Report) FlushesPerMinute() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(len(r.Segments)) / r.Duration.Minutes()
+}
+
+// String returns the human readable report.
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "workload %s, simulated %s\n", r.Workload, r.Duration)
+	fmt.Fprintf(&b, "  inserts: %d assigned (%d rows, %d bytes), %d rejected, %d txns\n",
+		r.InsertCount, r.InsertedRows, r.InsertedBinarySize, r.RejectedInserts, r.TxnCount)
+	fmt.Fprintf(&b, "  segments: %d flushed, %d unflushed, %.2f flushes per minute\n",
+		len(r.Segments), r.UnflushedSegments, r.FlushesPerMinute())
+	fmt.Fprintf(&b, "  segment binary size (MB): %s\n", r.SegmentBinarySize.format(1024*1024))
+	fmt.Fprintf(&b, "  segment rows: %s\n", r.SegmentRows.format(1))
+	fmt.Fprintf(&b, "  segment lifetime (s): %s\n", r.SegmentLifetimeSeconds.format(1))
+	fmt.Fprintf(&b, "  flush interval (s): %s\n", r.FlushIntervalSeconds.format(1))
+	reasons := make([]string, 0, len(r.SealReasons))
+	for reason := range r.SealReasons {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	for _, reason := range reasons {
+		fmt.Fprintf(&b, "  sealed by %s: %d\n", reason, r.SealReasons[reason])
+	}
+	return b.String()
+}
+
+// format formats the summary with the values divided by the unit.
+func (s Summary) format(unit float64) string {
+	return fmt.Sprintf("count=%d min=%.2f mean=%.2f p50=%.2f p90=%.2f p99=%.2f max=%.2f",
+		s.Count, s.Min/unit, s.Mean/unit, s.P50/unit, s.P90/unit, s.P99/unit, s.Max/unit)
+}
+
+// newReport creates the report of the simulation.
+func newReport(s *simulator, flushed []FlushedSegment, unflushed int) *Report {
+	sizes := make([]float64, 0, len(flushed))
+	rows := make([]float64, 0, len(flushed))
+	lifetimes := make([]float64, 0, len(flushed))
+	intervals := make([]float64, 0, len(flushed))
+	for i, segment := range flushed {
+		sizes = append(sizes, float64(segment.BinarySize))
+		rows = append(rows, float64(segment.Rows))
+		lifetimes = append(lifetimes, segment.FlushTime.Sub(segment.CreateTime).Seconds())
+		if i > 0 {
+			intervals = append(intervals, segment.FlushTime.Sub(flushed[i-1].FlushTime).Seconds())
+		}
+	}
+	return &Report{
+		Workload:               s.workload.Name,
+		Duration:               s.workload.Duration,
+		InsertCount:            s.insertCount,
+		InsertedRows:           s.insertedRows,
+		InsertedBinarySize:     s.insertedBinarySize,
+		RejectedInserts:        s.rejectedInserts,
+		TxnCount:               s.txnCount,
+		UnflushedSegments:      unflushed,
+		SealReasons:            s.sealReasons,
+		Segments:               flushed,
+		SegmentBinarySize:      newSummary(sizes),
+		SegmentRows:            newSummary(rows),
+		SegmentLifetimeSeconds: newSummary(lifetimes),
+		FlushIntervalSeconds:   newSummary(intervals),
+	}
+}
+
+// collectSealReasons collects the count of flushed segments by the seal policy of the pchannel from the metrics.
+func collectSealReasons(pchannel string) map[string]int {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		metrics.WALSegmentFlushedTotal.Collect(ch)
+		close(ch)
+	}()
+	reasons := make(map[string]int)
+	for metric := range ch {
+		m := &dto.Metric{}
+		if err := metric.Write(m); err != nil {
+			continue
+		}
+		labels := make(map[string]string, len(m.GetLabel()))
+		for _, label := range m.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		if labels[metrics.WALChannelLabelName] != pchannel {
+			continue
+		}
+		reasons[labels[metrics.WALSegmentSealPolicyNameLabelName]] += int(m.GetCounter().GetValue())
+	}
+	return reasons
+}
@@ -0,0 +1,64 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCannedWorkloads(t *testing.T) {
+	for _, w := range CannedWorkloads() {
+		t.Run(w.Name, func(t *testing.T) {
+			report := Run(t, w)
+			t.Log(report.String())
+
+			assert.NotZero(t, report.InsertCount)
+			assert.Zero(t, report.RejectedInserts)
+			assert.NotEmpty(t, report.Segments)
+			sealed := 0
+			for _, cnt := range report.SealReasons {
+				sealed += cnt
+			}
+			assert.Equal(t, len(report.Segments), sealed)
+
+			flushedRows := uint64(0)
+			for _, segment := range report.Segments {
+				flushedRows += segment.Rows
+				assert.False(t, segment.FlushTime.Before(segment.CreateTime))
+			}
+			assert.LessOrEqual(t, flushedRows, report.InsertedRows)
+		})
+	}
+}
+
+func TestSimulationReproducible(t *testing.T) {
+	w := CannedWorkloads()[1]
+	w.Duration = 10 * time.Minute
+	// the jitter of seal proportion is generated by the global random source.
+	w.Params[paramSegmentSealJitter] = "0"
+	r1 := Run(t, w)
+	r2 := Run(t, w)
+	assert.Equal(t, r1.InsertedRows, r2.InsertedRows)
+	assert.Equal(t, r1.SealReasons, r2.SealReasons)
+	assert.Equal(t, r1.SegmentBinarySize, r2.SegmentBinarySize)
+}
+
+func TestInvalidWorkload(t *testing.T) {
+	_, err := Workload{Name: "empty", Duration: time.Minute}.withDefaults()
+	assert.Error(t, err)
+	_, err = Workload{Name: "short", Duration: time.Millisecond, Collections: manySmallCollections(1)}.withDefaults()
+	assert.Error(t, err)
+	_, err = Workload{Name: "duplicate", Duration: time.Minute, Collections: append(manySmallCollections(1), manySmallCollections(1)...)}.withDefaults()
+	assert.Error(t, err)
+	_, err = Workload{Name: "txn", Duration: time.Minute, Collections: []CollectionWorkload{{CollectionID: 1, Partitions: 1, TxnRatio: 0.5}}}.withDefaults()
+	assert.Error(t, err)
+}
+
+func BenchmarkCannedWorkloads(b *testing.B) {
+	for _, w := range CannedWorkloads() {
+		b.Run(w.Name, func(b *testing.B) {
+			Benchmark(b, w)
+		})
+	}
+}
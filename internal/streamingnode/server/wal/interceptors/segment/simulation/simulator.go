@@ -0,0 +1,296 @@
+//go:build test
+// +build test
+
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus/internal/mocks/streamingnode/server/mock_wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/manager"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/stats"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/txn"
+	internaltypes "github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/idalloc"
+	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v2/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/rmq"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+)
+
+// runCounter makes the pchannel name of every simulation unique, so the metrics of different runs are never mixed.
+var runCounter = atomic.NewInt64(0)
+
+// Run runs the workload on a PChannelSegmentAllocManager with the mocked catalog and coordinator and the fake clock,
+// and reports the resulting segment size distribution, seal reasons and flush cadence.
+// The seal sweeps that are scheduled by the seal inspector in production are driven by the simulator at the simulated time,
+// so the result is reproducible with the same workload.
+// The resource singleton of streaming node is replaced, so a simulation should never run in parallel with other tests.
+func Run(t testing.TB, w Workload) *Report {
+	w, err := w.withDefaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+	paramtable.Init()
+	for key, value := range w.Params {
+		paramtable.Get().Save(key, value)
+	}
+	defer func() {
+		for key := range w.Params {
+			paramtable.Get().Reset(key)
+		}
+	}()
+
+	s := newSimulator(t, w)
+	defer s.close()
+	s.run()
+	return s.report()
+}
+
+// simulator drives the segment assignment of a pchannel by the workload.
+type simulator struct {
+	t         testing.TB
+	ctx       context.Context
+	workload  Workload
+	rand      *rand.Rand
+	clock     *clock.FakeClock
+	pchannel  types.PChannelInfo
+	catalog   *memoryCatalog
+	manager   *manager.PChannelSegmentAllocManager
+	txns      *txn.TxnManager
+	openTxns  map[int64]*openTxn // the open txn of every partition.
+	vchannels map[int64]string   // the vchannel of every collection.
+
+	insertedRows       uint64
+	insertedBinarySize uint64
+	insertCount        int
+	txnCount           int
+	rejectedInserts    int
+	sealReasons        map[string]int
+}
+
+// openTxn is a txn that is not committed yet.
+type openTxn struct {
+	session  *txn.TxnSession
+	commitAt time.Time
+}
+
+// newSimulator creates a new simulator with the mocked resources of streaming node.
+func newSimulator(t testing.TB, w Workload) *simulator {
+	// the create timestamp of segment is persisted in seconds, so the clock is started at a whole second.
+	fakeClock := clock.NewFakeClock(time.Unix(time.Now().Unix(), 0))
+	pchannel := types.PChannelInfo{
+		Name: fmt.Sprintf("simulation-%s-%d", w.Name, runCounter.Inc()),
+		Term: 1,
+	}
+	catalog := newMemoryCatalog(t, fakeClock)
+
+	collections := make([]*rootcoordpb.CollectionInfoOnPChannel, 0, len(w.Collections))
+	vchannels := make(map[int64]string, len(w.Collections))
+	for _, c := range w.Collections {
+		vchannel := fmt.Sprintf("%s_%dv0", pchannel.Name, c.CollectionID)
+		vchannels[c.CollectionID] = vchannel
+		partitions := make([]*rootcoordpb.PartitionInfoOnPChannel, 0, c.Partitions)
+		for _, partitionID := range c.PartitionIDs() {
+			partitions = append(partitions, &rootcoordpb.PartitionInfoOnPChannel{PartitionId: partitionID})
+		}
+		collections = append(collections, &rootcoordpb.CollectionInfoOnPChannel{
+			CollectionId: c.CollectionID,
+			Partitions:   partitions,
+			Vchannel:     vchannel,
+		})
+	}
+	mixCoordClient := idalloc.NewMockRootCoordClient(t)
+	mixCoordClient.EXPECT().AllocSegment(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, asr *datapb.AllocSegmentRequest, co ...grpc.CallOption) (*datapb.AllocSegmentResponse, error) {
+			return &datapb.AllocSegmentResponse{
+				SegmentInfo: &datapb.SegmentInfo{
+					ID:           asr.GetSegmentId(),
+					CollectionID: asr.GetCollectionId(),
+					PartitionID:  asr.GetPartitionId(),
+				},
+				Status: merr.Success(),
+			}, nil
+		}).Maybe()
+	mixCoordClient.EXPECT().GetPChannelInfo(mock.Anything, mock.Anything).Return(&rootcoordpb.GetPChannelInfoResponse{
+		Status:      merr.Success(),
+		Collections: collections,
+	}, nil).Maybe()
+	fMixCoordClient := syncutil.NewFuture[internaltypes.MixCoordClient]()
+	fMixCoordClient.Set(mixCoordClient)
+
+	resource.InitForTest(t,
+		resource.OptClock(fakeClock),
+		resource.OptStreamingNodeCatalog(catalog.mock),
+		resource.OptMixCoordClient(fMixCoordClient),
+	)
+
+	l := mock_wal.NewMockWAL(t)
+	l.EXPECT().Append(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, mm message.MutableMessage) (*wal.AppendResult, error) {
+		return &wal.AppendResult{
+			MessageID: rmq.NewRmqID(1),
+			TimeTick:  fakeClock.CurrentTSO(),
+		}, nil
+	}).Maybe()
+	f := syncutil.NewFuture[wal.WAL]()
+	f.Set(l)
+
+	ctx := context.Background()
+	m, err := manager.RecoverPChannelSegmentAllocManager(ctx, pchannel, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &simulator{
+		t:         t,
+		ctx:       ctx,
+		workload:  w,
+		rand:      rand.New(rand.NewSource(w.Seed)),
+		clock:     fakeClock,
+		pchannel:  pchannel,
+		catalog:   catalog,
+		manager:   m,
+		txns:      txn.NewTxnManager(pchannel, nil),
+		openTxns:  make(map[int64]*openTxn),
+		vchannels: vchannels,
+	}
+}
+
+// run runs the workload step by step.
+func (s *simulator) run() {
+	lastSweep := s.clock.Now()
+	for elapsed := time.Duration(0); elapsed < s.workload.Duration; elapsed += s.workload.Step {
+		s.clock.Advance(s.workload.Step)
+		for _, c := range s.workload.Collections {
+			for _, partitionID := range c.PartitionIDs() {
+				s.insert(c, partitionID)
+			}
+		}
+		s.commitTxns(false)
+
+		if s.clock.Since(lastSweep) >= s.workload.SealInterval {
+			lastSweep = s.clock.Now()
+			s.manager.TryToSealSegments(s.ctx)
+		}
+		s.sealByGrowingSegmentsSize()
+		s.manager.TryToSealWaitedSegment(s.ctx)
+	}
+	s.commitTxns(true)
+}
+
+// insert simulates the inserts into the partition in a step.
+func (s *simulator) insert(c CollectionWorkload, partitionID int64) {
+	n := sampleCount(s.rand, c.InsertRate, s.workload.Step)
+	for i := 0; i < n; i++ {
+		rows := uint64(c.RowsPerInsert.Sample(s.rand))
+		if rows == 0 {
+			rows = 1
+		}
+		binarySize := rows * uint64(c.RowSize.Sample(s.rand))
+		var session *txn.TxnSession
+		if c.TxnRatio > 0 && s.rand.Float64() < c.TxnRatio {
+			session = s.getOrBeginTxn(c, partitionID)
+		}
+		result, err := s.manager.AssignSegment(s.ctx, &manager.AssignSegmentRequest{
+			CollectionID: c.CollectionID,
+			PartitionID:  partitionID,
+			InsertMetrics: stats.InsertMetrics{
+				Rows:       rows,
+				BinarySize: binarySize,
+			},
+			TimeTick:   s.clock.CurrentTSO(),
+			TxnSession: session,
+		})
+		if err != nil {
+			s.rejectedInserts++
+			continue
+		}
+		result.Ack()
+		s.insertCount++
+		s.insertedRows += rows
+		s.insertedBinarySize += binarySize
+	}
+}
+
+// getOrBeginTxn returns the open txn of the partition, a new txn is began if there's no open txn.
+func (s *simulator) getOrBeginTxn(c CollectionWorkload, partitionID int64) *txn.TxnSession {
+	if t, ok := s.openTxns[partitionID]; ok {
+		return t.session
+	}
+	// the keepalive is longer than the txn, so the txn is never expired before commit.
+	keepalive := c.TxnDuration + 2*s.workload.SealInterval
+	msg := message.NewBeginTxnMessageBuilderV2().
+		WithVChannel(s.vchannels[c.CollectionID]).
+		WithHeader(&message.BeginTxnMessageHeader{KeepaliveMilliseconds: keepalive.Milliseconds()}).
+		WithBody(&message.BeginTxnMessageBody{}).
+		MustBuildMutable().
+		WithTimeTick(s.clock.CurrentTSO())
+	beginTxnMsg, err := message.AsMutableBeginTxnMessageV2(msg)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	session, err := s.txns.BeginNewTxn(s.ctx, beginTxnMsg)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	session.BeginDone()
+	s.openTxns[partitionID] = &openTxn{
+		session:  session,
+		commitAt: s.clock.Now().Add(c.TxnDuration),
+	}
+	s.txnCount++
+	return session
+}
+
+// commitTxns commits the txns that reach the commit time, all open txns are committed if force is true.
+func (s *simulator) commitTxns(force bool) {
+	for partitionID, t := range s.openTxns {
+		if !force && s.clock.Now().Before(t.commitAt) {
+			continue
+		}
+		if err := t.session.RequestCommitAndWait(s.ctx, s.clock.CurrentTSO()); err != nil {
+			s.t.Fatal(err)
+		}
+		t.session.CommitDone()
+		delete(s.openTxns, partitionID)
+	}
+	s.txns.CleanupTxnUntil(s.clock.CurrentTSO())
+}
+
+// sealByGrowingSegmentsSize seals the largest growing segment if the total size of growing segments exceeds the threshold,
+// it's scheduled by the seal inspector in production.
+func (s *simulator) sealByGrowingSegmentsSize() {
+	threshold := paramtable.Get().DataCoordCfg.GrowingSegmentsMemSizeInMB.GetAsUint64() * 1024 * 1024
+	belongs := resource.Resource().SegmentAssignStatsManager().SealByTotalGrowingSegmentsSize(threshold)
+	if belongs == nil {
+		return
+	}
+	s.manager.MustSealSegments(s.ctx, *belongs)
+}
+
+// report generates the report of the simulation.
+func (s *simulator) report() *Report {
+	// the seal reasons are collected from the metrics before the manager is closed.
+	s.sealReasons = collectSealReasons(s.pchannel.Name)
+	flushed, unflushed := s.catalog.Flushed()
+	return newReport(s, flushed, unflushed)
+}
+
+// close closes the simulator.
+func (s *simulator) close() {
+	s.manager.Close(s.ctx)
+}
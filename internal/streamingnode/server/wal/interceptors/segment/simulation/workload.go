@@ -0,0 +1,131 @@
+//go:build test
+// +build test
+
+package simulation
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	defaultStep         = time.Second
+	defaultSealInterval = 10 * time.Second // same as the seal all interval of the seal inspector.
+)
+
+// Workload is the spec of the simulated inserts on a pchannel.
+type Workload struct {
+	Name         string
+	Seed         int64             // the seed of the random source, the simulation is reproducible with the same seed if the seal jitter is disabled.
+	Duration     time.Duration     // the simulated duration of the workload.
+	Step         time.Duration     // the simulated time advanced by every step, 1s by default.
+	SealInterval time.Duration     // the interval of the seal sweep on all partitions, 10s by default.
+	Params       map[string]string // the params overridden during the simulation, such as the seal proportion, jitter and lifetime.
+	Collections  []CollectionWorkload
+}
+
+// CollectionWorkload is the spec of the simulated inserts into a collection.
+// The partition ids of the collection are CollectionID*1000+1, CollectionID*1000+2, ...
+type CollectionWorkload struct {
+	CollectionID  int64
+	Partitions    int
+	InsertRate    Distribution  // the count of insert messages per second on every partition.
+	RowsPerInsert Distribution  // the rows of every insert message.
+	RowSize       Distribution  // the binary size of a row in bytes.
+	TxnRatio      float64       // the ratio of the inserts written by txn, in [0, 1].
+	TxnDuration   time.Duration // the duration between the begin and the commit of a txn.
+}
+
+// PartitionIDs returns the partition ids of the collection.
+func (c CollectionWorkload) PartitionIDs() []int64 {
+	partitionIDs := make([]int64, 0, c.Partitions)
+	for i := 0; i < c.Partitions; i++ {
+		partitionIDs = append(partitionIDs, c.CollectionID*1000+int64(i)+1)
+	}
+	return partitionIDs
+}
+
+// Distribution is a uniform distribution in [Min, Max], it's a constant if Max is not greater than Min.
+// If BurstProbability is positive, the sample is multiplied by BurstFactor with the probability to simulate the traffic bursts.
+type Distribution struct {
+	Min              float64
+	Max              float64
+	BurstProbability float64
+	BurstFactor      float64
+}
+
+// Constant returns a distribution that always samples v.
+func Constant(v float64) Distribution {
+	return Distribution{Min: v, Max: v}
+}
+
+// Uniform returns a uniform distribution in [min, max].
+func Uniform(min float64, max float64) Distribution {
+	return Distribution{Min: min, Max: max}
+}
+
+// WithBurst returns a copy of the distribution with the bursts.
+func (d Distribution) WithBurst(probability float64, factor float64) Distribution {
+	d.BurstProbability = probability
+	d.BurstFactor = factor
+	return d
+}
+
+// Sample samples a value from the distribution.
+func (d Distribution) Sample(r *rand.Rand) float64 {
+	v := d.Min
+	if d.Max > d.Min {
+		v += r.Float64() * (d.Max - d.Min)
+	}
+	if d.BurstProbability > 0 && r.Float64() < d.BurstProbability {
+		v *= d.BurstFactor
+	}
+	return v
+}
+
+// sampleCount samples the count of events happened in the duration with the rate distribution per second.
+// The fractional part is rounded up by the probability of itself, so the expectation is kept.
+func sampleCount(r *rand.Rand, rate Distribution, d time.Duration) int {
+	expected := rate.Sample(r) * d.Seconds()
+	if expected <= 0 {
+		return 0
+	}
+	n := math.Floor(expected)
+	if r.Float64() < expected-n {
+		n++
+	}
+	return int(n)
+}
+
+// withDefaults fills the default value of the workload and validates it.
+func (w Workload) withDefaults() (Workload, error) {
+	if w.Step <= 0 {
+		w.Step = defaultStep
+	}
+	if w.SealInterval <= 0 {
+		w.SealInterval = defaultSealInterval
+	}
+	if w.Duration < w.Step {
+		return w, errors.Errorf("duration %s of workload %s is less than the step %s", w.Duration, w.Name, w.Step)
+	}
+	if len(w.Collections) == 0 {
+		return w, errors.Errorf("workload %s has no collection", w.Name)
+	}
+	collections := make(map[int64]struct{}, len(w.Collections))
+	for _, c := range w.Collections {
+		if c.CollectionID <= 0 || c.Partitions <= 0 || c.Partitions >= 1000 {
+			return w, errors.Errorf("invalid collection %d with %d partitions in workload %s", c.CollectionID, c.Partitions, w.Name)
+		}
+		if _, ok := collections[c.CollectionID]; ok {
+			return w, errors.Errorf("duplicate collection %d in workload %s", c.CollectionID, w.Name)
+		}
+		if c.TxnRatio > 0 && c.TxnDuration <= 0 {
+			return w, errors.Errorf("collection %d in workload %s writes txn without txn duration", c.CollectionID, w.Name)
+		}
+		collections[c.CollectionID] = struct{}{}
+	}
+	return w, nil
+}
@@ -0,0 +1,126 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+const (
+	// maxSegmentCreationSamples is the max count of the creation samples kept for a collection.
+	// If the samples are evicted by the count, the rate is projected over the span of the kept samples.
+	maxSegmentCreationSamples = 4096
+	segmentCreationRateUnit   = 24 * time.Hour
+)
+
+// SegmentCreationProjection is the projection of the segment creation of a collection.
+type SegmentCreationProjection struct {
+	CollectionID    int64
+	CreatedInWindow int           // the count of the segments created within the window.
+	Window          time.Duration // the span that the rate is projected over.
+	SegmentsPerDay  float64       // the projected count of the segments created per day.
+	Threshold       int64         // the alert threshold of the segments created per day.
+	TimeToThreshold time.Duration // the projected time to create the threshold count of segments, 0 if nothing is created.
+}
+
+// Exceeded checks if the projection crosses the alert threshold.
+func (p SegmentCreationProjection) Exceeded() bool {
+	return p.Threshold > 0 && p.SegmentsPerDay > float64(p.Threshold)
+}
+
+// newSegmentCreationRate creates a new segment creation rate of a collection.
+func newSegmentCreationRate() *segmentCreationRate {
+	return &segmentCreationRate{}
+}
+
+// segmentCreationRate keeps the creation time of the segments of a collection within the window.
+type segmentCreationRate struct {
+	createdAt []time.Time // the creation time in ascending order.
+	alerting  bool        // the projection is crossing the threshold.
+}
+
+// add adds a new creation sample and evicts the expired ones.
+func (r *segmentCreationRate) add(now time.Time, window time.Duration) {
+	r.createdAt = append(r.createdAt, now)
+	r.expire(now, window)
+}
+
+// expire evicts the samples out of the window or the count limit.
+func (r *segmentCreationRate) expire(now time.Time, window time.Duration) {
+	i := 0
+	for i < len(r.createdAt) && (len(r.createdAt)-i > maxSegmentCreationSamples || now.Sub(r.createdAt[i]) > window) {
+		i++
+	}
+	r.createdAt = r.createdAt[i:]
+}
+
+// project projects the segment creation rate of the collection.
+func (r *segmentCreationRate) project(now time.Time, window time.Duration, threshold int64) SegmentCreationProjection {
+	r.expire(now, window)
+	projection := SegmentCreationProjection{
+		CreatedInWindow: len(r.createdAt),
+		Window:          window,
+		Threshold:       threshold,
+	}
+	if len(r.createdAt) == 0 || window <= 0 {
+		return projection
+	}
+	// the rate is projected over the whole window, so a short burst is not amplified into a huge rate,
+	// unless the samples are evicted by the count limit.
+	if len(r.createdAt) >= maxSegmentCreationSamples {
+		if span := now.Sub(r.createdAt[0]); span > 0 && span < window {
+			projection.Window = span
+		}
+	}
+	projection.SegmentsPerDay = float64(len(r.createdAt)) * float64(segmentCreationRateUnit) / float64(projection.Window)
+	if threshold > 0 {
+		projection.TimeToThreshold = time.Duration(float64(threshold) / projection.SegmentsPerDay * float64(segmentCreationRateUnit))
+	}
+	return projection
+}
+
+// ObserveSegmentCreated records a new segment of the collection is created and projects the creation rate of it.
+// Return true only if the projection crosses the alert threshold from below, so the alert is not repeated for every creation.
+func (m *StatsManager) ObserveSegmentCreated(collectionID int64) (SegmentCreationProjection, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	window := paramtable.Get().StreamingCfg.WALSegmentAssignCreationRateAlertWindow.GetAsDurationByParse()
+	threshold := paramtable.Get().StreamingCfg.WALSegmentAssignCreationRateAlertThreshold.GetAsInt64()
+	if _, ok := m.creationRates[collectionID]; !ok {
+		m.creationRates[collectionID] = newSegmentCreationRate()
+	}
+	rate := m.creationRates[collectionID]
+	rate.add(m.clock.Now(), window)
+	projection := rate.project(m.clock.Now(), window, threshold)
+	projection.CollectionID = collectionID
+
+	exceeded := projection.Exceeded()
+	crossed := exceeded && !rate.alerting
+	rate.alerting = exceeded
+	return projection, crossed
+}
+
+// GetSegmentCreationProjection returns the projection of the segment creation rate of the collection.
+func (m *StatsManager) GetSegmentCreationProjection(collectionID int64) SegmentCreationProjection {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	window := paramtable.Get().StreamingCfg.WALSegmentAssignCreationRateAlertWindow.GetAsDurationByParse()
+	threshold := paramtable.Get().StreamingCfg.WALSegmentAssignCreationRateAlertThreshold.GetAsInt64()
+	rate, ok := m.creationRates[collectionID]
+	if !ok {
+		return SegmentCreationProjection{CollectionID: collectionID, Window: window, Threshold: threshold}
+	}
+	projection := rate.project(m.clock.Now(), window, threshold)
+	projection.CollectionID = collectionID
+	return projection
+}
+
+// UnregisterSegmentCreation removes the segment creation samples of the collection.
+func (m *StatsManager) UnregisterSegmentCreation(collectionID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.creationRates, collectionID)
+}
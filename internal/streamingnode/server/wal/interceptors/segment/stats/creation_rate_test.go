@@ -0,0 +1,89 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestSegmentCreationRate(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.WALSegmentAssignCreationRateAlertThreshold.Key, "240")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignCreationRateAlertThreshold.Key)
+	params.Save(params.StreamingCfg.WALSegmentAssignCreationRateAlertWindow.Key, "1h")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignCreationRateAlertWindow.Key)
+
+	c := clock.NewFakeClock(time.Unix(1000, 0))
+	m := NewStatsManager(c)
+	projection := m.GetSegmentCreationProjection(1)
+	assert.Equal(t, SegmentCreationProjection{CollectionID: 1, Window: time.Hour, Threshold: 240}, projection)
+	assert.False(t, projection.Exceeded())
+
+	// 10 segments per hour is 240 segments per day, the threshold is not crossed.
+	for i := 0; i < 10; i++ {
+		_, crossed := m.ObserveSegmentCreated(1)
+		assert.False(t, crossed)
+	}
+	projection = m.GetSegmentCreationProjection(1)
+	assert.Equal(t, 10, projection.CreatedInWindow)
+	assert.Equal(t, float64(240), projection.SegmentsPerDay)
+	assert.Equal(t, 24*time.Hour, projection.TimeToThreshold)
+
+	// the burst crosses the threshold, the alert is reported only once.
+	projection, crossed := m.ObserveSegmentCreated(1)
+	assert.True(t, crossed)
+	assert.True(t, projection.Exceeded())
+	assert.Equal(t, int64(1), projection.CollectionID)
+	assert.Equal(t, float64(264), projection.SegmentsPerDay)
+	assert.Less(t, projection.TimeToThreshold, 24*time.Hour)
+	for i := 0; i < 10; i++ {
+		_, crossed := m.ObserveSegmentCreated(1)
+		assert.False(t, crossed)
+	}
+
+	// the other collection is tracked separately.
+	_, crossed = m.ObserveSegmentCreated(2)
+	assert.False(t, crossed)
+	assert.Equal(t, 1, m.GetSegmentCreationProjection(2).CreatedInWindow)
+
+	// the burst is expired out of the window, the alert is reported again if the next burst crosses it.
+	c.Advance(time.Hour + time.Second)
+	assert.Zero(t, m.GetSegmentCreationProjection(1).CreatedInWindow)
+	_, crossed = m.ObserveSegmentCreated(1)
+	assert.False(t, crossed)
+	for i := 0; i < 10; i++ {
+		_, crossed = m.ObserveSegmentCreated(1)
+	}
+	assert.True(t, crossed)
+
+	// the threshold 0 never alerts.
+	params.Save(params.StreamingCfg.WALSegmentAssignCreationRateAlertThreshold.Key, "0")
+	m.UnregisterSegmentCreation(1)
+	for i := 0; i < 100; i++ {
+		_, crossed = m.ObserveSegmentCreated(1)
+		assert.False(t, crossed)
+	}
+	assert.Zero(t, m.GetSegmentCreationProjection(1).TimeToThreshold)
+}
+
+func TestSegmentCreationRateCountLimit(t *testing.T) {
+	r := newSegmentCreationRate()
+	now := time.Unix(1000, 0)
+	// a synthetic burst over the count limit within a minute.
+	for i := 0; i < maxSegmentCreationSamples+100; i++ {
+		r.add(now.Add(time.Duration(i)*time.Minute/maxSegmentCreationSamples), time.Hour)
+	}
+	assert.Len(t, r.createdAt, maxSegmentCreationSamples)
+
+	// the rate is projected over the span of the kept samples instead of the whole window.
+	projection := r.project(now.Add(time.Minute), time.Hour, 10000)
+	assert.Equal(t, maxSegmentCreationSamples, projection.CreatedInWindow)
+	assert.Less(t, projection.Window, time.Minute)
+	assert.Greater(t, projection.SegmentsPerDay, float64(maxSegmentCreationSamples*24*60))
+	assert.True(t, projection.Exceeded())
+}
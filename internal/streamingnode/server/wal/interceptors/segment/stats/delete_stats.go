@@ -0,0 +1,69 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+// DeleteMetrics is the metrics of delete operation.
+type DeleteMetrics struct {
+	Rows       uint64
+	BinarySize uint64
+}
+
+// Collect collects other metrics.
+func (m *DeleteMetrics) Collect(other DeleteMetrics) {
+	m.Rows += other.Rows
+	m.BinarySize += other.BinarySize
+}
+
+// DeleteSegmentStats is the usage stats of a L0 segment, which only holds the deletes of a partition.
+// Unlike SegmentStats, there's no max binary size, the L0 segment is never sealed by size.
+// The stat is persisted into the same proto of SegmentStats, the inserted fields are reused to record the deletes.
+type DeleteSegmentStats struct {
+	Delete           DeleteMetrics
+	CreateTime       time.Time // created timestamp of this segment, it's not a tso, just a local time.
+	LastModifiedTime time.Time // LastModifiedTime is the last delete time of this segment, it's not a tso, just a local time.
+}
+
+// NewDeleteSegmentStatFromProto creates a new L0 segment assignment stat from proto.
+func NewDeleteSegmentStatFromProto(statProto *streamingpb.SegmentAssignmentStat) *DeleteSegmentStats {
+	if statProto == nil {
+		return nil
+	}
+	return &DeleteSegmentStats{
+		Delete: DeleteMetrics{
+			Rows:       statProto.InsertedRows,
+			BinarySize: statProto.InsertedBinarySize,
+		},
+		CreateTime:       time.Unix(statProto.CreateTimestamp, 0),
+		LastModifiedTime: time.Unix(statProto.LastModifiedTimestamp, 0),
+	}
+}
+
+// NewProtoFromDeleteSegmentStat creates a new proto from L0 segment assignment stat.
+func NewProtoFromDeleteSegmentStat(stat *DeleteSegmentStats) *streamingpb.SegmentAssignmentStat {
+	if stat == nil {
+		return nil
+	}
+	return &streamingpb.SegmentAssignmentStat{
+		InsertedRows:          stat.Delete.Rows,
+		InsertedBinarySize:    stat.Delete.BinarySize,
+		CreateTimestamp:       stat.CreateTime.Unix(),
+		LastModifiedTimestamp: stat.LastModifiedTime.Unix(),
+	}
+}
+
+// AllocDeletes records the deletes on current segment, now is the local time of the allocation.
+// The L0 segment has no capacity limit, so the deletes are always assigned.
+func (s *DeleteSegmentStats) AllocDeletes(m DeleteMetrics, now time.Time) {
+	s.Delete.Collect(m)
+	s.LastModifiedTime = now
+}
+
+// Copy copies the L0 segment stats.
+func (s *DeleteSegmentStats) Copy() *DeleteSegmentStats {
+	s2 := *s
+	return &s2
+}
@@ -0,0 +1,136 @@
+package stats
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+)
+
+// FlushPending is a manual flush of a collection that is in-flight on a pchannel.
+type FlushPending struct {
+	PChannel     string    `json:"pchannel"`
+	CollectionID int64     `json:"collection_id"`
+	FlushTs      uint64    `json:"flush_ts"`
+	StartedAt    time.Time `json:"started_at"`
+	ExpireAt     time.Time `json:"expire_at"`
+}
+
+// flushPendingKey is the key of the flush pending registry.
+type flushPendingKey struct {
+	pchannel     string
+	collectionID int64
+}
+
+// FlushPendingRegistry registers the in-flight manual flushes of the collections on current node.
+// The flush is pending from the fence of the segments until the manual flush message is appended into wal,
+// the upstream components consult it to avoid interleaving the DDL with the manual flush.
+// The flush that is never appended (e.g. the client gives up) is expired by its deadline.
+type FlushPendingRegistry struct {
+	clock clock.Clock
+
+	mu      sync.Mutex
+	flushes map[flushPendingKey]FlushPending
+}
+
+// NewFlushPendingRegistry creates a new flush pending registry.
+func NewFlushPendingRegistry(c clock.Clock) *FlushPendingRegistry {
+	return &FlushPendingRegistry{
+		clock:   c,
+		flushes: make(map[flushPendingKey]FlushPending),
+	}
+}
+
+// Start marks the manual flush of the collection at the flush ts is in-flight, it's expired after the timeout.
+// The pending flush with a greater flush ts is kept, only its deadline is extended.
+func (r *FlushPendingRegistry) Start(pchannel string, collectionID int64, flushTs uint64, timeout time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock.Now()
+	key := flushPendingKey{pchannel: pchannel, collectionID: collectionID}
+	flush, ok := r.flushes[key]
+	if !ok || r.isExpired(flush, now) {
+		flush = FlushPending{
+			PChannel:     pchannel,
+			CollectionID: collectionID,
+			StartedAt:    now,
+		}
+	}
+	flush.FlushTs = max(flush.FlushTs, flushTs)
+	flush.ExpireAt = now.Add(timeout)
+	r.flushes[key] = flush
+}
+
+// Clear clears the pending flush of the collection if its flush ts is not greater than the given one,
+// it's called when the manual flush message is appended into wal.
+func (r *FlushPendingRegistry) Clear(pchannel string, collectionID int64, flushTs uint64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := flushPendingKey{pchannel: pchannel, collectionID: collectionID}
+	flush, ok := r.flushes[key]
+	if !ok || flush.FlushTs > flushTs {
+		return false
+	}
+	delete(r.flushes, key)
+	return true
+}
+
+// Get returns the pending flush of the collection on the pchannel.
+func (r *FlushPendingRegistry) Get(pchannel string, collectionID int64) (FlushPending, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.expireLocked()
+	flush, ok := r.flushes[flushPendingKey{pchannel: pchannel, collectionID: collectionID}]
+	return flush, ok
+}
+
+// List returns all pending flushes on current node, sorted by collection id and pchannel.
+func (r *FlushPendingRegistry) List() []FlushPending {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.expireLocked()
+	flushes := make([]FlushPending, 0, len(r.flushes))
+	for _, flush := range r.flushes {
+		flushes = append(flushes, flush)
+	}
+	sort.Slice(flushes, func(i, j int) bool {
+		if flushes[i].CollectionID != flushes[j].CollectionID {
+			return flushes[i].CollectionID < flushes[j].CollectionID
+		}
+		return flushes[i].PChannel < flushes[j].PChannel
+	})
+	return flushes
+}
+
+// RemovePChannel removes all pending flushes of the pchannel,
+// it's called when the pchannel is not managed by current node anymore.
+func (r *FlushPendingRegistry) RemovePChannel(pchannel string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key := range r.flushes {
+		if key.pchannel == pchannel {
+			delete(r.flushes, key)
+		}
+	}
+}
+
+// expireLocked removes the expired pending flushes, must be called with the lock held.
+func (r *FlushPendingRegistry) expireLocked() {
+	now := r.clock.Now()
+	for key, flush := range r.flushes {
+		if r.isExpired(flush, now) {
+			delete(r.flushes, key)
+		}
+	}
+}
+
+// isExpired checks if the pending flush is expired.
+func (r *FlushPendingRegistry) isExpired(flush FlushPending, now time.Time) bool {
+	return !now.Before(flush.ExpireAt)
+}
@@ -0,0 +1,62 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+)
+
+func TestFlushPendingRegistry(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(1000, 0))
+	r := NewFlushPendingRegistry(c)
+
+	_, ok := r.Get("p1", 1)
+	assert.False(t, ok)
+
+	r.Start("p1", 1, 100, time.Minute)
+	r.Start("p2", 1, 200, time.Minute)
+	flush, ok := r.Get("p1", 1)
+	assert.True(t, ok)
+	assert.Equal(t, FlushPending{
+		PChannel:     "p1",
+		CollectionID: 1,
+		FlushTs:      100,
+		StartedAt:    time.Unix(1000, 0),
+		ExpireAt:     time.Unix(1060, 0),
+	}, flush)
+
+	// the greater flush ts is kept, the deadline is extended.
+	c.Advance(30 * time.Second)
+	r.Start("p1", 1, 50, time.Minute)
+	flush, _ = r.Get("p1", 1)
+	assert.Equal(t, uint64(100), flush.FlushTs)
+	assert.Equal(t, time.Unix(1000, 0), flush.StartedAt)
+	assert.Equal(t, time.Unix(1090, 0), flush.ExpireAt)
+
+	// the stale manual flush doesn't clear the newer pending flush.
+	assert.False(t, r.Clear("p1", 1, 99))
+	assert.True(t, r.Clear("p1", 1, 100))
+	assert.False(t, r.Clear("p1", 1, 100))
+	_, ok = r.Get("p1", 1)
+	assert.False(t, ok)
+
+	// the pending flush is expired by its deadline.
+	r.Start("p1", 2, 300, time.Minute)
+	assert.Len(t, r.List(), 2)
+	c.Advance(30 * time.Second)
+	flushes := r.List()
+	assert.Len(t, flushes, 1)
+	assert.Equal(t, int64(2), flushes[0].CollectionID)
+	c.Advance(time.Minute)
+	assert.Empty(t, r.List())
+
+	r.Start("p1", 1, 400, time.Minute)
+	r.Start("p2", 1, 400, time.Minute)
+	r.RemovePChannel("p1")
+	flushes = r.List()
+	assert.Len(t, flushes, 1)
+	assert.Equal(t, "p2", flushes[0].PChannel)
+}
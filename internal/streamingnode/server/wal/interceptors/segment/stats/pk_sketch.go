@@ -0,0 +1,228 @@
+package stats
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+const (
+	MinPKSketchPrecision = 4
+	MaxPKSketchPrecision = 16
+
+	// the encoding formats of the persisted sketch.
+	pkSketchFormatDense  byte = 1 // every register is encoded as one byte.
+	pkSketchFormatSparse byte = 2 // only the non-zero registers are encoded as (uint16 index, uint8 value).
+)
+
+var ErrInvalidPKSketch = errors.New("invalid pk sketch")
+
+// PKSketch is a HyperLogLog sketch of the primary key hashes of a segment.
+// It estimates the count of distinct primary keys with bounded memory, 2^precision bytes,
+// and a standard error of about 1.04/sqrt(2^precision).
+// It's safe for concurrent use, so it can be shared by the copies of the segment stats.
+type PKSketch struct {
+	mu        sync.Mutex
+	precision uint8
+	registers []uint8
+}
+
+// NewPKSketch creates a new empty sketch, the precision is clamped into [MinPKSketchPrecision, MaxPKSketchPrecision].
+func NewPKSketch(precision int) *PKSketch {
+	precision = min(max(precision, MinPKSketchPrecision), MaxPKSketchPrecision)
+	return &PKSketch{
+		precision: uint8(precision),
+		registers: make([]uint8, 1<<precision),
+	}
+}
+
+// newPKSketchFromConfig creates a new empty sketch with the configured precision.
+func newPKSketchFromConfig() *PKSketch {
+	return NewPKSketch(paramtable.Get().StreamingCfg.WALSegmentAssignPKSketchPrecision.GetAsInt())
+}
+
+// Precision returns the precision of the sketch.
+func (s *PKSketch) Precision() int {
+	return int(s.precision)
+}
+
+// Add adds the primary key hashes into the sketch.
+func (s *PKSketch) Add(pkHashes ...uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, pkHash := range pkHashes {
+		// the hashes given by the proxy may be poorly distributed (e.g. the hash of an int64 primary key is itself),
+		// so they're mixed again before being split into the register index and the rank.
+		x := mixPKHash(pkHash)
+		idx := x >> (64 - s.precision)
+		// the guard bit bounds the rank to 64-precision+1 even if the remaining bits are all zero.
+		rank := uint8(bits.LeadingZeros64(x<<s.precision|1<<(s.precision-1))) + 1
+		if rank > s.registers[idx] {
+			s.registers[idx] = rank
+		}
+	}
+}
+
+// Merge merges the other sketch into current sketch, the precision of the two sketches must be the same.
+func (s *PKSketch) Merge(other *PKSketch) error {
+	if s == other {
+		return nil
+	}
+	other.mu.Lock()
+	registers := append([]uint8(nil), other.registers...)
+	other.mu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(registers) != len(s.registers) {
+		return errors.Wrapf(ErrInvalidPKSketch, "merge sketches of different precisions %d and %d", s.precision, other.precision)
+	}
+	for i, r := range registers {
+		if r > s.registers[i] {
+			s.registers[i] = r
+		}
+	}
+	return nil
+}
+
+// Estimate returns the approximate count of the distinct primary keys added into the sketch.
+func (s *PKSketch) Estimate() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := float64(len(s.registers))
+	sum := 0.0
+	zeros := 0
+	for _, r := range s.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := pkSketchAlpha(len(s.registers)) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// the raw estimate is heavily biased at the small cardinality, use the linear counting instead.
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// Marshal encodes the sketch compactly, the sparse format is used if most of the registers are empty.
+func (s *PKSketch) Marshal() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nonZeros := 0
+	for _, r := range s.registers {
+		if r != 0 {
+			nonZeros++
+		}
+	}
+	if nonZeros*3 >= len(s.registers) {
+		data := make([]byte, 0, 2+len(s.registers))
+		data = append(data, pkSketchFormatDense, s.precision)
+		return append(data, s.registers...)
+	}
+	data := make([]byte, 0, 2+nonZeros*3)
+	data = append(data, pkSketchFormatSparse, s.precision)
+	for i, r := range s.registers {
+		if r != 0 {
+			data = binary.BigEndian.AppendUint16(data, uint16(i))
+			data = append(data, r)
+		}
+	}
+	return data
+}
+
+// UnmarshalPKSketch decodes the sketch encoded by Marshal.
+func UnmarshalPKSketch(data []byte) (*PKSketch, error) {
+	if len(data) < 2 {
+		return nil, errors.Wrapf(ErrInvalidPKSketch, "too short, length: %d", len(data))
+	}
+	format, precision := data[0], int(data[1])
+	if precision < MinPKSketchPrecision || precision > MaxPKSketchPrecision {
+		return nil, errors.Wrapf(ErrInvalidPKSketch, "precision %d out of range", precision)
+	}
+	s := NewPKSketch(precision)
+	maxRank := uint8(64 - precision + 1)
+	body := data[2:]
+	switch format {
+	case pkSketchFormatDense:
+		if len(body) != len(s.registers) {
+			return nil, errors.Wrapf(ErrInvalidPKSketch, "dense registers length %d mismatch with precision %d", len(body), precision)
+		}
+		copy(s.registers, body)
+	case pkSketchFormatSparse:
+		if len(body)%3 != 0 {
+			return nil, errors.Wrapf(ErrInvalidPKSketch, "sparse registers length %d is not aligned", len(body))
+		}
+		for i := 0; i < len(body); i += 3 {
+			idx := int(binary.BigEndian.Uint16(body[i:]))
+			if idx >= len(s.registers) {
+				return nil, errors.Wrapf(ErrInvalidPKSketch, "sparse register index %d out of range", idx)
+			}
+			s.registers[idx] = body[i+2]
+		}
+	default:
+		return nil, errors.Wrapf(ErrInvalidPKSketch, "unknown format %d", format)
+	}
+	for _, r := range s.registers {
+		if r > maxRank {
+			return nil, errors.Wrapf(ErrInvalidPKSketch, "register %d out of range", r)
+		}
+	}
+	return s, nil
+}
+
+// pkSketchAlpha returns the bias correction constant of the sketch with m registers.
+func pkSketchAlpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
+
+// mixPKHash is the finalizer of splitmix64, it spreads the bits of the hash uniformly.
+func mixPKHash(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// ObservePKHashes observes the primary key hashes of the insert appended into the growing segment,
+// the distinct primary key estimate of the segment is updated if it's enabled.
+// The segment may be already sealed and unregistered, the observation is ignored.
+func (m *StatsManager) ObservePKHashes(segmentID int64, pkHashes []uint64) {
+	if len(pkHashes) == 0 || !paramtable.Get().StreamingCfg.WALSegmentAssignPKSketchEnabled.GetAsBool() {
+		return
+	}
+	m.mu.Lock()
+	stat, ok := m.segmentStats[segmentID]
+	if !ok {
+		m.mu.Unlock()
+		return
+	}
+	if stat.PKSketch == nil {
+		stat.PKSketch = newPKSketchFromConfig()
+	}
+	sketch := stat.PKSketch
+	m.mu.Unlock()
+
+	// the sketch is guarded by its own lock, so the hashes are added without blocking the other segments.
+	sketch.Add(pkHashes...)
+}
@@ -0,0 +1,129 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestPKSketchAccuracy(t *testing.T) {
+	for _, precision := range []int{10, 12, 14} {
+		// the tolerance is 4 times of the standard error, the small cardinality is almost exact by the linear counting.
+		tolerance := 4 * 1.04 / math.Sqrt(float64(uint64(1)<<precision))
+		for _, cardinality := range []uint64{1, 10, 100, 1000, 5000, 10000, 50000, 200000} {
+			s := NewPKSketch(precision)
+			assert.Zero(t, s.Estimate())
+			// the int64 primary keys are sequential, and every key is inserted 3 times.
+			for round := 0; round < 3; round++ {
+				for pk := uint64(1); pk <= cardinality; pk++ {
+					s.Add(pk)
+				}
+			}
+			estimate := s.Estimate()
+			relativeError := math.Abs(float64(estimate)-float64(cardinality)) / float64(cardinality)
+			assert.LessOrEqual(t, relativeError, max(tolerance, 0.01), "precision: %d, cardinality: %d, estimate: %d", precision, cardinality, estimate)
+		}
+	}
+}
+
+func TestPKSketchMerge(t *testing.T) {
+	s1 := NewPKSketch(12)
+	s2 := NewPKSketch(12)
+	for pk := uint64(0); pk < 20000; pk++ {
+		s1.Add(pk)
+	}
+	// the two sketches overlap on the half of keys.
+	for pk := uint64(10000); pk < 30000; pk++ {
+		s2.Add(pk)
+	}
+	assert.NoError(t, s1.Merge(s2))
+	assert.InEpsilon(t, 30000, float64(s1.Estimate()), 0.07)
+	assert.NoError(t, s1.Merge(s1))
+
+	assert.ErrorIs(t, s1.Merge(NewPKSketch(10)), ErrInvalidPKSketch)
+}
+
+func TestPKSketchMarshal(t *testing.T) {
+	// the precision is clamped.
+	assert.Equal(t, MinPKSketchPrecision, NewPKSketch(0).Precision())
+	assert.Equal(t, MaxPKSketchPrecision, NewPKSketch(64).Precision())
+
+	// the sparse format is used for the small segment.
+	s := NewPKSketch(12)
+	s.Add(1, 2, 3, 3)
+	data := s.Marshal()
+	assert.Equal(t, pkSketchFormatSparse, data[0])
+	assert.Less(t, len(data), 32)
+	s2, err := UnmarshalPKSketch(data)
+	assert.NoError(t, err)
+	assert.Equal(t, s.registers, s2.registers)
+	assert.Equal(t, uint64(3), s2.Estimate())
+
+	// the dense format is used for the large segment.
+	for pk := uint64(0); pk < 100000; pk++ {
+		s.Add(pk)
+	}
+	data = s.Marshal()
+	assert.Equal(t, pkSketchFormatDense, data[0])
+	assert.Equal(t, 2+4096, len(data))
+	s2, err = UnmarshalPKSketch(data)
+	assert.NoError(t, err)
+	assert.Equal(t, s.Estimate(), s2.Estimate())
+
+	for _, broken := range [][]byte{
+		nil,
+		{pkSketchFormatDense},
+		{pkSketchFormatDense, 3},
+		{pkSketchFormatDense, 12, 1, 2},
+		{pkSketchFormatSparse, 12, 0, 1},
+		{pkSketchFormatSparse, 12, 0xff, 0xff, 1},
+		{pkSketchFormatSparse, 12, 0, 1, 64},
+		{9, 12},
+	} {
+		_, err := UnmarshalPKSketch(broken)
+		assert.ErrorIs(t, err, ErrInvalidPKSketch)
+	}
+}
+
+func TestObservePKHashes(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+
+	m := NewStatsManager(clock.NewFakeClock(time.Unix(1000, 0)))
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "p1", VChannel: "v1", CollectionID: 1, PartitionID: 1, SegmentID: 1}, 1, createSegmentStats(0, 0, 1000))
+
+	// the estimate is disabled by default.
+	m.ObservePKHashes(1, []uint64{1, 2, 3})
+	assert.Nil(t, m.GetStatsOfSegment(1).PKSketch)
+
+	params.Save(params.StreamingCfg.WALSegmentAssignPKSketchEnabled.Key, "true")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignPKSketchEnabled.Key)
+	params.Save(params.StreamingCfg.WALSegmentAssignPKSketchPrecision.Key, "10")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignPKSketchPrecision.Key)
+
+	// the insert without primary key hashes is skipped.
+	m.ObservePKHashes(1, nil)
+	assert.Nil(t, m.GetStatsOfSegment(1).PKSketch)
+	m.ObservePKHashes(1, []uint64{1, 2, 3})
+	m.ObservePKHashes(1, []uint64{3, 4})
+	stat := m.GetStatsOfSegment(1)
+	assert.Equal(t, 10, stat.PKSketch.Precision())
+	assert.Equal(t, uint64(4), stat.PKSketch.Estimate())
+
+	// the sketch is persisted in the segment stat.
+	recovered := NewSegmentStatFromProto(NewProtoFromSegmentStat(stat))
+	assert.Equal(t, uint64(4), recovered.PKSketch.Estimate())
+	statProto := NewProtoFromSegmentStat(stat)
+	statProto.PkSketch = []byte{9}
+	assert.Nil(t, NewSegmentStatFromProto(statProto).PKSketch)
+
+	// the sketch is kept by the sealed stats, the observation of the unregistered segment is ignored.
+	sealed := m.UnregisterSealedSegment(1)
+	m.ObservePKHashes(1, []uint64{5})
+	assert.Equal(t, uint64(4), sealed.PKSketch.Estimate())
+}
@@ -0,0 +1,97 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+const (
+	// recentlyRemovedSegmentWindow is the window that the removed segment is kept to classify the late events.
+	recentlyRemovedSegmentWindow = 10 * time.Minute
+	// maxRecentlyRemovedSegments is the max count of the removed segments kept on a pchannel.
+	maxRecentlyRemovedSegments = 4096
+)
+
+// LateEvent is the event of a segment that arrives after the segment is flushed or discarded.
+type LateEvent string
+
+const (
+	LateEventAck  LateEvent = "ack"
+	LateEventSync LateEvent = "sync"
+)
+
+// newRemovedSegments creates a new removed segments of a pchannel.
+func newRemovedSegments() *removedSegments {
+	return &removedSegments{
+		removedAt: make(map[int64]time.Time),
+	}
+}
+
+// removedSegments is the exact set of the segments recently flushed or discarded on a pchannel.
+// The set is bounded by both the window and the count, the oldest one is evicted first.
+type removedSegments struct {
+	removedAt map[int64]time.Time
+	order     []int64 // the segment ids in the order of removal.
+}
+
+// add adds the removed segment into the set and evicts the expired ones.
+func (r *removedSegments) add(segmentID int64, now time.Time) {
+	if _, ok := r.removedAt[segmentID]; !ok {
+		r.order = append(r.order, segmentID)
+	}
+	r.removedAt[segmentID] = now
+	r.expire(now)
+}
+
+// contains checks if the segment is removed within the window.
+func (r *removedSegments) contains(segmentID int64, now time.Time) bool {
+	removedAt, ok := r.removedAt[segmentID]
+	return ok && now.Sub(removedAt) <= recentlyRemovedSegmentWindow
+}
+
+// expire evicts the segments out of the window or the count limit.
+func (r *removedSegments) expire(now time.Time) {
+	for len(r.order) > 0 {
+		oldest := r.order[0]
+		if len(r.order) <= maxRecentlyRemovedSegments && now.Sub(r.removedAt[oldest]) <= recentlyRemovedSegmentWindow {
+			return
+		}
+		delete(r.removedAt, oldest)
+		r.order = r.order[1:]
+	}
+}
+
+// ObserveSegmentRemoved records the segment is flushed or discarded on the pchannel,
+// so the acks and sync updates of it that arrive later are classified as late events.
+func (m *StatsManager) ObserveSegmentRemoved(pchannel string, segmentID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.removed[pchannel]; !ok {
+		m.removed[pchannel] = newRemovedSegments()
+	}
+	m.removed[pchannel].add(segmentID, m.clock.Now())
+}
+
+// ObserveLateEvent checks if the segment is recently flushed or discarded, the late event is counted if so.
+// The caller should never apply the late event, the segment is not alive anymore.
+func (m *StatsManager) ObserveLateEvent(segmentID int64, event LateEvent) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.observeLateEvent(segmentID, event)
+}
+
+// observeLateEvent checks and counts the late event, the lock should be held.
+func (m *StatsManager) observeLateEvent(segmentID int64, event LateEvent) bool {
+	now := m.clock.Now()
+	for pchannel, removed := range m.removed {
+		if removed.contains(segmentID, now) {
+			metrics.WALSegmentLateEventTotal.WithLabelValues(paramtable.GetStringNodeID(), pchannel, string(event)).Inc()
+			return true
+		}
+	}
+	return false
+}
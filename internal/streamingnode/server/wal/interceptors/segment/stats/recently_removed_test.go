@@ -0,0 +1,61 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestRecentlyRemovedSegments(t *testing.T) {
+	paramtable.Init()
+	c := clock.NewFakeClock(time.Unix(1000, 0))
+	m := NewStatsManager(c)
+	lateAcks := func(pchannel string) float64 {
+		return testutil.ToFloat64(metrics.WALSegmentLateEventTotal.WithLabelValues(paramtable.GetStringNodeID(), pchannel, string(LateEventAck)))
+	}
+	lateSyncs := func(pchannel string) float64 {
+		return testutil.ToFloat64(metrics.WALSegmentLateEventTotal.WithLabelValues(paramtable.GetStringNodeID(), pchannel, string(LateEventSync)))
+	}
+
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "p_removed", VChannel: "v1", CollectionID: 1, PartitionID: 1, SegmentID: 1}, 1, createSegmentStats(100, 100, 1000))
+	m.UnregisterSealedSegment(1)
+	assert.False(t, m.ObserveLateEvent(1, LateEventAck))
+
+	// the late events of the removed segment are classified and counted.
+	m.ObserveSegmentRemoved("p_removed", 1)
+	assert.True(t, m.ObserveLateEvent(1, LateEventAck))
+	assert.Equal(t, float64(1), lateAcks("p_removed"))
+	err := m.UpdateOnSync(1, SyncOperationMetrics{BinLogCounterIncr: 1, BinLogFileCounterIncr: 1})
+	assert.ErrorIs(t, err, ErrSegmentRecentlyRemoved)
+	assert.NotErrorIs(t, err, ErrSegmentNotFound)
+	assert.Equal(t, float64(1), lateSyncs("p_removed"))
+
+	// the unknown segment is still rejected as not found.
+	err = m.UpdateOnSync(2, SyncOperationMetrics{BinLogCounterIncr: 1})
+	assert.ErrorIs(t, err, ErrSegmentNotFound)
+	assert.False(t, m.ObserveLateEvent(2, LateEventAck))
+
+	// the removed segment is forgotten after the window.
+	c.Advance(recentlyRemovedSegmentWindow + time.Second)
+	assert.False(t, m.ObserveLateEvent(1, LateEventAck))
+	assert.ErrorIs(t, m.UpdateOnSync(1, SyncOperationMetrics{BinLogCounterIncr: 1}), ErrSegmentNotFound)
+	assert.Equal(t, float64(1), lateAcks("p_removed"))
+
+	// the oldest removed segment is evicted if the count limit is exceeded.
+	for segmentID := int64(100); segmentID < 100+maxRecentlyRemovedSegments+1; segmentID++ {
+		m.ObserveSegmentRemoved("p_removed", segmentID)
+	}
+	assert.False(t, m.ObserveLateEvent(100, LateEventAck))
+	assert.True(t, m.ObserveLateEvent(101, LateEventAck))
+	assert.Len(t, m.removed["p_removed"].removedAt, maxRecentlyRemovedSegments)
+
+	// the removed segments are cleared with the pchannel.
+	m.UnregisterAllStatsOnPChannel("p_removed")
+	assert.False(t, m.ObserveLateEvent(101, LateEventAck))
+}
@@ -0,0 +1,37 @@
+package stats
+
+import "time"
+
+// SealWaitReason is the reason why a segment in the seal queue is not flushed yet.
+type SealWaitReason string
+
+const (
+	SealWaitReasonUnackedAssignments SealWaitReason = "unacked_assignments" // the assignments of the segment are not acked yet.
+	SealWaitReasonOpenTxns           SealWaitReason = "open_txns"           // the txns writing into the segment are not done yet.
+	SealWaitReasonPersistBacklog     SealWaitReason = "persist_backlog"     // the seal is not persisted yet, it's delayed by the catalog write budget.
+	SealWaitReasonFence              SealWaitReason = "fence"               // the segment is sealed by a fence (e.g. manual flush) in progress.
+)
+
+// SealWaitState is the detailed state of the segments waiting for seal of a pchannel.
+type SealWaitState struct {
+	Waiting int // the count of the segments waiting for seal, including the ones being sealed right now.
+	Sealing int // the count of the segments taken out of the queue by the seal operation in progress.
+	Ready   int // the count of the queued segments not blocked by the acks, txns or persistence backlog, they're flushed by the next wake.
+	// Reasons is the count of the queued segments by the blocking reason,
+	// a segment may be blocked by more than one reason, so the sum may be greater than the queued segments.
+	Reasons map[SealWaitReason]int
+	// OldestBlockedAge is the age of the oldest blocked segment since its seal decision, 0 if no segment is blocked.
+	OldestBlockedAge time.Duration
+}
+
+// IsNoWait returns whether there's no segment wait for seal.
+func (s SealWaitState) IsNoWait() bool {
+	return s.Waiting == 0
+}
+
+// IsWakeUseful returns whether a wake of the seal queue may make progress.
+// The acks and the persistence backlog are only observed by the next wake,
+// but the segments only blocked by the open txns are released by the txn done, so the wake for them is useless.
+func (s SealWaitState) IsWakeUseful() bool {
+	return s.Ready > 0 || s.Reasons[SealWaitReasonUnackedAssignments] > 0 || s.Reasons[SealWaitReasonPersistBacklog] > 0
+}
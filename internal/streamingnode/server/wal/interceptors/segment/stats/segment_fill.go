@@ -0,0 +1,159 @@
+package stats
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/syncutil"
+)
+
+// SegmentFillUpdate is the fill of a growing segment delivered to the subscriber, such as the flusher.
+type SegmentFillUpdate struct {
+	Belongs       SegmentBelongs
+	Insert        InsertMetrics // the inserted metrics of the segment when the update happens.
+	MaxBinarySize uint64
+	FillRatio     float64   // the ratio of the inserted binary size to the max binary size, in [0, 1].
+	UpdatedAt     time.Time // the time of the update on the clock of stats manager.
+}
+
+// newSegmentFillNotifier creates a new segment fill notifier.
+func newSegmentFillNotifier() *SegmentFillNotifier {
+	return &SegmentFillNotifier{
+		subscribers:  make(map[*SegmentFillSubscriber]struct{}),
+		lastNotified: make(map[int64]time.Time),
+	}
+}
+
+// SegmentFillNotifier delivers the fill updates of the growing segments above the threshold to the subscribers.
+// The updates of a segment are rate-limited by the interval, and stopped after the segment is sealed.
+// It's written by the stats manager with its lock held, so the updates of a segment are never delivered after it's unregistered.
+type SegmentFillNotifier struct {
+	mu           sync.Mutex
+	subscribers  map[*SegmentFillSubscriber]struct{}
+	lastNotified map[int64]time.Time // map[SegmentID]time, the time of the last update of the segment.
+}
+
+// Subscribe subscribes the fill updates of the growing segments on the pchannel.
+// The subscriber should be closed after use.
+func (n *SegmentFillNotifier) Subscribe(pchannel string) *SegmentFillSubscriber {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	s := &SegmentFillSubscriber{
+		notifier: n,
+		pchannel: pchannel,
+		cond:     syncutil.NewContextCond(&sync.Mutex{}),
+		pending:  make(map[int64]SegmentFillUpdate),
+	}
+	n.subscribers[s] = struct{}{}
+	return s
+}
+
+// notify notifies the fill of the growing segment if it's above the threshold and not notified within the interval.
+func (n *SegmentFillNotifier) notify(belongs SegmentBelongs, stat *SegmentStats, now time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	// the notifier is on the hot path of insert, nothing is computed if no one subscribes.
+	if len(n.subscribers) == 0 || stat.MaxBinarySize == 0 {
+		return
+	}
+	params := paramtable.Get().StreamingCfg
+	fillRatio := min(float64(stat.Insert.BinarySize)/float64(stat.MaxBinarySize), 1)
+	if fillRatio < params.WALSegmentAssignFillNotifyThreshold.GetAsFloat() {
+		return
+	}
+	if last, ok := n.lastNotified[belongs.SegmentID]; ok && now.Sub(last) < params.WALSegmentAssignFillNotifyInterval.GetAsDurationByParse() {
+		return
+	}
+	n.lastNotified[belongs.SegmentID] = now
+	update := SegmentFillUpdate{
+		Belongs:       belongs,
+		Insert:        stat.Insert,
+		MaxBinarySize: stat.MaxBinarySize,
+		FillRatio:     fillRatio,
+		UpdatedAt:     now,
+	}
+	for s := range n.subscribers {
+		if s.pchannel == belongs.PChannel {
+			s.add(update)
+		}
+	}
+}
+
+// remove stops the updates of the segment, the undelivered update of it is dropped.
+func (n *SegmentFillNotifier) remove(segmentID int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.lastNotified, segmentID)
+	for s := range n.subscribers {
+		s.remove(segmentID)
+	}
+}
+
+// unsubscribe removes the subscriber from the notifier.
+func (n *SegmentFillNotifier) unsubscribe(s *SegmentFillSubscriber) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.subscribers, s)
+}
+
+// SegmentFillSubscriber is the subscriber of the fill updates of the growing segments on a pchannel.
+// Only the latest undelivered update of every segment is kept, so a slow subscriber never blocks the insert.
+type SegmentFillSubscriber struct {
+	notifier *SegmentFillNotifier
+	pchannel string
+	cond     *syncutil.ContextCond
+	pending  map[int64]SegmentFillUpdate // map[SegmentID]SegmentFillUpdate
+}
+
+// add adds the update of the segment and notifies the waiter.
+func (s *SegmentFillSubscriber) add(update SegmentFillUpdate) {
+	s.cond.LockAndBroadcast()
+	s.pending[update.Belongs.SegmentID] = update
+	s.cond.L.Unlock()
+}
+
+// remove removes the undelivered update of the segment.
+func (s *SegmentFillSubscriber) remove(segmentID int64) {
+	s.cond.L.Lock()
+	delete(s.pending, segmentID)
+	s.cond.L.Unlock()
+}
+
+// WaitChan returns a channel that is closed when there's undelivered update.
+func (s *SegmentFillSubscriber) WaitChan() <-chan struct{} {
+	s.cond.L.Lock()
+	if len(s.pending) > 0 {
+		s.cond.L.Unlock()
+		ch := make(chan struct{})
+		close(ch)
+		return ch
+	}
+	return s.cond.WaitChan()
+}
+
+// Get gets the undelivered updates in the order of segment id.
+func (s *SegmentFillSubscriber) Get() []SegmentFillUpdate {
+	s.cond.L.Lock()
+	pending := s.pending
+	s.pending = make(map[int64]SegmentFillUpdate)
+	s.cond.L.Unlock()
+
+	updates := make([]SegmentFillUpdate, 0, len(pending))
+	for _, update := range pending {
+		updates = append(updates, update)
+	}
+	sort.Slice(updates, func(i, j int) bool {
+		return updates[i].Belongs.SegmentID < updates[j].Belongs.SegmentID
+	})
+	return updates
+}
+
+// Close closes the subscriber, no more update is delivered.
+func (s *SegmentFillSubscriber) Close() {
+	s.notifier.unsubscribe(s)
+}
@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestSegmentFillNotifier(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.WALSegmentAssignFillNotifyThreshold.Key, "0.5")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignFillNotifyThreshold.Key)
+	params.Save(params.StreamingCfg.WALSegmentAssignFillNotifyInterval.Key, "1s")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignFillNotifyInterval.Key)
+
+	c := clock.NewFakeClock(time.Now())
+	m := NewStatsManager(c)
+	belongs := SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: 3}
+	m.RegisterNewGrowingSegment(belongs, 3, createSegmentStats(0, 0, 1000))
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel2", VChannel: "vchannel2", CollectionID: 1, PartitionID: 2, SegmentID: 4}, 4, createSegmentStats(0, 0, 1000))
+
+	s := m.FillNotifier().Subscribe("pchannel")
+	defer s.Close()
+	shouldBlock(t, s.WaitChan())
+
+	// the segment below the threshold is not notified.
+	assert.NoError(t, m.AllocRows(3, InsertMetrics{Rows: 1, BinarySize: 400}))
+	shouldBlock(t, s.WaitChan())
+
+	// the segment above the threshold is notified.
+	assert.NoError(t, m.AllocRows(3, InsertMetrics{Rows: 1, BinarySize: 200}))
+	<-s.WaitChan()
+	updates := s.Get()
+	assert.Len(t, updates, 1)
+	assert.Equal(t, belongs, updates[0].Belongs)
+	assert.Equal(t, uint64(600), updates[0].Insert.BinarySize)
+	assert.Equal(t, uint64(1000), updates[0].MaxBinarySize)
+	assert.InDelta(t, 0.6, updates[0].FillRatio, 1e-9)
+	assert.Equal(t, c.Now(), updates[0].UpdatedAt)
+	assert.Empty(t, s.Get())
+
+	// the updates within the interval are rate-limited.
+	assert.NoError(t, m.AllocRows(3, InsertMetrics{Rows: 1, BinarySize: 100}))
+	c.Advance(500 * time.Millisecond)
+	assert.NoError(t, m.AllocRows(3, InsertMetrics{Rows: 1, BinarySize: 10}))
+	shouldBlock(t, s.WaitChan())
+
+	// the update after the interval is delivered with the latest fill.
+	c.Advance(500 * time.Millisecond)
+	assert.NoError(t, m.AllocRows(3, InsertMetrics{Rows: 1, BinarySize: 10}))
+	<-s.WaitChan()
+	updates = s.Get()
+	assert.Len(t, updates, 1)
+	assert.Equal(t, uint64(720), updates[0].Insert.BinarySize)
+
+	// the segment of other pchannel is not delivered.
+	assert.NoError(t, m.AllocRows(4, InsertMetrics{Rows: 1, BinarySize: 900}))
+	shouldBlock(t, s.WaitChan())
+
+	// the undelivered update is dropped after the segment is sealed, and no update is delivered anymore.
+	c.Advance(time.Second)
+	assert.NoError(t, m.AllocRows(3, InsertMetrics{Rows: 1, BinarySize: 10}))
+	m.UnregisterSealedSegment(3)
+	shouldBlock(t, s.WaitChan())
+	assert.Empty(t, s.Get())
+	assert.NotContains(t, m.fillNotifier.lastNotified, int64(3))
+
+	// the closed subscriber is never notified.
+	m.RegisterNewGrowingSegment(belongs, 3, createSegmentStats(0, 0, 1000))
+	s.Close()
+	assert.NoError(t, m.AllocRows(3, InsertMetrics{Rows: 1, BinarySize: 900}))
+	assert.Empty(t, s.Get())
+	assert.Empty(t, m.fillNotifier.subscribers)
+}
@@ -0,0 +1,146 @@
+package stats
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+// SegmentStateIndex indexes the assignment state of the segments on current node by segment id.
+// The index is written by the segment assignment when the state of segment is committed,
+// and read by the consume side (such as flusher) on its hot path, so the read is lock-free.
+// The writes are serialized, so the flush completion never resurrects the entry of the removed segment.
+type SegmentStateIndex struct {
+	mu     sync.Mutex
+	states *typeutil.ConcurrentMap[int64, segmentStateEntry]
+}
+
+// segmentStateEntry is the entry of the segment state index.
+type segmentStateEntry struct {
+	pchannel       string
+	state          streamingpb.SegmentAssignmentState
+	flushCompleted bool // the flush of the sealed segment is completed by the flusher, but the meta is not removed yet.
+}
+
+// SealedUnflushedSegments is the sealed segments of a pchannel whose flush completion is not observed yet.
+type SealedUnflushedSegments struct {
+	PChannel   string
+	SegmentIDs []int64
+}
+
+// NewSegmentStateIndex creates a new segment state index.
+func NewSegmentStateIndex() *SegmentStateIndex {
+	return &SegmentStateIndex{
+		states: typeutil.NewConcurrentMap[int64, segmentStateEntry](),
+	}
+}
+
+// SegmentState returns the assignment state of the segment,
+// return false if the segment is not assigned on current node or already flushed.
+func (i *SegmentStateIndex) SegmentState(segmentID int64) (streamingpb.SegmentAssignmentState, bool) {
+	entry, ok := i.states.Get(segmentID)
+	if !ok {
+		return streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_UNKNOWN, false
+	}
+	return entry.state, true
+}
+
+// SegmentStateOfPChannel returns the assignment state of the segment on the pchannel,
+// return false if the segment is not assigned on the pchannel or already flushed.
+func (i *SegmentStateIndex) SegmentStateOfPChannel(pchannel string, segmentID int64) (streamingpb.SegmentAssignmentState, bool) {
+	entry, ok := i.states.Get(segmentID)
+	if !ok || entry.pchannel != pchannel {
+		return streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_UNKNOWN, false
+	}
+	return entry.state, true
+}
+
+// Update updates the assignment state of the segment, the flushed segment is removed from the index.
+// The flush completion of the segment is kept if the state is not changed.
+func (i *SegmentStateIndex) Update(pchannel string, segmentID int64, state streamingpb.SegmentAssignmentState) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if state == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED {
+		i.states.Remove(segmentID)
+		return
+	}
+	entry := segmentStateEntry{pchannel: pchannel, state: state}
+	if old, ok := i.states.Get(segmentID); ok && old.state == state {
+		entry.flushCompleted = old.flushCompleted
+	}
+	i.states.Insert(segmentID, entry)
+}
+
+// ObserveFlushCompleted records the flush of the sealed segment is completed by the flusher,
+// so the segment is not reported as sealed-but-unflushed anymore even if its meta is not removed yet.
+// Return false if the segment is not a sealed segment on current node.
+func (i *SegmentStateIndex) ObserveFlushCompleted(segmentID int64) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	entry, ok := i.states.Get(segmentID)
+	if !ok || entry.state != streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED {
+		return false
+	}
+	entry.flushCompleted = true
+	i.states.Insert(segmentID, entry)
+	return true
+}
+
+// SealedUnflushedSegmentsOfPChannel returns the sorted sealed segments of the pchannel whose flush completion is not observed yet.
+// The set is derived from the sealed metas, so it's recovered from the catalog after the restart.
+func (i *SegmentStateIndex) SealedUnflushedSegmentsOfPChannel(pchannel string) []int64 {
+	segmentIDs := make([]int64, 0)
+	i.states.Range(func(segmentID int64, entry segmentStateEntry) bool {
+		if entry.pchannel == pchannel && entry.isSealedUnflushed() {
+			segmentIDs = append(segmentIDs, segmentID)
+		}
+		return true
+	})
+	sort.Slice(segmentIDs, func(a, b int) bool { return segmentIDs[a] < segmentIDs[b] })
+	return segmentIDs
+}
+
+// ListSealedUnflushedSegments returns the sealed segments whose flush completion is not observed yet of all pchannels on current node,
+// sorted by pchannel and segment id.
+func (i *SegmentStateIndex) ListSealedUnflushedSegments() []SealedUnflushedSegments {
+	segments := make(map[string][]int64)
+	i.states.Range(func(segmentID int64, entry segmentStateEntry) bool {
+		if entry.isSealedUnflushed() {
+			segments[entry.pchannel] = append(segments[entry.pchannel], segmentID)
+		}
+		return true
+	})
+	result := make([]SealedUnflushedSegments, 0, len(segments))
+	for pchannel, segmentIDs := range segments {
+		sort.Slice(segmentIDs, func(a, b int) bool { return segmentIDs[a] < segmentIDs[b] })
+		result = append(result, SealedUnflushedSegments{PChannel: pchannel, SegmentIDs: segmentIDs})
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a].PChannel < result[b].PChannel })
+	return result
+}
+
+// isSealedUnflushed returns whether the segment is sealed and its flush completion is not observed yet.
+func (e segmentStateEntry) isSealedUnflushed() bool {
+	return e.state == streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED && !e.flushCompleted
+}
+
+// RemovePChannel removes all segments of the pchannel from the index,
+// it's called when the pchannel is not managed by current node anymore.
+func (i *SegmentStateIndex) RemovePChannel(pchannel string) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	removed := 0
+	i.states.Range(func(segmentID int64, entry segmentStateEntry) bool {
+		if entry.pchannel == pchannel {
+			i.states.Remove(segmentID)
+			removed++
+		}
+		return true
+	})
+	return removed
+}
@@ -0,0 +1,126 @@
+package stats
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
+)
+
+func TestSegmentStateIndex(t *testing.T) {
+	idx := NewSegmentStateIndex()
+	_, ok := idx.SegmentState(1)
+	assert.False(t, ok)
+
+	idx.Update("p1", 1, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING)
+	state, ok := idx.SegmentState(1)
+	assert.True(t, ok)
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING, state)
+
+	idx.Update("p1", 1, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED)
+	state, ok = idx.SegmentState(1)
+	assert.True(t, ok)
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED, state)
+
+	// the flushed segment is removed from the index.
+	idx.Update("p1", 1, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED)
+	_, ok = idx.SegmentState(1)
+	assert.False(t, ok)
+
+	// the segments of the closed pchannel are removed from the index.
+	idx.Update("p1", 2, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING)
+	idx.Update("p1", 3, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED)
+	idx.Update("p2", 4, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING)
+	_, ok = idx.SegmentStateOfPChannel("p2", 2)
+	assert.False(t, ok)
+	state, ok = idx.SegmentStateOfPChannel("p1", 2)
+	assert.True(t, ok)
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_PENDING, state)
+	assert.Equal(t, 2, idx.RemovePChannel("p1"))
+	_, ok = idx.SegmentState(2)
+	assert.False(t, ok)
+	_, ok = idx.SegmentState(3)
+	assert.False(t, ok)
+	_, ok = idx.SegmentState(4)
+	assert.True(t, ok)
+}
+
+func TestSealedUnflushedSegments(t *testing.T) {
+	idx := NewSegmentStateIndex()
+	idx.Update("p1", 1, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING)
+	idx.Update("p1", 3, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED)
+	idx.Update("p1", 2, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED)
+	idx.Update("p2", 4, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED)
+	assert.Equal(t, []int64{2, 3}, idx.SealedUnflushedSegmentsOfPChannel("p1"))
+	assert.Equal(t, []SealedUnflushedSegments{
+		{PChannel: "p1", SegmentIDs: []int64{2, 3}},
+		{PChannel: "p2", SegmentIDs: []int64{4}},
+	}, idx.ListSealedUnflushedSegments())
+
+	// the growing segment is never flushed, and the segment is sealed into the set.
+	assert.False(t, idx.ObserveFlushCompleted(1))
+	idx.Update("p1", 1, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED)
+	assert.Equal(t, []int64{1, 2, 3}, idx.SealedUnflushedSegmentsOfPChannel("p1"))
+
+	// the segment leaves the set when the flush is completed, even if the meta is still present.
+	assert.True(t, idx.ObserveFlushCompleted(2))
+	assert.Equal(t, []int64{1, 3}, idx.SealedUnflushedSegmentsOfPChannel("p1"))
+	idx.Update("p1", 2, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED)
+	assert.Equal(t, []int64{1, 3}, idx.SealedUnflushedSegmentsOfPChannel("p1"))
+	state, ok := idx.SegmentState(2)
+	assert.True(t, ok)
+	assert.Equal(t, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED, state)
+
+	// the segment leaves the set when the meta is removed, the late flush completion never brings it back.
+	idx.Update("p1", 3, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_FLUSHED)
+	assert.False(t, idx.ObserveFlushCompleted(3))
+	assert.Equal(t, []int64{1}, idx.SealedUnflushedSegmentsOfPChannel("p1"))
+	_, ok = idx.SegmentState(3)
+	assert.False(t, ok)
+
+	idx.RemovePChannel("p1")
+	assert.Empty(t, idx.SealedUnflushedSegmentsOfPChannel("p1"))
+	assert.Equal(t, []SealedUnflushedSegments{{PChannel: "p2", SegmentIDs: []int64{4}}}, idx.ListSealedUnflushedSegments())
+}
+
+// BenchmarkSegmentStateUnderConcurrentSeal benchmarks the state query while the segments are sealed concurrently.
+func BenchmarkSegmentStateUnderConcurrentSeal(b *testing.B) {
+	const segmentNum = 10000
+	idx := NewSegmentStateIndex()
+	for i := int64(0); i < segmentNum; i++ {
+		idx.Update("p1", i, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING)
+	}
+
+	// the sealer keeps transferring the segments between growing and sealed until the benchmark is done.
+	stop := make(chan struct{})
+	wg := sync.WaitGroup{}
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int64) {
+			defer wg.Done()
+			for i := w; ; i = (i + 4) % segmentNum {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				idx.Update("p1", i, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_SEALED)
+				idx.Update("p1", i, streamingpb.SegmentAssignmentState_SEGMENT_ASSIGNMENT_STATE_GROWING)
+			}
+		}(int64(w))
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := int64(0)
+		for pb.Next() {
+			idx.SegmentState(i % segmentNum)
+			i++
+		}
+	})
+	b.StopTimer()
+	close(stop)
+	wg.Wait()
+}
@@ -17,14 +17,16 @@ func NewSealSignalNotifier() *SealSignalNotifier {
 
 // SealSignalNotifier is a notifier for seal signal.
 type SealSignalNotifier struct {
-	cond   *syncutil.ContextCond
-	signal typeutil.Set[SegmentBelongs]
+	cond     *syncutil.ContextCond
+	signal   typeutil.Set[SegmentBelongs]
+	triggers int // the count of AddAndNotify since last Get.
 }
 
 // AddAndNotify adds a signal and notifies the waiter.
 func (n *SealSignalNotifier) AddAndNotify(belongs SegmentBelongs) {
 	n.cond.LockAndBroadcast()
 	n.signal.Insert(belongs)
+	n.triggers++
 	n.cond.L.Unlock()
 }
 
@@ -41,9 +43,16 @@ func (n *SealSignalNotifier) WaitChan() <-chan struct{} {
 
 // Get gets the signal.
 func (n *SealSignalNotifier) Get() typeutil.Set[SegmentBelongs] {
+	signal, _ := n.GetWithTriggers()
+	return signal
+}
+
+// GetWithTriggers gets the signal and the count of triggers that produce the signal.
+func (n *SealSignalNotifier) GetWithTriggers() (typeutil.Set[SegmentBelongs], int) {
 	n.cond.L.Lock()
-	signal := n.signal
+	signal, triggers := n.signal, n.triggers
 	n.signal = typeutil.NewSet[SegmentBelongs]()
+	n.triggers = 0
 	n.cond.L.Unlock()
-	return signal
+	return signal, triggers
 }
@@ -0,0 +1,133 @@
+package stats
+
+import (
+	"hash/fnv"
+	"sort"
+	"sync"
+)
+
+const (
+	sourceStatsShardNum = 16
+
+	// SourceOther is the source that the sources out of the bounded cardinality or the top k are folded into.
+	SourceOther = "other"
+	// SourceUnknown is the source of the assignment that doesn't declare its source.
+	SourceUnknown = "unknown"
+)
+
+// SourceStats is the aggregated segment assignments of a source node, e.g. a proxy.
+type SourceStats struct {
+	Source      string `json:"source"`
+	Assignments uint64 `json:"assignments"`
+	BinarySize  uint64 `json:"binary_size"`
+}
+
+// sourceStatsShard is a shard of the source stats, a source is always aggregated in the same shard.
+type sourceStatsShard struct {
+	mu      sync.Mutex
+	sources map[string]*SourceStats
+}
+
+// sourceAggregator aggregates the segment assignments by source node.
+// The sources are sharded by hash, so the concurrent assignments of different sources rarely contend on one lock,
+// the shards are merged on read.
+// The cardinality is bounded, the source that can not be held by its shard is folded into the other source.
+type sourceAggregator struct {
+	shards [sourceStatsShardNum]sourceStatsShard
+}
+
+// newSourceAggregator creates a new source aggregator.
+func newSourceAggregator() *sourceAggregator {
+	a := &sourceAggregator{}
+	for i := range a.shards {
+		a.shards[i].sources = make(map[string]*SourceStats)
+	}
+	return a
+}
+
+// Observe observes an assignment of the source.
+// maxSources is the max cardinality of the sources, the sources beyond it are folded into the other source.
+func (a *sourceAggregator) Observe(source string, insert InsertMetrics, maxSources int) {
+	if source == "" {
+		source = SourceUnknown
+	}
+	shard := &a.shards[sourceShardIndex(source)]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	stats, ok := shard.sources[source]
+	if !ok {
+		if len(shard.sources) >= max(maxSources/sourceStatsShardNum, 1) {
+			// the other source is always allowed, so the shard holds at most one more entry.
+			source = SourceOther
+			stats, ok = shard.sources[source]
+		}
+		if !ok {
+			stats = &SourceStats{Source: source}
+			shard.sources[source] = stats
+		}
+	}
+	stats.Assignments++
+	stats.BinarySize += insert.BinarySize
+}
+
+// TopK merges the shards and returns the top k sources ordered by the assignments,
+// the rest of the sources are folded into the other source at the tail.
+func (a *sourceAggregator) TopK(k int) []SourceStats {
+	merged := make(map[string]SourceStats)
+	for i := range a.shards {
+		shard := &a.shards[i]
+		shard.mu.Lock()
+		for source, stats := range shard.sources {
+			m := merged[source]
+			m.Source = source
+			m.Assignments += stats.Assignments
+			m.BinarySize += stats.BinarySize
+			merged[source] = m
+		}
+		shard.mu.Unlock()
+	}
+	other, hasOther := merged[SourceOther]
+	delete(merged, SourceOther)
+
+	sources := make([]SourceStats, 0, len(merged))
+	for _, stats := range merged {
+		sources = append(sources, stats)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if sources[i].Assignments != sources[j].Assignments {
+			return sources[i].Assignments > sources[j].Assignments
+		}
+		return sources[i].Source < sources[j].Source
+	})
+	if k > 0 && len(sources) > k {
+		for _, stats := range sources[k:] {
+			other.Assignments += stats.Assignments
+			other.BinarySize += stats.BinarySize
+		}
+		hasOther = true
+		sources = sources[:k]
+	}
+	if hasOther {
+		other.Source = SourceOther
+		sources = append(sources, other)
+	}
+	return sources
+}
+
+// Reset clears all aggregated sources.
+func (a *sourceAggregator) Reset() {
+	for i := range a.shards {
+		shard := &a.shards[i]
+		shard.mu.Lock()
+		shard.sources = make(map[string]*SourceStats)
+		shard.mu.Unlock()
+	}
+}
+
+// sourceShardIndex returns the shard index of the source.
+func sourceShardIndex(source string) int {
+	h := fnv.New32a()
+	h.Write([]byte(source))
+	return int(h.Sum32() % sourceStatsShardNum)
+}
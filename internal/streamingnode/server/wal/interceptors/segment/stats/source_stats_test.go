@@ -0,0 +1,50 @@
+package stats
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceAggregator(t *testing.T) {
+	a := newSourceAggregator()
+	insert := InsertMetrics{Rows: 1, BinarySize: 10}
+
+	// the concurrent assignments of the sources are merged on read.
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j <= i; j++ {
+				a.Observe(fmt.Sprintf("proxy-%d", i), insert, 1024)
+			}
+		}(i)
+	}
+	wg.Wait()
+	a.Observe("", insert, 1024)
+	assert.Equal(t, []SourceStats{
+		{Source: "proxy-3", Assignments: 4, BinarySize: 40},
+		{Source: "proxy-2", Assignments: 3, BinarySize: 30},
+		{Source: "other", Assignments: 4, BinarySize: 40},
+	}, a.TopK(2))
+	assert.Len(t, a.TopK(0), 5)
+	assert.Equal(t, SourceStats{Source: SourceUnknown, Assignments: 1, BinarySize: 10}, a.TopK(0)[4])
+
+	// the sources beyond the cardinality are folded into the other source.
+	a.Reset()
+	assert.Empty(t, a.TopK(10))
+	for i := 0; i < 1000; i++ {
+		a.Observe(fmt.Sprintf("proxy-%d", i), insert, sourceStatsShardNum)
+	}
+	sources := a.TopK(0)
+	assert.LessOrEqual(t, len(sources), sourceStatsShardNum+1)
+	total := uint64(0)
+	for _, source := range sources {
+		total += source.Assignments
+	}
+	assert.Equal(t, uint64(1000), total)
+	assert.Equal(t, SourceOther, sources[len(sources)-1].Source)
+}
@@ -1,6 +1,7 @@
 package stats
 
 import (
+	"math"
 	"time"
 
 	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
@@ -15,7 +16,10 @@ type SegmentStats struct {
 	LastModifiedTime  time.Time // LastWriteTime is the last write time of this segment, it's not a tso, just a local time.
 	BinLogCounter     uint64    // BinLogCounter is the counter of binlog (equal to the binlog file count of primary key), it's an async stat not real time.
 	BinLogFileCounter uint64    // BinLogFileCounter is the counter of binlog files, it's an async stat not real time.
+	SyncedBinarySize  uint64    // SyncedBinarySize is the binary size written by the sync operations, including the binlog overhead, it's an async stat not real time.
 	ReachLimit        bool      // ReachLimit is a flag to indicate the segment reach the limit once.
+	HighPriority      bool      // HighPriority is a flag to indicate the segment is dedicated to the high priority inserts, it's sealed on its own thresholds.
+	PKSketch          *PKSketch // PKSketch estimates the distinct primary keys of the segment, nil if not estimated, it's shared by the copies of stats.
 }
 
 // NewSegmentStatFromProto creates a new segment assignment stat from proto.
@@ -23,7 +27,7 @@ func NewSegmentStatFromProto(statProto *streamingpb.SegmentAssignmentStat) *Segm
 	if statProto == nil {
 		return nil
 	}
-	return &SegmentStats{
+	stat := &SegmentStats{
 		Insert: InsertMetrics{
 			Rows:       statProto.InsertedRows,
 			BinarySize: statProto.InsertedBinarySize,
@@ -32,7 +36,15 @@ func NewSegmentStatFromProto(statProto *streamingpb.SegmentAssignmentStat) *Segm
 		CreateTime:       time.Unix(statProto.CreateTimestamp, 0),
 		BinLogCounter:    statProto.BinlogCounter,
 		LastModifiedTime: time.Unix(statProto.LastModifiedTimestamp, 0),
+		SyncedBinarySize: statProto.SyncedBinarySize,
 	}
+	if len(statProto.PkSketch) > 0 {
+		// the sketch is only a hint for the dedup planning, the broken one is dropped and the estimate starts over.
+		if sketch, err := UnmarshalPKSketch(statProto.PkSketch); err == nil {
+			stat.PKSketch = sketch
+		}
+	}
+	return stat
 }
 
 // NewProtoFromSegmentStat creates a new proto from segment assignment stat.
@@ -40,25 +52,48 @@ func NewProtoFromSegmentStat(stat *SegmentStats) *streamingpb.SegmentAssignmentS
 	if stat == nil {
 		return nil
 	}
-	return &streamingpb.SegmentAssignmentStat{
+	statProto := &streamingpb.SegmentAssignmentStat{
 		MaxBinarySize:         stat.MaxBinarySize,
 		InsertedRows:          stat.Insert.Rows,
 		InsertedBinarySize:    stat.Insert.BinarySize,
 		CreateTimestamp:       stat.CreateTime.Unix(),
 		BinlogCounter:         stat.BinLogCounter,
 		LastModifiedTimestamp: stat.LastModifiedTime.Unix(),
+		SyncedBinarySize:      stat.SyncedBinarySize,
+	}
+	if stat.PKSketch != nil {
+		statProto.PkSketch = stat.PKSketch.Marshal()
 	}
+	return statProto
 }
 
+const (
+	// a sync operation generates one binlog of primary key and a binlog file for each field,
+	// so the bounds are far beyond any real sync operation, the larger increment must come from a buggy caller.
+	maxBinLogCounterIncrPerSync     = 1024
+	maxBinLogFileCounterIncrPerSync = 65536
+)
+
 // SyncOperationMetrics is the metrics of sync operation.
 type SyncOperationMetrics struct {
 	BinLogCounterIncr     uint64 // the counter increment of bin log
 	BinLogFileCounterIncr uint64 // the counter increment of bin log file
+	WrittenBinarySizeIncr uint64 // the binary size written by the sync operation, including the binlog overhead
 }
 
-// AllocRows alloc space of rows on current segment.
+// clamp clamps the increments of sync operation into the sane bounds, return true if any increment is clamped.
+func (f SyncOperationMetrics) clamp() (SyncOperationMetrics, bool) {
+	clamped := SyncOperationMetrics{
+		BinLogCounterIncr:     min(f.BinLogCounterIncr, maxBinLogCounterIncrPerSync),
+		BinLogFileCounterIncr: min(f.BinLogFileCounterIncr, maxBinLogFileCounterIncrPerSync),
+		WrittenBinarySizeIncr: f.WrittenBinarySizeIncr,
+	}
+	return clamped, clamped != f
+}
+
+// AllocRows alloc space of rows on current segment, now is the local time of the allocation.
 // Return true if the segment is assigned.
-func (s *SegmentStats) AllocRows(m InsertMetrics) bool {
+func (s *SegmentStats) AllocRows(m InsertMetrics, now time.Time) bool {
 	if m.BinarySize > s.BinaryCanBeAssign() {
 		if s.Insert.BinarySize > 0 {
 			// if the binary size is not empty, it means the segment cannot hold more data, mark it as reach limit.
@@ -68,10 +103,24 @@ func (s *SegmentStats) AllocRows(m InsertMetrics) bool {
 	}
 
 	s.Insert.Collect(m)
-	s.LastModifiedTime = time.Now()
+	s.LastModifiedTime = now
 	return true
 }
 
+// ReleaseRows releases the space of rows that is allocated but not used on current segment.
+// The reach limit flag is recomputed, the segment can be assigned again if it has space after release.
+func (s *SegmentStats) ReleaseRows(m InsertMetrics) InsertMetrics {
+	released := InsertMetrics{
+		Rows:       min(m.Rows, s.Insert.Rows),
+		BinarySize: min(m.BinarySize, s.Insert.BinarySize),
+	}
+	s.Insert.Subtract(released)
+	if s.ReachLimit && released.BinarySize > 0 && s.BinaryCanBeAssign() > 0 {
+		s.ReachLimit = false
+	}
+	return released
+}
+
 // BinaryCanBeAssign returns the capacity of binary size can be inserted.
 func (s *SegmentStats) BinaryCanBeAssign() uint64 {
 	return s.MaxBinarySize - s.Insert.BinarySize
@@ -88,9 +137,29 @@ func (s *SegmentStats) IsEmpty() bool {
 }
 
 // UpdateOnSync updates the stats of segment on sync.
+// The counters are saturated at the max value but never wrap.
 func (s *SegmentStats) UpdateOnSync(f SyncOperationMetrics) {
-	s.BinLogCounter += f.BinLogCounterIncr
-	s.BinLogFileCounter += f.BinLogFileCounterIncr
+	s.BinLogCounter = saturatingAdd(s.BinLogCounter, f.BinLogCounterIncr)
+	s.BinLogFileCounter = saturatingAdd(s.BinLogFileCounter, f.BinLogFileCounterIncr)
+	s.SyncedBinarySize = saturatingAdd(s.SyncedBinarySize, f.WrittenBinarySizeIncr)
+}
+
+// WriteAmplification returns the ratio of the binary size written by the sync operations
+// to the binary size assigned into the segment (the assignment-time estimate corrected by the acks),
+// return false if nothing is assigned or synced yet.
+func (s *SegmentStats) WriteAmplification() (float64, bool) {
+	if s.Insert.BinarySize == 0 || s.SyncedBinarySize == 0 {
+		return 0, false
+	}
+	return float64(s.SyncedBinarySize) / float64(s.Insert.BinarySize), true
+}
+
+// saturatingAdd returns a + b, or the max value of uint64 if it overflows.
+func saturatingAdd(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
 }
 
 // Copy copies the segment stats.
@@ -3,48 +3,111 @@ package stats
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+const (
+	syncMetricsRejectSegmentNotFound = "segment_not_found"
+	syncMetricsRejectOutOfRange      = "out_of_range"
 )
 
 var (
-	ErrNotEnoughSpace = errors.New("not enough space")
-	ErrTooLargeInsert = errors.New("insert too large")
+	ErrNotEnoughSpace         = errors.New("not enough space")
+	ErrTooLargeInsert         = errors.New("insert too large")
+	ErrSegmentNotFound        = errors.New("segment not found")
+	ErrSegmentRecentlyRemoved = errors.New("segment recently removed")
+	ErrSyncMetricsOutOfRange  = errors.New("sync metrics out of range")
 )
 
+var _ error = (*TooLargeInsertError)(nil)
+
+// TooLargeInsertError is the error returned when the insert is too large to be held by a segment.
+// It carries the size information for client to re-batch the insert, and can be matched by ErrTooLargeInsert.
+type TooLargeInsertError struct {
+	BinarySize          uint64 // the binary size of the rejected insert.
+	MaxBinarySize       uint64 // the effective max binary size of the segment.
+	RemainingBinarySize uint64 // the remaining capacity of the best candidate segment.
+}
+
+// Error implements error interface.
+func (e *TooLargeInsertError) Error() string {
+	return fmt.Sprintf("%s, binary size: %d, max binary size: %d, remaining binary size: %d",
+		ErrTooLargeInsert.Error(), e.BinarySize, e.MaxBinarySize, e.RemainingBinarySize)
+}
+
+// Is makes the error can be matched by ErrTooLargeInsert.
+func (e *TooLargeInsertError) Is(target error) bool {
+	return target == ErrTooLargeInsert
+}
+
 // StatsManager is the manager of stats.
 // It manages the insert stats of all segments, used to check if a segment has enough space to insert or should be sealed.
 // If there will be a lock contention, we can optimize it by apply lock per segment.
 type StatsManager struct {
-	mu            sync.Mutex
-	totalStats    InsertMetrics
-	pchannelStats map[string]*InsertMetrics
-	vchannelStats map[string]*InsertMetrics
-	segmentStats  map[int64]*SegmentStats       // map[SegmentID]SegmentStats
-	segmentIndex  map[int64]SegmentBelongs      // map[SegmentID]channels
-	pchannelIndex map[string]map[int64]struct{} // map[PChannel]SegmentID
-	sealNotifier  *SealSignalNotifier
+	mu             sync.Mutex
+	clock          clock.Clock
+	totalStats     InsertMetrics
+	pchannelStats  map[string]*InsertMetrics
+	vchannelStats  map[string]*GrowingRollup     // map[VChannel]GrowingRollup
+	segmentStats   map[int64]*SegmentStats       // map[SegmentID]SegmentStats
+	segmentIndex   map[int64]SegmentBelongs      // map[SegmentID]channels
+	pchannelIndex  map[string]map[int64]struct{} // map[PChannel]SegmentID
+	sealNotifier   *SealSignalNotifier
+	fillNotifier   *SegmentFillNotifier
+	timeToSeal     map[int64]*timeToSealWindow      // map[CollectionID]timeToSealWindow
+	sealQueues     map[string]SealQueueStats        // map[PChannel]SealQueueStats
+	highPriority   map[string]*InsertMetrics        // map[PChannel]InsertMetrics, the high priority segments are also counted in the pchannel and vchannel stats.
+	sources        *sourceAggregator                // the assignments aggregated by source node, it's guarded by its own shard locks.
+	removed        map[string]*removedSegments      // map[PChannel]removedSegments, the segments recently flushed or discarded.
+	creationRates  map[int64]*segmentCreationRate   // map[CollectionID]segmentCreationRate
+	saturations    map[string]float64               // map[PChannel]saturation, the rolling saturation of the append path.
+	timeTickTooOld map[int64]*timeTickTooOldCounter // map[CollectionID]timeTickTooOldCounter
+}
+
+// SealQueueStats is the stats of the segments that wait for the flying acks or txns before flushing.
+type SealQueueStats struct {
+	WaitingCount int    // the count of the segments in the seal queue.
+	MemoryBytes  uint64 // the approximate memory used by the segments in the seal queue.
 }
 
 type SegmentBelongs struct {
-	PChannel     string
-	VChannel     string
-	CollectionID int64
-	PartitionID  int64
-	SegmentID    int64
+	PChannel       string
+	VChannel       string
+	CollectionID   int64
+	PartitionID    int64
+	SegmentID      int64
+	HighPriority   bool  // the segment is dedicated to the high priority inserts.
+	StorageVersion int64 // the effective storage version of the segment, it's decided when the segment is created.
 }
 
 // NewStatsManager creates a new stats manager.
-func NewStatsManager() *StatsManager {
+// The clock is used to stamp the last modified time of the segments.
+func NewStatsManager(c clock.Clock) *StatsManager {
 	return &StatsManager{
-		mu:            sync.Mutex{},
-		totalStats:    InsertMetrics{},
-		pchannelStats: make(map[string]*InsertMetrics),
-		vchannelStats: make(map[string]*InsertMetrics),
-		segmentStats:  make(map[int64]*SegmentStats),
-		segmentIndex:  make(map[int64]SegmentBelongs),
-		pchannelIndex: make(map[string]map[int64]struct{}),
-		sealNotifier:  NewSealSignalNotifier(),
+		mu:             sync.Mutex{},
+		clock:          c,
+		totalStats:     InsertMetrics{},
+		pchannelStats:  make(map[string]*InsertMetrics),
+		vchannelStats:  make(map[string]*GrowingRollup),
+		segmentStats:   make(map[int64]*SegmentStats),
+		segmentIndex:   make(map[int64]SegmentBelongs),
+		pchannelIndex:  make(map[string]map[int64]struct{}),
+		sealNotifier:   NewSealSignalNotifier(),
+		fillNotifier:   newSegmentFillNotifier(),
+		timeToSeal:     make(map[int64]*timeToSealWindow),
+		sealQueues:     make(map[string]SealQueueStats),
+		highPriority:   make(map[string]*InsertMetrics),
+		sources:        newSourceAggregator(),
+		removed:        make(map[string]*removedSegments),
+		creationRates:  make(map[int64]*segmentCreationRate),
+		saturations:    make(map[string]float64),
+		timeTickTooOld: make(map[int64]*timeTickTooOldCounter),
 	}
 }
 
@@ -58,6 +121,7 @@ func (m *StatsManager) RegisterNewGrowingSegment(belongs SegmentBelongs, segment
 		panic(fmt.Sprintf("register a segment %d that already exist, critical bug", segmentID))
 	}
 
+	stats.HighPriority = belongs.HighPriority
 	m.segmentStats[segmentID] = stats
 	m.segmentIndex[segmentID] = belongs
 	if _, ok := m.pchannelIndex[belongs.PChannel]; !ok {
@@ -69,11 +133,8 @@ func (m *StatsManager) RegisterNewGrowingSegment(belongs SegmentBelongs, segment
 		m.pchannelStats[belongs.PChannel] = &InsertMetrics{}
 	}
 	m.pchannelStats[belongs.PChannel].Collect(stats.Insert)
-
-	if _, ok := m.vchannelStats[belongs.VChannel]; !ok {
-		m.vchannelStats[belongs.VChannel] = &InsertMetrics{}
-	}
-	m.vchannelStats[belongs.VChannel].Collect(stats.Insert)
+	m.collectVChannel(belongs, stats.Insert, 1)
+	m.collectHighPriority(belongs, stats.Insert)
 }
 
 // AllocRows alloc number of rows on current segment.
@@ -87,7 +148,7 @@ func (m *StatsManager) AllocRows(segmentID int64, insert InsertMetrics) error {
 		panic(fmt.Sprintf("alloc rows on a segment %d that not exist", segmentID))
 	}
 	stat := m.segmentStats[segmentID]
-	inserted := stat.AllocRows(insert)
+	inserted := stat.AllocRows(insert, m.clock.Now())
 
 	// update the total stats if inserted.
 	if inserted {
@@ -96,10 +157,9 @@ func (m *StatsManager) AllocRows(segmentID int64, insert InsertMetrics) error {
 			m.pchannelStats[info.PChannel] = &InsertMetrics{}
 		}
 		m.pchannelStats[info.PChannel].Collect(insert)
-		if _, ok := m.vchannelStats[info.VChannel]; !ok {
-			m.vchannelStats[info.VChannel] = &InsertMetrics{}
-		}
-		m.vchannelStats[info.VChannel].Collect(insert)
+		m.collectVChannel(info, insert, 0)
+		m.collectHighPriority(info, insert)
+		m.fillNotifier.notify(info, stat, m.clock.Now())
 		return nil
 	}
 
@@ -108,17 +168,78 @@ func (m *StatsManager) AllocRows(segmentID int64, insert InsertMetrics) error {
 		m.sealNotifier.AddAndNotify(info)
 	}
 	if stat.IsEmpty() {
-		return ErrTooLargeInsert
+		return &TooLargeInsertError{
+			BinarySize:          insert.BinarySize,
+			MaxBinarySize:       stat.MaxBinarySize,
+			RemainingBinarySize: stat.BinaryCanBeAssign(),
+		}
 	}
 	return ErrNotEnoughSpace
 }
 
+// ReleaseRows releases the rows that is allocated but not used on current segment.
+// The segment may be already sealed and unregistered, the release operation will be ignored.
+func (m *StatsManager) ReleaseRows(segmentID int64, release InsertMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	info, ok := m.segmentIndex[segmentID]
+	if !ok {
+		return
+	}
+	released := m.segmentStats[segmentID].ReleaseRows(release)
+	m.totalStats.Subtract(released)
+	if stats, ok := m.pchannelStats[info.PChannel]; ok {
+		stats.Subtract(released)
+	}
+	m.subtractVChannel(info, released, 0)
+	m.subtractHighPriority(info, released)
+}
+
+// ImportRows imports the rows assigned on the segment by the previous owner of the pchannel but not persisted into the meta.
+// The rows are collected even if the segment can not hold them, the segment is marked as reach limit and notified to be sealed then,
+// and the binary size is clamped to the max binary size, so the segment is never over-assigned by the following assignments.
+// Return false if the segment is not registered.
+func (m *StatsManager) ImportRows(segmentID int64, insert InsertMetrics) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	info, ok := m.segmentIndex[segmentID]
+	if !ok {
+		return false
+	}
+	stat := m.segmentStats[segmentID]
+	if insert.BinarySize >= stat.BinaryCanBeAssign() {
+		insert.BinarySize = stat.BinaryCanBeAssign()
+		stat.ReachLimit = true
+	}
+	stat.Insert.Collect(insert)
+	m.totalStats.Collect(insert)
+	if _, ok := m.pchannelStats[info.PChannel]; !ok {
+		m.pchannelStats[info.PChannel] = &InsertMetrics{}
+	}
+	m.pchannelStats[info.PChannel].Collect(insert)
+	m.collectVChannel(info, insert, 0)
+	m.collectHighPriority(info, insert)
+	m.fillNotifier.notify(info, stat, m.clock.Now())
+	if stat.ShouldBeSealed() {
+		m.sealNotifier.AddAndNotify(info)
+	}
+	return true
+}
+
 // SealNotifier returns the seal notifier.
 func (m *StatsManager) SealNotifier() *SealSignalNotifier {
 	// no lock here, because it's read only.
 	return m.sealNotifier
 }
 
+// FillNotifier returns the notifier of the fill updates of the growing segments.
+func (m *StatsManager) FillNotifier() *SegmentFillNotifier {
+	// no lock here, because it's read only.
+	return m.fillNotifier
+}
+
 // GetStatsOfSegment gets the stats of segment.
 func (m *StatsManager) GetStatsOfSegment(segmentID int64) *SegmentStats {
 	m.mu.Lock()
@@ -126,20 +247,69 @@ func (m *StatsManager) GetStatsOfSegment(segmentID int64) *SegmentStats {
 	return m.segmentStats[segmentID].Copy()
 }
 
+// RecapMaxBinarySize shrinks the max binary size of a growing segment to the new limit.
+// The segment is not re-capped if it's not exist or its inserted binary size already reaches the new limit,
+// return the old max binary size and whether the segment is re-capped.
+func (m *StatsManager) RecapMaxBinarySize(segmentID int64, maxBinarySize uint64) (uint64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stat, ok := m.segmentStats[segmentID]
+	if !ok {
+		return 0, false
+	}
+	oldMaxBinarySize := stat.MaxBinarySize
+	if maxBinarySize >= oldMaxBinarySize || stat.Insert.BinarySize >= maxBinarySize {
+		return oldMaxBinarySize, false
+	}
+	stat.MaxBinarySize = maxBinarySize
+	return oldMaxBinarySize, true
+}
+
+// UpdateSegmentPartition re-labels the growing segment into the new partition, the stats of the segment are kept as is.
+// Return false if the segment is not growing.
+func (m *StatsManager) UpdateSegmentPartition(segmentID int64, partitionID int64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	belongs, ok := m.segmentIndex[segmentID]
+	if !ok {
+		return false
+	}
+	belongs.PartitionID = partitionID
+	m.segmentIndex[segmentID] = belongs
+	return true
+}
+
 // UpdateOnSync updates the stats of segment on sync.
 // It's an async update operation, so it's not necessary to do success.
-func (m *StatsManager) UpdateOnSync(segmentID int64, syncMetric SyncOperationMetrics) {
+// ErrSegmentNotFound is returned if the segment is not growing (or already removed), nothing is updated.
+// ErrSegmentRecentlyRemoved is returned if the segment is recently flushed or discarded, it's an expected late update.
+// ErrSyncMetricsOutOfRange is returned if the increments are out of the sane bounds, the clamped increments are still applied.
+func (m *StatsManager) UpdateOnSync(segmentID int64, syncMetric SyncOperationMetrics) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Must be exist, otherwise it's a bug.
-	if _, ok := m.segmentIndex[segmentID]; !ok {
-		return
+	belongs, ok := m.segmentIndex[segmentID]
+	if !ok {
+		if m.observeLateEvent(segmentID, LateEventSync) {
+			return errors.Wrapf(ErrSegmentRecentlyRemoved, "segment %d", segmentID)
+		}
+		metrics.WALSegmentSyncMetricsRejectedTotal.WithLabelValues(paramtable.GetStringNodeID(), syncMetricsRejectSegmentNotFound).Inc()
+		return errors.Wrapf(ErrSegmentNotFound, "segment %d", segmentID)
 	}
-	m.segmentStats[segmentID].UpdateOnSync(syncMetric)
+	clamped, outOfRange := syncMetric.clamp()
+	m.segmentStats[segmentID].UpdateOnSync(clamped)
 
 	// binlog counter is updated, notify seal manager to do seal scanning.
-	m.sealNotifier.AddAndNotify(m.segmentIndex[segmentID])
+	m.sealNotifier.AddAndNotify(belongs)
+
+	if outOfRange {
+		metrics.WALSegmentSyncMetricsRejectedTotal.WithLabelValues(paramtable.GetStringNodeID(), syncMetricsRejectOutOfRange).Inc()
+		return errors.Wrapf(ErrSyncMetricsOutOfRange, "segment %d, binlog counter incr %d, binlog file counter incr %d",
+			segmentID, syncMetric.BinLogCounterIncr, syncMetric.BinLogFileCounterIncr)
+	}
+	return nil
 }
 
 // UnregisterSealedSegment unregisters the sealed segment.
@@ -175,13 +345,139 @@ func (m *StatsManager) unregisterSealedSegment(segmentID int64) *SegmentStats {
 			delete(m.pchannelStats, info.PChannel)
 		}
 	}
-	if _, ok := m.vchannelStats[info.VChannel]; ok {
-		m.vchannelStats[info.VChannel].Subtract(stats.Insert)
-		if m.vchannelStats[info.VChannel].BinarySize == 0 {
-			delete(m.vchannelStats, info.VChannel)
+	m.subtractVChannel(info, stats.Insert, 1)
+	m.subtractHighPriority(info, stats.Insert)
+	// the sealed segment is never filled anymore.
+	m.fillNotifier.remove(segmentID)
+	return stats
+}
+
+// collectHighPriority collects the insert metrics of the segment into the high priority stats if the segment is high priority.
+func (m *StatsManager) collectHighPriority(info SegmentBelongs, insert InsertMetrics) {
+	if !info.HighPriority {
+		return
+	}
+	if _, ok := m.highPriority[info.PChannel]; !ok {
+		m.highPriority[info.PChannel] = &InsertMetrics{}
+	}
+	m.highPriority[info.PChannel].Collect(insert)
+}
+
+// subtractHighPriority subtracts the insert metrics of the segment from the high priority stats if the segment is high priority.
+func (m *StatsManager) subtractHighPriority(info SegmentBelongs, insert InsertMetrics) {
+	if !info.HighPriority {
+		return
+	}
+	if stats, ok := m.highPriority[info.PChannel]; ok {
+		stats.Subtract(insert)
+		if stats.BinarySize == 0 {
+			delete(m.highPriority, info.PChannel)
 		}
 	}
-	return stats
+}
+
+// GetHighPriorityStats returns the total insert metrics of the growing high priority segments on current node.
+func (m *StatsManager) GetHighPriorityStats() InsertMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	total := InsertMetrics{}
+	for _, stats := range m.highPriority {
+		total.Collect(*stats)
+	}
+	return total
+}
+
+// ObserveTimeToSeal records a time to seal sample of the collection into its rolling window.
+func (m *StatsManager) ObserveTimeToSeal(collectionID int64, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.timeToSeal[collectionID]; !ok {
+		m.timeToSeal[collectionID] = newTimeToSealWindow(defaultTimeToSealWindowSize)
+	}
+	m.timeToSeal[collectionID].Add(d)
+}
+
+// GetTimeToSealSummary returns the rolling time to seal summary of all collections on current node.
+func (m *StatsManager) GetTimeToSealSummary() map[int64]TimeToSealSummary {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	summary := make(map[int64]TimeToSealSummary, len(m.timeToSeal))
+	for collectionID, window := range m.timeToSeal {
+		summary[collectionID] = window.Summary()
+	}
+	return summary
+}
+
+// UnregisterTimeToSeal removes the time to seal samples of the collection.
+func (m *StatsManager) UnregisterTimeToSeal(collectionID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.timeToSeal, collectionID)
+}
+
+// ObserveSourceAssign records a segment assignment of the source node, it never acquires the lock of stats manager.
+func (m *StatsManager) ObserveSourceAssign(source string, insert InsertMetrics) {
+	m.sources.Observe(source, insert, paramtable.Get().StreamingCfg.WALSegmentAssignSourceStatsMaxSources.GetAsInt())
+}
+
+// GetTopSources returns the top k source nodes ordered by the assignments,
+// the rest of the sources are folded into the other source at the tail.
+func (m *StatsManager) GetTopSources(k int) []SourceStats {
+	return m.sources.TopK(k)
+}
+
+// ResetSourceStats clears the assignments aggregated by source node.
+func (m *StatsManager) ResetSourceStats() {
+	m.sources.Reset()
+}
+
+// UpdateSealQueueStats updates the seal queue stats of the pchannel.
+func (m *StatsManager) UpdateSealQueueStats(pchannel string, stats SealQueueStats) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if stats.WaitingCount == 0 {
+		delete(m.sealQueues, pchannel)
+		return
+	}
+	m.sealQueues[pchannel] = stats
+}
+
+// GetSealQueueStats returns the total seal queue stats of all pchannels on current node.
+func (m *StatsManager) GetSealQueueStats() SealQueueStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	total := SealQueueStats{}
+	for _, stats := range m.sealQueues {
+		total.WaitingCount += stats.WaitingCount
+		total.MemoryBytes += stats.MemoryBytes
+	}
+	return total
+}
+
+// UpdatePChannelSaturation updates the rolling saturation of the append path of the pchannel.
+func (m *StatsManager) UpdatePChannelSaturation(pchannel string, saturation float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.saturations[pchannel] = saturation
+}
+
+// GetPChannelSaturations returns the rolling saturation of the append path of all pchannels on current node.
+func (m *StatsManager) GetPChannelSaturations() map[string]float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	saturations := make(map[string]float64, len(m.saturations))
+	for pchannel, saturation := range m.saturations {
+		saturations[pchannel] = saturation
+	}
+	return saturations
 }
 
 // UnregisterAllStatsOnPChannel unregisters all stats on pchannel.
@@ -189,6 +485,10 @@ func (m *StatsManager) UnregisterAllStatsOnPChannel(pchannel string) int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	delete(m.sealQueues, pchannel)
+	delete(m.removed, pchannel)
+	delete(m.saturations, pchannel)
+
 	segmentIDs, ok := m.pchannelIndex[pchannel]
 	if !ok {
 		return 0
@@ -205,8 +505,8 @@ func (m *StatsManager) SealByTotalGrowingSegmentsSize(vchannelThreshold uint64)
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	for _, metrics := range m.vchannelStats {
-		if metrics.BinarySize >= vchannelThreshold {
+	for _, rollup := range m.vchannelStats {
+		if rollup.Insert.BinarySize >= vchannelThreshold {
 			var (
 				largestSegment     int64  = 0
 				largestSegmentSize uint64 = 0
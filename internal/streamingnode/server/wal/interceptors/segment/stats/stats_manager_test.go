@@ -1,14 +1,18 @@
 package stats
 
 import (
+	"math"
+	"math/rand"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
 )
 
 func TestStatsManager(t *testing.T) {
-	m := NewStatsManager()
+	m := NewStatsManager(clock.NewSystemClock())
 
 	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: 3}, 3, createSegmentStats(100, 100, 300))
 	assert.Len(t, m.segmentStats, 1)
@@ -62,18 +66,18 @@ func TestStatsManager(t *testing.T) {
 	assert.NoError(t, err)
 	shouldBlock(t, m.SealNotifier().WaitChan())
 
-	assert.Equal(t, uint64(250), m.vchannelStats["vchannel3"].BinarySize)
-	assert.Equal(t, uint64(100), m.vchannelStats["vchannel2"].BinarySize)
-	assert.Equal(t, uint64(250), m.vchannelStats["vchannel"].BinarySize)
+	assert.Equal(t, uint64(250), m.vchannelStats["vchannel3"].Insert.BinarySize)
+	assert.Equal(t, uint64(100), m.vchannelStats["vchannel2"].Insert.BinarySize)
+	assert.Equal(t, uint64(250), m.vchannelStats["vchannel"].Insert.BinarySize)
 
 	assert.Equal(t, uint64(350), m.pchannelStats["pchannel"].BinarySize)
 	assert.Equal(t, uint64(250), m.pchannelStats["pchannel2"].BinarySize)
 
-	m.UpdateOnSync(3, SyncOperationMetrics{BinLogCounterIncr: 100})
+	assert.NoError(t, m.UpdateOnSync(3, SyncOperationMetrics{BinLogCounterIncr: 100}))
 	<-m.SealNotifier().WaitChan()
 	infos = m.SealNotifier().Get()
 	assert.Len(t, infos, 1)
-	m.UpdateOnSync(1000, SyncOperationMetrics{BinLogCounterIncr: 100})
+	assert.ErrorIs(t, m.UpdateOnSync(1000, SyncOperationMetrics{BinLogCounterIncr: 100}), ErrSegmentNotFound)
 	shouldBlock(t, m.SealNotifier().WaitChan())
 
 	err = m.AllocRows(3, InsertMetrics{Rows: 400, BinarySize: 400})
@@ -88,6 +92,11 @@ func TestStatsManager(t *testing.T) {
 
 	err = m.AllocRows(7, InsertMetrics{Rows: 400, BinarySize: 400})
 	assert.ErrorIs(t, err, ErrTooLargeInsert)
+	var tooLarge *TooLargeInsertError
+	assert.ErrorAs(t, err, &tooLarge)
+	assert.Equal(t, uint64(400), tooLarge.BinarySize)
+	assert.Equal(t, uint64(300), tooLarge.MaxBinarySize)
+	assert.Equal(t, uint64(300), tooLarge.RemainingBinarySize)
 	shouldBlock(t, m.SealNotifier().WaitChan())
 
 	m.UnregisterSealedSegment(3)
@@ -111,7 +120,7 @@ func TestStatsManager(t *testing.T) {
 }
 
 func TestSealByTotalGrowingSegmentsSize(t *testing.T) {
-	m := NewStatsManager()
+	m := NewStatsManager(clock.NewSystemClock())
 	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: 3}, 3, createSegmentStats(100, 100, 300))
 	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: 4}, 4, createSegmentStats(100, 200, 300))
 	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: 5}, 5, createSegmentStats(100, 100, 300))
@@ -127,6 +136,212 @@ func TestSealByTotalGrowingSegmentsSize(t *testing.T) {
 	assert.Empty(t, m.segmentIndex)
 }
 
+func TestReleaseRows(t *testing.T) {
+	m := NewStatsManager(clock.NewSystemClock())
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: 3}, 3, createSegmentStats(100, 100, 300))
+
+	err := m.AllocRows(3, InsertMetrics{Rows: 100, BinarySize: 100})
+	assert.NoError(t, err)
+	m.ReleaseRows(3, InsertMetrics{Rows: 40, BinarySize: 60})
+	stat := m.GetStatsOfSegment(3)
+	assert.Equal(t, uint64(160), stat.Insert.Rows)
+	assert.Equal(t, uint64(140), stat.Insert.BinarySize)
+	assert.Equal(t, uint64(140), m.pchannelStats["pchannel"].BinarySize)
+	assert.Equal(t, uint64(140), m.vchannelStats["vchannel"].Insert.BinarySize)
+	assert.Equal(t, uint64(140), m.totalStats.BinarySize)
+
+	// release more than inserted should be clamped.
+	m.ReleaseRows(3, InsertMetrics{Rows: 1000, BinarySize: 1000})
+	stat = m.GetStatsOfSegment(3)
+	assert.True(t, stat.IsEmpty())
+	assert.Equal(t, uint64(0), stat.Insert.BinarySize)
+
+	// release on a not exist segment should be ignored.
+	m.ReleaseRows(4, InsertMetrics{Rows: 1, BinarySize: 1})
+	m.UnregisterAllStatsOnPChannel("pchannel")
+	assert.Empty(t, m.segmentStats)
+}
+
+func TestImportRows(t *testing.T) {
+	m := NewStatsManager(clock.NewSystemClock())
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: 3}, 3, createSegmentStats(100, 100, 300))
+
+	assert.True(t, m.ImportRows(3, InsertMetrics{Rows: 50, BinarySize: 50}))
+	stat := m.GetStatsOfSegment(3)
+	assert.Equal(t, uint64(150), stat.Insert.Rows)
+	assert.False(t, stat.ShouldBeSealed())
+	assert.Equal(t, uint64(150), m.pchannelStats["pchannel"].BinarySize)
+	assert.Equal(t, uint64(150), m.vchannelStats["vchannel"].Insert.BinarySize)
+	assert.Equal(t, uint64(150), m.totalStats.BinarySize)
+	assert.Empty(t, m.SealNotifier().Get())
+
+	// the rows are imported even if the segment can not hold them, the binary size is clamped and the segment should be sealed.
+	assert.True(t, m.ImportRows(3, InsertMetrics{Rows: 200, BinarySize: 200}))
+	stat = m.GetStatsOfSegment(3)
+	assert.Equal(t, uint64(350), stat.Insert.Rows)
+	assert.Equal(t, uint64(300), stat.Insert.BinarySize)
+	assert.True(t, stat.ShouldBeSealed())
+	assert.Equal(t, uint64(300), m.totalStats.BinarySize)
+	assert.Len(t, m.SealNotifier().Get(), 1)
+	assert.ErrorIs(t, m.AllocRows(3, InsertMetrics{Rows: 1, BinarySize: 1}), ErrNotEnoughSpace)
+
+	// the import on a not exist segment should be ignored.
+	assert.False(t, m.ImportRows(4, InsertMetrics{Rows: 1, BinarySize: 1}))
+}
+
+func TestRecapMaxBinarySize(t *testing.T) {
+	m := NewStatsManager(clock.NewSystemClock())
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: 3}, 3, createSegmentStats(100, 100, 1000))
+
+	// the fill reaches the new limit, keep the old limit.
+	old, ok := m.RecapMaxBinarySize(3, 100)
+	assert.False(t, ok)
+	assert.Equal(t, uint64(1000), old)
+
+	// a larger limit is never applied.
+	_, ok = m.RecapMaxBinarySize(3, 2000)
+	assert.False(t, ok)
+
+	old, ok = m.RecapMaxBinarySize(3, 500)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1000), old)
+	assert.Equal(t, uint64(500), m.GetStatsOfSegment(3).MaxBinarySize)
+	assert.ErrorIs(t, m.AllocRows(3, InsertMetrics{Rows: 100, BinarySize: 401}), ErrNotEnoughSpace)
+
+	// not exist segment.
+	_, ok = m.RecapMaxBinarySize(4, 500)
+	assert.False(t, ok)
+}
+
+func TestUpdateSegmentPartition(t *testing.T) {
+	m := NewStatsManager(clock.NewSystemClock())
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: 3}, 3, createSegmentStats(100, 100, 1000))
+
+	assert.True(t, m.UpdateSegmentPartition(3, 4))
+	assert.Equal(t, int64(4), m.segmentIndex[3].PartitionID)
+	// the stats of the segment are kept.
+	assert.Equal(t, uint64(100), m.GetStatsOfSegment(3).Insert.BinarySize)
+	assert.Equal(t, uint64(100), m.vchannelStats["vchannel"].Insert.BinarySize)
+
+	// the sealed segment is unregistered with the new partition.
+	m.UnregisterSealedSegment(3)
+	assert.False(t, m.UpdateSegmentPartition(3, 5))
+}
+
+func TestSealQueueStats(t *testing.T) {
+	m := NewStatsManager(clock.NewSystemClock())
+	assert.Equal(t, SealQueueStats{}, m.GetSealQueueStats())
+
+	m.UpdateSealQueueStats("pchannel", SealQueueStats{WaitingCount: 2, MemoryBytes: 100})
+	m.UpdateSealQueueStats("pchannel2", SealQueueStats{WaitingCount: 1, MemoryBytes: 50})
+	assert.Equal(t, SealQueueStats{WaitingCount: 3, MemoryBytes: 150}, m.GetSealQueueStats())
+
+	m.UpdateSealQueueStats("pchannel", SealQueueStats{})
+	assert.Equal(t, SealQueueStats{WaitingCount: 1, MemoryBytes: 50}, m.GetSealQueueStats())
+
+	m.UnregisterAllStatsOnPChannel("pchannel2")
+	assert.Equal(t, SealQueueStats{}, m.GetSealQueueStats())
+}
+
+func TestHighPriorityStats(t *testing.T) {
+	m := NewStatsManager(clock.NewSystemClock())
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: 3}, 3, createSegmentStats(100, 100, 300))
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: 4, HighPriority: true}, 4, createSegmentStats(10, 10, 100))
+	assert.False(t, m.GetStatsOfSegment(3).HighPriority)
+	assert.True(t, m.GetStatsOfSegment(4).HighPriority)
+	assert.Equal(t, InsertMetrics{Rows: 10, BinarySize: 10}, m.GetHighPriorityStats())
+
+	assert.NoError(t, m.AllocRows(3, InsertMetrics{Rows: 10, BinarySize: 10}))
+	assert.NoError(t, m.AllocRows(4, InsertMetrics{Rows: 20, BinarySize: 20}))
+	assert.Equal(t, InsertMetrics{Rows: 30, BinarySize: 30}, m.GetHighPriorityStats())
+	assert.Equal(t, uint64(140), m.pchannelStats["pchannel"].BinarySize)
+
+	m.ReleaseRows(4, InsertMetrics{Rows: 5, BinarySize: 5})
+	assert.Equal(t, InsertMetrics{Rows: 25, BinarySize: 25}, m.GetHighPriorityStats())
+
+	m.UnregisterSealedSegment(4)
+	assert.Equal(t, InsertMetrics{}, m.GetHighPriorityStats())
+	assert.Empty(t, m.highPriority)
+	assert.Equal(t, uint64(110), m.pchannelStats["pchannel"].BinarySize)
+}
+
+func TestUpdateOnSyncValidation(t *testing.T) {
+	m := NewStatsManager(clock.NewSystemClock())
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: 3}, 3, createSegmentStats(100, 100, 300))
+
+	// the unknown or removed segment is never updated.
+	assert.ErrorIs(t, m.UpdateOnSync(4, SyncOperationMetrics{BinLogCounterIncr: 1}), ErrSegmentNotFound)
+	m.UnregisterSealedSegment(3)
+	assert.ErrorIs(t, m.UpdateOnSync(3, SyncOperationMetrics{BinLogCounterIncr: 1}), ErrSegmentNotFound)
+	assert.NotContains(t, m.segmentStats, int64(3))
+
+	// the out of range increment is clamped.
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: 5}, 5, createSegmentStats(100, 100, 300))
+	err := m.UpdateOnSync(5, SyncOperationMetrics{BinLogCounterIncr: math.MaxUint64, BinLogFileCounterIncr: maxBinLogFileCounterIncrPerSync + 1})
+	assert.ErrorIs(t, err, ErrSyncMetricsOutOfRange)
+	assert.Equal(t, uint64(maxBinLogCounterIncrPerSync), m.segmentStats[5].BinLogCounter)
+	assert.Equal(t, uint64(maxBinLogFileCounterIncrPerSync), m.segmentStats[5].BinLogFileCounter)
+
+	// the counter is saturated but never wraps.
+	m.segmentStats[5].BinLogCounter = math.MaxUint64 - 1
+	m.segmentStats[5].BinLogFileCounter = math.MaxUint64
+	assert.NoError(t, m.UpdateOnSync(5, SyncOperationMetrics{BinLogCounterIncr: 10, BinLogFileCounterIncr: 10}))
+	assert.Equal(t, uint64(math.MaxUint64), m.segmentStats[5].BinLogCounter)
+	assert.Equal(t, uint64(math.MaxUint64), m.segmentStats[5].BinLogFileCounter)
+}
+
+func TestUpdateOnSyncExtremeValues(t *testing.T) {
+	m := NewStatsManager(clock.NewSystemClock())
+	extremes := []uint64{
+		0, 1,
+		maxBinLogCounterIncrPerSync - 1, maxBinLogCounterIncrPerSync, maxBinLogCounterIncrPerSync + 1,
+		maxBinLogFileCounterIncrPerSync, maxBinLogFileCounterIncrPerSync + 1,
+		math.MaxUint32, math.MaxInt64, math.MaxUint64 - 1, math.MaxUint64,
+	}
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	segmentIDs := []int64{1, 2, 3}
+	for _, segmentID := range segmentIDs[:2] {
+		m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: segmentID}, segmentID, createSegmentStats(0, 0, 300))
+	}
+
+	for i := 0; i < 10000; i++ {
+		segmentID := segmentIDs[r.Intn(len(segmentIDs))]
+		metric := SyncOperationMetrics{
+			BinLogCounterIncr:     extremes[r.Intn(len(extremes))],
+			BinLogFileCounterIncr: extremes[r.Intn(len(extremes))],
+		}
+		before, ok := m.segmentStats[segmentID]
+		var oldCounter, oldFileCounter uint64
+		if ok {
+			oldCounter, oldFileCounter = before.BinLogCounter, before.BinLogFileCounter
+		}
+
+		err := m.UpdateOnSync(segmentID, metric)
+		if !ok {
+			// the unknown segment is never created by the update.
+			assert.ErrorIs(t, err, ErrSegmentNotFound)
+			assert.NotContains(t, m.segmentStats, segmentID)
+			continue
+		}
+		outOfRange := metric.BinLogCounterIncr > maxBinLogCounterIncrPerSync || metric.BinLogFileCounterIncr > maxBinLogFileCounterIncrPerSync
+		if outOfRange {
+			assert.ErrorIs(t, err, ErrSyncMetricsOutOfRange)
+		} else {
+			assert.NoError(t, err)
+		}
+
+		// the counters are monotonic, bounded by the clamped increments and never wrap.
+		stat := m.segmentStats[segmentID]
+		assert.GreaterOrEqual(t, stat.BinLogCounter, oldCounter)
+		assert.GreaterOrEqual(t, stat.BinLogFileCounter, oldFileCounter)
+		assert.LessOrEqual(t, stat.BinLogCounter-oldCounter, uint64(maxBinLogCounterIncrPerSync))
+		assert.LessOrEqual(t, stat.BinLogFileCounter-oldFileCounter, uint64(maxBinLogFileCounterIncrPerSync))
+		// the insert stats are never touched by the sync.
+		assert.Equal(t, InsertMetrics{}, stat.Insert)
+	}
+	assert.Equal(t, InsertMetrics{}, m.totalStats)
+}
+
 func createSegmentStats(row uint64, binarySize uint64, maxBinarSize uint64) *SegmentStats {
 	return &SegmentStats{
 		Insert: InsertMetrics{
@@ -148,3 +363,38 @@ func shouldBlock(t *testing.T, ch <-chan struct{}) {
 		return
 	}
 }
+
+func TestWriteAmplification(t *testing.T) {
+	m := NewStatsManager(clock.NewSystemClock())
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel", CollectionID: 1, PartitionID: 2, SegmentID: 3}, 3, createSegmentStats(0, 0, 1000))
+
+	// nothing is assigned or synced yet.
+	_, ok := m.GetStatsOfSegment(3).WriteAmplification()
+	assert.False(t, ok)
+
+	assert.NoError(t, m.AllocRows(3, InsertMetrics{Rows: 10, BinarySize: 500}))
+	_, ok = m.GetStatsOfSegment(3).WriteAmplification()
+	assert.False(t, ok)
+
+	// the assignment-time estimate is corrected by the release of the unused assignment.
+	m.ReleaseRows(3, InsertMetrics{Rows: 2, BinarySize: 100})
+	assert.NoError(t, m.UpdateOnSync(3, SyncOperationMetrics{BinLogCounterIncr: 1, BinLogFileCounterIncr: 2, WrittenBinarySizeIncr: 300}))
+	assert.NoError(t, m.UpdateOnSync(3, SyncOperationMetrics{BinLogCounterIncr: 1, BinLogFileCounterIncr: 2, WrittenBinarySizeIncr: 200}))
+
+	stat := m.UnregisterSealedSegment(3)
+	assert.Equal(t, uint64(500), stat.SyncedBinarySize)
+	ratio, ok := stat.WriteAmplification()
+	assert.True(t, ok)
+	assert.InDelta(t, 1.25, ratio, 1e-9)
+
+	// the synced binary size is persisted with the stat.
+	recovered := NewSegmentStatFromProto(NewProtoFromSegmentStat(stat))
+	assert.Equal(t, uint64(500), recovered.SyncedBinarySize)
+	recoveredRatio, ok := recovered.WriteAmplification()
+	assert.True(t, ok)
+	assert.Equal(t, ratio, recoveredRatio)
+
+	// the written binary size saturates but never wraps.
+	stat.UpdateOnSync(SyncOperationMetrics{WrittenBinarySizeIncr: math.MaxUint64})
+	assert.Equal(t, uint64(math.MaxUint64), stat.SyncedBinarySize)
+}
@@ -54,11 +54,11 @@ func TestSegmentStats(t *testing.T) {
 		Rows:       60,
 		BinarySize: 120,
 	}
-	inserted := stat.AllocRows(insert1)
+	inserted := stat.AllocRows(insert1, now.Add(time.Second))
 	assert.True(t, inserted)
 	assert.Equal(t, stat.Insert.Rows, uint64(160))
 	assert.Equal(t, stat.Insert.BinarySize, uint64(320))
-	assert.True(t, time.Now().After(now))
+	assert.Equal(t, now.Add(time.Second), stat.LastModifiedTime)
 	assert.False(t, stat.IsEmpty())
 	assert.False(t, stat.ShouldBeSealed())
 
@@ -66,8 +66,9 @@ func TestSegmentStats(t *testing.T) {
 		Rows:       100,
 		BinarySize: 100,
 	}
-	inserted = stat.AllocRows(insert1)
+	inserted = stat.AllocRows(insert1, now.Add(2*time.Second))
 	assert.False(t, inserted)
+	assert.Equal(t, now.Add(time.Second), stat.LastModifiedTime)
 	assert.Equal(t, stat.Insert.Rows, uint64(160))
 	assert.Equal(t, stat.Insert.BinarySize, uint64(320))
 	assert.False(t, stat.IsEmpty())
@@ -92,8 +93,68 @@ func TestOversizeAlloc(t *testing.T) {
 	// Try to alloc a oversized insert metrics.
 	inserted := stat.AllocRows(InsertMetrics{
 		BinarySize: 401,
-	})
+	}, now)
 	assert.False(t, inserted)
 	assert.True(t, stat.IsEmpty())
 	assert.False(t, stat.ShouldBeSealed())
 }
+
+func TestReleaseRowsResetReachLimit(t *testing.T) {
+	now := time.Now()
+	stat := &SegmentStats{
+		Insert:           InsertMetrics{Rows: 1, BinarySize: 300},
+		MaxBinarySize:    400,
+		CreateTime:       now,
+		LastModifiedTime: now,
+	}
+	assert.False(t, stat.AllocRows(InsertMetrics{Rows: 1, BinarySize: 200}, now))
+	assert.True(t, stat.ShouldBeSealed())
+
+	// nothing released keeps the limit.
+	stat.ReleaseRows(InsertMetrics{})
+	assert.True(t, stat.ShouldBeSealed())
+
+	// the released space can be assigned again.
+	stat.ReleaseRows(InsertMetrics{Rows: 1, BinarySize: 200})
+	assert.False(t, stat.ShouldBeSealed())
+	assert.True(t, stat.AllocRows(InsertMetrics{Rows: 1, BinarySize: 200}, now))
+
+	// the segment without space after release still reaches the limit.
+	stat = &SegmentStats{
+		Insert:        InsertMetrics{Rows: 2, BinarySize: 400},
+		MaxBinarySize: 400,
+		ReachLimit:    true,
+	}
+	stat.ReleaseRows(InsertMetrics{Rows: 1, BinarySize: 0})
+	assert.True(t, stat.ShouldBeSealed())
+}
+
+func TestDeleteSegmentStats(t *testing.T) {
+	assert.Nil(t, NewProtoFromDeleteSegmentStat(nil))
+	assert.Nil(t, NewDeleteSegmentStatFromProto(nil))
+
+	now := time.Now()
+	stat := &DeleteSegmentStats{
+		CreateTime:       now,
+		LastModifiedTime: now,
+	}
+	stat.AllocDeletes(DeleteMetrics{Rows: 3, BinarySize: 30}, now.Add(time.Minute))
+	stat.AllocDeletes(DeleteMetrics{Rows: 2, BinarySize: 20}, now.Add(2*time.Minute))
+	assert.Equal(t, uint64(5), stat.Delete.Rows)
+	assert.Equal(t, uint64(50), stat.Delete.BinarySize)
+	assert.Equal(t, now.Add(2*time.Minute), stat.LastModifiedTime)
+
+	pb := NewProtoFromDeleteSegmentStat(stat)
+	assert.Zero(t, pb.MaxBinarySize)
+	assert.Equal(t, uint64(5), pb.InsertedRows)
+	assert.Equal(t, uint64(50), pb.InsertedBinarySize)
+
+	stat2 := NewDeleteSegmentStatFromProto(pb)
+	assert.Equal(t, stat.Delete, stat2.Delete)
+	assert.Equal(t, stat.CreateTime.Unix(), stat2.CreateTime.Unix())
+	assert.Equal(t, stat.LastModifiedTime.Unix(), stat2.LastModifiedTime.Unix())
+
+	copied := stat.Copy()
+	copied.AllocDeletes(DeleteMetrics{Rows: 1, BinarySize: 1}, now)
+	assert.Equal(t, uint64(5), stat.Delete.Rows)
+}
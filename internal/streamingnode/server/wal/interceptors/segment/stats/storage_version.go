@@ -0,0 +1,56 @@
+package stats
+
+import "sort"
+
+// StorageVersionCount is the count of growing segments of a pchannel with the same storage version.
+type StorageVersionCount struct {
+	PChannel       string
+	StorageVersion int64
+	SegmentCount   int
+}
+
+// GetStorageVersionStats returns the count of growing segments of all pchannels on current node grouped by the storage version,
+// sorted by pchannel and storage version.
+func (m *StatsManager) GetStorageVersionStats() []StorageVersionCount {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	type key struct {
+		pchannel       string
+		storageVersion int64
+	}
+	counts := make(map[key]int)
+	for _, info := range m.segmentIndex {
+		counts[key{pchannel: info.PChannel, storageVersion: info.StorageVersion}]++
+	}
+	result := make([]StorageVersionCount, 0, len(counts))
+	for k, count := range counts {
+		result = append(result, StorageVersionCount{
+			PChannel:       k.pchannel,
+			StorageVersion: k.storageVersion,
+			SegmentCount:   count,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].PChannel != result[j].PChannel {
+			return result[i].PChannel < result[j].PChannel
+		}
+		return result[i].StorageVersion < result[j].StorageVersion
+	})
+	return result
+}
+
+// GetStorageVersionsOfCollection returns the count of growing segments of the collection grouped by the storage version.
+// More than one version means the storage version is toggled while the collection is being written.
+func (m *StatsManager) GetStorageVersionsOfCollection(collectionID int64) map[int64]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	versions := make(map[int64]int)
+	for _, info := range m.segmentIndex {
+		if info.CollectionID == collectionID {
+			versions[info.StorageVersion]++
+		}
+	}
+	return versions
+}
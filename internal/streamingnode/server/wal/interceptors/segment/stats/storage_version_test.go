@@ -0,0 +1,37 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestStorageVersionStats(t *testing.T) {
+	paramtable.Init()
+	m := NewStatsManager(clock.NewSystemClock())
+	assert.Empty(t, m.GetStorageVersionStats())
+
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel1", VChannel: "vchannel1", CollectionID: 1, PartitionID: 2, SegmentID: 3, StorageVersion: 2}, 3, createSegmentStats(0, 0, 1000))
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel1", VChannel: "vchannel1", CollectionID: 1, PartitionID: 3, SegmentID: 4, StorageVersion: 0}, 4, createSegmentStats(0, 0, 1000))
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel1", VChannel: "vchannel2", CollectionID: 2, PartitionID: 2, SegmentID: 5, StorageVersion: 2}, 5, createSegmentStats(0, 0, 1000))
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel0", VChannel: "vchannel3", CollectionID: 3, PartitionID: 2, SegmentID: 6, StorageVersion: 2}, 6, createSegmentStats(0, 0, 1000))
+
+	assert.Equal(t, []StorageVersionCount{
+		{PChannel: "pchannel0", StorageVersion: 2, SegmentCount: 1},
+		{PChannel: "pchannel1", StorageVersion: 0, SegmentCount: 1},
+		{PChannel: "pchannel1", StorageVersion: 2, SegmentCount: 2},
+	}, m.GetStorageVersionStats())
+	assert.Equal(t, map[int64]int{0: 1, 2: 1}, m.GetStorageVersionsOfCollection(1))
+	assert.Equal(t, map[int64]int{2: 1}, m.GetStorageVersionsOfCollection(2))
+	assert.Empty(t, m.GetStorageVersionsOfCollection(4))
+
+	m.UnregisterSealedSegment(4)
+	assert.Equal(t, map[int64]int{2: 1}, m.GetStorageVersionsOfCollection(1))
+	m.UnregisterAllStatsOnPChannel("pchannel1")
+	assert.Equal(t, []StorageVersionCount{
+		{PChannel: "pchannel0", StorageVersion: 2, SegmentCount: 1},
+	}, m.GetStorageVersionStats())
+}
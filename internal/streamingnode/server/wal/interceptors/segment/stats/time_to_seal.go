@@ -0,0 +1,56 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// defaultTimeToSealWindowSize is the sample count of the rolling window of time to seal of a collection.
+const defaultTimeToSealWindowSize = 1024
+
+// TimeToSealSummary is the summary of the time to seal of a collection.
+type TimeToSealSummary struct {
+	P99         time.Duration
+	SampleCount int
+}
+
+// newTimeToSealWindow creates a new rolling window of time to seal.
+func newTimeToSealWindow(size int) *timeToSealWindow {
+	return &timeToSealWindow{
+		samples: make([]time.Duration, 0, size),
+		size:    size,
+	}
+}
+
+// timeToSealWindow keeps the latest samples of time to seal of a collection.
+type timeToSealWindow struct {
+	samples []time.Duration
+	size    int
+	next    int // the position of the oldest sample when the window is full.
+}
+
+// Add adds a new sample into the window, the oldest sample is evicted if the window is full.
+func (w *timeToSealWindow) Add(d time.Duration) {
+	if len(w.samples) < w.size {
+		w.samples = append(w.samples, d)
+		return
+	}
+	w.samples[w.next] = d
+	w.next = (w.next + 1) % w.size
+}
+
+// Summary returns the p99 and sample count of the window.
+func (w *timeToSealWindow) Summary() TimeToSealSummary {
+	if len(w.samples) == 0 {
+		return TimeToSealSummary{}
+	}
+	sorted := make([]time.Duration, len(w.samples))
+	copy(sorted, w.samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	// nearest-rank percentile.
+	rank := (len(sorted)*99 + 99) / 100
+	return TimeToSealSummary{
+		P99:         sorted[rank-1],
+		SampleCount: len(sorted),
+	}
+}
@@ -0,0 +1,47 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+)
+
+func TestTimeToSealWindow(t *testing.T) {
+	w := newTimeToSealWindow(100)
+	assert.Equal(t, TimeToSealSummary{}, w.Summary())
+
+	w.Add(time.Minute)
+	assert.Equal(t, TimeToSealSummary{P99: time.Minute, SampleCount: 1}, w.Summary())
+
+	// 1s, 2s, ..., 100s, the p99 is 99s.
+	w = newTimeToSealWindow(100)
+	for i := 100; i > 0; i-- {
+		w.Add(time.Duration(i) * time.Second)
+	}
+	assert.Equal(t, TimeToSealSummary{P99: 99 * time.Second, SampleCount: 100}, w.Summary())
+
+	// the oldest samples 100s, 99s, ..., 51s are evicted.
+	for i := 101; i <= 150; i++ {
+		w.Add(time.Duration(i) * time.Second)
+	}
+	assert.Equal(t, TimeToSealSummary{P99: 149 * time.Second, SampleCount: 100}, w.Summary())
+}
+
+func TestStatsManagerTimeToSeal(t *testing.T) {
+	m := NewStatsManager(clock.NewSystemClock())
+	m.ObserveTimeToSeal(1, time.Minute)
+	m.ObserveTimeToSeal(1, 2*time.Minute)
+	m.ObserveTimeToSeal(2, time.Second)
+	assert.Equal(t, map[int64]TimeToSealSummary{
+		1: {P99: 2 * time.Minute, SampleCount: 2},
+		2: {P99: time.Second, SampleCount: 1},
+	}, m.GetTimeToSealSummary())
+
+	m.UnregisterTimeToSeal(1)
+	assert.Equal(t, map[int64]TimeToSealSummary{
+		2: {P99: time.Second, SampleCount: 1},
+	}, m.GetTimeToSealSummary())
+}
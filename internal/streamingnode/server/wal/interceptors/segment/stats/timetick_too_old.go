@@ -0,0 +1,139 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// maxTimeTickTooOldSamples is the max count of the time tick too old samples kept for a collection.
+// If the samples are evicted by the count, the rate is computed over the span of the kept samples.
+const maxTimeTickTooOldSamples = 4096
+
+// TimeTickTooOldStats is the stats of the assignments of a collection rejected by ErrTimeTickTooOld,
+// every rejection is redone with a refreshed time tick, so it's the canary of the lag of the time tick allocator.
+type TimeTickTooOldStats struct {
+	CollectionID  int64
+	Total         uint64            // the total count of the rejections of the collection.
+	VChannels     map[string]uint64 // the total count of the rejections of every vchannel of the collection.
+	InWindow      int               // the count of the rejections within the window.
+	Window        time.Duration     // the span that the rate is computed over.
+	RatePerSecond float64           // the rate of the rejections within the window.
+	Threshold     float64           // the warn threshold of the rate, 0 means never warn.
+	LastLag       time.Duration     // the lag of the last rejected time tick behind the time tick it's compared with.
+}
+
+// Exceeded checks if the rate crosses the warn threshold.
+func (s TimeTickTooOldStats) Exceeded() bool {
+	return s.Threshold > 0 && s.RatePerSecond > s.Threshold
+}
+
+// newTimeTickTooOldCounter creates a new time tick too old counter of a collection.
+func newTimeTickTooOldCounter() *timeTickTooOldCounter {
+	return &timeTickTooOldCounter{
+		vchannels: make(map[string]uint64),
+	}
+}
+
+// timeTickTooOldCounter counts the time tick too old rejections of a collection.
+type timeTickTooOldCounter struct {
+	total      uint64
+	vchannels  map[string]uint64
+	rejectedAt []time.Time // the rejection time within the window in ascending order.
+	lastLag    time.Duration
+	lastWarnAt time.Time // the time of the last warning, the warning is repeated at most once per window.
+}
+
+// add adds a new rejection sample and evicts the expired ones.
+func (c *timeTickTooOldCounter) add(now time.Time, window time.Duration, vchannel string, lag time.Duration) {
+	c.total++
+	c.vchannels[vchannel]++
+	c.lastLag = lag
+	c.rejectedAt = append(c.rejectedAt, now)
+	c.expire(now, window)
+}
+
+// expire evicts the samples out of the window or the count limit.
+func (c *timeTickTooOldCounter) expire(now time.Time, window time.Duration) {
+	i := 0
+	for i < len(c.rejectedAt) && (len(c.rejectedAt)-i > maxTimeTickTooOldSamples || now.Sub(c.rejectedAt[i]) > window) {
+		i++
+	}
+	c.rejectedAt = c.rejectedAt[i:]
+}
+
+// stats computes the stats of the counter.
+func (c *timeTickTooOldCounter) stats(now time.Time, window time.Duration, threshold float64) TimeTickTooOldStats {
+	c.expire(now, window)
+	vchannels := make(map[string]uint64, len(c.vchannels))
+	for vchannel, cnt := range c.vchannels {
+		vchannels[vchannel] = cnt
+	}
+	s := TimeTickTooOldStats{
+		Total:     c.total,
+		VChannels: vchannels,
+		InWindow:  len(c.rejectedAt),
+		Window:    window,
+		Threshold: threshold,
+		LastLag:   c.lastLag,
+	}
+	if len(c.rejectedAt) == 0 || window <= 0 {
+		return s
+	}
+	if len(c.rejectedAt) >= maxTimeTickTooOldSamples {
+		if span := now.Sub(c.rejectedAt[0]); span > 0 && span < window {
+			s.Window = span
+		}
+	}
+	s.RatePerSecond = float64(len(c.rejectedAt)) / s.Window.Seconds()
+	return s
+}
+
+// ObserveTimeTickTooOld records an assignment of the collection on the vchannel is rejected by ErrTimeTickTooOld,
+// the lag is the duration that the rejected time tick falls behind the time tick it's compared with.
+// Return true if the rate crosses the warn threshold and the warning is not repeated within the window.
+func (m *StatsManager) ObserveTimeTickTooOld(collectionID int64, vchannel string, lag time.Duration) (TimeTickTooOldStats, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	window := paramtable.Get().StreamingCfg.WALSegmentAssignTimeTickTooOldWindow.GetAsDurationByParse()
+	threshold := paramtable.Get().StreamingCfg.WALSegmentAssignTimeTickTooOldWarnThreshold.GetAsFloat()
+	if _, ok := m.timeTickTooOld[collectionID]; !ok {
+		m.timeTickTooOld[collectionID] = newTimeTickTooOldCounter()
+	}
+	counter := m.timeTickTooOld[collectionID]
+	now := m.clock.Now()
+	counter.add(now, window, vchannel, lag)
+	s := counter.stats(now, window, threshold)
+	s.CollectionID = collectionID
+
+	if !s.Exceeded() || (!counter.lastWarnAt.IsZero() && now.Sub(counter.lastWarnAt) < window) {
+		return s, false
+	}
+	counter.lastWarnAt = now
+	return s, true
+}
+
+// GetTimeTickTooOldStats returns the time tick too old stats of the collection.
+func (m *StatsManager) GetTimeTickTooOldStats(collectionID int64) TimeTickTooOldStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	window := paramtable.Get().StreamingCfg.WALSegmentAssignTimeTickTooOldWindow.GetAsDurationByParse()
+	threshold := paramtable.Get().StreamingCfg.WALSegmentAssignTimeTickTooOldWarnThreshold.GetAsFloat()
+	counter, ok := m.timeTickTooOld[collectionID]
+	if !ok {
+		return TimeTickTooOldStats{CollectionID: collectionID, VChannels: map[string]uint64{}, Window: window, Threshold: threshold}
+	}
+	s := counter.stats(m.clock.Now(), window, threshold)
+	s.CollectionID = collectionID
+	return s
+}
+
+// UnregisterTimeTickTooOld removes the time tick too old stats of the collection.
+func (m *StatsManager) UnregisterTimeTickTooOld(collectionID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.timeTickTooOld, collectionID)
+}
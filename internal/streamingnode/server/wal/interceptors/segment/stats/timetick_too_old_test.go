@@ -0,0 +1,77 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestTimeTickTooOld(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.WALSegmentAssignTimeTickTooOldWarnThreshold.Key, "1")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignTimeTickTooOldWarnThreshold.Key)
+	params.Save(params.StreamingCfg.WALSegmentAssignTimeTickTooOldWindow.Key, "10s")
+	defer params.Reset(params.StreamingCfg.WALSegmentAssignTimeTickTooOldWindow.Key)
+
+	c := clock.NewFakeClock(time.Unix(1000, 0))
+	m := NewStatsManager(c)
+	s := m.GetTimeTickTooOldStats(1)
+	assert.Equal(t, TimeTickTooOldStats{CollectionID: 1, VChannels: map[string]uint64{}, Window: 10 * time.Second, Threshold: 1}, s)
+	assert.False(t, s.Exceeded())
+
+	// 10 rejections in 10s is 1 per second, the threshold is not crossed.
+	for i := 0; i < 10; i++ {
+		_, warn := m.ObserveTimeTickTooOld(1, "v1", time.Millisecond)
+		assert.False(t, warn)
+	}
+	s = m.GetTimeTickTooOldStats(1)
+	assert.Equal(t, 10, s.InWindow)
+	assert.Equal(t, float64(1), s.RatePerSecond)
+
+	// the rate crosses the threshold, the warning is not repeated within the window.
+	s, warn := m.ObserveTimeTickTooOld(1, "v2", 2*time.Second)
+	assert.True(t, warn)
+	assert.True(t, s.Exceeded())
+	assert.Equal(t, int64(1), s.CollectionID)
+	assert.Equal(t, uint64(11), s.Total)
+	assert.Equal(t, map[string]uint64{"v1": 10, "v2": 1}, s.VChannels)
+	assert.Equal(t, 2*time.Second, s.LastLag)
+	for i := 0; i < 10; i++ {
+		_, warn = m.ObserveTimeTickTooOld(1, "v2", time.Second)
+		assert.False(t, warn)
+	}
+
+	// the other collection is tracked separately.
+	_, warn = m.ObserveTimeTickTooOld(2, "v3", time.Second)
+	assert.False(t, warn)
+	assert.Equal(t, uint64(1), m.GetTimeTickTooOldStats(2).Total)
+
+	// the rejections are expired out of the window, but the totals are kept.
+	c.Advance(10*time.Second + time.Millisecond)
+	s = m.GetTimeTickTooOldStats(1)
+	assert.Zero(t, s.InWindow)
+	assert.Zero(t, s.RatePerSecond)
+	assert.Equal(t, uint64(21), s.Total)
+
+	// the warning is repeated after the window if the rate still crosses the threshold.
+	for i := 0; i < 10; i++ {
+		_, warn = m.ObserveTimeTickTooOld(1, "v1", time.Second)
+		assert.False(t, warn)
+	}
+	_, warn = m.ObserveTimeTickTooOld(1, "v1", time.Second)
+	assert.True(t, warn)
+
+	// the threshold 0 never warns.
+	params.Save(params.StreamingCfg.WALSegmentAssignTimeTickTooOldWarnThreshold.Key, "0")
+	m.UnregisterTimeTickTooOld(1)
+	assert.Zero(t, m.GetTimeTickTooOldStats(1).Total)
+	for i := 0; i < 100; i++ {
+		_, warn = m.ObserveTimeTickTooOld(1, "v1", time.Second)
+		assert.False(t, warn)
+	}
+}
@@ -0,0 +1,110 @@
+package stats
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// GrowingRollup is the rollup of the growing segments of a vchannel or a collection.
+// The insert is attributed to the vchannel that the insert arrives on,
+// so the rollups of all vchannels of a collection are summed up into the rollup of the collection.
+type GrowingRollup struct {
+	PChannel     string // empty for the rollup of collection, the vchannels of a collection may be on different pchannels.
+	VChannel     string // empty for the rollup of collection.
+	CollectionID int64
+	SegmentCount int
+	Insert       InsertMetrics
+}
+
+// collectVChannel collects the insert metrics and the new registered segments into the rollup of the vchannel of the segment.
+func (m *StatsManager) collectVChannel(info SegmentBelongs, insert InsertMetrics, segments int) {
+	rollup, ok := m.vchannelStats[info.VChannel]
+	if !ok {
+		rollup = &GrowingRollup{
+			PChannel:     info.PChannel,
+			VChannel:     info.VChannel,
+			CollectionID: info.CollectionID,
+		}
+		m.vchannelStats[info.VChannel] = rollup
+	}
+	rollup.SegmentCount += segments
+	rollup.Insert.Collect(insert)
+	observeVChannelGrowing(rollup)
+}
+
+// subtractVChannel subtracts the insert metrics and the unregistered segments from the rollup of the vchannel of the segment,
+// the rollup is removed if there's no growing segment on the vchannel.
+func (m *StatsManager) subtractVChannel(info SegmentBelongs, insert InsertMetrics, segments int) {
+	rollup, ok := m.vchannelStats[info.VChannel]
+	if !ok {
+		return
+	}
+	rollup.SegmentCount -= segments
+	rollup.Insert.Subtract(insert)
+	if rollup.SegmentCount <= 0 {
+		delete(m.vchannelStats, info.VChannel)
+		deleteVChannelGrowing(rollup)
+		return
+	}
+	observeVChannelGrowing(rollup)
+}
+
+// GetVChannelGrowingStats returns the rollup of the growing segments of all vchannels on current node, sorted by vchannel.
+func (m *StatsManager) GetVChannelGrowingStats() []GrowingRollup {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rollups := make([]GrowingRollup, 0, len(m.vchannelStats))
+	for _, rollup := range m.vchannelStats {
+		rollups = append(rollups, *rollup)
+	}
+	sort.Slice(rollups, func(i, j int) bool { return rollups[i].VChannel < rollups[j].VChannel })
+	return rollups
+}
+
+// GetCollectionGrowingStats returns the rollup of the growing segments of all collections on current node.
+func (m *StatsManager) GetCollectionGrowingStats() map[int64]GrowingRollup {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rollups := make(map[int64]GrowingRollup)
+	for _, vchannel := range m.vchannelStats {
+		rollup := rollups[vchannel.CollectionID]
+		rollup.CollectionID = vchannel.CollectionID
+		rollup.SegmentCount += vchannel.SegmentCount
+		rollup.Insert.Collect(vchannel.Insert)
+		rollups[vchannel.CollectionID] = rollup
+	}
+	return rollups
+}
+
+// vchannelGrowingLabels returns the metric labels of the rollup of vchannel.
+func vchannelGrowingLabels(rollup *GrowingRollup) prometheus.Labels {
+	return prometheus.Labels{
+		metrics.NodeIDLabelName:          paramtable.GetStringNodeID(),
+		metrics.WALChannelLabelName:      rollup.PChannel,
+		metrics.WALVChannelLabelName:     rollup.VChannel,
+		metrics.WALCollectionIDLabelName: strconv.FormatInt(rollup.CollectionID, 10),
+	}
+}
+
+// observeVChannelGrowing exports the rollup of vchannel into the metrics.
+func observeVChannelGrowing(rollup *GrowingRollup) {
+	labels := vchannelGrowingLabels(rollup)
+	metrics.WALVChannelGrowingSegmentTotal.With(labels).Set(float64(rollup.SegmentCount))
+	metrics.WALVChannelGrowingRows.With(labels).Set(float64(rollup.Insert.Rows))
+	metrics.WALVChannelGrowingBytes.With(labels).Set(float64(rollup.Insert.BinarySize))
+}
+
+// deleteVChannelGrowing removes the exported metrics of the rollup of vchannel.
+func deleteVChannelGrowing(rollup *GrowingRollup) {
+	labels := vchannelGrowingLabels(rollup)
+	metrics.WALVChannelGrowingSegmentTotal.Delete(labels)
+	metrics.WALVChannelGrowingRows.Delete(labels)
+	metrics.WALVChannelGrowingBytes.Delete(labels)
+}
@@ -0,0 +1,54 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/segment/clock"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestVChannelGrowingStats(t *testing.T) {
+	paramtable.Init()
+	m := NewStatsManager(clock.NewSystemClock())
+
+	// two vchannels of one collection on one pchannel.
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel1", CollectionID: 1, PartitionID: 2, SegmentID: 3}, 3, createSegmentStats(10, 100, 1000))
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel1", CollectionID: 1, PartitionID: 3, SegmentID: 4}, 4, createSegmentStats(0, 0, 1000))
+	m.RegisterNewGrowingSegment(SegmentBelongs{PChannel: "pchannel", VChannel: "vchannel2", CollectionID: 1, PartitionID: 2, SegmentID: 5}, 5, createSegmentStats(20, 200, 1000))
+
+	// the insert is attributed to the vchannel that it arrives on.
+	assert.NoError(t, m.AllocRows(4, InsertMetrics{Rows: 5, BinarySize: 50}))
+	assert.NoError(t, m.AllocRows(5, InsertMetrics{Rows: 7, BinarySize: 70}))
+	m.ReleaseRows(5, InsertMetrics{Rows: 2, BinarySize: 20})
+
+	rollups := m.GetVChannelGrowingStats()
+	assert.Equal(t, []GrowingRollup{
+		{PChannel: "pchannel", VChannel: "vchannel1", CollectionID: 1, SegmentCount: 2, Insert: InsertMetrics{Rows: 15, BinarySize: 150}},
+		{PChannel: "pchannel", VChannel: "vchannel2", CollectionID: 1, SegmentCount: 1, Insert: InsertMetrics{Rows: 25, BinarySize: 250}},
+	}, rollups)
+
+	// the attribution sums match the totals.
+	sum := InsertMetrics{}
+	segments := 0
+	for _, rollup := range rollups {
+		sum.Collect(rollup.Insert)
+		segments += rollup.SegmentCount
+	}
+	assert.Equal(t, m.totalStats, sum)
+	assert.Equal(t, *m.pchannelStats["pchannel"], sum)
+	assert.Equal(t, len(m.segmentStats), segments)
+	collections := m.GetCollectionGrowingStats()
+	assert.Len(t, collections, 1)
+	assert.Equal(t, GrowingRollup{CollectionID: 1, SegmentCount: segments, Insert: sum}, collections[1])
+
+	// the empty segment keeps the rollup of its vchannel.
+	m.UnregisterSealedSegment(3)
+	rollups = m.GetVChannelGrowingStats()
+	assert.Equal(t, GrowingRollup{PChannel: "pchannel", VChannel: "vchannel1", CollectionID: 1, SegmentCount: 1, Insert: InsertMetrics{Rows: 5, BinarySize: 50}}, rollups[0])
+
+	m.UnregisterAllStatsOnPChannel("pchannel")
+	assert.Empty(t, m.GetVChannelGrowingStats())
+	assert.Empty(t, m.GetCollectionGrowingStats())
+}
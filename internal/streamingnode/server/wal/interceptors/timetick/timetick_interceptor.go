@@ -9,6 +9,7 @@ import (
 
 	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors"
+	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/redo"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/timetick/ack"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/interceptors/txn"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/utility"
@@ -165,6 +166,16 @@ func (impl *timeTickAppendInterceptor) handleCommit(ctx context.Context, msg mes
 		return nil, err
 	}
 
+	// The txn data may be written before a fence of the touched collections, validate it before commit.
+	if err = impl.txnManager.ValidateCommit(session); err != nil {
+		if errors.Is(err, txn.ErrTxnCommitDelayed) {
+			// redo the commit to refresh a new timetick until the flush of the fence is appended.
+			utility.MarkAppendRedo(ctx)
+			return nil, redo.ErrRedo
+		}
+		return nil, err
+	}
+
 	// Start commit the message.
 	if err = session.RequestCommitAndWait(ctx, msg.TimeTick()); err != nil {
 		return nil, err
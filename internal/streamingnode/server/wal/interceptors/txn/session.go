@@ -2,6 +2,7 @@ package txn
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/metricsutil"
@@ -24,6 +25,7 @@ func newTxnSession(
 	return &TxnSession{
 		mu:            sync.Mutex{},
 		vchannel:      vchannel,
+		beginTimetick: timetick,
 		lastTimetick:  timetick,
 		txnContext:    txnContext,
 		inFlightCount: 0,
@@ -38,6 +40,7 @@ func newTxnSession(
 type TxnSession struct {
 	mu               sync.Mutex
 	vchannel         string                       // The vchannel of the session.
+	beginTimetick    uint64                       // The timetick of the begin message of the session.
 	lastTimetick     uint64                       // session last timetick.
 	expired          bool                         // The flag indicates the transaction has trigger expired once.
 	txnContext       message.TxnContext           // transaction id of the session
@@ -47,6 +50,18 @@ type TxnSession struct {
 	rollback         bool                         // The flag indicates the transaction is rollbacked.
 	cleanupCallbacks []func()                     // The cleanup callbacks function for the session.
 	metricsGuard     *metricsutil.TxnMetricsGuard // The metrics guard for the session.
+	writes           map[int64]*txnSegmentWrite   // The data written into segments by the session, keyed by segment id.
+	pinned           []int64                      // The segments pinned by the session, their seal waits for the session done.
+	onDone           func(pinned []int64)         // The callback to notify the pinned segments are released when the session is done.
+	maxBinarySize    uint64                       // The max binary size of the data written by the session, 0 means not limited.
+}
+
+// txnSegmentWrite is the data written into a segment by the session.
+type txnSegmentWrite struct {
+	collectionID int64
+	rows         uint64
+	binarySize   uint64
+	minTimeTick  uint64 // the minimal timetick of the data written into the segment.
 }
 
 // VChannel returns the vchannel of the session.
@@ -54,6 +69,11 @@ func (s *TxnSession) VChannel() string {
 	return s.vchannel
 }
 
+// BeginTimeTick returns the timetick of the begin message of the session.
+func (s *TxnSession) BeginTimeTick() uint64 {
+	return s.beginTimetick
+}
+
 // TxnContext returns the txn context of the session.
 func (s *TxnSession) TxnContext() message.TxnContext {
 	return s.txnContext
@@ -174,14 +194,16 @@ func (s *TxnSession) RequestCommitAndWait(ctx context.Context, timetick uint64)
 // CommitDone marks the transaction as committed.
 func (s *TxnSession) CommitDone() {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 
 	if s.state != message.TxnStateOnCommit {
 		// unreachable code here.
 		panic("invalid state for commit done")
 	}
 	s.state = message.TxnStateCommitted
-	s.cleanup()
+	released := s.cleanup()
+	s.mu.Unlock()
+
+	s.notifyDone(released)
 }
 
 // RequestRollback rolls back the transaction.
@@ -202,14 +224,117 @@ func (s *TxnSession) RequestRollback(ctx context.Context, timetick uint64) error
 // RollbackDone marks the transaction as rollbacked.
 func (s *TxnSession) RollbackDone() {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 
 	if s.state != message.TxnStateOnRollback {
 		// unreachable code here.
 		panic("invalid state for rollback done")
 	}
 	s.state = message.TxnStateRollbacked
-	s.cleanup()
+	released := s.cleanup()
+	s.mu.Unlock()
+
+	s.notifyDone(released)
+}
+
+// RecordSegmentWrite records the data written into the segment of the collection at the timetick by the session.
+func (s *TxnSession) RecordSegmentWrite(collectionID int64, segmentID int64, rows uint64, binarySize uint64, timetick uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.writes == nil {
+		s.writes = make(map[int64]*txnSegmentWrite)
+	}
+	w, ok := s.writes[segmentID]
+	if !ok {
+		w = &txnSegmentWrite{collectionID: collectionID, minTimeTick: timetick}
+		s.writes[segmentID] = w
+	}
+	w.rows += rows
+	w.binarySize += binarySize
+	if timetick < w.minTimeTick {
+		w.minTimeTick = timetick
+	}
+}
+
+// MaxBinarySize returns the max binary size of the data written by the session, 0 means not limited.
+// The session begun under the warn pressure of the pchannel is limited.
+func (s *TxnSession) MaxBinarySize() uint64 {
+	return s.maxBinarySize
+}
+
+// CheckWriteLimit checks if the data of the binary size can be written by the session without exceeding its limit.
+// A retryable error is returned if the limit is exceeded, the txn should be rolled back and retried later.
+func (s *TxnSession) CheckWriteLimit(binarySize uint64) error {
+	if s.maxBinarySize == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	written := uint64(0)
+	for _, w := range s.writes {
+		written += w.binarySize
+	}
+	if written+binarySize > s.maxBinarySize {
+		return status.NewRetryLater("txn %d writes %d bytes, which exceeds the limit %d bytes of the txn begun under the pressure",
+			s.txnContext.TxnID, written+binarySize, s.maxBinarySize)
+	}
+	return nil
+}
+
+// WrittenCollections returns the minimal timetick of the data written by the session, keyed by collection id.
+func (s *TxnSession) WrittenCollections() map[int64]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	collections := make(map[int64]uint64)
+	for _, w := range s.writes {
+		if tt, ok := collections[w.collectionID]; !ok || w.minTimeTick < tt {
+			collections[w.collectionID] = w.minTimeTick
+		}
+	}
+	return collections
+}
+
+// IsOpenAtCollection checks if the session is still open and has written data into the segments of the collection.
+// The data of an open session is only visible after the session is committed.
+func (s *TxnSession) IsOpenAtCollection(collectionID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch s.state {
+	case message.TxnStateCommitted, message.TxnStateOnRollback, message.TxnStateRollbacked:
+		return false
+	}
+	for _, w := range s.writes {
+		if w.collectionID == collectionID {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteSummary returns the total rows, binary size and the per segment writes of the session.
+// The segment writes are sorted by segment id.
+func (s *TxnSession) WriteSummary() (uint64, uint64, []*message.TxnSegmentWrite) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var rows, binarySize uint64
+	segments := make([]*message.TxnSegmentWrite, 0, len(s.writes))
+	for segmentID, w := range s.writes {
+		rows += w.rows
+		binarySize += w.binarySize
+		segments = append(segments, &message.TxnSegmentWrite{
+			SegmentId:  segmentID,
+			Rows:       w.rows,
+			BinarySize: w.binarySize,
+		})
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].SegmentId < segments[j].SegmentId
+	})
+	return rows, binarySize, segments
 }
 
 // RegisterCleanup registers the cleanup function for the session.
@@ -226,22 +351,48 @@ func (s *TxnSession) RegisterCleanup(f func(), ts uint64) {
 	s.cleanupCallbacks = append(s.cleanupCallbacks, f)
 }
 
+// PinSegment pins the segment by the session, the release function will be called when the session is expired or done.
+// The seal of the pinned segment waits for the session done,
+// so the pinned segments are notified to be re-evaluated right away once the session is done.
+func (s *TxnSession) PinSegment(segmentID int64, release func(), ts uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isExpiredOrDone(ts) {
+		panic("unreachable code: pin segment for expired or done session")
+	}
+	s.cleanupCallbacks = append(s.cleanupCallbacks, release)
+	s.pinned = append(s.pinned, segmentID)
+}
+
 // Cleanup cleans up the session.
 func (s *TxnSession) Cleanup() {
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	released := s.cleanup()
+	s.mu.Unlock()
 
-	s.cleanup()
+	s.notifyDone(released)
 }
 
-// cleanup calls the cleanup functions.
-func (s *TxnSession) cleanup() {
+// cleanup calls the cleanup functions, returns the released pinned segments.
+func (s *TxnSession) cleanup() []int64 {
 	for _, f := range s.cleanupCallbacks {
 		f()
 	}
 	s.metricsGuard.Done(s.state)
 	s.metricsGuard = nil
 	s.cleanupCallbacks = nil
+	released := s.pinned
+	s.pinned = nil
+	return released
+}
+
+// notifyDone notifies the released pinned segments, it should be called without the lock held.
+func (s *TxnSession) notifyDone(released []int64) {
+	if len(released) == 0 || s.onDone == nil {
+		return
+	}
+	s.onDone(released)
 }
 
 // getDoneChan returns the channel for waiting the transaction committed.
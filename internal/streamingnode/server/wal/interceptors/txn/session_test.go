@@ -115,6 +115,238 @@ func TestSession(t *testing.T) {
 	assert.Equal(t, message.TxnStateOnRollback, session.state)
 }
 
+func TestSessionWriteSummary(t *testing.T) {
+	resource.InitForTest(t)
+	ctx := context.Background()
+
+	m := NewTxnManager(types.PChannelInfo{Name: "test"}, nil)
+	<-m.RecoverDone()
+	session, err := m.BeginNewTxn(ctx, newBeginTxnMessage(0, 10*time.Millisecond))
+	assert.NoError(t, err)
+	session.BeginDone()
+
+	rows, binarySize, segments := session.WriteSummary()
+	assert.Zero(t, rows)
+	assert.Zero(t, binarySize)
+	assert.Empty(t, segments)
+
+	session.RecordSegmentWrite(1, 2, 10, 100, 3)
+	session.RecordSegmentWrite(1, 1, 5, 50, 2)
+	session.RecordSegmentWrite(1, 2, 20, 200, 1)
+	rows, binarySize, segments = session.WriteSummary()
+	assert.Equal(t, uint64(35), rows)
+	assert.Equal(t, uint64(350), binarySize)
+	assert.Len(t, segments, 2)
+	assert.Equal(t, int64(1), segments[0].GetSegmentId())
+	assert.Equal(t, uint64(5), segments[0].GetRows())
+	assert.Equal(t, uint64(50), segments[0].GetBinarySize())
+	assert.Equal(t, int64(2), segments[1].GetSegmentId())
+	assert.Equal(t, uint64(30), segments[1].GetRows())
+	assert.Equal(t, uint64(300), segments[1].GetBinarySize())
+	assert.Equal(t, map[int64]uint64{1: 1}, session.WrittenCollections())
+}
+
+func TestManagerOpenTxnsOfCollection(t *testing.T) {
+	resource.InitForTest(t)
+	ctx := context.Background()
+
+	m := NewTxnManager(types.PChannelInfo{Name: "test"}, nil)
+	<-m.RecoverDone()
+	assert.Empty(t, m.OpenTxnsOfCollection(1))
+
+	// a txn without any write is not open at the collection.
+	idle, err := m.BeginNewTxn(ctx, newBeginTxnMessage(10, time.Second))
+	assert.NoError(t, err)
+	idle.BeginDone()
+
+	later, err := m.BeginNewTxn(ctx, newBeginTxnMessage(30, time.Second))
+	assert.NoError(t, err)
+	later.BeginDone()
+	later.RecordSegmentWrite(1, 100, 10, 100, 31)
+
+	earlier, err := m.BeginNewTxn(ctx, newBeginTxnMessage(20, time.Second))
+	assert.NoError(t, err)
+	earlier.BeginDone()
+	earlier.RecordSegmentWrite(1, 101, 10, 100, 21)
+	earlier.RecordSegmentWrite(2, 200, 10, 100, 22)
+
+	txns := m.OpenTxnsOfCollection(1)
+	assert.Len(t, txns, 2)
+	assert.Equal(t, int64(earlier.TxnContext().TxnID), txns[0].GetTxnId())
+	assert.Equal(t, uint64(20), txns[0].GetBeginTimeTick())
+	assert.Equal(t, int64(later.TxnContext().TxnID), txns[1].GetTxnId())
+	assert.Equal(t, uint64(30), txns[1].GetBeginTimeTick())
+
+	txns = m.OpenTxnsOfCollection(2)
+	assert.Len(t, txns, 1)
+	assert.Equal(t, int64(earlier.TxnContext().TxnID), txns[0].GetTxnId())
+
+	// the txn on commit is still open until it's committed.
+	assert.NoError(t, later.RequestCommitAndWait(ctx, 40))
+	assert.Len(t, m.OpenTxnsOfCollection(1), 2)
+	later.CommitDone()
+	assert.Len(t, m.OpenTxnsOfCollection(1), 1)
+
+	// the rollbacked data is never committed.
+	assert.NoError(t, earlier.RequestRollback(ctx, 50))
+	assert.Empty(t, m.OpenTxnsOfCollection(1))
+	assert.Empty(t, m.OpenTxnsOfCollection(2))
+}
+
+// txnDoneListenerFunc is a function adapter of TxnDoneListener.
+type txnDoneListenerFunc func(pinnedSegmentIDs []int64)
+
+func (f txnDoneListenerFunc) OnTxnDone(pinnedSegmentIDs []int64) {
+	f(pinnedSegmentIDs)
+}
+
+// collectionFenceViewFunc is a function adapter of CollectionFenceView.
+type collectionFenceViewFunc func(collectionID int64) (uint64, bool)
+
+func (f collectionFenceViewFunc) GetCollectionFence(collectionID int64) (uint64, bool) {
+	return f(collectionID)
+}
+
+func TestManagerValidateCommit(t *testing.T) {
+	resource.InitForTest(t)
+	ctx := context.Background()
+
+	m := NewTxnManager(types.PChannelInfo{Name: "test"}, nil)
+	<-m.RecoverDone()
+	session, err := m.BeginNewTxn(ctx, newBeginTxnMessage(10, time.Second))
+	assert.NoError(t, err)
+	session.BeginDone()
+	session.RecordSegmentWrite(1, 100, 10, 100, 20)
+	session.RecordSegmentWrite(2, 200, 10, 100, 30)
+
+	// no fence view is bound.
+	assert.NoError(t, m.ValidateCommit(session))
+
+	fences := map[int64]uint64{1: 15, 2: 15}
+	flushPending := true
+	m.BindCollectionFenceView(collectionFenceViewFunc(func(collectionID int64) (uint64, bool) {
+		return fences[collectionID], flushPending
+	}))
+	// all data is written after the fences.
+	assert.NoError(t, m.ValidateCommit(session))
+
+	// the data of collection 2 is written before the fence.
+	fences[2] = 30
+	assert.ErrorIs(t, m.ValidateCommit(session), ErrTxnCommitDelayed)
+	flushPending = false
+	assert.NoError(t, m.ValidateCommit(session))
+
+	paramtable.Get().Save(paramtable.Get().StreamingCfg.TxnFencedCommitPolicy.Key, FencedCommitPolicyFail)
+	defer paramtable.Get().Reset(paramtable.Get().StreamingCfg.TxnFencedCommitPolicy.Key)
+	err = m.ValidateCommit(session)
+	assert.True(t, status.AsStreamingError(err).IsTxnFenced())
+}
+
+// pressureViewFunc is a function adapter of PressureView.
+type pressureViewFunc func() PressureLevel
+
+func (f pressureViewFunc) PressureLevel() PressureLevel {
+	return f()
+}
+
+func TestManagerBeginUnderPressure(t *testing.T) {
+	resource.InitForTest(t)
+	ctx := context.Background()
+	params := paramtable.Get()
+	params.Save(params.StreamingCfg.TxnPressureWarnMaxBinarySize.Key, "300")
+	defer params.Reset(params.StreamingCfg.TxnPressureWarnMaxBinarySize.Key)
+
+	m := NewTxnManager(types.PChannelInfo{Name: "test"}, nil)
+	<-m.RecoverDone()
+	level := PressureLevelNormal
+	m.BindPressureView(pressureViewFunc(func() PressureLevel { return level }))
+
+	// the txn begun under the normal pressure is not limited.
+	normal, err := m.BeginNewTxn(ctx, newBeginTxnMessage(10, time.Second))
+	assert.NoError(t, err)
+	normal.BeginDone()
+	assert.Zero(t, normal.MaxBinarySize())
+	assert.NoError(t, normal.CheckWriteLimit(1024*1024))
+
+	// the txn begun under the warn pressure is limited by a reduced max binary size.
+	level = PressureLevelWarn
+	limited, err := m.BeginNewTxn(ctx, newBeginTxnMessage(10, time.Second))
+	assert.NoError(t, err)
+	limited.BeginDone()
+	assert.Equal(t, uint64(300), limited.MaxBinarySize())
+	assert.NoError(t, limited.CheckWriteLimit(200))
+	limited.RecordSegmentWrite(1, 100, 10, 200, 20)
+	assert.NoError(t, limited.CheckWriteLimit(100))
+	err = limited.CheckWriteLimit(101)
+	assert.True(t, status.AsStreamingError(err).IsRetryLater())
+
+	// the new txn is rejected under the critical pressure with a retryable error.
+	level = PressureLevelCritical
+	_, err = m.BeginNewTxn(ctx, newBeginTxnMessage(10, time.Second))
+	assert.True(t, status.AsStreamingError(err).IsRetryLater())
+
+	// the existing txns are still allowed to finish.
+	assert.NoError(t, limited.AddNewMessage(ctx, 20))
+	limited.AddNewMessageDoneAndKeepalive(20)
+	assert.NoError(t, limited.RequestCommitAndWait(ctx, 30))
+	limited.CommitDone()
+	assert.NoError(t, normal.RequestCommitAndWait(ctx, 30))
+	normal.CommitDone()
+
+	// the txn is begun again after the pressure is released.
+	level = PressureLevelNormal
+	_, err = m.BeginNewTxn(ctx, newBeginTxnMessage(10, time.Second))
+	assert.NoError(t, err)
+}
+
+func TestManagerTxnDoneListener(t *testing.T) {
+	resource.InitForTest(t)
+	ctx := context.Background()
+
+	m := NewTxnManager(types.PChannelInfo{Name: "test"}, nil)
+	<-m.RecoverDone()
+	var notified [][]int64
+	m.BindTxnDoneListener(txnDoneListenerFunc(func(pinnedSegmentIDs []int64) {
+		notified = append(notified, pinnedSegmentIDs)
+	}))
+
+	released := atomic.NewInt32(0)
+	pin := func(session *TxnSession, segmentIDs ...int64) {
+		for _, segmentID := range segmentIDs {
+			session.PinSegment(segmentID, func() { released.Inc() }, 0)
+		}
+	}
+
+	// the pinned segments are notified after they're released by the commit.
+	committed, err := m.BeginNewTxn(ctx, newBeginTxnMessage(10, time.Second))
+	assert.NoError(t, err)
+	committed.BeginDone()
+	pin(committed, 100, 101)
+	assert.NoError(t, committed.RequestCommitAndWait(ctx, 20))
+	committed.CommitDone()
+	assert.Equal(t, int32(2), released.Load())
+	assert.Equal(t, [][]int64{{100, 101}}, notified)
+
+	// the rollbacked txn notifies its pinned segments too.
+	rollbacked, err := m.BeginNewTxn(ctx, newBeginTxnMessage(10, time.Second))
+	assert.NoError(t, err)
+	rollbacked.BeginDone()
+	pin(rollbacked, 102)
+	assert.NoError(t, rollbacked.RequestRollback(ctx, 20))
+	rollbacked.RollbackDone()
+	assert.Equal(t, [][]int64{{100, 101}, {102}}, notified)
+
+	// the txn without pinned segments is not notified, and the released segments are never notified twice.
+	idle, err := m.BeginNewTxn(ctx, newBeginTxnMessage(10, time.Second))
+	assert.NoError(t, err)
+	idle.BeginDone()
+	assert.NoError(t, idle.RequestCommitAndWait(ctx, 20))
+	idle.CommitDone()
+	m.CleanupTxnUntil(tsoutil.AddPhysicalDurationOnTs(20, 10*time.Second))
+	assert.Len(t, notified, 2)
+	assert.Equal(t, int32(3), released.Load())
+}
+
 func TestManager(t *testing.T) {
 	resource.InitForTest(t)
 	m := NewTxnManager(types.PChannelInfo{Name: "test"}, nil)
@@ -2,9 +2,12 @@ package txn
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/streamingnode/server/resource"
@@ -48,6 +51,9 @@ func NewTxnManager(pchannel types.PChannelInfo, uncommittedTxnBuilders map[messa
 		closed:                    nil,
 		metrics:                   m,
 	}
+	for _, session := range sessions {
+		session.onDone = txnManager.notifyTxnDone
+	}
 	txnManager.notifyRecoverDone()
 	txnManager.SetLogger(resource.Resource().Logger().With(log.FieldComponent("txn-manager")))
 	txnManager.Logger().Info("txn manager recovered with txn", zap.Int64s("txnIDs", sessionIDs))
@@ -66,6 +72,126 @@ type TxnManager struct {
 	sessions                  map[message.TxnID]*TxnSession
 	closed                    lifetime.SafeChan
 	metrics                   *metricsutil.TxnMetrics
+	doneListener              atomic.Pointer[TxnDoneListener]
+	fenceView                 atomic.Pointer[CollectionFenceView]
+	pressureView              atomic.Pointer[PressureView]
+}
+
+// TxnDoneListener listens the segments released by the done txns.
+type TxnDoneListener interface {
+	// OnTxnDone is called with the segments pinned by the txn when the txn is committed, rollbacked or expired.
+	// It's called in the append path of the txn, so it should never block.
+	OnTxnDone(pinnedSegmentIDs []int64)
+}
+
+// BindTxnDoneListener binds the listener of the done txns.
+func (m *TxnManager) BindTxnDoneListener(listener TxnDoneListener) {
+	m.doneListener.Store(&listener)
+}
+
+// notifyTxnDone notifies the listener with the segments released by the done txn.
+func (m *TxnManager) notifyTxnDone(pinnedSegmentIDs []int64) {
+	listener := m.doneListener.Load()
+	if listener == nil {
+		return
+	}
+	(*listener).OnTxnDone(pinnedSegmentIDs)
+}
+
+const (
+	// FencedCommitPolicyDelay delays the fenced commit until the flush of the fence is appended.
+	FencedCommitPolicyDelay = "delay"
+	// FencedCommitPolicyFail fails the fenced commit with a fenced error.
+	FencedCommitPolicyFail = "fail"
+)
+
+// ErrTxnCommitDelayed is returned when the txn commit should be delayed until the flush of a fence is appended.
+var ErrTxnCommitDelayed = errors.New("txn commit is delayed by the in-flight flush of a fence")
+
+// CollectionFenceView is the view of the fences installed on the collections of the pchannel.
+type CollectionFenceView interface {
+	// GetCollectionFence returns the fenced assign timetick of the collection,
+	// and whether the flush of the fence is still in flight.
+	GetCollectionFence(collectionID int64) (fencedTimeTick uint64, flushPending bool)
+}
+
+// BindCollectionFenceView binds the fence view used to validate the txn commit.
+func (m *TxnManager) BindCollectionFenceView(view CollectionFenceView) {
+	m.fenceView.Store(&view)
+}
+
+// PressureLevel is the level of the backpressure of the append path of the pchannel.
+type PressureLevel int
+
+const (
+	// PressureLevelNormal means the txn is not limited.
+	PressureLevelNormal PressureLevel = iota
+	// PressureLevelWarn means the new txn is limited by a reduced max binary size.
+	PressureLevelWarn
+	// PressureLevelCritical means the new txn is rejected, the existing txns are still allowed to finish.
+	PressureLevelCritical
+)
+
+// String returns the string representation of the pressure level.
+func (l PressureLevel) String() string {
+	switch l {
+	case PressureLevelWarn:
+		return "warn"
+	case PressureLevelCritical:
+		return "critical"
+	default:
+		return "normal"
+	}
+}
+
+// PressureView is the view of the backpressure of the append path of the pchannel.
+type PressureView interface {
+	// PressureLevel returns the current pressure level, it's called in the append path of the begin txn, so it should never block.
+	PressureLevel() PressureLevel
+}
+
+// BindPressureView binds the pressure view used to throttle the new txns.
+func (m *TxnManager) BindPressureView(view PressureView) {
+	m.pressureView.Store(&view)
+}
+
+// pressureLevel returns the current pressure level, the normal level is returned if no view is bound.
+func (m *TxnManager) pressureLevel() PressureLevel {
+	view := m.pressureView.Load()
+	if view == nil {
+		return PressureLevelNormal
+	}
+	return (*view).PressureLevel()
+}
+
+// ValidateCommit validates the commit of the session against the fences installed on the touched collections.
+// The assignment of the txn data is fenced at assign time, but a txn may assign segments before a fence is installed
+// and commit after it, so the data written before the fence timetick becomes visible past the flush point.
+// The conflict is handled by the fenced commit policy:
+// the commit is delayed by ErrTxnCommitDelayed until the flush of the fence is appended,
+// or failed by a fenced error.
+func (m *TxnManager) ValidateCommit(session *TxnSession) error {
+	view := m.fenceView.Load()
+	if view == nil {
+		return nil
+	}
+	policy := paramtable.Get().StreamingCfg.TxnFencedCommitPolicy.GetValue()
+	for collectionID, minTimeTick := range session.WrittenCollections() {
+		fencedTimeTick, flushPending := (*view).GetCollectionFence(collectionID)
+		if minTimeTick > fencedTimeTick {
+			// all data of the collection is written after the fence.
+			continue
+		}
+		if policy == FencedCommitPolicyFail {
+			return status.NewTransactionFenced("txn %d writes collection %d at timetick %d, which is fenced at timetick %d",
+				session.TxnContext().TxnID, collectionID, minTimeTick, fencedTimeTick)
+		}
+		// FencedCommitPolicyDelay, the unknown policy is treated as delay too.
+		if flushPending {
+			return ErrTxnCommitDelayed
+		}
+	}
+	return nil
 }
 
 // RecoverDone returns a channel that is closed when all transactions are cleaned up.
@@ -88,6 +214,12 @@ func (m *TxnManager) BeginNewTxn(ctx context.Context, msg message.MutableBeginTx
 	if keepalive < 1*time.Millisecond {
 		return nil, status.NewInvaildArgument("keepalive must be greater than 1ms")
 	}
+	// the inserts of a txn bypass the throttles of the plain inserts once the segments are pinned by the session,
+	// so the txn is throttled at begin.
+	level := m.pressureLevel()
+	if level == PressureLevelCritical {
+		return nil, status.NewRetryLater("txn is rejected under the %s pressure of pchannel", level)
+	}
 	id, err := resource.Resource().IDAllocator().Allocate(ctx)
 	if err != nil {
 		return nil, err
@@ -105,6 +237,10 @@ func (m *TxnManager) BeginNewTxn(ctx context.Context, msg message.MutableBeginTx
 		Keepalive: keepalive,
 	}
 	session := newTxnSession(vchannel, txnCtx, timetick, m.metrics.BeginTxn())
+	session.onDone = m.notifyTxnDone
+	if level == PressureLevelWarn {
+		session.maxBinarySize = uint64(paramtable.Get().StreamingCfg.TxnPressureWarnMaxBinarySize.GetAsSize())
+	}
 	m.sessions[session.TxnContext().TxnID] = session
 	return session, nil
 }
@@ -170,6 +306,32 @@ func (m *TxnManager) GetSessionOfTxn(id message.TxnID) (*TxnSession, error) {
 	return session, nil
 }
 
+// OpenTxnsOfCollection returns the txns still open that have written data into the segments of the collection,
+// the txns are sorted by the begin timetick.
+// The data of these txns written before a flush is only visible after they're committed,
+// so the consumers should wait for them before declaring the flush point consistent.
+func (m *TxnManager) OpenTxnsOfCollection(collectionID int64) []*message.OpenTxn {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	txns := make([]*message.OpenTxn, 0)
+	for id, session := range m.sessions {
+		if session.IsOpenAtCollection(collectionID) {
+			txns = append(txns, &message.OpenTxn{
+				TxnId:         int64(id),
+				BeginTimeTick: session.BeginTimeTick(),
+			})
+		}
+	}
+	sort.Slice(txns, func(i, j int) bool {
+		if txns[i].BeginTimeTick != txns[j].BeginTimeTick {
+			return txns[i].BeginTimeTick < txns[j].BeginTimeTick
+		}
+		return txns[i].TxnId < txns[j].TxnId
+	})
+	return txns
+}
+
 // GracefulClose waits for all transactions to be cleaned up.
 func (m *TxnManager) GracefulClose(ctx context.Context) error {
 	defer m.metrics.Close()
@@ -32,6 +32,8 @@ type AppendMetrics struct {
 	appendDuration     time.Duration
 	implAppendDuration time.Duration
 	interceptors       map[string][]*InterceptorMetrics
+	redoMarked         bool // the current attempt is marked to be redone by the interceptor.
+	redoAttempts       int  // the count of retry attempts of the same logical message.
 }
 
 type AppendMetricsGuard struct {
@@ -62,6 +64,26 @@ func (m *AppendMetrics) StartAppendGuard() *AppendMetricsGuard {
 	}
 }
 
+// MarkRedo marks the current attempt is going to be redone as the same logical message.
+// It's set by the interceptor that returns the redo error.
+func (m *AppendMetrics) MarkRedo() {
+	m.redoMarked = true
+}
+
+// ObserveRedoAttempt propagates the redo mark to the retry attempt, it's called by the redo interceptor before the append is retried.
+// Return false if the redo is not marked by the interceptor, the retry is still counted as a physical attempt.
+func (m *AppendMetrics) ObserveRedoAttempt() bool {
+	marked := m.redoMarked
+	m.redoMarked = false
+	m.redoAttempts++
+	return marked
+}
+
+// PhysicalAttempts returns the count of attempts of the message through the interceptor chain, including the redo attempts.
+func (m *AppendMetrics) PhysicalAttempts() int {
+	return m.redoAttempts + 1
+}
+
 // IntoLogFields convert the metrics to log fields.
 func (m *AppendMetrics) IntoLogFields() []zap.Field {
 	fields := []zap.Field{
@@ -69,6 +91,9 @@ func (m *AppendMetrics) IntoLogFields() []zap.Field {
 		zap.Duration("append_duration", m.appendDuration),
 		zap.Duration("impl_append_duration", m.implAppendDuration),
 	}
+	if m.redoAttempts > 0 {
+		fields = append(fields, zap.Int("redo_attempts", m.redoAttempts))
+	}
 	for name, ims := range m.interceptors {
 		for i, im := range ims {
 			fields = append(fields, zap.Any(fmt.Sprintf("%s_%d", name, i), im))
@@ -1,7 +1,11 @@
 package metricsutil
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/atomic"
 
 	"github.com/milvus-io/milvus/pkg/v2/metrics"
 	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
@@ -14,12 +18,42 @@ func NewSegmentAssignMetrics(pchannel string) *SegmentAssignMetrics {
 		metrics.WALChannelLabelName: pchannel,
 	}
 	return &SegmentAssignMetrics{
-		constLabel:      constLabel,
-		allocTotal:      metrics.WALSegmentAllocTotal.MustCurryWith(constLabel),
-		segmentBytes:    metrics.WALSegmentBytes.With(constLabel),
-		flushedTotal:    metrics.WALSegmentFlushedTotal.MustCurryWith(constLabel),
-		partitionTotal:  metrics.WALPartitionTotal.With(constLabel),
-		collectionTotal: metrics.WALCollectionTotal.With(constLabel),
+		constLabel:                    constLabel,
+		allocTotal:                    metrics.WALSegmentAllocTotal.MustCurryWith(constLabel),
+		segmentBytes:                  metrics.WALSegmentBytes.With(constLabel),
+		timeToSeal:                    metrics.WALSegmentTimeToSealSeconds.MustCurryWith(constLabel),
+		writeAmplification:            metrics.WALSegmentWriteAmplification.MustCurryWith(constLabel),
+		flushedTotal:                  metrics.WALSegmentFlushedTotal.MustCurryWith(constLabel),
+		recoveredTotal:                metrics.WALSegmentRecoveredTotal.MustCurryWith(constLabel),
+		recoverySummary:               metrics.WALSegmentAssignRecoverySummary.MustCurryWith(constLabel),
+		recoveryStageDuration:         metrics.WALSegmentAssignRecoveryStageDurationSeconds.MustCurryWith(constLabel),
+		sealDeferredTotal:             metrics.WALSegmentSealDeferredTotal.MustCurryWith(constLabel),
+		sealAbortedTotal:              metrics.WALSegmentSealAbortedTotal.MustCurryWith(constLabel),
+		allocMismatchTotal:            metrics.WALSegmentAllocMismatchTotal.MustCurryWith(constLabel),
+		timeTickSealViolationTotal:    metrics.WALSegmentTimeTickSealViolationTotal.MustCurryWith(constLabel),
+		timeTickTooOldTotal:           metrics.WALSegmentTimeTickTooOldTotal.MustCurryWith(constLabel),
+		timeTickTooOldLag:             metrics.WALSegmentTimeTickTooOldLagSeconds.MustCurryWith(constLabel),
+		ackMisuseTotal:                metrics.WALSegmentAssignAckMisuseTotal.MustCurryWith(constLabel),
+		undersizedMaxSizeTotal:        metrics.WALSegmentUndersizedMaxSizeTotal.MustCurryWith(constLabel),
+		creationRateAlertTotal:        metrics.WALSegmentCreationRateAlertTotal.MustCurryWith(constLabel),
+		circuitBreakerTransitionTotal: metrics.WALSegmentAssignCircuitBreakerTransitionTotal.MustCurryWith(constLabel),
+		sealQueueWaitingTotal:         metrics.WALSegmentSealQueueWaitingTotal.With(constLabel),
+		sealQueueMemoryBytes:          metrics.WALSegmentSealQueueMemoryBytes.With(constLabel),
+		sealQueueForceResolvedTotal:   metrics.WALSegmentSealQueueForceResolvedTotal.With(constLabel),
+		sealQueuePersistBacklog:       metrics.WALSegmentSealQueuePersistBacklog.With(constLabel),
+		sealSweepEvaluated:            metrics.WALSegmentSealSweepEvaluatedPartitions.With(constLabel),
+		sealSweepDuration:             metrics.WALSegmentSealSweepDurationSeconds.With(constLabel),
+		catalogDuration:               metrics.WALSegmentAssignCatalogDurationSeconds.MustCurryWith(constLabel),
+		catalogTimeoutTotal:           metrics.WALSegmentAssignCatalogTimeoutTotal.MustCurryWith(constLabel),
+		unsealedTotal:                 metrics.WALSegmentUnsealedTotal.With(constLabel),
+		metaCompactedTotal:            metrics.WALSegmentMetaCompactedTotal.With(constLabel),
+		lockWait:                      metrics.WALSegmentAssignLockWaitSeconds.With(constLabel),
+		allocDuration:                 metrics.WALSegmentAllocDurationSeconds.With(constLabel),
+		rejectedTotal:                 metrics.WALSegmentAssignRejectedTotal.With(constLabel),
+		saturation:                    metrics.WALSegmentAssignSaturation.With(constLabel),
+		pressure:                      newAppendPathPressureCounter(),
+		partitionTotal:                metrics.WALPartitionTotal.With(constLabel),
+		collectionTotal:               metrics.WALCollectionTotal.With(constLabel),
 	}
 }
 
@@ -27,11 +61,66 @@ func NewSegmentAssignMetrics(pchannel string) *SegmentAssignMetrics {
 type SegmentAssignMetrics struct {
 	constLabel prometheus.Labels
 
-	allocTotal      *prometheus.GaugeVec
-	segmentBytes    prometheus.Observer
-	flushedTotal    *prometheus.CounterVec
-	partitionTotal  prometheus.Gauge
-	collectionTotal prometheus.Gauge
+	allocTotal                    *prometheus.GaugeVec
+	segmentBytes                  prometheus.Observer
+	timeToSeal                    prometheus.ObserverVec
+	writeAmplification            prometheus.ObserverVec
+	flushedTotal                  *prometheus.CounterVec
+	recoveredTotal                *prometheus.CounterVec
+	recoverySummary               *prometheus.GaugeVec
+	recoveryStageDuration         *prometheus.GaugeVec
+	sealDeferredTotal             *prometheus.CounterVec
+	sealAbortedTotal              *prometheus.CounterVec
+	allocMismatchTotal            *prometheus.CounterVec
+	timeTickSealViolationTotal    *prometheus.CounterVec
+	timeTickTooOldTotal           *prometheus.CounterVec
+	timeTickTooOldLag             prometheus.ObserverVec
+	ackMisuseTotal                *prometheus.CounterVec
+	undersizedMaxSizeTotal        *prometheus.CounterVec
+	creationRateAlertTotal        *prometheus.CounterVec
+	circuitBreakerTransitionTotal *prometheus.CounterVec
+	sealQueueWaitingTotal         prometheus.Gauge
+	sealQueueMemoryBytes          prometheus.Gauge
+	sealQueueForceResolvedTotal   prometheus.Counter
+	sealQueuePersistBacklog       prometheus.Gauge
+	sealSweepEvaluated            prometheus.Gauge
+	sealSweepDuration             prometheus.Observer
+	catalogDuration               prometheus.ObserverVec
+	catalogTimeoutTotal           *prometheus.CounterVec
+	unsealedTotal                 prometheus.Counter
+	metaCompactedTotal            prometheus.Counter
+	lockWait                      prometheus.Observer
+	allocDuration                 prometheus.Observer
+	rejectedTotal                 prometheus.Counter
+	saturation                    prometheus.Gauge
+	pressure                      *appendPathPressureCounter
+	partitionTotal                prometheus.Gauge
+	collectionTotal               prometheus.Gauge
+}
+
+// AppendPathPressure is the cumulative pressure observed on the append path of the segment assignment.
+type AppendPathPressure struct {
+	LockWait   time.Duration // the total duration of the assignments waiting for the lock of the partition.
+	Allocation time.Duration // the total duration of the new growing segment allocations.
+	Assigns    uint64        // the total count of the assignments.
+	Rejected   uint64        // the total count of the assignments rejected by the backpressure.
+}
+
+// appendPathPressureCounter accumulates the append path pressure.
+type appendPathPressureCounter struct {
+	lockWait   *atomic.Int64
+	allocation *atomic.Int64
+	assigns    *atomic.Uint64
+	rejected   *atomic.Uint64
+}
+
+func newAppendPathPressureCounter() *appendPathPressureCounter {
+	return &appendPathPressureCounter{
+		lockWait:   atomic.NewInt64(0),
+		allocation: atomic.NewInt64(0),
+		assigns:    atomic.NewUint64(0),
+		rejected:   atomic.NewUint64(0),
+	}
 }
 
 // UpdateGrowingSegmentState updates the metrics of the segment assignment state.
@@ -49,6 +138,164 @@ func (m *SegmentAssignMetrics) ObserveSegmentFlushed(policy string, bytes int64)
 	m.flushedTotal.WithLabelValues(policy).Inc()
 }
 
+// ObserveTimeToSeal records the duration from the first assigned insert of a segment to the seal decision of it.
+func (m *SegmentAssignMetrics) ObserveTimeToSeal(collectionID int64, policy string, d time.Duration) {
+	m.timeToSeal.WithLabelValues(strconv.FormatInt(collectionID, 10), policy).Observe(d.Seconds())
+}
+
+// ObserveWriteAmplification records the write amplification of a sealed segment of the collection.
+func (m *SegmentAssignMetrics) ObserveWriteAmplification(collectionID int64, ratio float64) {
+	m.writeAmplification.WithLabelValues(strconv.FormatInt(collectionID, 10)).Observe(ratio)
+}
+
+// RemoveCollection removes the metrics of the collection.
+func (m *SegmentAssignMetrics) RemoveCollection(collectionID int64) {
+	labels := prometheus.Labels{metrics.WALCollectionIDLabelName: strconv.FormatInt(collectionID, 10)}
+	for k, v := range m.constLabel {
+		labels[k] = v
+	}
+	metrics.WALSegmentTimeToSealSeconds.DeletePartialMatch(labels)
+	metrics.WALSegmentWriteAmplification.DeletePartialMatch(labels)
+	metrics.WALSegmentCreationRateAlertTotal.DeletePartialMatch(labels)
+	metrics.WALSegmentTimeTickTooOldTotal.DeletePartialMatch(labels)
+	metrics.WALSegmentTimeTickTooOldLagSeconds.DeletePartialMatch(labels)
+}
+
+// ObserveSegmentRecovered records a recovered segment assignment meta with its validation class.
+func (m *SegmentAssignMetrics) ObserveSegmentRecovered(class string) {
+	m.recoveredTotal.WithLabelValues(class).Inc()
+}
+
+// ObserveRecoverySummary exports the summary of the recovery, it's only called once when the recovery is done.
+func (m *SegmentAssignMetrics) ObserveRecoverySummary(items map[string]int, stages map[string]time.Duration) {
+	for item, cnt := range items {
+		m.recoverySummary.WithLabelValues(item).Set(float64(cnt))
+	}
+	for stage, d := range stages {
+		m.recoveryStageDuration.WithLabelValues(stage).Set(d.Seconds())
+	}
+}
+
+// ObserveCircuitBreakerTransition records a state transition of the collection circuit breaker.
+func (m *SegmentAssignMetrics) ObserveCircuitBreakerTransition(state string) {
+	m.circuitBreakerTransitionTotal.WithLabelValues(state).Inc()
+}
+
+// UpdateSealQueue updates the waiting segment count, the approximate memory and the persist backlog of the seal queue.
+func (m *SegmentAssignMetrics) UpdateSealQueue(waitingCount int, memoryBytes uint64, persistBacklog int) {
+	m.sealQueueWaitingTotal.Set(float64(waitingCount))
+	m.sealQueueMemoryBytes.Set(float64(memoryBytes))
+	m.sealQueuePersistBacklog.Set(float64(persistBacklog))
+}
+
+// ObserveSealSweep records the count of the partitions evaluated by a seal policy sweep and the duration of it.
+func (m *SegmentAssignMetrics) ObserveSealSweep(evaluated int, d time.Duration) {
+	m.sealSweepEvaluated.Set(float64(evaluated))
+	m.sealSweepDuration.Observe(d.Seconds())
+}
+
+// ObserveAllocMismatch records an alloc segment response of coordinator that mismatches the request.
+func (m *SegmentAssignMetrics) ObserveAllocMismatch(reason string) {
+	m.allocMismatchTotal.WithLabelValues(reason).Inc()
+}
+
+// ObserveTimeTickSealViolation records an insert whose time tick is not greater than the last seal time tick of the partition.
+func (m *SegmentAssignMetrics) ObserveTimeTickSealViolation(collectionID int64, mode string) {
+	m.timeTickSealViolationTotal.WithLabelValues(strconv.FormatInt(collectionID, 10), mode).Inc()
+}
+
+// ObserveTimeTickTooOld records an assignment of the collection on the vchannel rejected by the too old time tick,
+// the lag is the physical duration that the rejected time tick falls behind the time tick it's compared with.
+func (m *SegmentAssignMetrics) ObserveTimeTickTooOld(collectionID int64, vchannel string, lag time.Duration) {
+	collection := strconv.FormatInt(collectionID, 10)
+	m.timeTickTooOldTotal.WithLabelValues(collection, vchannel).Inc()
+	m.timeTickTooOldLag.WithLabelValues(collection).Observe(lag.Seconds())
+}
+
+// ObserveAckMisuse records a misused ack of the segment assign result, such as the double ack.
+func (m *SegmentAssignMetrics) ObserveAckMisuse(misuse string) {
+	m.ackMisuseTotal.WithLabelValues(misuse).Inc()
+}
+
+// ObserveUndersizedMaxSize records a collection whose segment max binary size can't hold the min rows of its average row size.
+func (m *SegmentAssignMetrics) ObserveUndersizedMaxSize(collectionID int64) {
+	m.undersizedMaxSizeTotal.WithLabelValues(strconv.FormatInt(collectionID, 10)).Inc()
+}
+
+// ObserveCreationRateAlert records the projected segment creation rate of the collection crosses the alert threshold.
+func (m *SegmentAssignMetrics) ObserveCreationRateAlert(collectionID int64) {
+	m.creationRateAlertTotal.WithLabelValues(strconv.FormatInt(collectionID, 10)).Inc()
+}
+
+// ObserveSealDeferred records a policy-driven seal that is deferred by the seal grace period.
+func (m *SegmentAssignMetrics) ObserveSealDeferred(policy string) {
+	m.sealDeferredTotal.WithLabelValues(policy).Inc()
+}
+
+// ObserveSealAborted records a seal aborted before the flush message is appended, because the sealed state is not durable in catalog.
+func (m *SegmentAssignMetrics) ObserveSealAborted(reason string) {
+	m.sealAbortedTotal.WithLabelValues(reason).Inc()
+}
+
+// ObserveSealQueueForceResolved records a segment that is flushed without waiting for the flying acks.
+func (m *SegmentAssignMetrics) ObserveSealQueueForceResolved() {
+	m.sealQueueForceResolvedTotal.Inc()
+}
+
+// ObserveCatalogOperation records the duration of a segment assignment catalog operation on the path.
+func (m *SegmentAssignMetrics) ObserveCatalogOperation(path string, d time.Duration, timeout bool) {
+	m.catalogDuration.WithLabelValues(path).Observe(d.Seconds())
+	if timeout {
+		m.catalogTimeoutTotal.WithLabelValues(path).Inc()
+	}
+}
+
+// ObserveSegmentUnsealed records a sealed but unflushed segment that is reverted back to growing.
+func (m *SegmentAssignMetrics) ObserveSegmentUnsealed() {
+	m.unsealedTotal.Inc()
+}
+
+// ObserveMetaCompacted records the count of segment assignment meta keys removed by the meta compaction.
+func (m *SegmentAssignMetrics) ObserveMetaCompacted(cnt int) {
+	m.metaCompactedTotal.Add(float64(cnt))
+}
+
+// ObserveAssignLockWait records the duration of an assignment waiting for the lock of the partition.
+func (m *SegmentAssignMetrics) ObserveAssignLockWait(d time.Duration) {
+	m.lockWait.Observe(d.Seconds())
+	m.pressure.lockWait.Add(int64(d))
+}
+
+// ObserveSegmentAllocation records the duration of a new growing segment allocation.
+func (m *SegmentAssignMetrics) ObserveSegmentAllocation(d time.Duration) {
+	m.allocDuration.Observe(d.Seconds())
+	m.pressure.allocation.Add(int64(d))
+}
+
+// ObserveAssign records an assignment, rejected is true if it's rejected by the backpressure.
+func (m *SegmentAssignMetrics) ObserveAssign(rejected bool) {
+	m.pressure.assigns.Inc()
+	if rejected {
+		m.rejectedTotal.Inc()
+		m.pressure.rejected.Inc()
+	}
+}
+
+// AppendPathPressure returns the cumulative pressure observed on the append path.
+func (m *SegmentAssignMetrics) AppendPathPressure() AppendPathPressure {
+	return AppendPathPressure{
+		LockWait:   time.Duration(m.pressure.lockWait.Load()),
+		Allocation: time.Duration(m.pressure.allocation.Load()),
+		Assigns:    m.pressure.assigns.Load(),
+		Rejected:   m.pressure.rejected.Load(),
+	}
+}
+
+// UpdateSaturation updates the rolling saturation score of the append path.
+func (m *SegmentAssignMetrics) UpdateSaturation(score float64) {
+	m.saturation.Set(score)
+}
+
 func (m *SegmentAssignMetrics) UpdatePartitionCount(cnt int) {
 	m.partitionTotal.Set(float64(cnt))
 }
@@ -60,7 +307,36 @@ func (m *SegmentAssignMetrics) UpdateCollectionCount(cnt int) {
 func (m *SegmentAssignMetrics) Close() {
 	metrics.WALSegmentAllocTotal.DeletePartialMatch(m.constLabel)
 	metrics.WALSegmentFlushedTotal.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentRecoveredTotal.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentAssignRecoverySummary.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentAssignRecoveryStageDurationSeconds.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentSealDeferredTotal.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentSealAbortedTotal.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentAllocMismatchTotal.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentTimeTickSealViolationTotal.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentTimeTickTooOldTotal.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentTimeTickTooOldLagSeconds.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentAssignAckMisuseTotal.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentUndersizedMaxSizeTotal.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentCreationRateAlertTotal.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentAssignCircuitBreakerTransitionTotal.DeletePartialMatch(m.constLabel)
 	metrics.WALSegmentBytes.Delete(m.constLabel)
+	metrics.WALSegmentTimeToSealSeconds.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentWriteAmplification.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentSealQueueWaitingTotal.Delete(m.constLabel)
+	metrics.WALSegmentSealQueueMemoryBytes.Delete(m.constLabel)
+	metrics.WALSegmentSealQueueForceResolvedTotal.Delete(m.constLabel)
+	metrics.WALSegmentSealQueuePersistBacklog.Delete(m.constLabel)
+	metrics.WALSegmentSealSweepEvaluatedPartitions.Delete(m.constLabel)
+	metrics.WALSegmentSealSweepDurationSeconds.Delete(m.constLabel)
+	metrics.WALSegmentAssignCatalogDurationSeconds.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentAssignCatalogTimeoutTotal.DeletePartialMatch(m.constLabel)
+	metrics.WALSegmentUnsealedTotal.Delete(m.constLabel)
+	metrics.WALSegmentMetaCompactedTotal.Delete(m.constLabel)
+	metrics.WALSegmentAssignLockWaitSeconds.Delete(m.constLabel)
+	metrics.WALSegmentAllocDurationSeconds.Delete(m.constLabel)
+	metrics.WALSegmentAssignRejectedTotal.Delete(m.constLabel)
+	metrics.WALSegmentAssignSaturation.Delete(m.constLabel)
 	metrics.WALPartitionTotal.Delete(m.constLabel)
 	metrics.WALCollectionTotal.Delete(m.constLabel)
 }
@@ -42,6 +42,7 @@ func NewWriteMetrics(pchannel types.PChannelInfo, walName string) *WriteMetrics
 		constLabel:                   constLabel,
 		bytes:                        metrics.WALAppendMessageBytes.MustCurryWith(constLabel),
 		total:                        metrics.WALAppendMessageTotal.MustCurryWith(constLabel),
+		physicalTotal:                metrics.WALAppendMessagePhysicalTotal.MustCurryWith(constLabel),
 		walDuration:                  metrics.WALAppendMessageDurationSeconds.MustCurryWith(constLabel),
 		walimplsDuration:             metrics.WALImplsAppendMessageDurationSeconds.MustCurryWith(constLabel),
 		walBeforeInterceptorDuration: metrics.WALAppendMessageBeforeInterceptorDurationSeconds.MustCurryWith(constLabel),
@@ -58,6 +59,7 @@ type WriteMetrics struct {
 	constLabel                   prometheus.Labels
 	bytes                        prometheus.ObserverVec
 	total                        *prometheus.CounterVec
+	physicalTotal                *prometheus.CounterVec
 	walDuration                  prometheus.ObserverVec
 	walimplsDuration             prometheus.ObserverVec
 	walBeforeInterceptorDuration prometheus.ObserverVec
@@ -82,7 +84,9 @@ func (m *WriteMetrics) done(appendMetrics *AppendMetrics) {
 		m.walimplsDuration.WithLabelValues(status).Observe(appendMetrics.implAppendDuration.Seconds())
 	}
 	m.bytes.WithLabelValues(status).Observe(float64(appendMetrics.bytes))
+	// the redo attempts are the same logical message, they're only counted by the physical total.
 	m.total.WithLabelValues(appendMetrics.msg.MessageType().String(), status).Inc()
+	m.physicalTotal.WithLabelValues(appendMetrics.msg.MessageType().String(), status).Add(float64(appendMetrics.PhysicalAttempts()))
 	m.walDuration.WithLabelValues(status).Observe(appendMetrics.appendDuration.Seconds())
 	for name, ims := range appendMetrics.interceptors {
 		for _, im := range ims {
@@ -113,6 +117,7 @@ func (m *WriteMetrics) Close() {
 	metrics.WALAppendMessageAfterInterceptorDurationSeconds.DeletePartialMatch(m.constLabel)
 	metrics.WALAppendMessageBytes.DeletePartialMatch(m.constLabel)
 	metrics.WALAppendMessageTotal.DeletePartialMatch(m.constLabel)
+	metrics.WALAppendMessagePhysicalTotal.DeletePartialMatch(m.constLabel)
 	metrics.WALAppendMessageDurationSeconds.DeletePartialMatch(m.constLabel)
 	metrics.WALImplsAppendMessageDurationSeconds.DeletePartialMatch(m.constLabel)
 	metrics.WALInfo.DeleteLabelValues(
@@ -195,6 +195,9 @@ func (r *RecoveryStorage) handleMessage(msg message.ImmutableMessage) {
 	case message.MessageTypeCreatePartition:
 		immutableMsg := message.MustAsImmutableCreatePartitionMessageV1(msg)
 		r.handleCreatePartition(immutableMsg)
+	case message.MessageTypeCreatePartitions:
+		immutableMsg := message.MustAsImmutableCreatePartitionsMessageV1(msg)
+		r.handleCreatePartitions(immutableMsg)
 	case message.MessageTypeDropPartition:
 		immutableMsg := message.MustAsImmutableDropPartitionMessageV1(msg)
 		r.handleDropPartition(immutableMsg)
@@ -207,8 +210,12 @@ func (r *RecoveryStorage) handleMessage(msg message.ImmutableMessage) {
 	case message.MessageTypeSchemaChange:
 		immutableMsg := message.MustAsImmutableCollectionSchemaChangeV2(msg)
 		r.handleSchemaChange(immutableMsg)
-	case message.MessageTypeTimeTick:
+	case message.MessageTypeMigrateSegments:
+		immutableMsg := message.MustAsImmutableMigrateSegmentsMessageV2(msg)
+		r.handleMigrateSegments(immutableMsg)
+	case message.MessageTypeTimeTick, message.MessageTypePreparePartitionDrop:
 		// nothing, the time tick message make no recovery operation.
+		// the drop fence installed by the prepare partition drop is not recovered, the writes of partition resume as same as the fence is expired.
 	default:
 		panic("unreachable: some message type can not be consumed, there's a critical bug.")
 	}
@@ -252,6 +259,19 @@ func (r *RecoveryStorage) handleFlush(msg message.ImmutableFlushMessageV2) {
 	}
 }
 
+// handleMigrateSegments handles the migrate segments message, the migrated growing segments are re-keyed into the target partition.
+func (r *RecoveryStorage) handleMigrateSegments(msg message.ImmutableMigrateSegmentsMessageV2) {
+	header := msg.Header()
+	for _, segmentID := range header.GetSegmentIds() {
+		if segment, ok := r.segments[segmentID]; ok && segment.IsGrowing() {
+			segment.ObserveMigrate(msg.TimeTick(), header.GetToPartitionId())
+		} else {
+			r.detectInconsistency(msg, "migrated segment not found", zap.Int64("segmentID", segmentID))
+		}
+	}
+	r.Logger().Info("migrate segments", log.FieldMessage(msg))
+}
+
 // handleManualFlush handles the manual flush message.
 func (r *RecoveryStorage) handleManualFlush(msg message.ImmutableManualFlushMessageV2) {
 	segments := make(map[int64]struct{}, len(msg.Header().SegmentIds))
@@ -259,6 +279,9 @@ func (r *RecoveryStorage) handleManualFlush(msg message.ImmutableManualFlushMess
 		segments[segmentID] = struct{}{}
 	}
 	r.flushSegments(msg, segments)
+	if vchannel, ok := r.vchannels[msg.VChannel()]; ok {
+		vchannel.ObserveManualFlush(msg)
+	}
 }
 
 // flushSegments flushes the segments in the recovery storage.
@@ -323,6 +346,15 @@ func (r *RecoveryStorage) handleCreatePartition(msg message.ImmutableCreateParti
 	r.Logger().Info("create partition", log.FieldMessage(msg))
 }
 
+// handleCreatePartitions handles the batched create partitions message.
+func (r *RecoveryStorage) handleCreatePartitions(msg message.ImmutableCreatePartitionsMessageV1) {
+	if vchannelInfo, ok := r.vchannels[msg.VChannel()]; !ok || vchannelInfo.meta.State == streamingpb.VChannelState_VCHANNEL_STATE_DROPPED {
+		return
+	}
+	r.vchannels[msg.VChannel()].ObserveCreatePartitions(msg)
+	r.Logger().Info("create partitions", log.FieldMessage(msg))
+}
+
 // handleDropPartition handles the drop partition message.
 func (r *RecoveryStorage) handleDropPartition(msg message.ImmutableDropPartitionMessageV1) {
 	r.vchannels[msg.VChannel()].ObserveDropPartition(msg)
@@ -361,8 +393,10 @@ func (r *RecoveryStorage) handleImport(_ message.ImmutableImportMessageV1) {
 func (r *RecoveryStorage) handleSchemaChange(msg message.ImmutableSchemaChangeMessageV2) {
 	// when schema change happens, we need to flush all segments in the collection.
 	// TODO: add the flush segment list into schema change message.
-	// TODO: persist the schema change into recoveryinfo.
 	r.flushAllSegmentOfCollection(msg, msg.Header().CollectionId)
+	if vchannel, ok := r.vchannels[msg.VChannel()]; ok {
+		vchannel.ObserveSchemaChange(msg)
+	}
 }
 
 // detectInconsistency detects the inconsistency in the recovery storage.
@@ -110,6 +110,17 @@ func (info *segmentRecoveryInfo) ObserveFlush(timetick uint64) {
 	info.dirty = true
 }
 
+// ObserveMigrate is called when the growing segment is migrated into another partition.
+func (info *segmentRecoveryInfo) ObserveMigrate(timetick uint64, partitionID int64) {
+	if timetick < info.meta.CheckpointTimeTick || info.meta.PartitionId == partitionID {
+		// the migration is already observed by the persisted info.
+		return
+	}
+	info.meta.PartitionId = partitionID
+	info.meta.CheckpointTimeTick = timetick
+	info.dirty = true
+}
+
 // ConsumeDirtyAndGetSnapshot consumes the dirty segment recovery info and returns a snapshot to persist.
 // Return nil if the segment recovery info is not dirty.
 func (info *segmentRecoveryInfo) ConsumeDirtyAndGetSnapshot() (dirtySnapshot *streamingpb.SegmentAssignmentMeta, shouldBeRemoved bool) {
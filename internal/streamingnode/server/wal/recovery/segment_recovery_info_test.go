@@ -81,6 +81,17 @@ func TestSegmentRecoveryInfo(t *testing.T) {
 	info.ObserveInsert(ts, assign)
 	assert.True(t, info.dirty)
 
+	// the growing segment is migrated into another partition with its stats.
+	ts += 1
+	info.ObserveMigrate(ts, 3)
+	assert.Equal(t, int64(3), info.meta.PartitionId)
+	assert.Equal(t, uint64(20), info.meta.Stat.InsertedBinarySize)
+	info.ConsumeDirtyAndGetSnapshot()
+	// the migration already observed is ignored.
+	info.ObserveMigrate(ts-1, 1)
+	assert.Equal(t, int64(3), info.meta.PartitionId)
+	assert.False(t, info.dirty)
+
 	ts += 1
 	info.ObserveFlush(ts)
 	snapshot, shouldBeRemoved = info.ConsumeDirtyAndGetSnapshot()
@@ -32,8 +32,9 @@ func newVChannelRecoveryInfoFromCreateCollectionMessage(msg message.ImmutableCre
 			Vchannel: msg.VChannel(),
 			State:    streamingpb.VChannelState_VCHANNEL_STATE_NORMAL,
 			CollectionInfo: &streamingpb.CollectionInfoOfVChannel{
-				CollectionId: msg.Header().CollectionId,
-				Partitions:   partitions,
+				CollectionId:     msg.Header().CollectionId,
+				Partitions:       partitions,
+				AssignmentConfig: getCollectionAssignmentConfig(msg),
 			},
 			CheckpointTimeTick: msg.TimeTick(),
 		},
@@ -42,6 +43,22 @@ func newVChannelRecoveryInfoFromCreateCollectionMessage(msg message.ImmutableCre
 	}
 }
 
+// getCollectionAssignmentConfig gets the assignment config carried by the create collection message of the restored collection.
+// The config is persisted into the vchannel meta, so it can be applied again when the wal is recovered.
+// The config is already validated before the message is persisted, so a broken one is just ignored.
+func getCollectionAssignmentConfig(msg message.ImmutableCreateCollectionMessageV1) *streamingpb.CollectionAssignmentConfig {
+	raw, err := message.GetCollectionAssignmentConfig(msg)
+	if err != nil || raw == nil {
+		return nil
+	}
+	config := &streamingpb.CollectionAssignmentConfig{}
+	if err := proto.Unmarshal(raw, config); err != nil {
+		return nil
+	}
+	config.CollectionId = msg.Header().CollectionId
+	return config
+}
+
 // vchannelRecoveryInfo is the recovery info for a vchannel.
 type vchannelRecoveryInfo struct {
 	meta  *streamingpb.VChannelMeta
@@ -103,26 +120,99 @@ func (info *vchannelRecoveryInfo) ObserveDropPartition(msg message.ImmutableDrop
 
 // ObserveCreatePartition is called when a create partition message is observed.
 func (info *vchannelRecoveryInfo) ObserveCreatePartition(msg message.ImmutableCreatePartitionMessageV1) {
-	if msg.TimeTick() < info.meta.CheckpointTimeTick {
+	info.observeCreatePartitions(msg.TimeTick(), msg.Header().PartitionId)
+}
+
+// ObserveCreatePartitions is called when a batched create partitions message is observed.
+func (info *vchannelRecoveryInfo) ObserveCreatePartitions(msg message.ImmutableCreatePartitionsMessageV1) {
+	info.observeCreatePartitions(msg.TimeTick(), msg.Header().PartitionIds...)
+}
+
+// observeCreatePartitions adds the partitions into the vchannel recovery info.
+func (info *vchannelRecoveryInfo) observeCreatePartitions(timetick uint64, partitionIDs ...int64) {
+	if timetick < info.meta.CheckpointTimeTick {
 		// the txn message will share the same time tick.
 		// (although the flush operation is not a txn message)
 		// so we only filter the time tick is less than the checkpoint time tick.
 		// Consistent state is guaranteed by the recovery storage.
 		return
 	}
-	for _, partition := range info.meta.CollectionInfo.Partitions {
-		if partition.PartitionId == msg.Header().PartitionId {
+	added := false
+	for _, partitionID := range partitionIDs {
+		if info.hasPartition(partitionID) {
 			// make it idempotent, only the first create partition message can be observed.
-			return
+			continue
 		}
+		info.meta.CollectionInfo.Partitions = append(info.meta.CollectionInfo.Partitions, &streamingpb.PartitionInfoOfVChannel{
+			PartitionId: partitionID,
+		})
+		added = true
+	}
+	if !added {
+		return
+	}
+	info.meta.CheckpointTimeTick = timetick
+	info.dirty = true
+}
+
+// ObserveManualFlush is called when a manual flush message is observed.
+// The flush ts of the last applied manual flush is recorded, so the replayed manual flush can be detected after failover.
+func (info *vchannelRecoveryInfo) ObserveManualFlush(msg message.ImmutableManualFlushMessageV2) {
+	if msg.TimeTick() < info.meta.CheckpointTimeTick {
+		// the checkpoint time tick is the time tick of the last message applied into the vchannel meta,
+		// so the manual flush before it has been applied before the checkpoint is persisted, and it's a replayed one.
+		// The manual flush at the checkpoint time tick is not filtered here,
+		// it can only be the manual flush that advanced the checkpoint itself, which is filtered by the flush ts below.
+		// Consistent state is guaranteed by the recovery storage's mutex.
+		return
+	}
+	if message.IsFlushOlderThanOnly(msg) || message.GetCollectionLifecycleSignal(msg) == message.CollectionLifecycleSignalLoad {
+		// no fence is applied by these manual flush, so it's always safe to replay them.
+		return
 	}
-	info.meta.CollectionInfo.Partitions = append(info.meta.CollectionInfo.Partitions, &streamingpb.PartitionInfoOfVChannel{
-		PartitionId: msg.Header().PartitionId,
-	})
+	flushTs := msg.Header().GetFlushTs()
+	if flushTs <= info.meta.CollectionInfo.GetLastManualFlushTimeTick() {
+		// make it idempotent, only the newer manual flush can be observed.
+		return
+	}
+	info.meta.CollectionInfo.LastManualFlushTimeTick = flushTs
+	info.meta.CheckpointTimeTick = msg.TimeTick()
+	info.dirty = true
+}
+
+// ObserveSchemaChange is called when a schema change message is observed.
+// The modify ts of the schema change is recorded as the schema version of the collection.
+func (info *vchannelRecoveryInfo) ObserveSchemaChange(msg message.ImmutableSchemaChangeMessageV2) {
+	if msg.TimeTick() < info.meta.CheckpointTimeTick {
+		// the txn message will share the same time tick.
+		// (although the flush operation is not a txn message)
+		// so we only filter the time tick is less than the checkpoint time tick.
+		// Consistent state is guaranteed by the recovery storage's mutex.
+		return
+	}
+	body, err := msg.Body()
+	if err != nil {
+		return
+	}
+	if body.GetModifyTs() <= info.meta.CollectionInfo.GetSchemaVersion() {
+		// make it idempotent, only the newer schema change can be observed.
+		return
+	}
+	info.meta.CollectionInfo.SchemaVersion = body.GetModifyTs()
 	info.meta.CheckpointTimeTick = msg.TimeTick()
 	info.dirty = true
 }
 
+// hasPartition returns true if the partition is recorded in the vchannel recovery info.
+func (info *vchannelRecoveryInfo) hasPartition(partitionID int64) bool {
+	for _, partition := range info.meta.CollectionInfo.Partitions {
+		if partition.PartitionId == partitionID {
+			return true
+		}
+	}
+	return false
+}
+
 // ConsumeDirtyAndGetSnapshot returns the snapshot of the vchannel recovery info.
 // It returns nil if the vchannel recovery info is not dirty.
 func (info *vchannelRecoveryInfo) ConsumeDirtyAndGetSnapshot() (dirtySnapshot *streamingpb.VChannelMeta, ShouldBeRemoved bool) {
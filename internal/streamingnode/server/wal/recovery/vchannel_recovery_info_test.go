@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	"github.com/milvus-io/milvus/pkg/v2/proto/streamingpb"
@@ -197,3 +198,195 @@ func TestNewVChannelRecoveryInfoFromCreateCollectionMessage(t *testing.T) {
 	assert.Nil(t, snapshot)
 	assert.True(t, shouldBeRemoved)
 }
+
+func TestNewVChannelRecoveryInfoWithAssignmentConfig(t *testing.T) {
+	raw, err := proto.Marshal(&streamingpb.CollectionAssignmentConfig{
+		CollectionId:   1,
+		HighPriority:   true,
+		StorageVersion: 2,
+	})
+	assert.NoError(t, err)
+	msg := message.NewCreateCollectionMessageBuilderV1().
+		WithHeader(&message.CreateCollectionMessageHeader{
+			CollectionId: 100,
+			PartitionIds: []int64{101},
+		}).
+		WithBody(&msgpb.CreateCollectionRequest{
+			CollectionName: "test-collection",
+			CollectionID:   100,
+			PartitionIDs:   []int64{101},
+		}).
+		WithVChannel("vchannel-1").
+		WithCollectionAssignmentConfig(raw).
+		MustBuildMutable()
+	msgID := rmq.NewRmqID(1)
+	immutableMsg := msg.WithTimeTick(12345).WithLastConfirmed(msgID).IntoImmutableMessage(msgID)
+	info := newVChannelRecoveryInfoFromCreateCollectionMessage(message.MustAsImmutableCreateCollectionMessageV1(immutableMsg))
+
+	// the collection id is replaced by the restored one.
+	config := info.meta.CollectionInfo.AssignmentConfig
+	assert.NotNil(t, config)
+	assert.Equal(t, int64(100), config.CollectionId)
+	assert.True(t, config.HighPriority)
+	assert.Equal(t, int64(2), config.StorageVersion)
+
+	// the broken config is ignored.
+	msg = message.NewCreateCollectionMessageBuilderV1().
+		WithHeader(&message.CreateCollectionMessageHeader{
+			CollectionId: 100,
+			PartitionIds: []int64{101},
+		}).
+		WithBody(&msgpb.CreateCollectionRequest{
+			CollectionName: "test-collection",
+			CollectionID:   100,
+			PartitionIDs:   []int64{101},
+		}).
+		WithVChannel("vchannel-1").
+		WithCollectionAssignmentConfig([]byte{0xff}).
+		MustBuildMutable()
+	immutableMsg = msg.WithTimeTick(12345).WithLastConfirmed(msgID).IntoImmutableMessage(msgID)
+	info = newVChannelRecoveryInfoFromCreateCollectionMessage(message.MustAsImmutableCreateCollectionMessageV1(immutableMsg))
+	assert.Nil(t, info.meta.CollectionInfo.AssignmentConfig)
+}
+
+func TestVChannelRecoveryInfoObserveCreatePartitions(t *testing.T) {
+	msg := message.NewCreateCollectionMessageBuilderV1().
+		WithHeader(&message.CreateCollectionMessageHeader{
+			CollectionId: 100,
+			PartitionIds: []int64{101, 102},
+		}).
+		WithBody(&msgpb.CreateCollectionRequest{
+			CollectionName: "test-collection",
+			CollectionID:   100,
+			PartitionIDs:   []int64{101, 102},
+		}).
+		WithVChannel("vchannel-1").
+		MustBuildMutable()
+	msgID := rmq.NewRmqID(1)
+	ts := uint64(12345)
+	immutableMsg := msg.WithTimeTick(ts).WithLastConfirmed(msgID).IntoImmutableMessage(msgID)
+	info := newVChannelRecoveryInfoFromCreateCollectionMessage(message.MustAsImmutableCreateCollectionMessageV1(immutableMsg))
+	info.ConsumeDirtyAndGetSnapshot()
+
+	// CreatePartitions, the existing partition is skipped.
+	msg2 := message.NewCreatePartitionsMessageBuilderV1().
+		WithHeader(&message.CreatePartitionsMessageHeader{
+			CollectionId: 100,
+			PartitionIds: []int64{102, 103, 104},
+		}).
+		WithBody(&msgpb.CreatePartitionRequest{
+			CollectionName: "test-collection",
+			CollectionID:   100,
+		}).
+		WithVChannel("vchannel-1").
+		MustBuildMutable()
+	msgID2 := rmq.NewRmqID(2)
+	ts += 1
+	immutableMsg2 := msg2.WithTimeTick(ts).WithLastConfirmed(msgID2).IntoImmutableMessage(msgID2)
+
+	info.ObserveCreatePartitions(message.MustAsImmutableCreatePartitionsMessageV1(immutableMsg2))
+	assert.Equal(t, ts, info.meta.CheckpointTimeTick)
+	assert.Len(t, info.meta.CollectionInfo.Partitions, 4)
+	assert.True(t, info.dirty)
+
+	snapshot, shouldBeRemoved := info.ConsumeDirtyAndGetSnapshot()
+	assert.NotNil(t, snapshot)
+	assert.False(t, shouldBeRemoved)
+
+	// idempotent
+	ts += 1
+	immutableMsg2 = msg2.WithTimeTick(ts).WithLastConfirmed(msgID2).IntoImmutableMessage(msgID2)
+	info.ObserveCreatePartitions(message.MustAsImmutableCreatePartitionsMessageV1(immutableMsg2))
+	assert.Len(t, info.meta.CollectionInfo.Partitions, 4)
+	assert.False(t, info.dirty)
+}
+
+func TestVChannelRecoveryInfoObserveManualFlush(t *testing.T) {
+	info := newVChannelRecoveryInfoFromVChannelMeta([]*streamingpb.VChannelMeta{
+		{
+			Vchannel: "vchannel-1",
+			State:    streamingpb.VChannelState_VCHANNEL_STATE_NORMAL,
+			CollectionInfo: &streamingpb.CollectionInfoOfVChannel{
+				CollectionId: 100,
+			},
+			CheckpointTimeTick: 100,
+		},
+	})["vchannel-1"]
+
+	newManualFlush := func(timetick uint64, flushTs uint64, olderThanOnly bool) message.ImmutableManualFlushMessageV2 {
+		b := message.NewManualFlushMessageBuilderV2().
+			WithHeader(&message.ManualFlushMessageHeader{
+				CollectionId: 100,
+				FlushTs:      flushTs,
+			}).
+			WithBody(&message.ManualFlushMessageBody{}).
+			WithVChannel("vchannel-1")
+		if olderThanOnly {
+			b = b.WithFlushOlderThanOnly()
+		}
+		msgID := rmq.NewRmqID(int64(timetick))
+		immutableMsg := b.MustBuildMutable().WithTimeTick(timetick).WithLastConfirmed(msgID).IntoImmutableMessage(msgID)
+		return message.MustAsImmutableManualFlushMessageV2(immutableMsg)
+	}
+
+	// the flush older than only manual flush is never recorded.
+	info.ObserveManualFlush(newManualFlush(101, 101, true))
+	assert.Zero(t, info.meta.CollectionInfo.LastManualFlushTimeTick)
+	assert.False(t, info.dirty)
+
+	info.ObserveManualFlush(newManualFlush(102, 101, false))
+	assert.Equal(t, uint64(101), info.meta.CollectionInfo.LastManualFlushTimeTick)
+	assert.Equal(t, uint64(102), info.meta.CheckpointTimeTick)
+	assert.True(t, info.dirty)
+	snapshot, _ := info.ConsumeDirtyAndGetSnapshot()
+	assert.Equal(t, uint64(101), snapshot.CollectionInfo.LastManualFlushTimeTick)
+
+	// the replayed manual flush is idempotent.
+	info.ObserveManualFlush(newManualFlush(103, 101, false))
+	assert.Equal(t, uint64(101), info.meta.CollectionInfo.LastManualFlushTimeTick)
+	assert.False(t, info.dirty)
+
+	info.ObserveManualFlush(newManualFlush(104, 103, false))
+	assert.Equal(t, uint64(103), info.meta.CollectionInfo.LastManualFlushTimeTick)
+	assert.True(t, info.dirty)
+}
+
+func TestVChannelRecoveryInfoObserveSchemaChange(t *testing.T) {
+	info := newVChannelRecoveryInfoFromVChannelMeta([]*streamingpb.VChannelMeta{
+		{
+			Vchannel: "vchannel-1",
+			State:    streamingpb.VChannelState_VCHANNEL_STATE_NORMAL,
+			CollectionInfo: &streamingpb.CollectionInfoOfVChannel{
+				CollectionId: 100,
+			},
+			CheckpointTimeTick: 100,
+		},
+	})["vchannel-1"]
+
+	newSchemaChange := func(timetick uint64, modifyTs uint64) message.ImmutableSchemaChangeMessageV2 {
+		msgID := rmq.NewRmqID(int64(timetick))
+		immutableMsg := message.NewSchemaChangeMessageBuilderV2().
+			WithHeader(&message.SchemaChangeMessageHeader{CollectionId: 100}).
+			WithBody(&message.SchemaChangeMessageBody{ModifyTs: modifyTs}).
+			WithVChannel("vchannel-1").
+			MustBuildMutable().WithTimeTick(timetick).WithLastConfirmed(msgID).IntoImmutableMessage(msgID)
+		return message.MustAsImmutableCollectionSchemaChangeV2(immutableMsg)
+	}
+
+	// the schema change before the checkpoint is ignored.
+	info.ObserveSchemaChange(newSchemaChange(99, 99))
+	assert.Zero(t, info.meta.CollectionInfo.SchemaVersion)
+	assert.False(t, info.dirty)
+
+	info.ObserveSchemaChange(newSchemaChange(102, 101))
+	assert.Equal(t, uint64(101), info.meta.CollectionInfo.SchemaVersion)
+	assert.Equal(t, uint64(102), info.meta.CheckpointTimeTick)
+	assert.True(t, info.dirty)
+	snapshot, _ := info.ConsumeDirtyAndGetSnapshot()
+	assert.Equal(t, uint64(101), snapshot.CollectionInfo.SchemaVersion)
+
+	// the replayed schema change is idempotent.
+	info.ObserveSchemaChange(newSchemaChange(103, 101))
+	assert.Equal(t, uint64(101), info.meta.CollectionInfo.SchemaVersion)
+	assert.False(t, info.dirty)
+}
@@ -86,3 +86,20 @@ func WithAppendMetricsContext(ctx context.Context, m *metricsutil.AppendMetrics)
 func MustGetAppendMetrics(ctx context.Context) *metricsutil.AppendMetrics {
 	return ctx.Value(metricsValue).(*metricsutil.AppendMetrics)
 }
+
+// MarkAppendRedo marks the append of the context is going to be redone as the same logical message,
+// so the redo attempt is not counted as a new logical append by the metrics.
+func MarkAppendRedo(ctx context.Context) {
+	if m, ok := ctx.Value(metricsValue).(*metricsutil.AppendMetrics); ok {
+		m.MarkRedo()
+	}
+}
+
+// ObserveAppendRedoAttempt observes a redo attempt of the append of the context.
+// Return false if the redo is not marked by the interceptor.
+func ObserveAppendRedoAttempt(ctx context.Context) bool {
+	if m, ok := ctx.Value(metricsValue).(*metricsutil.AppendMetrics); ok {
+		return m.ObserveRedoAttempt()
+	}
+	return true
+}
@@ -24,6 +24,7 @@ var streamingErrorToGRPCStatus = map[streamingpb.StreamingCode]codes.Code{
 	streamingpb.StreamingCode_STREAMING_CODE_INVAILD_ARGUMENT:          codes.InvalidArgument,
 	streamingpb.StreamingCode_STREAMING_CODE_TRANSACTION_EXPIRED:       codes.FailedPrecondition,
 	streamingpb.StreamingCode_STREAMING_CODE_INVALID_TRANSACTION_STATE: codes.FailedPrecondition,
+	streamingpb.StreamingCode_STREAMING_CODE_RETRY_LATER:               codes.ResourceExhausted,
 	streamingpb.StreamingCode_STREAMING_CODE_UNKNOWN:                   codes.Unknown,
 }
 
@@ -79,7 +80,7 @@ func (s *StreamingClientStatus) TryIntoStreamingError() *StreamingError {
 	}
 	for _, detail := range s.Details() {
 		if detail, ok := detail.(*streamingpb.StreamingError); ok {
-			return New(detail.Code, detail.Cause)
+			return NewFromPBError(detail)
 		}
 	}
 	return nil
@@ -46,3 +46,29 @@ func TestNewGRPCStatusFromStreamingError(t *testing.T) {
 	)
 	assert.Equal(t, codes.Unknown, st.Code())
 }
+
+func TestTooLargeInsertErrorDecoding(t *testing.T) {
+	serverErr := NewTooLargeInsertError(2048, 1024, 512)
+	assert.True(t, serverErr.IsUnrecoverable())
+	assert.True(t, serverErr.IsTooLargeInsert())
+
+	// decode from grpc status details at client side.
+	err := ConvertStreamingError("test", NewGRPCStatusFromStreamingError(serverErr).Err())
+	streamingErr := AsStreamingError(err)
+	assert.Equal(t, streamingpb.StreamingCode_STREAMING_CODE_UNRECOVERABLE, streamingErr.Code)
+	assert.True(t, streamingErr.IsUnrecoverable())
+	assert.True(t, streamingErr.IsTooLargeInsert())
+	assert.Equal(t, uint64(2048), streamingErr.TooLargeInsert.GetBinarySize())
+	assert.Equal(t, uint64(1024), streamingErr.TooLargeInsert.GetMaxBinarySize())
+	assert.Equal(t, uint64(512), streamingErr.TooLargeInsert.GetRemainingBinarySize())
+	assert.Contains(t, streamingErr.Cause, "max binary size: 1024")
+
+	// decode from the produce response error.
+	streamingErr = NewFromPBError(serverErr.AsPBError())
+	assert.True(t, streamingErr.IsTooLargeInsert())
+	assert.Equal(t, uint64(1024), streamingErr.TooLargeInsert.GetMaxBinarySize())
+
+	// other errors do not carry the detail.
+	streamingErr = AsStreamingError(ConvertStreamingError("test", NewGRPCStatusFromStreamingError(NewUnrecoverableError("test")).Err()))
+	assert.False(t, streamingErr.IsTooLargeInsert())
+}
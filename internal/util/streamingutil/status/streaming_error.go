@@ -46,13 +46,14 @@ func (e *StreamingError) IsSkippedOperation() bool {
 // IsUnrecoverable returns true if the error is unrecoverable.
 // Stop resuming retry and report to user.
 func (e *StreamingError) IsUnrecoverable() bool {
-	return e.Code == streamingpb.StreamingCode_STREAMING_CODE_UNRECOVERABLE || e.IsTxnUnavilable()
+	return e.Code == streamingpb.StreamingCode_STREAMING_CODE_UNRECOVERABLE || e.IsTxnUnavilable() || e.IsPChannelFull()
 }
 
 // IsTxnUnavilable returns true if the transaction is unavailable.
 func (e *StreamingError) IsTxnUnavilable() bool {
 	return e.Code == streamingpb.StreamingCode_STREAMING_CODE_TRANSACTION_EXPIRED ||
-		e.Code == streamingpb.StreamingCode_STREAMING_CODE_INVALID_TRANSACTION_STATE
+		e.Code == streamingpb.StreamingCode_STREAMING_CODE_INVALID_TRANSACTION_STATE ||
+		e.Code == streamingpb.StreamingCode_STREAMING_CODE_TRANSACTION_FENCED
 }
 
 // IsTxnExpired returns true if the transaction is expired.
@@ -60,11 +61,33 @@ func (e *StreamingError) IsTxnExpired() bool {
 	return e.Code == streamingpb.StreamingCode_STREAMING_CODE_TRANSACTION_EXPIRED
 }
 
+// IsTxnFenced returns true if the transaction commit conflicts with a fence.
+func (e *StreamingError) IsTxnFenced() bool {
+	return e.Code == streamingpb.StreamingCode_STREAMING_CODE_TRANSACTION_FENCED
+}
+
 // IsResourceAcquired returns true if the resource is acquired.
 func (e *StreamingError) IsResourceAcquired() bool {
 	return e.Code == streamingpb.StreamingCode_STREAMING_CODE_RESOURCE_ACQUIRED
 }
 
+// IsRetryLater returns true if the operation is rejected for now and should be retried later with backoff.
+func (e *StreamingError) IsRetryLater() bool {
+	return e.Code == streamingpb.StreamingCode_STREAMING_CODE_RETRY_LATER
+}
+
+// IsPChannelFull returns true if the pchannel reaches its capacity of collections or partitions.
+// The coordinator should place the collection on another pchannel.
+func (e *StreamingError) IsPChannelFull() bool {
+	return e.Code == streamingpb.StreamingCode_STREAMING_CODE_PCHANNEL_FULL
+}
+
+// IsTooLargeInsert returns true if the insert is rejected for too large.
+// The max acceptable size can be got from the TooLargeInsert detail to re-batch the insert.
+func (e *StreamingError) IsTooLargeInsert() bool {
+	return e.TooLargeInsert != nil
+}
+
 // NewOnShutdownError creates a new StreamingError with code STREAMING_CODE_ON_SHUTDOWN.
 func NewOnShutdownError(format string, args ...interface{}) *StreamingError {
 	return New(streamingpb.StreamingCode_STREAMING_CODE_ON_SHUTDOWN, format, args...)
@@ -116,6 +139,11 @@ func NewTransactionExpired(format string, args ...interface{}) *StreamingError {
 	return New(streamingpb.StreamingCode_STREAMING_CODE_TRANSACTION_EXPIRED, format, args...)
 }
 
+// NewTransactionFenced creates a new StreamingError with code STREAMING_CODE_TRANSACTION_FENCED.
+func NewTransactionFenced(format string, args ...interface{}) *StreamingError {
+	return New(streamingpb.StreamingCode_STREAMING_CODE_TRANSACTION_FENCED, format, args...)
+}
+
 // NewInvalidTransactionState creates a new StreamingError with code STREAMING_CODE_INVALID_TRANSACTION_STATE.
 func NewInvalidTransactionState(operation string, expectState message.TxnState, currentState message.TxnState) *StreamingError {
 	return New(streamingpb.StreamingCode_STREAMING_CODE_INVALID_TRANSACTION_STATE, "invalid transaction state for operation %s, expect %s, current %s", operation, expectState, currentState)
@@ -126,6 +154,28 @@ func NewUnrecoverableError(format string, args ...interface{}) *StreamingError {
 	return New(streamingpb.StreamingCode_STREAMING_CODE_UNRECOVERABLE, format, args...)
 }
 
+// NewRetryLater creates a new StreamingError with code STREAMING_CODE_RETRY_LATER.
+func NewRetryLater(format string, args ...interface{}) *StreamingError {
+	return New(streamingpb.StreamingCode_STREAMING_CODE_RETRY_LATER, format, args...)
+}
+
+// NewTooLargeInsertError creates a new unrecoverable StreamingError with the detail of a too large insert.
+func NewTooLargeInsertError(binarySize uint64, maxBinarySize uint64, remainingBinarySize uint64) *StreamingError {
+	e := New(streamingpb.StreamingCode_STREAMING_CODE_UNRECOVERABLE,
+		"insert too large, binary size: %d, max binary size: %d, remaining binary size: %d", binarySize, maxBinarySize, remainingBinarySize)
+	e.TooLargeInsert = &streamingpb.TooLargeInsertDetail{
+		BinarySize:          binarySize,
+		MaxBinarySize:       maxBinarySize,
+		RemainingBinarySize: remainingBinarySize,
+	}
+	return e
+}
+
+// NewPChannelFull creates a new StreamingError with code STREAMING_CODE_PCHANNEL_FULL.
+func NewPChannelFull(format string, args ...interface{}) *StreamingError {
+	return New(streamingpb.StreamingCode_STREAMING_CODE_PCHANNEL_FULL, format, args...)
+}
+
 // NewResourceAcquired creates a new StreamingError with code STREAMING_CODE_RESOURCE_ACQUIRED.
 func NewResourceAcquired(format string, args ...interface{}) *StreamingError {
 	return New(streamingpb.StreamingCode_STREAMING_CODE_RESOURCE_ACQUIRED, format, args...)
@@ -145,6 +195,13 @@ func New(code streamingpb.StreamingCode, format string, args ...interface{}) *St
 	}
 }
 
+// NewFromPBError creates a new StreamingError from streamingpb.StreamingError, the error detail is kept.
+func NewFromPBError(e *streamingpb.StreamingError) *StreamingError {
+	err := New(e.GetCode(), e.GetCause())
+	err.TooLargeInsert = e.GetTooLargeInsert()
+	return err
+}
+
 // As implements StreamingError as error.
 func AsStreamingError(err error) *StreamingError {
 	if err == nil {
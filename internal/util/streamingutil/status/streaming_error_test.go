@@ -63,6 +63,13 @@ func TestStreamingError(t *testing.T) {
 	pbErr = streamingErr.AsPBError()
 	assert.Equal(t, streamingpb.StreamingCode_STREAMING_CODE_RESOURCE_ACQUIRED, pbErr.Code)
 
+	streamingErr = NewRetryLater("test, %d", 1)
+	assert.Contains(t, streamingErr.Error(), "code: STREAMING_CODE_RETRY_LATER, cause: test, 1")
+	assert.True(t, streamingErr.IsRetryLater())
+	assert.False(t, streamingErr.IsUnrecoverable())
+	pbErr = streamingErr.AsPBError()
+	assert.Equal(t, streamingpb.StreamingCode_STREAMING_CODE_RETRY_LATER, pbErr.Code)
+
 	streamingErr = NewTransactionExpired("test, %d", 1)
 	assert.Contains(t, streamingErr.Error(), "code: STREAMING_CODE_TRANSACTION_EXPIRED, cause: test, 1")
 	assert.True(t, streamingErr.IsTxnExpired())
@@ -70,4 +77,21 @@ func TestStreamingError(t *testing.T) {
 	assert.True(t, streamingErr.IsUnrecoverable())
 	pbErr = streamingErr.AsPBError()
 	assert.Equal(t, streamingpb.StreamingCode_STREAMING_CODE_TRANSACTION_EXPIRED, pbErr.Code)
+
+	streamingErr = NewTransactionFenced("test, %d", 1)
+	assert.Contains(t, streamingErr.Error(), "code: STREAMING_CODE_TRANSACTION_FENCED, cause: test, 1")
+	assert.True(t, streamingErr.IsTxnFenced())
+	assert.False(t, streamingErr.IsTxnExpired())
+	assert.True(t, streamingErr.IsTxnUnavilable())
+	assert.True(t, streamingErr.IsUnrecoverable())
+	pbErr = streamingErr.AsPBError()
+	assert.Equal(t, streamingpb.StreamingCode_STREAMING_CODE_TRANSACTION_FENCED, pbErr.Code)
+
+	streamingErr = NewPChannelFull("test, %d", 1)
+	assert.Contains(t, streamingErr.Error(), "code: STREAMING_CODE_PCHANNEL_FULL, cause: test, 1")
+	assert.True(t, streamingErr.IsPChannelFull())
+	assert.True(t, streamingErr.IsUnrecoverable())
+	assert.False(t, streamingErr.IsTxnUnavilable())
+	pbErr = streamingErr.AsPBError()
+	assert.Equal(t, streamingpb.StreamingCode_STREAMING_CODE_PCHANNEL_FULL, pbErr.Code)
 }
@@ -21,26 +21,45 @@ const (
 	WALStatusCancel                         = "cancel"
 	WALStatusError                          = "error"
 
-	BroadcasterTaskStateLabelName     = "state"
-	ResourceKeyDomainLabelName        = "domain"
-	WALAccessModelLabelName           = "access_model"
-	WALScannerModelLabelName          = "scanner_model"
-	TimeTickSyncTypeLabelName         = "type"
-	TimeTickAckTypeLabelName          = "type"
-	WALInterceptorLabelName           = "interceptor_name"
-	WALTxnStateLabelName              = "state"
-	WALFlusherStateLabelName          = "state"
-	WALStateLabelName                 = "state"
-	WALChannelLabelName               = channelNameLabelName
-	WALSegmentSealPolicyNameLabelName = "policy"
-	WALSegmentAllocStateLabelName     = "state"
-	WALMessageTypeLabelName           = "message_type"
-	WALChannelTermLabelName           = "term"
-	WALNameLabelName                  = "wal_name"
-	WALTxnTypeLabelName               = "txn_type"
-	StatusLabelName                   = statusLabelName
-	StreamingNodeLabelName            = "streaming_node"
-	NodeIDLabelName                   = nodeIDLabelName
+	BroadcasterTaskStateLabelName       = "state"
+	ResourceKeyDomainLabelName          = "domain"
+	WALAccessModelLabelName             = "access_model"
+	WALScannerModelLabelName            = "scanner_model"
+	TimeTickSyncTypeLabelName           = "type"
+	TimeTickAckTypeLabelName            = "type"
+	WALInterceptorLabelName             = "interceptor_name"
+	WALTxnStateLabelName                = "state"
+	WALFlusherStateLabelName            = "state"
+	WALStateLabelName                   = "state"
+	WALChannelLabelName                 = channelNameLabelName
+	WALVChannelLabelName                = "vchannel"
+	WALSegmentSealPolicyNameLabelName   = "policy"
+	WALSegmentCatalogPathLabelName      = "path"
+	WALSegmentAllocStateLabelName       = "state"
+	WALSegmentRecoverClassLabelName     = "class"
+	WALCircuitBreakerStateLabelName     = "state"
+	WALSealWorkerFailureReasonLabelName = "reason"
+	WALSegmentSealAbortReasonLabelName  = "reason"
+	WALSyncMetricsRejectLabelName       = "reason"
+	WALSegmentLateEventLabelName        = "event"
+	WALSegmentAckMisuseLabelName        = "misuse"
+	WALRedoOutcomeLabelName             = "outcome"
+	WALShadowStrategyLabelName          = "strategy"
+	WALRecoveryItemLabelName            = "item"
+	WALRecoveryStageLabelName           = "stage"
+	WALShadowOutcomeLabelName           = "outcome"
+	WALHandlerLabelName                 = "handler"
+	WALAllocMismatchReasonLabelName     = "reason"
+	WALTimeTickValidationModeLabelName  = "mode"
+	WALDuplicateOutcomeLabelName        = "outcome"
+	WALCollectionIDLabelName            = collectionIDLabelName
+	WALMessageTypeLabelName             = "message_type"
+	WALChannelTermLabelName             = "term"
+	WALNameLabelName                    = "wal_name"
+	WALTxnTypeLabelName                 = "txn_type"
+	StatusLabelName                     = statusLabelName
+	StreamingNodeLabelName              = "streaming_node"
+	NodeIDLabelName                     = nodeIDLabelName
 )
 
 var (
@@ -242,12 +261,239 @@ var (
 		Help: "Total of segment sealed on wal",
 	}, WALChannelLabelName, WALSegmentSealPolicyNameLabelName)
 
+	WALSegmentAllocMismatchTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_alloc_mismatch_total",
+		Help: "Total of the alloc segment responses of coordinator that mismatch the request",
+	}, WALChannelLabelName, WALAllocMismatchReasonLabelName)
+
+	WALSegmentTimeTickSealViolationTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_timetick_seal_violation_total",
+		Help: "Total of the inserts whose time tick is not greater than the last seal time tick of the partition",
+	}, WALChannelLabelName, WALCollectionIDLabelName, WALTimeTickValidationModeLabelName)
+
+	WALSegmentTimeTickTooOldTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_timetick_too_old_total",
+		Help: "Total of the assignments rejected by the too old time tick and redone with a refreshed time tick",
+	}, WALChannelLabelName, WALCollectionIDLabelName, WALVChannelLabelName)
+
+	WALSegmentTimeTickTooOldLagSeconds = newWALHistogramVec(prometheus.HistogramOpts{
+		Name:    "segment_assign_timetick_too_old_lag_seconds",
+		Help:    "Lag of the rejected time tick behind the create or last seal time tick it's compared with, in physical time",
+		Buckets: prometheus.ExponentialBucketsRange(0.001, 60, 12), // 1ms -> 1m
+	}, WALChannelLabelName, WALCollectionIDLabelName)
+
+	WALSegmentAssignAckMisuseTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_ack_misuse_total",
+		Help: "Total of the misused acks of the segment assign results, such as the double ack or the ack after the segment assignment is closed",
+	}, WALChannelLabelName, WALSegmentAckMisuseLabelName)
+
+	WALSegmentUndersizedMaxSizeTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_undersized_max_size_total",
+		Help: "Total of the collections whose segment max binary size can't hold the min rows of their average row size",
+	}, WALChannelLabelName, WALCollectionIDLabelName)
+
+	WALSegmentCreationRateAlertTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_creation_rate_alert_total",
+		Help: "Total of the alerts that the projected segment creation rate of a collection crosses the threshold",
+	}, WALChannelLabelName, WALCollectionIDLabelName)
+
+	WALSegmentSealSweepEvaluatedPartitions = newWALGaugeVec(prometheus.GaugeOpts{
+		Name: "segment_assign_seal_sweep_evaluated_partitions",
+		Help: "Count of the partitions evaluated by the last seal policy sweep",
+	}, WALChannelLabelName)
+
+	WALSegmentSealSweepDurationSeconds = newWALHistogramVec(prometheus.HistogramOpts{
+		Name:    "segment_assign_seal_sweep_duration_seconds",
+		Help:    "Duration of the seal policy sweep over the partitions of a pchannel",
+		Buckets: prometheus.ExponentialBucketsRange(0.0001, 10, 11), // 100us -> 10s
+	}, WALChannelLabelName)
+
 	WALSegmentBytes = newWALHistogramVec(prometheus.HistogramOpts{
 		Name:    "segment_assign_segment_bytes",
 		Help:    "Bytes of segment alloc on wal",
 		Buckets: prometheus.ExponentialBucketsRange(5242880, 1073741824, 10), // 5MB -> 1024MB
 	}, WALChannelLabelName)
 
+	WALSegmentTimeToSealSeconds = newWALHistogramVec(prometheus.HistogramOpts{
+		Name:    "segment_assign_time_to_seal_seconds",
+		Help:    "Duration from the first assigned insert of a segment to the seal decision of it, the segments sealed by drop operations are excluded",
+		Buckets: prometheus.ExponentialBucketsRange(1, 14400, 12), // 1s -> 4h
+	}, WALChannelLabelName, WALCollectionIDLabelName, WALSegmentSealPolicyNameLabelName)
+
+	WALSegmentWriteAmplification = newWALHistogramVec(prometheus.HistogramOpts{
+		Name:    "segment_assign_write_amplification",
+		Help:    "Ratio of the binary size written by the sync operations to the binary size assigned into a segment, observed when the segment is sealed",
+		Buckets: prometheus.ExponentialBucketsRange(0.125, 16, 15), // 0.125 -> 16
+	}, WALChannelLabelName, WALCollectionIDLabelName)
+
+	WALSegmentRecoveredTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_recovered_segment_total",
+		Help: "Total of segment assignment meta recovered on wal, classified by the validation result",
+	}, WALChannelLabelName, WALSegmentRecoverClassLabelName)
+
+	WALSegmentAssignRecoverySummary = newWALGaugeVec(prometheus.GaugeOpts{
+		Name: "segment_assign_recovery_summary",
+		Help: "Summary of the last segment assignment recovery on wal, such as the count of restored collections and quarantined metas",
+	}, WALChannelLabelName, WALRecoveryItemLabelName)
+
+	WALSegmentAssignRecoveryStageDurationSeconds = newWALGaugeVec(prometheus.GaugeOpts{
+		Name: "segment_assign_recovery_stage_duration_seconds",
+		Help: "Duration of every stage of the last segment assignment recovery on wal",
+	}, WALChannelLabelName, WALRecoveryStageLabelName)
+
+	WALSegmentSealDeferredTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_seal_deferred_total",
+		Help: "Total of policy-driven seals that are deferred because the segment is still in the seal grace period",
+	}, WALChannelLabelName, WALSegmentSealPolicyNameLabelName)
+
+	WALSegmentSealAbortedTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_seal_aborted_total",
+		Help: "Total of seals aborted before the flush message is appended because the sealed state is not durable in catalog, the segment is retried by the next sweep",
+	}, WALChannelLabelName, WALSegmentSealAbortReasonLabelName)
+
+	WALSegmentSealTriggerCoalescedTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_seal_trigger_coalesced_total",
+		Help: "Total of seal triggers from sync operation that are coalesced into other seal sweeps",
+	})
+
+	WALSegmentSealWorkerFailureTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_seal_worker_failure_total",
+		Help: "Total of failures of the seal worker of pchannel, such as panic in seal sweep or stalled seal worker",
+	}, WALChannelLabelName, WALSealWorkerFailureReasonLabelName)
+
+	WALSegmentSyncMetricsRejectedTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_sync_metrics_rejected_total",
+		Help: "Total of malformed sync metrics that are rejected or clamped by the segment stats manager",
+	}, WALSyncMetricsRejectLabelName)
+
+	WALSegmentLateEventTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_late_event_total",
+		Help: "Total of acks and sync updates that arrive after the segment is flushed or discarded, they are expected and never applied",
+	}, WALChannelLabelName, WALSegmentLateEventLabelName)
+
+	WALVChannelGrowingSegmentTotal = newWALGaugeVec(prometheus.GaugeOpts{
+		Name: "segment_assign_vchannel_growing_segment_total",
+		Help: "Total of growing segments of vchannel on wal",
+	}, WALChannelLabelName, WALVChannelLabelName, WALCollectionIDLabelName)
+
+	WALVChannelGrowingRows = newWALGaugeVec(prometheus.GaugeOpts{
+		Name: "segment_assign_vchannel_growing_rows",
+		Help: "Rows of growing segments of vchannel on wal",
+	}, WALChannelLabelName, WALVChannelLabelName, WALCollectionIDLabelName)
+
+	WALVChannelGrowingBytes = newWALGaugeVec(prometheus.GaugeOpts{
+		Name: "segment_assign_vchannel_growing_bytes",
+		Help: "Binary size of growing segments of vchannel on wal",
+	}, WALChannelLabelName, WALVChannelLabelName, WALCollectionIDLabelName)
+
+	WALSegmentAssignZeroRowsInsertTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_zero_rows_insert_total",
+		Help: "Total of insert messages with zero rows that skip the segment assignment",
+	}, WALChannelLabelName)
+
+	WALSegmentFlushTimeTickRefreshTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_flush_timetick_refresh_total",
+		Help: "Total of flush messages redone to refresh a timetick greater than the inserts assigned to the segment",
+	}, WALChannelLabelName)
+
+	WALSegmentAssignHandlerPanicTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_handler_panic_total",
+		Help: "Total of panics recovered from the message handlers of segment assignment interceptor",
+	}, WALChannelLabelName, WALHandlerLabelName)
+
+	WALSegmentAssignDuplicatePartitionInsertTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_duplicate_partition_insert_total",
+		Help: "Total of insert messages whose header lists the same partition more than once, by merged or rejected",
+	}, WALChannelLabelName, WALDuplicateOutcomeLabelName)
+
+	WALSegmentAssignShadowTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_shadow_total",
+		Help: "Total of segment assignment decisions evaluated by the shadow strategy, labeled by the comparison outcome with the primary decision",
+	}, WALChannelLabelName, WALShadowStrategyLabelName, WALShadowOutcomeLabelName)
+
+	WALSegmentAssignCircuitBreakerTransitionTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_circuit_breaker_transition_total",
+		Help: "Total of state transitions of the collection circuit breaker of segment assignment",
+	}, WALChannelLabelName, WALCircuitBreakerStateLabelName)
+
+	WALSegmentSealQueueWaitingTotal = newWALGaugeVec(prometheus.GaugeOpts{
+		Name: "segment_assign_seal_queue_waiting_total",
+		Help: "Total of sealed segments that wait for the flying acks or txns before flushing",
+	}, WALChannelLabelName)
+
+	WALSegmentSealQueueMemoryBytes = newWALGaugeVec(prometheus.GaugeOpts{
+		Name: "segment_assign_seal_queue_memory_bytes",
+		Help: "Approximate memory used by the sealed segments that wait for the flying acks or txns before flushing",
+	}, WALChannelLabelName)
+
+	WALSegmentSealQueueForceResolvedTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_seal_queue_force_resolved_total",
+		Help: "Total of sealed segments that are flushed without waiting for the flying acks because the seal queue is full",
+	}, WALChannelLabelName)
+
+	WALSegmentSealQueuePersistBacklog = newWALGaugeVec(prometheus.GaugeOpts{
+		Name: "segment_assign_seal_queue_persist_backlog",
+		Help: "Total of segments decided to be sealed but not persisted yet because the catalog write budget of the seal cycle is exhausted",
+	}, WALChannelLabelName)
+
+	WALSegmentAssignCatalogDurationSeconds = newWALHistogramVec(prometheus.HistogramOpts{
+		Name:    "segment_assign_catalog_duration_seconds",
+		Help:    "Duration of the segment assignment catalog operation, classified by the assignment or sweep path",
+		Buckets: secondsBuckets,
+	}, WALChannelLabelName, WALSegmentCatalogPathLabelName)
+
+	WALSegmentAssignCatalogTimeoutTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_catalog_timeout_total",
+		Help: "Total of segment assignment catalog operations that exceed the per-operation timeout, classified by the assignment or sweep path",
+	}, WALChannelLabelName, WALSegmentCatalogPathLabelName)
+
+	WALSegmentUnsealedTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_unsealed_total",
+		Help: "Total of sealed but unflushed segments that are reverted back to growing by the emergency unseal",
+	}, WALChannelLabelName)
+
+	WALSegmentMetaCompactedTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_meta_compacted_total",
+		Help: "Total of segment assignment meta keys removed by the meta compaction of dropped partitions",
+	}, WALChannelLabelName)
+
+	WALSegmentAssignLockWaitSeconds = newWALHistogramVec(prometheus.HistogramOpts{
+		Name:    "segment_assign_lock_wait_seconds",
+		Help:    "Duration of the segment assignment waiting for the lock of the partition",
+		Buckets: secondsBuckets,
+	}, WALChannelLabelName)
+
+	WALSegmentAllocDurationSeconds = newWALHistogramVec(prometheus.HistogramOpts{
+		Name:    "segment_assign_alloc_duration_seconds",
+		Help:    "Duration of the allocation of a new growing segment on the append path",
+		Buckets: secondsBuckets,
+	}, WALChannelLabelName)
+
+	WALSegmentAssignRejectedTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "segment_assign_rejected_total",
+		Help: "Total of segment assignments rejected by the backpressure, such as the open circuit breaker or the assignment timeout",
+	}, WALChannelLabelName)
+
+	WALSegmentAssignSaturation = newWALGaugeVec(prometheus.GaugeOpts{
+		Name: "segment_assign_saturation",
+		Help: "Rolling saturation score of the append path of the pchannel in [0, 1], derived from the lock wait, the allocation latency and the rejection rate",
+	}, WALChannelLabelName)
+
+	WALRedoTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "redo_total",
+		Help: "Total of redo decisions of append operations, redo at server side or retry at client side",
+	}, WALChannelLabelName, WALRedoOutcomeLabelName)
+
+	WALRedoPendingTotal = newWALGaugeVec(prometheus.GaugeOpts{
+		Name: "redo_pending_total",
+		Help: "Total of messages currently in redo of wal",
+	}, WALChannelLabelName)
+
+	WALRedoOldestAgeSeconds = newWALGaugeVec(prometheus.GaugeOpts{
+		Name: "redo_oldest_age_seconds",
+		Help: "Age of the oldest message currently in redo of wal since its first redo attempt",
+	}, WALChannelLabelName)
+
 	WALPartitionTotal = newWALGaugeVec(prometheus.GaugeOpts{
 		Name: "segment_assign_partition_total",
 		Help: "Total of partition on wal",
@@ -267,7 +513,12 @@ var (
 
 	WALAppendMessageTotal = newWALCounterVec(prometheus.CounterOpts{
 		Name: "append_message_total",
-		Help: "Total of append message to wal",
+		Help: "Total of logical append message to wal, the redo attempts of the same message are counted once",
+	}, WALChannelLabelName, WALMessageTypeLabelName, StatusLabelName)
+
+	WALAppendMessagePhysicalTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "append_message_physical_total",
+		Help: "Total of append attempts of message through the interceptor chain, including the redo attempts of the same message",
 	}, WALChannelLabelName, WALMessageTypeLabelName, StatusLabelName)
 
 	WALAppendMessageBeforeInterceptorDurationSeconds = newWALHistogramVec(prometheus.HistogramOpts{
@@ -381,6 +632,11 @@ var (
 		Name: "flusher_time_tick",
 		Help: "the final timetick tick of flusher seen",
 	}, WALChannelLabelName, WALChannelTermLabelName)
+
+	WALFlusherQuarantinedMessageTotal = newWALCounterVec(prometheus.CounterOpts{
+		Name: "flusher_quarantined_message_total",
+		Help: "Total of messages quarantined by flusher for the corrupted segment assignment, the rows are never buffered",
+	}, WALChannelLabelName, WALChannelTermLabelName, WALMessageTypeLabelName)
 )
 
 // RegisterStreamingServiceClient registers streaming service client metrics
@@ -437,10 +693,55 @@ func registerWAL(registry *prometheus.Registry) {
 	registry.MustRegister(WALSegmentAllocTotal)
 	registry.MustRegister(WALSegmentFlushedTotal)
 	registry.MustRegister(WALSegmentBytes)
+	registry.MustRegister(WALSegmentTimeToSealSeconds)
+	registry.MustRegister(WALSegmentWriteAmplification)
+	registry.MustRegister(WALSegmentRecoveredTotal)
+	registry.MustRegister(WALSegmentAssignRecoverySummary)
+	registry.MustRegister(WALSegmentAssignRecoveryStageDurationSeconds)
+	registry.MustRegister(WALSegmentSealDeferredTotal)
+	registry.MustRegister(WALSegmentAllocMismatchTotal)
+	registry.MustRegister(WALSegmentTimeTickSealViolationTotal)
+	registry.MustRegister(WALSegmentTimeTickTooOldTotal)
+	registry.MustRegister(WALSegmentTimeTickTooOldLagSeconds)
+	registry.MustRegister(WALSegmentAssignAckMisuseTotal)
+	registry.MustRegister(WALSegmentUndersizedMaxSizeTotal)
+	registry.MustRegister(WALSegmentCreationRateAlertTotal)
+	registry.MustRegister(WALSegmentSealSweepEvaluatedPartitions)
+	registry.MustRegister(WALSegmentSealSweepDurationSeconds)
+	registry.MustRegister(WALSegmentSealTriggerCoalescedTotal)
+	registry.MustRegister(WALSegmentSealAbortedTotal)
+	registry.MustRegister(WALSegmentSealWorkerFailureTotal)
+	registry.MustRegister(WALSegmentSyncMetricsRejectedTotal)
+	registry.MustRegister(WALSegmentLateEventTotal)
+	registry.MustRegister(WALVChannelGrowingSegmentTotal)
+	registry.MustRegister(WALVChannelGrowingRows)
+	registry.MustRegister(WALVChannelGrowingBytes)
+	registry.MustRegister(WALSegmentAssignZeroRowsInsertTotal)
+	registry.MustRegister(WALSegmentAssignHandlerPanicTotal)
+	registry.MustRegister(WALSegmentAssignDuplicatePartitionInsertTotal)
+	registry.MustRegister(WALSegmentFlushTimeTickRefreshTotal)
+	registry.MustRegister(WALSegmentAssignShadowTotal)
+	registry.MustRegister(WALSegmentAssignCircuitBreakerTransitionTotal)
+	registry.MustRegister(WALSegmentSealQueueWaitingTotal)
+	registry.MustRegister(WALSegmentSealQueueMemoryBytes)
+	registry.MustRegister(WALSegmentSealQueueForceResolvedTotal)
+	registry.MustRegister(WALSegmentSealQueuePersistBacklog)
+	registry.MustRegister(WALSegmentAssignCatalogDurationSeconds)
+	registry.MustRegister(WALSegmentAssignCatalogTimeoutTotal)
+	registry.MustRegister(WALSegmentUnsealedTotal)
+	registry.MustRegister(WALSegmentMetaCompactedTotal)
+	registry.MustRegister(WALSegmentAssignLockWaitSeconds)
+	registry.MustRegister(WALSegmentAllocDurationSeconds)
+	registry.MustRegister(WALSegmentAssignRejectedTotal)
+	registry.MustRegister(WALSegmentAssignSaturation)
+	registry.MustRegister(WALRedoTotal)
+	registry.MustRegister(WALRedoPendingTotal)
+	registry.MustRegister(WALRedoOldestAgeSeconds)
 	registry.MustRegister(WALPartitionTotal)
 	registry.MustRegister(WALCollectionTotal)
 	registry.MustRegister(WALAppendMessageBytes)
 	registry.MustRegister(WALAppendMessageTotal)
+	registry.MustRegister(WALAppendMessagePhysicalTotal)
 	registry.MustRegister(WALAppendMessageBeforeInterceptorDurationSeconds)
 	registry.MustRegister(WALAppendMessageAfterInterceptorDurationSeconds)
 	registry.MustRegister(WALAppendMessageDurationSeconds)
@@ -462,6 +763,7 @@ func registerWAL(registry *prometheus.Registry) {
 	registry.MustRegister(WALScannerTxnBufBytes)
 	registry.MustRegister(WALFlusherInfo)
 	registry.MustRegister(WALFlusherTimeTick)
+	registry.MustRegister(WALFlusherQuarantinedMessageTotal)
 }
 
 func newStreamingCoordGaugeVec(opts prometheus.GaugeOpts, extra ...string) *prometheus.GaugeVec {
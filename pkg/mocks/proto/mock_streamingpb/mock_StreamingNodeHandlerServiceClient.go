@@ -25,6 +25,80 @@ func (_m *MockStreamingNodeHandlerServiceClient) EXPECT() *MockStreamingNodeHand
 	return &MockStreamingNodeHandlerServiceClient_Expecter{mock: &_m.Mock}
 }
 
+// AssignPreview provides a mock function with given fields: ctx, in, opts
+func (_m *MockStreamingNodeHandlerServiceClient) AssignPreview(ctx context.Context, in *streamingpb.AssignPreviewRequest, opts ...grpc.CallOption) (*streamingpb.AssignPreviewResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignPreview")
+	}
+
+	var r0 *streamingpb.AssignPreviewResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingpb.AssignPreviewRequest, ...grpc.CallOption) (*streamingpb.AssignPreviewResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingpb.AssignPreviewRequest, ...grpc.CallOption) *streamingpb.AssignPreviewResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*streamingpb.AssignPreviewResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *streamingpb.AssignPreviewRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStreamingNodeHandlerServiceClient_AssignPreview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignPreview'
+type MockStreamingNodeHandlerServiceClient_AssignPreview_Call struct {
+	*mock.Call
+}
+
+// AssignPreview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *streamingpb.AssignPreviewRequest
+//   - opts ...grpc.CallOption
+func (_e *MockStreamingNodeHandlerServiceClient_Expecter) AssignPreview(ctx interface{}, in interface{}, opts ...interface{}) *MockStreamingNodeHandlerServiceClient_AssignPreview_Call {
+	return &MockStreamingNodeHandlerServiceClient_AssignPreview_Call{Call: _e.mock.On("AssignPreview",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockStreamingNodeHandlerServiceClient_AssignPreview_Call) Run(run func(ctx context.Context, in *streamingpb.AssignPreviewRequest, opts ...grpc.CallOption)) *MockStreamingNodeHandlerServiceClient_AssignPreview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*streamingpb.AssignPreviewRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockStreamingNodeHandlerServiceClient_AssignPreview_Call) Return(_a0 *streamingpb.AssignPreviewResponse, _a1 error) *MockStreamingNodeHandlerServiceClient_AssignPreview_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStreamingNodeHandlerServiceClient_AssignPreview_Call) RunAndReturn(run func(context.Context, *streamingpb.AssignPreviewRequest, ...grpc.CallOption) (*streamingpb.AssignPreviewResponse, error)) *MockStreamingNodeHandlerServiceClient_AssignPreview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Consume provides a mock function with given fields: ctx, opts
 func (_m *MockStreamingNodeHandlerServiceClient) Consume(ctx context.Context, opts ...grpc.CallOption) (streamingpb.StreamingNodeHandlerService_ConsumeClient, error) {
 	_va := make([]interface{}, len(opts))
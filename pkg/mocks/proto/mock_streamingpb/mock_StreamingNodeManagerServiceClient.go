@@ -173,6 +173,302 @@ func (_c *MockStreamingNodeManagerServiceClient_CollectStatus_Call) RunAndReturn
 	return _c
 }
 
+// FlushSegmentsOlderThan provides a mock function with given fields: ctx, in, opts
+func (_m *MockStreamingNodeManagerServiceClient) FlushSegmentsOlderThan(ctx context.Context, in *streamingpb.StreamingNodeManagerFlushSegmentsOlderThanRequest, opts ...grpc.CallOption) (*streamingpb.StreamingNodeManagerFlushSegmentsOlderThanResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlushSegmentsOlderThan")
+	}
+
+	var r0 *streamingpb.StreamingNodeManagerFlushSegmentsOlderThanResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingpb.StreamingNodeManagerFlushSegmentsOlderThanRequest, ...grpc.CallOption) (*streamingpb.StreamingNodeManagerFlushSegmentsOlderThanResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingpb.StreamingNodeManagerFlushSegmentsOlderThanRequest, ...grpc.CallOption) *streamingpb.StreamingNodeManagerFlushSegmentsOlderThanResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*streamingpb.StreamingNodeManagerFlushSegmentsOlderThanResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *streamingpb.StreamingNodeManagerFlushSegmentsOlderThanRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStreamingNodeManagerServiceClient_FlushSegmentsOlderThan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushSegmentsOlderThan'
+type MockStreamingNodeManagerServiceClient_FlushSegmentsOlderThan_Call struct {
+	*mock.Call
+}
+
+// FlushSegmentsOlderThan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *streamingpb.StreamingNodeManagerFlushSegmentsOlderThanRequest
+//   - opts ...grpc.CallOption
+func (_e *MockStreamingNodeManagerServiceClient_Expecter) FlushSegmentsOlderThan(ctx interface{}, in interface{}, opts ...interface{}) *MockStreamingNodeManagerServiceClient_FlushSegmentsOlderThan_Call {
+	return &MockStreamingNodeManagerServiceClient_FlushSegmentsOlderThan_Call{Call: _e.mock.On("FlushSegmentsOlderThan",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockStreamingNodeManagerServiceClient_FlushSegmentsOlderThan_Call) Run(run func(ctx context.Context, in *streamingpb.StreamingNodeManagerFlushSegmentsOlderThanRequest, opts ...grpc.CallOption)) *MockStreamingNodeManagerServiceClient_FlushSegmentsOlderThan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*streamingpb.StreamingNodeManagerFlushSegmentsOlderThanRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockStreamingNodeManagerServiceClient_FlushSegmentsOlderThan_Call) Return(_a0 *streamingpb.StreamingNodeManagerFlushSegmentsOlderThanResponse, _a1 error) *MockStreamingNodeManagerServiceClient_FlushSegmentsOlderThan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStreamingNodeManagerServiceClient_FlushSegmentsOlderThan_Call) RunAndReturn(run func(context.Context, *streamingpb.StreamingNodeManagerFlushSegmentsOlderThanRequest, ...grpc.CallOption) (*streamingpb.StreamingNodeManagerFlushSegmentsOlderThanResponse, error)) *MockStreamingNodeManagerServiceClient_FlushSegmentsOlderThan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForceAddPartition provides a mock function with given fields: ctx, in, opts
+func (_m *MockStreamingNodeManagerServiceClient) ForceAddPartition(ctx context.Context, in *streamingpb.StreamingNodeManagerForceAddPartitionRequest, opts ...grpc.CallOption) (*streamingpb.StreamingNodeManagerForceAddPartitionResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceAddPartition")
+	}
+
+	var r0 *streamingpb.StreamingNodeManagerForceAddPartitionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingpb.StreamingNodeManagerForceAddPartitionRequest, ...grpc.CallOption) (*streamingpb.StreamingNodeManagerForceAddPartitionResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingpb.StreamingNodeManagerForceAddPartitionRequest, ...grpc.CallOption) *streamingpb.StreamingNodeManagerForceAddPartitionResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*streamingpb.StreamingNodeManagerForceAddPartitionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *streamingpb.StreamingNodeManagerForceAddPartitionRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStreamingNodeManagerServiceClient_ForceAddPartition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForceAddPartition'
+type MockStreamingNodeManagerServiceClient_ForceAddPartition_Call struct {
+	*mock.Call
+}
+
+// ForceAddPartition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *streamingpb.StreamingNodeManagerForceAddPartitionRequest
+//   - opts ...grpc.CallOption
+func (_e *MockStreamingNodeManagerServiceClient_Expecter) ForceAddPartition(ctx interface{}, in interface{}, opts ...interface{}) *MockStreamingNodeManagerServiceClient_ForceAddPartition_Call {
+	return &MockStreamingNodeManagerServiceClient_ForceAddPartition_Call{Call: _e.mock.On("ForceAddPartition",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockStreamingNodeManagerServiceClient_ForceAddPartition_Call) Run(run func(ctx context.Context, in *streamingpb.StreamingNodeManagerForceAddPartitionRequest, opts ...grpc.CallOption)) *MockStreamingNodeManagerServiceClient_ForceAddPartition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*streamingpb.StreamingNodeManagerForceAddPartitionRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockStreamingNodeManagerServiceClient_ForceAddPartition_Call) Return(_a0 *streamingpb.StreamingNodeManagerForceAddPartitionResponse, _a1 error) *MockStreamingNodeManagerServiceClient_ForceAddPartition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStreamingNodeManagerServiceClient_ForceAddPartition_Call) RunAndReturn(run func(context.Context, *streamingpb.StreamingNodeManagerForceAddPartitionRequest, ...grpc.CallOption) (*streamingpb.StreamingNodeManagerForceAddPartitionResponse, error)) *MockStreamingNodeManagerServiceClient_ForceAddPartition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSealHistory provides a mock function with given fields: ctx, in, opts
+func (_m *MockStreamingNodeManagerServiceClient) GetSealHistory(ctx context.Context, in *streamingpb.StreamingNodeManagerGetSealHistoryRequest, opts ...grpc.CallOption) (*streamingpb.StreamingNodeManagerGetSealHistoryResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSealHistory")
+	}
+
+	var r0 *streamingpb.StreamingNodeManagerGetSealHistoryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingpb.StreamingNodeManagerGetSealHistoryRequest, ...grpc.CallOption) (*streamingpb.StreamingNodeManagerGetSealHistoryResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingpb.StreamingNodeManagerGetSealHistoryRequest, ...grpc.CallOption) *streamingpb.StreamingNodeManagerGetSealHistoryResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*streamingpb.StreamingNodeManagerGetSealHistoryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *streamingpb.StreamingNodeManagerGetSealHistoryRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStreamingNodeManagerServiceClient_GetSealHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSealHistory'
+type MockStreamingNodeManagerServiceClient_GetSealHistory_Call struct {
+	*mock.Call
+}
+
+// GetSealHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *streamingpb.StreamingNodeManagerGetSealHistoryRequest
+//   - opts ...grpc.CallOption
+func (_e *MockStreamingNodeManagerServiceClient_Expecter) GetSealHistory(ctx interface{}, in interface{}, opts ...interface{}) *MockStreamingNodeManagerServiceClient_GetSealHistory_Call {
+	return &MockStreamingNodeManagerServiceClient_GetSealHistory_Call{Call: _e.mock.On("GetSealHistory",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockStreamingNodeManagerServiceClient_GetSealHistory_Call) Run(run func(ctx context.Context, in *streamingpb.StreamingNodeManagerGetSealHistoryRequest, opts ...grpc.CallOption)) *MockStreamingNodeManagerServiceClient_GetSealHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*streamingpb.StreamingNodeManagerGetSealHistoryRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockStreamingNodeManagerServiceClient_GetSealHistory_Call) Return(_a0 *streamingpb.StreamingNodeManagerGetSealHistoryResponse, _a1 error) *MockStreamingNodeManagerServiceClient_GetSealHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStreamingNodeManagerServiceClient_GetSealHistory_Call) RunAndReturn(run func(context.Context, *streamingpb.StreamingNodeManagerGetSealHistoryRequest, ...grpc.CallOption) (*streamingpb.StreamingNodeManagerGetSealHistoryResponse, error)) *MockStreamingNodeManagerServiceClient_GetSealHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReloadCollectionPartitions provides a mock function with given fields: ctx, in, opts
+func (_m *MockStreamingNodeManagerServiceClient) ReloadCollectionPartitions(ctx context.Context, in *streamingpb.StreamingNodeManagerReloadCollectionPartitionsRequest, opts ...grpc.CallOption) (*streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReloadCollectionPartitions")
+	}
+
+	var r0 *streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingpb.StreamingNodeManagerReloadCollectionPartitionsRequest, ...grpc.CallOption) (*streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingpb.StreamingNodeManagerReloadCollectionPartitionsRequest, ...grpc.CallOption) *streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *streamingpb.StreamingNodeManagerReloadCollectionPartitionsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStreamingNodeManagerServiceClient_ReloadCollectionPartitions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReloadCollectionPartitions'
+type MockStreamingNodeManagerServiceClient_ReloadCollectionPartitions_Call struct {
+	*mock.Call
+}
+
+// ReloadCollectionPartitions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *streamingpb.StreamingNodeManagerReloadCollectionPartitionsRequest
+//   - opts ...grpc.CallOption
+func (_e *MockStreamingNodeManagerServiceClient_Expecter) ReloadCollectionPartitions(ctx interface{}, in interface{}, opts ...interface{}) *MockStreamingNodeManagerServiceClient_ReloadCollectionPartitions_Call {
+	return &MockStreamingNodeManagerServiceClient_ReloadCollectionPartitions_Call{Call: _e.mock.On("ReloadCollectionPartitions",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockStreamingNodeManagerServiceClient_ReloadCollectionPartitions_Call) Run(run func(ctx context.Context, in *streamingpb.StreamingNodeManagerReloadCollectionPartitionsRequest, opts ...grpc.CallOption)) *MockStreamingNodeManagerServiceClient_ReloadCollectionPartitions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*streamingpb.StreamingNodeManagerReloadCollectionPartitionsRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockStreamingNodeManagerServiceClient_ReloadCollectionPartitions_Call) Return(_a0 *streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse, _a1 error) *MockStreamingNodeManagerServiceClient_ReloadCollectionPartitions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStreamingNodeManagerServiceClient_ReloadCollectionPartitions_Call) RunAndReturn(run func(context.Context, *streamingpb.StreamingNodeManagerReloadCollectionPartitionsRequest, ...grpc.CallOption) (*streamingpb.StreamingNodeManagerReloadCollectionPartitionsResponse, error)) *MockStreamingNodeManagerServiceClient_ReloadCollectionPartitions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Remove provides a mock function with given fields: ctx, in, opts
 func (_m *MockStreamingNodeManagerServiceClient) Remove(ctx context.Context, in *streamingpb.StreamingNodeManagerRemoveRequest, opts ...grpc.CallOption) (*streamingpb.StreamingNodeManagerRemoveResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -247,6 +543,80 @@ func (_c *MockStreamingNodeManagerServiceClient_Remove_Call) RunAndReturn(run fu
 	return _c
 }
 
+// SealSegments provides a mock function with given fields: ctx, in, opts
+func (_m *MockStreamingNodeManagerServiceClient) SealSegments(ctx context.Context, in *streamingpb.StreamingNodeManagerSealSegmentsRequest, opts ...grpc.CallOption) (*streamingpb.StreamingNodeManagerSealSegmentsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SealSegments")
+	}
+
+	var r0 *streamingpb.StreamingNodeManagerSealSegmentsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingpb.StreamingNodeManagerSealSegmentsRequest, ...grpc.CallOption) (*streamingpb.StreamingNodeManagerSealSegmentsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingpb.StreamingNodeManagerSealSegmentsRequest, ...grpc.CallOption) *streamingpb.StreamingNodeManagerSealSegmentsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*streamingpb.StreamingNodeManagerSealSegmentsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *streamingpb.StreamingNodeManagerSealSegmentsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStreamingNodeManagerServiceClient_SealSegments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SealSegments'
+type MockStreamingNodeManagerServiceClient_SealSegments_Call struct {
+	*mock.Call
+}
+
+// SealSegments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *streamingpb.StreamingNodeManagerSealSegmentsRequest
+//   - opts ...grpc.CallOption
+func (_e *MockStreamingNodeManagerServiceClient_Expecter) SealSegments(ctx interface{}, in interface{}, opts ...interface{}) *MockStreamingNodeManagerServiceClient_SealSegments_Call {
+	return &MockStreamingNodeManagerServiceClient_SealSegments_Call{Call: _e.mock.On("SealSegments",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockStreamingNodeManagerServiceClient_SealSegments_Call) Run(run func(ctx context.Context, in *streamingpb.StreamingNodeManagerSealSegmentsRequest, opts ...grpc.CallOption)) *MockStreamingNodeManagerServiceClient_SealSegments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*streamingpb.StreamingNodeManagerSealSegmentsRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockStreamingNodeManagerServiceClient_SealSegments_Call) Return(_a0 *streamingpb.StreamingNodeManagerSealSegmentsResponse, _a1 error) *MockStreamingNodeManagerServiceClient_SealSegments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStreamingNodeManagerServiceClient_SealSegments_Call) RunAndReturn(run func(context.Context, *streamingpb.StreamingNodeManagerSealSegmentsRequest, ...grpc.CallOption) (*streamingpb.StreamingNodeManagerSealSegmentsResponse, error)) *MockStreamingNodeManagerServiceClient_SealSegments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockStreamingNodeManagerServiceClient creates a new instance of MockStreamingNodeManagerServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockStreamingNodeManagerServiceClient(t interface {
@@ -365,8 +365,9 @@ var StreamingCoordAssignmentService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	StreamingNodeHandlerService_Produce_FullMethodName = "/milvus.proto.streaming.StreamingNodeHandlerService/Produce"
-	StreamingNodeHandlerService_Consume_FullMethodName = "/milvus.proto.streaming.StreamingNodeHandlerService/Consume"
+	StreamingNodeHandlerService_Produce_FullMethodName       = "/milvus.proto.streaming.StreamingNodeHandlerService/Produce"
+	StreamingNodeHandlerService_Consume_FullMethodName       = "/milvus.proto.streaming.StreamingNodeHandlerService/Consume"
+	StreamingNodeHandlerService_AssignPreview_FullMethodName = "/milvus.proto.streaming.StreamingNodeHandlerService/AssignPreview"
 )
 
 // StreamingNodeHandlerServiceClient is the client API for StreamingNodeHandlerService service.
@@ -388,6 +389,10 @@ type StreamingNodeHandlerServiceClient interface {
 	// log node, the RPC will return error CHANNEL_NOT_EXIST. If channel is
 	// moving away to other log node, the RPC will return error CHANNEL_FENCED.
 	Consume(ctx context.Context, opts ...grpc.CallOption) (StreamingNodeHandlerService_ConsumeClient, error)
+	// AssignPreview is a pre-flight capacity check of an insert batch.
+	// It runs the same capacity evaluation as the segment assignment of the insert,
+	// but never reserves the capacity or creates the segment.
+	AssignPreview(ctx context.Context, in *AssignPreviewRequest, opts ...grpc.CallOption) (*AssignPreviewResponse, error)
 }
 
 type streamingNodeHandlerServiceClient struct {
@@ -460,6 +465,15 @@ func (x *streamingNodeHandlerServiceConsumeClient) Recv() (*ConsumeResponse, err
 	return m, nil
 }
 
+func (c *streamingNodeHandlerServiceClient) AssignPreview(ctx context.Context, in *AssignPreviewRequest, opts ...grpc.CallOption) (*AssignPreviewResponse, error) {
+	out := new(AssignPreviewResponse)
+	err := c.cc.Invoke(ctx, StreamingNodeHandlerService_AssignPreview_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StreamingNodeHandlerServiceServer is the server API for StreamingNodeHandlerService service.
 // All implementations should embed UnimplementedStreamingNodeHandlerServiceServer
 // for forward compatibility
@@ -479,6 +493,10 @@ type StreamingNodeHandlerServiceServer interface {
 	// log node, the RPC will return error CHANNEL_NOT_EXIST. If channel is
 	// moving away to other log node, the RPC will return error CHANNEL_FENCED.
 	Consume(StreamingNodeHandlerService_ConsumeServer) error
+	// AssignPreview is a pre-flight capacity check of an insert batch.
+	// It runs the same capacity evaluation as the segment assignment of the insert,
+	// but never reserves the capacity or creates the segment.
+	AssignPreview(context.Context, *AssignPreviewRequest) (*AssignPreviewResponse, error)
 }
 
 // UnimplementedStreamingNodeHandlerServiceServer should be embedded to have forward compatible implementations.
@@ -491,6 +509,9 @@ func (UnimplementedStreamingNodeHandlerServiceServer) Produce(StreamingNodeHandl
 func (UnimplementedStreamingNodeHandlerServiceServer) Consume(StreamingNodeHandlerService_ConsumeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Consume not implemented")
 }
+func (UnimplementedStreamingNodeHandlerServiceServer) AssignPreview(context.Context, *AssignPreviewRequest) (*AssignPreviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignPreview not implemented")
+}
 
 // UnsafeStreamingNodeHandlerServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to StreamingNodeHandlerServiceServer will
@@ -555,13 +576,36 @@ func (x *streamingNodeHandlerServiceConsumeServer) Recv() (*ConsumeRequest, erro
 	return m, nil
 }
 
+func _StreamingNodeHandlerService_AssignPreview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignPreviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamingNodeHandlerServiceServer).AssignPreview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamingNodeHandlerService_AssignPreview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamingNodeHandlerServiceServer).AssignPreview(ctx, req.(*AssignPreviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StreamingNodeHandlerService_ServiceDesc is the grpc.ServiceDesc for StreamingNodeHandlerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var StreamingNodeHandlerService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "milvus.proto.streaming.StreamingNodeHandlerService",
 	HandlerType: (*StreamingNodeHandlerServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AssignPreview",
+			Handler:    _StreamingNodeHandlerService_AssignPreview_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "Produce",
@@ -580,9 +624,14 @@ var StreamingNodeHandlerService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	StreamingNodeManagerService_Assign_FullMethodName        = "/milvus.proto.streaming.StreamingNodeManagerService/Assign"
-	StreamingNodeManagerService_Remove_FullMethodName        = "/milvus.proto.streaming.StreamingNodeManagerService/Remove"
-	StreamingNodeManagerService_CollectStatus_FullMethodName = "/milvus.proto.streaming.StreamingNodeManagerService/CollectStatus"
+	StreamingNodeManagerService_Assign_FullMethodName                     = "/milvus.proto.streaming.StreamingNodeManagerService/Assign"
+	StreamingNodeManagerService_Remove_FullMethodName                     = "/milvus.proto.streaming.StreamingNodeManagerService/Remove"
+	StreamingNodeManagerService_CollectStatus_FullMethodName              = "/milvus.proto.streaming.StreamingNodeManagerService/CollectStatus"
+	StreamingNodeManagerService_SealSegments_FullMethodName               = "/milvus.proto.streaming.StreamingNodeManagerService/SealSegments"
+	StreamingNodeManagerService_ReloadCollectionPartitions_FullMethodName = "/milvus.proto.streaming.StreamingNodeManagerService/ReloadCollectionPartitions"
+	StreamingNodeManagerService_ForceAddPartition_FullMethodName          = "/milvus.proto.streaming.StreamingNodeManagerService/ForceAddPartition"
+	StreamingNodeManagerService_GetSealHistory_FullMethodName             = "/milvus.proto.streaming.StreamingNodeManagerService/GetSealHistory"
+	StreamingNodeManagerService_FlushSegmentsOlderThan_FullMethodName     = "/milvus.proto.streaming.StreamingNodeManagerService/FlushSegmentsOlderThan"
 )
 
 // StreamingNodeManagerServiceClient is the client API for StreamingNodeManagerService service.
@@ -606,6 +655,26 @@ type StreamingNodeManagerServiceClient interface {
 	// balance info on a log node. Used to recover channel info on log coord,
 	// collect balance info and health check.
 	CollectStatus(ctx context.Context, in *StreamingNodeManagerCollectStatusRequest, opts ...grpc.CallOption) (*StreamingNodeManagerCollectStatusResponse, error)
+	// SealSegments is unary RPC to seal a batch of growing segments on a log node
+	// together, used by the compaction to merge the segments right after flush.
+	// The outcome of every segment is returned, a segment failed to seal never
+	// fails the whole batch.
+	SealSegments(ctx context.Context, in *StreamingNodeManagerSealSegmentsRequest, opts ...grpc.CallOption) (*StreamingNodeManagerSealSegmentsResponse, error)
+	// ReloadCollectionPartitions is unary RPC to re-fetch the partitions of a
+	// collection from the coordinator and merge them into the segment assignment
+	// on a log node, used by the admin to repair the wrong partitions metadata.
+	ReloadCollectionPartitions(ctx context.Context, in *StreamingNodeManagerReloadCollectionPartitionsRequest, opts ...grpc.CallOption) (*StreamingNodeManagerReloadCollectionPartitionsResponse, error)
+	// ForceAddPartition is unary RPC to add a partition unknown by the
+	// coordinator into the segment assignment on a log node. It's a break-glass
+	// operation of the admin and only works if it's enabled by config.
+	ForceAddPartition(ctx context.Context, in *StreamingNodeManagerForceAddPartitionRequest, opts ...grpc.CallOption) (*StreamingNodeManagerForceAddPartitionResponse, error)
+	// GetSealHistory is unary RPC to get the recent seal events of the segments
+	// on a log node, used by the admin to debug the seal after an incident.
+	GetSealHistory(ctx context.Context, in *StreamingNodeManagerGetSealHistoryRequest, opts ...grpc.CallOption) (*StreamingNodeManagerGetSealHistoryResponse, error)
+	// FlushSegmentsOlderThan is unary RPC to seal the growing segments of a
+	// collection whose assigned data are all not newer than the given timetick
+	// on a log node, used by the admin to flush the cold data without fencing.
+	FlushSegmentsOlderThan(ctx context.Context, in *StreamingNodeManagerFlushSegmentsOlderThanRequest, opts ...grpc.CallOption) (*StreamingNodeManagerFlushSegmentsOlderThanResponse, error)
 }
 
 type streamingNodeManagerServiceClient struct {
@@ -643,6 +712,51 @@ func (c *streamingNodeManagerServiceClient) CollectStatus(ctx context.Context, i
 	return out, nil
 }
 
+func (c *streamingNodeManagerServiceClient) SealSegments(ctx context.Context, in *StreamingNodeManagerSealSegmentsRequest, opts ...grpc.CallOption) (*StreamingNodeManagerSealSegmentsResponse, error) {
+	out := new(StreamingNodeManagerSealSegmentsResponse)
+	err := c.cc.Invoke(ctx, StreamingNodeManagerService_SealSegments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamingNodeManagerServiceClient) ReloadCollectionPartitions(ctx context.Context, in *StreamingNodeManagerReloadCollectionPartitionsRequest, opts ...grpc.CallOption) (*StreamingNodeManagerReloadCollectionPartitionsResponse, error) {
+	out := new(StreamingNodeManagerReloadCollectionPartitionsResponse)
+	err := c.cc.Invoke(ctx, StreamingNodeManagerService_ReloadCollectionPartitions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamingNodeManagerServiceClient) ForceAddPartition(ctx context.Context, in *StreamingNodeManagerForceAddPartitionRequest, opts ...grpc.CallOption) (*StreamingNodeManagerForceAddPartitionResponse, error) {
+	out := new(StreamingNodeManagerForceAddPartitionResponse)
+	err := c.cc.Invoke(ctx, StreamingNodeManagerService_ForceAddPartition_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamingNodeManagerServiceClient) GetSealHistory(ctx context.Context, in *StreamingNodeManagerGetSealHistoryRequest, opts ...grpc.CallOption) (*StreamingNodeManagerGetSealHistoryResponse, error) {
+	out := new(StreamingNodeManagerGetSealHistoryResponse)
+	err := c.cc.Invoke(ctx, StreamingNodeManagerService_GetSealHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamingNodeManagerServiceClient) FlushSegmentsOlderThan(ctx context.Context, in *StreamingNodeManagerFlushSegmentsOlderThanRequest, opts ...grpc.CallOption) (*StreamingNodeManagerFlushSegmentsOlderThanResponse, error) {
+	out := new(StreamingNodeManagerFlushSegmentsOlderThanResponse)
+	err := c.cc.Invoke(ctx, StreamingNodeManagerService_FlushSegmentsOlderThan_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StreamingNodeManagerServiceServer is the server API for StreamingNodeManagerService service.
 // All implementations should embed UnimplementedStreamingNodeManagerServiceServer
 // for forward compatibility
@@ -664,6 +778,26 @@ type StreamingNodeManagerServiceServer interface {
 	// balance info on a log node. Used to recover channel info on log coord,
 	// collect balance info and health check.
 	CollectStatus(context.Context, *StreamingNodeManagerCollectStatusRequest) (*StreamingNodeManagerCollectStatusResponse, error)
+	// SealSegments is unary RPC to seal a batch of growing segments on a log node
+	// together, used by the compaction to merge the segments right after flush.
+	// The outcome of every segment is returned, a segment failed to seal never
+	// fails the whole batch.
+	SealSegments(context.Context, *StreamingNodeManagerSealSegmentsRequest) (*StreamingNodeManagerSealSegmentsResponse, error)
+	// ReloadCollectionPartitions is unary RPC to re-fetch the partitions of a
+	// collection from the coordinator and merge them into the segment assignment
+	// on a log node, used by the admin to repair the wrong partitions metadata.
+	ReloadCollectionPartitions(context.Context, *StreamingNodeManagerReloadCollectionPartitionsRequest) (*StreamingNodeManagerReloadCollectionPartitionsResponse, error)
+	// ForceAddPartition is unary RPC to add a partition unknown by the
+	// coordinator into the segment assignment on a log node. It's a break-glass
+	// operation of the admin and only works if it's enabled by config.
+	ForceAddPartition(context.Context, *StreamingNodeManagerForceAddPartitionRequest) (*StreamingNodeManagerForceAddPartitionResponse, error)
+	// GetSealHistory is unary RPC to get the recent seal events of the segments
+	// on a log node, used by the admin to debug the seal after an incident.
+	GetSealHistory(context.Context, *StreamingNodeManagerGetSealHistoryRequest) (*StreamingNodeManagerGetSealHistoryResponse, error)
+	// FlushSegmentsOlderThan is unary RPC to seal the growing segments of a
+	// collection whose assigned data are all not newer than the given timetick
+	// on a log node, used by the admin to flush the cold data without fencing.
+	FlushSegmentsOlderThan(context.Context, *StreamingNodeManagerFlushSegmentsOlderThanRequest) (*StreamingNodeManagerFlushSegmentsOlderThanResponse, error)
 }
 
 // UnimplementedStreamingNodeManagerServiceServer should be embedded to have forward compatible implementations.
@@ -679,6 +813,21 @@ func (UnimplementedStreamingNodeManagerServiceServer) Remove(context.Context, *S
 func (UnimplementedStreamingNodeManagerServiceServer) CollectStatus(context.Context, *StreamingNodeManagerCollectStatusRequest) (*StreamingNodeManagerCollectStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CollectStatus not implemented")
 }
+func (UnimplementedStreamingNodeManagerServiceServer) SealSegments(context.Context, *StreamingNodeManagerSealSegmentsRequest) (*StreamingNodeManagerSealSegmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SealSegments not implemented")
+}
+func (UnimplementedStreamingNodeManagerServiceServer) ReloadCollectionPartitions(context.Context, *StreamingNodeManagerReloadCollectionPartitionsRequest) (*StreamingNodeManagerReloadCollectionPartitionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadCollectionPartitions not implemented")
+}
+func (UnimplementedStreamingNodeManagerServiceServer) ForceAddPartition(context.Context, *StreamingNodeManagerForceAddPartitionRequest) (*StreamingNodeManagerForceAddPartitionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceAddPartition not implemented")
+}
+func (UnimplementedStreamingNodeManagerServiceServer) GetSealHistory(context.Context, *StreamingNodeManagerGetSealHistoryRequest) (*StreamingNodeManagerGetSealHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSealHistory not implemented")
+}
+func (UnimplementedStreamingNodeManagerServiceServer) FlushSegmentsOlderThan(context.Context, *StreamingNodeManagerFlushSegmentsOlderThanRequest) (*StreamingNodeManagerFlushSegmentsOlderThanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FlushSegmentsOlderThan not implemented")
+}
 
 // UnsafeStreamingNodeManagerServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to StreamingNodeManagerServiceServer will
@@ -745,6 +894,96 @@ func _StreamingNodeManagerService_CollectStatus_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StreamingNodeManagerService_SealSegments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StreamingNodeManagerSealSegmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamingNodeManagerServiceServer).SealSegments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamingNodeManagerService_SealSegments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamingNodeManagerServiceServer).SealSegments(ctx, req.(*StreamingNodeManagerSealSegmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamingNodeManagerService_ReloadCollectionPartitions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StreamingNodeManagerReloadCollectionPartitionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamingNodeManagerServiceServer).ReloadCollectionPartitions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamingNodeManagerService_ReloadCollectionPartitions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamingNodeManagerServiceServer).ReloadCollectionPartitions(ctx, req.(*StreamingNodeManagerReloadCollectionPartitionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamingNodeManagerService_ForceAddPartition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StreamingNodeManagerForceAddPartitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamingNodeManagerServiceServer).ForceAddPartition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamingNodeManagerService_ForceAddPartition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamingNodeManagerServiceServer).ForceAddPartition(ctx, req.(*StreamingNodeManagerForceAddPartitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamingNodeManagerService_GetSealHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StreamingNodeManagerGetSealHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamingNodeManagerServiceServer).GetSealHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamingNodeManagerService_GetSealHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamingNodeManagerServiceServer).GetSealHistory(ctx, req.(*StreamingNodeManagerGetSealHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamingNodeManagerService_FlushSegmentsOlderThan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StreamingNodeManagerFlushSegmentsOlderThanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamingNodeManagerServiceServer).FlushSegmentsOlderThan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamingNodeManagerService_FlushSegmentsOlderThan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamingNodeManagerServiceServer).FlushSegmentsOlderThan(ctx, req.(*StreamingNodeManagerFlushSegmentsOlderThanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StreamingNodeManagerService_ServiceDesc is the grpc.ServiceDesc for StreamingNodeManagerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -764,6 +1003,26 @@ var StreamingNodeManagerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CollectStatus",
 			Handler:    _StreamingNodeManagerService_CollectStatus_Handler,
 		},
+		{
+			MethodName: "SealSegments",
+			Handler:    _StreamingNodeManagerService_SealSegments_Handler,
+		},
+		{
+			MethodName: "ReloadCollectionPartitions",
+			Handler:    _StreamingNodeManagerService_ReloadCollectionPartitions_Handler,
+		},
+		{
+			MethodName: "ForceAddPartition",
+			Handler:    _StreamingNodeManagerService_ForceAddPartition_Handler,
+		},
+		{
+			MethodName: "GetSealHistory",
+			Handler:    _StreamingNodeManagerService_GetSealHistory_Handler,
+		},
+		{
+			MethodName: "FlushSegmentsOlderThan",
+			Handler:    _StreamingNodeManagerService_FlushSegmentsOlderThan_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "streaming.proto",
@@ -137,6 +137,10 @@ func fromMessageToTsMsgV2(msg message.ImmutableMessage) (msgstream.TsMsg, error)
 		tsMsg, err = NewCreateSegmentMessageBody(msg)
 	case message.MessageTypeSchemaChange:
 		tsMsg, err = NewSchemaChangeMessageBody(msg)
+	case message.MessageTypePreparePartitionDrop:
+		tsMsg, err = NewPreparePartitionDropMessageBody(msg)
+	case message.MessageTypeMigrateSegments:
+		tsMsg, err = NewMigrateSegmentsMessageBody(msg)
 	default:
 		panic("unsupported message type")
 	}
@@ -195,9 +199,15 @@ func recoverInsertMsgFromHeader(insertMsg *msgstream.InsertMsg, header *message.
 			break
 		}
 	}
-	if assignment.GetSegmentAssignment().GetSegmentId() == 0 {
+	if assignment == nil {
 		panic("unreachable code, partition id is not exist")
 	}
+	if assignment.GetSegmentAssignment().GetSegmentId() == 0 {
+		// zero rows insert message will not be assigned to any segment at streaming node.
+		if insertMsg.GetNumRows() != 0 || assignment.GetRows() != 0 {
+			panic("unreachable code, segment assignment is not exist")
+		}
+	}
 
 	insertMsg.SegmentID = assignment.GetSegmentAssignment().GetSegmentId()
 	// timetick should has been assign at streaming node.
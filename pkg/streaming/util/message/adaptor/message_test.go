@@ -57,6 +57,23 @@ func TestNewMsgPackFromInsertMessage(t *testing.T) {
 	}
 }
 
+func TestNewMsgPackFromZeroRowsInsertMessage(t *testing.T) {
+	id := rmq.NewRmqID(1)
+	tt := uint64(time.Now().UnixNano())
+	// zero rows insert message is not assigned to any segment.
+	insertMsg := message.CreateTestInsertMessage(t, 0, 0, tt, id)
+	pack, err := NewMsgPackFromMessage(insertMsg.IntoImmutableMessage(id))
+	assert.NoError(t, err)
+	assert.Len(t, pack.Msgs, 1)
+	assert.Zero(t, pack.Msgs[0].(*msgstream.InsertMsg).GetSegmentID())
+	assert.Empty(t, pack.Msgs[0].(*msgstream.InsertMsg).Timestamps)
+
+	insertMsg = message.CreateTestInsertMessage(t, 0, 10, tt, id)
+	assert.Panics(t, func() {
+		NewMsgPackFromMessage(insertMsg.IntoImmutableMessage(id))
+	})
+}
+
 func TestNewMsgPackFromCreateCollectionMessage(t *testing.T) {
 	id := rmq.NewRmqID(1)
 
@@ -88,3 +105,43 @@ func TestNewMsgPackFromCreateSegmentMessage(t *testing.T) {
 	assert.Equal(t, tt, pack.BeginTs)
 	assert.Equal(t, tt, pack.EndTs)
 }
+
+func TestNewMsgPackFromPreparePartitionDropMessage(t *testing.T) {
+	id := rmq.NewRmqID(1)
+
+	tt := uint64(time.Now().UnixNano())
+	mutableMsg, err := message.NewPreparePartitionDropMessageBuilderV2().
+		WithHeader(&message.PreparePartitionDropMessageHeader{CollectionId: 1, PartitionId: 2}).
+		WithBody(&message.PreparePartitionDropMessageBody{}).
+		WithVChannel("v1").
+		BuildMutable()
+	assert.NoError(t, err)
+	immutableMsg := mutableMsg.WithTimeTick(tt).WithLastConfirmedUseMessageID().IntoImmutableMessage(id)
+	pack, err := NewMsgPackFromMessage(immutableMsg)
+	assert.NoError(t, err)
+	assert.NotNil(t, pack)
+	assert.Equal(t, tt, pack.BeginTs)
+	assert.Equal(t, tt, pack.EndTs)
+	// the prepare is only forwarded as a time tick.
+	assert.Empty(t, pack.Msgs)
+}
+
+func TestNewMsgPackFromMigrateSegmentsMessage(t *testing.T) {
+	id := rmq.NewRmqID(1)
+
+	tt := uint64(time.Now().UnixNano())
+	mutableMsg, err := message.NewMigrateSegmentsMessageBuilderV2().
+		WithHeader(&message.MigrateSegmentsMessageHeader{CollectionId: 1, FromPartitionId: 2, ToPartitionId: 3, SegmentIds: []int64{4}}).
+		WithBody(&message.MigrateSegmentsMessageBody{}).
+		WithVChannel("v1").
+		BuildMutable()
+	assert.NoError(t, err)
+	immutableMsg := mutableMsg.WithTimeTick(tt).WithLastConfirmedUseMessageID().IntoImmutableMessage(id)
+	pack, err := NewMsgPackFromMessage(immutableMsg)
+	assert.NoError(t, err)
+	assert.NotNil(t, pack)
+	assert.Equal(t, tt, pack.BeginTs)
+	assert.Equal(t, tt, pack.EndTs)
+	// the migration is only forwarded as a time tick.
+	assert.Empty(t, pack.Msgs)
+}
@@ -15,9 +15,14 @@ var messageTypeToCommonpbMsgType = map[message.MessageType]commonpb.MsgType{
 	message.MessageTypeCreateCollection: commonpb.MsgType_CreateCollection,
 	message.MessageTypeDropCollection:   commonpb.MsgType_DropCollection,
 	message.MessageTypeCreatePartition:  commonpb.MsgType_CreatePartition,
+	message.MessageTypeCreatePartitions: commonpb.MsgType_CreatePartition,
 	message.MessageTypeDropPartition:    commonpb.MsgType_DropPartition,
 	message.MessageTypeImport:           commonpb.MsgType_Import,
 	message.MessageTypeSchemaChange:     commonpb.MsgType_AddCollectionField, // TODO change to schema change
+	// the prepare partition drop is only meaningful to the segment assignment of wal, it is consumed as a time tick.
+	message.MessageTypePreparePartitionDrop: commonpb.MsgType_TimeTick,
+	// the migrate segments is re-keyed by the streaming consumers, the msgstream consumers only see its time tick.
+	message.MessageTypeMigrateSegments: commonpb.MsgType_TimeTick,
 }
 
 // MustGetCommonpbMsgTypeFromMessageType returns the commonpb.MsgType from message.MessageType.
@@ -147,3 +147,55 @@ func NewSchemaChangeMessageBody(msg message.ImmutableMessage) (msgstream.TsMsg,
 		BroadcastID:         msg.BroadcastHeader().BroadcastID,
 	}, nil
 }
+
+type PreparePartitionDropMessageBody struct {
+	*tsMsgImpl
+	PreparePartitionDropMessage message.ImmutablePreparePartitionDropMessageV2
+}
+
+// NewPreparePartitionDropMessageBody creates the ts message of prepare partition drop message,
+// its type is time tick, so it's filtered out from the msg pack and only the time tick is forwarded.
+func NewPreparePartitionDropMessageBody(msg message.ImmutableMessage) (msgstream.TsMsg, error) {
+	prepareMsg, err := message.AsImmutablePreparePartitionDropMessageV2(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &PreparePartitionDropMessageBody{
+		tsMsgImpl: &tsMsgImpl{
+			BaseMsg: msgstream.BaseMsg{
+				BeginTimestamp: msg.TimeTick(),
+				EndTimestamp:   msg.TimeTick(),
+			},
+			ts:      msg.TimeTick(),
+			sz:      msg.EstimateSize(),
+			msgType: MustGetCommonpbMsgTypeFromMessageType(msg.MessageType()),
+		},
+		PreparePartitionDropMessage: prepareMsg,
+	}, nil
+}
+
+type MigrateSegmentsMessageBody struct {
+	*tsMsgImpl
+	MigrateSegmentsMessage message.ImmutableMigrateSegmentsMessageV2
+}
+
+// NewMigrateSegmentsMessageBody creates the ts message of migrate segments message,
+// its type is time tick, so it's filtered out from the msg pack and only the time tick is forwarded.
+func NewMigrateSegmentsMessageBody(msg message.ImmutableMessage) (msgstream.TsMsg, error) {
+	migrateMsg, err := message.AsImmutableMigrateSegmentsMessageV2(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &MigrateSegmentsMessageBody{
+		tsMsgImpl: &tsMsgImpl{
+			BaseMsg: msgstream.BaseMsg{
+				BeginTimestamp: msg.TimeTick(),
+				EndTimestamp:   msg.TimeTick(),
+			},
+			ts:      msg.TimeTick(),
+			sz:      msg.EstimateSize(),
+			msgType: MustGetCommonpbMsgTypeFromMessageType(msg.MessageType()),
+		},
+		MigrateSegmentsMessage: migrateMsg,
+	}, nil
+}
@@ -1,6 +1,7 @@
 package message
 
 import (
+	"encoding/base64"
 	"fmt"
 	"reflect"
 
@@ -54,22 +55,25 @@ func NewImmutableMesasge(
 
 // List all type-safe mutable message builders here.
 var (
-	NewTimeTickMessageBuilderV1         = createNewMessageBuilderV1[*TimeTickMessageHeader, *msgpb.TimeTickMsg]()
-	NewInsertMessageBuilderV1           = createNewMessageBuilderV1[*InsertMessageHeader, *msgpb.InsertRequest]()
-	NewDeleteMessageBuilderV1           = createNewMessageBuilderV1[*DeleteMessageHeader, *msgpb.DeleteRequest]()
-	NewCreateCollectionMessageBuilderV1 = createNewMessageBuilderV1[*CreateCollectionMessageHeader, *msgpb.CreateCollectionRequest]()
-	NewDropCollectionMessageBuilderV1   = createNewMessageBuilderV1[*DropCollectionMessageHeader, *msgpb.DropCollectionRequest]()
-	NewCreatePartitionMessageBuilderV1  = createNewMessageBuilderV1[*CreatePartitionMessageHeader, *msgpb.CreatePartitionRequest]()
-	NewDropPartitionMessageBuilderV1    = createNewMessageBuilderV1[*DropPartitionMessageHeader, *msgpb.DropPartitionRequest]()
-	NewImportMessageBuilderV1           = createNewMessageBuilderV1[*ImportMessageHeader, *msgpb.ImportMsg]()
-	NewCreateSegmentMessageBuilderV2    = createNewMessageBuilderV2[*CreateSegmentMessageHeader, *CreateSegmentMessageBody]()
-	NewFlushMessageBuilderV2            = createNewMessageBuilderV2[*FlushMessageHeader, *FlushMessageBody]()
-	NewManualFlushMessageBuilderV2      = createNewMessageBuilderV2[*ManualFlushMessageHeader, *ManualFlushMessageBody]()
-	NewBeginTxnMessageBuilderV2         = createNewMessageBuilderV2[*BeginTxnMessageHeader, *BeginTxnMessageBody]()
-	NewCommitTxnMessageBuilderV2        = createNewMessageBuilderV2[*CommitTxnMessageHeader, *CommitTxnMessageBody]()
-	NewRollbackTxnMessageBuilderV2      = createNewMessageBuilderV2[*RollbackTxnMessageHeader, *RollbackTxnMessageBody]()
-	NewSchemaChangeMessageBuilderV2     = createNewMessageBuilderV2[*SchemaChangeMessageHeader, *SchemaChangeMessageBody]()
-	newTxnMessageBuilderV2              = createNewMessageBuilderV2[*TxnMessageHeader, *TxnMessageBody]()
+	NewTimeTickMessageBuilderV1             = createNewMessageBuilderV1[*TimeTickMessageHeader, *msgpb.TimeTickMsg]()
+	NewInsertMessageBuilderV1               = createNewMessageBuilderV1[*InsertMessageHeader, *msgpb.InsertRequest]()
+	NewDeleteMessageBuilderV1               = createNewMessageBuilderV1[*DeleteMessageHeader, *msgpb.DeleteRequest]()
+	NewCreateCollectionMessageBuilderV1     = createNewMessageBuilderV1[*CreateCollectionMessageHeader, *msgpb.CreateCollectionRequest]()
+	NewDropCollectionMessageBuilderV1       = createNewMessageBuilderV1[*DropCollectionMessageHeader, *msgpb.DropCollectionRequest]()
+	NewCreatePartitionMessageBuilderV1      = createNewMessageBuilderV1[*CreatePartitionMessageHeader, *msgpb.CreatePartitionRequest]()
+	NewCreatePartitionsMessageBuilderV1     = createNewMessageBuilderV1[*CreatePartitionsMessageHeader, *msgpb.CreatePartitionRequest]()
+	NewDropPartitionMessageBuilderV1        = createNewMessageBuilderV1[*DropPartitionMessageHeader, *msgpb.DropPartitionRequest]()
+	NewImportMessageBuilderV1               = createNewMessageBuilderV1[*ImportMessageHeader, *msgpb.ImportMsg]()
+	NewCreateSegmentMessageBuilderV2        = createNewMessageBuilderV2[*CreateSegmentMessageHeader, *CreateSegmentMessageBody]()
+	NewFlushMessageBuilderV2                = createNewMessageBuilderV2[*FlushMessageHeader, *FlushMessageBody]()
+	NewManualFlushMessageBuilderV2          = createNewMessageBuilderV2[*ManualFlushMessageHeader, *ManualFlushMessageBody]()
+	NewBeginTxnMessageBuilderV2             = createNewMessageBuilderV2[*BeginTxnMessageHeader, *BeginTxnMessageBody]()
+	NewCommitTxnMessageBuilderV2            = createNewMessageBuilderV2[*CommitTxnMessageHeader, *CommitTxnMessageBody]()
+	NewRollbackTxnMessageBuilderV2          = createNewMessageBuilderV2[*RollbackTxnMessageHeader, *RollbackTxnMessageBody]()
+	NewSchemaChangeMessageBuilderV2         = createNewMessageBuilderV2[*SchemaChangeMessageHeader, *SchemaChangeMessageBody]()
+	NewPreparePartitionDropMessageBuilderV2 = createNewMessageBuilderV2[*PreparePartitionDropMessageHeader, *PreparePartitionDropMessageBody]()
+	NewMigrateSegmentsMessageBuilderV2      = createNewMessageBuilderV2[*MigrateSegmentsMessageHeader, *MigrateSegmentsMessageBody]()
+	newTxnMessageBuilderV2                  = createNewMessageBuilderV2[*TxnMessageHeader, *TxnMessageBody]()
 )
 
 // createNewMessageBuilderV1 creates a new message builder with v1 marker.
@@ -124,6 +128,71 @@ func (b *mutableMesasgeBuilder[H, B]) WithNotPersisted() *mutableMesasgeBuilder[
 	return b
 }
 
+// WithCollectionLifecycleSignal creates a new builder with collection lifecycle signal.
+// Only manual flush message can carry the collection lifecycle signal.
+func (b *mutableMesasgeBuilder[H, B]) WithCollectionLifecycleSignal(signal CollectionLifecycleSignal) *mutableMesasgeBuilder[H, B] {
+	messageType := mustGetMessageTypeFromHeader(b.header)
+	if messageType != MessageTypeManualFlush {
+		panic("only manual flush message can carry the collection lifecycle signal")
+	}
+	b.WithProperty(messageCollectionLifecycle, string(signal))
+	return b
+}
+
+// WithFlushOlderThanOnly creates a new builder that only flush the segments older than the flush ts without fencing.
+// Only manual flush message can carry the flag.
+func (b *mutableMesasgeBuilder[H, B]) WithFlushOlderThanOnly() *mutableMesasgeBuilder[H, B] {
+	messageType := mustGetMessageTypeFromHeader(b.header)
+	if messageType != MessageTypeManualFlush {
+		panic("only manual flush message can carry the flush older than only flag")
+	}
+	b.WithProperty(messageFlushOlderThanOnly, "")
+	return b
+}
+
+// WithDiscardGrowingSegments creates a new builder that discards the growing segments of the dropped partition without flushing.
+// Only drop partition message can carry the flag.
+func (b *mutableMesasgeBuilder[H, B]) WithDiscardGrowingSegments() *mutableMesasgeBuilder[H, B] {
+	messageType := mustGetMessageTypeFromHeader(b.header)
+	if messageType != MessageTypeDropPartition {
+		panic("only drop partition message can carry the discard growing segments flag")
+	}
+	b.WithProperty(messageDiscardGrowingSegments, "")
+	return b
+}
+
+// WithSourceNode creates a new builder with the node that produces the message, e.g. the proxy of the insert message.
+// It's used to attribute the load of the wal to the upstream node.
+func (b *mutableMesasgeBuilder[H, B]) WithSourceNode(source string) *mutableMesasgeBuilder[H, B] {
+	b.WithProperty(messageSourceNode, source)
+	return b
+}
+
+// WithCollectionAssignmentConfig creates a new builder with the serialized collection assignment config of the backup,
+// so the restored collection is assigned as same as the original one.
+// Only create collection message can carry the collection assignment config.
+func (b *mutableMesasgeBuilder[H, B]) WithCollectionAssignmentConfig(config []byte) *mutableMesasgeBuilder[H, B] {
+	messageType := mustGetMessageTypeFromHeader(b.header)
+	if messageType != MessageTypeCreateCollection {
+		panic("only create collection message can carry the collection assignment config")
+	}
+	b.WithProperty(messageCollectionAssignmentConfig, base64.StdEncoding.EncodeToString(config))
+	return b
+}
+
+// WithPreassignedProducer creates a new builder of the insert message whose segment assignments are pre-assigned by the internal producer,
+// e.g. the data repair tool or the migration replayer, the segment assignment of wal is bypassed for the message.
+// The bypass is only honored if the producer is allowed by the wal.
+// Only insert message can carry the preassigned producer.
+func (b *mutableMesasgeBuilder[H, B]) WithPreassignedProducer(producer string) *mutableMesasgeBuilder[H, B] {
+	messageType := mustGetMessageTypeFromHeader(b.header)
+	if messageType != MessageTypeInsert {
+		panic("only insert message can carry the preassigned producer")
+	}
+	b.WithProperty(messagePreassignedProducer, producer)
+	return b
+}
+
 // WithBody creates a new builder with message body.
 func (b *mutableMesasgeBuilder[H, B]) WithBody(body B) *mutableMesasgeBuilder[H, B] {
 	b.body = body
@@ -83,3 +83,142 @@ func TestImmutableTxnBuilder(t *testing.T) {
 	assert.NoError(t, err)
 	log.Info("test", zap.Object("msg", immutableTxnMsg))
 }
+
+func TestCollectionLifecycleSignal(t *testing.T) {
+	b := message.NewManualFlushMessageBuilderV2().
+		WithHeader(&message.ManualFlushMessageHeader{CollectionId: 1}).
+		WithBody(&message.ManualFlushMessageBody{}).
+		WithVChannel("v1").
+		MustBuildMutable()
+	assert.Equal(t, message.CollectionLifecycleSignalNone, message.GetCollectionLifecycleSignal(b))
+
+	b = message.NewManualFlushMessageBuilderV2().
+		WithHeader(&message.ManualFlushMessageHeader{CollectionId: 1}).
+		WithBody(&message.ManualFlushMessageBody{}).
+		WithVChannel("v1").
+		WithCollectionLifecycleSignal(message.CollectionLifecycleSignalRelease).
+		MustBuildMutable()
+	assert.Equal(t, message.CollectionLifecycleSignalRelease, message.GetCollectionLifecycleSignal(b))
+
+	assert.Panics(t, func() {
+		message.NewCreateCollectionMessageBuilderV1().
+			WithHeader(&message.CreateCollectionMessageHeader{}).
+			WithCollectionLifecycleSignal(message.CollectionLifecycleSignalLoad)
+	})
+}
+
+func TestFlushOlderThanOnly(t *testing.T) {
+	b := message.NewManualFlushMessageBuilderV2().
+		WithHeader(&message.ManualFlushMessageHeader{CollectionId: 1}).
+		WithBody(&message.ManualFlushMessageBody{}).
+		WithVChannel("v1").
+		MustBuildMutable()
+	assert.False(t, message.IsFlushOlderThanOnly(b))
+
+	b = message.NewManualFlushMessageBuilderV2().
+		WithHeader(&message.ManualFlushMessageHeader{CollectionId: 1}).
+		WithBody(&message.ManualFlushMessageBody{}).
+		WithVChannel("v1").
+		WithFlushOlderThanOnly().
+		MustBuildMutable()
+	assert.True(t, message.IsFlushOlderThanOnly(b))
+
+	assert.Panics(t, func() {
+		message.NewCreateCollectionMessageBuilderV1().
+			WithHeader(&message.CreateCollectionMessageHeader{}).
+			WithFlushOlderThanOnly()
+	})
+}
+
+func TestDiscardGrowingSegments(t *testing.T) {
+	b := message.NewDropPartitionMessageBuilderV1().
+		WithHeader(&message.DropPartitionMessageHeader{CollectionId: 1, PartitionId: 2}).
+		WithBody(&msgpb.DropPartitionRequest{}).
+		WithVChannel("v1").
+		MustBuildMutable()
+	assert.False(t, message.IsDiscardGrowingSegments(b))
+
+	b = message.NewDropPartitionMessageBuilderV1().
+		WithHeader(&message.DropPartitionMessageHeader{CollectionId: 1, PartitionId: 2}).
+		WithBody(&msgpb.DropPartitionRequest{}).
+		WithVChannel("v1").
+		WithDiscardGrowingSegments().
+		MustBuildMutable()
+	assert.True(t, message.IsDiscardGrowingSegments(b))
+
+	assert.Panics(t, func() {
+		message.NewManualFlushMessageBuilderV2().
+			WithHeader(&message.ManualFlushMessageHeader{}).
+			WithDiscardGrowingSegments()
+	})
+}
+
+func TestSourceNode(t *testing.T) {
+	b := message.NewInsertMessageBuilderV1().
+		WithHeader(&message.InsertMessageHeader{CollectionId: 1}).
+		WithBody(&msgpb.InsertRequest{}).
+		WithVChannel("v1").
+		MustBuildMutable()
+	assert.Empty(t, message.GetSourceNode(b))
+
+	b = message.NewInsertMessageBuilderV1().
+		WithHeader(&message.InsertMessageHeader{CollectionId: 1}).
+		WithBody(&msgpb.InsertRequest{}).
+		WithVChannel("v1").
+		WithSourceNode("1").
+		MustBuildMutable()
+	assert.Equal(t, "1", message.GetSourceNode(b))
+}
+
+func TestCollectionAssignmentConfig(t *testing.T) {
+	b := message.NewCreateCollectionMessageBuilderV1().
+		WithHeader(&message.CreateCollectionMessageHeader{CollectionId: 1}).
+		WithBody(&msgpb.CreateCollectionRequest{}).
+		WithVChannel("v1").
+		MustBuildMutable()
+	config, err := message.GetCollectionAssignmentConfig(b)
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	b = message.NewCreateCollectionMessageBuilderV1().
+		WithHeader(&message.CreateCollectionMessageHeader{CollectionId: 1}).
+		WithBody(&msgpb.CreateCollectionRequest{}).
+		WithVChannel("v1").
+		WithCollectionAssignmentConfig([]byte{0x08, 0x01}).
+		MustBuildMutable()
+	config, err = message.GetCollectionAssignmentConfig(b)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x08, 0x01}, config)
+
+	assert.Panics(t, func() {
+		message.NewDropCollectionMessageBuilderV1().
+			WithHeader(&message.DropCollectionMessageHeader{}).
+			WithCollectionAssignmentConfig([]byte{0x08, 0x01})
+	})
+}
+
+func TestPreassignedProducer(t *testing.T) {
+	b := message.NewInsertMessageBuilderV1().
+		WithHeader(&message.InsertMessageHeader{CollectionId: 1}).
+		WithBody(&msgpb.InsertRequest{}).
+		WithVChannel("v1").
+		MustBuildMutable()
+	_, ok := message.GetPreassignedProducer(b)
+	assert.False(t, ok)
+
+	b = message.NewInsertMessageBuilderV1().
+		WithHeader(&message.InsertMessageHeader{CollectionId: 1}).
+		WithBody(&msgpb.InsertRequest{}).
+		WithVChannel("v1").
+		WithPreassignedProducer("repair").
+		MustBuildMutable()
+	producer, ok := message.GetPreassignedProducer(b)
+	assert.True(t, ok)
+	assert.Equal(t, "repair", producer)
+
+	assert.Panics(t, func() {
+		message.NewDeleteMessageBuilderV1().
+			WithHeader(&message.DeleteMessageHeader{}).
+			WithPreassignedProducer("repair")
+	})
+}
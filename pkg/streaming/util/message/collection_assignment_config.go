@@ -0,0 +1,21 @@
+package message
+
+import (
+	"encoding/base64"
+
+	"github.com/cockroachdb/errors"
+)
+
+// GetCollectionAssignmentConfig gets the serialized collection assignment config carried by the create collection message.
+// nil is returned if the message doesn't carry the config.
+func GetCollectionAssignmentConfig(msg BasicMessage) ([]byte, error) {
+	value, ok := msg.Properties().Get(messageCollectionAssignmentConfig)
+	if !ok {
+		return nil, nil
+	}
+	config, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode collection assignment config")
+	}
+	return config, nil
+}
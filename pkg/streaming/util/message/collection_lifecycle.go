@@ -0,0 +1,26 @@
+package message
+
+// CollectionLifecycleSignal is the signal of collection load/release boundary,
+// it's carried by the manual flush message to notify the streaming node.
+type CollectionLifecycleSignal string
+
+const (
+	CollectionLifecycleSignalNone    CollectionLifecycleSignal = ""
+	CollectionLifecycleSignalLoad    CollectionLifecycleSignal = "load"
+	CollectionLifecycleSignalRelease CollectionLifecycleSignal = "release"
+)
+
+// GetCollectionLifecycleSignal gets the collection lifecycle signal of the message.
+// CollectionLifecycleSignalNone is returned if the message doesn't carry the signal.
+func GetCollectionLifecycleSignal(msg BasicMessage) CollectionLifecycleSignal {
+	signal, ok := msg.Properties().Get(messageCollectionLifecycle)
+	if !ok {
+		return CollectionLifecycleSignalNone
+	}
+	switch CollectionLifecycleSignal(signal) {
+	case CollectionLifecycleSignalLoad, CollectionLifecycleSignalRelease:
+		return CollectionLifecycleSignal(signal)
+	default:
+		return CollectionLifecycleSignalNone
+	}
+}
@@ -0,0 +1,8 @@
+package message
+
+// IsDiscardGrowingSegments checks if the drop partition message discards the growing segments of the partition.
+// The discarded segments are dropped without flush, otherwise they are sealed and flushed.
+func IsDiscardGrowingSegments(msg BasicMessage) bool {
+	_, ok := msg.Properties().Get(messageDiscardGrowingSegments)
+	return ok
+}
@@ -0,0 +1,8 @@
+package message
+
+// IsFlushOlderThanOnly checks if the manual flush message only flush the segments older than the flush ts.
+// The segments with newer data are kept growing and no fence is applied to the collection.
+func IsFlushOlderThanOnly(msg BasicMessage) bool {
+	_, ok := msg.Properties().Get(messageFlushOlderThanOnly)
+	return ok
+}
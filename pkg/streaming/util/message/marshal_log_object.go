@@ -95,9 +95,20 @@ func marshalSpecializedHeader(t MessageType, h string, enc zapcore.ObjectEncoder
 	case *CreatePartitionMessageHeader:
 		enc.AddInt64("collectionID", header.GetCollectionId())
 		enc.AddInt64("partitionID", header.GetPartitionId())
+	case *CreatePartitionsMessageHeader:
+		enc.AddInt64("collectionID", header.GetCollectionId())
+		encodeIDs("partitionIDs", header.GetPartitionIds(), enc)
 	case *DropPartitionMessageHeader:
 		enc.AddInt64("collectionID", header.GetCollectionId())
 		enc.AddInt64("partitionID", header.GetPartitionId())
+	case *PreparePartitionDropMessageHeader:
+		enc.AddInt64("collectionID", header.GetCollectionId())
+		enc.AddInt64("partitionID", header.GetPartitionId())
+	case *MigrateSegmentsMessageHeader:
+		enc.AddInt64("collectionID", header.GetCollectionId())
+		enc.AddInt64("fromPartitionID", header.GetFromPartitionId())
+		enc.AddInt64("toPartitionID", header.GetToPartitionId())
+		encodeIDs("segmentIDs", header.GetSegmentIds(), enc)
 	case *CreateSegmentMessageHeader:
 		enc.AddInt64("collectionID", header.GetCollectionId())
 		enc.AddInt64("segmentID", header.GetSegmentId())
@@ -113,9 +124,13 @@ func marshalSpecializedHeader(t MessageType, h string, enc zapcore.ObjectEncoder
 }
 
 func encodeSegmentIDs(segmentIDs []int64, enc zapcore.ObjectEncoder) {
-	ids := make([]string, 0, len(segmentIDs))
-	for _, id := range segmentIDs {
-		ids = append(ids, strconv.FormatInt(id, 10))
+	encodeIDs("segmentIDs", segmentIDs, enc)
+}
+
+func encodeIDs(key string, ids []int64, enc zapcore.ObjectEncoder) {
+	strs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strs = append(strs, strconv.FormatInt(id, 10))
 	}
-	enc.AddString("segmentIDs", strings.Join(ids, "|"))
+	enc.AddString(key, strings.Join(strs, "|"))
 }
@@ -40,6 +40,15 @@ func TestMessageType(t *testing.T) {
 	assert.False(t, MessageTypeDropCollection.IsSystem())
 	assert.False(t, MessageTypeCreatePartition.IsSystem())
 	assert.False(t, MessageTypeDropPartition.IsSystem())
+	assert.False(t, MessageTypeCreatePartitions.IsSystem())
+	assert.True(t, MessageTypeCreatePartitions.Valid())
+	assert.True(t, MessageTypeCreatePartitions.IsExclusiveRequired())
+	assert.False(t, MessageTypePreparePartitionDrop.IsSystem())
+	assert.True(t, MessageTypePreparePartitionDrop.Valid())
+	assert.True(t, MessageTypePreparePartitionDrop.IsExclusiveRequired())
+	assert.False(t, MessageTypeMigrateSegments.IsSystem())
+	assert.True(t, MessageTypeMigrateSegments.Valid())
+	assert.False(t, MessageTypeMigrateSegments.IsExclusiveRequired())
 }
 
 func TestVersion(t *testing.T) {
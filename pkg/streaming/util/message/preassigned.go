@@ -0,0 +1,7 @@
+package message
+
+// GetPreassignedProducer returns the internal producer that pre-assigns the segments of the insert message.
+// Return false if the segments of the message are not pre-assigned.
+func GetPreassignedProducer(msg BasicMessage) (string, bool) {
+	return msg.Properties().Get(messagePreassignedProducer)
+}
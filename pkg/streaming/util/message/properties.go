@@ -15,6 +15,12 @@ const (
 	messageTxnContext                       = "_tx"  // transaction context.
 	messageCipherHeader                     = "_ch"  // message cipher header.
 	messageNotPersisteted                   = "_np"  // check if the message is unpersisted.
+	messageCollectionLifecycle              = "_cl"  // collection lifecycle signal carried by the manual flush message.
+	messageFlushOlderThanOnly               = "_fo"  // manual flush message only flush the segments older than the flush ts.
+	messageDiscardGrowingSegments           = "_dg"  // drop partition message discards the growing segments without flush.
+	messageSourceNode                       = "_sn"  // the node that produces the message, e.g. the proxy of the insert message.
+	messageCollectionAssignmentConfig       = "_ac"  // the collection assignment config carried by the create collection message of the restored collection.
+	messagePreassignedProducer              = "_pa"  // the internal producer that pre-assigns the segments of the insert message.
 )
 
 var (
@@ -0,0 +1,74 @@
+package message
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+
+	"github.com/cockroachdb/errors"
+)
+
+var (
+	// ErrSegmentAssignmentCorrupted is returned if the checksum of the segment assignment mismatches,
+	// the rows of the assignment must not be buffered into the segment declared by it.
+	ErrSegmentAssignmentCorrupted = errors.New("segment assignment corrupted")
+
+	segmentAssignmentChecksumTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// SegmentAssignmentChecksum computes the checksum of the segment assignment of an insert message,
+// signed by the assignment epoch of the segment, which is carried by the create segment and flush message of the segment.
+// 0 is returned if the epoch is 0, the assignment is unsigned.
+// The timetick of the insert in a txn is rewritten into the commit timetick at the consume side, which is unknown at the assignment,
+// so the assignment of it is signed with the timetick 0.
+func SegmentAssignmentChecksum(epoch uint64, collectionID int64, partitionID int64, segmentID int64, timetick uint64) uint32 {
+	if epoch == 0 {
+		return 0
+	}
+	var b [40]byte
+	binary.LittleEndian.PutUint64(b[0:], epoch)
+	binary.LittleEndian.PutUint64(b[8:], uint64(collectionID))
+	binary.LittleEndian.PutUint64(b[16:], uint64(partitionID))
+	binary.LittleEndian.PutUint64(b[24:], uint64(segmentID))
+	binary.LittleEndian.PutUint64(b[32:], timetick)
+	return crc32.Checksum(b[:], segmentAssignmentChecksumTable)
+}
+
+// VerifySegmentAssignment verifies the segment assignment of a partition of the insert message of the collection at the signed timetick,
+// the epoch is the assignment epoch of the assigned segment learned from its create segment or flush message.
+// The assignment is not verifiable if the epoch is 0, e.g. the segment is created before the signing is supported, nil is returned.
+func VerifySegmentAssignment(epoch uint64, collectionID int64, timetick uint64, assignment *PartitionSegmentAssignment) error {
+	if epoch == 0 {
+		return nil
+	}
+	segmentID := assignment.GetSegmentAssignment().GetSegmentId()
+	expected := SegmentAssignmentChecksum(epoch, collectionID, assignment.GetPartitionId(), segmentID, timetick)
+	if actual := assignment.GetSegmentAssignment().GetChecksum(); actual != expected {
+		return errors.Wrapf(ErrSegmentAssignmentCorrupted,
+			"collection %d, partition %d, segment %d, timetick %d, epoch %d, checksum %d, expected %d",
+			collectionID, assignment.GetPartitionId(), segmentID, timetick, epoch, actual, expected)
+	}
+	return nil
+}
+
+// VerifyInsertMessageSegmentAssignments verifies all segment assignments of the insert message before the rows are buffered,
+// epochOf returns the assignment epoch of the segment known by the consumer,
+// the assignment of the segment whose epoch is unknown by the consumer is not verifiable and skipped.
+// The insert in a txn is verified with the timetick 0 as it's signed, so its assignment is not bound to its timetick,
+// only the collection, partition and segment of it are protected.
+func VerifyInsertMessageSegmentAssignments(msg ImmutableInsertMessageV1, epochOf func(segmentID int64) (uint64, bool)) error {
+	header := msg.Header()
+	timetick := msg.TimeTick()
+	if msg.TxnContext() != nil {
+		timetick = 0
+	}
+	for _, assignment := range header.GetPartitions() {
+		epoch, ok := epochOf(assignment.GetSegmentAssignment().GetSegmentId())
+		if !ok {
+			continue
+		}
+		if err := VerifySegmentAssignment(epoch, header.GetCollectionId(), timetick, assignment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package message_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v2/streaming/walimpls/impls/walimplstest"
+)
+
+func TestSegmentAssignmentChecksum(t *testing.T) {
+	// the unsigned assignment is never verified.
+	assert.Zero(t, message.SegmentAssignmentChecksum(0, 1, 2, 3, 100))
+	assert.NoError(t, message.VerifySegmentAssignment(0, 1, 100, &message.PartitionSegmentAssignment{
+		PartitionId:       2,
+		SegmentAssignment: &message.SegmentAssignment{SegmentId: 3, Checksum: 12345},
+	}))
+
+	checksum := message.SegmentAssignmentChecksum(7, 1, 2, 3, 100)
+	assert.NotZero(t, checksum)
+	assert.Equal(t, checksum, message.SegmentAssignmentChecksum(7, 1, 2, 3, 100))
+	// every signed field changes the checksum.
+	assert.NotEqual(t, checksum, message.SegmentAssignmentChecksum(8, 1, 2, 3, 100))
+	assert.NotEqual(t, checksum, message.SegmentAssignmentChecksum(7, 10, 2, 3, 100))
+	assert.NotEqual(t, checksum, message.SegmentAssignmentChecksum(7, 1, 20, 3, 100))
+	assert.NotEqual(t, checksum, message.SegmentAssignmentChecksum(7, 1, 2, 30, 100))
+	assert.NotEqual(t, checksum, message.SegmentAssignmentChecksum(7, 1, 2, 3, 101))
+}
+
+func TestVerifyInsertMessageSegmentAssignments(t *testing.T) {
+	const (
+		epoch    = uint64(7)
+		timetick = uint64(100)
+	)
+	build := func(partitions ...*message.PartitionSegmentAssignment) message.ImmutableInsertMessageV1 {
+		msgID := walimplstest.NewTestMessageID(1)
+		m := message.NewInsertMessageBuilderV1().
+			WithVChannel("v1").
+			WithHeader(&message.InsertMessageHeader{
+				CollectionId: 1,
+				Partitions:   partitions,
+			}).
+			WithBody(&msgpb.InsertRequest{CollectionID: 1}).
+			MustBuildMutable().
+			WithTimeTick(timetick).
+			WithLastConfirmed(msgID).
+			IntoImmutableMessage(msgID)
+		return message.MustAsImmutableInsertMessageV1(m)
+	}
+	signed := func(partitionID int64, segmentID int64) *message.PartitionSegmentAssignment {
+		return &message.PartitionSegmentAssignment{
+			PartitionId: partitionID,
+			SegmentAssignment: &message.SegmentAssignment{
+				SegmentId: segmentID,
+				Checksum:  message.SegmentAssignmentChecksum(epoch, 1, partitionID, segmentID, timetick),
+			},
+		}
+	}
+	epochOf := func(segmentID int64) (uint64, bool) {
+		switch segmentID {
+		case 1000, 2000:
+			return epoch, true
+		case 3000:
+			// the segment is created before the signing is supported.
+			return 0, true
+		}
+		return 0, false
+	}
+
+	// round trip.
+	assert.NoError(t, message.VerifyInsertMessageSegmentAssignments(build(signed(1, 1000), signed(2, 2000)), epochOf))
+	assert.NoError(t, message.VerifyInsertMessageSegmentAssignments(build(&message.PartitionSegmentAssignment{
+		PartitionId:       3,
+		SegmentAssignment: &message.SegmentAssignment{SegmentId: 3000},
+	}), epochOf))
+
+	// the segment id is tampered into another known segment.
+	tampered := signed(2, 2000)
+	tampered.SegmentAssignment.SegmentId = 1000
+	err := message.VerifyInsertMessageSegmentAssignments(build(signed(1, 1000), tampered), epochOf)
+	assert.ErrorIs(t, err, message.ErrSegmentAssignmentCorrupted)
+
+	// the partition id is tampered.
+	tampered = signed(1, 1000)
+	tampered.PartitionId = 2
+	err = message.VerifyInsertMessageSegmentAssignments(build(tampered), epochOf)
+	assert.ErrorIs(t, err, message.ErrSegmentAssignmentCorrupted)
+
+	// the checksum is dropped.
+	tampered = signed(1, 1000)
+	tampered.SegmentAssignment.Checksum = 0
+	err = message.VerifyInsertMessageSegmentAssignments(build(tampered), epochOf)
+	assert.ErrorIs(t, err, message.ErrSegmentAssignmentCorrupted)
+
+	// the insert in a txn is signed with the timetick 0, its timetick is rewritten into the commit timetick at the consume side.
+	msgID := walimplstest.NewTestMessageID(1)
+	txnInsert := message.NewInsertMessageBuilderV1().
+		WithVChannel("v1").
+		WithHeader(&message.InsertMessageHeader{
+			CollectionId: 1,
+			Partitions: []*message.PartitionSegmentAssignment{{
+				PartitionId: 1,
+				SegmentAssignment: &message.SegmentAssignment{
+					SegmentId: 1000,
+					Checksum:  message.SegmentAssignmentChecksum(epoch, 1, 1, 1000, 0),
+				},
+			}},
+		}).
+		WithBody(&msgpb.InsertRequest{CollectionID: 1}).
+		MustBuildMutable().
+		WithTimeTick(timetick).
+		WithTxnContext(message.TxnContext{TxnID: 1}).
+		WithLastConfirmed(msgID).
+		IntoImmutableMessage(msgID)
+	assert.NoError(t, message.VerifyInsertMessageSegmentAssignments(message.MustAsImmutableInsertMessageV1(txnInsert), epochOf))
+
+	// the segment unknown by the consumer is not verifiable.
+	tampered = signed(1, 1000)
+	tampered.SegmentAssignment.SegmentId = 4000
+	assert.NoError(t, message.VerifyInsertMessageSegmentAssignments(build(tampered), epochOf))
+}
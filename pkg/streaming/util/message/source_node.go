@@ -0,0 +1,8 @@
+package message
+
+// GetSourceNode returns the node that produces the message, e.g. the proxy of the insert message.
+// Return empty string if the message doesn't declare its source node.
+func GetSourceNode(msg BasicMessage) string {
+	source, _ := msg.Properties().Get(messageSourceNode)
+	return source
+}
@@ -12,79 +12,96 @@ import (
 )
 
 type (
-	SegmentAssignment             = messagespb.SegmentAssignment
-	PartitionSegmentAssignment    = messagespb.PartitionSegmentAssignment
-	TimeTickMessageHeader         = messagespb.TimeTickMessageHeader
-	InsertMessageHeader           = messagespb.InsertMessageHeader
-	DeleteMessageHeader           = messagespb.DeleteMessageHeader
-	CreateCollectionMessageHeader = messagespb.CreateCollectionMessageHeader
-	DropCollectionMessageHeader   = messagespb.DropCollectionMessageHeader
-	CreatePartitionMessageHeader  = messagespb.CreatePartitionMessageHeader
-	DropPartitionMessageHeader    = messagespb.DropPartitionMessageHeader
-	FlushMessageHeader            = messagespb.FlushMessageHeader
-	CreateSegmentMessageHeader    = messagespb.CreateSegmentMessageHeader
-	ManualFlushMessageHeader      = messagespb.ManualFlushMessageHeader
-	BeginTxnMessageHeader         = messagespb.BeginTxnMessageHeader
-	CommitTxnMessageHeader        = messagespb.CommitTxnMessageHeader
-	RollbackTxnMessageHeader      = messagespb.RollbackTxnMessageHeader
-	TxnMessageHeader              = messagespb.TxnMessageHeader
-	ImportMessageHeader           = messagespb.ImportMessageHeader
-	SchemaChangeMessageHeader     = messagespb.SchemaChangeMessageHeader
+	SegmentAssignment                 = messagespb.SegmentAssignment
+	PartitionSegmentAssignment        = messagespb.PartitionSegmentAssignment
+	TimeTickMessageHeader             = messagespb.TimeTickMessageHeader
+	InsertMessageHeader               = messagespb.InsertMessageHeader
+	DeleteMessageHeader               = messagespb.DeleteMessageHeader
+	CreateCollectionMessageHeader     = messagespb.CreateCollectionMessageHeader
+	DropCollectionMessageHeader       = messagespb.DropCollectionMessageHeader
+	CreatePartitionMessageHeader      = messagespb.CreatePartitionMessageHeader
+	DropPartitionMessageHeader        = messagespb.DropPartitionMessageHeader
+	FlushMessageHeader                = messagespb.FlushMessageHeader
+	CreateSegmentMessageHeader        = messagespb.CreateSegmentMessageHeader
+	ManualFlushMessageHeader          = messagespb.ManualFlushMessageHeader
+	BeginTxnMessageHeader             = messagespb.BeginTxnMessageHeader
+	CommitTxnMessageHeader            = messagespb.CommitTxnMessageHeader
+	RollbackTxnMessageHeader          = messagespb.RollbackTxnMessageHeader
+	TxnMessageHeader                  = messagespb.TxnMessageHeader
+	ImportMessageHeader               = messagespb.ImportMessageHeader
+	SchemaChangeMessageHeader         = messagespb.SchemaChangeMessageHeader
+	CreatePartitionsMessageHeader     = messagespb.CreatePartitionsMessageHeader
+	PreparePartitionDropMessageHeader = messagespb.PreparePartitionDropMessageHeader
+	MigrateSegmentsMessageHeader      = messagespb.MigrateSegmentsMessageHeader
 )
 
 type (
-	FlushMessageBody         = messagespb.FlushMessageBody
-	CreateSegmentMessageBody = messagespb.CreateSegmentMessageBody
-	ManualFlushMessageBody   = messagespb.ManualFlushMessageBody
-	BeginTxnMessageBody      = messagespb.BeginTxnMessageBody
-	CommitTxnMessageBody     = messagespb.CommitTxnMessageBody
-	RollbackTxnMessageBody   = messagespb.RollbackTxnMessageBody
-	TxnMessageBody           = messagespb.TxnMessageBody
-	SchemaChangeMessageBody  = messagespb.SchemaChangeMessageBody
+	FlushMessageBody                = messagespb.FlushMessageBody
+	CreateSegmentMessageBody        = messagespb.CreateSegmentMessageBody
+	ManualFlushMessageBody          = messagespb.ManualFlushMessageBody
+	BeginTxnMessageBody             = messagespb.BeginTxnMessageBody
+	CommitTxnMessageBody            = messagespb.CommitTxnMessageBody
+	RollbackTxnMessageBody          = messagespb.RollbackTxnMessageBody
+	TxnMessageBody                  = messagespb.TxnMessageBody
+	SchemaChangeMessageBody         = messagespb.SchemaChangeMessageBody
+	PreparePartitionDropMessageBody = messagespb.PreparePartitionDropMessageBody
+	MigrateSegmentsMessageBody      = messagespb.MigrateSegmentsMessageBody
 )
 
 type (
-	ManualFlushExtraResponse = messagespb.ManualFlushExtraResponse
+	ManualFlushExtraResponse          = messagespb.ManualFlushExtraResponse
+	TxnCommitExtraResponse            = messagespb.TxnCommitExtraResponse
+	TxnRollbackExtraResponse          = messagespb.TxnRollbackExtraResponse
+	TxnSegmentWrite                   = messagespb.TxnSegmentWrite
+	OpenTxn                           = messagespb.OpenTxn
+	ManualFlushSegmentStat            = messagespb.ManualFlushSegmentStat
+	PreparePartitionDropExtraResponse = messagespb.PreparePartitionDropExtraResponse
 )
 
 // messageTypeMap maps the proto message type to the message type.
 var messageTypeMap = map[reflect.Type]MessageType{
-	reflect.TypeOf(&TimeTickMessageHeader{}):         MessageTypeTimeTick,
-	reflect.TypeOf(&InsertMessageHeader{}):           MessageTypeInsert,
-	reflect.TypeOf(&DeleteMessageHeader{}):           MessageTypeDelete,
-	reflect.TypeOf(&CreateCollectionMessageHeader{}): MessageTypeCreateCollection,
-	reflect.TypeOf(&DropCollectionMessageHeader{}):   MessageTypeDropCollection,
-	reflect.TypeOf(&CreatePartitionMessageHeader{}):  MessageTypeCreatePartition,
-	reflect.TypeOf(&DropPartitionMessageHeader{}):    MessageTypeDropPartition,
-	reflect.TypeOf(&CreateSegmentMessageHeader{}):    MessageTypeCreateSegment,
-	reflect.TypeOf(&FlushMessageHeader{}):            MessageTypeFlush,
-	reflect.TypeOf(&ManualFlushMessageHeader{}):      MessageTypeManualFlush,
-	reflect.TypeOf(&BeginTxnMessageHeader{}):         MessageTypeBeginTxn,
-	reflect.TypeOf(&CommitTxnMessageHeader{}):        MessageTypeCommitTxn,
-	reflect.TypeOf(&RollbackTxnMessageHeader{}):      MessageTypeRollbackTxn,
-	reflect.TypeOf(&TxnMessageHeader{}):              MessageTypeTxn,
-	reflect.TypeOf(&ImportMessageHeader{}):           MessageTypeImport,
-	reflect.TypeOf(&SchemaChangeMessageHeader{}):     MessageTypeSchemaChange,
+	reflect.TypeOf(&TimeTickMessageHeader{}):             MessageTypeTimeTick,
+	reflect.TypeOf(&InsertMessageHeader{}):               MessageTypeInsert,
+	reflect.TypeOf(&DeleteMessageHeader{}):               MessageTypeDelete,
+	reflect.TypeOf(&CreateCollectionMessageHeader{}):     MessageTypeCreateCollection,
+	reflect.TypeOf(&DropCollectionMessageHeader{}):       MessageTypeDropCollection,
+	reflect.TypeOf(&CreatePartitionMessageHeader{}):      MessageTypeCreatePartition,
+	reflect.TypeOf(&DropPartitionMessageHeader{}):        MessageTypeDropPartition,
+	reflect.TypeOf(&CreateSegmentMessageHeader{}):        MessageTypeCreateSegment,
+	reflect.TypeOf(&FlushMessageHeader{}):                MessageTypeFlush,
+	reflect.TypeOf(&ManualFlushMessageHeader{}):          MessageTypeManualFlush,
+	reflect.TypeOf(&BeginTxnMessageHeader{}):             MessageTypeBeginTxn,
+	reflect.TypeOf(&CommitTxnMessageHeader{}):            MessageTypeCommitTxn,
+	reflect.TypeOf(&RollbackTxnMessageHeader{}):          MessageTypeRollbackTxn,
+	reflect.TypeOf(&TxnMessageHeader{}):                  MessageTypeTxn,
+	reflect.TypeOf(&ImportMessageHeader{}):               MessageTypeImport,
+	reflect.TypeOf(&SchemaChangeMessageHeader{}):         MessageTypeSchemaChange,
+	reflect.TypeOf(&CreatePartitionsMessageHeader{}):     MessageTypeCreatePartitions,
+	reflect.TypeOf(&PreparePartitionDropMessageHeader{}): MessageTypePreparePartitionDrop,
+	reflect.TypeOf(&MigrateSegmentsMessageHeader{}):      MessageTypeMigrateSegments,
 }
 
 // messageTypeToCustomHeaderMap maps the message type to the proto message type.
 var messageTypeToCustomHeaderMap = map[MessageType]reflect.Type{
-	MessageTypeTimeTick:         reflect.TypeOf(&TimeTickMessageHeader{}),
-	MessageTypeInsert:           reflect.TypeOf(&InsertMessageHeader{}),
-	MessageTypeDelete:           reflect.TypeOf(&DeleteMessageHeader{}),
-	MessageTypeCreateCollection: reflect.TypeOf(&CreateCollectionMessageHeader{}),
-	MessageTypeDropCollection:   reflect.TypeOf(&DropCollectionMessageHeader{}),
-	MessageTypeCreatePartition:  reflect.TypeOf(&CreatePartitionMessageHeader{}),
-	MessageTypeDropPartition:    reflect.TypeOf(&DropPartitionMessageHeader{}),
-	MessageTypeCreateSegment:    reflect.TypeOf(&CreateSegmentMessageHeader{}),
-	MessageTypeFlush:            reflect.TypeOf(&FlushMessageHeader{}),
-	MessageTypeManualFlush:      reflect.TypeOf(&ManualFlushMessageHeader{}),
-	MessageTypeBeginTxn:         reflect.TypeOf(&BeginTxnMessageHeader{}),
-	MessageTypeCommitTxn:        reflect.TypeOf(&CommitTxnMessageHeader{}),
-	MessageTypeRollbackTxn:      reflect.TypeOf(&RollbackTxnMessageHeader{}),
-	MessageTypeTxn:              reflect.TypeOf(&TxnMessageHeader{}),
-	MessageTypeImport:           reflect.TypeOf(&ImportMessageHeader{}),
-	MessageTypeSchemaChange:     reflect.TypeOf(&SchemaChangeMessageHeader{}),
+	MessageTypeTimeTick:             reflect.TypeOf(&TimeTickMessageHeader{}),
+	MessageTypeInsert:               reflect.TypeOf(&InsertMessageHeader{}),
+	MessageTypeDelete:               reflect.TypeOf(&DeleteMessageHeader{}),
+	MessageTypeCreateCollection:     reflect.TypeOf(&CreateCollectionMessageHeader{}),
+	MessageTypeDropCollection:       reflect.TypeOf(&DropCollectionMessageHeader{}),
+	MessageTypeCreatePartition:      reflect.TypeOf(&CreatePartitionMessageHeader{}),
+	MessageTypeDropPartition:        reflect.TypeOf(&DropPartitionMessageHeader{}),
+	MessageTypeCreateSegment:        reflect.TypeOf(&CreateSegmentMessageHeader{}),
+	MessageTypeFlush:                reflect.TypeOf(&FlushMessageHeader{}),
+	MessageTypeManualFlush:          reflect.TypeOf(&ManualFlushMessageHeader{}),
+	MessageTypeBeginTxn:             reflect.TypeOf(&BeginTxnMessageHeader{}),
+	MessageTypeCommitTxn:            reflect.TypeOf(&CommitTxnMessageHeader{}),
+	MessageTypeRollbackTxn:          reflect.TypeOf(&RollbackTxnMessageHeader{}),
+	MessageTypeTxn:                  reflect.TypeOf(&TxnMessageHeader{}),
+	MessageTypeImport:               reflect.TypeOf(&ImportMessageHeader{}),
+	MessageTypeSchemaChange:         reflect.TypeOf(&SchemaChangeMessageHeader{}),
+	MessageTypeCreatePartitions:     reflect.TypeOf(&CreatePartitionsMessageHeader{}),
+	MessageTypePreparePartitionDrop: reflect.TypeOf(&PreparePartitionDropMessageHeader{}),
+	MessageTypeMigrateSegments:      reflect.TypeOf(&MigrateSegmentsMessageHeader{}),
 }
 
 // A system preserved message, should not allowed to provide outside of the streaming system.
@@ -102,112 +119,133 @@ var cipherMessageType = map[MessageType]struct{}{
 }
 
 var exclusiveRequiredMessageType = map[MessageType]struct{}{
-	MessageTypeCreateCollection: {},
-	MessageTypeDropCollection:   {},
-	MessageTypeCreatePartition:  {},
-	MessageTypeDropPartition:    {},
-	MessageTypeManualFlush:      {},
-	MessageTypeSchemaChange:     {},
+	MessageTypeCreateCollection:     {},
+	MessageTypeDropCollection:       {},
+	MessageTypeCreatePartition:      {},
+	MessageTypeDropPartition:        {},
+	MessageTypeManualFlush:          {},
+	MessageTypeSchemaChange:         {},
+	MessageTypeCreatePartitions:     {},
+	MessageTypePreparePartitionDrop: {},
 }
 
 // List all specialized message types.
 type (
-	MutableTimeTickMessageV1         = specializedMutableMessage[*TimeTickMessageHeader, *msgpb.TimeTickMsg]
-	MutableInsertMessageV1           = specializedMutableMessage[*InsertMessageHeader, *msgpb.InsertRequest]
-	MutableDeleteMessageV1           = specializedMutableMessage[*DeleteMessageHeader, *msgpb.DeleteRequest]
-	MutableCreateCollectionMessageV1 = specializedMutableMessage[*CreateCollectionMessageHeader, *msgpb.CreateCollectionRequest]
-	MutableDropCollectionMessageV1   = specializedMutableMessage[*DropCollectionMessageHeader, *msgpb.DropCollectionRequest]
-	MutableCreatePartitionMessageV1  = specializedMutableMessage[*CreatePartitionMessageHeader, *msgpb.CreatePartitionRequest]
-	MutableDropPartitionMessageV1    = specializedMutableMessage[*DropPartitionMessageHeader, *msgpb.DropPartitionRequest]
-	MutableImportMessageV1           = specializedMutableMessage[*ImportMessageHeader, *msgpb.ImportMsg]
-	MutableCreateSegmentMessageV2    = specializedMutableMessage[*CreateSegmentMessageHeader, *CreateSegmentMessageBody]
-	MutableFlushMessageV2            = specializedMutableMessage[*FlushMessageHeader, *FlushMessageBody]
-	MutableBeginTxnMessageV2         = specializedMutableMessage[*BeginTxnMessageHeader, *BeginTxnMessageBody]
-	MutableCommitTxnMessageV2        = specializedMutableMessage[*CommitTxnMessageHeader, *CommitTxnMessageBody]
-	MutableRollbackTxnMessageV2      = specializedMutableMessage[*RollbackTxnMessageHeader, *RollbackTxnMessageBody]
-	MutableSchemaChangeMessageV2     = specializedMutableMessage[*SchemaChangeMessageHeader, *SchemaChangeMessageBody]
-
-	ImmutableTimeTickMessageV1         = specializedImmutableMessage[*TimeTickMessageHeader, *msgpb.TimeTickMsg]
-	ImmutableInsertMessageV1           = specializedImmutableMessage[*InsertMessageHeader, *msgpb.InsertRequest]
-	ImmutableDeleteMessageV1           = specializedImmutableMessage[*DeleteMessageHeader, *msgpb.DeleteRequest]
-	ImmutableCreateCollectionMessageV1 = specializedImmutableMessage[*CreateCollectionMessageHeader, *msgpb.CreateCollectionRequest]
-	ImmutableDropCollectionMessageV1   = specializedImmutableMessage[*DropCollectionMessageHeader, *msgpb.DropCollectionRequest]
-	ImmutableCreatePartitionMessageV1  = specializedImmutableMessage[*CreatePartitionMessageHeader, *msgpb.CreatePartitionRequest]
-	ImmutableDropPartitionMessageV1    = specializedImmutableMessage[*DropPartitionMessageHeader, *msgpb.DropPartitionRequest]
-	ImmutableImportMessageV1           = specializedImmutableMessage[*ImportMessageHeader, *msgpb.ImportMsg]
-	ImmutableCreateSegmentMessageV2    = specializedImmutableMessage[*CreateSegmentMessageHeader, *CreateSegmentMessageBody]
-	ImmutableFlushMessageV2            = specializedImmutableMessage[*FlushMessageHeader, *FlushMessageBody]
-	ImmutableManualFlushMessageV2      = specializedImmutableMessage[*ManualFlushMessageHeader, *ManualFlushMessageBody]
-	ImmutableBeginTxnMessageV2         = specializedImmutableMessage[*BeginTxnMessageHeader, *BeginTxnMessageBody]
-	ImmutableCommitTxnMessageV2        = specializedImmutableMessage[*CommitTxnMessageHeader, *CommitTxnMessageBody]
-	ImmutableRollbackTxnMessageV2      = specializedImmutableMessage[*RollbackTxnMessageHeader, *RollbackTxnMessageBody]
-	ImmutableSchemaChangeMessageV2     = specializedImmutableMessage[*SchemaChangeMessageHeader, *SchemaChangeMessageBody]
+	MutableTimeTickMessageV1             = specializedMutableMessage[*TimeTickMessageHeader, *msgpb.TimeTickMsg]
+	MutableInsertMessageV1               = specializedMutableMessage[*InsertMessageHeader, *msgpb.InsertRequest]
+	MutableDeleteMessageV1               = specializedMutableMessage[*DeleteMessageHeader, *msgpb.DeleteRequest]
+	MutableCreateCollectionMessageV1     = specializedMutableMessage[*CreateCollectionMessageHeader, *msgpb.CreateCollectionRequest]
+	MutableDropCollectionMessageV1       = specializedMutableMessage[*DropCollectionMessageHeader, *msgpb.DropCollectionRequest]
+	MutableCreatePartitionMessageV1      = specializedMutableMessage[*CreatePartitionMessageHeader, *msgpb.CreatePartitionRequest]
+	MutableCreatePartitionsMessageV1     = specializedMutableMessage[*CreatePartitionsMessageHeader, *msgpb.CreatePartitionRequest]
+	MutableDropPartitionMessageV1        = specializedMutableMessage[*DropPartitionMessageHeader, *msgpb.DropPartitionRequest]
+	MutableImportMessageV1               = specializedMutableMessage[*ImportMessageHeader, *msgpb.ImportMsg]
+	MutableCreateSegmentMessageV2        = specializedMutableMessage[*CreateSegmentMessageHeader, *CreateSegmentMessageBody]
+	MutableFlushMessageV2                = specializedMutableMessage[*FlushMessageHeader, *FlushMessageBody]
+	MutableBeginTxnMessageV2             = specializedMutableMessage[*BeginTxnMessageHeader, *BeginTxnMessageBody]
+	MutableCommitTxnMessageV2            = specializedMutableMessage[*CommitTxnMessageHeader, *CommitTxnMessageBody]
+	MutableRollbackTxnMessageV2          = specializedMutableMessage[*RollbackTxnMessageHeader, *RollbackTxnMessageBody]
+	MutableSchemaChangeMessageV2         = specializedMutableMessage[*SchemaChangeMessageHeader, *SchemaChangeMessageBody]
+	MutablePreparePartitionDropMessageV2 = specializedMutableMessage[*PreparePartitionDropMessageHeader, *PreparePartitionDropMessageBody]
+	MutableMigrateSegmentsMessageV2      = specializedMutableMessage[*MigrateSegmentsMessageHeader, *MigrateSegmentsMessageBody]
+
+	ImmutableTimeTickMessageV1             = specializedImmutableMessage[*TimeTickMessageHeader, *msgpb.TimeTickMsg]
+	ImmutableInsertMessageV1               = specializedImmutableMessage[*InsertMessageHeader, *msgpb.InsertRequest]
+	ImmutableDeleteMessageV1               = specializedImmutableMessage[*DeleteMessageHeader, *msgpb.DeleteRequest]
+	ImmutableCreateCollectionMessageV1     = specializedImmutableMessage[*CreateCollectionMessageHeader, *msgpb.CreateCollectionRequest]
+	ImmutableDropCollectionMessageV1       = specializedImmutableMessage[*DropCollectionMessageHeader, *msgpb.DropCollectionRequest]
+	ImmutableCreatePartitionMessageV1      = specializedImmutableMessage[*CreatePartitionMessageHeader, *msgpb.CreatePartitionRequest]
+	ImmutableCreatePartitionsMessageV1     = specializedImmutableMessage[*CreatePartitionsMessageHeader, *msgpb.CreatePartitionRequest]
+	ImmutableDropPartitionMessageV1        = specializedImmutableMessage[*DropPartitionMessageHeader, *msgpb.DropPartitionRequest]
+	ImmutableImportMessageV1               = specializedImmutableMessage[*ImportMessageHeader, *msgpb.ImportMsg]
+	ImmutableCreateSegmentMessageV2        = specializedImmutableMessage[*CreateSegmentMessageHeader, *CreateSegmentMessageBody]
+	ImmutableFlushMessageV2                = specializedImmutableMessage[*FlushMessageHeader, *FlushMessageBody]
+	ImmutableManualFlushMessageV2          = specializedImmutableMessage[*ManualFlushMessageHeader, *ManualFlushMessageBody]
+	ImmutableBeginTxnMessageV2             = specializedImmutableMessage[*BeginTxnMessageHeader, *BeginTxnMessageBody]
+	ImmutableCommitTxnMessageV2            = specializedImmutableMessage[*CommitTxnMessageHeader, *CommitTxnMessageBody]
+	ImmutableRollbackTxnMessageV2          = specializedImmutableMessage[*RollbackTxnMessageHeader, *RollbackTxnMessageBody]
+	ImmutableSchemaChangeMessageV2         = specializedImmutableMessage[*SchemaChangeMessageHeader, *SchemaChangeMessageBody]
+	ImmutablePreparePartitionDropMessageV2 = specializedImmutableMessage[*PreparePartitionDropMessageHeader, *PreparePartitionDropMessageBody]
+	ImmutableMigrateSegmentsMessageV2      = specializedImmutableMessage[*MigrateSegmentsMessageHeader, *MigrateSegmentsMessageBody]
 )
 
 // List all as functions for specialized messages.
 var (
-	AsMutableTimeTickMessageV1         = asSpecializedMutableMessage[*TimeTickMessageHeader, *msgpb.TimeTickMsg]
-	AsMutableInsertMessageV1           = asSpecializedMutableMessage[*InsertMessageHeader, *msgpb.InsertRequest]
-	AsMutableDeleteMessageV1           = asSpecializedMutableMessage[*DeleteMessageHeader, *msgpb.DeleteRequest]
-	AsMutableCreateCollectionMessageV1 = asSpecializedMutableMessage[*CreateCollectionMessageHeader, *msgpb.CreateCollectionRequest]
-	AsMutableDropCollectionMessageV1   = asSpecializedMutableMessage[*DropCollectionMessageHeader, *msgpb.DropCollectionRequest]
-	AsMutableCreatePartitionMessageV1  = asSpecializedMutableMessage[*CreatePartitionMessageHeader, *msgpb.CreatePartitionRequest]
-	AsMutableDropPartitionMessageV1    = asSpecializedMutableMessage[*DropPartitionMessageHeader, *msgpb.DropPartitionRequest]
-	AsMutableImportMessageV1           = asSpecializedMutableMessage[*ImportMessageHeader, *msgpb.ImportMsg]
-	AsMutableCreateSegmentMessageV2    = asSpecializedMutableMessage[*CreateSegmentMessageHeader, *CreateSegmentMessageBody]
-	AsMutableFlushMessageV2            = asSpecializedMutableMessage[*FlushMessageHeader, *FlushMessageBody]
-	AsMutableManualFlushMessageV2      = asSpecializedMutableMessage[*ManualFlushMessageHeader, *ManualFlushMessageBody]
-	AsMutableBeginTxnMessageV2         = asSpecializedMutableMessage[*BeginTxnMessageHeader, *BeginTxnMessageBody]
-	AsMutableCommitTxnMessageV2        = asSpecializedMutableMessage[*CommitTxnMessageHeader, *CommitTxnMessageBody]
-	AsMutableRollbackTxnMessageV2      = asSpecializedMutableMessage[*RollbackTxnMessageHeader, *RollbackTxnMessageBody]
-
-	MustAsMutableTimeTickMessageV1         = mustAsSpecializedMutableMessage[*TimeTickMessageHeader, *msgpb.TimeTickMsg]
-	MustAsMutableInsertMessageV1           = mustAsSpecializedMutableMessage[*InsertMessageHeader, *msgpb.InsertRequest]
-	MustAsMutableDeleteMessageV1           = mustAsSpecializedMutableMessage[*DeleteMessageHeader, *msgpb.DeleteRequest]
-	MustAsMutableCreateCollectionMessageV1 = mustAsSpecializedMutableMessage[*CreateCollectionMessageHeader, *msgpb.CreateCollectionRequest]
-	MustAsMutableDropCollectionMessageV1   = mustAsSpecializedMutableMessage[*DropCollectionMessageHeader, *msgpb.DropCollectionRequest]
-	MustAsMutableCreatePartitionMessageV1  = mustAsSpecializedMutableMessage[*CreatePartitionMessageHeader, *msgpb.CreatePartitionRequest]
-	MustAsMutableDropPartitionMessageV1    = mustAsSpecializedMutableMessage[*DropPartitionMessageHeader, *msgpb.DropPartitionRequest]
-	MustAsMutableImportMessageV1           = mustAsSpecializedMutableMessage[*ImportMessageHeader, *msgpb.ImportMsg]
-	MustAsMutableCreateSegmentMessageV2    = mustAsSpecializedMutableMessage[*CreateSegmentMessageHeader, *CreateSegmentMessageBody]
-	MustAsMutableFlushMessageV2            = mustAsSpecializedMutableMessage[*FlushMessageHeader, *FlushMessageBody]
-	MustAsMutableManualFlushMessageV2      = mustAsSpecializedMutableMessage[*ManualFlushMessageHeader, *ManualFlushMessageBody]
-	MustAsMutableBeginTxnMessageV2         = mustAsSpecializedMutableMessage[*BeginTxnMessageHeader, *BeginTxnMessageBody]
-	MustAsMutableCommitTxnMessageV2        = mustAsSpecializedMutableMessage[*CommitTxnMessageHeader, *CommitTxnMessageBody]
-	MustAsMutableRollbackTxnMessageV2      = mustAsSpecializedMutableMessage[*RollbackTxnMessageHeader, *RollbackTxnMessageBody]
-	MustAsMutableCollectionSchemaChangeV2  = mustAsSpecializedImmutableMessage[*SchemaChangeMessageHeader, *SchemaChangeMessageBody]
-
-	AsImmutableTimeTickMessageV1         = asSpecializedImmutableMessage[*TimeTickMessageHeader, *msgpb.TimeTickMsg]
-	AsImmutableInsertMessageV1           = asSpecializedImmutableMessage[*InsertMessageHeader, *msgpb.InsertRequest]
-	AsImmutableDeleteMessageV1           = asSpecializedImmutableMessage[*DeleteMessageHeader, *msgpb.DeleteRequest]
-	AsImmutableCreateCollectionMessageV1 = asSpecializedImmutableMessage[*CreateCollectionMessageHeader, *msgpb.CreateCollectionRequest]
-	AsImmutableDropCollectionMessageV1   = asSpecializedImmutableMessage[*DropCollectionMessageHeader, *msgpb.DropCollectionRequest]
-	AsImmutableCreatePartitionMessageV1  = asSpecializedImmutableMessage[*CreatePartitionMessageHeader, *msgpb.CreatePartitionRequest]
-	AsImmutableDropPartitionMessageV1    = asSpecializedImmutableMessage[*DropPartitionMessageHeader, *msgpb.DropPartitionRequest]
-	AsImmutableImportMessageV1           = asSpecializedImmutableMessage[*ImportMessageHeader, *msgpb.ImportMsg]
-	AsImmutableCreateSegmentMessageV2    = asSpecializedImmutableMessage[*CreateSegmentMessageHeader, *CreateSegmentMessageBody]
-	AsImmutableFlushMessageV2            = asSpecializedImmutableMessage[*FlushMessageHeader, *FlushMessageBody]
-	AsImmutableManualFlushMessageV2      = asSpecializedImmutableMessage[*ManualFlushMessageHeader, *ManualFlushMessageBody]
-	AsImmutableBeginTxnMessageV2         = asSpecializedImmutableMessage[*BeginTxnMessageHeader, *BeginTxnMessageBody]
-	AsImmutableCommitTxnMessageV2        = asSpecializedImmutableMessage[*CommitTxnMessageHeader, *CommitTxnMessageBody]
-	AsImmutableRollbackTxnMessageV2      = asSpecializedImmutableMessage[*RollbackTxnMessageHeader, *RollbackTxnMessageBody]
-	AsImmutableCollectionSchemaChangeV2  = asSpecializedImmutableMessage[*SchemaChangeMessageHeader, *SchemaChangeMessageBody]
-
-	MustAsImmutableTimeTickMessageV1         = mustAsSpecializedImmutableMessage[*TimeTickMessageHeader, *msgpb.TimeTickMsg]
-	MustAsImmutableInsertMessageV1           = mustAsSpecializedImmutableMessage[*InsertMessageHeader, *msgpb.InsertRequest]
-	MustAsImmutableDeleteMessageV1           = mustAsSpecializedImmutableMessage[*DeleteMessageHeader, *msgpb.DeleteRequest]
-	MustAsImmutableCreateCollectionMessageV1 = mustAsSpecializedImmutableMessage[*CreateCollectionMessageHeader, *msgpb.CreateCollectionRequest]
-	MustAsImmutableDropCollectionMessageV1   = mustAsSpecializedImmutableMessage[*DropCollectionMessageHeader, *msgpb.DropCollectionRequest]
-	MustAsImmutableCreatePartitionMessageV1  = mustAsSpecializedImmutableMessage[*CreatePartitionMessageHeader, *msgpb.CreatePartitionRequest]
-	MustAsImmutableDropPartitionMessageV1    = mustAsSpecializedImmutableMessage[*DropPartitionMessageHeader, *msgpb.DropPartitionRequest]
-	MustAsImmutableImportMessageV1           = mustAsSpecializedImmutableMessage[*ImportMessageHeader, *msgpb.ImportMsg]
-	MustAsImmutableCreateSegmentMessageV2    = mustAsSpecializedImmutableMessage[*CreateSegmentMessageHeader, *CreateSegmentMessageBody]
-	MustAsImmutableFlushMessageV2            = mustAsSpecializedImmutableMessage[*FlushMessageHeader, *FlushMessageBody]
-	MustAsImmutableManualFlushMessageV2      = mustAsSpecializedImmutableMessage[*ManualFlushMessageHeader, *ManualFlushMessageBody]
-	MustAsImmutableBeginTxnMessageV2         = mustAsSpecializedImmutableMessage[*BeginTxnMessageHeader, *BeginTxnMessageBody]
-	MustAsImmutableCommitTxnMessageV2        = mustAsSpecializedImmutableMessage[*CommitTxnMessageHeader, *CommitTxnMessageBody]
-	MustAsImmutableCollectionSchemaChangeV2  = mustAsSpecializedImmutableMessage[*SchemaChangeMessageHeader, *SchemaChangeMessageBody]
-	AsImmutableTxnMessage                    = func(msg ImmutableMessage) ImmutableTxnMessage {
+	AsMutableTimeTickMessageV1             = asSpecializedMutableMessage[*TimeTickMessageHeader, *msgpb.TimeTickMsg]
+	AsMutableInsertMessageV1               = asSpecializedMutableMessage[*InsertMessageHeader, *msgpb.InsertRequest]
+	AsMutableDeleteMessageV1               = asSpecializedMutableMessage[*DeleteMessageHeader, *msgpb.DeleteRequest]
+	AsMutableCreateCollectionMessageV1     = asSpecializedMutableMessage[*CreateCollectionMessageHeader, *msgpb.CreateCollectionRequest]
+	AsMutableDropCollectionMessageV1       = asSpecializedMutableMessage[*DropCollectionMessageHeader, *msgpb.DropCollectionRequest]
+	AsMutableCreatePartitionMessageV1      = asSpecializedMutableMessage[*CreatePartitionMessageHeader, *msgpb.CreatePartitionRequest]
+	AsMutableCreatePartitionsMessageV1     = asSpecializedMutableMessage[*CreatePartitionsMessageHeader, *msgpb.CreatePartitionRequest]
+	AsMutableDropPartitionMessageV1        = asSpecializedMutableMessage[*DropPartitionMessageHeader, *msgpb.DropPartitionRequest]
+	AsMutableImportMessageV1               = asSpecializedMutableMessage[*ImportMessageHeader, *msgpb.ImportMsg]
+	AsMutableCreateSegmentMessageV2        = asSpecializedMutableMessage[*CreateSegmentMessageHeader, *CreateSegmentMessageBody]
+	AsMutableFlushMessageV2                = asSpecializedMutableMessage[*FlushMessageHeader, *FlushMessageBody]
+	AsMutableManualFlushMessageV2          = asSpecializedMutableMessage[*ManualFlushMessageHeader, *ManualFlushMessageBody]
+	AsMutableBeginTxnMessageV2             = asSpecializedMutableMessage[*BeginTxnMessageHeader, *BeginTxnMessageBody]
+	AsMutableCommitTxnMessageV2            = asSpecializedMutableMessage[*CommitTxnMessageHeader, *CommitTxnMessageBody]
+	AsMutableRollbackTxnMessageV2          = asSpecializedMutableMessage[*RollbackTxnMessageHeader, *RollbackTxnMessageBody]
+	AsMutableSchemaChangeMessageV2         = asSpecializedMutableMessage[*SchemaChangeMessageHeader, *SchemaChangeMessageBody]
+	AsMutablePreparePartitionDropMessageV2 = asSpecializedMutableMessage[*PreparePartitionDropMessageHeader, *PreparePartitionDropMessageBody]
+	AsMutableMigrateSegmentsMessageV2      = asSpecializedMutableMessage[*MigrateSegmentsMessageHeader, *MigrateSegmentsMessageBody]
+
+	MustAsMutableTimeTickMessageV1             = mustAsSpecializedMutableMessage[*TimeTickMessageHeader, *msgpb.TimeTickMsg]
+	MustAsMutableInsertMessageV1               = mustAsSpecializedMutableMessage[*InsertMessageHeader, *msgpb.InsertRequest]
+	MustAsMutableDeleteMessageV1               = mustAsSpecializedMutableMessage[*DeleteMessageHeader, *msgpb.DeleteRequest]
+	MustAsMutableCreateCollectionMessageV1     = mustAsSpecializedMutableMessage[*CreateCollectionMessageHeader, *msgpb.CreateCollectionRequest]
+	MustAsMutableDropCollectionMessageV1       = mustAsSpecializedMutableMessage[*DropCollectionMessageHeader, *msgpb.DropCollectionRequest]
+	MustAsMutableCreatePartitionMessageV1      = mustAsSpecializedMutableMessage[*CreatePartitionMessageHeader, *msgpb.CreatePartitionRequest]
+	MustAsMutableCreatePartitionsMessageV1     = mustAsSpecializedMutableMessage[*CreatePartitionsMessageHeader, *msgpb.CreatePartitionRequest]
+	MustAsMutableDropPartitionMessageV1        = mustAsSpecializedMutableMessage[*DropPartitionMessageHeader, *msgpb.DropPartitionRequest]
+	MustAsMutableImportMessageV1               = mustAsSpecializedMutableMessage[*ImportMessageHeader, *msgpb.ImportMsg]
+	MustAsMutableCreateSegmentMessageV2        = mustAsSpecializedMutableMessage[*CreateSegmentMessageHeader, *CreateSegmentMessageBody]
+	MustAsMutableFlushMessageV2                = mustAsSpecializedMutableMessage[*FlushMessageHeader, *FlushMessageBody]
+	MustAsMutableManualFlushMessageV2          = mustAsSpecializedMutableMessage[*ManualFlushMessageHeader, *ManualFlushMessageBody]
+	MustAsMutableBeginTxnMessageV2             = mustAsSpecializedMutableMessage[*BeginTxnMessageHeader, *BeginTxnMessageBody]
+	MustAsMutableCommitTxnMessageV2            = mustAsSpecializedMutableMessage[*CommitTxnMessageHeader, *CommitTxnMessageBody]
+	MustAsMutableRollbackTxnMessageV2          = mustAsSpecializedMutableMessage[*RollbackTxnMessageHeader, *RollbackTxnMessageBody]
+	MustAsMutableCollectionSchemaChangeV2      = mustAsSpecializedImmutableMessage[*SchemaChangeMessageHeader, *SchemaChangeMessageBody]
+	MustAsMutablePreparePartitionDropMessageV2 = mustAsSpecializedMutableMessage[*PreparePartitionDropMessageHeader, *PreparePartitionDropMessageBody]
+	MustAsMutableMigrateSegmentsMessageV2      = mustAsSpecializedMutableMessage[*MigrateSegmentsMessageHeader, *MigrateSegmentsMessageBody]
+
+	AsImmutableTimeTickMessageV1             = asSpecializedImmutableMessage[*TimeTickMessageHeader, *msgpb.TimeTickMsg]
+	AsImmutableInsertMessageV1               = asSpecializedImmutableMessage[*InsertMessageHeader, *msgpb.InsertRequest]
+	AsImmutableDeleteMessageV1               = asSpecializedImmutableMessage[*DeleteMessageHeader, *msgpb.DeleteRequest]
+	AsImmutableCreateCollectionMessageV1     = asSpecializedImmutableMessage[*CreateCollectionMessageHeader, *msgpb.CreateCollectionRequest]
+	AsImmutableDropCollectionMessageV1       = asSpecializedImmutableMessage[*DropCollectionMessageHeader, *msgpb.DropCollectionRequest]
+	AsImmutableCreatePartitionMessageV1      = asSpecializedImmutableMessage[*CreatePartitionMessageHeader, *msgpb.CreatePartitionRequest]
+	AsImmutableCreatePartitionsMessageV1     = asSpecializedImmutableMessage[*CreatePartitionsMessageHeader, *msgpb.CreatePartitionRequest]
+	AsImmutableDropPartitionMessageV1        = asSpecializedImmutableMessage[*DropPartitionMessageHeader, *msgpb.DropPartitionRequest]
+	AsImmutableImportMessageV1               = asSpecializedImmutableMessage[*ImportMessageHeader, *msgpb.ImportMsg]
+	AsImmutableCreateSegmentMessageV2        = asSpecializedImmutableMessage[*CreateSegmentMessageHeader, *CreateSegmentMessageBody]
+	AsImmutableFlushMessageV2                = asSpecializedImmutableMessage[*FlushMessageHeader, *FlushMessageBody]
+	AsImmutableManualFlushMessageV2          = asSpecializedImmutableMessage[*ManualFlushMessageHeader, *ManualFlushMessageBody]
+	AsImmutableBeginTxnMessageV2             = asSpecializedImmutableMessage[*BeginTxnMessageHeader, *BeginTxnMessageBody]
+	AsImmutableCommitTxnMessageV2            = asSpecializedImmutableMessage[*CommitTxnMessageHeader, *CommitTxnMessageBody]
+	AsImmutableRollbackTxnMessageV2          = asSpecializedImmutableMessage[*RollbackTxnMessageHeader, *RollbackTxnMessageBody]
+	AsImmutableCollectionSchemaChangeV2      = asSpecializedImmutableMessage[*SchemaChangeMessageHeader, *SchemaChangeMessageBody]
+	AsImmutablePreparePartitionDropMessageV2 = asSpecializedImmutableMessage[*PreparePartitionDropMessageHeader, *PreparePartitionDropMessageBody]
+	AsImmutableMigrateSegmentsMessageV2      = asSpecializedImmutableMessage[*MigrateSegmentsMessageHeader, *MigrateSegmentsMessageBody]
+
+	MustAsImmutableTimeTickMessageV1             = mustAsSpecializedImmutableMessage[*TimeTickMessageHeader, *msgpb.TimeTickMsg]
+	MustAsImmutableInsertMessageV1               = mustAsSpecializedImmutableMessage[*InsertMessageHeader, *msgpb.InsertRequest]
+	MustAsImmutableDeleteMessageV1               = mustAsSpecializedImmutableMessage[*DeleteMessageHeader, *msgpb.DeleteRequest]
+	MustAsImmutableCreateCollectionMessageV1     = mustAsSpecializedImmutableMessage[*CreateCollectionMessageHeader, *msgpb.CreateCollectionRequest]
+	MustAsImmutableDropCollectionMessageV1       = mustAsSpecializedImmutableMessage[*DropCollectionMessageHeader, *msgpb.DropCollectionRequest]
+	MustAsImmutableCreatePartitionMessageV1      = mustAsSpecializedImmutableMessage[*CreatePartitionMessageHeader, *msgpb.CreatePartitionRequest]
+	MustAsImmutableCreatePartitionsMessageV1     = mustAsSpecializedImmutableMessage[*CreatePartitionsMessageHeader, *msgpb.CreatePartitionRequest]
+	MustAsImmutableDropPartitionMessageV1        = mustAsSpecializedImmutableMessage[*DropPartitionMessageHeader, *msgpb.DropPartitionRequest]
+	MustAsImmutableImportMessageV1               = mustAsSpecializedImmutableMessage[*ImportMessageHeader, *msgpb.ImportMsg]
+	MustAsImmutableCreateSegmentMessageV2        = mustAsSpecializedImmutableMessage[*CreateSegmentMessageHeader, *CreateSegmentMessageBody]
+	MustAsImmutableFlushMessageV2                = mustAsSpecializedImmutableMessage[*FlushMessageHeader, *FlushMessageBody]
+	MustAsImmutableManualFlushMessageV2          = mustAsSpecializedImmutableMessage[*ManualFlushMessageHeader, *ManualFlushMessageBody]
+	MustAsImmutableBeginTxnMessageV2             = mustAsSpecializedImmutableMessage[*BeginTxnMessageHeader, *BeginTxnMessageBody]
+	MustAsImmutableCommitTxnMessageV2            = mustAsSpecializedImmutableMessage[*CommitTxnMessageHeader, *CommitTxnMessageBody]
+	MustAsImmutableCollectionSchemaChangeV2      = mustAsSpecializedImmutableMessage[*SchemaChangeMessageHeader, *SchemaChangeMessageBody]
+	MustAsImmutablePreparePartitionDropMessageV2 = mustAsSpecializedImmutableMessage[*PreparePartitionDropMessageHeader, *PreparePartitionDropMessageBody]
+	MustAsImmutableMigrateSegmentsMessageV2      = mustAsSpecializedImmutableMessage[*MigrateSegmentsMessageHeader, *MigrateSegmentsMessageBody]
+	AsImmutableTxnMessage                        = func(msg ImmutableMessage) ImmutableTxnMessage {
 		underlying, ok := msg.(*immutableTxnMessageImpl)
 		if !ok {
 			return nil
@@ -75,3 +75,70 @@ func TestAsSpecializedMessage(t *testing.T) {
 		message.MustAsMutableCreateCollectionMessageV1(m)
 	})
 }
+
+func TestCreatePartitionsMessage(t *testing.T) {
+	m, err := message.NewCreatePartitionsMessageBuilderV1().
+		WithVChannel("v1").
+		WithHeader(&message.CreatePartitionsMessageHeader{
+			CollectionId: 1,
+			PartitionIds: []int64{2, 3, 4},
+		}).
+		WithBody(&msgpb.CreatePartitionRequest{
+			CollectionID: 1,
+		}).BuildMutable()
+	assert.NoError(t, err)
+	assert.Equal(t, message.MessageTypeCreatePartitions, m.MessageType())
+
+	createPartitionsMsg, err := message.AsMutableCreatePartitionsMessageV1(m)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), createPartitionsMsg.Header().CollectionId)
+	assert.Equal(t, []int64{2, 3, 4}, createPartitionsMsg.Header().PartitionIds)
+
+	_, err = message.AsMutableCreatePartitionMessageV1(m)
+	assert.Error(t, err)
+}
+
+func TestPreparePartitionDropMessage(t *testing.T) {
+	m, err := message.NewPreparePartitionDropMessageBuilderV2().
+		WithVChannel("v1").
+		WithHeader(&message.PreparePartitionDropMessageHeader{
+			CollectionId: 1,
+			PartitionId:  2,
+		}).
+		WithBody(&message.PreparePartitionDropMessageBody{}).BuildMutable()
+	assert.NoError(t, err)
+	assert.Equal(t, message.MessageTypePreparePartitionDrop, m.MessageType())
+	assert.Equal(t, "PREPARE_PARTITION_DROP", m.MessageType().String())
+
+	prepareMsg, err := message.AsMutablePreparePartitionDropMessageV2(m)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), prepareMsg.Header().CollectionId)
+	assert.Equal(t, int64(2), prepareMsg.Header().PartitionId)
+
+	_, err = message.AsMutableDropPartitionMessageV1(m)
+	assert.Error(t, err)
+}
+
+func TestMigrateSegmentsMessage(t *testing.T) {
+	m, err := message.NewMigrateSegmentsMessageBuilderV2().
+		WithVChannel("v1").
+		WithHeader(&message.MigrateSegmentsMessageHeader{
+			CollectionId:    1,
+			FromPartitionId: 2,
+			ToPartitionId:   3,
+			SegmentIds:      []int64{4, 5},
+		}).
+		WithBody(&message.MigrateSegmentsMessageBody{}).BuildMutable()
+	assert.NoError(t, err)
+	assert.Equal(t, message.MessageTypeMigrateSegments, m.MessageType())
+	assert.Equal(t, "MIGRATE_SEGMENTS", m.MessageType().String())
+
+	migrateMsg, err := message.AsMutableMigrateSegmentsMessageV2(m)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), migrateMsg.Header().FromPartitionId)
+	assert.Equal(t, int64(3), migrateMsg.Header().ToPartitionId)
+	assert.Equal(t, []int64{4, 5}, migrateMsg.Header().SegmentIds)
+
+	_, err = message.AsMutablePreparePartitionDropMessageV2(m)
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/cockroachdb/errors"
 
@@ -75,7 +76,40 @@ func (n StreamingNodeInfo) String() string {
 type StreamingNodeStatus struct {
 	StreamingNodeInfo
 	// TODO: balance attributes should added here in future.
-	Err error
+	TimeToSealP99   map[int64]time.Duration         // the rolling p99 of time to seal of collections on the node.
+	VChannelGrowing map[string]VChannelGrowingStats // the growing segments of vchannels on the node, used for the per-vchannel attribution.
+	StorageVersions map[string]map[int64]int64      // the count of growing segments of pchannels on the node by the storage version.
+	SealedUnflushed map[string][]int64              // the sealed segments of pchannels on the node whose flush is still in flight, never compacted by the coordinator.
+	Saturations     map[string]PChannelSaturation   // the rolling saturation of the append path of pchannels on the node.
+	// the build and behavior metadata of the segment assignment of pchannels on the node, used for debugging the mixed-version cluster.
+	AssignmentMetadata map[string]PChannelAssignmentMetadata
+	Err                error
+}
+
+// PChannelAssignmentMetadata is the build and behavior metadata of the segment assignment of a pchannel on the streaming node.
+type PChannelAssignmentMetadata struct {
+	Term            int64
+	NodeVersion     string
+	EnabledFeatures []string // the enabled feature flags relevant to the segment assignment.
+	PolicyChain     []string // the async seal policies in the evaluation order.
+	Collections     int64    // the count of the collections registered on the pchannel.
+	Partitions      int64    // the count of the partitions registered on the pchannel.
+	MaxCollections  int64    // the max collections per pchannel, 0 if unlimited.
+	MaxPartitions   int64    // the max partitions per pchannel, 0 if unlimited.
+}
+
+// PChannelSaturation is the rolling saturation of the append path of a pchannel on the streaming node.
+type PChannelSaturation struct {
+	Saturation float64 // the rolling saturation score in [0, 1].
+	Saturated  bool    // the saturation is above the configured threshold.
+}
+
+// VChannelGrowingStats is the rollup of the growing segments of a vchannel on the streaming node.
+type VChannelGrowingStats struct {
+	CollectionID int64
+	SegmentCount int64
+	Rows         uint64
+	BinarySize   uint64
 }
 
 // IsHealthy returns whether the streaming node is healthy.
@@ -83,6 +117,12 @@ func (n *StreamingNodeStatus) IsHealthy() bool {
 	return n.Err == nil
 }
 
+// IsPChannelSaturated returns whether the append path of the pchannel on the node is saturated,
+// the coordinator should avoid placing more vchannels on it.
+func (n *StreamingNodeStatus) IsPChannelSaturated(pchannel string) bool {
+	return n.Saturations[pchannel].Saturated
+}
+
 // ErrorOfNode returns the error of the streaming node.
 func (n *StreamingNodeStatus) ErrorOfNode() error {
 	if n == nil {
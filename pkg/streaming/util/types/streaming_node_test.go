@@ -13,6 +13,14 @@ func TestStreamingNodeStatus(t *testing.T) {
 	s = StreamingNodeStatus{Err: ErrNotAlive}
 	assert.False(t, s.IsHealthy())
 
+	s = StreamingNodeStatus{Saturations: map[string]PChannelSaturation{
+		"p1": {Saturation: 0.9, Saturated: true},
+		"p2": {Saturation: 0.1},
+	}}
+	assert.True(t, s.IsPChannelSaturated("p1"))
+	assert.False(t, s.IsPChannelSaturated("p2"))
+	assert.False(t, s.IsPChannelSaturated("p3"))
+
 	info := StreamingNodeInfo{
 		ServerID: 1,
 		Address:  "localhost:8080",
@@ -5384,7 +5384,9 @@ type streamingConfig struct {
 	WALBroadcasterConcurrencyRatio ParamItem `refreshable:"false"`
 
 	// txn
-	TxnDefaultKeepaliveTimeout ParamItem `refreshable:"true"`
+	TxnDefaultKeepaliveTimeout   ParamItem `refreshable:"true"`
+	TxnFencedCommitPolicy        ParamItem `refreshable:"true"`
+	TxnPressureWarnMaxBinarySize ParamItem `refreshable:"true"`
 
 	// write ahead buffer
 	WALWriteAheadBufferCapacity  ParamItem `refreshable:"true"`
@@ -5397,6 +5399,82 @@ type streamingConfig struct {
 	WALRecoveryPersistInterval      ParamItem `refreshable:"true"`
 	WALRecoveryMaxDirtyMessage      ParamItem `refreshable:"true"`
 	WALRecoveryGracefulCloseTimeout ParamItem `refreshable:"true"`
+
+	// segment assignment configuration.
+	WALSegmentAssignSyncSealDebounce              ParamItem `refreshable:"true"`
+	WALSegmentAssignSealOnCollectionRelease       ParamItem `refreshable:"true"`
+	WALSegmentAssignPrewarmOnCollectionLoad       ParamItem `refreshable:"true"`
+	WALSegmentAssignMaxSizeReconcileFactor        ParamItem `refreshable:"true"`
+	WALSegmentAssignMaxSizeReconcileRecap         ParamItem `refreshable:"true"`
+	WALSegmentAssignWatchdogStallIntervals        ParamItem `refreshable:"true"`
+	WALSegmentAssignSystemCollectionMaxID         ParamItem `refreshable:"true"`
+	WALSegmentAssignStorageVersionOverrides       ParamItem `refreshable:"true"`
+	WALSegmentAssignRecoveryBackoffInitial        ParamItem `refreshable:"true"`
+	WALSegmentAssignRecoveryBackoffMax            ParamItem `refreshable:"true"`
+	WALSegmentAssignRecoveryBackoffFactor         ParamItem `refreshable:"true"`
+	WALSegmentAssignRecoveryBackoffJitter         ParamItem `refreshable:"true"`
+	WALSegmentAssignSealQueueMaxWaiting           ParamItem `refreshable:"true"`
+	WALSegmentAssignSealQueueAckBlockedWarn       ParamItem `refreshable:"true"`
+	WALSegmentAssignSealQueuePersistMaxEntries    ParamItem `refreshable:"true"`
+	WALSegmentAssignSealQueuePersistMaxBytes      ParamItem `refreshable:"true"`
+	WALSegmentAssignHighPriorityCollections       ParamItem `refreshable:"true"`
+	WALSegmentAssignHighPrioritySizeRatio         ParamItem `refreshable:"true"`
+	WALSegmentAssignHighPriorityMaxLifetime       ParamItem `refreshable:"true"`
+	WALSegmentAssignL0MaxDeletes                  ParamItem `refreshable:"true"`
+	WALSegmentAssignL0MaxLifetime                 ParamItem `refreshable:"true"`
+	WALSegmentAssignSealGracePeriod               ParamItem `refreshable:"true"`
+	WALSegmentAssignMetaCompactionInterval        ParamItem `refreshable:"false"`
+	WALSegmentAssignSealedNotifyEnabled           ParamItem `refreshable:"true"`
+	WALSegmentAssignEmergencyUnsealEnabled        ParamItem `refreshable:"true"`
+	WALSegmentAssignCatalogAssignTimeout          ParamItem `refreshable:"true"`
+	WALSegmentAssignCatalogSweepTimeout           ParamItem `refreshable:"true"`
+	WALSegmentAssignFlushQueueMaxDepth            ParamItem `refreshable:"true"`
+	WALSegmentAssignShadowPChannels               ParamItem `refreshable:"true"`
+	WALSegmentAssignShadowStrategy                ParamItem `refreshable:"true"`
+	WALSegmentAssignReservePartitions             ParamItem `refreshable:"true"`
+	WALSegmentAssignReserveFillThreshold          ParamItem `refreshable:"true"`
+	WALSegmentAssignSmallCollectionCollections    ParamItem `refreshable:"true"`
+	WALSegmentAssignSmallCollectionMaxLifetime    ParamItem `refreshable:"true"`
+	WALSegmentAssignSmallCollectionMinSize        ParamItem `refreshable:"true"`
+	WALSegmentAssignRowSizeCheckMinRows           ParamItem `refreshable:"true"`
+	WALSegmentAssignRowSizeCheckAutoBump          ParamItem `refreshable:"true"`
+	WALSegmentAssignSourceStatsMaxSources         ParamItem `refreshable:"true"`
+	WALSegmentAssignSourceStatsTopK               ParamItem `refreshable:"true"`
+	WALSegmentAssignSourceStatsLogInterval        ParamItem `refreshable:"false"`
+	WALSegmentAssignTxnNonBlockingSealCollections ParamItem `refreshable:"true"`
+	WALSegmentAssignTimeTickStrictCollections     ParamItem `refreshable:"true"`
+	WALSegmentAssignFlushPendingTimeout           ParamItem `refreshable:"true"`
+	WALSegmentAssignCreationRateAlertThreshold    ParamItem `refreshable:"true"`
+	WALSegmentAssignCreationRateAlertWindow       ParamItem `refreshable:"true"`
+	WALSegmentAssignPreassignedProducers          ParamItem `refreshable:"true"`
+	WALSegmentAssignSealSweepFullPassCycles       ParamItem `refreshable:"true"`
+	WALSegmentAssignSaturationThreshold           ParamItem `refreshable:"true"`
+	WALSegmentAssignForceAddPartitionEnabled      ParamItem `refreshable:"true"`
+	WALSegmentAssignPKSketchEnabled               ParamItem `refreshable:"true"`
+	WALSegmentAssignPKSketchPrecision             ParamItem `refreshable:"false"`
+	WALSegmentAssignStandbySnapshotInterval       ParamItem `refreshable:"true"`
+	WALSegmentAssignTimeTickTooOldWarnThreshold   ParamItem `refreshable:"true"`
+	WALSegmentAssignTimeTickTooOldWindow          ParamItem `refreshable:"true"`
+	WALSegmentAssignDeferMetaCreationEnabled      ParamItem `refreshable:"false"`
+	WALSegmentAssignAutoSealDisabledCollections   ParamItem `refreshable:"true"`
+	WALSegmentAssignAutoSealDisabledWarnThreshold ParamItem `refreshable:"true"`
+	WALSegmentAssignAutoSealDisabledWarnInterval  ParamItem `refreshable:"true"`
+	WALSegmentAssignPreparePartitionDropTTL       ParamItem `refreshable:"true"`
+	WALSegmentAssignRandomSeed                    ParamItem `refreshable:"false"`
+	WALSegmentAssignFlushTimeTickPrewarmThreshold ParamItem `refreshable:"true"`
+	WALSegmentAssignPressureWarnSaturation        ParamItem `refreshable:"true"`
+	WALSegmentAssignPressureCriticalSaturation    ParamItem `refreshable:"true"`
+	WALSegmentAssignSealHistorySize               ParamItem `refreshable:"false"`
+	WALSegmentAssignMaxCollectionsPerPChannel     ParamItem `refreshable:"true"`
+	WALSegmentAssignMaxPartitionsPerPChannel      ParamItem `refreshable:"true"`
+	WALSegmentAssignManualFlushMaxFutureSkew      ParamItem `refreshable:"true"`
+	WALSegmentAssignFillNotifyThreshold           ParamItem `refreshable:"true"`
+	WALSegmentAssignFillNotifyInterval            ParamItem `refreshable:"true"`
+
+	// redo configuration.
+	WALRedoPolicy                    ParamItem `refreshable:"true"`
+	WALRedoAdaptiveInflightThreshold ParamItem `refreshable:"true"`
+	WALRedoRegistryCapacity          ParamItem `refreshable:"true"`
 }
 
 func (p *streamingConfig) init(base *BaseTable) {
@@ -5507,6 +5585,26 @@ it also determine the depth of depth first search method that is used to find th
 		Export:       true,
 	}
 	p.TxnDefaultKeepaliveTimeout.Init(base.mgr)
+	p.TxnFencedCommitPolicy = ParamItem{
+		Key:     "streaming.txn.fencedCommitPolicy",
+		Version: "2.6.0",
+		Doc: `The policy of the txn commit that conflicts with a fence of the touched collections installed after the txn data is written,
+"delay" by default, the commit is delayed until the flush of the fence is appended, so the commit is ordered after the flush point.
+"fail" fails the commit with a fenced error, and the txn should be rolled back and retried by the client.`,
+		DefaultValue: "delay",
+		Export:       true,
+	}
+	p.TxnFencedCommitPolicy.Init(base.mgr)
+	p.TxnPressureWarnMaxBinarySize = ParamItem{
+		Key:     "streaming.txn.pressureWarnMaxBinarySize",
+		Version: "2.6.0",
+		Doc: `The max binary size of the data written by a txn begun under the warn pressure of the pchannel, 64m by default.
+The txn exceeding the limit is rejected with a retryable error, the txn begun under the normal pressure is not limited.
+0 means the txn is not limited under the warn pressure.`,
+		DefaultValue: "64m",
+		Export:       true,
+	}
+	p.TxnPressureWarnMaxBinarySize.Init(base.mgr)
 
 	p.WALWriteAheadBufferCapacity = ParamItem{
 		Key:          "streaming.walWriteAheadBuffer.capacity",
@@ -5567,6 +5665,813 @@ If that persist operation exceeds this timeout, the wal recovery module will clo
 		Export:       true,
 	}
 	p.WALRecoveryGracefulCloseTimeout.Init(base.mgr)
+
+	p.WALSegmentAssignSyncSealDebounce = ParamItem{
+		Key:     "streaming.walSegmentAssign.syncSealDebounce",
+		Version: "2.6.0",
+		Doc: `The debounce window of seal operation triggered by the sync of flusher, 50ms by default.
+The seal triggers arrived in the window are coalesced into one seal sweep, set it to 0 to disable the debounce.
+The direct seal operations such as seal by total growing segments size are not affected.`,
+		DefaultValue: "50ms",
+		Export:       true,
+	}
+	p.WALSegmentAssignSyncSealDebounce.Init(base.mgr)
+
+	p.WALSegmentAssignSealOnCollectionRelease = ParamItem{
+		Key:     "streaming.walSegmentAssign.sealOnCollectionRelease",
+		Version: "2.6.0",
+		Doc: `Whether to seal all growing segments of the collection when the collection release signal arrives, false by default.
+If enabled, the release will not be done until all growing segments of the collection are sealed and flushed,
+so the next load of the collection doesn't need to consume the growing data from wal.`,
+		DefaultValue: "false",
+		Export:       true,
+	}
+	p.WALSegmentAssignSealOnCollectionRelease.Init(base.mgr)
+
+	p.WALSegmentAssignPrewarmOnCollectionLoad = ParamItem{
+		Key:     "streaming.walSegmentAssign.prewarmOnCollectionLoad",
+		Version: "2.6.0",
+		Doc: `Whether to prewarm the segment assignment of the collection when the collection load signal arrives, false by default.
+If enabled, a growing segment will be allocated for every partition of the collection that has no growing segment,
+so the first insert after load doesn't need to wait for the segment allocation.`,
+		DefaultValue: "false",
+		Export:       true,
+	}
+	p.WALSegmentAssignPrewarmOnCollectionLoad.Init(base.mgr)
+
+	p.WALSegmentAssignCircuitBreakerFailureThreshold = ParamItem{
+		Key:     "streaming.walSegmentAssign.circuitBreaker.failureThreshold",
+		Version: "2.6.0",
+		Doc: `The consecutive hard failure count of segment assignment to open the circuit breaker of a collection, 10 by default.
+When the circuit breaker is open, the insert of the collection will fail fast until the cool down is over.
+The retryable failures such as too old timetick are not counted, set it to 0 to disable the circuit breaker.`,
+		DefaultValue: "10",
+		Export:       true,
+	}
+	p.WALSegmentAssignCircuitBreakerFailureThreshold.Init(base.mgr)
+
+	p.WALSegmentAssignCircuitBreakerCooldown = ParamItem{
+		Key:     "streaming.walSegmentAssign.circuitBreaker.cooldown",
+		Version: "2.6.0",
+		Doc: `The cool down of the open circuit breaker of a collection, 30s by default.
+After the cool down, the circuit breaker becomes half open and only one probe insert is allowed to pass,
+the circuit breaker is closed if the probe succeeds, otherwise it is opened again.`,
+		DefaultValue: "30s",
+		Export:       true,
+	}
+	p.WALSegmentAssignCircuitBreakerCooldown.Init(base.mgr)
+
+	p.WALSegmentAssignMaxSizeReconcileFactor = ParamItem{
+		Key:     "streaming.walSegmentAssign.maxSizeReconcile.factor",
+		Version: "2.6.0",
+		Doc: `The factor to detect the growing segment with a stale max binary size, 1.5 by default.
+If the max binary size of a growing segment exceeds the current limit (dataCoord.segment.maxSize * dataCoord.segment.sealProportion) by more than the factor,
+the segment is reconciled by the periodic seal inspection, set it to 0 to disable the reconciliation.`,
+		DefaultValue: "1.5",
+		Export:       true,
+	}
+	p.WALSegmentAssignMaxSizeReconcileFactor.Init(base.mgr)
+
+	p.WALSegmentAssignMaxSizeReconcileRecap = ParamItem{
+		Key:     "streaming.walSegmentAssign.maxSizeReconcile.recap",
+		Version: "2.6.0",
+		Doc: `Whether to re-cap the stale growing segment to the current limit when reconciling, true by default.
+If enabled, the segment whose inserted size is still below the current limit is re-capped and keeps growing, otherwise it's sealed.
+If disabled, every stale growing segment is sealed immediately.`,
+		DefaultValue: "true",
+		Export:       true,
+	}
+	p.WALSegmentAssignMaxSizeReconcileRecap.Init(base.mgr)
+
+	p.WALSegmentAssignWatchdogStallIntervals = ParamItem{
+		Key:     "streaming.walSegmentAssign.watchdog.stallIntervals",
+		Version: "2.6.0",
+		Doc: `The count of seal inspection intervals (10s) to detect a stalled seal worker of a pchannel, 3 by default.
+If a pchannel doesn't complete any seal sweep within the intervals, the seal worker of the pchannel is recreated,
+set it to 0 to disable the watchdog.`,
+		DefaultValue: "3",
+		Export:       true,
+	}
+	p.WALSegmentAssignWatchdogStallIntervals.Init(base.mgr)
+
+	p.WALSegmentAssignSystemCollectionMaxID = ParamItem{
+		Key:     "streaming.walSegmentAssign.systemCollectionMaxID",
+		Version: "2.6.0",
+		Doc: `The max collection id of the reserved system collection id range [1, systemCollectionMaxID], 0 by default.
+The segment assignment of a system collection is never rejected by the tenant-facing protection such as the circuit breaker,
+but the hard correctness checks such as fencing and max segment size are still applied, set it to 0 to disable the reserved range.`,
+		DefaultValue: "0",
+		Export:       true,
+	}
+	p.WALSegmentAssignSystemCollectionMaxID.Init(base.mgr)
+
+	p.WALSegmentAssignStorageVersionOverrides = ParamItem{
+		Key:     "streaming.walSegmentAssign.storageVersionOverrides",
+		Version: "2.6.0",
+		Doc: `The storage version overrides of the new segments of the selected collections, empty by default.
+Formatted as "collectionID:storageVersion" separated by comma, e.g. "100:2,101:0", the storage version can be 0 (v1) or 2 (v2).
+The collection without override follows common.storage.enablev2, the storage version of an allocated segment is never changed.`,
+		DefaultValue: "",
+		Export:       true,
+	}
+	p.WALSegmentAssignStorageVersionOverrides.Init(base.mgr)
+
+	p.WALSegmentAssignRecoveryBackoffInitial = ParamItem{
+		Key:     "streaming.walSegmentAssign.recoveryBackoff.initialInterval",
+		Version: "2.6.0",
+		Doc: `The initial interval of the retry backoff of segment assignment recovery, 10ms by default.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "10ms",
+		Export:       true,
+	}
+	p.WALSegmentAssignRecoveryBackoffInitial.Init(base.mgr)
+
+	p.WALSegmentAssignRecoveryBackoffMax = ParamItem{
+		Key:     "streaming.walSegmentAssign.recoveryBackoff.maxInterval",
+		Version: "2.6.0",
+		Doc: `The max interval of the retry backoff of segment assignment recovery, 1s by default.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "1s",
+		Export:       true,
+	}
+	p.WALSegmentAssignRecoveryBackoffMax.Init(base.mgr)
+
+	p.WALSegmentAssignRecoveryBackoffFactor = ParamItem{
+		Key:          "streaming.walSegmentAssign.recoveryBackoff.multiplier",
+		Version:      "2.6.0",
+		Doc:          "The multiplier of the retry backoff of segment assignment recovery, 2 by default",
+		DefaultValue: "2",
+		Export:       true,
+	}
+	p.WALSegmentAssignRecoveryBackoffFactor.Init(base.mgr)
+
+	p.WALSegmentAssignRecoveryBackoffJitter = ParamItem{
+		Key:     "streaming.walSegmentAssign.recoveryBackoff.jitter",
+		Version: "2.6.0",
+		Doc: `The jitter ratio applied to every retry interval of segment assignment recovery, 0.5 by default.
+The interval is randomized in [interval*(1-jitter), interval*(1+jitter)], so the recovering pchannels don't hit the catalog at the same time.
+The jitter can't be disabled, 0 falls back to the default jitter.`,
+		DefaultValue: "0.5",
+		Export:       true,
+	}
+	p.WALSegmentAssignRecoveryBackoffJitter.Init(base.mgr)
+
+	p.WALSegmentAssignSealQueueMaxWaiting = ParamItem{
+		Key:     "streaming.walSegmentAssign.sealQueue.maxWaiting",
+		Version: "2.6.0",
+		Doc: `The max count of sealed segments of a pchannel that wait for the flying acks before flushing, 1024 by default, 0 means no limit.
+If the cap is exceeded, the oldest waiting segments are flushed without waiting for the flying acks, the inserts of these acks are discarded.
+The segments with open txns are never flushed by the cap until the txns are expired or done.`,
+		DefaultValue: "1024",
+		Export:       true,
+	}
+	p.WALSegmentAssignSealQueueMaxWaiting.Init(base.mgr)
+
+	p.WALSegmentAssignSealQueueAckBlockedWarn = ParamItem{
+		Key:     "streaming.walSegmentAssign.sealQueue.ackBlockedWarnCycles",
+		Version: "2.6.0",
+		Doc: `The count of seal cycles that a sealed segment is blocked by the flying acks before a warning is logged, 30 by default, 0 means never warn.
+The warning is repeated every such count of cycles until the segment is flushed, it carries the ages of the blocking acks to find the clients that delay the acks.`,
+		DefaultValue: "30",
+		Export:       true,
+	}
+	p.WALSegmentAssignSealQueueAckBlockedWarn.Init(base.mgr)
+
+	p.WALSegmentAssignSealQueuePersistMaxEntries = ParamItem{
+		Key:     "streaming.walSegmentAssign.sealQueue.persistMaxEntries",
+		Version: "2.6.0",
+		Doc: `The max count of segment assignment metas written into catalog by a seal cycle of a pchannel, 0 by default, 0 means no limit.
+The seals beyond the budget are kept in memory and persisted at the following cycles before their flush messages are appended,
+so the sweep of a large amount of segments doesn't burst the catalog.`,
+		DefaultValue: "0",
+		Export:       true,
+	}
+	p.WALSegmentAssignSealQueuePersistMaxEntries.Init(base.mgr)
+
+	p.WALSegmentAssignSealQueuePersistMaxBytes = ParamItem{
+		Key:     "streaming.walSegmentAssign.sealQueue.persistMaxBytes",
+		Version: "2.6.0",
+		Doc: `The max bytes of segment assignment metas written into catalog by a seal cycle of a pchannel, 0 by default, 0 means no limit.
+It works with persistMaxEntries, the first write of a cycle is always allowed so the seal keeps progressing.`,
+		DefaultValue: "0",
+		Export:       true,
+	}
+	p.WALSegmentAssignSealQueuePersistMaxBytes.Init(base.mgr)
+
+	p.WALSegmentAssignHighPriorityCollections = ParamItem{
+		Key:     "streaming.walSegmentAssign.highPriority.collections",
+		Version: "2.6.0",
+		Doc: `The collections whose inserts are always assigned as high priority, empty by default.
+Formatted as collection ids separated by comma, e.g. "100,101", the insert can also be flagged as high priority by its header.
+The high priority inserts are assigned into the dedicated growing segments of the partition, isolated from the bulk inserts.`,
+		DefaultValue: "",
+		Export:       true,
+	}
+	p.WALSegmentAssignHighPriorityCollections.Init(base.mgr)
+
+	p.WALSegmentAssignHighPrioritySizeRatio = ParamItem{
+		Key:     "streaming.walSegmentAssign.highPriority.sizeRatio",
+		Version: "2.6.0",
+		Doc: `The ratio of the max binary size of the high priority segment to the normal segment, 0.25 by default.
+The high priority segment is smaller than the normal one, so it's sealed and flushed earlier.`,
+		DefaultValue: "0.25",
+		Export:       true,
+	}
+	p.WALSegmentAssignHighPrioritySizeRatio.Init(base.mgr)
+
+	p.WALSegmentAssignHighPriorityMaxLifetime = ParamItem{
+		Key:     "streaming.walSegmentAssign.highPriority.maxLifetime",
+		Version: "2.6.0",
+		Doc: `The max lifetime of the high priority segment, 60s by default, it's used instead of dataCoord.segment.maxLife.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "60s",
+		Export:       true,
+	}
+	p.WALSegmentAssignHighPriorityMaxLifetime.Init(base.mgr)
+
+	p.WALSegmentAssignL0MaxDeletes = ParamItem{
+		Key:     "streaming.walSegmentAssign.l0.maxDeletes",
+		Version: "2.6.0",
+		Doc: `The max count of deleted rows that can be assigned into a L0 segment, 100000 by default.
+The L0 segment only holds the deletes of the partition, it's never sealed by size but by the count of deletes and its lifetime.`,
+		DefaultValue: "100000",
+		Export:       true,
+	}
+	p.WALSegmentAssignL0MaxDeletes.Init(base.mgr)
+
+	p.WALSegmentAssignL0MaxLifetime = ParamItem{
+		Key:     "streaming.walSegmentAssign.l0.maxLifetime",
+		Version: "2.6.0",
+		Doc: `The max lifetime of the L0 segment, 10m by default.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "10m",
+		Export:       true,
+	}
+	p.WALSegmentAssignL0MaxLifetime.Init(base.mgr)
+
+	p.WALSegmentAssignSealGracePeriod = ParamItem{
+		Key:     "streaming.walSegmentAssign.sealGracePeriod",
+		Version: "2.6.0",
+		Doc: `The grace period before a newly created segment can be sealed by the seal policies, 0s by default (disabled).
+The segment younger than the grace period is exempt from the policy-driven sealing, the manual flush, drop and fence operations still seal it.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "0s",
+		Export:       true,
+	}
+	p.WALSegmentAssignSealGracePeriod.Init(base.mgr)
+
+	p.WALSegmentAssignMetaCompactionInterval = ParamItem{
+		Key:     "streaming.walSegmentAssign.metaCompactionInterval",
+		Version: "2.6.0",
+		Doc: `The interval of compacting the segment assignment meta keyspace of the wal in the catalog, 1h by default, 0s to disable it.
+The assignment metas of the partitions or collections that are dropped are removed by the compaction.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "1h",
+		Export:       true,
+	}
+	p.WALSegmentAssignMetaCompactionInterval.Init(base.mgr)
+
+	p.WALSegmentAssignSealedNotifyEnabled = ParamItem{
+		Key:     "streaming.walSegmentAssign.sealedNotify.enabled",
+		Version: "2.6.0",
+		Doc: `Whether to push the sealed segment to the coordinator directly after its flush message is durably appended, false by default.
+The notification is best-effort and never blocks the seal, the wal is still the source of truth of the sealed segment.
+The connection to the coordinator is only created at the startup of streaming node if it's enabled.`,
+		DefaultValue: "false",
+		Export:       true,
+	}
+	p.WALSegmentAssignSealedNotifyEnabled.Init(base.mgr)
+
+	p.WALSegmentAssignEmergencyUnsealEnabled = ParamItem{
+		Key:     "streaming.walSegmentAssign.emergencyUnseal.enabled",
+		Version: "2.6.0",
+		Doc: `Whether a sealed segment whose flush message is never appended into wal can be reverted back to growing, false by default.
+It's an emergency operation to relieve the segment capacity shortage, only enable it temporarily when it's necessary.
+The segment sealed by fence, flush, schema change or drop operation can never be reverted.`,
+		DefaultValue: "false",
+		Export:       true,
+	}
+	p.WALSegmentAssignEmergencyUnsealEnabled.Init(base.mgr)
+
+	p.WALSegmentAssignCatalogAssignTimeout = ParamItem{
+		Key:     "streaming.walSegmentAssign.catalogTimeout.assign",
+		Version: "2.6.0",
+		Doc: `The timeout of every segment assignment catalog write on the assignment path, 5s by default.
+The timed out insert is rejected with a retry later error, the client should retry it with backoff.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "5s",
+		Export:       true,
+	}
+	p.WALSegmentAssignCatalogAssignTimeout.Init(base.mgr)
+
+	p.WALSegmentAssignCatalogSweepTimeout = ParamItem{
+		Key:     "streaming.walSegmentAssign.catalogTimeout.sweep",
+		Version: "2.6.0",
+		Doc: `The timeout of every segment assignment catalog operation on the seal sweep path, 30s by default.
+The timed out seal operation is retried at the next sweep cycle, so a hung catalog can never block the sweep indefinitely.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "30s",
+		Export:       true,
+	}
+	p.WALSegmentAssignCatalogSweepTimeout.Init(base.mgr)
+
+	p.WALSegmentAssignFlushQueueMaxDepth = ParamItem{
+		Key:     "streaming.walSegmentAssign.flushQueue.maxDepth",
+		Version: "2.6.0",
+		Doc: `The max count of the queued flush operations of a collection on a pchannel, 16 by default.
+The concurrent flush of the same collection with a not greater flush ts is coalesced onto the in-flight one,
+otherwise it's queued until the in-flight one is done, the flush beyond the max depth is rejected with a retry later error.`,
+		DefaultValue: "16",
+		Export:       true,
+	}
+	p.WALSegmentAssignFlushQueueMaxDepth.Init(base.mgr)
+
+	p.WALSegmentAssignShadowPChannels = ParamItem{
+		Key:     "streaming.walSegmentAssign.shadow.pchannels",
+		Version: "2.6.0",
+		Doc: `The pchannels that the shadow segment assignment is enabled on, separated by comma, empty by default.
+The shadow assignment evaluates where the insert would land with the shadow strategy asynchronously after the primary assignment,
+the outcome is only recorded into the log and metrics, it never persists any meta, reserves any capacity or modifies the insert message.`,
+		DefaultValue: "",
+		Export:       true,
+	}
+	p.WALSegmentAssignShadowPChannels.Init(base.mgr)
+
+	p.WALSegmentAssignShadowStrategy = ParamItem{
+		Key:     "streaming.walSegmentAssign.shadow.strategy",
+		Version: "2.6.0",
+		Doc: `The strategy evaluated by the shadow segment assignment, best_fit by default.
+first_fit: assign the insert to the first growing segment that can hold it, same as the primary assignment.
+best_fit: assign the insert to the growing segment with the least remaining capacity that can hold it.`,
+		DefaultValue: "best_fit",
+		Export:       true,
+	}
+	p.WALSegmentAssignShadowStrategy.Init(base.mgr)
+
+	p.WALSegmentAssignReservePartitions = ParamItem{
+		Key:     "streaming.walSegmentAssign.reserve.partitions",
+		Version: "2.6.0",
+		Doc: `The partitions that keep a reserved growing segment to absorb the write burst across the seal boundary, empty by default.
+Formatted as partition ids separated by comma, e.g. "100,101", or "*" for all partitions.
+Once the current growing segment of the partition passes the fill threshold, the next growing segment is pre-allocated,
+so the insert that can not be held by the current segment is switched to the reserved one without allocating a segment on the critical path.`,
+		DefaultValue: "",
+		Export:       true,
+	}
+	p.WALSegmentAssignReservePartitions.Init(base.mgr)
+
+	p.WALSegmentAssignReserveFillThreshold = ParamItem{
+		Key:     "streaming.walSegmentAssign.reserve.fillThreshold",
+		Version: "2.6.0",
+		Doc: `The fill ratio of the current growing segment that triggers the pre-allocation of the reserved growing segment, 0.7 by default.
+It should be in range (0, 1], the reserved segment is only allocated for the partitions enabled by reserve.partitions.`,
+		DefaultValue: "0.7",
+		Export:       true,
+	}
+	p.WALSegmentAssignReserveFillThreshold.Init(base.mgr)
+
+	p.WALSegmentAssignSmallCollectionCollections = ParamItem{
+		Key:     "streaming.walSegmentAssign.smallCollection.collections",
+		Version: "2.6.0",
+		Doc: `The small collections whose growing segment is sized by the observed ingest rate, empty by default.
+Formatted as collection ids separated by comma, e.g. "100,101".
+The max binary size of the new growing segment of the small collection is recalculated by the seal inspector periodically,
+so the tiny collection is flushed promptly without holding a large reservation.`,
+		DefaultValue: "",
+		Export:       true,
+	}
+	p.WALSegmentAssignSmallCollectionCollections.Init(base.mgr)
+
+	p.WALSegmentAssignSmallCollectionMaxLifetime = ParamItem{
+		Key:     "streaming.walSegmentAssign.smallCollection.maxLifetime",
+		Version: "2.6.0",
+		Doc: `The max lifetime of the growing segment of the small collection, 5m by default.
+The max binary size of the segment is the binary size expected to be ingested within the lifetime at the observed ingest rate.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "5m",
+		Export:       true,
+	}
+	p.WALSegmentAssignSmallCollectionMaxLifetime.Init(base.mgr)
+
+	p.WALSegmentAssignSmallCollectionMinSize = ParamItem{
+		Key:     "streaming.walSegmentAssign.smallCollection.minSize",
+		Version: "2.6.0",
+		Doc: `The min max binary size of the growing segment of the small collection in MB, 1 by default.
+The size derived from the ingest rate is never less than it and never greater than the normal segment size.`,
+		DefaultValue: "1",
+		Export:       true,
+	}
+	p.WALSegmentAssignSmallCollectionMinSize.Init(base.mgr)
+
+	p.WALSegmentAssignRowSizeCheckMinRows = ParamItem{
+		Key:     "streaming.walSegmentAssign.rowSizeCheck.minRows",
+		Version: "2.6.0",
+		Doc: `The min count of rows of the average row size that a growing segment should hold, 100 by default, 0 to disable the check.
+The average row size of the collection is observed from its inserts,
+a prominent warning is logged and a metric is counted if the max binary size of the segment can't hold such a batch,
+it usually means a misconfigured dataCoord.segment.maxSize.`,
+		DefaultValue: "100",
+		Export:       true,
+	}
+	p.WALSegmentAssignRowSizeCheckMinRows.Init(base.mgr)
+
+	p.WALSegmentAssignRowSizeCheckAutoBump = ParamItem{
+		Key:     "streaming.walSegmentAssign.rowSizeCheck.autoBump",
+		Version: "2.6.0",
+		Doc: `Whether to bump the max binary size of the new growing segment of the collection that fails the row size check, false by default.
+The max binary size is bumped to the floor that can hold the min rows of the average row size,
+the bumped floor can be seen in the segment assignment introspection of the collection.`,
+		DefaultValue: "false",
+		Export:       true,
+	}
+	p.WALSegmentAssignRowSizeCheckAutoBump.Init(base.mgr)
+
+	p.WALSegmentAssignSourceStatsMaxSources = ParamItem{
+		Key:     "streaming.walSegmentAssign.sourceStats.maxSources",
+		Version: "2.6.0",
+		Doc: `The max count of the source nodes (e.g. proxies) whose segment assignments are aggregated separately, 1024 by default.
+The assignments of the sources beyond the limit are aggregated into the "other" source.`,
+		DefaultValue: "1024",
+		Export:       true,
+	}
+	p.WALSegmentAssignSourceStatsMaxSources.Init(base.mgr)
+
+	p.WALSegmentAssignSourceStatsTopK = ParamItem{
+		Key:     "streaming.walSegmentAssign.sourceStats.topK",
+		Version: "2.6.0",
+		Doc: `The count of the top source nodes by segment assignments shown in the introspection and the periodic log, 10 by default.
+The rest of the sources are folded into the "other" source.`,
+		DefaultValue: "10",
+		Export:       true,
+	}
+	p.WALSegmentAssignSourceStatsTopK.Init(base.mgr)
+
+	p.WALSegmentAssignSourceStatsLogInterval = ParamItem{
+		Key:     "streaming.walSegmentAssign.sourceStats.logInterval",
+		Version: "2.6.0",
+		Doc: `The interval of logging the top source nodes by segment assignments, 1m by default, the log is disabled if it's not positive.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "1m",
+		Export:       true,
+	}
+	p.WALSegmentAssignSourceStatsLogInterval.Init(base.mgr)
+
+	p.WALSegmentAssignTxnNonBlockingSealCollections = ParamItem{
+		Key:     "streaming.walSegmentAssign.txnNonBlockingSealCollections",
+		Version: "2.6.0",
+		Doc: `The collections whose segments are not blocked from sealing by the running txns, empty by default.
+Formatted as collection ids separated by comma, e.g. "100,101".
+By default, a segment written by a running txn is kept unflushed until the txn is committed, rollbacked or expired.
+The segments of these collections remain seal-eligible, so the txn may span a seal boundary and its commit lists multiple segments.
+It's suitable for the collections that use txn only for client-side batching.`,
+		DefaultValue: "",
+		Export:       true,
+	}
+	p.WALSegmentAssignTxnNonBlockingSealCollections.Init(base.mgr)
+
+	p.WALSegmentAssignTimeTickStrictCollections = ParamItem{
+		Key:     "streaming.walSegmentAssign.timeTickStrictCollections",
+		Version: "2.6.0",
+		Doc: `The collections whose inserts are validated against the last seal time tick of the partition, empty by default.
+Formatted as collection ids separated by comma, e.g. "100,101".
+By default, an insert whose time tick is older than the last sealed segment of the partition lands in the next growing segment,
+so the time tick of the segments of the partition may be not monotonic.
+The insert of these collections is redone with a refreshed time tick instead, the violations are counted in both modes.`,
+		DefaultValue: "",
+		Export:       true,
+	}
+	p.WALSegmentAssignTimeTickStrictCollections.Init(base.mgr)
+
+	p.WALSegmentAssignFlushPendingTimeout = ParamItem{
+		Key:     "streaming.walSegmentAssign.flushPendingTimeout",
+		Version: "2.6.0",
+		Doc: `The timeout of the flush pending flag of a collection, 5m by default.
+The flag is set when the manual flush fences the segments of the collection, and cleared when the manual flush message is appended.
+The flag of a manual flush that is never appended is expired after the timeout, so the DDL of the collection is not blocked forever.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "5m",
+		Export:       true,
+	}
+	p.WALSegmentAssignFlushPendingTimeout.Init(base.mgr)
+
+	p.WALSegmentAssignCreationRateAlertThreshold = ParamItem{
+		Key:     "streaming.walSegmentAssign.creationRateAlertThreshold",
+		Version: "2.6.0",
+		Doc: `The alert threshold of the projected count of segments created per day of a collection, 10000 by default, 0 means never alert.
+The creation rate of a collection is projected over the creation rate alert window,
+a warning carrying the segment limitation policy is logged and counted when the projection crosses the threshold.
+It's purely observational, the collection with aggressive small segment settings can burn through the segment ids and catalog rows.`,
+		DefaultValue: "10000",
+		Export:       true,
+	}
+	p.WALSegmentAssignCreationRateAlertThreshold.Init(base.mgr)
+
+	p.WALSegmentAssignCreationRateAlertWindow = ParamItem{
+		Key:     "streaming.walSegmentAssign.creationRateAlertWindow",
+		Version: "2.6.0",
+		Doc: `The window that the segment creation rate of a collection is projected over, 1h by default.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "1h",
+		Export:       true,
+	}
+	p.WALSegmentAssignCreationRateAlertWindow.Init(base.mgr)
+
+	p.WALSegmentAssignPreassignedProducers = ParamItem{
+		Key:     "streaming.walSegmentAssign.preassignedProducers",
+		Version: "2.6.0",
+		Doc: `The internal producers allowed to bypass the segment assignment with the pre-assigned insert messages, empty by default.
+Formatted as producer names separated by comma, e.g. "repair,replayer".
+The insert message pre-assigned by other producers is rejected, the pre-assigned segments must be growing segments of the partition of the insert.`,
+		DefaultValue: "",
+		Export:       true,
+	}
+	p.WALSegmentAssignPreassignedProducers.Init(base.mgr)
+
+	p.WALSegmentAssignSealSweepFullPassCycles = ParamItem{
+		Key:     "streaming.walSegmentAssign.sealSweepFullPassCycles",
+		Version: "2.6.0",
+		Doc: `The count of seal policy sweep cycles that every partition is evaluated at least once, 10 by default.
+The sweep only evaluates the partitions changed since the last sweep (by assignment, sync, txn or config change),
+and 1/N of the unchanged partitions in a rolling pass, so the time-based seal policies of the idle partitions are still applied.
+1 (or less) means every partition is evaluated at every sweep cycle.`,
+		DefaultValue: "10",
+		Export:       true,
+	}
+	p.WALSegmentAssignSealSweepFullPassCycles.Init(base.mgr)
+
+	p.WALSegmentAssignSaturationThreshold = ParamItem{
+		Key:     "streaming.walSegmentAssign.saturationThreshold",
+		Version: "2.6.0",
+		Doc: `The threshold of the rolling saturation of the append path of a pchannel in [0, 1], 0.8 by default.
+The saturation is the max of the lock wait ratio, the segment allocation ratio and the backpressure rejection ratio of the segment assignment.
+The pchannel whose saturation is above the threshold is flagged as saturated in the report to the coordinator,
+so the coordinator can avoid placing more vchannels on it. 0 (or less) means never flagged.`,
+		DefaultValue: "0.8",
+		Export:       true,
+	}
+	p.WALSegmentAssignSaturationThreshold.Init(base.mgr)
+
+	p.WALSegmentAssignForceAddPartitionEnabled = ParamItem{
+		Key:     "streaming.walSegmentAssign.forceAddPartition.enabled",
+		Version: "2.6.0",
+		Doc: `Whether a partition unknown by the coordinator metadata can be forcibly added into the segment assignment by the admin, false by default.
+It's a break-glass operation to unblock the insert when the coordinator metadata is wrong, only enable it temporarily when it's necessary.`,
+		DefaultValue: "false",
+		Export:       true,
+	}
+	p.WALSegmentAssignForceAddPartitionEnabled.Init(base.mgr)
+
+	p.WALSegmentAssignPKSketchEnabled = ParamItem{
+		Key:     "streaming.walSegmentAssign.pkSketch.enabled",
+		Version: "2.6.0",
+		Doc: `Whether to estimate the distinct primary keys of the growing segments from the primary key hashes in the insert header, false by default.
+The estimate is persisted in the segment stat and carried by the flush message, so the coordinator can plan the dedup and compaction.
+The insert without primary key hashes is skipped by the estimate.`,
+		DefaultValue: "false",
+		Export:       true,
+	}
+	p.WALSegmentAssignPKSketchEnabled.Init(base.mgr)
+
+	p.WALSegmentAssignPKSketchPrecision = ParamItem{
+		Key:     "streaming.walSegmentAssign.pkSketch.precision",
+		Version: "2.6.0",
+		Doc: `The precision of the distinct primary key estimate of a growing segment in [4, 16], 12 by default.
+The estimate uses 2^precision bytes of memory per growing segment, and its standard error is about 1.04/sqrt(2^precision).`,
+		DefaultValue: "12",
+		Export:       true,
+	}
+	p.WALSegmentAssignPKSketchPrecision.Init(base.mgr)
+
+	p.WALSegmentAssignStandbySnapshotInterval = ParamItem{
+		Key:     "streaming.walSegmentAssign.standby.snapshotInterval",
+		Version: "2.6.0",
+		Doc: `The interval of publishing the full snapshot of the segment assignment state to the warm standby of the pchannel, 30s by default.
+The assignment events are published to the standby as deltas between the snapshots, the snapshot is also published right after the standby is lagged.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "30s",
+		Export:       true,
+	}
+	p.WALSegmentAssignStandbySnapshotInterval.Init(base.mgr)
+
+	p.WALSegmentAssignTimeTickTooOldWarnThreshold = ParamItem{
+		Key:     "streaming.walSegmentAssign.timeTickTooOld.warnThreshold",
+		Version: "2.6.0",
+		Doc: `The warn threshold of the rate per second of the assignments of a collection rejected by the too old time tick, 10 by default, 0 means never warn.
+The rejected insert is redone with a refreshed time tick, so the rejections are the canary of the lag of the time tick allocator.
+A warning carrying the lag of the rejected time tick is logged at most once per window when the rate crosses the threshold.`,
+		DefaultValue: "10",
+		Export:       true,
+	}
+	p.WALSegmentAssignTimeTickTooOldWarnThreshold.Init(base.mgr)
+
+	p.WALSegmentAssignTimeTickTooOldWindow = ParamItem{
+		Key:     "streaming.walSegmentAssign.timeTickTooOld.window",
+		Version: "2.6.0",
+		Doc: `The window that the rate of the assignments rejected by the too old time tick is computed over, 1m by default.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "1m",
+		Export:       true,
+	}
+	p.WALSegmentAssignTimeTickTooOldWindow.Init(base.mgr)
+
+	p.WALSegmentAssignDeferMetaCreationEnabled = ParamItem{
+		Key:     "streaming.walSegmentAssign.deferMetaCreation.enabled",
+		Version: "2.6.0",
+		Doc: `Whether to defer the first catalog write of a new growing segment until its first insert is durably appended, false by default.
+The meta of the segment is held in memory until then, so the segment that never receives an insert produces no catalog write.
+The segment allocated at the coordinator but unknown by the catalog is adopted as sealed and flushed right away when the pchannel is recovered with it enabled.`,
+		DefaultValue: "false",
+		Export:       true,
+	}
+	p.WALSegmentAssignDeferMetaCreationEnabled.Init(base.mgr)
+
+	p.WALSegmentAssignAutoSealDisabledCollections = ParamItem{
+		Key:     "streaming.walSegmentAssign.autoSealDisabled.collections",
+		Version: "2.6.0",
+		Doc: `The collections whose growing segments are never sealed by the seal policies, empty by default.
+Formatted as collection ids separated by comma, e.g. "100,101".
+The segment of such collection is only sealed by the manual flush, the drop of collection or partition, the fence and the hard memory protection,
+it's used by the ingestion pipeline that orchestrates the flush by itself.`,
+		DefaultValue: "",
+		Export:       true,
+	}
+	p.WALSegmentAssignAutoSealDisabledCollections.Init(base.mgr)
+
+	p.WALSegmentAssignAutoSealDisabledWarnThreshold = ParamItem{
+		Key:     "streaming.walSegmentAssign.autoSealDisabled.warnThreshold",
+		Version: "2.6.0",
+		Doc: `The growing binary size in MB of a collection opted out of the auto seal that triggers a warning, 4096 by default, 0 to disable the warning.
+The warning is repeated every warnInterval until the growing binary size falls below it, so the opt-out doesn't silently become a memory leak.`,
+		DefaultValue: "4096",
+		Export:       true,
+	}
+	p.WALSegmentAssignAutoSealDisabledWarnThreshold.Init(base.mgr)
+
+	p.WALSegmentAssignAutoSealDisabledWarnInterval = ParamItem{
+		Key:     "streaming.walSegmentAssign.autoSealDisabled.warnInterval",
+		Version: "2.6.0",
+		Doc: `The min interval between the warnings of a collection opted out of the auto seal, 5m by default.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "5m",
+		Export:       true,
+	}
+	p.WALSegmentAssignAutoSealDisabledWarnInterval.Init(base.mgr)
+
+	p.WALSegmentAssignPreparePartitionDropTTL = ParamItem{
+		Key:     "streaming.walSegmentAssign.preparePartitionDropTTL",
+		Version: "2.6.0",
+		Doc: `The ttl of the fence installed by the prepare of partition drop, 5m by default.
+The prepare seals the growing segments of the partition and rejects the newer inserts of it, so the following drop partition is cheap.
+The fence is cleared after the ttl if the drop partition never arrives, and the writes of the partition resume.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "5m",
+		Export:       true,
+	}
+	p.WALSegmentAssignPreparePartitionDropTTL.Init(base.mgr)
+
+	p.WALSegmentAssignRandomSeed = ParamItem{
+		Key:     "streaming.walSegmentAssign.randomSeed",
+		Version: "2.6.0",
+		Doc: `The seed of the random source of the segment assignment of a pchannel, 0 by default.
+The random source is used by the randomized decisions, such as the jitter of the segment size limitation.
+The seed is logged when the pchannel is recovered, set it to reproduce the same decisions for debugging.
+0 means a new seed is generated at every recovery.`,
+		DefaultValue: "0",
+		Export:       true,
+	}
+	p.WALSegmentAssignRandomSeed.Init(base.mgr)
+
+	p.WALSegmentAssignFlushTimeTickPrewarmThreshold = ParamItem{
+		Key:     "streaming.walSegmentAssign.flushTimeTickPrewarmThreshold",
+		Version: "2.6.0",
+		Doc: `The min count of flush messages appended by a seal batch to pre-warm the timetick allocator of the wal, 8 by default.
+The allocator is advanced beyond the max barrier timetick of the batch once before the flush messages are appended,
+so the barrier of every flush message is satisfied by the local timetick of the allocator without a round trip.
+0 (or less) means the pre-warm is disabled.`,
+		DefaultValue: "8",
+		Export:       true,
+	}
+	p.WALSegmentAssignFlushTimeTickPrewarmThreshold.Init(base.mgr)
+
+	p.WALSegmentAssignPressureWarnSaturation = ParamItem{
+		Key:     "streaming.walSegmentAssign.pressureWarnSaturation",
+		Version: "2.6.0",
+		Doc: `The saturation of the append path of a pchannel to enter the warn pressure, 0.8 by default.
+The txn begun under the warn pressure is limited by streaming.txn.pressureWarnMaxBinarySize.
+0 (or less) means the warn pressure is disabled.`,
+		DefaultValue: "0.8",
+		Export:       true,
+	}
+	p.WALSegmentAssignPressureWarnSaturation.Init(base.mgr)
+
+	p.WALSegmentAssignPressureCriticalSaturation = ParamItem{
+		Key:     "streaming.walSegmentAssign.pressureCriticalSaturation",
+		Version: "2.6.0",
+		Doc: `The saturation of the append path of a pchannel to enter the critical pressure, 0.95 by default.
+The new txn is rejected with a retryable error under the critical pressure, the existing txns are still allowed to finish.
+0 (or less) means the critical pressure is disabled.`,
+		DefaultValue: "0.95",
+		Export:       true,
+	}
+	p.WALSegmentAssignPressureCriticalSaturation.Init(base.mgr)
+
+	p.WALSegmentAssignSealHistorySize = ParamItem{
+		Key:     "streaming.walSegmentAssign.sealHistorySize",
+		Version: "2.6.0",
+		Doc: `The count of the recent seal events kept in memory of every pchannel for the post-incident debugging, 256 by default.
+The events are served by the debug endpoint and the admin rpc of the streaming node, the oldest event is dropped once it's full.
+0 means the seal history is disabled.`,
+		DefaultValue: "256",
+		Export:       true,
+	}
+	p.WALSegmentAssignSealHistorySize.Init(base.mgr)
+
+	p.WALSegmentAssignMaxCollectionsPerPChannel = ParamItem{
+		Key:     "streaming.walSegmentAssign.maxCollectionsPerPChannel",
+		Version: "2.6.0",
+		Doc: `The max count of the collections registered on one pchannel, 4096 by default.
+The create collection beyond the cap is rejected, so the coordinator places the collection on another pchannel.
+The collections recovered from the wal are never rejected. 0 means unlimited.`,
+		DefaultValue: "4096",
+		Export:       true,
+	}
+	p.WALSegmentAssignMaxCollectionsPerPChannel.Init(base.mgr)
+
+	p.WALSegmentAssignMaxPartitionsPerPChannel = ParamItem{
+		Key:     "streaming.walSegmentAssign.maxPartitionsPerPChannel",
+		Version: "2.6.0",
+		Doc: `The max total count of the partitions of all collections registered on one pchannel, 65536 by default.
+The create collection or partition beyond the cap is rejected, so the coordinator places the collection on another pchannel.
+The partitions recovered from the wal are never rejected. 0 means unlimited.`,
+		DefaultValue: "65536",
+		Export:       true,
+	}
+	p.WALSegmentAssignMaxPartitionsPerPChannel.Init(base.mgr)
+
+	p.WALSegmentAssignManualFlushMaxFutureSkew = ParamItem{
+		Key:     "streaming.walSegmentAssign.manualFlushMaxFutureSkew",
+		Version: "2.6.0",
+		Doc: `The max skew of the flush ts of manual flush ahead of the current timetick of the wal, 5s by default.
+The flush ts within the skew is clamped to the current timetick, the flush ts beyond it is rejected,
+as same as the zero flush ts or the flush ts older than the creation of the collection.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "5s",
+		Export:       true,
+	}
+	p.WALSegmentAssignManualFlushMaxFutureSkew.Init(base.mgr)
+
+	p.WALSegmentAssignFillNotifyThreshold = ParamItem{
+		Key:     "streaming.walSegmentAssign.fillNotify.threshold",
+		Version: "2.6.0",
+		Doc: `The fill ratio of a growing segment to deliver its fill updates to the flusher, 0.8 by default.
+The flusher defers the periodic sync of the segment about to be sealed, so its buffer is synced by a final large sync at seal instead of many small ones.`,
+		DefaultValue: "0.8",
+		Export:       true,
+	}
+	p.WALSegmentAssignFillNotifyThreshold.Init(base.mgr)
+
+	p.WALSegmentAssignFillNotifyInterval = ParamItem{
+		Key:     "streaming.walSegmentAssign.fillNotify.interval",
+		Version: "2.6.0",
+		Doc: `The min interval between the fill updates of a growing segment delivered to the flusher, 1s by default.
+The updates of a segment are stopped after it's sealed.
+It's ok to set it into duration string, such as 30s or 1m30s, see time.ParseDuration`,
+		DefaultValue: "1s",
+		Export:       true,
+	}
+	p.WALSegmentAssignFillNotifyInterval.Init(base.mgr)
+
+	p.WALRedoPolicy = ParamItem{
+		Key:     "streaming.walRedo.policy",
+		Version: "2.6.0",
+		Doc: `The policy of redo the append operation which should be re-executed with a refreshed context, adaptive by default.
+server: the redo is always done at the streaming node.
+client: the redo is always converted into a retry later error, the client retries it with backoff.
+adaptive: the redo is done at the streaming node if the wal is not saturated, otherwise it's converted into a retry later error.`,
+		DefaultValue: "adaptive",
+		Export:       true,
+	}
+	p.WALRedoPolicy.Init(base.mgr)
+
+	p.WALRedoAdaptiveInflightThreshold = ParamItem{
+		Key:     "streaming.walRedo.adaptiveInflightThreshold",
+		Version: "2.6.0",
+		Doc: `The threshold of inflight append operations of a wal to treat the wal as saturated for the adaptive redo policy, 256 by default.
+The redo is converted into a retry later error if the inflight append operations is greater than the threshold.`,
+		DefaultValue: "256",
+		Export:       true,
+	}
+	p.WALRedoAdaptiveInflightThreshold.Init(base.mgr)
+
+	p.WALRedoRegistryCapacity = ParamItem{
+		Key:     "streaming.walRedo.registryCapacity",
+		Version: "2.6.0",
+		Doc: `The max count of the messages currently in redo tracked by a wal, 1024 by default.
+The redo message beyond the capacity is not tracked, it's only counted as overflow.`,
+		DefaultValue: "1024",
+		Export:       true,
+	}
+	p.WALRedoRegistryCapacity.Init(base.mgr)
 }
 
 // runtimeConfig is just a private environment value table.
@@ -622,12 +622,88 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, 3, params.StreamingCfg.WALBalancerPolicyVChannelFairRebalanceMaxStep.GetAsInt())
 		assert.Equal(t, 1.0, params.StreamingCfg.WALBroadcasterConcurrencyRatio.GetAsFloat())
 		assert.Equal(t, 10*time.Second, params.StreamingCfg.TxnDefaultKeepaliveTimeout.GetAsDurationByParse())
+		assert.Equal(t, "delay", params.StreamingCfg.TxnFencedCommitPolicy.GetValue())
+		assert.Equal(t, int64(64*1024*1024), params.StreamingCfg.TxnPressureWarnMaxBinarySize.GetAsSize())
 		assert.Equal(t, 30*time.Second, params.StreamingCfg.WALWriteAheadBufferKeepalive.GetAsDurationByParse())
 		assert.Equal(t, int64(64*1024*1024), params.StreamingCfg.WALWriteAheadBufferCapacity.GetAsSize())
 		assert.Equal(t, 1*time.Second, params.StreamingCfg.LoggingAppendSlowThreshold.GetAsDurationByParse())
 		assert.Equal(t, 3*time.Second, params.StreamingCfg.WALRecoveryGracefulCloseTimeout.GetAsDurationByParse())
 		assert.Equal(t, 100, params.StreamingCfg.WALRecoveryMaxDirtyMessage.GetAsInt())
 		assert.Equal(t, 10*time.Second, params.StreamingCfg.WALRecoveryPersistInterval.GetAsDurationByParse())
+		assert.Equal(t, 50*time.Millisecond, params.StreamingCfg.WALSegmentAssignSyncSealDebounce.GetAsDurationByParse())
+		assert.False(t, params.StreamingCfg.WALSegmentAssignSealOnCollectionRelease.GetAsBool())
+		assert.False(t, params.StreamingCfg.WALSegmentAssignPrewarmOnCollectionLoad.GetAsBool())
+		assert.Equal(t, 10, params.StreamingCfg.WALSegmentAssignCircuitBreakerFailureThreshold.GetAsInt())
+		assert.Equal(t, 30*time.Second, params.StreamingCfg.WALSegmentAssignCircuitBreakerCooldown.GetAsDurationByParse())
+		assert.Equal(t, 1.5, params.StreamingCfg.WALSegmentAssignMaxSizeReconcileFactor.GetAsFloat())
+		assert.True(t, params.StreamingCfg.WALSegmentAssignMaxSizeReconcileRecap.GetAsBool())
+		assert.Equal(t, 3, params.StreamingCfg.WALSegmentAssignWatchdogStallIntervals.GetAsInt())
+		assert.Equal(t, int64(0), params.StreamingCfg.WALSegmentAssignSystemCollectionMaxID.GetAsInt64())
+		assert.Empty(t, params.StreamingCfg.WALSegmentAssignStorageVersionOverrides.GetValue())
+		assert.Equal(t, 10*time.Millisecond, params.StreamingCfg.WALSegmentAssignRecoveryBackoffInitial.GetAsDurationByParse())
+		assert.Equal(t, time.Second, params.StreamingCfg.WALSegmentAssignRecoveryBackoffMax.GetAsDurationByParse())
+		assert.Equal(t, 2.0, params.StreamingCfg.WALSegmentAssignRecoveryBackoffFactor.GetAsFloat())
+		assert.Equal(t, 0.5, params.StreamingCfg.WALSegmentAssignRecoveryBackoffJitter.GetAsFloat())
+		assert.Equal(t, 1024, params.StreamingCfg.WALSegmentAssignSealQueueMaxWaiting.GetAsInt())
+		assert.Equal(t, 30, params.StreamingCfg.WALSegmentAssignSealQueueAckBlockedWarn.GetAsInt())
+		assert.Equal(t, 0, params.StreamingCfg.WALSegmentAssignSealQueuePersistMaxEntries.GetAsInt())
+		assert.Equal(t, 0, params.StreamingCfg.WALSegmentAssignSealQueuePersistMaxBytes.GetAsInt())
+		assert.Empty(t, params.StreamingCfg.WALSegmentAssignHighPriorityCollections.GetValue())
+		assert.Equal(t, 0.25, params.StreamingCfg.WALSegmentAssignHighPrioritySizeRatio.GetAsFloat())
+		assert.Equal(t, 60*time.Second, params.StreamingCfg.WALSegmentAssignHighPriorityMaxLifetime.GetAsDurationByParse())
+		assert.Equal(t, int64(100000), params.StreamingCfg.WALSegmentAssignL0MaxDeletes.GetAsInt64())
+		assert.Equal(t, 10*time.Minute, params.StreamingCfg.WALSegmentAssignL0MaxLifetime.GetAsDurationByParse())
+		assert.Equal(t, time.Duration(0), params.StreamingCfg.WALSegmentAssignSealGracePeriod.GetAsDurationByParse())
+		assert.Equal(t, time.Hour, params.StreamingCfg.WALSegmentAssignMetaCompactionInterval.GetAsDurationByParse())
+		assert.False(t, params.StreamingCfg.WALSegmentAssignSealedNotifyEnabled.GetAsBool())
+		assert.False(t, params.StreamingCfg.WALSegmentAssignEmergencyUnsealEnabled.GetAsBool())
+		assert.Equal(t, 5*time.Second, params.StreamingCfg.WALSegmentAssignCatalogAssignTimeout.GetAsDurationByParse())
+		assert.Equal(t, 30*time.Second, params.StreamingCfg.WALSegmentAssignCatalogSweepTimeout.GetAsDurationByParse())
+		assert.Equal(t, 16, params.StreamingCfg.WALSegmentAssignFlushQueueMaxDepth.GetAsInt())
+		assert.Empty(t, params.StreamingCfg.WALSegmentAssignShadowPChannels.GetAsStrings())
+		assert.Equal(t, "best_fit", params.StreamingCfg.WALSegmentAssignShadowStrategy.GetValue())
+		assert.Empty(t, params.StreamingCfg.WALSegmentAssignReservePartitions.GetAsStrings())
+		assert.Equal(t, 0.7, params.StreamingCfg.WALSegmentAssignReserveFillThreshold.GetAsFloat())
+		assert.Empty(t, params.StreamingCfg.WALSegmentAssignSmallCollectionCollections.GetAsStrings())
+		assert.Equal(t, 5*time.Minute, params.StreamingCfg.WALSegmentAssignSmallCollectionMaxLifetime.GetAsDurationByParse())
+		assert.Equal(t, 1.0, params.StreamingCfg.WALSegmentAssignSmallCollectionMinSize.GetAsFloat())
+		assert.Equal(t, 100, params.StreamingCfg.WALSegmentAssignRowSizeCheckMinRows.GetAsInt())
+		assert.False(t, params.StreamingCfg.WALSegmentAssignRowSizeCheckAutoBump.GetAsBool())
+		assert.Equal(t, 1024, params.StreamingCfg.WALSegmentAssignSourceStatsMaxSources.GetAsInt())
+		assert.Equal(t, 10, params.StreamingCfg.WALSegmentAssignSourceStatsTopK.GetAsInt())
+		assert.Equal(t, time.Minute, params.StreamingCfg.WALSegmentAssignSourceStatsLogInterval.GetAsDurationByParse())
+		assert.Empty(t, params.StreamingCfg.WALSegmentAssignTxnNonBlockingSealCollections.GetAsStrings())
+		assert.Empty(t, params.StreamingCfg.WALSegmentAssignTimeTickStrictCollections.GetAsStrings())
+		assert.Equal(t, 5*time.Minute, params.StreamingCfg.WALSegmentAssignFlushPendingTimeout.GetAsDurationByParse())
+		assert.Equal(t, int64(10000), params.StreamingCfg.WALSegmentAssignCreationRateAlertThreshold.GetAsInt64())
+		assert.Equal(t, time.Hour, params.StreamingCfg.WALSegmentAssignCreationRateAlertWindow.GetAsDurationByParse())
+		assert.Empty(t, params.StreamingCfg.WALSegmentAssignPreassignedProducers.GetAsStrings())
+		assert.Equal(t, 10, params.StreamingCfg.WALSegmentAssignSealSweepFullPassCycles.GetAsInt())
+		assert.Equal(t, 0.8, params.StreamingCfg.WALSegmentAssignSaturationThreshold.GetAsFloat())
+		assert.False(t, params.StreamingCfg.WALSegmentAssignForceAddPartitionEnabled.GetAsBool())
+		assert.False(t, params.StreamingCfg.WALSegmentAssignPKSketchEnabled.GetAsBool())
+		assert.Equal(t, 12, params.StreamingCfg.WALSegmentAssignPKSketchPrecision.GetAsInt())
+		assert.Equal(t, 30*time.Second, params.StreamingCfg.WALSegmentAssignStandbySnapshotInterval.GetAsDurationByParse())
+		assert.Equal(t, float64(10), params.StreamingCfg.WALSegmentAssignTimeTickTooOldWarnThreshold.GetAsFloat())
+		assert.Equal(t, time.Minute, params.StreamingCfg.WALSegmentAssignTimeTickTooOldWindow.GetAsDurationByParse())
+		assert.False(t, params.StreamingCfg.WALSegmentAssignDeferMetaCreationEnabled.GetAsBool())
+		assert.Empty(t, params.StreamingCfg.WALSegmentAssignAutoSealDisabledCollections.GetAsStrings())
+		assert.Equal(t, 4096.0, params.StreamingCfg.WALSegmentAssignAutoSealDisabledWarnThreshold.GetAsFloat())
+		assert.Equal(t, 5*time.Minute, params.StreamingCfg.WALSegmentAssignAutoSealDisabledWarnInterval.GetAsDurationByParse())
+		assert.Equal(t, 5*time.Minute, params.StreamingCfg.WALSegmentAssignPreparePartitionDropTTL.GetAsDurationByParse())
+		assert.Equal(t, int64(0), params.StreamingCfg.WALSegmentAssignRandomSeed.GetAsInt64())
+		assert.Equal(t, 8, params.StreamingCfg.WALSegmentAssignFlushTimeTickPrewarmThreshold.GetAsInt())
+		assert.Equal(t, 0.8, params.StreamingCfg.WALSegmentAssignPressureWarnSaturation.GetAsFloat())
+		assert.Equal(t, 0.95, params.StreamingCfg.WALSegmentAssignPressureCriticalSaturation.GetAsFloat())
+		assert.Equal(t, 256, params.StreamingCfg.WALSegmentAssignSealHistorySize.GetAsInt())
+		assert.Equal(t, 4096, params.StreamingCfg.WALSegmentAssignMaxCollectionsPerPChannel.GetAsInt())
+		assert.Equal(t, 65536, params.StreamingCfg.WALSegmentAssignMaxPartitionsPerPChannel.GetAsInt())
+		assert.Equal(t, 5*time.Second, params.StreamingCfg.WALSegmentAssignManualFlushMaxFutureSkew.GetAsDurationByParse())
+		assert.Equal(t, 0.8, params.StreamingCfg.WALSegmentAssignFillNotifyThreshold.GetAsFloat())
+		assert.Equal(t, time.Second, params.StreamingCfg.WALSegmentAssignFillNotifyInterval.GetAsDurationByParse())
+		assert.Equal(t, "adaptive", params.StreamingCfg.WALRedoPolicy.GetValue())
+		assert.Equal(t, 256, params.StreamingCfg.WALRedoAdaptiveInflightThreshold.GetAsInt())
+		assert.Equal(t, 1024, params.StreamingCfg.WALRedoRegistryCapacity.GetAsInt())
 
 		params.Save(params.StreamingCfg.WALBalancerTriggerInterval.Key, "50s")
 		params.Save(params.StreamingCfg.WALBalancerBackoffInitialInterval.Key, "50s")
@@ -646,6 +722,7 @@ func TestComponentParam(t *testing.T) {
 		params.Save(params.StreamingCfg.WALRecoveryGracefulCloseTimeout.Key, "4s")
 		params.Save(params.StreamingCfg.WALRecoveryMaxDirtyMessage.Key, "200")
 		params.Save(params.StreamingCfg.WALRecoveryPersistInterval.Key, "20s")
+		params.Save(params.StreamingCfg.WALSegmentAssignSyncSealDebounce.Key, "100ms")
 		assert.Equal(t, 50*time.Second, params.StreamingCfg.WALBalancerTriggerInterval.GetAsDurationByParse())
 		assert.Equal(t, 50*time.Second, params.StreamingCfg.WALBalancerBackoffInitialInterval.GetAsDurationByParse())
 		assert.Equal(t, 3.5, params.StreamingCfg.WALBalancerBackoffMultiplier.GetAsFloat())
@@ -663,6 +740,7 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, 4*time.Second, params.StreamingCfg.WALRecoveryGracefulCloseTimeout.GetAsDurationByParse())
 		assert.Equal(t, 200, params.StreamingCfg.WALRecoveryMaxDirtyMessage.GetAsInt())
 		assert.Equal(t, 20*time.Second, params.StreamingCfg.WALRecoveryPersistInterval.GetAsDurationByParse())
+		assert.Equal(t, 100*time.Millisecond, params.StreamingCfg.WALSegmentAssignSyncSealDebounce.GetAsDurationByParse())
 	})
 
 	t.Run("channel config priority", func(t *testing.T) {
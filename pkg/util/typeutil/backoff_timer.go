@@ -27,6 +27,9 @@ type BackoffConfig struct {
 	InitialInterval time.Duration
 	Multiplier      float64
 	MaxInterval     time.Duration
+	// RandomizationFactor is the jitter ratio of every interval,
+	// the default randomization factor of backoff is used if it's zero.
+	RandomizationFactor float64
 }
 
 func (c BackoffTimerConfig) DefaultInterval() time.Duration {
@@ -59,6 +62,9 @@ func (t *BackoffTimer) EnableBackoff() {
 		backoff.InitialInterval = cfg.InitialInterval
 		backoff.Multiplier = cfg.Multiplier
 		backoff.MaxInterval = cfg.MaxInterval
+		if cfg.RandomizationFactor > 0 {
+			backoff.RandomizationFactor = cfg.RandomizationFactor
+		}
 		backoff.MaxElapsedTime = 0
 		backoff.Reset()
 		t.backoff = backoff
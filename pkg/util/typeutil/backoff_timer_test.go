@@ -32,3 +32,21 @@ func TestBackoffTimer(t *testing.T) {
 		assert.Equal(t, time.Second, b.NextInterval())
 	}
 }
+
+func TestBackoffTimerRandomizationFactor(t *testing.T) {
+	b := NewBackoffTimer(BackoffTimerConfig{
+		Default: time.Second,
+		Backoff: BackoffConfig{
+			InitialInterval:     100 * time.Millisecond,
+			Multiplier:          1,
+			MaxInterval:         100 * time.Millisecond,
+			RandomizationFactor: 0.1,
+		},
+	})
+	b.EnableBackoff()
+	for i := 0; i < 10; i++ {
+		interval := b.NextInterval()
+		assert.GreaterOrEqual(t, interval, 90*time.Millisecond)
+		assert.LessOrEqual(t, interval, 110*time.Millisecond)
+	}
+}